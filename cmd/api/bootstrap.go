@@ -1,11 +1,15 @@
 package main
 
 import (
+	"github.com/Adebayobenjamin/numerisbook/pkg/auth"
 	"github.com/Adebayobenjamin/numerisbook/pkg/configs"
 	"github.com/Adebayobenjamin/numerisbook/pkg/controllers"
+	"github.com/Adebayobenjamin/numerisbook/pkg/email"
+	"github.com/Adebayobenjamin/numerisbook/pkg/notifiers"
 	"github.com/Adebayobenjamin/numerisbook/pkg/repositories"
 	"github.com/Adebayobenjamin/numerisbook/pkg/router"
 	"github.com/Adebayobenjamin/numerisbook/pkg/services"
+	"github.com/Adebayobenjamin/numerisbook/pkg/workers"
 	"go.uber.org/dig"
 )
 
@@ -21,12 +25,41 @@ var serviceConstructors = []interface{}{
 
 	// CONTROLLERS
 	controllers.NewInvoiceController,
+	controllers.NewSettingsController,
+	controllers.NewRecurringInvoiceController,
+	controllers.NewShareLinkController,
+	controllers.NewAPIKeyController,
+	controllers.NewUserController,
+	controllers.NewClientController,
+	controllers.NewBusinessProfileController,
+	controllers.NewBankAccountController,
+	controllers.NewCatalogItemController,
+	controllers.NewCreditNoteController,
+	controllers.NewQuoteController,
+	controllers.NewPaymentController,
+	controllers.NewClientCreditController,
 
 	// SERVICES
 	services.NewAuditService,
 	services.NewInvoiceService,
 	services.NewReminderService,
 	services.NewCustomerService,
+	services.NewDocumentSequenceService,
+	services.NewRecurringInvoiceService,
+	services.NewInvoiceEmailService,
+	services.NewShareLinkService,
+	services.NewAPIKeyService,
+	services.NewAuthService,
+	services.NewUserService,
+	services.NewClientService,
+	services.NewBusinessProfileService,
+	services.NewBankAccountService,
+	services.NewCatalogItemService,
+	services.NewCreditNoteService,
+	services.NewQuoteService,
+	services.NewIdempotencyService,
+	services.NewPaymentService,
+	services.NewClientCreditService,
 
 	// REPOSITORIES
 	repositories.NewAuditTrailRepository,
@@ -34,6 +67,33 @@ var serviceConstructors = []interface{}{
 	repositories.NewPaymentRepository,
 	repositories.NewReminderRepository,
 	repositories.NewCustomerRepository,
+	repositories.NewDocumentSequenceRepository,
+	repositories.NewRecurringInvoiceRepository,
+	repositories.NewShareLinkRepository,
+	repositories.NewAPIKeyRepository,
+	repositories.NewUserRepository,
+	repositories.NewClientRepository,
+	repositories.NewBusinessProfileRepository,
+	repositories.NewBankAccountRepository,
+	repositories.NewCatalogItemRepository,
+	repositories.NewCreditNoteRepository,
+	repositories.NewQuoteRepository,
+	repositories.NewIdempotencyKeyRepository,
+	repositories.NewClientCreditRepository,
+
+	// WORKERS
+	workers.NewRecurringInvoiceWorker,
+	workers.NewReminderWorker,
+	workers.NewQuoteExpiryWorker,
+	workers.NewIdempotencyKeyWorker,
+
+	// AUTH
+	auth.NewTokenVerifier,
+
+	// NOTIFIERS
+	email.NewMailer,
+	notifiers.NewNotifier,
+
 	//ENVIRONMENT
 	configs.NewEnvironment,
 
@@ -11,6 +11,7 @@ import (
 	"syscall"
 	"time"
 
+	"github.com/Adebayobenjamin/numerisbook/pkg/workers"
 	"github.com/gin-gonic/gin"
 	"github.com/jmoiron/sqlx"
 )
@@ -18,11 +19,23 @@ import (
 // SETUP SERVER CONFIGS
 
 type application struct {
-	server *http.Server
-	db     *sqlx.DB
+	server                 *http.Server
+	db                     *sqlx.DB
+	recurringInvoiceWorker *workers.RecurringInvoiceWorker
+	reminderWorker         *workers.ReminderWorker
+	quoteExpiryWorker      *workers.QuoteExpiryWorker
+	idempotencyKeyWorker   *workers.IdempotencyKeyWorker
 }
 
 func (a *application) Start() {
+	// BACKGROUND WORKERS
+	workerCtx, stopWorkers := context.WithCancel(context.Background())
+	defer stopWorkers()
+	go a.recurringInvoiceWorker.Start(workerCtx)
+	go a.reminderWorker.Start(workerCtx)
+	go a.quoteExpiryWorker.Start(workerCtx)
+	go a.idempotencyKeyWorker.Start(workerCtx)
+
 	log.Printf("server is running on port: %s", a.server.Addr)
 	go func() {
 		if err := a.server.ListenAndServe(); err != nil {
@@ -39,6 +52,7 @@ func (a *application) Start() {
 	signal.Notify(quit, syscall.SIGINT, syscall.SIGTERM)
 	<-quit
 	log.Println("Shutting Down Server...")
+	stopWorkers()
 	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
 	defer cancel()
 	if err := a.server.Shutdown(ctx); err != nil {
@@ -47,13 +61,24 @@ func (a *application) Start() {
 	log.Println("Server Exiting!")
 }
 
-func NewApplication(handler *gin.Engine, db *sqlx.DB) *application {
+func NewApplication(
+	handler *gin.Engine,
+	db *sqlx.DB,
+	recurringInvoiceWorker *workers.RecurringInvoiceWorker,
+	reminderWorker *workers.ReminderWorker,
+	quoteExpiryWorker *workers.QuoteExpiryWorker,
+	idempotencyKeyWorker *workers.IdempotencyKeyWorker,
+) *application {
 	PORT := fmt.Sprintf(":%s", os.Getenv("PORT"))
 	return &application{
 		server: &http.Server{
 			Addr:    PORT,
 			Handler: handler,
 		},
-		db: db,
+		db:                     db,
+		recurringInvoiceWorker: recurringInvoiceWorker,
+		reminderWorker:         reminderWorker,
+		quoteExpiryWorker:      quoteExpiryWorker,
+		idempotencyKeyWorker:   idempotencyKeyWorker,
 	}
 }
@@ -0,0 +1,122 @@
+package auth
+
+import (
+	"crypto/rsa"
+	"crypto/x509"
+	"encoding/pem"
+	"errors"
+	"fmt"
+	"os"
+	"time"
+
+	"github.com/Adebayobenjamin/numerisbook/pkg/models"
+)
+
+// Authentication methods a Principal can be resolved through. MethodSystem is
+// never resolved from a request, it marks work the system does on its own.
+const (
+	MethodJWT    = "jwt"
+	MethodAPIKey = "api_key"
+	MethodSystem = "system"
+)
+
+// ErrUnauthenticated is returned for missing, malformed, expired or unknown credentials
+var ErrUnauthenticated = errors.New("unauthenticated")
+
+// Principal is the caller a request was authenticated as
+type Principal struct {
+	CustomerID uint
+	Subject    string
+	Method     string
+	Role       models.UserRole
+	// UserID is set when the request was authenticated as a member with a JWT
+	UserID *uint
+	// APIKeyID is set when the request was authenticated with an API key
+	APIKeyID *uint
+}
+
+// NewTokenVerifier returns a verifier configured from the environment:
+//
+//   - JWT_HS256_SECRET enables HS256 tokens signed with a shared secret
+//   - JWT_PUBLIC_KEY_FILE enables RS256 tokens signed by a PEM encoded RSA key
+//   - JWT_JWKS_FILE enables RS256 tokens signed by any RSA key of a JWKS file
+//   - JWT_ISSUER and JWT_AUDIENCE, when set, must match the token's claims
+//
+// With none of the keys configured every token is rejected.
+func NewTokenVerifier() (*TokenVerifier, error) {
+	config := VerifierConfig{
+		Issuer:   os.Getenv("JWT_ISSUER"),
+		Audience: os.Getenv("JWT_AUDIENCE"),
+		Leeway:   time.Minute,
+		RSAKeys:  map[string]*rsa.PublicKey{},
+	}
+
+	if secret := os.Getenv("JWT_HS256_SECRET"); secret != "" {
+		config.HMACSecret = []byte(secret)
+	}
+
+	if path := os.Getenv("JWT_PUBLIC_KEY_FILE"); path != "" {
+		key, err := loadRSAPublicKey(path)
+		if err != nil {
+			return nil, err
+		}
+		config.RSAKeys[""] = key
+	}
+
+	if path := os.Getenv("JWT_JWKS_FILE"); path != "" {
+		data, err := os.ReadFile(path)
+		if err != nil {
+			return nil, fmt.Errorf("failed to read jwks file: %w", err)
+		}
+		keys, err := ParseJWKS(data)
+		if err != nil {
+			return nil, err
+		}
+		// every JWKS key has a kid, so none of them can replace the key from
+		// JWT_PUBLIC_KEY_FILE, which is kept under ""
+		for kid, key := range keys {
+			config.RSAKeys[kid] = key
+		}
+	}
+
+	return NewVerifier(config), nil
+}
+
+// loadRSAPublicKey reads a PEM encoded PKIX or PKCS#1 RSA public key of at
+// least minRSAKeyBits
+func loadRSAPublicKey(path string) (*rsa.PublicKey, error) {
+	key, err := parseRSAPublicKeyFile(path)
+	if err != nil {
+		return nil, err
+	}
+	if err := checkRSAKeySize(key); err != nil {
+		return nil, fmt.Errorf("jwt public key: %w", err)
+	}
+	return key, nil
+}
+
+func parseRSAPublicKeyFile(path string) (*rsa.PublicKey, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read jwt public key: %w", err)
+	}
+
+	block, _ := pem.Decode(data)
+	if block == nil {
+		return nil, fmt.Errorf("jwt public key is not PEM encoded")
+	}
+
+	if key, err := x509.ParsePKCS1PublicKey(block.Bytes); err == nil {
+		return key, nil
+	}
+
+	parsed, err := x509.ParsePKIXPublicKey(block.Bytes)
+	if err != nil {
+		return nil, fmt.Errorf("failed to parse jwt public key: %w", err)
+	}
+	key, ok := parsed.(*rsa.PublicKey)
+	if !ok {
+		return nil, fmt.Errorf("jwt public key is not an RSA key")
+	}
+	return key, nil
+}
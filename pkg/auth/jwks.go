@@ -0,0 +1,80 @@
+package auth
+
+import (
+	"crypto/rsa"
+	"encoding/base64"
+	"encoding/json"
+	"fmt"
+	"math/big"
+)
+
+// minRSAKeyBits is the smallest RSA modulus trusted to verify tokens
+const minRSAKeyBits = 2048
+
+type jsonWebKey struct {
+	Kty string `json:"kty"`
+	Kid string `json:"kid"`
+	Use string `json:"use"`
+	Alg string `json:"alg"`
+	N   string `json:"n"`
+	E   string `json:"e"`
+}
+
+// ParseJWKS reads the RSA signing keys of a JSON Web Key Set, indexed by key
+// ID. Keys of other types or meant for encryption are skipped. Signing keys
+// without a key ID, sharing one, or shorter than minRSAKeyBits make the whole
+// set invalid, so that no key silently takes another's place.
+func ParseJWKS(data []byte) (map[string]*rsa.PublicKey, error) {
+	var set struct {
+		Keys []jsonWebKey `json:"keys"`
+	}
+	if err := json.Unmarshal(data, &set); err != nil {
+		return nil, fmt.Errorf("failed to parse jwks: %w", err)
+	}
+
+	keys := make(map[string]*rsa.PublicKey, len(set.Keys))
+	for _, key := range set.Keys {
+		if key.Kty != "RSA" || (key.Use != "" && key.Use != "sig") || (key.Alg != "" && key.Alg != AlgorithmRS256) {
+			continue
+		}
+
+		if key.Kid == "" {
+			return nil, fmt.Errorf("jwks has an RSA signing key without a kid")
+		}
+		if _, ok := keys[key.Kid]; ok {
+			return nil, fmt.Errorf("jwks has more than one key with kid %q", key.Kid)
+		}
+
+		n, err := base64.RawURLEncoding.DecodeString(key.N)
+		if err != nil {
+			return nil, fmt.Errorf("invalid modulus for jwk %q: %w", key.Kid, err)
+		}
+		e, err := base64.RawURLEncoding.DecodeString(key.E)
+		if err != nil {
+			return nil, fmt.Errorf("invalid exponent for jwk %q: %w", key.Kid, err)
+		}
+		exponent := new(big.Int).SetBytes(e)
+		if !exponent.IsInt64() || exponent.Int64() < 3 || exponent.Int64() > 1<<31-1 {
+			return nil, fmt.Errorf("invalid exponent for jwk %q", key.Kid)
+		}
+
+		publicKey := &rsa.PublicKey{N: new(big.Int).SetBytes(n), E: int(exponent.Int64())}
+		if err := checkRSAKeySize(publicKey); err != nil {
+			return nil, fmt.Errorf("jwk %q: %w", key.Kid, err)
+		}
+		keys[key.Kid] = publicKey
+	}
+
+	if len(keys) == 0 {
+		return nil, fmt.Errorf("jwks has no RSA signing keys")
+	}
+	return keys, nil
+}
+
+// checkRSAKeySize refuses RSA keys too short to be trusted with signatures
+func checkRSAKeySize(key *rsa.PublicKey) error {
+	if bits := key.N.BitLen(); bits < minRSAKeyBits {
+		return fmt.Errorf("rsa key has %d bits, at least %d are required", bits, minRSAKeyBits)
+	}
+	return nil
+}
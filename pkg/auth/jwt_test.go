@@ -0,0 +1,251 @@
+package auth
+
+import (
+	"crypto"
+	"crypto/hmac"
+	"crypto/rand"
+	"crypto/rsa"
+	"crypto/sha256"
+	"crypto/x509"
+	"encoding/base64"
+	"encoding/json"
+	"encoding/pem"
+	"fmt"
+	"math/big"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+var testNow = time.Date(2024, 3, 8, 9, 0, 0, 0, time.UTC)
+
+func encodeSegment(t *testing.T, value interface{}) string {
+	data, err := json.Marshal(value)
+	require.NoError(t, err)
+	return base64.RawURLEncoding.EncodeToString(data)
+}
+
+func signHS256(t *testing.T, secret string, header map[string]interface{}, claims map[string]interface{}) string {
+	signed := encodeSegment(t, header) + "." + encodeSegment(t, claims)
+	mac := hmac.New(sha256.New, []byte(secret))
+	mac.Write([]byte(signed))
+	return signed + "." + base64.RawURLEncoding.EncodeToString(mac.Sum(nil))
+}
+
+func signRS256(t *testing.T, key *rsa.PrivateKey, kid string, claims map[string]interface{}) string {
+	signed := encodeSegment(t, map[string]interface{}{"alg": "RS256", "kid": kid}) + "." + encodeSegment(t, claims)
+	digest := sha256.Sum256([]byte(signed))
+	signature, err := rsa.SignPKCS1v15(rand.Reader, key, crypto.SHA256, digest[:])
+	require.NoError(t, err)
+	return signed + "." + base64.RawURLEncoding.EncodeToString(signature)
+}
+
+func validClaims() map[string]interface{} {
+	return map[string]interface{}{
+		"sub":         "user-42",
+		"customer_id": 7,
+		"iss":         "https://auth.numeris.test",
+		"aud":         []string{"numerisbook"},
+		"exp":         testNow.Add(time.Hour).Unix(),
+		"iat":         testNow.Unix(),
+	}
+}
+
+func TestVerifyHS256(t *testing.T) {
+	verifier := NewVerifier(VerifierConfig{
+		HMACSecret: []byte("secret"),
+		Issuer:     "https://auth.numeris.test",
+		Audience:   "numerisbook",
+		Leeway:     time.Minute,
+	})
+	hs256 := map[string]interface{}{"alg": "HS256", "typ": "JWT"}
+
+	t.Run("valid token", func(t *testing.T) {
+		principal, err := verifier.Verify(signHS256(t, "secret", hs256, validClaims()), testNow)
+
+		require.NoError(t, err)
+		assert.Equal(t, &Principal{CustomerID: 7, Subject: "user-42", Method: MethodJWT}, principal)
+	})
+
+	t.Run("numeric subject names the customer", func(t *testing.T) {
+		claims := validClaims()
+		delete(claims, "customer_id")
+		claims["sub"] = "12"
+
+		principal, err := verifier.Verify(signHS256(t, "secret", hs256, claims), testNow)
+
+		require.NoError(t, err)
+		assert.Equal(t, uint(12), principal.CustomerID)
+	})
+
+	tests := []struct {
+		name    string
+		token   func() string
+		wantErr string
+	}{
+		{
+			name:    "wrong secret",
+			token:   func() string { return signHS256(t, "other", hs256, validClaims()) },
+			wantErr: "invalid token signature",
+		},
+		{
+			name: "alg none",
+			token: func() string {
+				return encodeSegment(t, map[string]string{"alg": "none"}) + "." + encodeSegment(t, validClaims()) + "."
+			},
+			wantErr: `unsupported signing algorithm "none"`,
+		},
+		{
+			name: "expired beyond leeway",
+			token: func() string {
+				claims := validClaims()
+				claims["exp"] = testNow.Add(-2 * time.Minute).Unix()
+				return signHS256(t, "secret", hs256, claims)
+			},
+			wantErr: "token has expired",
+		},
+		{
+			name: "no expiry",
+			token: func() string {
+				claims := validClaims()
+				delete(claims, "exp")
+				return signHS256(t, "secret", hs256, claims)
+			},
+			wantErr: "token has no expiry",
+		},
+		{
+			name: "not valid yet",
+			token: func() string {
+				claims := validClaims()
+				claims["nbf"] = testNow.Add(time.Hour).Unix()
+				return signHS256(t, "secret", hs256, claims)
+			},
+			wantErr: "token is not valid yet",
+		},
+		{
+			name: "wrong audience",
+			token: func() string {
+				claims := validClaims()
+				claims["aud"] = "someone-else"
+				return signHS256(t, "secret", hs256, claims)
+			},
+			wantErr: "unexpected token audience",
+		},
+		{
+			name: "wrong issuer",
+			token: func() string {
+				claims := validClaims()
+				claims["iss"] = "https://evil.test"
+				return signHS256(t, "secret", hs256, claims)
+			},
+			wantErr: "unexpected token issuer",
+		},
+		{
+			name: "no customer",
+			token: func() string {
+				claims := validClaims()
+				delete(claims, "customer_id")
+				return signHS256(t, "secret", hs256, claims)
+			},
+			wantErr: "token does not name a customer",
+		},
+		{
+			name:    "malformed",
+			token:   func() string { return "not.a-token" },
+			wantErr: "malformed token",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			principal, err := verifier.Verify(tt.token(), testNow)
+
+			assert.Nil(t, principal)
+			assert.ErrorIs(t, err, ErrUnauthenticated)
+			assert.ErrorContains(t, err, tt.wantErr)
+		})
+	}
+}
+
+func TestVerifyRS256WithJWKS(t *testing.T) {
+	key, err := rsa.GenerateKey(rand.Reader, 2048)
+	require.NoError(t, err)
+	other, err := rsa.GenerateKey(rand.Reader, 2048)
+	require.NoError(t, err)
+
+	jwks := fmt.Sprintf(`{"keys": [
+		{"kty": "RSA", "kid": "key-1", "use": "sig", "alg": "RS256", "n": %q, "e": %q},
+		{"kty": "EC", "kid": "ec-key", "crv": "P-256"}
+	]}`,
+		base64.RawURLEncoding.EncodeToString(key.N.Bytes()),
+		base64.RawURLEncoding.EncodeToString(big.NewInt(int64(key.E)).Bytes()))
+
+	keys, err := ParseJWKS([]byte(jwks))
+	require.NoError(t, err)
+	assert.Len(t, keys, 1)
+
+	verifier := NewVerifier(VerifierConfig{RSAKeys: keys})
+
+	principal, err := verifier.Verify(signRS256(t, key, "key-1", validClaims()), testNow)
+	require.NoError(t, err)
+	assert.Equal(t, uint(7), principal.CustomerID)
+
+	_, err = verifier.Verify(signRS256(t, other, "key-1", validClaims()), testNow)
+	assert.ErrorContains(t, err, "invalid token signature")
+
+	_, err = verifier.Verify(signRS256(t, key, "key-2", validClaims()), testNow)
+	assert.ErrorContains(t, err, `unknown signing key "key-2"`)
+
+	// an RS256 verifier must not accept HS256 tokens keyed with public material
+	_, err = verifier.Verify(signHS256(t, string(key.N.Bytes()), map[string]interface{}{"alg": "HS256", "kid": "key-1"}, validClaims()), testNow)
+	assert.ErrorContains(t, err, "HS256 tokens are not accepted")
+}
+
+func TestParseJWKS_RefusesAmbiguousOrWeakKeys(t *testing.T) {
+	key, err := rsa.GenerateKey(rand.Reader, 2048)
+	require.NoError(t, err)
+	weak, err := rsa.GenerateKey(rand.Reader, 1024)
+	require.NoError(t, err)
+
+	jwk := func(kid string, key *rsa.PrivateKey) string {
+		return fmt.Sprintf(`{"kty": "RSA", "kid": %q, "use": "sig", "alg": "RS256", "n": %q, "e": %q}`, kid,
+			base64.RawURLEncoding.EncodeToString(key.N.Bytes()),
+			base64.RawURLEncoding.EncodeToString(big.NewInt(int64(key.E)).Bytes()))
+	}
+
+	t.Run("a key without a kid is refused", func(t *testing.T) {
+		_, err := ParseJWKS([]byte(`{"keys": [` + jwk("", key) + `]}`))
+		assert.ErrorContains(t, err, "without a kid")
+	})
+
+	t.Run("two keys with the same kid are refused", func(t *testing.T) {
+		_, err := ParseJWKS([]byte(`{"keys": [` + jwk("key-1", key) + `, ` + jwk("key-1", key) + `]}`))
+		assert.ErrorContains(t, err, `more than one key with kid "key-1"`)
+	})
+
+	t.Run("a modulus under 2048 bits is refused", func(t *testing.T) {
+		_, err := ParseJWKS([]byte(`{"keys": [` + jwk("key-1", key) + `, ` + jwk("weak", weak) + `]}`))
+		assert.ErrorContains(t, err, `jwk "weak": rsa key has 1024 bits`)
+	})
+}
+
+func TestLoadRSAPublicKey_RefusesShortKeys(t *testing.T) {
+	writeKey := func(t *testing.T, bits int) string {
+		key, err := rsa.GenerateKey(rand.Reader, bits)
+		require.NoError(t, err)
+		path := filepath.Join(t.TempDir(), "jwt.pub")
+		data := pem.EncodeToMemory(&pem.Block{Type: "RSA PUBLIC KEY", Bytes: x509.MarshalPKCS1PublicKey(&key.PublicKey)})
+		require.NoError(t, os.WriteFile(path, data, 0o600))
+		return path
+	}
+
+	_, err := loadRSAPublicKey(writeKey(t, 2048))
+	assert.NoError(t, err)
+
+	_, err = loadRSAPublicKey(writeKey(t, 1024))
+	assert.ErrorContains(t, err, "rsa key has 1024 bits, at least 2048 are required")
+}
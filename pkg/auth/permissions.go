@@ -0,0 +1,106 @@
+package auth
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	"github.com/Adebayobenjamin/numerisbook/pkg/models"
+)
+
+// ErrForbidden is returned when the caller's role does not grant a permission
+var ErrForbidden = errors.New("forbidden")
+
+// ErrMissingPrincipal is returned when a permission is checked on a context
+// nobody was authenticated in
+var ErrMissingPrincipal = fmt.Errorf("%w: no authenticated caller", ErrForbidden)
+
+// Permission names an action a role may be allowed to take
+type Permission string
+
+const (
+	PermissionInvoicesRead    Permission = "invoices:read"
+	PermissionInvoicesWrite   Permission = "invoices:write"
+	PermissionPaymentsConfirm Permission = "payments:confirm"
+	PermissionSettingsRead    Permission = "settings:read"
+	PermissionSettingsManage  Permission = "settings:manage"
+	PermissionMembersManage   Permission = "members:manage"
+	PermissionAPIKeysManage   Permission = "api_keys:manage"
+)
+
+// rolePermissions lists what each role may do. Viewers can only read
+// invoices, accountants run the books and owners can do everything,
+// including managing who else has access.
+var rolePermissions = map[models.UserRole][]Permission{
+	models.UserRoleViewer: {
+		PermissionInvoicesRead,
+	},
+	models.UserRoleAccountant: {
+		PermissionInvoicesRead,
+		PermissionInvoicesWrite,
+		PermissionPaymentsConfirm,
+		PermissionSettingsRead,
+	},
+	models.UserRoleOwner: {
+		PermissionInvoicesRead,
+		PermissionInvoicesWrite,
+		PermissionPaymentsConfirm,
+		PermissionSettingsRead,
+		PermissionSettingsManage,
+		PermissionMembersManage,
+		PermissionAPIKeysManage,
+	},
+}
+
+// IsValidRole reports whether role is one of the known roles
+func IsValidRole(role models.UserRole) bool {
+	_, ok := rolePermissions[role]
+	return ok
+}
+
+// Can reports whether a role grants a permission
+func Can(role models.UserRole, permission Permission) bool {
+	for _, granted := range rolePermissions[role] {
+		if granted == permission {
+			return true
+		}
+	}
+	return false
+}
+
+// Authorize checks that the caller in ctx holds a permission. Calls without a
+// principal are refused; background work acting for the system has to say so
+// with WithSystemPrincipal.
+func Authorize(ctx context.Context, permission Permission) error {
+	principal, ok := PrincipalFromContext(ctx)
+	if !ok {
+		return ErrMissingPrincipal
+	}
+	if principal.Method == MethodSystem {
+		return nil
+	}
+	if !Can(principal.Role, permission) {
+		return fmt.Errorf("%w: the %s role does not grant %s", ErrForbidden, principal.Role, permission)
+	}
+	return nil
+}
+
+type principalContextKey struct{}
+
+// WithPrincipal returns a copy of ctx carrying the authenticated caller
+func WithPrincipal(ctx context.Context, principal *Principal) context.Context {
+	return context.WithValue(ctx, principalContextKey{}, principal)
+}
+
+// WithSystemPrincipal returns a copy of ctx in which the system acts for
+// customerID, e.g. a background worker or a request authenticated by a share
+// link's token. The system holds every permission.
+func WithSystemPrincipal(ctx context.Context, customerID uint) context.Context {
+	return WithPrincipal(ctx, &Principal{CustomerID: customerID, Subject: MethodSystem, Method: MethodSystem})
+}
+
+// PrincipalFromContext returns the caller a request was authenticated as
+func PrincipalFromContext(ctx context.Context) (*Principal, bool) {
+	principal, ok := ctx.Value(principalContextKey{}).(*Principal)
+	return principal, ok && principal != nil
+}
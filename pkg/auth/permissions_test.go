@@ -0,0 +1,63 @@
+package auth
+
+import (
+	"context"
+	"testing"
+
+	"github.com/Adebayobenjamin/numerisbook/pkg/models"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestCan(t *testing.T) {
+	tests := []struct {
+		role       models.UserRole
+		permission Permission
+		want       bool
+	}{
+		{models.UserRoleViewer, PermissionInvoicesRead, true},
+		{models.UserRoleViewer, PermissionInvoicesWrite, false},
+		{models.UserRoleViewer, PermissionPaymentsConfirm, false},
+		{models.UserRoleAccountant, PermissionPaymentsConfirm, true},
+		{models.UserRoleAccountant, PermissionSettingsManage, false},
+		{models.UserRoleAccountant, PermissionMembersManage, false},
+		{models.UserRoleOwner, PermissionMembersManage, true},
+		{models.UserRoleOwner, PermissionAPIKeysManage, true},
+		{models.UserRole("admin"), PermissionInvoicesRead, false},
+	}
+
+	for _, tt := range tests {
+		t.Run(string(tt.role)+" "+string(tt.permission), func(t *testing.T) {
+			assert.Equal(t, tt.want, Can(tt.role, tt.permission))
+		})
+	}
+}
+
+func TestAuthorize(t *testing.T) {
+	ctx := context.Background()
+
+	t.Run("calls without a principal are refused", func(t *testing.T) {
+		err := Authorize(ctx, PermissionInvoicesRead)
+
+		assert.ErrorIs(t, err, ErrMissingPrincipal)
+		assert.ErrorIs(t, err, ErrForbidden)
+	})
+
+	t.Run("the system principal holds every permission", func(t *testing.T) {
+		system := WithSystemPrincipal(ctx, 1)
+
+		assert.NoError(t, Authorize(system, PermissionPaymentsConfirm))
+		assert.NoError(t, Authorize(system, PermissionAPIKeysManage))
+	})
+
+	t.Run("viewer cannot confirm payments", func(t *testing.T) {
+		viewer := WithPrincipal(ctx, &Principal{CustomerID: 1, Role: models.UserRoleViewer})
+
+		assert.ErrorIs(t, Authorize(viewer, PermissionPaymentsConfirm), ErrForbidden)
+	})
+
+	t.Run("accountant can confirm payments", func(t *testing.T) {
+		accountant := WithPrincipal(ctx, &Principal{CustomerID: 1, Role: models.UserRoleAccountant})
+
+		assert.NoError(t, Authorize(accountant, PermissionPaymentsConfirm))
+	})
+}
@@ -27,7 +27,27 @@ func ThrowForbiddenException(ctx *gin.Context, err string) {
 	ctx.AbortWithStatusJSON(http.StatusForbidden, res)
 }
 
+func ThrowNotFoundException(ctx *gin.Context, err string) {
+	res := common.BuildErrorResponse("Not Found", err)
+	ctx.AbortWithStatusJSON(http.StatusNotFound, res)
+}
+
 func ThrowInternalServerError(ctx *gin.Context, err string) {
 	res := common.BuildErrorResponse("Internal Server Error", err)
 	ctx.AbortWithStatusJSON(http.StatusInternalServerError, res)
 }
+
+func ThrowConflictException(ctx *gin.Context, err string) {
+	res := common.BuildErrorResponse("Conflict", err)
+	ctx.AbortWithStatusJSON(http.StatusConflict, res)
+}
+
+func ThrowPreconditionFailedException(ctx *gin.Context, err string) {
+	res := common.BuildErrorResponse("Precondition Failed", err)
+	ctx.AbortWithStatusJSON(http.StatusPreconditionFailed, res)
+}
+
+func ThrowPreconditionRequiredException(ctx *gin.Context, err string) {
+	res := common.BuildErrorResponse("Precondition Required", err)
+	ctx.AbortWithStatusJSON(http.StatusPreconditionRequired, res)
+}
@@ -0,0 +1,38 @@
+package exceptions
+
+import (
+	"errors"
+	"fmt"
+)
+
+// ErrNotFound is wrapped by errors for records that do not exist or are not
+// visible to the caller, so they can be reported as 404s
+var ErrNotFound = errors.New("not found")
+
+// ErrLocked is wrapped by errors for changes to records whose status no
+// longer allows them, so they can be reported as 409s
+var ErrLocked = errors.New("can no longer be changed")
+
+// ErrVersionMismatch is wrapped by errors for writes made against a copy of
+// a record that has changed since it was read, so they can be reported as 412s
+var ErrVersionMismatch = errors.New("was changed by another request")
+
+// InvalidStatusTransitionError is returned when a document is asked to move
+// between two statuses its lifecycle does not allow
+type InvalidStatusTransitionError struct {
+	Entity string
+	From   string
+	To     string
+}
+
+func (e *InvalidStatusTransitionError) Error() string {
+	return fmt.Sprintf("cannot move %s from %q to %q", e.Entity, e.From, e.To)
+}
+
+// ErrIdempotencyKeyReused is wrapped by errors for idempotency keys sent again
+// with a different request, so they can be reported as 422s
+var ErrIdempotencyKeyReused = errors.New("was already used for a different request")
+
+// ErrIdempotencyKeyInProgress is wrapped by errors for idempotency keys whose
+// first request has not finished yet, so they can be reported as 409s
+var ErrIdempotencyKeyInProgress = errors.New("is still being processed")
@@ -0,0 +1,71 @@
+package money
+
+import (
+	"fmt"
+	"strings"
+)
+
+// DefaultExponent is the number of minor-unit digits assumed for amounts that
+// have not been tagged with a currency yet. It matches the DECIMAL(15,2)
+// columns the amounts are stored in.
+const DefaultExponent = 2
+
+// Currency describes how amounts of an ISO 4217 currency are rounded
+type Currency struct {
+	Code     string
+	Exponent int
+}
+
+// currencies lists the billing currencies the system accepts. Currencies with
+// more than DefaultExponent minor-unit digits are deliberately left out because
+// the database cannot store them without losing precision.
+var currencies = map[string]Currency{
+	"AED": {Code: "AED", Exponent: 2},
+	"AUD": {Code: "AUD", Exponent: 2},
+	"BRL": {Code: "BRL", Exponent: 2},
+	"CAD": {Code: "CAD", Exponent: 2},
+	"CHF": {Code: "CHF", Exponent: 2},
+	"CNY": {Code: "CNY", Exponent: 2},
+	"DKK": {Code: "DKK", Exponent: 2},
+	"EGP": {Code: "EGP", Exponent: 2},
+	"EUR": {Code: "EUR", Exponent: 2},
+	"GBP": {Code: "GBP", Exponent: 2},
+	"GHS": {Code: "GHS", Exponent: 2},
+	"HKD": {Code: "HKD", Exponent: 2},
+	"INR": {Code: "INR", Exponent: 2},
+	"JPY": {Code: "JPY", Exponent: 0},
+	"KES": {Code: "KES", Exponent: 2},
+	"KRW": {Code: "KRW", Exponent: 0},
+	"MAD": {Code: "MAD", Exponent: 2},
+	"MXN": {Code: "MXN", Exponent: 2},
+	"NGN": {Code: "NGN", Exponent: 2},
+	"NOK": {Code: "NOK", Exponent: 2},
+	"NZD": {Code: "NZD", Exponent: 2},
+	"RWF": {Code: "RWF", Exponent: 0},
+	"SEK": {Code: "SEK", Exponent: 2},
+	"SGD": {Code: "SGD", Exponent: 2},
+	"TZS": {Code: "TZS", Exponent: 2},
+	"UGX": {Code: "UGX", Exponent: 0},
+	"USD": {Code: "USD", Exponent: 2},
+	"XAF": {Code: "XAF", Exponent: 0},
+	"XOF": {Code: "XOF", Exponent: 0},
+	"ZAR": {Code: "ZAR", Exponent: 2},
+}
+
+// LookupCurrency returns the rounding rules for a currency code
+func LookupCurrency(code string) (Currency, error) {
+	currency, ok := currencies[strings.ToUpper(code)]
+	if !ok {
+		return Currency{}, fmt.Errorf("unsupported currency %q", code)
+	}
+	return currency, nil
+}
+
+// exponentOf returns the minor-unit digits of a currency, falling back to
+// DefaultExponent for untagged or unknown codes
+func exponentOf(code string) int {
+	if currency, ok := currencies[strings.ToUpper(code)]; ok {
+		return currency.Exponent
+	}
+	return DefaultExponent
+}
@@ -0,0 +1,361 @@
+package money
+
+import (
+	"bytes"
+	"database/sql/driver"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"math/big"
+	"strings"
+)
+
+// ErrCurrencyMismatch is returned when arithmetic is attempted on amounts of different currencies
+var ErrCurrencyMismatch = errors.New("currency mismatch")
+
+// ErrTooPrecise is returned when an amount has digits below its currency's
+// minor unit, e.g. 10.5 JPY or 10.005 USD, so it cannot be kept without rounding
+var ErrTooPrecise = errors.New("has more decimal places than its currency allows")
+
+// Money is an exact monetary amount held as an integer number of minor units
+// (cents, kobo, ...) together with its ISO 4217 currency code.
+//
+// Amounts read from JSON or the database carry no currency until they are
+// tagged with WithCurrency; until then they are treated as having
+// DefaultExponent minor-unit digits. The decimal they were read from is kept
+// when it has more digits than that, so WithCurrency can refuse it rather
+// than round it.
+type Money struct {
+	Amount   int64
+	Currency string
+
+	raw string
+}
+
+// New returns an amount of minor units in the given currency
+func New(amount int64, currency string) Money {
+	return Money{Amount: amount, Currency: strings.ToUpper(currency)}
+}
+
+// Zero returns a zero amount in the given currency
+func Zero(currency string) Money {
+	return New(0, currency)
+}
+
+// Parse reads a decimal string such as "1250.50" and rounds it to the minor
+// unit of the currency. An empty currency parses with DefaultExponent digits.
+func Parse(value string, currency string) (Money, error) {
+	amount, err := parseMinorUnits(value, exponentOf(currency))
+	if err != nil {
+		return Money{}, err
+	}
+	return New(amount, currency), nil
+}
+
+// ParseExact is like Parse but refuses a value with digits below the
+// currency's minor unit, such as "10.5" JPY, with ErrTooPrecise instead of
+// rounding it
+func ParseExact(value string, currency string) (Money, error) {
+	target, err := LookupCurrency(currency)
+	if err != nil {
+		return Money{}, err
+	}
+	amount, err := parseExactMinorUnits(value, target)
+	if err != nil {
+		return Money{}, err
+	}
+	return New(amount, target.Code), nil
+}
+
+// MustParse is like Parse but panics on invalid input. It is meant for constants and tests.
+func MustParse(value string, currency string) Money {
+	m, err := Parse(value, currency)
+	if err != nil {
+		panic(err)
+	}
+	return m
+}
+
+// WithCurrency tags an amount with its currency, rescaling the minor units to
+// the currency's exponent. An amount with digits below the currency's minor
+// unit fails with ErrTooPrecise instead of being rounded. It does not convert
+// between currencies: tagging an amount that already belongs to another
+// currency fails.
+func (m Money) WithCurrency(currency string) (Money, error) {
+	target, err := LookupCurrency(currency)
+	if err != nil {
+		return Money{}, err
+	}
+
+	if m.Currency == target.Code {
+		return m, nil
+	}
+	if m.Currency != "" {
+		return Money{}, fmt.Errorf("%w: cannot re-tag %s as %s", ErrCurrencyMismatch, m.Currency, target.Code)
+	}
+
+	value := m.raw
+	if value == "" {
+		value = formatMinorUnits(m.Amount, DefaultExponent)
+	}
+	amount, err := parseExactMinorUnits(value, target)
+	if err != nil {
+		return Money{}, err
+	}
+	return Money{Amount: amount, Currency: target.Code}, nil
+}
+
+// Add returns m + other
+func (m Money) Add(other Money) (Money, error) {
+	if err := m.assertSameCurrency(other); err != nil {
+		return Money{}, err
+	}
+	return Money{Amount: m.Amount + other.Amount, Currency: m.Currency}, nil
+}
+
+// Sub returns m - other
+func (m Money) Sub(other Money) (Money, error) {
+	if err := m.assertSameCurrency(other); err != nil {
+		return Money{}, err
+	}
+	return Money{Amount: m.Amount - other.Amount, Currency: m.Currency}, nil
+}
+
+// Mul returns m multiplied by a whole quantity
+func (m Money) Mul(quantity int64) Money {
+	return Money{Amount: m.Amount * quantity, Currency: m.Currency}
+}
+
+// Allocate splits the amount in proportion to the given weights. The parts
+// always add back up to the amount: minor units lost to rounding go to the
+// parts with the largest remainders. If every weight is zero, nothing is
+// allocated and all parts are zero.
+func (m Money) Allocate(weights []int64) []Money {
+	parts := make([]Money, len(weights))
+	total := big.NewInt(0)
+	for idx, weight := range weights {
+		parts[idx] = Money{Currency: m.Currency}
+		total.Add(total, big.NewInt(weight))
+	}
+	if total.Sign() == 0 {
+		return parts
+	}
+
+	remainders := make([]*big.Int, len(weights))
+	allocated := int64(0)
+	for idx, weight := range weights {
+		share := new(big.Int).Mul(big.NewInt(m.Amount), big.NewInt(weight))
+		quotient, remainder := new(big.Int).QuoRem(share, total, new(big.Int))
+		parts[idx].Amount = quotient.Int64()
+		remainders[idx] = remainder
+		allocated += quotient.Int64()
+	}
+
+	step := int64(1)
+	if m.Amount < 0 {
+		step = -1
+	}
+	for left := m.Amount - allocated; left != 0; left -= step {
+		largest := 0
+		for idx := range remainders {
+			if new(big.Int).Abs(remainders[idx]).Cmp(new(big.Int).Abs(remainders[largest])) > 0 {
+				largest = idx
+			}
+		}
+		parts[largest].Amount += step
+		remainders[largest] = big.NewInt(0)
+	}
+
+	return parts
+}
+
+// Cmp compares two amounts of the same currency and returns -1, 0 or +1
+func (m Money) Cmp(other Money) (int, error) {
+	if err := m.assertSameCurrency(other); err != nil {
+		return 0, err
+	}
+	switch {
+	case m.Amount < other.Amount:
+		return -1, nil
+	case m.Amount > other.Amount:
+		return 1, nil
+	default:
+		return 0, nil
+	}
+}
+
+// Neg returns the amount with its sign flipped
+func (m Money) Neg() Money {
+	return Money{Amount: -m.Amount, Currency: m.Currency}
+}
+
+// IsZero reports whether the amount is zero
+func (m Money) IsZero() bool {
+	return m.Amount == 0
+}
+
+// IsNegative reports whether the amount is below zero
+func (m Money) IsNegative() bool {
+	return m.Amount < 0
+}
+
+// IsPositive reports whether the amount is above zero
+func (m Money) IsPositive() bool {
+	return m.Amount > 0
+}
+
+// String formats the amount as a plain decimal, e.g. "1250.50"
+func (m Money) String() string {
+	return formatMinorUnits(m.Amount, exponentOf(m.Currency))
+}
+
+// MarshalJSON encodes the amount as an exact JSON number
+func (m Money) MarshalJSON() ([]byte, error) {
+	return []byte(m.String()), nil
+}
+
+// UnmarshalJSON accepts a JSON number or a quoted decimal string
+func (m *Money) UnmarshalJSON(data []byte) error {
+	if bytes.Equal(data, []byte("null")) {
+		*m = Money{Currency: m.Currency}
+		return nil
+	}
+
+	value := string(data)
+	if len(data) > 0 && data[0] == '"' {
+		if err := json.Unmarshal(data, &value); err != nil {
+			return err
+		}
+	}
+
+	return m.decode(value)
+}
+
+// Value implements driver.Valuer so amounts are written to DECIMAL columns as exact strings
+func (m Money) Value() (driver.Value, error) {
+	return m.String(), nil
+}
+
+// Scan implements sql.Scanner for DECIMAL columns. NULL scans as zero.
+func (m *Money) Scan(src interface{}) error {
+	var value string
+	switch v := src.(type) {
+	case nil:
+		m.Amount = 0
+		m.raw = ""
+		return nil
+	case []byte:
+		value = string(v)
+	case string:
+		value = v
+	case int64:
+		value = fmt.Sprintf("%d", v)
+	case float64:
+		value = big.NewFloat(v).Text('f', -1)
+	default:
+		return fmt.Errorf("cannot scan %T into money", src)
+	}
+
+	return m.decode(value)
+}
+
+// decode reads a decimal into the amount. An amount that is already tagged must
+// fit its currency; an untagged one keeps the decimal if it has more digits
+// than DefaultExponent, for WithCurrency to check.
+func (m *Money) decode(value string) error {
+	if m.Currency != "" {
+		amount, err := parseExactMinorUnits(value, Currency{Code: m.Currency, Exponent: exponentOf(m.Currency)})
+		if err != nil {
+			return err
+		}
+		m.Amount = amount
+		return nil
+	}
+
+	amount, exact, err := scaleDecimal(value, DefaultExponent)
+	if err != nil {
+		return err
+	}
+	m.Amount = amount
+	m.raw = ""
+	if !exact {
+		m.raw = strings.TrimSpace(value)
+	}
+	return nil
+}
+
+func (m Money) assertSameCurrency(other Money) error {
+	if m.Currency != other.Currency {
+		return fmt.Errorf("%w: %q and %q", ErrCurrencyMismatch, m.Currency, other.Currency)
+	}
+	return nil
+}
+
+// parseMinorUnits converts a decimal string into minor units, rounding half away from zero
+func parseMinorUnits(value string, exponent int) (int64, error) {
+	amount, _, err := scaleDecimal(value, exponent)
+	return amount, err
+}
+
+// parseExactMinorUnits is parseMinorUnits for amounts that must fit the
+// currency's minor unit without rounding
+func parseExactMinorUnits(value string, currency Currency) (int64, error) {
+	amount, exact, err := scaleDecimal(value, currency.Exponent)
+	if err != nil {
+		return 0, err
+	}
+	if !exact {
+		return 0, fmt.Errorf("amount %s %w, %s has %d", strings.TrimSpace(value), ErrTooPrecise, currency.Code, currency.Exponent)
+	}
+	return amount, nil
+}
+
+// scaleDecimal reads a decimal string as minor units of the given exponent,
+// rounding half away from zero, and reports whether nothing was rounded off
+func scaleDecimal(value string, exponent int) (int64, bool, error) {
+	value = strings.TrimSpace(value)
+	rat, ok := new(big.Rat).SetString(value)
+	if !ok || value == "" {
+		return 0, false, fmt.Errorf("invalid amount %q", value)
+	}
+
+	scaled := new(big.Rat).Mul(rat, new(big.Rat).SetInt(pow10(exponent)))
+	amount := roundHalfAwayFromZero(scaled.Num(), scaled.Denom())
+	if !amount.IsInt64() {
+		return 0, false, fmt.Errorf("amount %q is out of range", value)
+	}
+	return amount.Int64(), scaled.IsInt(), nil
+}
+
+func formatMinorUnits(amount int64, exponent int) string {
+	sign := ""
+	if amount < 0 {
+		sign = "-"
+	}
+	digits := new(big.Int).Abs(big.NewInt(amount)).String()
+	if exponent == 0 {
+		return sign + digits
+	}
+	if len(digits) <= exponent {
+		digits = strings.Repeat("0", exponent-len(digits)+1) + digits
+	}
+	return sign + digits[:len(digits)-exponent] + "." + digits[len(digits)-exponent:]
+}
+
+// roundHalfAwayFromZero divides num by den and rounds the quotient half away from zero
+func roundHalfAwayFromZero(num *big.Int, den *big.Int) *big.Int {
+	quotient, remainder := new(big.Int).QuoRem(num, den, new(big.Int))
+	twice := new(big.Int).Mul(new(big.Int).Abs(remainder), big.NewInt(2))
+	if twice.Cmp(new(big.Int).Abs(den)) >= 0 {
+		if (num.Sign() < 0) != (den.Sign() < 0) {
+			quotient.Sub(quotient, big.NewInt(1))
+		} else {
+			quotient.Add(quotient, big.NewInt(1))
+		}
+	}
+	return quotient
+}
+
+func pow10(exponent int) *big.Int {
+	return new(big.Int).Exp(big.NewInt(10), big.NewInt(int64(exponent)), nil)
+}
@@ -0,0 +1,270 @@
+package money
+
+import (
+	"encoding/json"
+	"errors"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestParse(t *testing.T) {
+	tests := []struct {
+		name     string
+		value    string
+		currency string
+		want     Money
+		wantErr  bool
+	}{
+		{name: "two decimals", value: "1250.50", currency: "USD", want: New(125050, "USD")},
+		{name: "whole number", value: "12", currency: "NGN", want: New(1200, "NGN")},
+		{name: "rounds half away from zero", value: "0.005", currency: "USD", want: New(1, "USD")},
+		{name: "rounds negative half away from zero", value: "-0.005", currency: "USD", want: New(-1, "USD")},
+		{name: "rounds down below half", value: "0.0049", currency: "USD", want: New(0, "USD")},
+		{name: "zero exponent currency", value: "100.5", currency: "JPY", want: New(101, "JPY")},
+		{name: "exponent notation", value: "1e2", currency: "EUR", want: New(10000, "EUR")},
+		{name: "untagged uses default exponent", value: "9.99", currency: "", want: Money{Amount: 999}},
+		{name: "invalid", value: "ten", currency: "USD", wantErr: true},
+		{name: "empty", value: "", currency: "USD", wantErr: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := Parse(tt.value, tt.currency)
+			if tt.wantErr {
+				assert.Error(t, err)
+				return
+			}
+			assert.NoError(t, err)
+			assert.Equal(t, tt.want, got)
+		})
+	}
+}
+
+func TestParseExact(t *testing.T) {
+	yen, err := ParseExact("1500.00", "jpy")
+	assert.NoError(t, err)
+	assert.Equal(t, New(1500, "JPY"), yen)
+
+	_, err = ParseExact("10.5", "JPY")
+	assert.ErrorIs(t, err, ErrTooPrecise)
+
+	_, err = ParseExact("0.005", "USD")
+	assert.ErrorIs(t, err, ErrTooPrecise)
+
+	_, err = ParseExact("1", "XYZ")
+	assert.Error(t, err)
+}
+
+func TestArithmeticRefusesMixedCurrencies(t *testing.T) {
+	usd := New(1000, "USD")
+	eur := New(1000, "EUR")
+
+	_, err := usd.Add(eur)
+	assert.True(t, errors.Is(err, ErrCurrencyMismatch))
+
+	_, err = usd.Sub(eur)
+	assert.True(t, errors.Is(err, ErrCurrencyMismatch))
+
+	_, err = usd.Cmp(eur)
+	assert.True(t, errors.Is(err, ErrCurrencyMismatch))
+
+	// untagged amounts must be tagged before they can be combined
+	_, err = usd.Add(Money{Amount: 100})
+	assert.True(t, errors.Is(err, ErrCurrencyMismatch))
+}
+
+func TestArithmetic(t *testing.T) {
+	a := MustParse("0.1", "USD")
+	b := MustParse("0.2", "USD")
+
+	sum, err := a.Add(b)
+	assert.NoError(t, err)
+	assert.Equal(t, MustParse("0.3", "USD"), sum)
+
+	diff, err := sum.Sub(MustParse("0.5", "USD"))
+	assert.NoError(t, err)
+	assert.True(t, diff.IsNegative())
+	assert.Equal(t, "-0.20", diff.String())
+
+	cmp, err := sum.Cmp(MustParse("0.30", "USD"))
+	assert.NoError(t, err)
+	assert.Equal(t, 0, cmp)
+
+	assert.Equal(t, New(750, "USD"), New(250, "USD").Mul(3))
+	assert.Equal(t, New(-250, "USD"), New(250, "USD").Neg())
+}
+
+func TestWithCurrency(t *testing.T) {
+	untagged := Money{Amount: 10050}
+
+	usd, err := untagged.WithCurrency("usd")
+	assert.NoError(t, err)
+	assert.Equal(t, New(10050, "USD"), usd)
+
+	jpy, err := Money{Amount: 150000}.WithCurrency("JPY")
+	assert.NoError(t, err)
+	assert.Equal(t, New(1500, "JPY"), jpy)
+
+	_, err = untagged.WithCurrency("JPY")
+	assert.ErrorIs(t, err, ErrTooPrecise)
+
+	same, err := usd.WithCurrency("USD")
+	assert.NoError(t, err)
+	assert.Equal(t, usd, same)
+
+	_, err = usd.WithCurrency("EUR")
+	assert.True(t, errors.Is(err, ErrCurrencyMismatch))
+
+	_, err = untagged.WithCurrency("KWD")
+	assert.Error(t, err)
+}
+
+func TestString(t *testing.T) {
+	assert.Equal(t, "0.05", New(5, "USD").String())
+	assert.Equal(t, "-1.00", New(-100, "USD").String())
+	assert.Equal(t, "1500", New(1500, "JPY").String())
+	assert.Equal(t, "12.34", Money{Amount: 1234}.String())
+}
+
+func TestJSON(t *testing.T) {
+	type payload struct {
+		Amount Money `json:"amount"`
+	}
+
+	data, err := json.Marshal(payload{Amount: New(123456, "USD")})
+	assert.NoError(t, err)
+	assert.JSONEq(t, `{"amount": 1234.56}`, string(data))
+
+	var fromNumber payload
+	assert.NoError(t, json.Unmarshal([]byte(`{"amount": 0.30}`), &fromNumber))
+	assert.Equal(t, int64(30), fromNumber.Amount.Amount)
+
+	var fromString payload
+	assert.NoError(t, json.Unmarshal([]byte(`{"amount": "19.99"}`), &fromString))
+	assert.Equal(t, int64(1999), fromString.Amount.Amount)
+
+	var invalid payload
+	assert.Error(t, json.Unmarshal([]byte(`{"amount": "abc"}`), &invalid))
+
+	// digits below a cent are kept until the currency is known
+	var precise payload
+	assert.NoError(t, json.Unmarshal([]byte(`{"amount": 10.005}`), &precise))
+	_, err = precise.Amount.WithCurrency("USD")
+	assert.ErrorIs(t, err, ErrTooPrecise)
+	assert.EqualError(t, err, "amount 10.005 has more decimal places than its currency allows, USD has 2")
+
+	var trailingZeros payload
+	assert.NoError(t, json.Unmarshal([]byte(`{"amount": "10.5000"}`), &trailingZeros))
+	usd, err := trailingZeros.Amount.WithCurrency("USD")
+	assert.NoError(t, err)
+	assert.Equal(t, New(1050, "USD"), usd)
+
+	var yen payload
+	assert.NoError(t, json.Unmarshal([]byte(`{"amount": 1500.5}`), &yen))
+	_, err = yen.Amount.WithCurrency("JPY")
+	assert.ErrorIs(t, err, ErrTooPrecise)
+
+	tagged := payload{Amount: Zero("JPY")}
+	assert.ErrorIs(t, json.Unmarshal([]byte(`{"amount": 1500.5}`), &tagged), ErrTooPrecise)
+}
+
+func TestScanAndValue(t *testing.T) {
+	var m Money
+	assert.NoError(t, m.Scan([]byte("1500.25")))
+	assert.Equal(t, int64(150025), m.Amount)
+
+	assert.NoError(t, m.Scan(nil))
+	assert.True(t, m.IsZero())
+
+	assert.NoError(t, m.Scan(int64(7)))
+	assert.Equal(t, int64(700), m.Amount)
+
+	assert.Error(t, m.Scan(true))
+
+	var precise Money
+	assert.NoError(t, precise.Scan("0.125"))
+	_, err := precise.WithCurrency("EUR")
+	assert.ErrorIs(t, err, ErrTooPrecise)
+
+	yen := Zero("JPY")
+	assert.NoError(t, yen.Scan([]byte("15000.00")))
+	assert.Equal(t, New(15000, "JPY"), yen)
+
+	value, err := New(150025, "USD").Value()
+	assert.NoError(t, err)
+	assert.Equal(t, "1500.25", value)
+
+	value, err = New(1500, "JPY").Value()
+	assert.NoError(t, err)
+	assert.Equal(t, "1500", value)
+}
+
+func TestRate(t *testing.T) {
+	rate, err := ParseRate("7.5")
+	assert.NoError(t, err)
+	assert.Equal(t, Rate(75000), rate)
+	assert.Equal(t, "7.5", rate.String())
+	assert.Equal(t, "20", MustParseRate("20.00").String())
+
+	_, err = ParseRate("abc")
+	assert.Error(t, err)
+
+	assert.Equal(t, New(1500, "USD"), New(20000, "USD").ApplyRate(rate))
+	assert.Equal(t, New(1, "USD"), New(7, "USD").ApplyRate(rate))
+	assert.Equal(t, New(10000, "USD"), New(12000, "USD").ExcludeRate(MustParseRate("20")))
+
+	value, err := rate.Value()
+	assert.NoError(t, err)
+	assert.Equal(t, "7.5000", value)
+
+	var scanned Rate
+	assert.NoError(t, scanned.Scan([]byte("12.3456")))
+	assert.Equal(t, Rate(123456), scanned)
+
+	var decoded struct {
+		Rate Rate `json:"rate"`
+	}
+	assert.NoError(t, json.Unmarshal([]byte(`{"rate": 12.5}`), &decoded))
+	assert.Equal(t, Rate(125000), decoded.Rate)
+}
+
+func TestAllocate(t *testing.T) {
+	tests := []struct {
+		name    string
+		amount  Money
+		weights []int64
+		want    []Money
+	}{
+		{
+			name:    "even split",
+			amount:  New(1000, "USD"),
+			weights: []int64{1, 1},
+			want:    []Money{New(500, "USD"), New(500, "USD")},
+		},
+		{
+			name:    "remainder goes to the largest remainders",
+			amount:  New(10, "USD"),
+			weights: []int64{100, 100, 100},
+			want:    []Money{New(4, "USD"), New(3, "USD"), New(3, "USD")},
+		},
+		{
+			name:    "proportional",
+			amount:  New(1000, "USD"),
+			weights: []int64{20000, 5000},
+			want:    []Money{New(800, "USD"), New(200, "USD")},
+		},
+		{
+			name:    "zero weights allocate nothing",
+			amount:  New(1000, "USD"),
+			weights: []int64{0, 0},
+			want:    []Money{New(0, "USD"), New(0, "USD")},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.Equal(t, tt.want, tt.amount.Allocate(tt.weights))
+		})
+	}
+}
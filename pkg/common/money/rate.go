@@ -0,0 +1,121 @@
+package money
+
+import (
+	"bytes"
+	"database/sql/driver"
+	"encoding/json"
+	"fmt"
+	"math/big"
+	"strings"
+)
+
+// RateExponent is the number of decimal places a percentage is kept to
+const RateExponent = 4
+
+// ratePrecision is the value of 1% expressed in Rate units
+const ratePrecision = 10000
+
+// Rate is an exact percentage, e.g. 7.5% for VAT, held in ten-thousandths of a
+// percent so it can be applied to Money without floating point error
+type Rate int64
+
+// HundredPercent is a rate of 100%
+const HundredPercent Rate = 100 * ratePrecision
+
+// ParseRate reads a percentage such as "7.5" (meaning 7.5%)
+func ParseRate(value string) (Rate, error) {
+	units, err := parseMinorUnits(value, RateExponent)
+	if err != nil {
+		return 0, fmt.Errorf("invalid rate %q", value)
+	}
+	return Rate(units), nil
+}
+
+// MustParseRate is like ParseRate but panics on invalid input
+func MustParseRate(value string) Rate {
+	rate, err := ParseRate(value)
+	if err != nil {
+		panic(err)
+	}
+	return rate
+}
+
+// String formats the rate as a percentage without trailing zeros, e.g. "7.5"
+func (r Rate) String() string {
+	formatted := formatMinorUnits(int64(r), RateExponent)
+	formatted = strings.TrimRight(formatted, "0")
+	return strings.TrimSuffix(formatted, ".")
+}
+
+// ApplyRate returns the given percentage of the amount, rounded to the currency's minor unit
+func (m Money) ApplyRate(rate Rate) Money {
+	num := new(big.Int).Mul(big.NewInt(m.Amount), big.NewInt(int64(rate)))
+	amount := roundHalfAwayFromZero(num, big.NewInt(int64(HundredPercent)))
+	return Money{Amount: amount.Int64(), Currency: m.Currency}
+}
+
+// ExcludeRate returns the part of an amount that remains once a rate that is
+// already included in it is taken out, i.e. amount / (1 + rate)
+func (m Money) ExcludeRate(rate Rate) Money {
+	num := new(big.Int).Mul(big.NewInt(m.Amount), big.NewInt(int64(HundredPercent)))
+	den := big.NewInt(int64(HundredPercent + rate))
+	amount := roundHalfAwayFromZero(num, den)
+	return Money{Amount: amount.Int64(), Currency: m.Currency}
+}
+
+// MarshalJSON encodes the rate as a JSON number
+func (r Rate) MarshalJSON() ([]byte, error) {
+	return []byte(r.String()), nil
+}
+
+// UnmarshalJSON accepts a JSON number or a quoted decimal string
+func (r *Rate) UnmarshalJSON(data []byte) error {
+	if bytes.Equal(data, []byte("null")) {
+		*r = 0
+		return nil
+	}
+
+	value := string(data)
+	if len(data) > 0 && data[0] == '"' {
+		if err := json.Unmarshal(data, &value); err != nil {
+			return err
+		}
+	}
+
+	rate, err := ParseRate(value)
+	if err != nil {
+		return err
+	}
+	*r = rate
+	return nil
+}
+
+// Value implements driver.Valuer for DECIMAL columns
+func (r Rate) Value() (driver.Value, error) {
+	return formatMinorUnits(int64(r), RateExponent), nil
+}
+
+// Scan implements sql.Scanner for DECIMAL columns
+func (r *Rate) Scan(src interface{}) error {
+	var value string
+	switch v := src.(type) {
+	case nil:
+		*r = 0
+		return nil
+	case []byte:
+		value = string(v)
+	case string:
+		value = v
+	case int64:
+		value = fmt.Sprintf("%d", v)
+	default:
+		return fmt.Errorf("cannot scan %T into rate", src)
+	}
+
+	rate, err := ParseRate(value)
+	if err != nil {
+		return err
+	}
+	*r = rate
+	return nil
+}
@@ -0,0 +1,216 @@
+// Package rrule implements the subset of RFC 5545 recurrence rules needed to
+// schedule recurring invoices: FREQ, INTERVAL, BYDAY (weekly rules only),
+// BYMONTHDAY (monthly rules only), COUNT and UNTIL.
+package rrule
+
+import (
+	"fmt"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+)
+
+type Frequency string
+
+const (
+	FrequencyDaily   Frequency = "DAILY"
+	FrequencyWeekly  Frequency = "WEEKLY"
+	FrequencyMonthly Frequency = "MONTHLY"
+	FrequencyYearly  Frequency = "YEARLY"
+)
+
+// maxPeriods bounds the search for the next occurrence so a rule that can
+// never match (e.g. BYMONTHDAY=30 on a yearly February rule) cannot spin forever
+const maxPeriods = 100000
+
+var weekdays = map[string]time.Weekday{
+	"MO": time.Monday,
+	"TU": time.Tuesday,
+	"WE": time.Wednesday,
+	"TH": time.Thursday,
+	"FR": time.Friday,
+	"SA": time.Saturday,
+	"SU": time.Sunday,
+}
+
+// Rule is a parsed recurrence rule
+type Rule struct {
+	Freq       Frequency
+	Interval   int
+	ByDay      []time.Weekday
+	ByMonthDay []int
+	// Count is the number of occurrences the rule stops after, 0 for no limit
+	Count int
+	// Until is the last moment an occurrence may fall on, nil for no limit
+	Until *time.Time
+}
+
+// Parse reads a rule such as "FREQ=MONTHLY;INTERVAL=3;BYMONTHDAY=-1". A
+// leading "RRULE:" is accepted.
+func Parse(value string) (*Rule, error) {
+	value = strings.TrimPrefix(strings.TrimSpace(value), "RRULE:")
+	if value == "" {
+		return nil, fmt.Errorf("rrule is empty")
+	}
+
+	rule := &Rule{Interval: 1}
+	for _, part := range strings.Split(value, ";") {
+		key, val, ok := strings.Cut(part, "=")
+		if !ok || val == "" {
+			return nil, fmt.Errorf("invalid rrule part %q", part)
+		}
+
+		switch strings.ToUpper(key) {
+		case "FREQ":
+			rule.Freq = Frequency(strings.ToUpper(val))
+		case "INTERVAL":
+			interval, err := strconv.Atoi(val)
+			if err != nil || interval < 1 {
+				return nil, fmt.Errorf("invalid rrule interval %q", val)
+			}
+			rule.Interval = interval
+		case "COUNT":
+			count, err := strconv.Atoi(val)
+			if err != nil || count < 1 {
+				return nil, fmt.Errorf("invalid rrule count %q", val)
+			}
+			rule.Count = count
+		case "UNTIL":
+			until, err := parseUntil(val)
+			if err != nil {
+				return nil, err
+			}
+			rule.Until = &until
+		case "BYDAY":
+			for _, day := range strings.Split(val, ",") {
+				weekday, ok := weekdays[strings.ToUpper(day)]
+				if !ok {
+					return nil, fmt.Errorf("invalid rrule weekday %q", day)
+				}
+				rule.ByDay = append(rule.ByDay, weekday)
+			}
+		case "BYMONTHDAY":
+			for _, day := range strings.Split(val, ",") {
+				monthDay, err := strconv.Atoi(day)
+				if err != nil || monthDay == 0 || monthDay < -31 || monthDay > 31 {
+					return nil, fmt.Errorf("invalid rrule month day %q", day)
+				}
+				rule.ByMonthDay = append(rule.ByMonthDay, monthDay)
+			}
+		default:
+			return nil, fmt.Errorf("unsupported rrule part %q", key)
+		}
+	}
+
+	switch rule.Freq {
+	case FrequencyDaily, FrequencyWeekly, FrequencyMonthly, FrequencyYearly:
+	case "":
+		return nil, fmt.Errorf("rrule needs a FREQ")
+	default:
+		return nil, fmt.Errorf("unsupported rrule frequency %q", rule.Freq)
+	}
+	if len(rule.ByDay) > 0 && rule.Freq != FrequencyWeekly {
+		return nil, fmt.Errorf("BYDAY is only supported on weekly rules")
+	}
+	if len(rule.ByMonthDay) > 0 && rule.Freq != FrequencyMonthly {
+		return nil, fmt.Errorf("BYMONTHDAY is only supported on monthly rules")
+	}
+	if rule.Count > 0 && rule.Until != nil {
+		return nil, fmt.Errorf("rrule cannot have both COUNT and UNTIL")
+	}
+
+	return rule, nil
+}
+
+// Next returns the first occurrence of the rule, counted from start, that
+// falls strictly after after. The second result is false once the rule has
+// no more occurrences because of UNTIL; COUNT is left to the caller, which
+// knows how many occurrences have already been used.
+//
+// Month days past the end of a month fall on its last day, so a rule for the
+// 31st still runs in shorter months instead of skipping them.
+func (r *Rule) Next(start time.Time, after time.Time) (time.Time, bool) {
+	for period := 0; period < maxPeriods; period++ {
+		for _, occurrence := range r.occurrencesInPeriod(start, period) {
+			if occurrence.Before(start) || !occurrence.After(after) {
+				continue
+			}
+			if r.Until != nil && occurrence.After(*r.Until) {
+				return time.Time{}, false
+			}
+			return occurrence, true
+		}
+	}
+	return time.Time{}, false
+}
+
+// occurrencesInPeriod lists, in order, the occurrences in the period'th
+// interval of the rule after start
+func (r *Rule) occurrencesInPeriod(start time.Time, period int) []time.Time {
+	step := period * r.Interval
+	clock := func(year int, month time.Month, day int) time.Time {
+		return time.Date(year, month, day, start.Hour(), start.Minute(), start.Second(), 0, start.Location())
+	}
+
+	switch r.Freq {
+	case FrequencyDaily:
+		return []time.Time{start.AddDate(0, 0, step)}
+
+	case FrequencyWeekly:
+		days := r.ByDay
+		if len(days) == 0 {
+			days = []time.Weekday{start.Weekday()}
+		}
+		// weeks start on Monday, as RFC 5545 assumes by default
+		monday := start.AddDate(0, 0, -((int(start.Weekday())+6)%7)+7*step)
+		occurrences := make([]time.Time, 0, len(days))
+		for _, day := range days {
+			occurrences = append(occurrences, monday.AddDate(0, 0, (int(day)+6)%7))
+		}
+		sort.Slice(occurrences, func(i, j int) bool { return occurrences[i].Before(occurrences[j]) })
+		return occurrences
+
+	case FrequencyMonthly:
+		first := time.Date(start.Year(), start.Month(), 1, 0, 0, 0, 0, start.Location()).AddDate(0, step, 0)
+		lastDay := daysIn(first.Year(), first.Month())
+		days := r.ByMonthDay
+		if len(days) == 0 {
+			days = []int{start.Day()}
+		}
+		occurrences := make([]time.Time, 0, len(days))
+		for _, day := range days {
+			if day < 0 {
+				day = lastDay + day + 1
+			}
+			day = max(1, min(day, lastDay))
+			occurrences = append(occurrences, clock(first.Year(), first.Month(), day))
+		}
+		sort.Slice(occurrences, func(i, j int) bool { return occurrences[i].Before(occurrences[j]) })
+		return occurrences
+
+	case FrequencyYearly:
+		year := start.Year() + step
+		day := min(start.Day(), daysIn(year, start.Month()))
+		return []time.Time{clock(year, start.Month(), day)}
+	}
+
+	return nil
+}
+
+func daysIn(year int, month time.Month) int {
+	return time.Date(year, month+1, 0, 0, 0, 0, 0, time.UTC).Day()
+}
+
+func parseUntil(value string) (time.Time, error) {
+	for _, layout := range []string{"20060102T150405Z", "20060102"} {
+		if until, err := time.Parse(layout, value); err == nil {
+			if layout == "20060102" {
+				// a date-only UNTIL includes the whole day
+				until = until.Add(24*time.Hour - time.Second)
+			}
+			return until, nil
+		}
+	}
+	return time.Time{}, fmt.Errorf("invalid rrule until %q", value)
+}
@@ -0,0 +1,159 @@
+package rrule
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func date(year int, month time.Month, day int) time.Time {
+	return time.Date(year, month, day, 9, 0, 0, 0, time.UTC)
+}
+
+func TestParse(t *testing.T) {
+	tests := []struct {
+		name    string
+		value   string
+		want    *Rule
+		wantErr bool
+		errMsg  string
+	}{
+		{
+			name:  "monthly every quarter on the last day",
+			value: "RRULE:FREQ=MONTHLY;INTERVAL=3;BYMONTHDAY=-1",
+			want:  &Rule{Freq: FrequencyMonthly, Interval: 3, ByMonthDay: []int{-1}},
+		},
+		{
+			name:  "weekly on weekdays with a count",
+			value: "FREQ=WEEKLY;BYDAY=MO,FR;COUNT=4",
+			want:  &Rule{Freq: FrequencyWeekly, Interval: 1, ByDay: []time.Weekday{time.Monday, time.Friday}, Count: 4},
+		},
+		{name: "missing frequency", value: "INTERVAL=2", wantErr: true, errMsg: "needs a FREQ"},
+		{name: "unsupported frequency", value: "FREQ=HOURLY", wantErr: true, errMsg: "unsupported rrule frequency"},
+		{name: "unsupported part", value: "FREQ=MONTHLY;BYSETPOS=1", wantErr: true, errMsg: "unsupported rrule part"},
+		{name: "invalid interval", value: "FREQ=DAILY;INTERVAL=0", wantErr: true, errMsg: "invalid rrule interval"},
+		{name: "byday on a monthly rule", value: "FREQ=MONTHLY;BYDAY=MO", wantErr: true, errMsg: "only supported on weekly rules"},
+		{name: "count and until", value: "FREQ=DAILY;COUNT=2;UNTIL=20250101", wantErr: true, errMsg: "both COUNT and UNTIL"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			rule, err := Parse(tt.value)
+
+			if tt.wantErr {
+				assert.Error(t, err)
+				assert.Contains(t, err.Error(), tt.errMsg)
+				return
+			}
+
+			assert.NoError(t, err)
+			assert.Equal(t, tt.want, rule)
+		})
+	}
+}
+
+func TestNext(t *testing.T) {
+	tests := []struct {
+		name   string
+		rule   string
+		start  time.Time
+		after  time.Time
+		want   time.Time
+		wantOK bool
+	}{
+		{
+			name:   "first occurrence is the start itself",
+			rule:   "FREQ=MONTHLY",
+			start:  date(2025, time.January, 15),
+			after:  date(2025, time.January, 14),
+			want:   date(2025, time.January, 15),
+			wantOK: true,
+		},
+		{
+			name:   "monthly",
+			rule:   "FREQ=MONTHLY",
+			start:  date(2025, time.January, 15),
+			after:  date(2025, time.January, 15),
+			want:   date(2025, time.February, 15),
+			wantOK: true,
+		},
+		{
+			name:   "month end falls on the last day of shorter months",
+			rule:   "FREQ=MONTHLY",
+			start:  date(2025, time.January, 31),
+			after:  date(2025, time.January, 31),
+			want:   date(2025, time.February, 28),
+			wantOK: true,
+		},
+		{
+			name:   "quarterly",
+			rule:   "FREQ=MONTHLY;INTERVAL=3",
+			start:  date(2025, time.January, 1),
+			after:  date(2025, time.January, 1),
+			want:   date(2025, time.April, 1),
+			wantOK: true,
+		},
+		{
+			name:   "last day of the month",
+			rule:   "FREQ=MONTHLY;BYMONTHDAY=-1",
+			start:  date(2024, time.January, 10),
+			after:  date(2024, time.January, 31),
+			want:   date(2024, time.February, 29),
+			wantOK: true,
+		},
+		{
+			name:   "weekly on several days",
+			rule:   "FREQ=WEEKLY;BYDAY=MO,TH",
+			start:  date(2025, time.March, 3), // a Monday
+			after:  date(2025, time.March, 3),
+			want:   date(2025, time.March, 6),
+			wantOK: true,
+		},
+		{
+			name:   "every other week",
+			rule:   "FREQ=WEEKLY;INTERVAL=2",
+			start:  date(2025, time.March, 5),
+			after:  date(2025, time.March, 5),
+			want:   date(2025, time.March, 19),
+			wantOK: true,
+		},
+		{
+			name:   "yearly from a leap day",
+			rule:   "FREQ=YEARLY",
+			start:  date(2024, time.February, 29),
+			after:  date(2024, time.February, 29),
+			want:   date(2025, time.February, 28),
+			wantOK: true,
+		},
+		{
+			name:   "catches up from far behind",
+			rule:   "FREQ=DAILY;INTERVAL=10",
+			start:  date(2025, time.January, 1),
+			after:  date(2025, time.March, 1),
+			want:   date(2025, time.March, 2),
+			wantOK: true,
+		},
+		{
+			name:   "until ends the rule",
+			rule:   "FREQ=MONTHLY;UNTIL=20250301",
+			start:  date(2025, time.January, 15),
+			after:  date(2025, time.February, 15),
+			wantOK: false,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			rule, err := Parse(tt.rule)
+			assert.NoError(t, err)
+
+			next, ok := rule.Next(tt.start, tt.after)
+
+			assert.Equal(t, tt.wantOK, ok)
+			if tt.wantOK {
+				assert.Equal(t, tt.want, next)
+			}
+		})
+	}
+}
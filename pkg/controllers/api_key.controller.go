@@ -0,0 +1,97 @@
+package controllers
+
+import (
+	"errors"
+	"net/http"
+	"strconv"
+
+	"github.com/Adebayobenjamin/numerisbook/pkg/common"
+	"github.com/Adebayobenjamin/numerisbook/pkg/common/exceptions"
+	controller_interfaces "github.com/Adebayobenjamin/numerisbook/pkg/controllers/interfaces"
+	request_dto "github.com/Adebayobenjamin/numerisbook/pkg/dtos/request"
+	"github.com/Adebayobenjamin/numerisbook/pkg/helper"
+	services_interfaces "github.com/Adebayobenjamin/numerisbook/pkg/services/interfaces"
+	"github.com/gin-gonic/gin"
+	"github.com/rs/zerolog"
+)
+
+type apiKeyController struct {
+	logger        *zerolog.Logger
+	apiKeyService services_interfaces.APIKeyService
+}
+
+// Create implements controller_interfaces.APIKeyController.
+func (a *apiKeyController) Create(ctx *gin.Context) {
+	var request request_dto.CreateAPIKeyRequest
+	if err := ctx.ShouldBindJSON(&request); err != nil {
+		exceptions.ThrowUnProcessableEntityException(ctx, err.Error())
+		return
+	}
+
+	customerID, err := helper.GetCustomerIDFromContext(ctx)
+	if err != nil {
+		exceptions.ThrowBadRequestException(ctx, err.Error())
+		return
+	}
+
+	key, err := a.apiKeyService.CreateKey(ctx, customerID, &request)
+	if err != nil {
+		exceptions.ThrowBadRequestException(ctx, err.Error())
+		return
+	}
+
+	ctx.JSON(http.StatusCreated, common.BuildSuccessResponse("api key created successfully, store it now as it will not be shown again", key))
+}
+
+// GetCustomerKeys implements controller_interfaces.APIKeyController.
+func (a *apiKeyController) GetCustomerKeys(ctx *gin.Context) {
+	customerID, err := helper.GetCustomerIDFromContext(ctx)
+	if err != nil {
+		exceptions.ThrowBadRequestException(ctx, err.Error())
+		return
+	}
+
+	keys, err := a.apiKeyService.GetCustomerKeys(ctx, customerID)
+	if err != nil {
+		exceptions.ThrowBadRequestException(ctx, err.Error())
+		return
+	}
+
+	ctx.JSON(http.StatusOK, common.BuildSuccessResponse("api keys fetched successfully", keys))
+}
+
+// Revoke implements controller_interfaces.APIKeyController.
+func (a *apiKeyController) Revoke(ctx *gin.Context) {
+	customerID, err := helper.GetCustomerIDFromContext(ctx)
+	if err != nil {
+		exceptions.ThrowBadRequestException(ctx, err.Error())
+		return
+	}
+
+	keyID, err := strconv.ParseUint(ctx.Param("api_key_id"), 10, 64)
+	if err != nil {
+		exceptions.ThrowUnProcessableEntityException(ctx, "invalid api key id")
+		return
+	}
+
+	if err := a.apiKeyService.RevokeKey(ctx, uint(keyID), customerID); err != nil {
+		if errors.Is(err, exceptions.ErrNotFound) {
+			exceptions.ThrowNotFoundException(ctx, err.Error())
+			return
+		}
+		exceptions.ThrowBadRequestException(ctx, err.Error())
+		return
+	}
+
+	ctx.JSON(http.StatusOK, common.BuildSuccessResponse("api key revoked successfully", nil))
+}
+
+func NewAPIKeyController(
+	logger *zerolog.Logger,
+	apiKeyService services_interfaces.APIKeyService,
+) controller_interfaces.APIKeyController {
+	return &apiKeyController{
+		logger:        logger,
+		apiKeyService: apiKeyService,
+	}
+}
@@ -0,0 +1,170 @@
+package controllers
+
+import (
+	"errors"
+	"net/http"
+	"strconv"
+
+	"github.com/Adebayobenjamin/numerisbook/pkg/auth"
+	"github.com/Adebayobenjamin/numerisbook/pkg/common"
+	"github.com/Adebayobenjamin/numerisbook/pkg/common/exceptions"
+	controller_interfaces "github.com/Adebayobenjamin/numerisbook/pkg/controllers/interfaces"
+	request_dto "github.com/Adebayobenjamin/numerisbook/pkg/dtos/request"
+	"github.com/Adebayobenjamin/numerisbook/pkg/helper"
+	services_interfaces "github.com/Adebayobenjamin/numerisbook/pkg/services/interfaces"
+	"github.com/gin-gonic/gin"
+	"github.com/rs/zerolog"
+)
+
+type bankAccountController struct {
+	logger             *zerolog.Logger
+	bankAccountService services_interfaces.BankAccountService
+}
+
+// Create implements controller_interfaces.BankAccountController.
+func (b *bankAccountController) Create(ctx *gin.Context) {
+	var request request_dto.CreateBankAccountRequest
+	if err := ctx.ShouldBindJSON(&request); err != nil {
+		exceptions.ThrowUnProcessableEntityException(ctx, err.Error())
+		return
+	}
+
+	customerID, err := helper.GetCustomerIDFromContext(ctx)
+	if err != nil {
+		exceptions.ThrowBadRequestException(ctx, err.Error())
+		return
+	}
+
+	account, err := b.bankAccountService.CreateBankAccount(ctx, customerID, &request)
+	if err != nil {
+		b.throwServiceError(ctx, err)
+		return
+	}
+
+	ctx.JSON(http.StatusCreated, common.BuildSuccessResponse("bank account created successfully", account))
+}
+
+// GetCustomerBankAccounts implements controller_interfaces.BankAccountController.
+func (b *bankAccountController) GetCustomerBankAccounts(ctx *gin.Context) {
+	customerID, err := helper.GetCustomerIDFromContext(ctx)
+	if err != nil {
+		exceptions.ThrowBadRequestException(ctx, err.Error())
+		return
+	}
+
+	accounts, err := b.bankAccountService.GetBankAccounts(ctx, customerID)
+	if err != nil {
+		b.throwServiceError(ctx, err)
+		return
+	}
+
+	ctx.JSON(http.StatusOK, common.BuildSuccessResponse("bank accounts fetched successfully", accounts))
+}
+
+// GetDetails implements controller_interfaces.BankAccountController.
+func (b *bankAccountController) GetDetails(ctx *gin.Context) {
+	customerID, accountID, err := b.getAccountIDFromParams(ctx)
+	if err != nil {
+		exceptions.ThrowUnProcessableEntityException(ctx, err.Error())
+		return
+	}
+
+	account, err := b.bankAccountService.GetBankAccountByIDAndCustomer(ctx, accountID, customerID)
+	if err != nil {
+		b.throwServiceError(ctx, err)
+		return
+	}
+
+	ctx.JSON(http.StatusOK, common.BuildSuccessResponse("bank account fetched successfully", account))
+}
+
+// Update implements controller_interfaces.BankAccountController.
+func (b *bankAccountController) Update(ctx *gin.Context) {
+	var request request_dto.UpdateBankAccountRequest
+	if err := ctx.ShouldBindJSON(&request); err != nil {
+		exceptions.ThrowUnProcessableEntityException(ctx, err.Error())
+		return
+	}
+
+	customerID, accountID, err := b.getAccountIDFromParams(ctx)
+	if err != nil {
+		exceptions.ThrowUnProcessableEntityException(ctx, err.Error())
+		return
+	}
+
+	account, err := b.bankAccountService.UpdateBankAccount(ctx, accountID, customerID, &request)
+	if err != nil {
+		b.throwServiceError(ctx, err)
+		return
+	}
+
+	ctx.JSON(http.StatusOK, common.BuildSuccessResponse("bank account updated successfully", account))
+}
+
+// Delete implements controller_interfaces.BankAccountController.
+func (b *bankAccountController) Delete(ctx *gin.Context) {
+	customerID, accountID, err := b.getAccountIDFromParams(ctx)
+	if err != nil {
+		exceptions.ThrowUnProcessableEntityException(ctx, err.Error())
+		return
+	}
+
+	if err := b.bankAccountService.DeleteBankAccount(ctx, accountID, customerID); err != nil {
+		b.throwServiceError(ctx, err)
+		return
+	}
+
+	ctx.JSON(http.StatusOK, common.BuildSuccessResponse("bank account deleted successfully", nil))
+}
+
+// SetDefault implements controller_interfaces.BankAccountController.
+func (b *bankAccountController) SetDefault(ctx *gin.Context) {
+	customerID, accountID, err := b.getAccountIDFromParams(ctx)
+	if err != nil {
+		exceptions.ThrowUnProcessableEntityException(ctx, err.Error())
+		return
+	}
+
+	account, err := b.bankAccountService.SetDefaultBankAccount(ctx, accountID, customerID)
+	if err != nil {
+		b.throwServiceError(ctx, err)
+		return
+	}
+
+	ctx.JSON(http.StatusOK, common.BuildSuccessResponse("default bank account set successfully", account))
+}
+
+func (b *bankAccountController) getAccountIDFromParams(ctx *gin.Context) (uint, uint, error) {
+	customerID, err := helper.GetCustomerIDFromContext(ctx)
+	if err != nil {
+		return 0, 0, err
+	}
+
+	accountID, err := strconv.ParseUint(ctx.Param("bank_account_id"), 10, 64)
+	if err != nil {
+		return 0, 0, errors.New("invalid bank account id")
+	}
+
+	return customerID, uint(accountID), nil
+}
+
+func (b *bankAccountController) throwServiceError(ctx *gin.Context, err error) {
+	switch {
+	case errors.Is(err, exceptions.ErrNotFound):
+		exceptions.ThrowNotFoundException(ctx, err.Error())
+	case errors.Is(err, auth.ErrForbidden):
+		exceptions.ThrowForbiddenException(ctx, err.Error())
+	default:
+		exceptions.ThrowBadRequestException(ctx, err.Error())
+	}
+}
+
+func NewBankAccountController(
+	logger *zerolog.Logger,
+	bankAccountService services_interfaces.BankAccountService,
+) controller_interfaces.BankAccountController {
+	return &bankAccountController{
+		logger:             logger,
+		bankAccountService: bankAccountService,
+	}
+}
@@ -0,0 +1,170 @@
+package controllers
+
+import (
+	"errors"
+	"net/http"
+	"strconv"
+
+	"github.com/Adebayobenjamin/numerisbook/pkg/auth"
+	"github.com/Adebayobenjamin/numerisbook/pkg/common"
+	"github.com/Adebayobenjamin/numerisbook/pkg/common/exceptions"
+	controller_interfaces "github.com/Adebayobenjamin/numerisbook/pkg/controllers/interfaces"
+	request_dto "github.com/Adebayobenjamin/numerisbook/pkg/dtos/request"
+	"github.com/Adebayobenjamin/numerisbook/pkg/helper"
+	services_interfaces "github.com/Adebayobenjamin/numerisbook/pkg/services/interfaces"
+	"github.com/gin-gonic/gin"
+	"github.com/rs/zerolog"
+)
+
+type businessProfileController struct {
+	logger                 *zerolog.Logger
+	businessProfileService services_interfaces.BusinessProfileService
+}
+
+// Create implements controller_interfaces.BusinessProfileController.
+func (b *businessProfileController) Create(ctx *gin.Context) {
+	var request request_dto.CreateBusinessProfileRequest
+	if err := ctx.ShouldBindJSON(&request); err != nil {
+		exceptions.ThrowUnProcessableEntityException(ctx, err.Error())
+		return
+	}
+
+	customerID, err := helper.GetCustomerIDFromContext(ctx)
+	if err != nil {
+		exceptions.ThrowBadRequestException(ctx, err.Error())
+		return
+	}
+
+	profile, err := b.businessProfileService.CreateBusinessProfile(ctx, customerID, &request)
+	if err != nil {
+		b.throwServiceError(ctx, err)
+		return
+	}
+
+	ctx.JSON(http.StatusCreated, common.BuildSuccessResponse("business profile created successfully", profile))
+}
+
+// GetCustomerBusinessProfiles implements controller_interfaces.BusinessProfileController.
+func (b *businessProfileController) GetCustomerBusinessProfiles(ctx *gin.Context) {
+	customerID, err := helper.GetCustomerIDFromContext(ctx)
+	if err != nil {
+		exceptions.ThrowBadRequestException(ctx, err.Error())
+		return
+	}
+
+	profiles, err := b.businessProfileService.GetBusinessProfiles(ctx, customerID)
+	if err != nil {
+		b.throwServiceError(ctx, err)
+		return
+	}
+
+	ctx.JSON(http.StatusOK, common.BuildSuccessResponse("business profiles fetched successfully", profiles))
+}
+
+// GetDetails implements controller_interfaces.BusinessProfileController.
+func (b *businessProfileController) GetDetails(ctx *gin.Context) {
+	customerID, profileID, err := b.getProfileIDFromParams(ctx)
+	if err != nil {
+		exceptions.ThrowUnProcessableEntityException(ctx, err.Error())
+		return
+	}
+
+	profile, err := b.businessProfileService.GetBusinessProfileByIDAndCustomer(ctx, profileID, customerID)
+	if err != nil {
+		b.throwServiceError(ctx, err)
+		return
+	}
+
+	ctx.JSON(http.StatusOK, common.BuildSuccessResponse("business profile fetched successfully", profile))
+}
+
+// Update implements controller_interfaces.BusinessProfileController.
+func (b *businessProfileController) Update(ctx *gin.Context) {
+	var request request_dto.UpdateBusinessProfileRequest
+	if err := ctx.ShouldBindJSON(&request); err != nil {
+		exceptions.ThrowUnProcessableEntityException(ctx, err.Error())
+		return
+	}
+
+	customerID, profileID, err := b.getProfileIDFromParams(ctx)
+	if err != nil {
+		exceptions.ThrowUnProcessableEntityException(ctx, err.Error())
+		return
+	}
+
+	profile, err := b.businessProfileService.UpdateBusinessProfile(ctx, profileID, customerID, &request)
+	if err != nil {
+		b.throwServiceError(ctx, err)
+		return
+	}
+
+	ctx.JSON(http.StatusOK, common.BuildSuccessResponse("business profile updated successfully", profile))
+}
+
+// Delete implements controller_interfaces.BusinessProfileController.
+func (b *businessProfileController) Delete(ctx *gin.Context) {
+	customerID, profileID, err := b.getProfileIDFromParams(ctx)
+	if err != nil {
+		exceptions.ThrowUnProcessableEntityException(ctx, err.Error())
+		return
+	}
+
+	if err := b.businessProfileService.DeleteBusinessProfile(ctx, profileID, customerID); err != nil {
+		b.throwServiceError(ctx, err)
+		return
+	}
+
+	ctx.JSON(http.StatusOK, common.BuildSuccessResponse("business profile deleted successfully", nil))
+}
+
+// SetDefault implements controller_interfaces.BusinessProfileController.
+func (b *businessProfileController) SetDefault(ctx *gin.Context) {
+	customerID, profileID, err := b.getProfileIDFromParams(ctx)
+	if err != nil {
+		exceptions.ThrowUnProcessableEntityException(ctx, err.Error())
+		return
+	}
+
+	profile, err := b.businessProfileService.SetDefaultBusinessProfile(ctx, profileID, customerID)
+	if err != nil {
+		b.throwServiceError(ctx, err)
+		return
+	}
+
+	ctx.JSON(http.StatusOK, common.BuildSuccessResponse("default business profile set successfully", profile))
+}
+
+func (b *businessProfileController) getProfileIDFromParams(ctx *gin.Context) (uint, uint, error) {
+	customerID, err := helper.GetCustomerIDFromContext(ctx)
+	if err != nil {
+		return 0, 0, err
+	}
+
+	profileID, err := strconv.ParseUint(ctx.Param("business_profile_id"), 10, 64)
+	if err != nil {
+		return 0, 0, errors.New("invalid business profile id")
+	}
+
+	return customerID, uint(profileID), nil
+}
+
+func (b *businessProfileController) throwServiceError(ctx *gin.Context, err error) {
+	switch {
+	case errors.Is(err, exceptions.ErrNotFound):
+		exceptions.ThrowNotFoundException(ctx, err.Error())
+	case errors.Is(err, auth.ErrForbidden):
+		exceptions.ThrowForbiddenException(ctx, err.Error())
+	default:
+		exceptions.ThrowBadRequestException(ctx, err.Error())
+	}
+}
+
+func NewBusinessProfileController(
+	logger *zerolog.Logger,
+	businessProfileService services_interfaces.BusinessProfileService,
+) controller_interfaces.BusinessProfileController {
+	return &businessProfileController{
+		logger:                 logger,
+		businessProfileService: businessProfileService,
+	}
+}
@@ -0,0 +1,182 @@
+package controllers
+
+import (
+	"errors"
+	"net/http"
+	"strconv"
+
+	"github.com/Adebayobenjamin/numerisbook/pkg/auth"
+	"github.com/Adebayobenjamin/numerisbook/pkg/common"
+	"github.com/Adebayobenjamin/numerisbook/pkg/common/exceptions"
+	controller_interfaces "github.com/Adebayobenjamin/numerisbook/pkg/controllers/interfaces"
+	request_dto "github.com/Adebayobenjamin/numerisbook/pkg/dtos/request"
+	"github.com/Adebayobenjamin/numerisbook/pkg/helper"
+	services_interfaces "github.com/Adebayobenjamin/numerisbook/pkg/services/interfaces"
+	"github.com/gin-gonic/gin"
+	"github.com/rs/zerolog"
+)
+
+type catalogItemController struct {
+	logger             *zerolog.Logger
+	catalogItemService services_interfaces.CatalogItemService
+}
+
+// Create implements controller_interfaces.CatalogItemController.
+func (c *catalogItemController) Create(ctx *gin.Context) {
+	var request request_dto.CreateCatalogItemRequest
+	if err := ctx.ShouldBindJSON(&request); err != nil {
+		exceptions.ThrowUnProcessableEntityException(ctx, err.Error())
+		return
+	}
+
+	customerID, err := helper.GetCustomerIDFromContext(ctx)
+	if err != nil {
+		exceptions.ThrowBadRequestException(ctx, err.Error())
+		return
+	}
+
+	item, err := c.catalogItemService.CreateCatalogItem(ctx, customerID, &request)
+	if err != nil {
+		c.throwServiceError(ctx, err)
+		return
+	}
+
+	ctx.JSON(http.StatusCreated, common.BuildSuccessResponse("catalog item created successfully", item))
+}
+
+// GetCustomerCatalogItems implements controller_interfaces.CatalogItemController.
+func (c *catalogItemController) GetCustomerCatalogItems(ctx *gin.Context) {
+	var request request_dto.GetAllCatalogItemsRequest
+	if err := ctx.ShouldBindQuery(&request); err != nil {
+		exceptions.ThrowUnProcessableEntityException(ctx, err.Error())
+		return
+	}
+
+	customerID, err := helper.GetCustomerIDFromContext(ctx)
+	if err != nil {
+		exceptions.ThrowBadRequestException(ctx, err.Error())
+		return
+	}
+
+	items, err := c.catalogItemService.GetCatalogItems(ctx, customerID, &request)
+	if err != nil {
+		c.throwServiceError(ctx, err)
+		return
+	}
+
+	ctx.JSON(http.StatusOK, common.BuildSuccessResponse("catalog items fetched successfully", items))
+}
+
+// GetDetails implements controller_interfaces.CatalogItemController.
+func (c *catalogItemController) GetDetails(ctx *gin.Context) {
+	customerID, itemID, err := c.getCatalogItemIDFromParams(ctx)
+	if err != nil {
+		exceptions.ThrowUnProcessableEntityException(ctx, err.Error())
+		return
+	}
+
+	item, err := c.catalogItemService.GetCatalogItemByIDAndCustomer(ctx, itemID, customerID)
+	if err != nil {
+		c.throwServiceError(ctx, err)
+		return
+	}
+
+	ctx.JSON(http.StatusOK, common.BuildSuccessResponse("catalog item fetched successfully", item))
+}
+
+// Update implements controller_interfaces.CatalogItemController.
+func (c *catalogItemController) Update(ctx *gin.Context) {
+	var request request_dto.UpdateCatalogItemRequest
+	if err := ctx.ShouldBindJSON(&request); err != nil {
+		exceptions.ThrowUnProcessableEntityException(ctx, err.Error())
+		return
+	}
+
+	customerID, itemID, err := c.getCatalogItemIDFromParams(ctx)
+	if err != nil {
+		exceptions.ThrowUnProcessableEntityException(ctx, err.Error())
+		return
+	}
+
+	item, err := c.catalogItemService.UpdateCatalogItem(ctx, itemID, customerID, &request)
+	if err != nil {
+		c.throwServiceError(ctx, err)
+		return
+	}
+
+	ctx.JSON(http.StatusOK, common.BuildSuccessResponse("catalog item updated successfully", item))
+}
+
+// Delete implements controller_interfaces.CatalogItemController.
+func (c *catalogItemController) Delete(ctx *gin.Context) {
+	customerID, itemID, err := c.getCatalogItemIDFromParams(ctx)
+	if err != nil {
+		exceptions.ThrowUnProcessableEntityException(ctx, err.Error())
+		return
+	}
+
+	if err := c.catalogItemService.DeleteCatalogItem(ctx, itemID, customerID); err != nil {
+		c.throwServiceError(ctx, err)
+		return
+	}
+
+	ctx.JSON(http.StatusOK, common.BuildSuccessResponse("catalog item deleted successfully", nil))
+}
+
+// GetRevenue implements controller_interfaces.CatalogItemController.
+func (c *catalogItemController) GetRevenue(ctx *gin.Context) {
+	var request request_dto.GetCatalogRevenueRequest
+	if err := ctx.ShouldBindQuery(&request); err != nil {
+		exceptions.ThrowUnProcessableEntityException(ctx, err.Error())
+		return
+	}
+
+	customerID, err := helper.GetCustomerIDFromContext(ctx)
+	if err != nil {
+		exceptions.ThrowBadRequestException(ctx, err.Error())
+		return
+	}
+
+	revenue, err := c.catalogItemService.GetCatalogRevenue(ctx, customerID, &request)
+	if err != nil {
+		c.throwServiceError(ctx, err)
+		return
+	}
+
+	ctx.JSON(http.StatusOK, common.BuildSuccessResponse("catalog revenue fetched successfully", revenue))
+}
+
+func (c *catalogItemController) getCatalogItemIDFromParams(ctx *gin.Context) (uint, uint, error) {
+	customerID, err := helper.GetCustomerIDFromContext(ctx)
+	if err != nil {
+		return 0, 0, err
+	}
+
+	itemID, err := strconv.ParseUint(ctx.Param("catalog_item_id"), 10, 64)
+	if err != nil {
+		return 0, 0, errors.New("invalid catalog item id")
+	}
+
+	return customerID, uint(itemID), nil
+}
+
+func (c *catalogItemController) throwServiceError(ctx *gin.Context, err error) {
+	switch {
+	case errors.Is(err, exceptions.ErrNotFound):
+		exceptions.ThrowNotFoundException(ctx, err.Error())
+	case errors.Is(err, auth.ErrForbidden):
+		exceptions.ThrowForbiddenException(ctx, err.Error())
+	default:
+		exceptions.ThrowBadRequestException(ctx, err.Error())
+	}
+}
+
+func NewCatalogItemController(
+	logger *zerolog.Logger,
+	catalogItemService services_interfaces.CatalogItemService,
+) controller_interfaces.CatalogItemController {
+	return &catalogItemController{
+		logger:             logger,
+		catalogItemService: catalogItemService,
+	}
+}
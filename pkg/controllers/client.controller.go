@@ -0,0 +1,176 @@
+package controllers
+
+import (
+	"errors"
+	"net/http"
+	"strconv"
+
+	"github.com/Adebayobenjamin/numerisbook/pkg/auth"
+	"github.com/Adebayobenjamin/numerisbook/pkg/common"
+	"github.com/Adebayobenjamin/numerisbook/pkg/common/exceptions"
+	controller_interfaces "github.com/Adebayobenjamin/numerisbook/pkg/controllers/interfaces"
+	request_dto "github.com/Adebayobenjamin/numerisbook/pkg/dtos/request"
+	"github.com/Adebayobenjamin/numerisbook/pkg/helper"
+	services_interfaces "github.com/Adebayobenjamin/numerisbook/pkg/services/interfaces"
+	"github.com/gin-gonic/gin"
+	"github.com/rs/zerolog"
+)
+
+type clientController struct {
+	logger        *zerolog.Logger
+	clientService services_interfaces.ClientService
+}
+
+// Create implements controller_interfaces.ClientController.
+func (c *clientController) Create(ctx *gin.Context) {
+	var request request_dto.CreateClientRequest
+	if err := ctx.ShouldBindJSON(&request); err != nil {
+		exceptions.ThrowUnProcessableEntityException(ctx, err.Error())
+		return
+	}
+
+	customerID, err := helper.GetCustomerIDFromContext(ctx)
+	if err != nil {
+		exceptions.ThrowBadRequestException(ctx, err.Error())
+		return
+	}
+
+	client, err := c.clientService.CreateClient(ctx, customerID, &request)
+	if err != nil {
+		c.throwServiceError(ctx, err)
+		return
+	}
+
+	ctx.JSON(http.StatusCreated, common.BuildSuccessResponse("client created successfully", client))
+}
+
+// GetCustomerClients implements controller_interfaces.ClientController.
+func (c *clientController) GetCustomerClients(ctx *gin.Context) {
+	var request request_dto.GetAllClientsRequest
+	if err := ctx.ShouldBindQuery(&request); err != nil {
+		exceptions.ThrowUnProcessableEntityException(ctx, err.Error())
+		return
+	}
+
+	customerID, err := helper.GetCustomerIDFromContext(ctx)
+	if err != nil {
+		exceptions.ThrowBadRequestException(ctx, err.Error())
+		return
+	}
+
+	clients, err := c.clientService.GetClients(ctx, customerID, &request)
+	if err != nil {
+		c.throwServiceError(ctx, err)
+		return
+	}
+
+	ctx.JSON(http.StatusOK, common.BuildSuccessResponse("clients fetched successfully", clients))
+}
+
+// GetDetails implements controller_interfaces.ClientController.
+func (c *clientController) GetDetails(ctx *gin.Context) {
+	customerID, clientID, err := c.getClientIDFromParams(ctx)
+	if err != nil {
+		exceptions.ThrowUnProcessableEntityException(ctx, err.Error())
+		return
+	}
+
+	client, err := c.clientService.GetClientByIDAndCustomer(ctx, clientID, customerID)
+	if err != nil {
+		c.throwServiceError(ctx, err)
+		return
+	}
+
+	ctx.JSON(http.StatusOK, common.BuildSuccessResponse("client fetched successfully", client))
+}
+
+// Update implements controller_interfaces.ClientController.
+func (c *clientController) Update(ctx *gin.Context) {
+	var request request_dto.UpdateClientRequest
+	if err := ctx.ShouldBindJSON(&request); err != nil {
+		exceptions.ThrowUnProcessableEntityException(ctx, err.Error())
+		return
+	}
+
+	customerID, clientID, err := c.getClientIDFromParams(ctx)
+	if err != nil {
+		exceptions.ThrowUnProcessableEntityException(ctx, err.Error())
+		return
+	}
+
+	client, err := c.clientService.UpdateClient(ctx, clientID, customerID, &request)
+	if err != nil {
+		c.throwServiceError(ctx, err)
+		return
+	}
+
+	ctx.JSON(http.StatusOK, common.BuildSuccessResponse("client updated successfully", client))
+}
+
+// Delete implements controller_interfaces.ClientController.
+func (c *clientController) Delete(ctx *gin.Context) {
+	customerID, clientID, err := c.getClientIDFromParams(ctx)
+	if err != nil {
+		exceptions.ThrowUnProcessableEntityException(ctx, err.Error())
+		return
+	}
+
+	if err := c.clientService.DeleteClient(ctx, clientID, customerID); err != nil {
+		c.throwServiceError(ctx, err)
+		return
+	}
+
+	ctx.JSON(http.StatusOK, common.BuildSuccessResponse("client deleted successfully", nil))
+}
+
+// Restore implements controller_interfaces.ClientController.
+func (c *clientController) Restore(ctx *gin.Context) {
+	customerID, clientID, err := c.getClientIDFromParams(ctx)
+	if err != nil {
+		exceptions.ThrowUnProcessableEntityException(ctx, err.Error())
+		return
+	}
+
+	client, err := c.clientService.RestoreClient(ctx, clientID, customerID)
+	if err != nil {
+		c.throwServiceError(ctx, err)
+		return
+	}
+
+	ctx.JSON(http.StatusOK, common.BuildSuccessResponse("client restored successfully", client))
+}
+
+func (c *clientController) getClientIDFromParams(ctx *gin.Context) (uint, uint, error) {
+	customerID, err := helper.GetCustomerIDFromContext(ctx)
+	if err != nil {
+		return 0, 0, err
+	}
+
+	clientID, err := strconv.ParseUint(ctx.Param("client_id"), 10, 64)
+	if err != nil {
+		return 0, 0, errors.New("invalid client id")
+	}
+
+	return customerID, uint(clientID), nil
+}
+
+func (c *clientController) throwServiceError(ctx *gin.Context, err error) {
+	switch {
+	case errors.Is(err, exceptions.ErrNotFound):
+		exceptions.ThrowNotFoundException(ctx, err.Error())
+	case errors.Is(err, auth.ErrForbidden):
+		exceptions.ThrowForbiddenException(ctx, err.Error())
+	default:
+		exceptions.ThrowBadRequestException(ctx, err.Error())
+	}
+}
+
+func NewClientController(
+	logger *zerolog.Logger,
+	clientService services_interfaces.ClientService,
+) controller_interfaces.ClientController {
+	return &clientController{
+		logger:        logger,
+		clientService: clientService,
+	}
+}
@@ -0,0 +1,107 @@
+package controllers
+
+import (
+	"errors"
+	"net/http"
+	"strconv"
+
+	"github.com/Adebayobenjamin/numerisbook/pkg/auth"
+	"github.com/Adebayobenjamin/numerisbook/pkg/common"
+	"github.com/Adebayobenjamin/numerisbook/pkg/common/exceptions"
+	controller_interfaces "github.com/Adebayobenjamin/numerisbook/pkg/controllers/interfaces"
+	request_dto "github.com/Adebayobenjamin/numerisbook/pkg/dtos/request"
+	"github.com/Adebayobenjamin/numerisbook/pkg/helper"
+	services_interfaces "github.com/Adebayobenjamin/numerisbook/pkg/services/interfaces"
+	"github.com/gin-gonic/gin"
+	"github.com/rs/zerolog"
+)
+
+type clientCreditController struct {
+	logger              *zerolog.Logger
+	clientCreditService services_interfaces.ClientCreditService
+}
+
+// GetClientCredits implements controller_interfaces.ClientCreditController.
+func (c *clientCreditController) GetClientCredits(ctx *gin.Context) {
+	var request request_dto.GetClientCreditsRequest
+	if err := ctx.ShouldBindQuery(&request); err != nil {
+		exceptions.ThrowUnProcessableEntityException(ctx, err.Error())
+		return
+	}
+
+	customerID, clientID, err := c.getClientIDFromParams(ctx)
+	if err != nil {
+		exceptions.ThrowUnProcessableEntityException(ctx, err.Error())
+		return
+	}
+
+	credits, err := c.clientCreditService.GetClientCredits(ctx, clientID, customerID, &request)
+	if err != nil {
+		c.throwServiceError(ctx, err)
+		return
+	}
+
+	ctx.JSON(http.StatusOK, common.BuildSuccessResponse("client credits fetched successfully", credits))
+}
+
+// ApplyCredit implements controller_interfaces.ClientCreditController.
+func (c *clientCreditController) ApplyCredit(ctx *gin.Context) {
+	var request request_dto.ApplyClientCreditRequest
+	if err := ctx.ShouldBindJSON(&request); err != nil {
+		exceptions.ThrowUnProcessableEntityException(ctx, err.Error())
+		return
+	}
+
+	customerID, clientID, err := c.getClientIDFromParams(ctx)
+	if err != nil {
+		exceptions.ThrowUnProcessableEntityException(ctx, err.Error())
+		return
+	}
+
+	payment, err := c.clientCreditService.ApplyCredit(ctx, clientID, customerID, &request)
+	if err != nil {
+		c.throwServiceError(ctx, err)
+		return
+	}
+
+	ctx.JSON(http.StatusCreated, common.BuildSuccessResponse("client credit applied successfully", payment))
+}
+
+func (c *clientCreditController) getClientIDFromParams(ctx *gin.Context) (uint, uint, error) {
+	customerID, err := helper.GetCustomerIDFromContext(ctx)
+	if err != nil {
+		return 0, 0, err
+	}
+
+	clientID, err := strconv.ParseUint(ctx.Param("client_id"), 10, 64)
+	if err != nil {
+		return 0, 0, errors.New("invalid client id")
+	}
+
+	return customerID, uint(clientID), nil
+}
+
+func (c *clientCreditController) throwServiceError(ctx *gin.Context, err error) {
+	var transitionErr *exceptions.InvalidStatusTransitionError
+	switch {
+	case errors.Is(err, exceptions.ErrNotFound):
+		exceptions.ThrowNotFoundException(ctx, err.Error())
+	case errors.Is(err, auth.ErrForbidden):
+		exceptions.ThrowForbiddenException(ctx, err.Error())
+	case errors.As(err, &transitionErr):
+		// the invoice was paid, voided or written off
+		exceptions.ThrowConflictException(ctx, err.Error())
+	default:
+		exceptions.ThrowBadRequestException(ctx, err.Error())
+	}
+}
+
+func NewClientCreditController(
+	logger *zerolog.Logger,
+	clientCreditService services_interfaces.ClientCreditService,
+) controller_interfaces.ClientCreditController {
+	return &clientCreditController{
+		logger:              logger,
+		clientCreditService: clientCreditService,
+	}
+}
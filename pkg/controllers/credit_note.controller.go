@@ -0,0 +1,146 @@
+package controllers
+
+import (
+	"errors"
+	"net/http"
+	"strconv"
+
+	"github.com/Adebayobenjamin/numerisbook/pkg/auth"
+	"github.com/Adebayobenjamin/numerisbook/pkg/common"
+	"github.com/Adebayobenjamin/numerisbook/pkg/common/exceptions"
+	controller_interfaces "github.com/Adebayobenjamin/numerisbook/pkg/controllers/interfaces"
+	request_dto "github.com/Adebayobenjamin/numerisbook/pkg/dtos/request"
+	"github.com/Adebayobenjamin/numerisbook/pkg/helper"
+	"github.com/Adebayobenjamin/numerisbook/pkg/models"
+	services_interfaces "github.com/Adebayobenjamin/numerisbook/pkg/services/interfaces"
+	"github.com/gin-gonic/gin"
+	"github.com/rs/zerolog"
+)
+
+type creditNoteController struct {
+	logger            *zerolog.Logger
+	creditNoteService services_interfaces.CreditNoteService
+	invoiceService    services_interfaces.InvoiceService
+}
+
+// Create implements controller_interfaces.CreditNoteController.
+func (c *creditNoteController) Create(ctx *gin.Context) {
+	var request request_dto.CreateCreditNoteRequest
+	if err := ctx.ShouldBindJSON(&request); err != nil {
+		exceptions.ThrowUnProcessableEntityException(ctx, err.Error())
+		return
+	}
+
+	invoice, err := c.getInvoiceFromParams(ctx)
+	if err != nil {
+		throwLookupError(ctx, err)
+		return
+	}
+
+	note, err := c.creditNoteService.CreateCreditNote(ctx, invoice, &request)
+	if err != nil {
+		c.throwServiceError(ctx, err)
+		return
+	}
+
+	ctx.JSON(http.StatusCreated, common.BuildSuccessResponse("credit note issued successfully", note))
+}
+
+// GetInvoiceCreditNotes implements controller_interfaces.CreditNoteController.
+func (c *creditNoteController) GetInvoiceCreditNotes(ctx *gin.Context) {
+	invoice, err := c.getInvoiceFromParams(ctx)
+	if err != nil {
+		throwLookupError(ctx, err)
+		return
+	}
+
+	notes, err := c.creditNoteService.GetInvoiceCreditNotes(ctx, invoice.ID)
+	if err != nil {
+		c.throwServiceError(ctx, err)
+		return
+	}
+
+	ctx.JSON(http.StatusOK, common.BuildSuccessResponse("credit notes fetched successfully", notes))
+}
+
+// GetCustomerCreditNotes implements controller_interfaces.CreditNoteController.
+func (c *creditNoteController) GetCustomerCreditNotes(ctx *gin.Context) {
+	var request request_dto.GetAllRequest
+	if err := ctx.ShouldBindQuery(&request); err != nil {
+		exceptions.ThrowUnProcessableEntityException(ctx, err.Error())
+		return
+	}
+
+	customerID, err := helper.GetCustomerIDFromContext(ctx)
+	if err != nil {
+		exceptions.ThrowBadRequestException(ctx, err.Error())
+		return
+	}
+
+	notes, err := c.creditNoteService.GetCustomerCreditNotes(ctx, customerID, &request)
+	if err != nil {
+		c.throwServiceError(ctx, err)
+		return
+	}
+
+	ctx.JSON(http.StatusOK, common.BuildSuccessResponse("credit notes fetched successfully", notes))
+}
+
+// GetDetails implements controller_interfaces.CreditNoteController.
+func (c *creditNoteController) GetDetails(ctx *gin.Context) {
+	customerID, err := helper.GetCustomerIDFromContext(ctx)
+	if err != nil {
+		exceptions.ThrowBadRequestException(ctx, err.Error())
+		return
+	}
+
+	creditNoteID, err := strconv.ParseUint(ctx.Param("credit_note_id"), 10, 64)
+	if err != nil {
+		exceptions.ThrowUnProcessableEntityException(ctx, "invalid credit note id")
+		return
+	}
+
+	note, err := c.creditNoteService.GetCreditNoteByIDAndCustomer(ctx, uint(creditNoteID), customerID)
+	if err != nil {
+		c.throwServiceError(ctx, err)
+		return
+	}
+
+	ctx.JSON(http.StatusOK, common.BuildSuccessResponse("credit note fetched successfully", note))
+}
+
+func (c *creditNoteController) getInvoiceFromParams(ctx *gin.Context) (*models.Invoice, error) {
+	invoiceID, err := strconv.ParseUint(ctx.Param("invoice_id"), 10, 64)
+	if err != nil {
+		return nil, errors.New("invalid invoice id")
+	}
+
+	return c.invoiceService.GetInvoiceByIDandCustomer(ctx, uint(invoiceID))
+}
+
+func (c *creditNoteController) throwServiceError(ctx *gin.Context, err error) {
+	switch {
+	case errors.Is(err, exceptions.ErrNotFound):
+		exceptions.ThrowNotFoundException(ctx, err.Error())
+	case errors.Is(err, auth.ErrForbidden):
+		exceptions.ThrowForbiddenException(ctx, err.Error())
+	case errors.Is(err, exceptions.ErrVersionMismatch):
+		// the invoice moved on while the credit note was worked out, there
+		// is no If-Match to fail so this is a plain conflict to retry
+		exceptions.ThrowConflictException(ctx, err.Error())
+	default:
+		exceptions.ThrowBadRequestException(ctx, err.Error())
+	}
+}
+
+func NewCreditNoteController(
+	logger *zerolog.Logger,
+	creditNoteService services_interfaces.CreditNoteService,
+	invoiceService services_interfaces.InvoiceService,
+) controller_interfaces.CreditNoteController {
+	return &creditNoteController{
+		logger:            logger,
+		creditNoteService: creditNoteService,
+		invoiceService:    invoiceService,
+	}
+}
@@ -0,0 +1,20 @@
+package controllers
+
+import (
+	"errors"
+
+	"github.com/Adebayobenjamin/numerisbook/pkg/common/exceptions"
+	"github.com/gin-gonic/gin"
+)
+
+// throwLookupError reports a record named in the path that could not be
+// loaded. Records that do not exist and records of another customer are
+// both reported as 404, so ids cannot be probed across customers.
+func throwLookupError(ctx *gin.Context, err error) {
+	if errors.Is(err, exceptions.ErrNotFound) {
+		exceptions.ThrowNotFoundException(ctx, err.Error())
+		return
+	}
+
+	exceptions.ThrowBadRequestException(ctx, err.Error())
+}
@@ -0,0 +1,9 @@
+package controller_interfaces
+
+import "github.com/gin-gonic/gin"
+
+type APIKeyController interface {
+	Create(ctx *gin.Context)
+	GetCustomerKeys(ctx *gin.Context)
+	Revoke(ctx *gin.Context)
+}
@@ -0,0 +1,12 @@
+package controller_interfaces
+
+import "github.com/gin-gonic/gin"
+
+type BusinessProfileController interface {
+	Create(ctx *gin.Context)
+	GetCustomerBusinessProfiles(ctx *gin.Context)
+	GetDetails(ctx *gin.Context)
+	Update(ctx *gin.Context)
+	Delete(ctx *gin.Context)
+	SetDefault(ctx *gin.Context)
+}
@@ -0,0 +1,12 @@
+package controller_interfaces
+
+import "github.com/gin-gonic/gin"
+
+type CatalogItemController interface {
+	Create(ctx *gin.Context)
+	GetCustomerCatalogItems(ctx *gin.Context)
+	GetDetails(ctx *gin.Context)
+	Update(ctx *gin.Context)
+	Delete(ctx *gin.Context)
+	GetRevenue(ctx *gin.Context)
+}
@@ -0,0 +1,12 @@
+package controller_interfaces
+
+import "github.com/gin-gonic/gin"
+
+type ClientController interface {
+	Create(ctx *gin.Context)
+	GetCustomerClients(ctx *gin.Context)
+	GetDetails(ctx *gin.Context)
+	Update(ctx *gin.Context)
+	Delete(ctx *gin.Context)
+	Restore(ctx *gin.Context)
+}
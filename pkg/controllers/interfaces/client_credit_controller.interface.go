@@ -0,0 +1,8 @@
+package controller_interfaces
+
+import "github.com/gin-gonic/gin"
+
+type ClientCreditController interface {
+	GetClientCredits(ctx *gin.Context)
+	ApplyCredit(ctx *gin.Context)
+}
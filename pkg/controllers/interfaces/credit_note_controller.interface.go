@@ -0,0 +1,10 @@
+package controller_interfaces
+
+import "github.com/gin-gonic/gin"
+
+type CreditNoteController interface {
+	Create(ctx *gin.Context)
+	GetInvoiceCreditNotes(ctx *gin.Context)
+	GetCustomerCreditNotes(ctx *gin.Context)
+	GetDetails(ctx *gin.Context)
+}
@@ -4,13 +4,19 @@ import "github.com/gin-gonic/gin"
 
 type InvoiceController interface {
 	Create(ctx *gin.Context)
+	Update(ctx *gin.Context)
+	Delete(ctx *gin.Context)
 	GetStatistics(ctx *gin.Context)
 	GetCustomerInvoices(ctx *gin.Context)
 	Duplicate(ctx *gin.Context)
-	GetShareableLink(ctx *gin.Context)
 	GetCustomerAuditTrails(ctx *gin.Context)
 	GetSingleInvoiceAuditTrails(ctx *gin.Context)
 	SetReminder(ctx *gin.Context)
 	GetDetails(ctx *gin.Context)
+	GetPDF(ctx *gin.Context)
 	ConfirmPayment(ctx *gin.Context)
+	Send(ctx *gin.Context)
+	Void(ctx *gin.Context)
+	Cancel(ctx *gin.Context)
+	WriteOff(ctx *gin.Context)
 }
@@ -0,0 +1,10 @@
+package controller_interfaces
+
+import "github.com/gin-gonic/gin"
+
+type PaymentController interface {
+	GetCustomerPayments(ctx *gin.Context)
+	GetDetails(ctx *gin.Context)
+	Refund(ctx *gin.Context)
+	Reverse(ctx *gin.Context)
+}
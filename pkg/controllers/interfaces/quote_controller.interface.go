@@ -0,0 +1,16 @@
+package controller_interfaces
+
+import "github.com/gin-gonic/gin"
+
+type QuoteController interface {
+	Create(ctx *gin.Context)
+	GetCustomerQuotes(ctx *gin.Context)
+	GetDetails(ctx *gin.Context)
+	Send(ctx *gin.Context)
+	Accept(ctx *gin.Context)
+	Decline(ctx *gin.Context)
+	Convert(ctx *gin.Context)
+	GetSharedQuote(ctx *gin.Context)
+	AcceptSharedQuote(ctx *gin.Context)
+	DeclineSharedQuote(ctx *gin.Context)
+}
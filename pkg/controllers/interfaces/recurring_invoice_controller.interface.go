@@ -0,0 +1,12 @@
+package controller_interfaces
+
+import "github.com/gin-gonic/gin"
+
+type RecurringInvoiceController interface {
+	Create(ctx *gin.Context)
+	GetCustomerProfiles(ctx *gin.Context)
+	GetDetails(ctx *gin.Context)
+	Pause(ctx *gin.Context)
+	Resume(ctx *gin.Context)
+	Cancel(ctx *gin.Context)
+}
@@ -0,0 +1,14 @@
+package controller_interfaces
+
+import "github.com/gin-gonic/gin"
+
+type SettingsController interface {
+	GetInvoiceNumbering(ctx *gin.Context)
+	UpdateInvoiceNumbering(ctx *gin.Context)
+	GetCreditNoteNumbering(ctx *gin.Context)
+	UpdateCreditNoteNumbering(ctx *gin.Context)
+	GetQuoteNumbering(ctx *gin.Context)
+	UpdateQuoteNumbering(ctx *gin.Context)
+	GetBranding(ctx *gin.Context)
+	UpdateBranding(ctx *gin.Context)
+}
@@ -0,0 +1,10 @@
+package controller_interfaces
+
+import "github.com/gin-gonic/gin"
+
+type ShareLinkController interface {
+	Get(ctx *gin.Context)
+	Regenerate(ctx *gin.Context)
+	Revoke(ctx *gin.Context)
+	GetSharedInvoice(ctx *gin.Context)
+}
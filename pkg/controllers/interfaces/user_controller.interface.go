@@ -0,0 +1,10 @@
+package controller_interfaces
+
+import "github.com/gin-gonic/gin"
+
+type UserController interface {
+	Add(ctx *gin.Context)
+	GetCustomerUsers(ctx *gin.Context)
+	ChangeRole(ctx *gin.Context)
+	Remove(ctx *gin.Context)
+}
@@ -5,7 +5,9 @@ import (
 	"fmt"
 	"net/http"
 	"strconv"
+	"strings"
 
+	"github.com/Adebayobenjamin/numerisbook/pkg/auth"
 	"github.com/Adebayobenjamin/numerisbook/pkg/common"
 	"github.com/Adebayobenjamin/numerisbook/pkg/common/exceptions"
 	controller_interfaces "github.com/Adebayobenjamin/numerisbook/pkg/controllers/interfaces"
@@ -23,65 +25,42 @@ type invoiceController struct {
 	auditService    services_interfaces.AuditService
 	reminderService services_interfaces.RemiderService
 	customerService services_interfaces.CustomerService
+	emailService    services_interfaces.InvoiceEmailService
 }
 
 // ConfirmPayment implements controller_interfaces.InvoiceController.
 func (i *invoiceController) ConfirmPayment(ctx *gin.Context) {
 	var request request_dto.PaymentConfirmationRequest
-	var err error
-	var invoice *models.Invoice
-
-	if err = ctx.ShouldBindJSON(&request); err != nil {
+	if err := ctx.ShouldBindJSON(&request); err != nil {
 		exceptions.ThrowUnProcessableEntityException(ctx, err.Error())
 		return
 	}
 
-	// get customer from context (set by middleware)
-	customer, err := i.getCustomerFromContext(ctx)
-	if err != nil {
-		exceptions.ThrowBadRequestException(ctx, err.Error())
-		return
-	}
-
 	// get invoice from params and fetch from db
-	invoice, err = i.getInvoiceDetailsFromParams(ctx, customer.ID)
+	invoice, err := i.getInvoiceDetailsFromParams(ctx)
 	if err != nil {
-		exceptions.ThrowBadRequestException(ctx, err.Error())
+		i.throwServiceError(ctx, err)
 		return
 	}
 
-	defer func() {
-		// audit payment confirmation
-		if err == nil && invoice != nil {
-			i.auditService.CreateAuditTrail(
-				ctx,
-				models.EventTypePaymentConfirmed,
-				models.LogLevelInfo,
-				fmt.Sprintf("Confirmed Payment for Invoice %s/%s", invoice.InvoiceNumber, customer.Name),
-				invoice.ID,
-				customer.ID,
-			)
-		}
-	}()
-
-	// TODO: call service to validate payment amount
-	err = i.invoiceService.ValidatePaymentAmount(ctx, request.Amount, invoice, request.IsPartial)
+	// the request only carries a number, the currency is always the invoice's
+	amount, err := request.Amount.WithCurrency(invoice.BillingCurrency)
 	if err != nil {
 		exceptions.ThrowBadRequestException(ctx, err.Error())
 		return
 	}
 
-	// TODO: call service to confirm payment
-	err = i.invoiceService.ConfirmPayment(ctx, invoice.ID, request.Amount, request.PaymentDate, request.IsPartial)
+	// validating the amount, recording the payment, settling the invoice and
+	// auditing all of it happen together
+	invoice, err = i.invoiceService.ConfirmPayment(ctx, request.Payment(invoice.ID, amount))
 	if err != nil {
-		exceptions.ThrowBadRequestException(ctx, err.Error())
+		i.throwServiceError(ctx, err)
 		return
 	}
 
-	err = i.invoiceService.SetInvoiceStatusIfFullyPaid(ctx, invoice)
-	if err != nil {
-		exceptions.ThrowBadRequestException(ctx, err.Error())
-		return
+	// the payment is already recorded, a receipt that fails to go out should not fail the request
+	if receiptErr := i.emailService.SendPaymentReceipt(ctx, invoice.ID, amount, request.PaymentDate); receiptErr != nil {
+		i.logger.Error().Err(receiptErr).Uint("invoice_id", invoice.ID).Msg("failed to email payment receipt")
 	}
 
 	ctx.JSON(http.StatusOK, common.BuildSuccessResponse("payment confirmed successfully", nil))
@@ -140,9 +119,77 @@ func (i *invoiceController) Create(ctx *gin.Context) {
 		}
 	}
 
+	ctx.Header("ETag", invoiceETag(invoice.Version))
 	ctx.JSON(http.StatusCreated, common.BuildSuccessResponse("invoice created successfully", invoice))
 }
 
+// Update implements controller_interfaces.InvoiceController.
+func (i *invoiceController) Update(ctx *gin.Context) {
+	var request request_dto.CreateInvoiceRequest
+	var err error
+	var invoice *models.Invoice
+
+	if err = ctx.ShouldBindJSON(&request); err != nil {
+		exceptions.ThrowUnProcessableEntityException(ctx, err.Error())
+		return
+	}
+
+	customer, err := i.getCustomerFromContext(ctx)
+	if err != nil {
+		exceptions.ThrowBadRequestException(ctx, err.Error())
+		return
+	}
+
+	existing, err := i.getInvoiceDetailsFromParams(ctx)
+	if err != nil {
+		i.throwServiceError(ctx, err)
+		return
+	}
+
+	version, ok := i.getExpectedVersion(ctx)
+	if !ok {
+		return
+	}
+
+	invoice, err = i.invoiceService.UpdateInvoice(ctx, existing, version, &request)
+	if err != nil {
+		i.throwServiceError(ctx, err)
+		return
+	}
+
+	if len(request.ReminderSchedules) > 0 {
+		err = i.reminderService.SetInvoiceReminders(ctx, invoice, customer.ID, request.ReminderSchedules)
+		if err != nil {
+			exceptions.ThrowBadRequestException(ctx, err.Error())
+			return
+		}
+	}
+
+	ctx.Header("ETag", invoiceETag(invoice.Version))
+	ctx.JSON(http.StatusOK, common.BuildSuccessResponse("invoice updated successfully", invoice))
+}
+
+// Delete implements controller_interfaces.InvoiceController.
+func (i *invoiceController) Delete(ctx *gin.Context) {
+	invoice, err := i.getInvoiceDetailsFromParams(ctx)
+	if err != nil {
+		i.throwServiceError(ctx, err)
+		return
+	}
+
+	version, ok := i.getExpectedVersion(ctx)
+	if !ok {
+		return
+	}
+
+	if err = i.invoiceService.DeleteInvoice(ctx, invoice, version); err != nil {
+		i.throwServiceError(ctx, err)
+		return
+	}
+
+	ctx.JSON(http.StatusOK, common.BuildSuccessResponse("invoice deleted successfully", nil))
+}
+
 // Duplicate implements controller_interfaces.InvoiceController.
 func (i *invoiceController) Duplicate(ctx *gin.Context) {
 	invoiceID := ctx.Param("invoice_id")
@@ -166,6 +213,9 @@ func (i *invoiceController) Duplicate(ctx *gin.Context) {
 	}
 
 	defer func() {
+		if newInvoice == nil {
+			return
+		}
 		i.auditService.CreateAuditTrail(
 			ctx,
 			models.EventTypeInvoiceCreated,
@@ -178,16 +228,16 @@ func (i *invoiceController) Duplicate(ctx *gin.Context) {
 	}()
 
 	// TODO: call service to copy invoice details
-	existingInvoice, err := i.invoiceService.GetInvoiceByIDandCustomer(ctx, uint(invoiceIDUint), customer.ID)
+	existingInvoice, err := i.invoiceService.GetInvoiceByIDandCustomer(ctx, uint(invoiceIDUint))
 	if err != nil {
-		exceptions.ThrowBadRequestException(ctx, err.Error())
+		i.throwServiceError(ctx, err)
 		return
 	}
 
 	// TODO: call service to create the new invoice
 	newInvoice, err = i.invoiceService.DuplicateInvoice(ctx, existingInvoice)
 	if err != nil {
-		exceptions.ThrowBadRequestException(ctx, err.Error())
+		i.throwServiceError(ctx, err)
 		return
 	}
 
@@ -203,13 +253,7 @@ func (i *invoiceController) GetCustomerInvoices(ctx *gin.Context) {
 		return
 	}
 
-	customer, err := i.getCustomerFromContext(ctx)
-	if err != nil {
-		exceptions.ThrowBadRequestException(ctx, err.Error())
-		return
-	}
-
-	invoices, err := i.invoiceService.GetCustomerInvoices(ctx, request.Limit, request.Page, customer.ID)
+	invoices, err := i.invoiceService.GetCustomerInvoices(ctx, request.Limit, request.Page)
 	if err != nil {
 		exceptions.ThrowBadRequestException(ctx, err.Error())
 		return
@@ -245,59 +289,38 @@ func (i *invoiceController) GetCustomerAuditTrails(ctx *gin.Context) {
 
 // GetDetails implements controller_interfaces.InvoiceController.
 func (i *invoiceController) GetDetails(ctx *gin.Context) {
-	invoiceID := ctx.Param("invoice_id")
-
-	if invoiceID == "" {
-		exceptions.ThrowUnProcessableEntityException(ctx, "invoice id is required")
-		return
-	}
-
-	invoiceIDUint, err := strconv.ParseUint(invoiceID, 10, 64)
+	invoice, err := i.getInvoiceDetailsFromParams(ctx)
 	if err != nil {
-		exceptions.ThrowUnProcessableEntityException(ctx, "invalid invoice id")
+		i.throwServiceError(ctx, err)
 		return
 	}
 
-	//TODO: call service to get invoice details
-	invoiceDetails, err := i.invoiceService.GetInvoiceDetails(ctx, uint(invoiceIDUint))
+	invoiceDetails, err := i.invoiceService.GetInvoiceDetails(ctx, invoice.ID)
 	if err != nil {
-		exceptions.ThrowBadRequestException(ctx, err.Error())
+		i.throwServiceError(ctx, err)
 		return
 	}
 
+	ctx.Header("ETag", invoiceETag(invoiceDetails.Version))
 	ctx.JSON(http.StatusOK, common.BuildSuccessResponse("invoice details fetched successfully", invoiceDetails))
 }
 
-// GetShareableLink implements controller_interfaces.InvoiceController.
-func (i *invoiceController) GetShareableLink(ctx *gin.Context) {
-	invoiceID := ctx.Param("invoice_id")
-
-	if invoiceID == "" {
-		exceptions.ThrowUnProcessableEntityException(ctx, "invoice id is required")
-	}
-
-	customer, err := i.getCustomerFromContext(ctx)
-	if err != nil {
-		exceptions.ThrowBadRequestException(ctx, err.Error())
-	}
-
-	invoiceIDUint, err := strconv.ParseUint(invoiceID, 10, 64)
-	if err != nil {
-		exceptions.ThrowUnProcessableEntityException(ctx, "invalid invoice id")
-	}
-
-	invoice, err := i.invoiceService.GetInvoiceByIDandCustomer(ctx, uint(invoiceIDUint), customer.ID)
+// GetPDF implements controller_interfaces.InvoiceController.
+func (i *invoiceController) GetPDF(ctx *gin.Context) {
+	invoice, err := i.getInvoiceDetailsFromParams(ctx)
 	if err != nil {
-		exceptions.ThrowBadRequestException(ctx, err.Error())
+		i.throwServiceError(ctx, err)
+		return
 	}
 
-	//TODO: call service to get shareable link
-	shareableLink, err := i.invoiceService.GetShareableLink(ctx, invoice)
+	document, err := i.invoiceService.RenderInvoicePDF(ctx, invoice.ID)
 	if err != nil {
-		exceptions.ThrowBadRequestException(ctx, err.Error())
+		i.throwServiceError(ctx, err)
+		return
 	}
 
-	ctx.JSON(http.StatusOK, common.BuildSuccessResponse("shareable link fetched successfully", shareableLink))
+	ctx.Header("Content-Disposition", fmt.Sprintf(`inline; filename="%s"`, invoice.InvoiceNumber+".pdf"))
+	ctx.Data(http.StatusOK, "application/pdf", document)
 }
 
 // GetSingleInvoiceAuditTrails implements controller_interfaces.InvoiceController.
@@ -306,28 +329,27 @@ func (i *invoiceController) GetSingleInvoiceAuditTrails(ctx *gin.Context) {
 
 	if err := ctx.ShouldBindQuery(&request); err != nil {
 		exceptions.ThrowUnProcessableEntityException(ctx, err.Error())
+		return
 	}
 
-	invoiceID := ctx.Param("invoice_id")
-
-	if invoiceID == "" {
-		exceptions.ThrowUnProcessableEntityException(ctx, "invoice id is required")
-	}
-
-	invoiceIDUint, err := strconv.ParseUint(invoiceID, 10, 64)
+	customer, err := i.getCustomerFromContext(ctx)
 	if err != nil {
-		exceptions.ThrowUnProcessableEntityException(ctx, "invalid invoice id")
+		exceptions.ThrowBadRequestException(ctx, err.Error())
+		return
 	}
 
-	customer, err := i.getCustomerFromContext(ctx)
+	// another customer's invoice is reported as missing rather than as an empty trail
+	invoice, err := i.getInvoiceDetailsFromParams(ctx)
 	if err != nil {
-		exceptions.ThrowBadRequestException(ctx, err.Error())
+		i.throwServiceError(ctx, err)
+		return
 	}
 
 	//TODO: call service to get single invoice audit trails
-	auditTrails, err := i.auditService.GetAuditTrailsByInvoiceID(ctx, uint(invoiceIDUint), customer.ID, request.Limit, request.Page)
+	auditTrails, err := i.auditService.GetAuditTrailsByInvoiceID(ctx, invoice.ID, customer.ID, request.Limit, request.Page)
 	if err != nil {
 		exceptions.ThrowBadRequestException(ctx, err.Error())
+		return
 	}
 
 	ctx.JSON(http.StatusOK, common.BuildSuccessResponse("audit trails fetched successfully", auditTrails))
@@ -336,13 +358,7 @@ func (i *invoiceController) GetSingleInvoiceAuditTrails(ctx *gin.Context) {
 // GetStatistics implements controller_interfaces.InvoiceController.
 func (i *invoiceController) GetStatistics(ctx *gin.Context) {
 	// TODO: call service to get invoice statistics
-	customer, err := i.getCustomerFromContext(ctx)
-	if err != nil {
-		exceptions.ThrowBadRequestException(ctx, err.Error())
-		return
-	}
-
-	statistics, err := i.invoiceService.GetInvoiceStatistics(ctx, customer.ID)
+	statistics, err := i.invoiceService.GetInvoiceStatistics(ctx)
 	if err != nil {
 		exceptions.ThrowBadRequestException(ctx, err.Error())
 		return
@@ -378,9 +394,9 @@ func (i *invoiceController) SetReminder(ctx *gin.Context) {
 		return
 	}
 
-	invoice, err := i.invoiceService.GetInvoiceByIDandCustomer(ctx, uint(invoiceIDUint), customer.ID)
+	invoice, err := i.invoiceService.GetInvoiceByIDandCustomer(ctx, uint(invoiceIDUint))
 	if err != nil {
-		exceptions.ThrowBadRequestException(ctx, err.Error())
+		i.throwServiceError(ctx, err)
 		return
 	}
 
@@ -393,6 +409,81 @@ func (i *invoiceController) SetReminder(ctx *gin.Context) {
 	ctx.JSON(http.StatusOK, common.BuildSuccessResponse("reminders set successfully", nil))
 }
 
+// Send implements controller_interfaces.InvoiceController.
+func (i *invoiceController) Send(ctx *gin.Context) {
+	invoice, err := i.getInvoiceDetailsFromParams(ctx)
+	if err != nil {
+		i.throwServiceError(ctx, err)
+		return
+	}
+
+	err = i.emailService.SendInvoice(ctx, invoice)
+	if err != nil {
+		i.throwServiceError(ctx, err)
+		return
+	}
+
+	ctx.JSON(http.StatusOK, common.BuildSuccessResponse("invoice sent successfully", invoice))
+}
+
+// Void implements controller_interfaces.InvoiceController.
+func (i *invoiceController) Void(ctx *gin.Context) {
+	i.changeStatus(ctx, models.InvoiceStatusVoid, "invoice voided successfully")
+}
+
+// Cancel implements controller_interfaces.InvoiceController.
+func (i *invoiceController) Cancel(ctx *gin.Context) {
+	i.changeStatus(ctx, models.InvoiceStatusCancelled, "invoice cancelled successfully")
+}
+
+// WriteOff implements controller_interfaces.InvoiceController.
+func (i *invoiceController) WriteOff(ctx *gin.Context) {
+	i.changeStatus(ctx, models.InvoiceStatusWrittenOff, "invoice written off successfully")
+}
+
+func (i *invoiceController) changeStatus(ctx *gin.Context, status models.InvoiceStatus, message string) {
+	invoice, err := i.getInvoiceDetailsFromParams(ctx)
+	if err != nil {
+		i.throwServiceError(ctx, err)
+		return
+	}
+
+	err = i.invoiceService.ChangeInvoiceStatus(ctx, invoice, status)
+	if err != nil {
+		i.throwServiceError(ctx, err)
+		return
+	}
+
+	ctx.JSON(http.StatusOK, common.BuildSuccessResponse(message, invoice))
+}
+
+// throwServiceError maps typed service errors onto the matching http exception
+func (i *invoiceController) throwServiceError(ctx *gin.Context, err error) {
+	var transitionErr *exceptions.InvalidStatusTransitionError
+	if errors.As(err, &transitionErr) {
+		exceptions.ThrowConflictException(ctx, err.Error())
+		return
+	}
+	if errors.Is(err, exceptions.ErrLocked) {
+		exceptions.ThrowConflictException(ctx, err.Error())
+		return
+	}
+	if errors.Is(err, exceptions.ErrVersionMismatch) {
+		exceptions.ThrowPreconditionFailedException(ctx, err.Error())
+		return
+	}
+	if errors.Is(err, auth.ErrForbidden) {
+		exceptions.ThrowForbiddenException(ctx, err.Error())
+		return
+	}
+	if errors.Is(err, exceptions.ErrNotFound) {
+		exceptions.ThrowNotFoundException(ctx, err.Error())
+		return
+	}
+
+	exceptions.ThrowBadRequestException(ctx, err.Error())
+}
+
 func (i *invoiceController) getCustomerFromContext(ctx *gin.Context) (*models.Customer, error) {
 	customerID, err := helper.GetCustomerIDFromContext(ctx)
 	if err != nil {
@@ -402,7 +493,7 @@ func (i *invoiceController) getCustomerFromContext(ctx *gin.Context) (*models.Cu
 	return i.customerService.GetCustomerByID(ctx, customerID)
 }
 
-func (i *invoiceController) getInvoiceDetailsFromParams(ctx *gin.Context, customerID uint) (*models.Invoice, error) {
+func (i *invoiceController) getInvoiceDetailsFromParams(ctx *gin.Context) (*models.Invoice, error) {
 	invoiceID := ctx.Param("invoice_id")
 	if invoiceID == "" {
 		return nil, errors.New("invoice id is required")
@@ -413,7 +504,30 @@ func (i *invoiceController) getInvoiceDetailsFromParams(ctx *gin.Context, custom
 		return nil, errors.New("invalid invoice id")
 	}
 
-	return i.invoiceService.GetInvoiceByIDandCustomer(ctx, uint(invoiceIDUint), customerID)
+	return i.invoiceService.GetInvoiceByIDandCustomer(ctx, uint(invoiceIDUint))
+}
+
+// getExpectedVersion reads the version of the invoice the caller last saw
+// from the If-Match header, aborting the request when there is none
+func (i *invoiceController) getExpectedVersion(ctx *gin.Context) (uint, bool) {
+	etag := ctx.GetHeader("If-Match")
+	if etag == "" {
+		exceptions.ThrowPreconditionRequiredException(ctx, "the If-Match header must carry the invoice's ETag")
+		return 0, false
+	}
+
+	version, err := strconv.ParseUint(strings.Trim(strings.TrimPrefix(etag, "W/"), `"`), 10, 64)
+	if err != nil {
+		exceptions.ThrowPreconditionFailedException(ctx, "the If-Match header does not match the invoice's ETag")
+		return 0, false
+	}
+
+	return uint(version), true
+}
+
+// invoiceETag is the entity tag of an invoice at the given version
+func invoiceETag(version uint) string {
+	return fmt.Sprintf(`"%d"`, version)
 }
 
 func NewInvoiceController(
@@ -422,6 +536,7 @@ func NewInvoiceController(
 	auditService services_interfaces.AuditService,
 	reminderService services_interfaces.RemiderService,
 	customerService services_interfaces.CustomerService,
+	emailService services_interfaces.InvoiceEmailService,
 ) controller_interfaces.InvoiceController {
 	return &invoiceController{
 		logger:          logger,
@@ -429,5 +544,6 @@ func NewInvoiceController(
 		auditService:    auditService,
 		reminderService: reminderService,
 		customerService: customerService,
+		emailService:    emailService,
 	}
 }
@@ -3,11 +3,15 @@ package controllers
 import (
 	"bytes"
 	"encoding/json"
+	"errors"
+	"fmt"
 	"net/http"
 	"net/http/httptest"
 	"testing"
 	"time"
 
+	"github.com/Adebayobenjamin/numerisbook/pkg/common/exceptions"
+	"github.com/Adebayobenjamin/numerisbook/pkg/common/money"
 	request_dto "github.com/Adebayobenjamin/numerisbook/pkg/dtos/request"
 	"github.com/Adebayobenjamin/numerisbook/pkg/models"
 	services_mocks "github.com/Adebayobenjamin/numerisbook/pkg/services/mocks"
@@ -26,52 +30,49 @@ func TestConfirmPayment(t *testing.T) {
 	mockAuditService := services_mocks.NewMockAuditService(ctrl)
 	mockReminderService := services_mocks.NewMockRemiderService(ctrl)
 	mockCustomerService := services_mocks.NewMockCustomerService(ctrl)
+	mockEmailService := services_mocks.NewMockInvoiceEmailService(ctrl)
 
 	logger := zerolog.New(nil)
-	controller := NewInvoiceController(&logger, mockInvoiceService, mockAuditService, mockReminderService, mockCustomerService)
+	controller := NewInvoiceController(&logger, mockInvoiceService, mockAuditService, mockReminderService, mockCustomerService, mockEmailService)
 
 	gin.SetMode(gin.TestMode)
 	router := gin.Default()
 	router.POST("/invoices/:invoice_id/confirm-payment", controller.ConfirmPayment)
 
 	// Test data
-	customer := &models.Customer{ID: 1, Name: "John Doe"}
-	invoice := &models.Invoice{ID: 1, InvoiceNumber: "INV-001", Status: "Pending"}
+	invoice := &models.Invoice{ID: 1, InvoiceNumber: "INV-001", Status: "Pending", BillingCurrency: "USD"}
 	requestBody := request_dto.PaymentConfirmationRequest{
-		Amount:      100.00,
-		PaymentDate: time.Now(),
+		Amount:      money.MustParse("100.00", ""),
+		PaymentDate: time.Now().UTC().Truncate(time.Second),
 		IsPartial:   false,
+		Method:      models.PaymentMethodCard,
+		Reference:   "ch_3PxYz",
+		PayerName:   "Jane Doe",
+		Attachments: []request_dto.PaymentAttachment{{Name: "receipt.pdf", URL: "https://files.example.com/receipt.pdf"}},
 	}
 	body, _ := json.Marshal(requestBody)
 
-	// Mock context extraction
-	mockCustomerService.EXPECT().
-		GetCustomerByID(gomock.Any(), gomock.Any()).
-		Return(customer, nil).
-		AnyTimes()
-
 	mockInvoiceService.EXPECT().
-		GetInvoiceByIDandCustomer(gomock.Any(), gomock.Any(), gomock.Any()).
+		GetInvoiceByIDandCustomer(gomock.Any(), gomock.Any()).
 		Return(invoice, nil).
 		AnyTimes()
 
 	mockInvoiceService.EXPECT().
-		ValidatePaymentAmount(gomock.Any(), requestBody.Amount, invoice, requestBody.IsPartial).
-		Return(nil).
+		ConfirmPayment(gomock.Any(), &models.Payment{
+			InvoiceID:   invoice.ID,
+			Amount:      money.New(10000, "USD"),
+			Date:        requestBody.PaymentDate,
+			Method:      models.PaymentMethodCard,
+			Reference:   "ch_3PxYz",
+			PayerName:   "Jane Doe",
+			Attachments: models.PaymentAttachments{{Name: "receipt.pdf", URL: "https://files.example.com/receipt.pdf"}},
+		}).
+		Return(&models.Invoice{ID: 1, InvoiceNumber: "INV-001", Status: models.InvoiceStatusPaid, BillingCurrency: "USD"}, nil).
 		Times(1)
 
-	mockInvoiceService.EXPECT().
-		ConfirmPayment(gomock.Any(), invoice.ID, requestBody.Amount, requestBody.PaymentDate, requestBody.IsPartial).
-		Return(nil).
-		Times(1)
-
-	mockInvoiceService.EXPECT().
-		SetInvoiceStatusIfFullyPaid(gomock.Any(), invoice).
-		Return(nil).
-		Times(1)
-
-	mockAuditService.EXPECT().
-		CreateAuditTrail(gomock.Any(), models.EventTypePaymentConfirmed, models.LogLevelInfo, gomock.Any(), invoice.ID, customer.ID).
+	mockEmailService.EXPECT().
+		SendPaymentReceipt(gomock.Any(), invoice.ID, money.New(10000, "USD"), requestBody.PaymentDate).
+		Return(errors.New("smtp is not configured")).
 		Times(1)
 
 	// Make HTTP request
@@ -85,3 +86,179 @@ func TestConfirmPayment(t *testing.T) {
 	assert.Equal(t, http.StatusOK, resp.Code)
 	assert.Contains(t, resp.Body.String(), "payment confirmed successfully")
 }
+
+func TestVoidRejectsIllegalTransition(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockInvoiceService := services_mocks.NewMockInvoiceService(ctrl)
+	mockAuditService := services_mocks.NewMockAuditService(ctrl)
+	mockReminderService := services_mocks.NewMockRemiderService(ctrl)
+	mockCustomerService := services_mocks.NewMockCustomerService(ctrl)
+	mockEmailService := services_mocks.NewMockInvoiceEmailService(ctrl)
+
+	logger := zerolog.New(nil)
+	controller := NewInvoiceController(&logger, mockInvoiceService, mockAuditService, mockReminderService, mockCustomerService, mockEmailService)
+
+	gin.SetMode(gin.TestMode)
+	router := gin.Default()
+	router.POST("/invoices/:invoice_id/void", controller.Void)
+
+	invoice := &models.Invoice{ID: 1, InvoiceNumber: "INV-001", Status: models.InvoiceStatusPaid}
+
+	mockInvoiceService.EXPECT().
+		GetInvoiceByIDandCustomer(gomock.Any(), uint(1)).
+		Return(invoice, nil)
+
+	mockInvoiceService.EXPECT().
+		ChangeInvoiceStatus(gomock.Any(), invoice, models.InvoiceStatusVoid).
+		Return(&exceptions.InvalidStatusTransitionError{Entity: "invoice", From: "paid", To: "void"})
+
+	req := httptest.NewRequest(http.MethodPost, "/invoices/1/void", nil)
+	resp := httptest.NewRecorder()
+
+	router.ServeHTTP(resp, req)
+
+	assert.Equal(t, http.StatusConflict, resp.Code)
+	assert.Contains(t, resp.Body.String(), "cannot move invoice")
+}
+
+func TestSendEmailsTheInvoice(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockInvoiceService := services_mocks.NewMockInvoiceService(ctrl)
+	mockCustomerService := services_mocks.NewMockCustomerService(ctrl)
+	mockEmailService := services_mocks.NewMockInvoiceEmailService(ctrl)
+
+	logger := zerolog.New(nil)
+	controller := NewInvoiceController(&logger, mockInvoiceService, nil, nil, mockCustomerService, mockEmailService)
+
+	gin.SetMode(gin.TestMode)
+	router := gin.Default()
+	router.POST("/invoices/:invoice_id/send", controller.Send)
+
+	tests := []struct {
+		name     string
+		invoice  *models.Invoice
+		sendErr  error
+		wantCode int
+	}{
+		{
+			name:     "draft invoice is emailed",
+			invoice:  &models.Invoice{ID: 1, Status: models.InvoiceStatusDraft},
+			wantCode: http.StatusOK,
+		},
+		{
+			name:     "paid invoice cannot be sent",
+			invoice:  &models.Invoice{ID: 1, Status: models.InvoiceStatusPaid},
+			sendErr:  &exceptions.InvalidStatusTransitionError{Entity: "invoice", From: "paid", To: "sent"},
+			wantCode: http.StatusConflict,
+		},
+		{
+			name:     "delivery failure",
+			invoice:  &models.Invoice{ID: 1, Status: models.InvoiceStatusDraft},
+			sendErr:  errors.New("failed to email invoice: smtp is not configured"),
+			wantCode: http.StatusBadRequest,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			mockInvoiceService.EXPECT().GetInvoiceByIDandCustomer(gomock.Any(), uint(1)).Return(tt.invoice, nil)
+			mockEmailService.EXPECT().SendInvoice(gomock.Any(), tt.invoice).Return(tt.sendErr)
+
+			req := httptest.NewRequest(http.MethodPost, "/invoices/1/send", nil)
+			resp := httptest.NewRecorder()
+
+			router.ServeHTTP(resp, req)
+
+			assert.Equal(t, tt.wantCode, resp.Code)
+		})
+	}
+}
+
+func TestGetPDFServesTheRenderedInvoice(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockInvoiceService := services_mocks.NewMockInvoiceService(ctrl)
+	mockCustomerService := services_mocks.NewMockCustomerService(ctrl)
+
+	logger := zerolog.New(nil)
+	controller := NewInvoiceController(&logger, mockInvoiceService, nil, nil, mockCustomerService, nil)
+
+	gin.SetMode(gin.TestMode)
+	router := gin.Default()
+	router.GET("/invoices/:invoice_id/pdf", controller.GetPDF)
+
+	invoice := &models.Invoice{ID: 1, InvoiceNumber: "INV-001"}
+	document := []byte("%PDF-1.4\n%%EOF\n")
+
+	mockInvoiceService.EXPECT().GetInvoiceByIDandCustomer(gomock.Any(), uint(1)).Return(invoice, nil)
+	mockInvoiceService.EXPECT().RenderInvoicePDF(gomock.Any(), invoice.ID).Return(document, nil)
+
+	req := httptest.NewRequest(http.MethodGet, "/invoices/1/pdf", nil)
+	resp := httptest.NewRecorder()
+
+	router.ServeHTTP(resp, req)
+
+	assert.Equal(t, http.StatusOK, resp.Code)
+	assert.Equal(t, "application/pdf", resp.Header().Get("Content-Type"))
+	assert.Equal(t, `inline; filename="INV-001.pdf"`, resp.Header().Get("Content-Disposition"))
+	assert.Equal(t, document, resp.Body.Bytes())
+}
+
+func TestDeleteChecksTheETag(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockInvoiceService := services_mocks.NewMockInvoiceService(ctrl)
+	mockAuditService := services_mocks.NewMockAuditService(ctrl)
+	mockReminderService := services_mocks.NewMockRemiderService(ctrl)
+	mockCustomerService := services_mocks.NewMockCustomerService(ctrl)
+	mockEmailService := services_mocks.NewMockInvoiceEmailService(ctrl)
+
+	logger := zerolog.New(nil)
+	controller := NewInvoiceController(&logger, mockInvoiceService, mockAuditService, mockReminderService, mockCustomerService, mockEmailService)
+
+	gin.SetMode(gin.TestMode)
+	router := gin.Default()
+	router.DELETE("/invoices/:invoice_id", controller.Delete)
+
+	invoice := &models.Invoice{ID: 1, InvoiceNumber: "INV-001", Status: models.InvoiceStatusDraft, Version: 3}
+
+	mockInvoiceService.EXPECT().
+		GetInvoiceByIDandCustomer(gomock.Any(), uint(1)).
+		Return(invoice, nil).
+		AnyTimes()
+
+	deleteWith := func(ifMatch string) *httptest.ResponseRecorder {
+		req := httptest.NewRequest(http.MethodDelete, "/invoices/1", nil)
+		if ifMatch != "" {
+			req.Header.Set("If-Match", ifMatch)
+		}
+		resp := httptest.NewRecorder()
+		router.ServeHTTP(resp, req)
+		return resp
+	}
+
+	t.Run("without If-Match", func(t *testing.T) {
+		assert.Equal(t, http.StatusPreconditionRequired, deleteWith("").Code)
+	})
+
+	t.Run("stale ETag", func(t *testing.T) {
+		mockInvoiceService.EXPECT().
+			DeleteInvoice(gomock.Any(), invoice, uint(2)).
+			Return(fmt.Errorf("invoice %w", exceptions.ErrVersionMismatch))
+
+		assert.Equal(t, http.StatusPreconditionFailed, deleteWith(`"2"`).Code)
+	})
+
+	t.Run("current ETag", func(t *testing.T) {
+		// the deletion is audited in the same transaction, not by the controller
+		mockInvoiceService.EXPECT().DeleteInvoice(gomock.Any(), invoice, uint(3)).Return(nil)
+
+		assert.Equal(t, http.StatusOK, deleteWith(`W/"3"`).Code)
+	})
+}
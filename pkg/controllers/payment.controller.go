@@ -0,0 +1,135 @@
+package controllers
+
+import (
+	"errors"
+	"net/http"
+	"strconv"
+
+	"github.com/Adebayobenjamin/numerisbook/pkg/auth"
+	"github.com/Adebayobenjamin/numerisbook/pkg/common"
+	"github.com/Adebayobenjamin/numerisbook/pkg/common/exceptions"
+	controller_interfaces "github.com/Adebayobenjamin/numerisbook/pkg/controllers/interfaces"
+	request_dto "github.com/Adebayobenjamin/numerisbook/pkg/dtos/request"
+	"github.com/Adebayobenjamin/numerisbook/pkg/helper"
+	services_interfaces "github.com/Adebayobenjamin/numerisbook/pkg/services/interfaces"
+	"github.com/gin-gonic/gin"
+	"github.com/rs/zerolog"
+)
+
+type paymentController struct {
+	logger         *zerolog.Logger
+	paymentService services_interfaces.PaymentService
+}
+
+// GetCustomerPayments implements controller_interfaces.PaymentController.
+func (p *paymentController) GetCustomerPayments(ctx *gin.Context) {
+	var request request_dto.GetAllPaymentsRequest
+	if err := ctx.ShouldBindQuery(&request); err != nil {
+		exceptions.ThrowUnProcessableEntityException(ctx, err.Error())
+		return
+	}
+
+	customerID, err := helper.GetCustomerIDFromContext(ctx)
+	if err != nil {
+		exceptions.ThrowBadRequestException(ctx, err.Error())
+		return
+	}
+
+	payments, err := p.paymentService.GetCustomerPayments(ctx, customerID, &request)
+	if err != nil {
+		p.throwServiceError(ctx, err)
+		return
+	}
+
+	ctx.JSON(http.StatusOK, common.BuildSuccessResponse("payments fetched successfully", payments))
+}
+
+// GetDetails implements controller_interfaces.PaymentController.
+func (p *paymentController) GetDetails(ctx *gin.Context) {
+	customerID, err := helper.GetCustomerIDFromContext(ctx)
+	if err != nil {
+		exceptions.ThrowBadRequestException(ctx, err.Error())
+		return
+	}
+
+	paymentID, err := strconv.ParseUint(ctx.Param("payment_id"), 10, 64)
+	if err != nil {
+		exceptions.ThrowUnProcessableEntityException(ctx, "invalid payment id")
+		return
+	}
+
+	payment, err := p.paymentService.GetPaymentByIDAndCustomer(ctx, uint(paymentID), customerID)
+	if err != nil {
+		p.throwServiceError(ctx, err)
+		return
+	}
+
+	ctx.JSON(http.StatusOK, common.BuildSuccessResponse("payment fetched successfully", payment))
+}
+
+// Refund implements controller_interfaces.PaymentController.
+func (p *paymentController) Refund(ctx *gin.Context) {
+	var request request_dto.RefundPaymentRequest
+	if err := ctx.ShouldBindJSON(&request); err != nil {
+		exceptions.ThrowUnProcessableEntityException(ctx, err.Error())
+		return
+	}
+
+	paymentID, err := strconv.ParseUint(ctx.Param("payment_id"), 10, 64)
+	if err != nil {
+		exceptions.ThrowUnProcessableEntityException(ctx, "invalid payment id")
+		return
+	}
+
+	refund, err := p.paymentService.RefundPayment(ctx, uint(paymentID), &request)
+	if err != nil {
+		p.throwServiceError(ctx, err)
+		return
+	}
+
+	ctx.JSON(http.StatusCreated, common.BuildSuccessResponse("payment refunded successfully", refund))
+}
+
+// Reverse implements controller_interfaces.PaymentController.
+func (p *paymentController) Reverse(ctx *gin.Context) {
+	var request request_dto.ReversePaymentRequest
+	if err := ctx.ShouldBindJSON(&request); err != nil {
+		exceptions.ThrowUnProcessableEntityException(ctx, err.Error())
+		return
+	}
+
+	paymentID, err := strconv.ParseUint(ctx.Param("payment_id"), 10, 64)
+	if err != nil {
+		exceptions.ThrowUnProcessableEntityException(ctx, "invalid payment id")
+		return
+	}
+
+	reversal, err := p.paymentService.ReversePayment(ctx, uint(paymentID), &request)
+	if err != nil {
+		p.throwServiceError(ctx, err)
+		return
+	}
+
+	ctx.JSON(http.StatusCreated, common.BuildSuccessResponse("payment reversed successfully", reversal))
+}
+
+func (p *paymentController) throwServiceError(ctx *gin.Context, err error) {
+	switch {
+	case errors.Is(err, exceptions.ErrNotFound):
+		exceptions.ThrowNotFoundException(ctx, err.Error())
+	case errors.Is(err, auth.ErrForbidden):
+		exceptions.ThrowForbiddenException(ctx, err.Error())
+	default:
+		exceptions.ThrowBadRequestException(ctx, err.Error())
+	}
+}
+
+func NewPaymentController(
+	logger *zerolog.Logger,
+	paymentService services_interfaces.PaymentService,
+) controller_interfaces.PaymentController {
+	return &paymentController{
+		logger:         logger,
+		paymentService: paymentService,
+	}
+}
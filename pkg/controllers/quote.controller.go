@@ -0,0 +1,261 @@
+package controllers
+
+import (
+	"errors"
+	"io"
+	"net/http"
+	"strconv"
+
+	"github.com/Adebayobenjamin/numerisbook/pkg/auth"
+	"github.com/Adebayobenjamin/numerisbook/pkg/common"
+	"github.com/Adebayobenjamin/numerisbook/pkg/common/exceptions"
+	controller_interfaces "github.com/Adebayobenjamin/numerisbook/pkg/controllers/interfaces"
+	request_dto "github.com/Adebayobenjamin/numerisbook/pkg/dtos/request"
+	"github.com/Adebayobenjamin/numerisbook/pkg/helper"
+	"github.com/Adebayobenjamin/numerisbook/pkg/models"
+	services_interfaces "github.com/Adebayobenjamin/numerisbook/pkg/services/interfaces"
+	"github.com/gin-gonic/gin"
+	"github.com/rs/zerolog"
+)
+
+type quoteController struct {
+	logger       *zerolog.Logger
+	quoteService services_interfaces.QuoteService
+}
+
+// Create implements controller_interfaces.QuoteController.
+func (q *quoteController) Create(ctx *gin.Context) {
+	var request request_dto.CreateQuoteRequest
+	if err := ctx.ShouldBindJSON(&request); err != nil {
+		exceptions.ThrowUnProcessableEntityException(ctx, err.Error())
+		return
+	}
+
+	customerID, err := helper.GetCustomerIDFromContext(ctx)
+	if err != nil {
+		exceptions.ThrowBadRequestException(ctx, err.Error())
+		return
+	}
+
+	quote, err := q.quoteService.CreateQuote(ctx, customerID, &request)
+	if err != nil {
+		q.throwServiceError(ctx, err)
+		return
+	}
+
+	ctx.JSON(http.StatusCreated, common.BuildSuccessResponse("quote created successfully", quote))
+}
+
+// GetCustomerQuotes implements controller_interfaces.QuoteController.
+func (q *quoteController) GetCustomerQuotes(ctx *gin.Context) {
+	var request request_dto.GetAllRequest
+	if err := ctx.ShouldBindQuery(&request); err != nil {
+		exceptions.ThrowUnProcessableEntityException(ctx, err.Error())
+		return
+	}
+
+	customerID, err := helper.GetCustomerIDFromContext(ctx)
+	if err != nil {
+		exceptions.ThrowBadRequestException(ctx, err.Error())
+		return
+	}
+
+	quotes, err := q.quoteService.GetCustomerQuotes(ctx, customerID, &request)
+	if err != nil {
+		q.throwServiceError(ctx, err)
+		return
+	}
+
+	ctx.JSON(http.StatusOK, common.BuildSuccessResponse("quotes fetched successfully", quotes))
+}
+
+// GetDetails implements controller_interfaces.QuoteController.
+func (q *quoteController) GetDetails(ctx *gin.Context) {
+	quote, err := q.getQuoteFromParams(ctx)
+	if err != nil {
+		throwLookupError(ctx, err)
+		return
+	}
+
+	ctx.JSON(http.StatusOK, common.BuildSuccessResponse("quote fetched successfully", quote))
+}
+
+// Send implements controller_interfaces.QuoteController.
+func (q *quoteController) Send(ctx *gin.Context) {
+	quote, err := q.getQuoteFromParams(ctx)
+	if err != nil {
+		throwLookupError(ctx, err)
+		return
+	}
+
+	if err := q.quoteService.SendQuote(ctx, quote); err != nil {
+		q.throwServiceError(ctx, err)
+		return
+	}
+
+	ctx.JSON(http.StatusOK, common.BuildSuccessResponse("quote sent successfully", quote))
+}
+
+// Accept implements controller_interfaces.QuoteController.
+func (q *quoteController) Accept(ctx *gin.Context) {
+	quote, err := q.getQuoteFromParams(ctx)
+	if err != nil {
+		throwLookupError(ctx, err)
+		return
+	}
+
+	if err := q.quoteService.AcceptQuote(ctx, quote); err != nil {
+		q.throwServiceError(ctx, err)
+		return
+	}
+
+	ctx.JSON(http.StatusOK, common.BuildSuccessResponse("quote accepted successfully", quote))
+}
+
+// Decline implements controller_interfaces.QuoteController.
+func (q *quoteController) Decline(ctx *gin.Context) {
+	request, ok := bindDeclineQuoteRequest(ctx)
+	if !ok {
+		return
+	}
+
+	quote, err := q.getQuoteFromParams(ctx)
+	if err != nil {
+		throwLookupError(ctx, err)
+		return
+	}
+
+	if err := q.quoteService.DeclineQuote(ctx, quote, request.Reason); err != nil {
+		q.throwServiceError(ctx, err)
+		return
+	}
+
+	ctx.JSON(http.StatusOK, common.BuildSuccessResponse("quote declined successfully", quote))
+}
+
+// Convert implements controller_interfaces.QuoteController.
+func (q *quoteController) Convert(ctx *gin.Context) {
+	quote, err := q.getQuoteFromParams(ctx)
+	if err != nil {
+		throwLookupError(ctx, err)
+		return
+	}
+
+	invoice, err := q.quoteService.ConvertQuote(ctx, quote)
+	if err != nil {
+		q.throwServiceError(ctx, err)
+		return
+	}
+
+	ctx.JSON(http.StatusCreated, common.BuildSuccessResponse("quote converted successfully", invoice))
+}
+
+// GetSharedQuote implements controller_interfaces.QuoteController.
+func (q *quoteController) GetSharedQuote(ctx *gin.Context) {
+	quote, err := q.quoteService.GetSharedQuote(ctx, ctx.Param("token"))
+	if err != nil {
+		q.throwPublicError(ctx, err)
+		return
+	}
+
+	q.writePublicQuote(ctx, "quote fetched successfully", quote)
+}
+
+// AcceptSharedQuote implements controller_interfaces.QuoteController.
+func (q *quoteController) AcceptSharedQuote(ctx *gin.Context) {
+	quote, err := q.quoteService.RespondToSharedQuote(ctx, ctx.Param("token"), true, "")
+	if err != nil {
+		q.throwPublicError(ctx, err)
+		return
+	}
+
+	q.writePublicQuote(ctx, "quote accepted successfully", quote)
+}
+
+// DeclineSharedQuote implements controller_interfaces.QuoteController.
+func (q *quoteController) DeclineSharedQuote(ctx *gin.Context) {
+	request, ok := bindDeclineQuoteRequest(ctx)
+	if !ok {
+		return
+	}
+
+	quote, err := q.quoteService.RespondToSharedQuote(ctx, ctx.Param("token"), false, request.Reason)
+	if err != nil {
+		q.throwPublicError(ctx, err)
+		return
+	}
+
+	q.writePublicQuote(ctx, "quote declined successfully", quote)
+}
+
+func (q *quoteController) getQuoteFromParams(ctx *gin.Context) (*models.Quote, error) {
+	customerID, err := helper.GetCustomerIDFromContext(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	quoteID, err := strconv.ParseUint(ctx.Param("quote_id"), 10, 64)
+	if err != nil {
+		return nil, errors.New("invalid quote id")
+	}
+
+	return q.quoteService.GetQuoteByIDAndCustomer(ctx, uint(quoteID), customerID)
+}
+
+func (q *quoteController) writePublicQuote(ctx *gin.Context, message string, quote any) {
+	// share links are bearer credentials, keep them and the quote out of shared caches
+	ctx.Header("Cache-Control", "no-store")
+	ctx.Header("Referrer-Policy", "no-referrer")
+	ctx.JSON(http.StatusOK, common.BuildSuccessResponse(message, quote))
+}
+
+// throwPublicError reports errors to the holder of a share link, who is told
+// why their answer was refused but not about anything that went wrong inside
+func (q *quoteController) throwPublicError(ctx *gin.Context, err error) {
+	var transitionErr *exceptions.InvalidStatusTransitionError
+	switch {
+	case errors.Is(err, exceptions.ErrNotFound):
+		exceptions.ThrowNotFoundException(ctx, err.Error())
+	case errors.As(err, &transitionErr), errors.Is(err, exceptions.ErrLocked), errors.Is(err, exceptions.ErrVersionMismatch):
+		exceptions.ThrowConflictException(ctx, err.Error())
+	default:
+		q.logger.Error().Err(err).Msg("failed to handle shared quote")
+		exceptions.ThrowInternalServerError(ctx, "failed to handle quote")
+	}
+}
+
+func (q *quoteController) throwServiceError(ctx *gin.Context, err error) {
+	var transitionErr *exceptions.InvalidStatusTransitionError
+	switch {
+	case errors.Is(err, exceptions.ErrNotFound):
+		exceptions.ThrowNotFoundException(ctx, err.Error())
+	case errors.Is(err, auth.ErrForbidden):
+		exceptions.ThrowForbiddenException(ctx, err.Error())
+	case errors.As(err, &transitionErr), errors.Is(err, exceptions.ErrLocked), errors.Is(err, exceptions.ErrVersionMismatch):
+		// the quote was answered, expired or converted by someone else first
+		exceptions.ThrowConflictException(ctx, err.Error())
+	default:
+		exceptions.ThrowBadRequestException(ctx, err.Error())
+	}
+}
+
+// bindDeclineQuoteRequest reads the optional reason for declining a quote; an
+// empty body declines without one
+func bindDeclineQuoteRequest(ctx *gin.Context) (*request_dto.DeclineQuoteRequest, bool) {
+	var request request_dto.DeclineQuoteRequest
+	if err := ctx.ShouldBindJSON(&request); err != nil && !errors.Is(err, io.EOF) {
+		exceptions.ThrowUnProcessableEntityException(ctx, err.Error())
+		return nil, false
+	}
+	return &request, true
+}
+
+func NewQuoteController(
+	logger *zerolog.Logger,
+	quoteService services_interfaces.QuoteService,
+) controller_interfaces.QuoteController {
+	return &quoteController{
+		logger:       logger,
+		quoteService: quoteService,
+	}
+}
@@ -0,0 +1,144 @@
+package controllers
+
+import (
+	"errors"
+	"net/http"
+	"strconv"
+
+	"github.com/Adebayobenjamin/numerisbook/pkg/common"
+	"github.com/Adebayobenjamin/numerisbook/pkg/common/exceptions"
+	controller_interfaces "github.com/Adebayobenjamin/numerisbook/pkg/controllers/interfaces"
+	request_dto "github.com/Adebayobenjamin/numerisbook/pkg/dtos/request"
+	"github.com/Adebayobenjamin/numerisbook/pkg/helper"
+	"github.com/Adebayobenjamin/numerisbook/pkg/models"
+	services_interfaces "github.com/Adebayobenjamin/numerisbook/pkg/services/interfaces"
+	"github.com/gin-gonic/gin"
+	"github.com/rs/zerolog"
+)
+
+type recurringInvoiceController struct {
+	logger                  *zerolog.Logger
+	recurringInvoiceService services_interfaces.RecurringInvoiceService
+}
+
+// Create implements controller_interfaces.RecurringInvoiceController.
+func (r *recurringInvoiceController) Create(ctx *gin.Context) {
+	var request request_dto.CreateRecurringInvoiceRequest
+	if err := ctx.ShouldBindJSON(&request); err != nil {
+		exceptions.ThrowUnProcessableEntityException(ctx, err.Error())
+		return
+	}
+
+	customerID, err := helper.GetCustomerIDFromContext(ctx)
+	if err != nil {
+		exceptions.ThrowBadRequestException(ctx, err.Error())
+		return
+	}
+
+	profile, err := r.recurringInvoiceService.CreateProfile(ctx, customerID, &request)
+	if err != nil {
+		exceptions.ThrowBadRequestException(ctx, err.Error())
+		return
+	}
+
+	ctx.JSON(http.StatusCreated, common.BuildSuccessResponse("recurring invoice created successfully", profile))
+}
+
+// GetCustomerProfiles implements controller_interfaces.RecurringInvoiceController.
+func (r *recurringInvoiceController) GetCustomerProfiles(ctx *gin.Context) {
+	var request request_dto.GetAllRequest
+	if err := ctx.ShouldBindQuery(&request); err != nil {
+		exceptions.ThrowUnProcessableEntityException(ctx, err.Error())
+		return
+	}
+
+	customerID, err := helper.GetCustomerIDFromContext(ctx)
+	if err != nil {
+		exceptions.ThrowBadRequestException(ctx, err.Error())
+		return
+	}
+
+	profiles, err := r.recurringInvoiceService.GetCustomerProfiles(ctx, request.Limit, request.Page, customerID)
+	if err != nil {
+		exceptions.ThrowBadRequestException(ctx, err.Error())
+		return
+	}
+
+	ctx.JSON(http.StatusOK, common.BuildSuccessResponse("recurring invoices fetched successfully", profiles))
+}
+
+// GetDetails implements controller_interfaces.RecurringInvoiceController.
+func (r *recurringInvoiceController) GetDetails(ctx *gin.Context) {
+	profile, err := r.getProfileFromParams(ctx)
+	if err != nil {
+		throwLookupError(ctx, err)
+		return
+	}
+
+	ctx.JSON(http.StatusOK, common.BuildSuccessResponse("recurring invoice fetched successfully", profile))
+}
+
+// Pause implements controller_interfaces.RecurringInvoiceController.
+func (r *recurringInvoiceController) Pause(ctx *gin.Context) {
+	r.changeStatus(ctx, models.RecurringProfileStatusPaused, "recurring invoice paused successfully")
+}
+
+// Resume implements controller_interfaces.RecurringInvoiceController.
+func (r *recurringInvoiceController) Resume(ctx *gin.Context) {
+	r.changeStatus(ctx, models.RecurringProfileStatusActive, "recurring invoice resumed successfully")
+}
+
+// Cancel implements controller_interfaces.RecurringInvoiceController.
+func (r *recurringInvoiceController) Cancel(ctx *gin.Context) {
+	r.changeStatus(ctx, models.RecurringProfileStatusCancelled, "recurring invoice cancelled successfully")
+}
+
+func (r *recurringInvoiceController) changeStatus(ctx *gin.Context, status models.RecurringProfileStatus, message string) {
+	profile, err := r.getProfileFromParams(ctx)
+	if err != nil {
+		throwLookupError(ctx, err)
+		return
+	}
+
+	err = r.recurringInvoiceService.ChangeProfileStatus(ctx, profile, status)
+	if err != nil {
+		var transitionErr *exceptions.InvalidStatusTransitionError
+		if errors.As(err, &transitionErr) {
+			exceptions.ThrowConflictException(ctx, err.Error())
+			return
+		}
+		exceptions.ThrowBadRequestException(ctx, err.Error())
+		return
+	}
+
+	ctx.JSON(http.StatusOK, common.BuildSuccessResponse(message, profile))
+}
+
+func (r *recurringInvoiceController) getProfileFromParams(ctx *gin.Context) (*models.RecurringInvoiceProfile, error) {
+	customerID, err := helper.GetCustomerIDFromContext(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	profileID := ctx.Param("profile_id")
+	if profileID == "" {
+		return nil, errors.New("recurring invoice id is required")
+	}
+
+	profileIDUint, err := strconv.ParseUint(profileID, 10, 64)
+	if err != nil {
+		return nil, errors.New("invalid recurring invoice id")
+	}
+
+	return r.recurringInvoiceService.GetProfileByIDAndCustomer(ctx, uint(profileIDUint), customerID)
+}
+
+func NewRecurringInvoiceController(
+	logger *zerolog.Logger,
+	recurringInvoiceService services_interfaces.RecurringInvoiceService,
+) controller_interfaces.RecurringInvoiceController {
+	return &recurringInvoiceController{
+		logger:                  logger,
+		recurringInvoiceService: recurringInvoiceService,
+	}
+}
@@ -0,0 +1,152 @@
+package controllers
+
+import (
+	"net/http"
+
+	"github.com/Adebayobenjamin/numerisbook/pkg/common"
+	"github.com/Adebayobenjamin/numerisbook/pkg/common/exceptions"
+	controller_interfaces "github.com/Adebayobenjamin/numerisbook/pkg/controllers/interfaces"
+	request_dto "github.com/Adebayobenjamin/numerisbook/pkg/dtos/request"
+	response_dto "github.com/Adebayobenjamin/numerisbook/pkg/dtos/response"
+	"github.com/Adebayobenjamin/numerisbook/pkg/helper"
+	"github.com/Adebayobenjamin/numerisbook/pkg/models"
+	services_interfaces "github.com/Adebayobenjamin/numerisbook/pkg/services/interfaces"
+	"github.com/gin-gonic/gin"
+	"github.com/rs/zerolog"
+)
+
+type settingsController struct {
+	logger                  *zerolog.Logger
+	documentSequenceService services_interfaces.DocumentSequenceService
+	customerService         services_interfaces.CustomerService
+}
+
+// GetInvoiceNumbering implements controller_interfaces.SettingsController.
+func (s *settingsController) GetInvoiceNumbering(ctx *gin.Context) {
+	s.getNumbering(ctx, models.DocumentTypeInvoice, "invoice")
+}
+
+// UpdateInvoiceNumbering implements controller_interfaces.SettingsController.
+func (s *settingsController) UpdateInvoiceNumbering(ctx *gin.Context) {
+	s.updateNumbering(ctx, models.DocumentTypeInvoice, "invoice")
+}
+
+// GetCreditNoteNumbering implements controller_interfaces.SettingsController.
+func (s *settingsController) GetCreditNoteNumbering(ctx *gin.Context) {
+	s.getNumbering(ctx, models.DocumentTypeCreditNote, "credit note")
+}
+
+// UpdateCreditNoteNumbering implements controller_interfaces.SettingsController.
+func (s *settingsController) UpdateCreditNoteNumbering(ctx *gin.Context) {
+	s.updateNumbering(ctx, models.DocumentTypeCreditNote, "credit note")
+}
+
+// GetQuoteNumbering implements controller_interfaces.SettingsController.
+func (s *settingsController) GetQuoteNumbering(ctx *gin.Context) {
+	s.getNumbering(ctx, models.DocumentTypeQuote, "quote")
+}
+
+// UpdateQuoteNumbering implements controller_interfaces.SettingsController.
+func (s *settingsController) UpdateQuoteNumbering(ctx *gin.Context) {
+	s.updateNumbering(ctx, models.DocumentTypeQuote, "quote")
+}
+
+// getNumbering responds with the customer's numbering of one type of document
+func (s *settingsController) getNumbering(ctx *gin.Context, documentType models.DocumentType, label string) {
+	customerID, err := helper.GetCustomerIDFromContext(ctx)
+	if err != nil {
+		exceptions.ThrowBadRequestException(ctx, err.Error())
+		return
+	}
+
+	sequence, err := s.documentSequenceService.GetSequence(ctx, customerID, documentType)
+	if err != nil {
+		exceptions.ThrowBadRequestException(ctx, err.Error())
+		return
+	}
+
+	ctx.JSON(http.StatusOK, common.BuildSuccessResponse(label+" numbering fetched successfully", sequence))
+}
+
+// updateNumbering changes the customer's numbering of one type of document
+func (s *settingsController) updateNumbering(ctx *gin.Context, documentType models.DocumentType, label string) {
+	var request request_dto.UpdateDocumentSequenceRequest
+	if err := ctx.ShouldBindJSON(&request); err != nil {
+		exceptions.ThrowUnProcessableEntityException(ctx, err.Error())
+		return
+	}
+
+	customerID, err := helper.GetCustomerIDFromContext(ctx)
+	if err != nil {
+		exceptions.ThrowBadRequestException(ctx, err.Error())
+		return
+	}
+
+	sequence, err := s.documentSequenceService.UpdateSequence(ctx, customerID, documentType, &request)
+	if err != nil {
+		exceptions.ThrowBadRequestException(ctx, err.Error())
+		return
+	}
+
+	ctx.JSON(http.StatusOK, common.BuildSuccessResponse(label+" numbering updated successfully", sequence))
+}
+
+// GetBranding implements controller_interfaces.SettingsController.
+func (s *settingsController) GetBranding(ctx *gin.Context) {
+	customerID, err := helper.GetCustomerIDFromContext(ctx)
+	if err != nil {
+		exceptions.ThrowBadRequestException(ctx, err.Error())
+		return
+	}
+
+	customer, err := s.customerService.GetCustomerByID(ctx, customerID)
+	if err != nil {
+		exceptions.ThrowBadRequestException(ctx, err.Error())
+		return
+	}
+
+	ctx.JSON(http.StatusOK, common.BuildSuccessResponse("branding fetched successfully", brandingResponse(customer)))
+}
+
+// UpdateBranding implements controller_interfaces.SettingsController.
+func (s *settingsController) UpdateBranding(ctx *gin.Context) {
+	var request request_dto.UpdateBrandingRequest
+	if err := ctx.ShouldBindJSON(&request); err != nil {
+		exceptions.ThrowUnProcessableEntityException(ctx, err.Error())
+		return
+	}
+
+	customerID, err := helper.GetCustomerIDFromContext(ctx)
+	if err != nil {
+		exceptions.ThrowBadRequestException(ctx, err.Error())
+		return
+	}
+
+	customer, err := s.customerService.UpdateBranding(ctx, customerID, &request)
+	if err != nil {
+		exceptions.ThrowBadRequestException(ctx, err.Error())
+		return
+	}
+
+	ctx.JSON(http.StatusOK, common.BuildSuccessResponse("branding updated successfully", brandingResponse(customer)))
+}
+
+func brandingResponse(customer *models.Customer) *response_dto.GetBrandingResponse {
+	return &response_dto.GetBrandingResponse{
+		BrandColor:  customer.BrandColor,
+		LogoURL:     customer.LogoURL,
+		EmailFooter: customer.EmailFooter,
+	}
+}
+
+func NewSettingsController(
+	logger *zerolog.Logger,
+	documentSequenceService services_interfaces.DocumentSequenceService,
+	customerService services_interfaces.CustomerService,
+) controller_interfaces.SettingsController {
+	return &settingsController{
+		logger:                  logger,
+		documentSequenceService: documentSequenceService,
+		customerService:         customerService,
+	}
+}
@@ -0,0 +1,117 @@
+package controllers
+
+import (
+	"errors"
+	"net/http"
+	"strconv"
+
+	"github.com/Adebayobenjamin/numerisbook/pkg/auth"
+	"github.com/Adebayobenjamin/numerisbook/pkg/common"
+	"github.com/Adebayobenjamin/numerisbook/pkg/common/exceptions"
+	controller_interfaces "github.com/Adebayobenjamin/numerisbook/pkg/controllers/interfaces"
+	"github.com/Adebayobenjamin/numerisbook/pkg/models"
+	services_interfaces "github.com/Adebayobenjamin/numerisbook/pkg/services/interfaces"
+	"github.com/gin-gonic/gin"
+	"github.com/rs/zerolog"
+)
+
+type shareLinkController struct {
+	logger           *zerolog.Logger
+	shareLinkService services_interfaces.ShareLinkService
+	invoiceService   services_interfaces.InvoiceService
+}
+
+// Get implements controller_interfaces.ShareLinkController.
+func (s *shareLinkController) Get(ctx *gin.Context) {
+	invoice, err := s.getInvoiceFromParams(ctx)
+	if err != nil {
+		throwLookupError(ctx, err)
+		return
+	}
+
+	link, err := s.shareLinkService.GetShareLink(ctx, invoice)
+	if err != nil {
+		// viewers can read a live link but not create the first one
+		if errors.Is(err, auth.ErrForbidden) {
+			exceptions.ThrowForbiddenException(ctx, err.Error())
+			return
+		}
+		exceptions.ThrowBadRequestException(ctx, err.Error())
+		return
+	}
+
+	ctx.JSON(http.StatusOK, common.BuildSuccessResponse("shareable link fetched successfully", link))
+}
+
+// Regenerate implements controller_interfaces.ShareLinkController.
+func (s *shareLinkController) Regenerate(ctx *gin.Context) {
+	invoice, err := s.getInvoiceFromParams(ctx)
+	if err != nil {
+		throwLookupError(ctx, err)
+		return
+	}
+
+	link, err := s.shareLinkService.RegenerateShareLink(ctx, invoice)
+	if err != nil {
+		exceptions.ThrowBadRequestException(ctx, err.Error())
+		return
+	}
+
+	ctx.JSON(http.StatusCreated, common.BuildSuccessResponse("shareable link regenerated successfully", link))
+}
+
+// Revoke implements controller_interfaces.ShareLinkController.
+func (s *shareLinkController) Revoke(ctx *gin.Context) {
+	invoice, err := s.getInvoiceFromParams(ctx)
+	if err != nil {
+		throwLookupError(ctx, err)
+		return
+	}
+
+	if err := s.shareLinkService.RevokeShareLinks(ctx, invoice); err != nil {
+		exceptions.ThrowBadRequestException(ctx, err.Error())
+		return
+	}
+
+	ctx.JSON(http.StatusOK, common.BuildSuccessResponse("shareable link revoked successfully", nil))
+}
+
+// GetSharedInvoice implements controller_interfaces.ShareLinkController.
+func (s *shareLinkController) GetSharedInvoice(ctx *gin.Context) {
+	invoice, err := s.shareLinkService.GetSharedInvoice(ctx, ctx.Param("token"))
+	if err != nil {
+		if errors.Is(err, exceptions.ErrNotFound) {
+			exceptions.ThrowNotFoundException(ctx, err.Error())
+			return
+		}
+		s.logger.Error().Err(err).Msg("failed to load shared invoice")
+		exceptions.ThrowInternalServerError(ctx, "failed to load invoice")
+		return
+	}
+
+	// share links are bearer credentials, keep them and the invoice out of shared caches
+	ctx.Header("Cache-Control", "no-store")
+	ctx.Header("Referrer-Policy", "no-referrer")
+	ctx.JSON(http.StatusOK, common.BuildSuccessResponse("invoice fetched successfully", invoice))
+}
+
+func (s *shareLinkController) getInvoiceFromParams(ctx *gin.Context) (*models.Invoice, error) {
+	invoiceID, err := strconv.ParseUint(ctx.Param("invoice_id"), 10, 64)
+	if err != nil {
+		return nil, errors.New("invalid invoice id")
+	}
+
+	return s.invoiceService.GetInvoiceByIDandCustomer(ctx, uint(invoiceID))
+}
+
+func NewShareLinkController(
+	logger *zerolog.Logger,
+	shareLinkService services_interfaces.ShareLinkService,
+	invoiceService services_interfaces.InvoiceService,
+) controller_interfaces.ShareLinkController {
+	return &shareLinkController{
+		logger:           logger,
+		shareLinkService: shareLinkService,
+		invoiceService:   invoiceService,
+	}
+}
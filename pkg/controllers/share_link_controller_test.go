@@ -0,0 +1,65 @@
+package controllers
+
+import (
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/Adebayobenjamin/numerisbook/pkg/common/exceptions"
+	response_dto "github.com/Adebayobenjamin/numerisbook/pkg/dtos/response"
+	services_mocks "github.com/Adebayobenjamin/numerisbook/pkg/services/mocks"
+	"github.com/gin-gonic/gin"
+	"github.com/rs/zerolog"
+	"github.com/stretchr/testify/assert"
+	"go.uber.org/mock/gomock"
+)
+
+func TestGetSharedInvoice(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockShareLinkService := services_mocks.NewMockShareLinkService(ctrl)
+
+	logger := zerolog.New(nil)
+	controller := NewShareLinkController(&logger, mockShareLinkService, nil)
+
+	gin.SetMode(gin.TestMode)
+	router := gin.Default()
+	router.GET("/public/invoices/:token", controller.GetSharedInvoice)
+
+	tests := []struct {
+		name     string
+		view     *response_dto.PublicInvoiceResponse
+		err      error
+		wantCode int
+	}{
+		{
+			name:     "valid token",
+			view:     &response_dto.PublicInvoiceResponse{InvoiceNumber: "INV-001"},
+			wantCode: http.StatusOK,
+		},
+		{
+			name:     "invalid, expired or revoked token",
+			err:      fmt.Errorf("shared invoice %w", exceptions.ErrNotFound),
+			wantCode: http.StatusNotFound,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			mockShareLinkService.EXPECT().GetSharedInvoice(gomock.Any(), "token").Return(tt.view, tt.err)
+
+			req := httptest.NewRequest(http.MethodGet, "/public/invoices/token", nil)
+			resp := httptest.NewRecorder()
+
+			router.ServeHTTP(resp, req)
+
+			assert.Equal(t, tt.wantCode, resp.Code)
+			if tt.view != nil {
+				assert.Equal(t, "no-store", resp.Header().Get("Cache-Control"))
+				assert.Contains(t, resp.Body.String(), "INV-001")
+			}
+		})
+	}
+}
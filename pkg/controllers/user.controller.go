@@ -0,0 +1,134 @@
+package controllers
+
+import (
+	"errors"
+	"net/http"
+	"strconv"
+
+	"github.com/Adebayobenjamin/numerisbook/pkg/auth"
+	"github.com/Adebayobenjamin/numerisbook/pkg/common"
+	"github.com/Adebayobenjamin/numerisbook/pkg/common/exceptions"
+	controller_interfaces "github.com/Adebayobenjamin/numerisbook/pkg/controllers/interfaces"
+	request_dto "github.com/Adebayobenjamin/numerisbook/pkg/dtos/request"
+	"github.com/Adebayobenjamin/numerisbook/pkg/helper"
+	services_interfaces "github.com/Adebayobenjamin/numerisbook/pkg/services/interfaces"
+	"github.com/gin-gonic/gin"
+	"github.com/rs/zerolog"
+)
+
+type userController struct {
+	logger      *zerolog.Logger
+	userService services_interfaces.UserService
+}
+
+// Add implements controller_interfaces.UserController.
+func (u *userController) Add(ctx *gin.Context) {
+	var request request_dto.AddUserRequest
+	if err := ctx.ShouldBindJSON(&request); err != nil {
+		exceptions.ThrowUnProcessableEntityException(ctx, err.Error())
+		return
+	}
+
+	customerID, err := helper.GetCustomerIDFromContext(ctx)
+	if err != nil {
+		exceptions.ThrowBadRequestException(ctx, err.Error())
+		return
+	}
+
+	user, err := u.userService.AddUser(ctx, customerID, &request)
+	if err != nil {
+		u.throwServiceError(ctx, err)
+		return
+	}
+
+	ctx.JSON(http.StatusCreated, common.BuildSuccessResponse("user added successfully", user))
+}
+
+// GetCustomerUsers implements controller_interfaces.UserController.
+func (u *userController) GetCustomerUsers(ctx *gin.Context) {
+	customerID, err := helper.GetCustomerIDFromContext(ctx)
+	if err != nil {
+		exceptions.ThrowBadRequestException(ctx, err.Error())
+		return
+	}
+
+	users, err := u.userService.GetUsers(ctx, customerID)
+	if err != nil {
+		u.throwServiceError(ctx, err)
+		return
+	}
+
+	ctx.JSON(http.StatusOK, common.BuildSuccessResponse("users fetched successfully", users))
+}
+
+// ChangeRole implements controller_interfaces.UserController.
+func (u *userController) ChangeRole(ctx *gin.Context) {
+	var request request_dto.ChangeUserRoleRequest
+	if err := ctx.ShouldBindJSON(&request); err != nil {
+		exceptions.ThrowUnProcessableEntityException(ctx, err.Error())
+		return
+	}
+
+	customerID, err := helper.GetCustomerIDFromContext(ctx)
+	if err != nil {
+		exceptions.ThrowBadRequestException(ctx, err.Error())
+		return
+	}
+
+	userID, err := strconv.ParseUint(ctx.Param("user_id"), 10, 64)
+	if err != nil {
+		exceptions.ThrowUnProcessableEntityException(ctx, "invalid user id")
+		return
+	}
+
+	user, err := u.userService.ChangeRole(ctx, uint(userID), customerID, request.Role)
+	if err != nil {
+		u.throwServiceError(ctx, err)
+		return
+	}
+
+	ctx.JSON(http.StatusOK, common.BuildSuccessResponse("user role updated successfully", user))
+}
+
+// Remove implements controller_interfaces.UserController.
+func (u *userController) Remove(ctx *gin.Context) {
+	customerID, err := helper.GetCustomerIDFromContext(ctx)
+	if err != nil {
+		exceptions.ThrowBadRequestException(ctx, err.Error())
+		return
+	}
+
+	userID, err := strconv.ParseUint(ctx.Param("user_id"), 10, 64)
+	if err != nil {
+		exceptions.ThrowUnProcessableEntityException(ctx, "invalid user id")
+		return
+	}
+
+	if err := u.userService.RemoveUser(ctx, uint(userID), customerID); err != nil {
+		u.throwServiceError(ctx, err)
+		return
+	}
+
+	ctx.JSON(http.StatusOK, common.BuildSuccessResponse("user removed successfully", nil))
+}
+
+func (u *userController) throwServiceError(ctx *gin.Context, err error) {
+	switch {
+	case errors.Is(err, exceptions.ErrNotFound):
+		exceptions.ThrowNotFoundException(ctx, err.Error())
+	case errors.Is(err, auth.ErrForbidden):
+		exceptions.ThrowForbiddenException(ctx, err.Error())
+	default:
+		exceptions.ThrowBadRequestException(ctx, err.Error())
+	}
+}
+
+func NewUserController(
+	logger *zerolog.Logger,
+	userService services_interfaces.UserService,
+) controller_interfaces.UserController {
+	return &userController{
+		logger:      logger,
+		userService: userService,
+	}
+}
@@ -0,0 +1,11 @@
+package request_dto
+
+import "github.com/Adebayobenjamin/numerisbook/pkg/models"
+
+type AddUserRequest struct {
+	// Subject is the sub claim of the tokens the member will sign in with
+	Subject string          `json:"subject" binding:"required,max=255"`
+	Email   string          `json:"email" binding:"required,email,max=255"`
+	Name    string          `json:"name" binding:"required,max=255"`
+	Role    models.UserRole `json:"role" binding:"required,oneof=owner accountant viewer"`
+}
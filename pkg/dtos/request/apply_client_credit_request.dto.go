@@ -0,0 +1,10 @@
+package request_dto
+
+import "github.com/Adebayobenjamin/numerisbook/pkg/common/money"
+
+// ApplyClientCreditRequest pays one of the client's invoices out of their
+// credit. Amount defaults to as much of the credit as the invoice can take.
+type ApplyClientCreditRequest struct {
+	InvoiceID uint         `json:"invoice_id" binding:"required"`
+	Amount    *money.Money `json:"amount"`
+}
@@ -0,0 +1,7 @@
+package request_dto
+
+import "github.com/Adebayobenjamin/numerisbook/pkg/models"
+
+type ChangeUserRoleRequest struct {
+	Role models.UserRole `json:"role" binding:"required,oneof=owner accountant viewer"`
+}
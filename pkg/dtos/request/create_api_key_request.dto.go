@@ -0,0 +1,14 @@
+package request_dto
+
+import (
+	"time"
+
+	"github.com/Adebayobenjamin/numerisbook/pkg/models"
+)
+
+type CreateAPIKeyRequest struct {
+	Name string `json:"name" binding:"required,max=100"`
+	// Role defaults to viewer so new keys are read-only unless asked otherwise
+	Role      models.UserRole `json:"role" binding:"omitempty,oneof=owner accountant viewer"`
+	ExpiresAt *time.Time      `json:"expires_at"`
+}
@@ -0,0 +1,21 @@
+package request_dto
+
+type CreateBankAccountRequest struct {
+	BankName      string `json:"bank_name" binding:"required,max=255"`
+	AccountNumber string `json:"account_number" binding:"required,max=50"`
+	AccountName   string `json:"account_name" binding:"required,max=255"`
+	AchRoutingNo  string `json:"ach_routing_no" binding:"max=50"`
+	BankAddress   string `json:"bank_address" binding:"max=500"`
+	// IsDefault makes the account the one invoices ask to be paid into when
+	// they do not pick one. A customer's first account is always the default.
+	IsDefault bool `json:"is_default"`
+}
+
+// UpdateBankAccountRequest replaces every detail of a bank account
+type UpdateBankAccountRequest struct {
+	BankName      string `json:"bank_name" binding:"required,max=255"`
+	AccountNumber string `json:"account_number" binding:"required,max=50"`
+	AccountName   string `json:"account_name" binding:"required,max=255"`
+	AchRoutingNo  string `json:"ach_routing_no" binding:"max=50"`
+	BankAddress   string `json:"bank_address" binding:"max=500"`
+}
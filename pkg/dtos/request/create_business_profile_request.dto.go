@@ -0,0 +1,19 @@
+package request_dto
+
+type CreateBusinessProfileRequest struct {
+	Name    string `json:"name" binding:"required,max=255"`
+	Email   string `json:"email" binding:"required,email,max=255"`
+	Phone   string `json:"phone" binding:"required,max=50"`
+	Address string `json:"address" binding:"max=500"`
+	// IsDefault makes the profile the one invoices are issued under when they
+	// do not pick one. A customer's first profile is always the default.
+	IsDefault bool `json:"is_default"`
+}
+
+// UpdateBusinessProfileRequest replaces every detail of a business profile
+type UpdateBusinessProfileRequest struct {
+	Name    string `json:"name" binding:"required,max=255"`
+	Email   string `json:"email" binding:"required,email,max=255"`
+	Phone   string `json:"phone" binding:"required,max=50"`
+	Address string `json:"address" binding:"max=500"`
+}
@@ -0,0 +1,32 @@
+package request_dto
+
+import "github.com/Adebayobenjamin/numerisbook/pkg/common/money"
+
+type CreateCatalogItemRequest struct {
+	SKU         string      `json:"sku" binding:"required,max=64"`
+	Name        string      `json:"name" binding:"required,max=255"`
+	Description string      `json:"description" binding:"max=2000"`
+	UnitPrice   money.Money `json:"unit_price"`
+	Currency    string      `json:"currency" binding:"required,len=3"`
+	Unit        string      `json:"unit" binding:"max=32"`
+	Tax         *CatalogTax `json:"tax"`
+}
+
+// UpdateCatalogItemRequest replaces every detail of a catalog item. Invoices
+// already issued keep the details they were issued with.
+type UpdateCatalogItemRequest struct {
+	SKU         string      `json:"sku" binding:"required,max=64"`
+	Name        string      `json:"name" binding:"required,max=255"`
+	Description string      `json:"description" binding:"max=2000"`
+	UnitPrice   money.Money `json:"unit_price"`
+	Currency    string      `json:"currency" binding:"required,len=3"`
+	Unit        string      `json:"unit" binding:"max=32"`
+	Tax         *CatalogTax `json:"tax"`
+}
+
+// CatalogTax is the tax charged on a catalog item by default
+type CatalogTax struct {
+	Name        string     `json:"name" binding:"required,max=100"`
+	Rate        money.Rate `json:"rate"`
+	IsInclusive bool       `json:"is_inclusive"`
+}
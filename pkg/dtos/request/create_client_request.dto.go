@@ -0,0 +1,16 @@
+package request_dto
+
+type CreateClientRequest struct {
+	Name    string `json:"name" binding:"required,max=255"`
+	Email   string `json:"email" binding:"required,email,max=255"`
+	Phone   string `json:"phone" binding:"required,max=50"`
+	Address string `json:"address" binding:"max=500"`
+}
+
+// UpdateClientRequest replaces every detail of a client
+type UpdateClientRequest struct {
+	Name    string `json:"name" binding:"required,max=255"`
+	Email   string `json:"email" binding:"required,email,max=255"`
+	Phone   string `json:"phone" binding:"required,max=50"`
+	Address string `json:"address" binding:"max=500"`
+}
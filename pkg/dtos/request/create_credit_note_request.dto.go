@@ -0,0 +1,17 @@
+package request_dto
+
+import "time"
+
+// CreateCreditNoteRequest credits an issued invoice. Leaving Items out
+// credits everything on the invoice that has not been credited yet.
+type CreateCreditNoteRequest struct {
+	Reason    string           `json:"reason" binding:"required,max=500"`
+	IssueDate *time.Time       `json:"issue_date"`
+	Items     []CreditNoteItem `json:"items" binding:"dive"`
+}
+
+// CreditNoteItem credits Quantity units of one line of the invoice
+type CreditNoteItem struct {
+	InvoiceItemID uint `json:"invoice_item_id" binding:"required"`
+	Quantity      int  `json:"quantity" binding:"required,min=1"`
+}
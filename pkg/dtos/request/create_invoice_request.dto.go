@@ -1,34 +1,129 @@
 package request_dto
 
 import (
+	"encoding/json"
 	"time"
 
+	"github.com/Adebayobenjamin/numerisbook/pkg/common/money"
 	"github.com/Adebayobenjamin/numerisbook/pkg/models"
 )
 
 type CreateInvoiceRequest struct {
-	Sender            Sender                                  `json:"sender" binding:"required"`
 	IssueDate         time.Time                               `json:"issue_date" binding:"required"`
 	DueDate           time.Time                               `json:"due_date" binding:"required"`
 	BillingCurrency   string                                  `json:"billing_currency" binding:"required"`
-	Items             []InvoiceItem                           `json:"items" binding:"required"`
-	Discount          float64                                 `json:"discount"`
+	Items             []InvoiceItem                           `json:"items" binding:"required,dive"`
+	Discount          *Discount                               `json:"discount,omitempty"`
 	Notes             string                                  `json:"notes"`
 	ReminderSchedules map[models.InvoiceReminderSchedule]bool `json:"reminder_schedules"`
-	PaymentInfo       PaymentInfo                             `json:"payment_info"`
+	PaymentInfo       *PaymentInfo                            `json:"payment_info"`
+
+	// ClientID bills a client from the directory. Client bills someone by
+	// their details instead, adding them to the directory if they are new.
+	ClientID *uint          `json:"client_id"`
+	Client   *ClientDetails `json:"client"`
+	// Deprecated: Sender is what Client used to be called and is read the same way
+	Sender *ClientDetails `json:"sender"`
+
+	// BusinessProfileID picks the profile the invoice is issued under and
+	// BankAccountID the account it asks to be paid into. Without them the
+	// customer's defaults are used, and PaymentInfo can give one-off details.
+	BusinessProfileID *uint `json:"business_profile_id"`
+	BankAccountID     *uint `json:"bank_account_id"`
+}
+
+// ClientDetails names the party an invoice is billed to
+type ClientDetails struct {
+	Name    string `json:"name" binding:"required,max=255"`
+	Phone   string `json:"phone" binding:"required,max=50"`
+	Address string `json:"address" binding:"max=500"`
+	Email   string `json:"email" binding:"required,email,max=255"`
 }
 
-type Sender struct {
-	Name    string `json:"name" binding:"required"`
-	Phone   string `json:"phone" binding:"required"`
-	Address string `json:"address" binding:"required"`
-	Email   string `json:"email" binding:"required"`
+// InlineClient returns the billed party's details given inline, if any
+func (r *CreateInvoiceRequest) InlineClient() *ClientDetails {
+	if r.Client != nil {
+		return r.Client
+	}
+	return r.Sender
 }
 
+// Invoice copies the request's dates, currency, notes and lines onto an
+// invoice. Amounts are copied as they were read, so tagging them with the
+// currency still sees every decimal that was sent. Discounts, the billed
+// party, the issuer and payment details are resolved separately.
+func (r *CreateInvoiceRequest) Invoice() *models.Invoice {
+	invoice := &models.Invoice{
+		IssueDate:       r.IssueDate,
+		DueDate:         r.DueDate,
+		BillingCurrency: r.BillingCurrency,
+		Notes:           r.Notes,
+	}
+	for _, item := range r.Items {
+		line := models.InvoiceItem{
+			CatalogItemID: item.CatalogItemID,
+			Description:   item.Description,
+			Quantity:      item.Quantity,
+			Unit:          item.Unit,
+			UnitPrice:     item.UnitPrice,
+		}
+		for _, tax := range item.Taxes {
+			line.Taxes = append(line.Taxes, models.InvoiceItemTax{
+				Name:        tax.Name,
+				Rate:        tax.Rate,
+				IsInclusive: tax.IsInclusive,
+				IsCompound:  tax.IsCompound,
+			})
+		}
+		invoice.Items = append(invoice.Items, line)
+	}
+	return invoice
+}
+
+// InvoiceItem is a line of an invoice. A line that references a catalog item
+// takes its description, unit, price and tax from the catalog for whichever
+// of them it leaves out.
 type InvoiceItem struct {
-	Description string  `json:"description"`
-	Quantity    int     `json:"quantity" binding:"required"`
-	UnitPrice   float64 `json:"unit_price" binding:"required"`
+	CatalogItemID *uint            `json:"catalog_item_id"`
+	Description   string           `json:"description"`
+	Quantity      int              `json:"quantity" binding:"required"`
+	Unit          string           `json:"unit" binding:"max=32"`
+	UnitPrice     money.Money      `json:"unit_price" binding:"required_without=CatalogItemID"`
+	Discount      *Discount        `json:"discount,omitempty"`
+	Taxes         []InvoiceItemTax `json:"taxes" binding:"dive"`
+}
+
+// Discount is taken off a line or the whole invoice before tax. Value is an
+// amount in the billing currency for fixed discounts and a percentage for
+// percentage discounts.
+type Discount struct {
+	Type  models.DiscountType `json:"type" binding:"required,oneof=fixed percentage"`
+	Value json.Number         `json:"value" binding:"required"`
+}
+
+// UnmarshalJSON also accepts a bare number, which is read as a fixed discount
+// so clients sending the old flat discount keep working
+func (d *Discount) UnmarshalJSON(data []byte) error {
+	var amount json.Number
+	if err := json.Unmarshal(data, &amount); err == nil {
+		*d = Discount{Type: models.DiscountTypeFixed, Value: amount}
+		return nil
+	}
+
+	type discount Discount
+	var decoded discount
+	if err := json.Unmarshal(data, &decoded); err != nil {
+		return err
+	}
+	*d = Discount(decoded)
+	return nil
+}
+
+type InvoiceItemTax struct {
+	Name        string     `json:"name" binding:"required"`
+	Rate        money.Rate `json:"rate"`
+	IsInclusive bool       `json:"is_inclusive"`
+	IsCompound  bool       `json:"is_compound"`
 }
 
 type PaymentInfo struct {
@@ -0,0 +1,46 @@
+package request_dto
+
+import "time"
+
+// CreateQuoteRequest describes a quote with the same lines, discounts, taxes
+// and parties as an invoice. The invoice it converts into is issued on the
+// day of the conversion and due DueInDays later.
+type CreateQuoteRequest struct {
+	IssueDate       time.Time     `json:"issue_date" binding:"required"`
+	ExpiryDate      time.Time     `json:"expiry_date" binding:"required"`
+	DueInDays       int           `json:"due_in_days" binding:"min=1"`
+	BillingCurrency string        `json:"billing_currency" binding:"required"`
+	Items           []InvoiceItem `json:"items" binding:"required,dive"`
+	Discount        *Discount     `json:"discount,omitempty"`
+	Notes           string        `json:"notes"`
+	PaymentInfo     *PaymentInfo  `json:"payment_info"`
+
+	// the billed party, issuer and bank account, given the same way as on CreateInvoiceRequest
+	ClientID          *uint          `json:"client_id"`
+	Client            *ClientDetails `json:"client"`
+	BusinessProfileID *uint          `json:"business_profile_id"`
+	BankAccountID     *uint          `json:"bank_account_id"`
+}
+
+// InvoiceRequest is the invoice request the quote is priced as
+func (r *CreateQuoteRequest) InvoiceRequest() *CreateInvoiceRequest {
+	return &CreateInvoiceRequest{
+		IssueDate: r.IssueDate,
+		// only used to validate the request, the real due date is set on conversion
+		DueDate:           r.ExpiryDate.AddDate(0, 0, r.DueInDays),
+		BillingCurrency:   r.BillingCurrency,
+		Items:             r.Items,
+		Discount:          r.Discount,
+		Notes:             r.Notes,
+		PaymentInfo:       r.PaymentInfo,
+		ClientID:          r.ClientID,
+		Client:            r.Client,
+		BusinessProfileID: r.BusinessProfileID,
+		BankAccountID:     r.BankAccountID,
+	}
+}
+
+// DeclineQuoteRequest optionally says why a quote was turned down
+type DeclineQuoteRequest struct {
+	Reason string `json:"reason" binding:"max=500"`
+}
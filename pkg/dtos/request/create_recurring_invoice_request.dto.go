@@ -0,0 +1,41 @@
+package request_dto
+
+import (
+	"time"
+
+	"github.com/Adebayobenjamin/numerisbook/pkg/models"
+)
+
+type CreateRecurringInvoiceRequest struct {
+	Name      string                    `json:"name" binding:"required"`
+	Frequency models.RecurringFrequency `json:"frequency" binding:"required,oneof=weekly monthly quarterly yearly custom"`
+	// RRule is required for custom frequencies, e.g. "FREQ=MONTHLY;BYMONTHDAY=-1"
+	RRule          string                   `json:"rrule" binding:"required_if=Frequency custom"`
+	StartDate      time.Time                `json:"start_date" binding:"required"`
+	EndDate        *time.Time               `json:"end_date"`
+	MaxOccurrences *int                     `json:"max_occurrences" binding:"omitempty,min=1"`
+	DueInDays      int                      `json:"due_in_days" binding:"min=1"`
+	AutoSend       bool                     `json:"auto_send"`
+	Invoice        RecurringInvoiceTemplate `json:"invoice" binding:"required"`
+}
+
+// RecurringInvoiceTemplate is the content of every invoice a profile
+// generates; the dates are filled in on each run
+type RecurringInvoiceTemplate struct {
+	BillingCurrency   string                                  `json:"billing_currency" binding:"required"`
+	Items             []InvoiceItem                           `json:"items" binding:"required,dive"`
+	Discount          *Discount                               `json:"discount,omitempty"`
+	Notes             string                                  `json:"notes"`
+	ReminderSchedules map[models.InvoiceReminderSchedule]bool `json:"reminder_schedules"`
+	PaymentInfo       *PaymentInfo                            `json:"payment_info"`
+
+	// the billed party, given the same way as on CreateInvoiceRequest
+	ClientID *uint          `json:"client_id"`
+	Client   *ClientDetails `json:"client"`
+	// Deprecated: Sender is what Client used to be called and is read the same way
+	Sender *ClientDetails `json:"sender"`
+
+	// the issuer and bank account, read again for every invoice generated
+	BusinessProfileID *uint `json:"business_profile_id"`
+	BankAccountID     *uint `json:"bank_account_id"`
+}
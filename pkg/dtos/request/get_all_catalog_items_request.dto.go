@@ -0,0 +1,17 @@
+package request_dto
+
+import "time"
+
+type GetAllCatalogItemsRequest struct {
+	Limit int `form:"limit"`
+	Page  int `form:"page"`
+	// Search matches items whose SKU or name contains it
+	Search string `form:"search" binding:"max=255"`
+}
+
+// GetCatalogRevenueRequest limits the revenue report to invoices issued
+// between From and To, both days included. Either can be left out.
+type GetCatalogRevenueRequest struct {
+	From *time.Time `form:"from" time_format:"2006-01-02"`
+	To   *time.Time `form:"to" time_format:"2006-01-02"`
+}
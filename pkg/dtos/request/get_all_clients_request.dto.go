@@ -0,0 +1,10 @@
+package request_dto
+
+type GetAllClientsRequest struct {
+	Limit int `form:"limit"`
+	Page  int `form:"page"`
+	// Search matches clients whose name, email or phone contains it
+	Search string `form:"search" binding:"max=255"`
+	// Deleted lists archived clients instead of active ones
+	Deleted bool `form:"deleted"`
+}
@@ -0,0 +1,12 @@
+package request_dto
+
+import "github.com/Adebayobenjamin/numerisbook/pkg/models"
+
+type GetAllPaymentsRequest struct {
+	Limit int `form:"limit"`
+	Page  int `form:"page"`
+	// Method lists only the payments made that way
+	Method models.PaymentMethod `form:"method" binding:"omitempty,oneof=bank_transfer card cash cheque mobile_money other"`
+	// InvoiceID lists only the payments of one invoice
+	InvoiceID uint `form:"invoice_id"`
+}
@@ -0,0 +1,6 @@
+package request_dto
+
+type GetClientCreditsRequest struct {
+	Limit int `form:"limit"`
+	Page  int `form:"page"`
+}
@@ -1,9 +1,47 @@
 package request_dto
 
-import "time"
+import (
+	"time"
+
+	"github.com/Adebayobenjamin/numerisbook/pkg/common/money"
+	"github.com/Adebayobenjamin/numerisbook/pkg/models"
+)
 
 type PaymentConfirmationRequest struct {
-	Amount      float64   `json:"amount" binding:"required"`
-	PaymentDate time.Time `json:"payment_date" binding:"required"`
-	IsPartial   bool      `json:"is_partial"`
+	Amount      money.Money `json:"amount" binding:"required"`
+	PaymentDate time.Time   `json:"payment_date" binding:"required"`
+	IsPartial   bool        `json:"is_partial"`
+	// Method defaults to other when left out
+	Method      models.PaymentMethod `json:"method" binding:"omitempty,oneof=bank_transfer card cash cheque mobile_money other"`
+	Reference   string               `json:"reference" binding:"max=255"`
+	PayerName   string               `json:"payer_name" binding:"max=255"`
+	Notes       string               `json:"notes" binding:"max=2000"`
+	Attachments []PaymentAttachment  `json:"attachments" binding:"max=10,dive"`
+}
+
+// PaymentAttachment links a document backing the payment, stored elsewhere
+type PaymentAttachment struct {
+	Name string `json:"name" binding:"required,max=255"`
+	URL  string `json:"url" binding:"required,url,max=512"`
+}
+
+// Payment is the payment the request confirms on an invoice, for amount in
+// the invoice's currency
+func (r *PaymentConfirmationRequest) Payment(invoiceID uint, amount money.Money) *models.Payment {
+	attachments := make(models.PaymentAttachments, 0, len(r.Attachments))
+	for _, attachment := range r.Attachments {
+		attachments = append(attachments, models.PaymentAttachment{Name: attachment.Name, URL: attachment.URL})
+	}
+
+	return &models.Payment{
+		InvoiceID:   invoiceID,
+		Amount:      amount,
+		IsPartial:   r.IsPartial,
+		Date:        r.PaymentDate,
+		Method:      r.Method,
+		Reference:   r.Reference,
+		PayerName:   r.PayerName,
+		Notes:       r.Notes,
+		Attachments: attachments,
+	}
 }
@@ -0,0 +1,24 @@
+package request_dto
+
+import (
+	"time"
+
+	"github.com/Adebayobenjamin/numerisbook/pkg/common/money"
+	"github.com/Adebayobenjamin/numerisbook/pkg/models"
+)
+
+// RefundPaymentRequest gives back some or all of a payment. Amount defaults
+// to everything not already refunded, Date to today and Method to the one
+// the payment was made with.
+type RefundPaymentRequest struct {
+	Amount    *money.Money         `json:"amount"`
+	Reason    string               `json:"reason" binding:"required,max=500"`
+	Date      *time.Time           `json:"date"`
+	Method    models.PaymentMethod `json:"method" binding:"omitempty,oneof=bank_transfer card cash cheque mobile_money other"`
+	Reference string               `json:"reference" binding:"max=255"`
+}
+
+// ReversePaymentRequest undoes a payment that was recorded by mistake
+type ReversePaymentRequest struct {
+	Reason string `json:"reason" binding:"required,max=500"`
+}
@@ -0,0 +1,7 @@
+package request_dto
+
+type UpdateBrandingRequest struct {
+	BrandColor  string `json:"brand_color" binding:"omitempty,hexcolor,len=7"`
+	LogoURL     string `json:"logo_url" binding:"omitempty,url,max=512"`
+	EmailFooter string `json:"email_footer" binding:"max=500"`
+}
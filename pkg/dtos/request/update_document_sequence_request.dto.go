@@ -0,0 +1,9 @@
+package request_dto
+
+import "github.com/Adebayobenjamin/numerisbook/pkg/models"
+
+type UpdateDocumentSequenceRequest struct {
+	Prefix      string                     `json:"prefix" binding:"max=20"`
+	Format      string                     `json:"format" binding:"required,max=100"`
+	ResetPolicy models.SequenceResetPolicy `json:"reset_policy" binding:"required,oneof=never yearly"`
+}
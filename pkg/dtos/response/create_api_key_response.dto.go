@@ -0,0 +1,9 @@
+package response_dto
+
+import "github.com/Adebayobenjamin/numerisbook/pkg/models"
+
+// CreateAPIKeyResponse carries the plain key, which is only ever shown once
+type CreateAPIKeyResponse struct {
+	models.APIKey
+	Key string `json:"key"`
+}
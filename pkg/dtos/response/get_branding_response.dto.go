@@ -0,0 +1,7 @@
+package response_dto
+
+type GetBrandingResponse struct {
+	BrandColor  *string `json:"brand_color"`
+	LogoURL     *string `json:"logo_url"`
+	EmailFooter *string `json:"email_footer"`
+}
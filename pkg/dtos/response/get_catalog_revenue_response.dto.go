@@ -0,0 +1,27 @@
+package response_dto
+
+import "github.com/Adebayobenjamin/numerisbook/pkg/common/money"
+
+// CatalogItemRevenue is what one catalog item brought in, in one currency,
+// across the invoices issued in the period. NetRevenue is after discounts
+// and before tax.
+type CatalogItemRevenue struct {
+	CatalogItemID uint        `db:"catalog_item_id" json:"catalog_item_id"`
+	SKU           string      `db:"sku" json:"sku"`
+	Name          string      `db:"name" json:"name"`
+	Currency      string      `db:"currency" json:"currency"`
+	InvoiceCount  int         `db:"invoice_count" json:"invoice_count"`
+	Quantity      int         `db:"quantity" json:"quantity"`
+	NetRevenue    money.Money `db:"net_revenue" json:"net_revenue"`
+	TaxTotal      money.Money `db:"tax_total" json:"tax_total"`
+}
+
+// AssignCurrency tags the amounts with the currency they were invoiced in
+func (r *CatalogItemRevenue) AssignCurrency() error {
+	var err error
+	if r.NetRevenue, err = r.NetRevenue.WithCurrency(r.Currency); err != nil {
+		return err
+	}
+	r.TaxTotal, err = r.TaxTotal.WithCurrency(r.Currency)
+	return err
+}
@@ -0,0 +1,19 @@
+package response_dto
+
+import (
+	"github.com/Adebayobenjamin/numerisbook/pkg/common/money"
+	"github.com/Adebayobenjamin/numerisbook/pkg/models"
+)
+
+// ClientCreditBalance is the credit a client has available in one currency
+type ClientCreditBalance struct {
+	Currency string      `db:"currency" json:"currency"`
+	Balance  money.Money `db:"balance" json:"balance"`
+}
+
+// GetClientCreditsResponse is a client's credit balances with the entries of
+// their credit ledger, newest first
+type GetClientCreditsResponse struct {
+	Balances []ClientCreditBalance `json:"balances"`
+	Entries  []models.ClientCredit `json:"entries"`
+}
@@ -3,30 +3,91 @@ package response_dto
 import (
 	"time"
 
+	"github.com/Adebayobenjamin/numerisbook/pkg/common/money"
 	"github.com/Adebayobenjamin/numerisbook/pkg/models"
 )
 
+// GetInvoiceDetailsResponse is an invoice as issued by the Issuer to the
+// client it bills. The Issuer carries the details copied onto the invoice
+// together with the account's current branding.
 type GetInvoiceDetailsResponse struct {
 	ID                 uint                     `db:"id" json:"id"`
 	InvoiceNumber      string                   `db:"invoice_number" json:"invoice_number"`
-	Sender             *models.Sender           `db:"sender" json:"sender"`
 	CustomerID         uint                     `db:"customer_id" json:"customer_id"`
-	Customer           *models.Customer         `db:"customer" json:"customer"`
+	Issuer             *models.Customer         `db:"issuer" json:"issuer"`
 	IssueDate          time.Time                `db:"issue_date" json:"issue_date"`
 	DueDate            time.Time                `db:"due_date" json:"due_date"`
-	TotalAmountDue     float64                  `db:"total_amount_due" json:"total_amount_due"`
-	Subtotal           float64                  `db:"subtotal" json:"subtotal"`
+	TotalAmountDue     money.Money              `db:"total_amount_due" json:"total_amount_due"`
+	CreditedTotal      money.Money              `db:"credited_total" json:"credited_total"`
+	BalanceDue         money.Money              `db:"balance_due" json:"balance_due"`
+	Subtotal           money.Money              `db:"subtotal" json:"subtotal"`
+	TaxTotal           money.Money              `db:"tax_total" json:"tax_total"`
+	TaxSummary         []models.TaxBreakdown    `db:"tax_summary" json:"tax_summary"`
 	IsFullyPaid        bool                     `db:"is_fully_paid" json:"is_fully_paid"`
 	BillingCurrency    string                   `db:"billing_currency" json:"billing_currency"`
 	Items              []models.InvoiceItem     `db:"items" json:"items"`
 	Reminders          []models.InvoiceReminder `db:"reminders" json:"reminders"`
-	Discount           float64                  `db:"discount" json:"discount"`
+	Discount           money.Money              `db:"discount" json:"discount"`
+	DiscountType       models.DiscountType      `db:"discount_type" json:"discount_type"`
+	DiscountRate       money.Rate               `db:"discount_rate" json:"discount_rate"`
+	DiscountTotal      money.Money              `db:"discount_total" json:"discount_total"`
 	Payments           []models.Payment         `db:"payments" json:"payments"`
+	CreditNotes        []models.CreditNote      `db:"credit_notes" json:"credit_notes"`
 	Status             models.InvoiceStatus     `db:"status" json:"status"`
+	Version            uint                     `db:"version" json:"version"`
 	PaymentInformation *models.PaymentInfo      `db:"payment_information" json:"payment_information"`
 	ShareableLink      *string                  `db:"shareable_link" json:"shareable_link"`
 	Notes              string                   `db:"notes" json:"notes"`
 	CreatedAt          time.Time                `db:"created_at" json:"created_at"`
 	UpdatedAt          time.Time                `db:"updated_at" json:"updated_at,omitempty"`
 	DeletedAt          *time.Time               `db:"deleted_at" json:"deleted_at,omitempty"`
+	// the issuer's copied details, served through Issuer
+	models.InvoiceIssuer `json:"-"`
+	// the party billed, as copied onto the invoice when it was issued
+	models.InvoiceClient `json:"client"`
+}
+
+// AssignCurrency tags every amount in the response with the invoice's billing currency
+func (r *GetInvoiceDetailsResponse) AssignCurrency() error {
+	var err error
+	currency := r.BillingCurrency
+
+	if r.TotalAmountDue, err = r.TotalAmountDue.WithCurrency(currency); err != nil {
+		return err
+	}
+	if r.CreditedTotal, err = r.CreditedTotal.WithCurrency(currency); err != nil {
+		return err
+	}
+	if r.Subtotal, err = r.Subtotal.WithCurrency(currency); err != nil {
+		return err
+	}
+	if r.TaxTotal, err = r.TaxTotal.WithCurrency(currency); err != nil {
+		return err
+	}
+	if r.Discount, err = r.Discount.WithCurrency(currency); err != nil {
+		return err
+	}
+	if r.DiscountTotal, err = r.DiscountTotal.WithCurrency(currency); err != nil {
+		return err
+	}
+
+	for idx := range r.Items {
+		if err = r.Items[idx].AssignCurrency(currency); err != nil {
+			return err
+		}
+	}
+
+	for idx := range r.Payments {
+		if r.Payments[idx].Amount, err = r.Payments[idx].Amount.WithCurrency(currency); err != nil {
+			return err
+		}
+	}
+
+	for idx := range r.CreditNotes {
+		if err = r.CreditNotes[idx].AssignCurrency(); err != nil {
+			return err
+		}
+	}
+
+	return nil
 }
@@ -1,12 +1,47 @@
 package response_dto
 
+import "github.com/Adebayobenjamin/numerisbook/pkg/common/money"
+
 type GetInvoiceStatisticsResponse struct {
-	TotalPaid          int     `db:"total_paid" json:"total_paid"`
-	TotalPaidAmount    float64 `db:"total_paid_amount" json:"total_paid_amount"`
-	TotalOverDue       int     `db:"total_over_due" json:"total_over_due"`
-	TotalOverDueAmount float64 `db:"total_over_due_amount" json:"total_over_due_amount"`
-	TotalDraft         int     `db:"total_draft" json:"total_draft"`
-	TotalDraftAmount   float64 `db:"total_draft_amount" json:"total_draft_amount"`
-	TotalUnpaid        int     `db:"total_unpaid" json:"total_unpaid"`
-	TotalUnpaidAmount  float64 `db:"total_unpaid_amount" json:"total_unpaid_amount"`
+	TotalPaid    int `db:"total_paid" json:"total_paid"`
+	TotalOverDue int `db:"total_over_due" json:"total_over_due"`
+	TotalDraft   int `db:"total_draft" json:"total_draft"`
+	TotalUnpaid  int `db:"total_unpaid" json:"total_unpaid"`
+	// credit notes issued against the customer's invoices
+	TotalCreditNotes int `db:"total_credit_notes" json:"total_credit_notes"`
+	// amounts cannot be added up across currencies, so they are given per currency
+	Currencies []InvoiceCurrencyStatistics `db:"-" json:"currencies"`
+}
+
+// InvoiceCurrencyStatistics holds the invoice amounts of the statistics in
+// one currency
+type InvoiceCurrencyStatistics struct {
+	Currency           string      `db:"currency" json:"currency"`
+	TotalPaidAmount    money.Money `db:"total_paid_amount" json:"total_paid_amount"`
+	TotalOverDueAmount money.Money `db:"total_over_due_amount" json:"total_over_due_amount"`
+	TotalDraftAmount   money.Money `db:"total_draft_amount" json:"total_draft_amount"`
+	TotalUnpaidAmount  money.Money `db:"total_unpaid_amount" json:"total_unpaid_amount"`
+	// what credit notes took off the invoices
+	TotalCreditedAmount money.Money `db:"total_credited_amount" json:"total_credited_amount"`
+	// what clients have overpaid and not yet had applied to their invoices
+	TotalClientCredit money.Money `db:"total_client_credit" json:"total_client_credit"`
+}
+
+// AssignCurrency tags the amounts with the currency they are in
+func (s *InvoiceCurrencyStatistics) AssignCurrency() error {
+	amounts := []*money.Money{
+		&s.TotalPaidAmount,
+		&s.TotalOverDueAmount,
+		&s.TotalDraftAmount,
+		&s.TotalUnpaidAmount,
+		&s.TotalCreditedAmount,
+		&s.TotalClientCredit,
+	}
+	for _, amount := range amounts {
+		var err error
+		if *amount, err = amount.WithCurrency(s.Currency); err != nil {
+			return err
+		}
+	}
+	return nil
 }
@@ -0,0 +1,57 @@
+package response_dto
+
+import (
+	"time"
+
+	"github.com/Adebayobenjamin/numerisbook/pkg/common/money"
+	"github.com/Adebayobenjamin/numerisbook/pkg/models"
+)
+
+// PublicInvoiceResponse is the read-only view of an invoice served to anyone
+// holding a share link. It leaves out internal IDs, reminders, payments and
+// everything else only the issuer should see.
+type PublicInvoiceResponse struct {
+	InvoiceNumber      string                    `json:"invoice_number"`
+	Status             models.InvoiceStatus      `json:"status"`
+	IssueDate          time.Time                 `json:"issue_date"`
+	DueDate            time.Time                 `json:"due_date"`
+	BillingCurrency    string                    `json:"billing_currency"`
+	From               PublicInvoiceParty        `json:"from"`
+	BillTo             PublicInvoiceParty        `json:"bill_to"`
+	Items              []PublicInvoiceItem       `json:"items"`
+	Subtotal           money.Money               `json:"subtotal"`
+	DiscountTotal      money.Money               `json:"discount_total"`
+	TaxTotal           money.Money               `json:"tax_total"`
+	TaxSummary         []models.TaxBreakdown     `json:"tax_summary"`
+	TotalAmountDue     money.Money               `json:"total_amount_due"`
+	AmountPaid         money.Money               `json:"amount_paid"`
+	BalanceDue         money.Money               `json:"balance_due"`
+	PaymentInformation *PublicPaymentInformation `json:"payment_information"`
+	Notes              string                    `json:"notes"`
+}
+
+type PublicInvoiceParty struct {
+	Name       string  `json:"name"`
+	Email      string  `json:"email"`
+	Phone      string  `json:"phone"`
+	Address    string  `json:"address"`
+	LogoURL    *string `json:"logo_url,omitempty"`
+	BrandColor *string `json:"brand_color,omitempty"`
+}
+
+type PublicInvoiceItem struct {
+	Description    string      `json:"description"`
+	Quantity       int         `json:"quantity"`
+	UnitPrice      money.Money `json:"unit_price"`
+	DiscountAmount money.Money `json:"discount_amount"`
+	TaxTotal       money.Money `json:"tax_total"`
+	TotalPrice     money.Money `json:"total_price"`
+}
+
+type PublicPaymentInformation struct {
+	BankName      string `json:"bank_name"`
+	AccountName   string `json:"account_name"`
+	AccountNumber string `json:"account_number"`
+	AchRoutingNo  string `json:"ach_routing_no"`
+	BankAddress   string `json:"bank_address"`
+}
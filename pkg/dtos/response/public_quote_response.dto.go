@@ -0,0 +1,27 @@
+package response_dto
+
+import (
+	"time"
+
+	"github.com/Adebayobenjamin/numerisbook/pkg/common/money"
+	"github.com/Adebayobenjamin/numerisbook/pkg/models"
+)
+
+// PublicQuoteResponse is the view of a quote served to the client it was sent
+// to, who can accept or decline it from the same link
+type PublicQuoteResponse struct {
+	QuoteNumber     string              `json:"quote_number"`
+	Status          models.QuoteStatus  `json:"status"`
+	IssueDate       time.Time           `json:"issue_date"`
+	ExpiryDate      time.Time           `json:"expiry_date"`
+	BillingCurrency string              `json:"billing_currency"`
+	From            PublicInvoiceParty  `json:"from"`
+	BillTo          PublicInvoiceParty  `json:"bill_to"`
+	Items           []PublicInvoiceItem `json:"items"`
+	Subtotal        money.Money         `json:"subtotal"`
+	DiscountTotal   money.Money         `json:"discount_total"`
+	TaxTotal        money.Money         `json:"tax_total"`
+	TotalAmount     money.Money         `json:"total_amount"`
+	Notes           string              `json:"notes"`
+	RespondedAt     *time.Time          `json:"responded_at"`
+}
@@ -0,0 +1,13 @@
+package response_dto
+
+import "time"
+
+// ShareLinkResponse is a signed link to the public view of an invoice and how often it has been opened
+type ShareLinkResponse struct {
+	URL           string     `json:"url"`
+	ExpiresAt     time.Time  `json:"expires_at"`
+	FirstViewedAt *time.Time `json:"first_viewed_at"`
+	LastViewedAt  *time.Time `json:"last_viewed_at"`
+	ViewCount     int        `json:"view_count"`
+	CreatedAt     time.Time  `json:"created_at"`
+}
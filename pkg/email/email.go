@@ -0,0 +1,42 @@
+package email
+
+import (
+	"context"
+	"os"
+)
+
+// Message is a rendered email ready to be handed to a Mailer. Text and HTML
+// are sent as alternatives of the same content.
+type Message struct {
+	FromName    string
+	To          string
+	ToName      string
+	ReplyTo     string
+	Subject     string
+	Text        string
+	HTML        string
+	Attachments []Attachment
+}
+
+// Attachment is a file sent along with a Message
+type Attachment struct {
+	Filename    string
+	ContentType string
+	Data        []byte
+}
+
+// Mailer delivers email messages
+type Mailer interface {
+	Send(ctx context.Context, message Message) error
+}
+
+// NewMailer returns an SMTP mailer configured from the SMTP_* environment variables
+func NewMailer() Mailer {
+	return NewSMTPMailer(SMTPConfig{
+		Host:     os.Getenv("SMTP_HOST"),
+		Port:     os.Getenv("SMTP_PORT"),
+		Username: os.Getenv("SMTP_USERNAME"),
+		Password: os.Getenv("SMTP_PASSWORD"),
+		From:     os.Getenv("SMTP_FROM"),
+	})
+}
@@ -0,0 +1,228 @@
+package email
+
+import (
+	"bytes"
+	"context"
+	"crypto/tls"
+	"encoding/base64"
+	"errors"
+	"fmt"
+	"io"
+	"mime"
+	"mime/multipart"
+	"mime/quotedprintable"
+	"net"
+	"net/mail"
+	"net/smtp"
+	"net/textproto"
+	"time"
+)
+
+// smtpDialTimeout bounds how long connecting to the SMTP server may take when ctx has no deadline
+const smtpDialTimeout = 10 * time.Second
+
+// SMTPConfig holds the connection details of an SMTP server. From is the
+// envelope sender and the address every message is sent from.
+type SMTPConfig struct {
+	Host     string
+	Port     string
+	Username string
+	Password string
+	From     string
+}
+
+type smtpMailer struct {
+	config SMTPConfig
+}
+
+// Send implements Mailer.
+func (s *smtpMailer) Send(ctx context.Context, message Message) error {
+	if s.config.Host == "" || s.config.From == "" {
+		return errors.New("smtp is not configured")
+	}
+	if message.To == "" {
+		return errors.New("email has no recipient")
+	}
+
+	body, err := s.buildMessage(message)
+	if err != nil {
+		return err
+	}
+
+	dialer := net.Dialer{Timeout: smtpDialTimeout}
+	conn, err := dialer.DialContext(ctx, "tcp", net.JoinHostPort(s.config.Host, s.config.Port))
+	if err != nil {
+		return fmt.Errorf("failed to connect to smtp server: %w", err)
+	}
+	if deadline, ok := ctx.Deadline(); ok {
+		conn.SetDeadline(deadline)
+	}
+
+	client, err := smtp.NewClient(conn, s.config.Host)
+	if err != nil {
+		conn.Close()
+		return fmt.Errorf("failed to start smtp session: %w", err)
+	}
+	defer client.Close()
+
+	if ok, _ := client.Extension("STARTTLS"); ok {
+		if err := client.StartTLS(&tls.Config{ServerName: s.config.Host}); err != nil {
+			return fmt.Errorf("failed to start tls: %w", err)
+		}
+	}
+
+	if s.config.Username != "" {
+		auth := smtp.PlainAuth("", s.config.Username, s.config.Password, s.config.Host)
+		if err := client.Auth(auth); err != nil {
+			return fmt.Errorf("failed to authenticate with smtp server: %w", err)
+		}
+	}
+
+	if err := client.Mail(s.config.From); err != nil {
+		return fmt.Errorf("smtp server rejected sender: %w", err)
+	}
+	if err := client.Rcpt(message.To); err != nil {
+		return fmt.Errorf("smtp server rejected recipient: %w", err)
+	}
+
+	writer, err := client.Data()
+	if err != nil {
+		return fmt.Errorf("failed to start email body: %w", err)
+	}
+	if _, err := writer.Write(body); err != nil {
+		return fmt.Errorf("failed to write email body: %w", err)
+	}
+	if err := writer.Close(); err != nil {
+		return fmt.Errorf("smtp server rejected email: %w", err)
+	}
+
+	return client.Quit()
+}
+
+// buildMessage encodes the message as a multipart/alternative MIME email,
+// wrapped in multipart/mixed when it carries attachments
+func (s *smtpMailer) buildMessage(message Message) ([]byte, error) {
+	from := mail.Address{Name: message.FromName, Address: s.config.From}
+	to := mail.Address{Name: message.ToName, Address: message.To}
+
+	var out bytes.Buffer
+	fmt.Fprintf(&out, "From: %s\r\n", from.String())
+	fmt.Fprintf(&out, "To: %s\r\n", to.String())
+	if message.ReplyTo != "" {
+		fmt.Fprintf(&out, "Reply-To: %s\r\n", message.ReplyTo)
+	}
+	fmt.Fprintf(&out, "Subject: %s\r\n", mime.QEncoding.Encode("utf-8", message.Subject))
+	fmt.Fprintf(&out, "Date: %s\r\n", time.Now().Format(time.RFC1123Z))
+	out.WriteString("MIME-Version: 1.0\r\n")
+
+	body, boundary, err := buildAlternatives(message)
+	if err != nil {
+		return nil, fmt.Errorf("failed to build email: %w", err)
+	}
+
+	if len(message.Attachments) == 0 {
+		fmt.Fprintf(&out, "Content-Type: multipart/alternative; boundary=%q\r\n\r\n", boundary)
+		out.Write(body)
+		return out.Bytes(), nil
+	}
+
+	var buf bytes.Buffer
+	mixed := multipart.NewWriter(&buf)
+	part, err := mixed.CreatePart(textproto.MIMEHeader{
+		"Content-Type": {mime.FormatMediaType("multipart/alternative", map[string]string{"boundary": boundary})},
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to build email: %w", err)
+	}
+	if _, err := part.Write(body); err != nil {
+		return nil, fmt.Errorf("failed to build email: %w", err)
+	}
+
+	for _, attachment := range message.Attachments {
+		if err := writeAttachment(mixed, attachment); err != nil {
+			return nil, fmt.Errorf("failed to build email: %w", err)
+		}
+	}
+	if err := mixed.Close(); err != nil {
+		return nil, fmt.Errorf("failed to build email: %w", err)
+	}
+
+	fmt.Fprintf(&out, "Content-Type: multipart/mixed; boundary=%q\r\n\r\n", mixed.Boundary())
+	out.Write(buf.Bytes())
+	return out.Bytes(), nil
+}
+
+// buildAlternatives encodes the text and HTML bodies as a multipart/alternative
+// body and returns it together with its boundary
+func buildAlternatives(message Message) ([]byte, string, error) {
+	var buf bytes.Buffer
+	parts := multipart.NewWriter(&buf)
+
+	alternatives := []struct {
+		contentType string
+		content     string
+	}{
+		{"text/plain; charset=utf-8", message.Text},
+		{"text/html; charset=utf-8", message.HTML},
+	}
+	for _, alternative := range alternatives {
+		if alternative.content == "" {
+			continue
+		}
+		part, err := parts.CreatePart(textproto.MIMEHeader{
+			"Content-Type":              {alternative.contentType},
+			"Content-Transfer-Encoding": {"quoted-printable"},
+		})
+		if err != nil {
+			return nil, "", err
+		}
+		encoder := quotedprintable.NewWriter(part)
+		if _, err := encoder.Write([]byte(alternative.content)); err != nil {
+			return nil, "", err
+		}
+		if err := encoder.Close(); err != nil {
+			return nil, "", err
+		}
+	}
+	if err := parts.Close(); err != nil {
+		return nil, "", err
+	}
+
+	return buf.Bytes(), parts.Boundary(), nil
+}
+
+// writeAttachment adds a base64 encoded attachment part, wrapping lines at 76 characters
+func writeAttachment(parts *multipart.Writer, attachment Attachment) error {
+	contentType := attachment.ContentType
+	if contentType == "" {
+		contentType = "application/octet-stream"
+	}
+	part, err := parts.CreatePart(textproto.MIMEHeader{
+		"Content-Type":              {mime.FormatMediaType(contentType, map[string]string{"name": attachment.Filename})},
+		"Content-Disposition":       {mime.FormatMediaType("attachment", map[string]string{"filename": attachment.Filename})},
+		"Content-Transfer-Encoding": {"base64"},
+	})
+	if err != nil {
+		return err
+	}
+
+	encoded := base64.StdEncoding.EncodeToString(attachment.Data)
+	for len(encoded) > 0 {
+		line := encoded
+		if len(line) > 76 {
+			line = line[:76]
+		}
+		encoded = encoded[len(line):]
+		if _, err := io.WriteString(part, line+"\r\n"); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// NewSMTPMailer returns a Mailer that delivers through the given SMTP server.
+// STARTTLS is used whenever the server offers it and authentication is
+// skipped when no username is configured.
+func NewSMTPMailer(config SMTPConfig) Mailer {
+	return &smtpMailer{config: config}
+}
@@ -0,0 +1,191 @@
+package email
+
+import (
+	"bufio"
+	"bytes"
+	"context"
+	"encoding/base64"
+	"io"
+	"mime"
+	"mime/multipart"
+	"net"
+	"net/mail"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// fakeSMTPServer accepts a single SMTP session and records what it was sent
+type fakeSMTPServer struct {
+	listener net.Listener
+	from     string
+	to       []string
+	data     string
+	done     chan struct{}
+}
+
+func startFakeSMTPServer(t *testing.T) *fakeSMTPServer {
+	listener, err := net.Listen("tcp", "127.0.0.1:0")
+	require.NoError(t, err)
+
+	server := &fakeSMTPServer{listener: listener, done: make(chan struct{})}
+	go server.serve()
+	t.Cleanup(func() { listener.Close() })
+	return server
+}
+
+func (f *fakeSMTPServer) serve() {
+	defer close(f.done)
+
+	conn, err := f.listener.Accept()
+	if err != nil {
+		return
+	}
+	defer conn.Close()
+
+	reader := bufio.NewReader(conn)
+	reply := func(line string) { io.WriteString(conn, line+"\r\n") }
+
+	reply("220 localhost ESMTP")
+	for {
+		line, err := reader.ReadString('\n')
+		if err != nil {
+			return
+		}
+		command := strings.TrimSpace(line)
+		switch verb := strings.ToUpper(strings.SplitN(command, " ", 2)[0]); verb {
+		case "EHLO", "HELO":
+			reply("250 localhost")
+		case "MAIL":
+			f.from = strings.Trim(strings.TrimPrefix(command, "MAIL FROM:"), "<>")
+			reply("250 OK")
+		case "RCPT":
+			f.to = append(f.to, strings.Trim(strings.TrimPrefix(command, "RCPT TO:"), "<>"))
+			reply("250 OK")
+		case "DATA":
+			reply("354 End data with <CR><LF>.<CR><LF>")
+			var data strings.Builder
+			for {
+				line, err := reader.ReadString('\n')
+				if err != nil {
+					return
+				}
+				if line == ".\r\n" {
+					break
+				}
+				data.WriteString(line)
+			}
+			f.data = data.String()
+			reply("250 OK")
+		case "QUIT":
+			reply("221 Bye")
+			return
+		default:
+			reply("502 Command not implemented")
+		}
+	}
+}
+
+func TestSMTPMailer_Send(t *testing.T) {
+	server := startFakeSMTPServer(t)
+	host, port, _ := net.SplitHostPort(server.listener.Addr().String())
+
+	mailer := NewSMTPMailer(SMTPConfig{Host: host, Port: port, From: "billing@numeris.test"})
+	err := mailer.Send(context.Background(), Message{
+		FromName: "Acme Ltd",
+		To:       "ada@example.com",
+		ToName:   "Ada Lovelace",
+		ReplyTo:  "accounts@acme.test",
+		Subject:  "Invoice INV-2024-00001 from Acme Ltd",
+		Text:     "Plain body",
+		HTML:     "<p>HTML body</p>",
+	})
+	require.NoError(t, err)
+	<-server.done
+
+	assert.Equal(t, "billing@numeris.test", server.from)
+	assert.Equal(t, []string{"ada@example.com"}, server.to)
+
+	message, err := mail.ReadMessage(strings.NewReader(server.data))
+	require.NoError(t, err)
+	assert.Equal(t, `"Acme Ltd" <billing@numeris.test>`, message.Header.Get("From"))
+	assert.Equal(t, `"Ada Lovelace" <ada@example.com>`, message.Header.Get("To"))
+	assert.Equal(t, "accounts@acme.test", message.Header.Get("Reply-To"))
+	assert.Equal(t, "Invoice INV-2024-00001 from Acme Ltd", message.Header.Get("Subject"))
+
+	mediaType, params, err := mime.ParseMediaType(message.Header.Get("Content-Type"))
+	require.NoError(t, err)
+	assert.Equal(t, "multipart/alternative", mediaType)
+
+	parts := multipart.NewReader(message.Body, params["boundary"])
+	var bodies []string
+	for {
+		part, err := parts.NextPart()
+		if err == io.EOF {
+			break
+		}
+		require.NoError(t, err)
+		content, _ := io.ReadAll(part)
+		bodies = append(bodies, part.Header.Get("Content-Type")+": "+string(content))
+	}
+	assert.Equal(t, []string{
+		"text/plain; charset=utf-8: Plain body",
+		"text/html; charset=utf-8: <p>HTML body</p>",
+	}, bodies)
+}
+
+func TestSMTPMailer_SendWithAttachment(t *testing.T) {
+	server := startFakeSMTPServer(t)
+	host, port, _ := net.SplitHostPort(server.listener.Addr().String())
+
+	document := bytes.Repeat([]byte("%PDF-1.4 binary\x00\xff"), 20)
+	mailer := NewSMTPMailer(SMTPConfig{Host: host, Port: port, From: "billing@numeris.test"})
+	err := mailer.Send(context.Background(), Message{
+		To:      "ada@example.com",
+		Subject: "Invoice INV-2024-00001",
+		Text:    "Plain body",
+		HTML:    "<p>HTML body</p>",
+		Attachments: []Attachment{
+			{Filename: "INV-2024-00001.pdf", ContentType: "application/pdf", Data: document},
+		},
+	})
+	require.NoError(t, err)
+	<-server.done
+
+	message, err := mail.ReadMessage(strings.NewReader(server.data))
+	require.NoError(t, err)
+
+	mediaType, params, err := mime.ParseMediaType(message.Header.Get("Content-Type"))
+	require.NoError(t, err)
+	assert.Equal(t, "multipart/mixed", mediaType)
+
+	parts := multipart.NewReader(message.Body, params["boundary"])
+
+	body, err := parts.NextPart()
+	require.NoError(t, err)
+	bodyType, _, err := mime.ParseMediaType(body.Header.Get("Content-Type"))
+	require.NoError(t, err)
+	assert.Equal(t, "multipart/alternative", bodyType)
+
+	attachment, err := parts.NextPart()
+	require.NoError(t, err)
+	assert.Equal(t, "INV-2024-00001.pdf", attachment.FileName())
+	assert.Equal(t, "base64", attachment.Header.Get("Content-Transfer-Encoding"))
+	encoded, _ := io.ReadAll(attachment)
+	decoded, err := base64.StdEncoding.DecodeString(strings.ReplaceAll(string(encoded), "\r\n", ""))
+	require.NoError(t, err)
+	assert.Equal(t, document, decoded)
+
+	_, err = parts.NextPart()
+	assert.Equal(t, io.EOF, err)
+}
+
+func TestSMTPMailer_SendRequiresConfiguration(t *testing.T) {
+	err := NewSMTPMailer(SMTPConfig{}).Send(context.Background(), Message{To: "ada@example.com"})
+	assert.EqualError(t, err, "smtp is not configured")
+
+	err = NewSMTPMailer(SMTPConfig{Host: "localhost", From: "billing@numeris.test"}).Send(context.Background(), Message{})
+	assert.EqualError(t, err, "email has no recipient")
+}
@@ -0,0 +1,124 @@
+package email
+
+import (
+	"bytes"
+	"embed"
+	"fmt"
+	htmltemplate "html/template"
+	"strings"
+	texttemplate "text/template"
+	"time"
+)
+
+// Template names one of the transactional emails in templates/
+type Template string
+
+const (
+	TemplateInvoiceSent     Template = "invoice_sent"
+	TemplatePaymentReminder Template = "payment_reminder"
+	TemplatePaymentReceived Template = "payment_received"
+	TemplateInvoiceOverdue  Template = "invoice_overdue"
+)
+
+// defaultBrandColor is used for headers and buttons when the sender has not picked a colour
+const defaultBrandColor = "#1f2937"
+
+//go:embed templates/*.tmpl
+var templateFiles embed.FS
+
+// Branding carries the sender's details shown in the header and footer of every email
+type Branding struct {
+	Name    string
+	Email   string
+	Phone   string
+	Address string
+	LogoURL string
+	Color   string
+	Footer  string
+}
+
+// TemplateData holds the values the invoice templates can refer to. Amounts
+// are pre-formatted so the templates do not need to know about currencies.
+type TemplateData struct {
+	Brand         Branding
+	RecipientName string
+	InvoiceNumber string
+	Currency      string
+	TotalAmount   string
+	AmountPaid    string
+	Balance       string
+	PaidInFull    bool
+	IssueDate     time.Time
+	DueDate       time.Time
+	PaymentDate   time.Time
+	InvoiceURL    string
+	Notes         string
+}
+
+// Rendered is the subject and bodies produced from a template
+type Rendered struct {
+	Subject string
+	Text    string
+	HTML    string
+}
+
+var templateFuncs = map[string]interface{}{
+	"date": func(t time.Time) string {
+		return t.Format("January 2, 2006")
+	},
+}
+
+type compiledTemplate struct {
+	text *texttemplate.Template
+	html *htmltemplate.Template
+}
+
+var compiledTemplates = mustCompileTemplates(
+	TemplateInvoiceSent,
+	TemplatePaymentReminder,
+	TemplatePaymentReceived,
+	TemplateInvoiceOverdue,
+)
+
+// Render produces the subject, plain text and HTML bodies of a template
+func Render(name Template, data TemplateData) (*Rendered, error) {
+	compiled, ok := compiledTemplates[name]
+	if !ok {
+		return nil, fmt.Errorf("unknown email template %q", name)
+	}
+
+	if data.Brand.Color == "" {
+		data.Brand.Color = defaultBrandColor
+	}
+
+	var subject, text, html bytes.Buffer
+	if err := compiled.text.ExecuteTemplate(&subject, "subject", data); err != nil {
+		return nil, fmt.Errorf("failed to render %s subject: %w", name, err)
+	}
+	if err := compiled.text.ExecuteTemplate(&text, "text_layout", data); err != nil {
+		return nil, fmt.Errorf("failed to render %s text: %w", name, err)
+	}
+	if err := compiled.html.ExecuteTemplate(&html, "html_layout", data); err != nil {
+		return nil, fmt.Errorf("failed to render %s html: %w", name, err)
+	}
+
+	return &Rendered{
+		Subject: strings.TrimSpace(subject.String()),
+		Text:    strings.TrimSpace(text.String()) + "\n",
+		HTML:    html.String(),
+	}, nil
+}
+
+// mustCompileTemplates parses every template together with the shared layout
+// once at start up, so a broken template fails fast rather than on first send
+func mustCompileTemplates(names ...Template) map[Template]compiledTemplate {
+	compiled := make(map[Template]compiledTemplate, len(names))
+	for _, name := range names {
+		files := []string{"templates/layout.tmpl", fmt.Sprintf("templates/%s.tmpl", name)}
+		compiled[name] = compiledTemplate{
+			text: texttemplate.Must(texttemplate.New(string(name)).Funcs(templateFuncs).ParseFS(templateFiles, files...)),
+			html: htmltemplate.Must(htmltemplate.New(string(name)).Funcs(templateFuncs).ParseFS(templateFiles, files...)),
+		}
+	}
+	return compiled
+}
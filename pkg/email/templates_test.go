@@ -0,0 +1,103 @@
+package email
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func templateData() TemplateData {
+	return TemplateData{
+		Brand: Branding{
+			Name:   "Acme Ltd",
+			Email:  "accounts@acme.test",
+			Color:  "#ff6600",
+			Footer: "Acme Ltd is registered in England.",
+		},
+		RecipientName: "Ada",
+		InvoiceNumber: "INV-2024-00001",
+		Currency:      "USD",
+		TotalAmount:   "1000.00",
+		AmountPaid:    "250.00",
+		Balance:       "750.00",
+		IssueDate:     time.Date(2024, 3, 1, 0, 0, 0, 0, time.UTC),
+		DueDate:       time.Date(2024, 3, 15, 0, 0, 0, 0, time.UTC),
+		PaymentDate:   time.Date(2024, 3, 10, 0, 0, 0, 0, time.UTC),
+		InvoiceURL:    "https://numeris.test/i/abc",
+	}
+}
+
+func TestRender(t *testing.T) {
+	tests := []struct {
+		template Template
+		subject  string
+		text     string
+	}{
+		{
+			template: TemplateInvoiceSent,
+			subject:  "Invoice INV-2024-00001 from Acme Ltd",
+			text:     "Acme Ltd has sent you invoice INV-2024-00001 for USD 750.00, due on March 15, 2024.",
+		},
+		{
+			template: TemplatePaymentReminder,
+			subject:  "Reminder: invoice INV-2024-00001 is due on March 15, 2024",
+			text:     "for USD 750.00 is due on March 15, 2024.",
+		},
+		{
+			template: TemplateInvoiceOverdue,
+			subject:  "Overdue: invoice INV-2024-00001 was due on March 15, 2024",
+			text:     "USD 750.00 is still outstanding",
+		},
+		{
+			template: TemplatePaymentReceived,
+			subject:  "Payment received for invoice INV-2024-00001",
+			text:     "The remaining balance is USD 750.00, due on March 15, 2024.",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(string(tt.template), func(t *testing.T) {
+			rendered, err := Render(tt.template, templateData())
+
+			require.NoError(t, err)
+			assert.Equal(t, tt.subject, rendered.Subject)
+			assert.Contains(t, rendered.Text, "Hi Ada,")
+			assert.Contains(t, rendered.Text, tt.text)
+			assert.Contains(t, rendered.Text, "Acme Ltd is registered in England.")
+			assert.Contains(t, rendered.HTML, "background:#ff6600")
+			assert.Contains(t, rendered.HTML, `href="https://numeris.test/i/abc"`)
+		})
+	}
+}
+
+func TestRenderEscapesHTML(t *testing.T) {
+	data := templateData()
+	data.Brand.Name = `<script>alert("x")</script>`
+	data.Brand.Color = `red;background:url(javascript:alert(1))`
+
+	rendered, err := Render(TemplateInvoiceSent, data)
+
+	require.NoError(t, err)
+	assert.NotContains(t, rendered.HTML, "<script>")
+	assert.NotContains(t, rendered.HTML, "javascript:")
+}
+
+func TestRenderDefaultsAndPaidInFull(t *testing.T) {
+	data := templateData()
+	data.Brand.Color = ""
+	data.Balance = "0.00"
+	data.PaidInFull = true
+
+	rendered, err := Render(TemplatePaymentReceived, data)
+
+	require.NoError(t, err)
+	assert.Contains(t, rendered.HTML, "background:"+defaultBrandColor)
+	assert.Contains(t, rendered.Text, "The invoice is now paid in full.")
+}
+
+func TestRenderUnknownTemplate(t *testing.T) {
+	_, err := Render("welcome", templateData())
+	assert.EqualError(t, err, `unknown email template "welcome"`)
+}
@@ -3,6 +3,9 @@ package helper
 import (
 	"encoding/json"
 	"fmt"
+	"regexp"
+	"strconv"
+	"strings"
 	"time"
 
 	"github.com/gin-gonic/gin"
@@ -41,11 +44,73 @@ func JSONUnmarshalToType[T any](data any, valueType *T) error {
 	return nil
 }
 
-func GenerateInvoiceNumber() string {
-	// TODO: Generate invoice number format
-	return fmt.Sprintf("INV-%d", time.Now().Unix())
-}
-
 func ReturnPointer[T any](value T) *T {
 	return &value
 }
+
+var documentNumberToken = regexp.MustCompile(`\{([A-Z]+)(?::(\d+))?\}`)
+
+// FormatDocumentNumber fills in a numbering template such as
+// "{PREFIX}-{YYYY}-{SEQ:05}". The supported tokens are {PREFIX}, {YYYY}, {YY},
+// {MM} and {SEQ}; {SEQ:05} pads the sequence with zeros to five digits.
+func FormatDocumentNumber(format string, prefix string, date time.Time, sequence uint64) (string, error) {
+	var err error
+	hasSequence := false
+
+	number := documentNumberToken.ReplaceAllStringFunc(format, func(token string) string {
+		parts := documentNumberToken.FindStringSubmatch(token)
+		name, width := parts[1], parts[2]
+
+		if width != "" && name != "SEQ" {
+			err = fmt.Errorf("token {%s} does not take a width", name)
+			return token
+		}
+
+		switch name {
+		case "PREFIX":
+			return prefix
+		case "YYYY":
+			return fmt.Sprintf("%04d", date.Year())
+		case "YY":
+			return fmt.Sprintf("%02d", date.Year()%100)
+		case "MM":
+			return fmt.Sprintf("%02d", int(date.Month()))
+		case "SEQ":
+			hasSequence = true
+			digits, _ := strconv.Atoi(width)
+			return fmt.Sprintf("%0*d", digits, sequence)
+		default:
+			err = fmt.Errorf("unknown token {%s}", name)
+			return token
+		}
+	})
+	if err != nil {
+		return "", err
+	}
+
+	if !hasSequence {
+		return "", fmt.Errorf("format %q must contain a {SEQ} token", format)
+	}
+
+	return number, nil
+}
+
+var phoneSeparators = strings.NewReplacer(" ", "", "-", "", ".", "", "(", "", ")", "")
+
+var e164Phone = regexp.MustCompile(`^\+[1-9][0-9]{6,14}$`)
+
+// NormalizePhone strips the spaces, dashes, dots and brackets people type
+// into phone numbers and checks that what is left is an international
+// number such as "+14155550123"
+func NormalizePhone(phone string) (string, error) {
+	normalized := phoneSeparators.Replace(strings.TrimSpace(phone))
+	if strings.HasPrefix(normalized, "00") {
+		normalized = "+" + normalized[2:]
+	}
+
+	if !e164Phone.MatchString(normalized) {
+		return "", fmt.Errorf("phone %q must be an international number starting with a country code, e.g. +14155550123", phone)
+	}
+
+	return normalized, nil
+}
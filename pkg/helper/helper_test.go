@@ -1,7 +1,6 @@
 package helper
 
 import (
-	"fmt"
 	"testing"
 	"time"
 
@@ -100,24 +99,69 @@ func TestJSONUnmarshalToType(t *testing.T) {
 	})
 }
 
-func TestGenerateInvoiceNumber(t *testing.T) {
-	t.Run("format check", func(t *testing.T) {
-		now := time.Now()
-		invoiceNumber := GenerateInvoiceNumber()
+func TestFormatDocumentNumber(t *testing.T) {
+	date := time.Date(2025, time.March, 14, 0, 0, 0, 0, time.UTC)
 
-		assert.Contains(t, invoiceNumber, "INV-")
-		expectedTimestamp := fmt.Sprintf("%d", now.Unix())
-		assert.Contains(t, invoiceNumber, expectedTimestamp)
-	})
+	tests := []struct {
+		name     string
+		format   string
+		sequence uint64
+		expected string
+		wantErr  bool
+		errMsg   string
+	}{
+		{
+			name:     "default format",
+			format:   "{PREFIX}-{YYYY}-{SEQ:05}",
+			sequence: 42,
+			expected: "INV-2025-00042",
+		},
+		{
+			name:     "short year and month",
+			format:   "{PREFIX}/{YY}{MM}/{SEQ}",
+			sequence: 7,
+			expected: "INV/2503/7",
+		},
+		{
+			name:     "sequence wider than padding",
+			format:   "{SEQ:03}",
+			sequence: 12345,
+			expected: "12345",
+		},
+		{
+			name:    "missing sequence",
+			format:  "{PREFIX}-{YYYY}",
+			wantErr: true,
+			errMsg:  "must contain a {SEQ} token",
+		},
+		{
+			name:    "unknown token",
+			format:  "{PREFIX}-{DAY}-{SEQ}",
+			wantErr: true,
+			errMsg:  "unknown token {DAY}",
+		},
+		{
+			name:    "width on a token that is not the sequence",
+			format:  "{YYYY:02}-{SEQ}",
+			wantErr: true,
+			errMsg:  "does not take a width",
+		},
+	}
 
-	t.Run("uniqueness check", func(t *testing.T) {
-		// Generate two invoice numbers with a slight delay
-		first := GenerateInvoiceNumber()
-		time.Sleep(time.Second)
-		second := GenerateInvoiceNumber()
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			number, err := FormatDocumentNumber(tt.format, "INV", date, tt.sequence)
 
-		assert.NotEqual(t, first, second)
-	})
+			if tt.wantErr {
+				assert.Error(t, err)
+				assert.Contains(t, err.Error(), tt.errMsg)
+				return
+			}
+
+			assert.NoError(t, err)
+			assert.Equal(t, tt.expected, number)
+		})
+	}
 }
 
 func TestReturnPointer(t *testing.T) {
@@ -137,3 +181,34 @@ func TestReturnPointer(t *testing.T) {
 		assert.Equal(t, value, *ptr)
 	})
 }
+
+func TestNormalizePhone(t *testing.T) {
+	tests := []struct {
+		name     string
+		phone    string
+		expected string
+		wantErr  bool
+	}{
+		{name: "already normalized", phone: "+14155550123", expected: "+14155550123"},
+		{name: "formatting is stripped", phone: " +1 (415) 555-0123 ", expected: "+14155550123"},
+		{name: "dotted", phone: "+44.20.7946.0958", expected: "+442079460958"},
+		{name: "international 00 prefix", phone: "0044 20 7946 0958", expected: "+442079460958"},
+		{name: "missing country code", phone: "(415) 555-0123", wantErr: true},
+		{name: "letters", phone: "+1 415 CALL NOW", wantErr: true},
+		{name: "too short", phone: "+1234", wantErr: true},
+		{name: "too long", phone: "+1234567890123456", wantErr: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			phone, err := NormalizePhone(tt.phone)
+
+			if tt.wantErr {
+				assert.Error(t, err)
+				return
+			}
+			assert.NoError(t, err)
+			assert.Equal(t, tt.expected, phone)
+		})
+	}
+}
@@ -0,0 +1,123 @@
+package middlewares
+
+import (
+	"bytes"
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"errors"
+	"io"
+	"net/http"
+
+	"github.com/Adebayobenjamin/numerisbook/pkg/common/exceptions"
+	"github.com/Adebayobenjamin/numerisbook/pkg/models"
+	services_interfaces "github.com/Adebayobenjamin/numerisbook/pkg/services/interfaces"
+	"github.com/gin-gonic/gin"
+)
+
+// Idempotent answers a request sent again with the same Idempotency-Key
+// header with the response the first one was given, instead of running it
+// twice. Keys are per customer, so it must run after RequiresAuth. Requests
+// without the header, and reads, are passed through untouched. Server errors
+// and panics are not kept, so the request can be retried with the same key.
+func Idempotent(idempotencyService services_interfaces.IdempotencyService) gin.HandlerFunc {
+	return func(ctx *gin.Context) {
+		key := ctx.GetHeader("Idempotency-Key")
+		if key == "" || !isMutating(ctx.Request.Method) {
+			ctx.Next()
+			return
+		}
+
+		body, err := io.ReadAll(ctx.Request.Body)
+		if err != nil {
+			exceptions.ThrowBadRequestException(ctx, "failed to read request body")
+			return
+		}
+		ctx.Request.Body = io.NopCloser(bytes.NewReader(body))
+
+		record, err := idempotencyService.Begin(ctx.Request.Context(), key, hashRequest(ctx.Request, body))
+		if err != nil {
+			switch {
+			case errors.Is(err, exceptions.ErrIdempotencyKeyReused):
+				exceptions.ThrowUnProcessableEntityException(ctx, err.Error())
+			case errors.Is(err, exceptions.ErrIdempotencyKeyInProgress):
+				exceptions.ThrowConflictException(ctx, err.Error())
+			default:
+				exceptions.ThrowBadRequestException(ctx, err.Error())
+			}
+			return
+		}
+
+		if record.Status == models.IdempotencyKeyStatusCompleted {
+			for name, values := range record.ResponseHeaders {
+				ctx.Writer.Header()[name] = values
+			}
+			ctx.Header("Idempotent-Replayed", "true")
+			ctx.Writer.WriteHeader(record.ResponseStatus)
+			ctx.Writer.Write(record.ResponseBody)
+			ctx.Abort()
+			return
+		}
+
+		// the response is kept even if the client has gone away, since it is
+		// the one a retry must be given
+		storeCtx := context.WithoutCancel(ctx.Request.Context())
+
+		// a handler that panics never finished, so its key is released before
+		// the panic carries on to the recovery middleware
+		defer func() {
+			if recovered := recover(); recovered != nil {
+				if err := idempotencyService.Release(storeCtx, record); err != nil {
+					ctx.Error(err)
+				}
+				panic(recovered)
+			}
+		}()
+
+		recorder := &responseRecorder{ResponseWriter: ctx.Writer}
+		ctx.Writer = recorder
+		ctx.Next()
+
+		if recorder.Status() >= http.StatusInternalServerError {
+			err = idempotencyService.Release(storeCtx, record)
+		} else {
+			err = idempotencyService.Complete(storeCtx, record, recorder.Status(), recorder.Header(), recorder.body.Bytes())
+		}
+		if err != nil {
+			ctx.Error(err)
+		}
+	}
+}
+
+func isMutating(method string) bool {
+	switch method {
+	case http.MethodPost, http.MethodPut, http.MethodPatch, http.MethodDelete:
+		return true
+	default:
+		return false
+	}
+}
+
+// hashRequest identifies a request by its method, path, query and body
+func hashRequest(request *http.Request, body []byte) string {
+	hash := sha256.New()
+	hash.Write([]byte(request.Method + " " + request.URL.RequestURI() + "\n"))
+	hash.Write(body)
+	return hex.EncodeToString(hash.Sum(nil))
+}
+
+// responseRecorder keeps a copy of the response body as it is written
+type responseRecorder struct {
+	gin.ResponseWriter
+	body bytes.Buffer
+}
+
+func (r *responseRecorder) Write(data []byte) (int, error) {
+	r.body.Write(data)
+	return r.ResponseWriter.Write(data)
+}
+
+func (r *responseRecorder) WriteString(s string) (int, error) {
+	r.body.WriteString(s)
+	return r.ResponseWriter.WriteString(s)
+}
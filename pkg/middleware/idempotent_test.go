@@ -0,0 +1,154 @@
+package middlewares
+
+import (
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/Adebayobenjamin/numerisbook/pkg/common/exceptions"
+	"github.com/Adebayobenjamin/numerisbook/pkg/models"
+	services_mocks "github.com/Adebayobenjamin/numerisbook/pkg/services/mocks"
+	"github.com/gin-gonic/gin"
+	"github.com/stretchr/testify/assert"
+	"go.uber.org/mock/gomock"
+)
+
+func TestIdempotent(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+	ctrl := gomock.NewController(t)
+	mockIdempotencyService := services_mocks.NewMockIdempotencyService(ctrl)
+
+	handled := 0
+	router := gin.New()
+	router.Use(Idempotent(mockIdempotencyService))
+	router.POST("/invoices", func(ctx *gin.Context) {
+		handled++
+		ctx.Header("ETag", `"1"`)
+		ctx.JSON(http.StatusCreated, gin.H{"id": handled})
+	})
+	router.POST("/failing", func(ctx *gin.Context) {
+		handled++
+		exceptions.ThrowInternalServerError(ctx, "database is down")
+	})
+	router.POST("/panicking", func(ctx *gin.Context) {
+		handled++
+		panic("nil map")
+	})
+	router.GET("/invoices", func(ctx *gin.Context) {
+		handled++
+		ctx.Status(http.StatusOK)
+	})
+
+	send := func(method, path, key, body string) *httptest.ResponseRecorder {
+		req := httptest.NewRequest(method, path, strings.NewReader(body))
+		if key != "" {
+			req.Header.Set("Idempotency-Key", key)
+		}
+		resp := httptest.NewRecorder()
+		router.ServeHTTP(resp, req)
+		return resp
+	}
+	requestHash := hashRequest(httptest.NewRequest(http.MethodPost, "/invoices", nil), []byte(`{"amount":"10.00"}`))
+
+	t.Run("requests without a key run every time", func(t *testing.T) {
+		handled = 0
+
+		send(http.MethodPost, "/invoices", "", `{"amount":"10.00"}`)
+		send(http.MethodPost, "/invoices", "", `{"amount":"10.00"}`)
+
+		assert.Equal(t, 2, handled)
+	})
+
+	t.Run("reads are not tracked", func(t *testing.T) {
+		handled = 0
+
+		resp := send(http.MethodGet, "/invoices", "key-1", "")
+
+		assert.Equal(t, http.StatusOK, resp.Code)
+		assert.Equal(t, 1, handled)
+	})
+
+	t.Run("first request keeps its response", func(t *testing.T) {
+		handled = 0
+		record := &models.IdempotencyKey{ID: 3, Status: models.IdempotencyKeyStatusProcessing}
+		mockIdempotencyService.EXPECT().Begin(gomock.Any(), "key-1", requestHash).Return(record, nil)
+		mockIdempotencyService.EXPECT().
+			Complete(gomock.Any(), record, http.StatusCreated, gomock.Any(), []byte(`{"id":1}`)).
+			DoAndReturn(func(_ any, _ *models.IdempotencyKey, _ int, headers http.Header, _ []byte) error {
+				assert.Equal(t, `"1"`, headers.Get("ETag"))
+				return nil
+			})
+
+		resp := send(http.MethodPost, "/invoices", "key-1", `{"amount":"10.00"}`)
+
+		assert.Equal(t, http.StatusCreated, resp.Code)
+		assert.Equal(t, 1, handled)
+	})
+
+	t.Run("retry is answered from the kept response", func(t *testing.T) {
+		handled = 0
+		mockIdempotencyService.EXPECT().Begin(gomock.Any(), "key-1", requestHash).Return(&models.IdempotencyKey{
+			ID:              3,
+			Status:          models.IdempotencyKeyStatusCompleted,
+			ResponseStatus:  http.StatusCreated,
+			ResponseHeaders: models.ResponseHeaders{"Content-Type": {"application/json; charset=utf-8"}, "Etag": {`"1"`}},
+			ResponseBody:    []byte(`{"id":1}`),
+		}, nil)
+
+		resp := send(http.MethodPost, "/invoices", "key-1", `{"amount":"10.00"}`)
+
+		assert.Equal(t, http.StatusCreated, resp.Code)
+		assert.Equal(t, `{"id":1}`, resp.Body.String())
+		assert.Equal(t, `"1"`, resp.Header().Get("ETag"))
+		assert.Equal(t, "true", resp.Header().Get("Idempotent-Replayed"))
+		assert.Equal(t, 0, handled)
+	})
+
+	t.Run("key reused with a different body", func(t *testing.T) {
+		handled = 0
+		mockIdempotencyService.EXPECT().Begin(gomock.Any(), "key-1", gomock.Not(requestHash)).
+			Return(nil, fmt.Errorf("idempotency key %w", exceptions.ErrIdempotencyKeyReused))
+
+		resp := send(http.MethodPost, "/invoices", "key-1", `{"amount":"20.00"}`)
+
+		assert.Equal(t, http.StatusUnprocessableEntity, resp.Code)
+		assert.Equal(t, 0, handled)
+	})
+
+	t.Run("key whose first request is still running", func(t *testing.T) {
+		handled = 0
+		mockIdempotencyService.EXPECT().Begin(gomock.Any(), "key-2", gomock.Any()).
+			Return(nil, fmt.Errorf("idempotency key %w", exceptions.ErrIdempotencyKeyInProgress))
+
+		resp := send(http.MethodPost, "/invoices", "key-2", `{"amount":"10.00"}`)
+
+		assert.Equal(t, http.StatusConflict, resp.Code)
+		assert.Equal(t, 0, handled)
+	})
+
+	t.Run("server errors release the key for a retry", func(t *testing.T) {
+		handled = 0
+		record := &models.IdempotencyKey{ID: 4, Status: models.IdempotencyKeyStatusProcessing}
+		mockIdempotencyService.EXPECT().Begin(gomock.Any(), "key-3", gomock.Any()).Return(record, nil)
+		mockIdempotencyService.EXPECT().Release(gomock.Any(), record).Return(nil)
+
+		resp := send(http.MethodPost, "/failing", "key-3", `{}`)
+
+		assert.Equal(t, http.StatusInternalServerError, resp.Code)
+		assert.Equal(t, 1, handled)
+	})
+
+	t.Run("a panicking handler releases the key for a retry", func(t *testing.T) {
+		handled = 0
+		record := &models.IdempotencyKey{ID: 5, Status: models.IdempotencyKeyStatusProcessing}
+		mockIdempotencyService.EXPECT().Begin(gomock.Any(), "key-4", gomock.Any()).Return(record, nil)
+		mockIdempotencyService.EXPECT().Release(gomock.Any(), record).Return(nil)
+
+		assert.PanicsWithValue(t, "nil map", func() {
+			send(http.MethodPost, "/panicking", "key-4", `{}`)
+		})
+		assert.Equal(t, 1, handled)
+	})
+}
@@ -0,0 +1,54 @@
+package middlewares
+
+import (
+	"errors"
+	"strings"
+
+	"github.com/Adebayobenjamin/numerisbook/pkg/auth"
+	"github.com/Adebayobenjamin/numerisbook/pkg/common/exceptions"
+	services_interfaces "github.com/Adebayobenjamin/numerisbook/pkg/services/interfaces"
+	"github.com/Adebayobenjamin/numerisbook/pkg/tenant"
+	"github.com/gin-gonic/gin"
+)
+
+// RequiresAuth authenticates the request with a bearer JWT or an API key,
+// sent either as a bearer token or in the X-API-Key header, and stores the
+// resolved customer under "customer_id" and the caller under "principal".
+// The caller is also attached to the request context so services can
+// authorize and audit on its behalf, and the request is scoped to the
+// caller's customer so repositories never read another customer's rows.
+func RequiresAuth(authService services_interfaces.AuthService) gin.HandlerFunc {
+	return func(ctx *gin.Context) {
+		credential := ctx.GetHeader("X-API-Key")
+		if credential == "" {
+			scheme, token, found := strings.Cut(ctx.GetHeader("Authorization"), " ")
+			if found && strings.EqualFold(scheme, "Bearer") {
+				credential = strings.TrimSpace(token)
+			}
+		}
+
+		if credential == "" {
+			ctx.Header("WWW-Authenticate", `Bearer realm="numerisbook"`)
+			exceptions.ThrowUnAuthorizedException(ctx, "authentication is required")
+			return
+		}
+
+		principal, err := authService.Authenticate(ctx, credential)
+		if err != nil {
+			if errors.Is(err, auth.ErrUnauthenticated) {
+				ctx.Header("WWW-Authenticate", `Bearer realm="numerisbook", error="invalid_token"`)
+				exceptions.ThrowUnAuthorizedException(ctx, "invalid or expired credentials")
+				return
+			}
+			exceptions.ThrowInternalServerError(ctx, "failed to authenticate request")
+			return
+		}
+
+		ctx.Set("customer_id", principal.CustomerID)
+		ctx.Set("principal", principal)
+		scoped := tenant.WithCustomerID(ctx.Request.Context(), principal.CustomerID)
+		ctx.Request = ctx.Request.WithContext(auth.WithPrincipal(scoped, principal))
+
+		ctx.Next()
+	}
+}
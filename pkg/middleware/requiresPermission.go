@@ -0,0 +1,28 @@
+package middlewares
+
+import (
+	"fmt"
+
+	"github.com/Adebayobenjamin/numerisbook/pkg/auth"
+	"github.com/Adebayobenjamin/numerisbook/pkg/common/exceptions"
+	"github.com/gin-gonic/gin"
+)
+
+// RequiresPermission rejects requests whose caller's role does not grant
+// permission. It must run after RequiresAuth.
+func RequiresPermission(permission auth.Permission) gin.HandlerFunc {
+	return func(ctx *gin.Context) {
+		principal, ok := auth.PrincipalFromContext(ctx.Request.Context())
+		if !ok {
+			exceptions.ThrowUnAuthorizedException(ctx, "authentication is required")
+			return
+		}
+
+		if !auth.Can(principal.Role, permission) {
+			exceptions.ThrowForbiddenException(ctx, fmt.Sprintf("the %s role does not grant %s", principal.Role, permission))
+			return
+		}
+
+		ctx.Next()
+	}
+}
@@ -0,0 +1,46 @@
+package middlewares
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/Adebayobenjamin/numerisbook/pkg/auth"
+	"github.com/Adebayobenjamin/numerisbook/pkg/models"
+	"github.com/gin-gonic/gin"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestRequiresPermission(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+
+	tests := []struct {
+		name      string
+		principal *auth.Principal
+		wantCode  int
+	}{
+		{name: "not authenticated", wantCode: http.StatusUnauthorized},
+		{name: "viewer", principal: &auth.Principal{CustomerID: 1, Role: models.UserRoleViewer}, wantCode: http.StatusForbidden},
+		{name: "accountant", principal: &auth.Principal{CustomerID: 1, Role: models.UserRoleAccountant}, wantCode: http.StatusOK},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			router := gin.New()
+			router.POST("/confirm-payment", func(ctx *gin.Context) {
+				if tt.principal != nil {
+					ctx.Request = ctx.Request.WithContext(auth.WithPrincipal(ctx.Request.Context(), tt.principal))
+				}
+			}, RequiresPermission(auth.PermissionPaymentsConfirm), func(ctx *gin.Context) {
+				ctx.Status(http.StatusOK)
+			})
+
+			req := httptest.NewRequest(http.MethodPost, "/confirm-payment", nil)
+			resp := httptest.NewRecorder()
+
+			router.ServeHTTP(resp, req)
+
+			assert.Equal(t, tt.wantCode, resp.Code)
+		})
+	}
+}
@@ -0,0 +1,16 @@
+DROP INDEX IF EXISTS idx_invoices_status ON invoices;
+
+DELETE FROM audit_trails WHERE event_type = 'invoice_status_changed';
+
+ALTER TABLE audit_trails
+MODIFY COLUMN event_type ENUM('invoice_created', 'invoice_duplicated', 'payment_confirmed') NOT NULL;
+
+ALTER TABLE invoices
+MODIFY COLUMN status ENUM('draft', 'sent', 'partially_paid', 'paid', 'overdue', 'void', 'cancelled', 'written_off', 'pending payment') NOT NULL;
+
+-- Collapse the lifecycle back onto the original four statuses
+UPDATE invoices SET status = 'pending payment' WHERE status IN ('partially_paid', 'overdue');
+UPDATE invoices SET status = 'draft' WHERE status IN ('void', 'cancelled', 'written_off');
+
+ALTER TABLE invoices
+MODIFY COLUMN status ENUM('draft', 'sent', 'paid', 'pending payment') NOT NULL;
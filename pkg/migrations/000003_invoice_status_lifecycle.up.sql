@@ -0,0 +1,14 @@
+-- Widen the status enum so legacy rows can be moved onto the new lifecycle
+ALTER TABLE invoices
+MODIFY COLUMN status ENUM('draft', 'sent', 'paid', 'pending payment', 'partially_paid', 'overdue', 'void', 'cancelled', 'written_off') NOT NULL;
+
+-- "pending payment" invoices have already been issued, which is what "sent" means now
+UPDATE invoices SET status = 'sent' WHERE status = 'pending payment';
+
+ALTER TABLE invoices
+MODIFY COLUMN status ENUM('draft', 'sent', 'partially_paid', 'paid', 'overdue', 'void', 'cancelled', 'written_off') NOT NULL DEFAULT 'draft';
+
+ALTER TABLE audit_trails
+MODIFY COLUMN event_type ENUM('invoice_created', 'invoice_duplicated', 'payment_confirmed', 'invoice_status_changed') NOT NULL;
+
+CREATE INDEX idx_invoices_status ON invoices(status);
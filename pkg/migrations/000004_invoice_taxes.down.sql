@@ -0,0 +1,11 @@
+DROP INDEX IF EXISTS idx_invoice_item_taxes_invoice_item_id ON invoice_item_taxes;
+DROP INDEX IF EXISTS idx_invoice_item_taxes_invoice_id ON invoice_item_taxes;
+
+DROP TABLE IF EXISTS invoice_item_taxes;
+
+ALTER TABLE invoice_items
+DROP COLUMN tax_total,
+DROP COLUMN net_amount;
+
+ALTER TABLE invoices
+DROP COLUMN tax_total;
@@ -0,0 +1,29 @@
+ALTER TABLE invoices
+ADD COLUMN tax_total DECIMAL(15,2) NOT NULL DEFAULT 0.00 AFTER subtotal;
+
+ALTER TABLE invoice_items
+ADD COLUMN net_amount DECIMAL(15,2) NOT NULL DEFAULT 0.00 AFTER unit_price,
+ADD COLUMN tax_total DECIMAL(15,2) NOT NULL DEFAULT 0.00 AFTER net_amount;
+
+-- existing lines were never taxed, so their net amount is their total
+UPDATE invoice_items SET net_amount = total_price;
+
+CREATE TABLE IF NOT EXISTS invoice_item_taxes (
+    id BIGINT UNSIGNED AUTO_INCREMENT PRIMARY KEY,
+    invoice_item_id BIGINT UNSIGNED NOT NULL,
+    invoice_id BIGINT UNSIGNED NOT NULL,
+    name VARCHAR(100) NOT NULL,
+    rate DECIMAL(9,4) NOT NULL,
+    is_inclusive BOOLEAN DEFAULT FALSE,
+    is_compound BOOLEAN DEFAULT FALSE,
+    taxable_amount DECIMAL(15,2) NOT NULL,
+    amount DECIMAL(15,2) NOT NULL,
+    created_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP,
+    updated_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP ON UPDATE CURRENT_TIMESTAMP,
+    deleted_at TIMESTAMP NULL,
+    FOREIGN KEY (invoice_item_id) REFERENCES invoice_items(id),
+    FOREIGN KEY (invoice_id) REFERENCES invoices(id)
+);
+
+CREATE INDEX idx_invoice_item_taxes_invoice_id ON invoice_item_taxes(invoice_id);
+CREATE INDEX idx_invoice_item_taxes_invoice_item_id ON invoice_item_taxes(invoice_item_id);
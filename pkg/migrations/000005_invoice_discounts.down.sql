@@ -0,0 +1,13 @@
+ALTER TABLE invoice_items
+DROP COLUMN invoice_discount_amount,
+DROP COLUMN discount_amount,
+DROP COLUMN discount_rate,
+DROP COLUMN discount_type;
+
+ALTER TABLE invoices
+MODIFY COLUMN discount DECIMAL(15,2) DEFAULT 0.00;
+
+ALTER TABLE invoices
+DROP COLUMN discount_total,
+DROP COLUMN discount_rate,
+DROP COLUMN discount_type;
@@ -0,0 +1,16 @@
+ALTER TABLE invoices
+ADD COLUMN discount_type ENUM('fixed', 'percentage') NOT NULL DEFAULT 'fixed' AFTER discount,
+ADD COLUMN discount_rate DECIMAL(9,4) NOT NULL DEFAULT 0.0000 AFTER discount_type,
+ADD COLUMN discount_total DECIMAL(15,2) NOT NULL DEFAULT 0.00 AFTER discount_rate;
+
+-- invoices created so far only had a fixed invoice-level discount
+UPDATE invoices SET discount = COALESCE(discount, 0.00), discount_total = COALESCE(discount, 0.00);
+
+ALTER TABLE invoices
+MODIFY COLUMN discount DECIMAL(15,2) NOT NULL DEFAULT 0.00;
+
+ALTER TABLE invoice_items
+ADD COLUMN discount_type ENUM('fixed', 'percentage') NOT NULL DEFAULT 'fixed' AFTER unit_price,
+ADD COLUMN discount_rate DECIMAL(9,4) NOT NULL DEFAULT 0.0000 AFTER discount_type,
+ADD COLUMN discount_amount DECIMAL(15,2) NOT NULL DEFAULT 0.00 AFTER discount_rate,
+ADD COLUMN invoice_discount_amount DECIMAL(15,2) NOT NULL DEFAULT 0.00 AFTER discount_amount;
@@ -0,0 +1,5 @@
+ALTER TABLE invoices
+DROP INDEX uq_invoices_customer_invoice_number,
+ADD UNIQUE KEY invoice_number (invoice_number);
+
+DROP TABLE IF EXISTS document_sequences;
@@ -0,0 +1,19 @@
+CREATE TABLE IF NOT EXISTS document_sequences (
+    id BIGINT UNSIGNED AUTO_INCREMENT PRIMARY KEY,
+    customer_id BIGINT UNSIGNED NOT NULL,
+    document_type ENUM('invoice') NOT NULL,
+    prefix VARCHAR(20) NOT NULL DEFAULT '',
+    format VARCHAR(100) NOT NULL,
+    reset_policy ENUM('never', 'yearly') NOT NULL DEFAULT 'yearly',
+    next_value BIGINT UNSIGNED NOT NULL DEFAULT 1,
+    current_year SMALLINT UNSIGNED NOT NULL,
+    created_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP,
+    updated_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP ON UPDATE CURRENT_TIMESTAMP,
+    FOREIGN KEY (customer_id) REFERENCES customers(id),
+    UNIQUE KEY uq_document_sequences_customer_type (customer_id, document_type)
+);
+
+-- numbers are now unique per customer rather than across every customer
+ALTER TABLE invoices
+DROP INDEX invoice_number,
+ADD UNIQUE KEY uq_invoices_customer_invoice_number (customer_id, invoice_number);
@@ -0,0 +1,6 @@
+DELETE FROM audit_trails WHERE event_type = 'recurring_invoice_generated';
+
+ALTER TABLE audit_trails
+MODIFY COLUMN event_type ENUM('invoice_created', 'invoice_duplicated', 'payment_confirmed', 'invoice_status_changed') NOT NULL;
+
+DROP TABLE IF EXISTS recurring_invoice_profiles;
@@ -0,0 +1,27 @@
+CREATE TABLE IF NOT EXISTS recurring_invoice_profiles (
+    id BIGINT UNSIGNED AUTO_INCREMENT PRIMARY KEY,
+    customer_id BIGINT UNSIGNED NOT NULL,
+    name VARCHAR(255) NOT NULL,
+    frequency ENUM('weekly', 'monthly', 'quarterly', 'yearly', 'custom') NOT NULL,
+    rrule VARCHAR(255) NOT NULL,
+    start_date TIMESTAMP NOT NULL,
+    end_date TIMESTAMP NULL,
+    max_occurrences INT UNSIGNED NULL,
+    occurrences_generated INT UNSIGNED NOT NULL DEFAULT 0,
+    due_in_days INT UNSIGNED NOT NULL,
+    auto_send BOOLEAN DEFAULT FALSE,
+    template JSON NOT NULL,
+    status ENUM('active', 'paused', 'completed', 'cancelled') NOT NULL DEFAULT 'active',
+    next_run_at TIMESTAMP NULL,
+    last_run_at TIMESTAMP NULL,
+    created_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP,
+    updated_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP ON UPDATE CURRENT_TIMESTAMP,
+    deleted_at TIMESTAMP NULL,
+    FOREIGN KEY (customer_id) REFERENCES customers(id)
+);
+
+CREATE INDEX idx_recurring_invoice_profiles_due ON recurring_invoice_profiles(status, next_run_at);
+CREATE INDEX idx_recurring_invoice_profiles_customer_id ON recurring_invoice_profiles(customer_id);
+
+ALTER TABLE audit_trails
+MODIFY COLUMN event_type ENUM('invoice_created', 'invoice_duplicated', 'payment_confirmed', 'invoice_status_changed', 'recurring_invoice_generated') NOT NULL;
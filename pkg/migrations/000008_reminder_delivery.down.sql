@@ -0,0 +1,7 @@
+DROP INDEX idx_invoice_reminders_due ON invoice_reminders;
+
+ALTER TABLE invoice_reminders
+DROP COLUMN next_attempt_at,
+DROP COLUMN last_error,
+DROP COLUMN attempts,
+DROP COLUMN sent_at;
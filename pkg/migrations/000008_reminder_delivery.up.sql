@@ -0,0 +1,7 @@
+ALTER TABLE invoice_reminders
+ADD COLUMN sent_at TIMESTAMP NULL AFTER reminder_date,
+ADD COLUMN attempts INT UNSIGNED NOT NULL DEFAULT 0 AFTER sent_at,
+ADD COLUMN last_error TEXT NULL AFTER attempts,
+ADD COLUMN next_attempt_at TIMESTAMP NULL AFTER last_error;
+
+CREATE INDEX idx_invoice_reminders_due ON invoice_reminders(sent_at, reminder_date);
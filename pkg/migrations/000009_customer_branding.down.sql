@@ -0,0 +1,4 @@
+ALTER TABLE customers
+DROP COLUMN email_footer,
+DROP COLUMN logo_url,
+DROP COLUMN brand_color;
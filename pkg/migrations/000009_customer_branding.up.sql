@@ -0,0 +1,4 @@
+ALTER TABLE customers
+ADD COLUMN brand_color VARCHAR(7) NULL AFTER email,
+ADD COLUMN logo_url VARCHAR(512) NULL AFTER brand_color,
+ADD COLUMN email_footer VARCHAR(500) NULL AFTER logo_url;
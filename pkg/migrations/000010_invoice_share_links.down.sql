@@ -0,0 +1,6 @@
+DELETE FROM audit_trails WHERE event_type IN ('share_link_created', 'share_link_revoked', 'invoice_viewed');
+
+ALTER TABLE audit_trails
+MODIFY COLUMN event_type ENUM('invoice_created', 'invoice_duplicated', 'payment_confirmed', 'invoice_status_changed', 'recurring_invoice_generated') NOT NULL;
+
+DROP TABLE IF EXISTS invoice_share_links;
@@ -0,0 +1,24 @@
+CREATE TABLE IF NOT EXISTS invoice_share_links (
+    id BIGINT UNSIGNED AUTO_INCREMENT PRIMARY KEY,
+    invoice_id BIGINT UNSIGNED NOT NULL,
+    customer_id BIGINT UNSIGNED NOT NULL,
+    token_id CHAR(32) NOT NULL,
+    expires_at TIMESTAMP NOT NULL,
+    revoked_at TIMESTAMP NULL,
+    first_viewed_at TIMESTAMP NULL,
+    last_viewed_at TIMESTAMP NULL,
+    view_count INT UNSIGNED NOT NULL DEFAULT 0,
+    created_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP,
+    updated_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP ON UPDATE CURRENT_TIMESTAMP,
+    UNIQUE KEY uq_invoice_share_links_token_id (token_id),
+    FOREIGN KEY (invoice_id) REFERENCES invoices(id),
+    FOREIGN KEY (customer_id) REFERENCES customers(id)
+);
+
+CREATE INDEX idx_invoice_share_links_invoice_id ON invoice_share_links(invoice_id, revoked_at, expires_at);
+
+ALTER TABLE audit_trails
+MODIFY COLUMN event_type ENUM('invoice_created', 'invoice_duplicated', 'payment_confirmed', 'invoice_status_changed', 'recurring_invoice_generated', 'share_link_created', 'share_link_revoked', 'invoice_viewed') NOT NULL;
+
+-- links built from the numeric invoice ID no longer resolve
+UPDATE invoices SET shareable_link = NULL WHERE shareable_link IS NOT NULL;
@@ -0,0 +1,9 @@
+ALTER TABLE audit_trails
+DROP COLUMN actor_api_key_id,
+DROP COLUMN actor_user_id,
+DROP COLUMN actor_type;
+
+ALTER TABLE api_keys
+DROP COLUMN role;
+
+DROP TABLE IF EXISTS users;
@@ -0,0 +1,28 @@
+CREATE TABLE IF NOT EXISTS users (
+    id BIGINT UNSIGNED AUTO_INCREMENT PRIMARY KEY,
+    customer_id BIGINT UNSIGNED NOT NULL,
+    subject VARCHAR(255) NOT NULL,
+    email VARCHAR(255) NOT NULL,
+    name VARCHAR(255) NOT NULL,
+    role ENUM('owner', 'accountant', 'viewer') NOT NULL,
+    created_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP,
+    updated_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP ON UPDATE CURRENT_TIMESTAMP,
+    UNIQUE KEY uq_users_customer_subject (customer_id, subject),
+    FOREIGN KEY (customer_id) REFERENCES customers(id)
+);
+
+-- every existing account becomes the owner of its organisation, signing in
+-- with tokens whose subject is the customer id
+INSERT INTO users (customer_id, subject, email, name, role)
+SELECT id, CAST(id AS CHAR), email, name, 'owner' FROM customers;
+
+ALTER TABLE api_keys
+ADD COLUMN role ENUM('owner', 'accountant', 'viewer') NOT NULL DEFAULT 'viewer' AFTER name;
+
+-- keys created before roles existed had full access
+UPDATE api_keys SET role = 'owner';
+
+ALTER TABLE audit_trails
+ADD COLUMN actor_type ENUM('system', 'user', 'api_key') NOT NULL DEFAULT 'system' AFTER customer_id,
+ADD COLUMN actor_user_id BIGINT UNSIGNED NULL AFTER actor_type,
+ADD COLUMN actor_api_key_id BIGINT UNSIGNED NULL AFTER actor_user_id;
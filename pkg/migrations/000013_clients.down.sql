@@ -0,0 +1,5 @@
+ALTER TABLE invoices
+DROP FOREIGN KEY fk_invoices_client_id,
+DROP COLUMN client_id;
+
+DROP TABLE IF EXISTS clients;
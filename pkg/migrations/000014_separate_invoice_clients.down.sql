@@ -0,0 +1,32 @@
+CREATE TABLE IF NOT EXISTS senders (
+    id BIGINT UNSIGNED AUTO_INCREMENT PRIMARY KEY,
+    name VARCHAR(255) NOT NULL,
+    phone VARCHAR(50),
+    address TEXT,
+    email VARCHAR(255),
+    invoice_id BIGINT UNSIGNED NOT NULL,
+    created_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP,
+    updated_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP ON UPDATE CURRENT_TIMESTAMP,
+    deleted_at TIMESTAMP NULL,
+    FOREIGN KEY (invoice_id) REFERENCES invoices(id)
+);
+
+INSERT INTO senders (name, phone, address, email, invoice_id, created_at, updated_at)
+SELECT client_name, client_phone, client_address, client_email, id, created_at, updated_at
+FROM invoices
+WHERE client_name <> '';
+
+-- templates that name a client get its details back inline
+UPDATE recurring_invoice_profiles p
+JOIN clients c ON c.id = JSON_UNQUOTE(JSON_EXTRACT(p.template, '$.client_id'))
+SET p.template = JSON_REMOVE(
+    JSON_SET(p.template, '$.sender', JSON_OBJECT('name', c.name, 'phone', c.phone, 'address', COALESCE(c.address, ''), 'email', c.email)),
+    '$.client_id'
+)
+WHERE JSON_EXTRACT(p.template, '$.client_id') IS NOT NULL;
+
+ALTER TABLE invoices
+DROP COLUMN client_address,
+DROP COLUMN client_phone,
+DROP COLUMN client_email,
+DROP COLUMN client_name;
@@ -0,0 +1,87 @@
+-- the billed party used to live in a senders row per invoice; it now lives in
+-- the client directory, with a copy of its details kept on the invoice itself
+ALTER TABLE invoices
+ADD COLUMN client_name VARCHAR(255) NOT NULL DEFAULT '' AFTER client_id,
+ADD COLUMN client_email VARCHAR(255) NOT NULL DEFAULT '' AFTER client_name,
+ADD COLUMN client_phone VARCHAR(50) NOT NULL DEFAULT '' AFTER client_email,
+ADD COLUMN client_address TEXT NOT NULL AFTER client_phone;
+
+UPDATE invoices i
+JOIN senders s ON s.invoice_id = i.id AND s.deleted_at IS NULL
+SET i.client_name = s.name,
+    i.client_email = COALESCE(s.email, ''),
+    i.client_phone = COALESCE(s.phone, ''),
+    i.client_address = COALESCE(s.address, '');
+
+-- every distinct party a customer has billed becomes a client, keyed by
+-- email (or by name when no email was given) and using its latest details
+INSERT INTO clients (customer_id, name, email, phone, address, created_at, updated_at)
+SELECT customer_id, client_name, LOWER(TRIM(client_email)), client_phone, client_address, created_at, CURRENT_TIMESTAMP
+FROM (
+    SELECT
+        i.customer_id, i.client_name, i.client_email, i.client_phone, i.client_address, i.created_at,
+        ROW_NUMBER() OVER (
+            PARTITION BY i.customer_id, COALESCE(NULLIF(LOWER(TRIM(i.client_email)), ''), CONCAT('name:', i.client_name))
+            ORDER BY i.created_at DESC, i.id DESC
+        ) AS position
+    FROM invoices i
+    WHERE i.client_id IS NULL AND i.client_name <> ''
+) latest
+WHERE position = 1
+AND NOT EXISTS (
+    SELECT 1 FROM clients c
+    WHERE c.customer_id = latest.customer_id AND c.deleted_at IS NULL
+    AND COALESCE(NULLIF(c.email, ''), CONCAT('name:', c.name)) = COALESCE(NULLIF(LOWER(TRIM(latest.client_email)), ''), CONCAT('name:', latest.client_name))
+);
+
+UPDATE invoices i
+SET i.client_id = (
+    SELECT MIN(c.id) FROM clients c
+    WHERE c.customer_id = i.customer_id AND c.deleted_at IS NULL
+    AND COALESCE(NULLIF(c.email, ''), CONCAT('name:', c.name)) = COALESCE(NULLIF(LOWER(TRIM(i.client_email)), ''), CONCAT('name:', i.client_name))
+)
+WHERE i.client_id IS NULL AND i.client_name <> '';
+
+-- recurring templates stored the party inline as well; they now name the
+-- client so each run copies its current details
+INSERT INTO clients (customer_id, name, email, phone, address, created_at, updated_at)
+SELECT customer_id, name, email, phone, address, CURRENT_TIMESTAMP, CURRENT_TIMESTAMP
+FROM (
+    SELECT
+        p.customer_id,
+        JSON_UNQUOTE(JSON_EXTRACT(p.template, '$.sender.name')) AS name,
+        LOWER(TRIM(COALESCE(JSON_UNQUOTE(JSON_EXTRACT(p.template, '$.sender.email')), ''))) AS email,
+        COALESCE(JSON_UNQUOTE(JSON_EXTRACT(p.template, '$.sender.phone')), '') AS phone,
+        COALESCE(JSON_UNQUOTE(JSON_EXTRACT(p.template, '$.sender.address')), '') AS address,
+        ROW_NUMBER() OVER (
+            PARTITION BY p.customer_id, COALESCE(
+                NULLIF(LOWER(TRIM(JSON_UNQUOTE(JSON_EXTRACT(p.template, '$.sender.email')))), ''),
+                CONCAT('name:', JSON_UNQUOTE(JSON_EXTRACT(p.template, '$.sender.name')))
+            )
+            ORDER BY p.created_at DESC, p.id DESC
+        ) AS position
+    FROM recurring_invoice_profiles p
+    WHERE JSON_EXTRACT(p.template, '$.sender') IS NOT NULL
+) senders_in_templates
+WHERE position = 1
+AND NOT EXISTS (
+    SELECT 1 FROM clients c
+    WHERE c.customer_id = senders_in_templates.customer_id AND c.deleted_at IS NULL
+    AND COALESCE(NULLIF(c.email, ''), CONCAT('name:', c.name)) = COALESCE(NULLIF(senders_in_templates.email, ''), CONCAT('name:', senders_in_templates.name))
+);
+
+UPDATE recurring_invoice_profiles p
+SET p.template = JSON_REMOVE(
+    JSON_SET(p.template, '$.client_id', (
+        SELECT MIN(c.id) FROM clients c
+        WHERE c.customer_id = p.customer_id AND c.deleted_at IS NULL
+        AND COALESCE(NULLIF(c.email, ''), CONCAT('name:', c.name)) = COALESCE(
+            NULLIF(LOWER(TRIM(JSON_UNQUOTE(JSON_EXTRACT(p.template, '$.sender.email')))), ''),
+            CONCAT('name:', JSON_UNQUOTE(JSON_EXTRACT(p.template, '$.sender.name')))
+        )
+    )),
+    '$.sender'
+)
+WHERE JSON_EXTRACT(p.template, '$.sender') IS NOT NULL;
+
+DROP TABLE IF EXISTS senders;
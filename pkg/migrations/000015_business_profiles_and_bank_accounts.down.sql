@@ -0,0 +1,14 @@
+ALTER TABLE payment_info
+DROP FOREIGN KEY fk_payment_info_bank_account_id,
+DROP COLUMN bank_account_id;
+
+ALTER TABLE invoices
+DROP FOREIGN KEY fk_invoices_business_profile_id,
+DROP COLUMN business_profile_id,
+DROP COLUMN issuer_name,
+DROP COLUMN issuer_email,
+DROP COLUMN issuer_phone,
+DROP COLUMN issuer_address;
+
+DROP TABLE IF EXISTS bank_accounts;
+DROP TABLE IF EXISTS business_profiles;
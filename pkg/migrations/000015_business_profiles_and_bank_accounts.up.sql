@@ -0,0 +1,56 @@
+CREATE TABLE IF NOT EXISTS business_profiles (
+    id BIGINT UNSIGNED AUTO_INCREMENT PRIMARY KEY,
+    customer_id BIGINT UNSIGNED NOT NULL,
+    name VARCHAR(255) NOT NULL,
+    email VARCHAR(255) NOT NULL,
+    phone VARCHAR(50) NOT NULL,
+    address TEXT,
+    is_default BOOLEAN NOT NULL DEFAULT FALSE,
+    created_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP,
+    updated_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP ON UPDATE CURRENT_TIMESTAMP,
+    deleted_at TIMESTAMP NULL,
+    FOREIGN KEY (customer_id) REFERENCES customers(id)
+);
+
+CREATE INDEX idx_business_profiles_customer_id ON business_profiles(customer_id, deleted_at);
+
+CREATE TABLE IF NOT EXISTS bank_accounts (
+    id BIGINT UNSIGNED AUTO_INCREMENT PRIMARY KEY,
+    customer_id BIGINT UNSIGNED NOT NULL,
+    bank_name VARCHAR(255) NOT NULL,
+    account_number VARCHAR(50) NOT NULL,
+    account_name VARCHAR(255) NOT NULL,
+    ach_routing_no VARCHAR(50) NOT NULL DEFAULT '',
+    bank_address TEXT,
+    is_default BOOLEAN NOT NULL DEFAULT FALSE,
+    created_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP,
+    updated_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP ON UPDATE CURRENT_TIMESTAMP,
+    deleted_at TIMESTAMP NULL,
+    FOREIGN KEY (customer_id) REFERENCES customers(id)
+);
+
+CREATE INDEX idx_bank_accounts_customer_id ON bank_accounts(customer_id, deleted_at);
+
+-- invoices keep their own copy of the issuer's details, taken from a business
+-- profile or, without one, from the account itself
+ALTER TABLE invoices
+ADD COLUMN business_profile_id BIGINT UNSIGNED NULL AFTER customer_id,
+ADD COLUMN issuer_name VARCHAR(255) NOT NULL DEFAULT '',
+ADD COLUMN issuer_email VARCHAR(255) NOT NULL DEFAULT '',
+ADD COLUMN issuer_phone VARCHAR(50) NOT NULL DEFAULT '',
+ADD COLUMN issuer_address TEXT NOT NULL,
+ADD CONSTRAINT fk_invoices_business_profile_id FOREIGN KEY (business_profile_id) REFERENCES business_profiles(id);
+
+-- invoices issued so far were issued under the account's own details
+UPDATE invoices i
+JOIN customers c ON c.id = i.customer_id
+SET i.issuer_name = COALESCE(c.name, ''),
+    i.issuer_email = COALESCE(c.email, ''),
+    i.issuer_phone = COALESCE(c.phone, ''),
+    i.issuer_address = COALESCE(c.address, '');
+
+-- payment details stay copied per invoice, with a reference to the saved
+-- bank account they were taken from
+ALTER TABLE payment_info
+ADD COLUMN bank_account_id BIGINT UNSIGNED NULL AFTER invoice_id,
+ADD CONSTRAINT fk_payment_info_bank_account_id FOREIGN KEY (bank_account_id) REFERENCES bank_accounts(id);
@@ -0,0 +1,7 @@
+ALTER TABLE invoice_items
+DROP FOREIGN KEY fk_invoice_items_catalog_item_id,
+DROP INDEX idx_invoice_items_catalog_item_id,
+DROP COLUMN catalog_item_id,
+DROP COLUMN unit;
+
+DROP TABLE IF EXISTS catalog_items;
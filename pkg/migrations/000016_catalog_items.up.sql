@@ -0,0 +1,29 @@
+CREATE TABLE IF NOT EXISTS catalog_items (
+    id BIGINT UNSIGNED AUTO_INCREMENT PRIMARY KEY,
+    customer_id BIGINT UNSIGNED NOT NULL,
+    sku VARCHAR(64) NOT NULL,
+    name VARCHAR(255) NOT NULL,
+    description TEXT,
+    unit_price DECIMAL(15,2) NOT NULL DEFAULT 0.00,
+    currency VARCHAR(3) NOT NULL,
+    unit VARCHAR(32) NOT NULL DEFAULT '',
+    tax_name VARCHAR(100) NOT NULL DEFAULT '',
+    tax_rate DECIMAL(9,4) NOT NULL DEFAULT 0.0000,
+    tax_is_inclusive BOOLEAN NOT NULL DEFAULT FALSE,
+    created_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP,
+    updated_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP ON UPDATE CURRENT_TIMESTAMP,
+    deleted_at TIMESTAMP NULL,
+    FOREIGN KEY (customer_id) REFERENCES customers(id)
+);
+
+CREATE INDEX idx_catalog_items_customer_id ON catalog_items(customer_id, deleted_at);
+CREATE INDEX idx_catalog_items_sku ON catalog_items(customer_id, sku);
+
+-- lines keep their own description, price and taxes, the catalog item is
+-- only a reference back to where they were taken from
+ALTER TABLE invoice_items
+ADD COLUMN catalog_item_id BIGINT UNSIGNED NULL AFTER invoice_id,
+ADD COLUMN unit VARCHAR(32) NOT NULL DEFAULT '' AFTER quantity,
+ADD CONSTRAINT fk_invoice_items_catalog_item_id FOREIGN KEY (catalog_item_id) REFERENCES catalog_items(id);
+
+CREATE INDEX idx_invoice_items_catalog_item_id ON invoice_items(catalog_item_id);
@@ -0,0 +1,7 @@
+DELETE FROM audit_trails WHERE event_type IN ('invoice_updated', 'invoice_deleted');
+
+ALTER TABLE audit_trails
+MODIFY COLUMN event_type ENUM('invoice_created', 'invoice_duplicated', 'payment_confirmed', 'invoice_status_changed', 'recurring_invoice_generated', 'share_link_created', 'share_link_revoked', 'invoice_viewed') NOT NULL;
+
+ALTER TABLE invoices
+DROP COLUMN version;
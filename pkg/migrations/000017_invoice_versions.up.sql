@@ -0,0 +1,7 @@
+-- bumped whenever an invoice changes, so an edit made against an older copy
+-- of the invoice can be refused instead of overwriting someone else's
+ALTER TABLE invoices
+ADD COLUMN version INT UNSIGNED NOT NULL DEFAULT 1 AFTER status;
+
+ALTER TABLE audit_trails
+MODIFY COLUMN event_type ENUM('invoice_created', 'invoice_duplicated', 'invoice_updated', 'invoice_deleted', 'payment_confirmed', 'invoice_status_changed', 'recurring_invoice_generated', 'share_link_created', 'share_link_revoked', 'invoice_viewed') NOT NULL;
@@ -0,0 +1,15 @@
+DELETE FROM audit_trails WHERE event_type = 'credit_note_issued';
+
+ALTER TABLE audit_trails
+MODIFY COLUMN event_type ENUM('invoice_created', 'invoice_duplicated', 'invoice_updated', 'invoice_deleted', 'payment_confirmed', 'invoice_status_changed', 'recurring_invoice_generated', 'share_link_created', 'share_link_revoked', 'invoice_viewed') NOT NULL;
+
+DELETE FROM document_sequences WHERE document_type = 'credit_note';
+
+ALTER TABLE document_sequences
+MODIFY COLUMN document_type ENUM('invoice') NOT NULL;
+
+ALTER TABLE invoices
+DROP COLUMN credited_total;
+
+DROP TABLE IF EXISTS credit_note_items;
+DROP TABLE IF EXISTS credit_notes;
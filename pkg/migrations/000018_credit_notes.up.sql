@@ -0,0 +1,51 @@
+CREATE TABLE IF NOT EXISTS credit_notes (
+    id BIGINT UNSIGNED AUTO_INCREMENT PRIMARY KEY,
+    customer_id BIGINT UNSIGNED NOT NULL,
+    invoice_id BIGINT UNSIGNED NOT NULL,
+    credit_note_number VARCHAR(100) NOT NULL,
+    reason TEXT NOT NULL,
+    issue_date TIMESTAMP NOT NULL,
+    billing_currency VARCHAR(3) NOT NULL,
+    net_total DECIMAL(15,2) NOT NULL DEFAULT 0.00,
+    tax_total DECIMAL(15,2) NOT NULL DEFAULT 0.00,
+    total DECIMAL(15,2) NOT NULL DEFAULT 0.00,
+    created_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP,
+    updated_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP ON UPDATE CURRENT_TIMESTAMP,
+    deleted_at TIMESTAMP NULL,
+    FOREIGN KEY (customer_id) REFERENCES customers(id),
+    FOREIGN KEY (invoice_id) REFERENCES invoices(id),
+    UNIQUE KEY uq_credit_notes_customer_number (customer_id, credit_note_number)
+);
+
+CREATE INDEX idx_credit_notes_invoice_id ON credit_notes(invoice_id, deleted_at);
+
+CREATE TABLE IF NOT EXISTS credit_note_items (
+    id BIGINT UNSIGNED AUTO_INCREMENT PRIMARY KEY,
+    credit_note_id BIGINT UNSIGNED NOT NULL,
+    invoice_item_id BIGINT UNSIGNED NOT NULL,
+    description TEXT NOT NULL,
+    quantity INT NOT NULL,
+    unit_price DECIMAL(15,2) NOT NULL,
+    net_amount DECIMAL(15,2) NOT NULL DEFAULT 0.00,
+    tax_total DECIMAL(15,2) NOT NULL DEFAULT 0.00,
+    total DECIMAL(15,2) NOT NULL DEFAULT 0.00,
+    created_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP,
+    updated_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP ON UPDATE CURRENT_TIMESTAMP,
+    deleted_at TIMESTAMP NULL,
+    FOREIGN KEY (credit_note_id) REFERENCES credit_notes(id),
+    FOREIGN KEY (invoice_item_id) REFERENCES invoice_items(id)
+);
+
+CREATE INDEX idx_credit_note_items_credit_note_id ON credit_note_items(credit_note_id);
+CREATE INDEX idx_credit_note_items_invoice_item_id ON credit_note_items(invoice_item_id);
+
+-- what has been credited so far is kept on the invoice, so the amount still
+-- payable can be read without adding up its credit notes
+ALTER TABLE invoices
+ADD COLUMN credited_total DECIMAL(15,2) NOT NULL DEFAULT 0.00 AFTER total_amount_due;
+
+ALTER TABLE document_sequences
+MODIFY COLUMN document_type ENUM('invoice', 'credit_note') NOT NULL;
+
+ALTER TABLE audit_trails
+MODIFY COLUMN event_type ENUM('invoice_created', 'invoice_duplicated', 'invoice_updated', 'invoice_deleted', 'payment_confirmed', 'invoice_status_changed', 'recurring_invoice_generated', 'share_link_created', 'share_link_revoked', 'invoice_viewed', 'credit_note_issued') NOT NULL;
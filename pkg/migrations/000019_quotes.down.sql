@@ -0,0 +1,13 @@
+DELETE FROM audit_trails WHERE event_type = 'quote_converted';
+
+ALTER TABLE audit_trails
+DROP FOREIGN KEY fk_audit_trails_quote_id,
+DROP COLUMN quote_id,
+MODIFY COLUMN event_type ENUM('invoice_created', 'invoice_duplicated', 'invoice_updated', 'invoice_deleted', 'payment_confirmed', 'invoice_status_changed', 'recurring_invoice_generated', 'share_link_created', 'share_link_revoked', 'invoice_viewed', 'credit_note_issued') NOT NULL;
+
+DELETE FROM document_sequences WHERE document_type = 'quote';
+
+ALTER TABLE document_sequences
+MODIFY COLUMN document_type ENUM('invoice', 'credit_note') NOT NULL;
+
+DROP TABLE IF EXISTS quotes;
@@ -0,0 +1,60 @@
+-- quotes carry the same lines, discounts and taxes as invoices, worked out
+-- when the quote is created. The lines and payment details are kept as JSON
+-- until the quote is converted, when they are copied into a new invoice.
+CREATE TABLE IF NOT EXISTS quotes (
+    id BIGINT UNSIGNED AUTO_INCREMENT PRIMARY KEY,
+    customer_id BIGINT UNSIGNED NOT NULL,
+    quote_number VARCHAR(100) NOT NULL,
+    status ENUM('draft', 'sent', 'accepted', 'declined', 'expired') NOT NULL DEFAULT 'draft',
+    business_profile_id BIGINT UNSIGNED NULL,
+    issuer_name VARCHAR(255) NOT NULL DEFAULT '',
+    issuer_email VARCHAR(255) NOT NULL DEFAULT '',
+    issuer_phone VARCHAR(50) NOT NULL DEFAULT '',
+    issuer_address TEXT NOT NULL,
+    client_id BIGINT UNSIGNED NULL,
+    client_name VARCHAR(255) NOT NULL DEFAULT '',
+    client_email VARCHAR(255) NOT NULL DEFAULT '',
+    client_phone VARCHAR(50) NOT NULL DEFAULT '',
+    client_address TEXT NOT NULL,
+    issue_date TIMESTAMP NOT NULL,
+    expiry_date TIMESTAMP NOT NULL,
+    due_in_days INT UNSIGNED NOT NULL,
+    billing_currency VARCHAR(3) NOT NULL,
+    subtotal DECIMAL(15,2) NOT NULL DEFAULT 0.00,
+    discount DECIMAL(15,2) NOT NULL DEFAULT 0.00,
+    discount_type ENUM('fixed', 'percentage') NOT NULL DEFAULT 'fixed',
+    discount_rate DECIMAL(9,4) NOT NULL DEFAULT 0.0000,
+    discount_total DECIMAL(15,2) NOT NULL DEFAULT 0.00,
+    tax_total DECIMAL(15,2) NOT NULL DEFAULT 0.00,
+    total_amount DECIMAL(15,2) NOT NULL DEFAULT 0.00,
+    notes TEXT,
+    items JSON NOT NULL,
+    payment_info JSON NOT NULL,
+    share_token_id CHAR(32) NULL,
+    sent_at TIMESTAMP NULL,
+    responded_at TIMESTAMP NULL,
+    decline_reason TEXT,
+    converted_at TIMESTAMP NULL,
+    invoice_id BIGINT UNSIGNED NULL,
+    created_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP,
+    updated_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP ON UPDATE CURRENT_TIMESTAMP,
+    deleted_at TIMESTAMP NULL,
+    FOREIGN KEY (customer_id) REFERENCES customers(id),
+    FOREIGN KEY (business_profile_id) REFERENCES business_profiles(id),
+    FOREIGN KEY (client_id) REFERENCES clients(id),
+    FOREIGN KEY (invoice_id) REFERENCES invoices(id),
+    UNIQUE KEY uq_quotes_customer_number (customer_id, quote_number),
+    UNIQUE KEY uq_quotes_share_token_id (share_token_id)
+);
+
+CREATE INDEX idx_quotes_customer_id ON quotes(customer_id, deleted_at);
+CREATE INDEX idx_quotes_expiry ON quotes(status, expiry_date);
+
+ALTER TABLE document_sequences
+MODIFY COLUMN document_type ENUM('invoice', 'credit_note', 'quote') NOT NULL;
+
+-- the entry recording a conversion points at both the quote and the invoice
+ALTER TABLE audit_trails
+ADD COLUMN quote_id BIGINT UNSIGNED NULL AFTER invoice_id,
+ADD CONSTRAINT fk_audit_trails_quote_id FOREIGN KEY (quote_id) REFERENCES quotes(id),
+MODIFY COLUMN event_type ENUM('invoice_created', 'invoice_duplicated', 'invoice_updated', 'invoice_deleted', 'payment_confirmed', 'invoice_status_changed', 'recurring_invoice_generated', 'share_link_created', 'share_link_revoked', 'invoice_viewed', 'credit_note_issued', 'quote_converted') NOT NULL;
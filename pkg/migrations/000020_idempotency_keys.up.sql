@@ -0,0 +1,20 @@
+-- a retried request carrying the same Idempotency-Key gets the response the
+-- first one was given instead of being run again. The response is kept
+-- until expires_at, after which the key can be used afresh.
+CREATE TABLE IF NOT EXISTS idempotency_keys (
+    id BIGINT UNSIGNED AUTO_INCREMENT PRIMARY KEY,
+    customer_id BIGINT UNSIGNED NOT NULL,
+    idempotency_key VARCHAR(255) NOT NULL,
+    request_hash CHAR(64) NOT NULL,
+    status ENUM('processing', 'completed') NOT NULL DEFAULT 'processing',
+    response_status INT NOT NULL DEFAULT 0,
+    response_headers JSON NOT NULL,
+    response_body MEDIUMBLOB NULL,
+    expires_at TIMESTAMP NOT NULL,
+    created_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP,
+    updated_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP ON UPDATE CURRENT_TIMESTAMP,
+    FOREIGN KEY (customer_id) REFERENCES customers(id),
+    UNIQUE KEY uq_idempotency_keys_customer_key (customer_id, idempotency_key)
+);
+
+CREATE INDEX idx_idempotency_keys_expires_at ON idempotency_keys(expires_at);
@@ -0,0 +1,8 @@
+DROP INDEX idx_payments_method ON payments;
+
+ALTER TABLE payments
+DROP COLUMN attachments,
+DROP COLUMN notes,
+DROP COLUMN payer_name,
+DROP COLUMN reference,
+DROP COLUMN method;
@@ -0,0 +1,16 @@
+-- how a payment was made and what identifies it on a bank statement.
+-- Payments recorded before this are kept as 'other'.
+ALTER TABLE payments
+ADD COLUMN method ENUM('bank_transfer', 'card', 'cash', 'cheque', 'mobile_money', 'other') NOT NULL DEFAULT 'other' AFTER date,
+ADD COLUMN reference VARCHAR(255) NOT NULL DEFAULT '' AFTER method,
+ADD COLUMN payer_name VARCHAR(255) NOT NULL DEFAULT '' AFTER reference,
+ADD COLUMN notes TEXT NULL AFTER payer_name,
+ADD COLUMN attachments JSON NULL AFTER notes;
+
+UPDATE payments SET notes = '', attachments = JSON_ARRAY();
+
+ALTER TABLE payments
+MODIFY COLUMN notes TEXT NOT NULL,
+MODIFY COLUMN attachments JSON NOT NULL;
+
+CREATE INDEX idx_payments_method ON payments(method);
@@ -0,0 +1,12 @@
+DELETE FROM audit_trails WHERE event_type IN ('payment_refunded', 'payment_reversed');
+
+ALTER TABLE audit_trails
+MODIFY COLUMN event_type ENUM('invoice_created', 'invoice_duplicated', 'invoice_updated', 'invoice_deleted', 'payment_confirmed', 'invoice_status_changed', 'recurring_invoice_generated', 'share_link_created', 'share_link_revoked', 'invoice_viewed', 'credit_note_issued', 'quote_converted') NOT NULL;
+
+DELETE FROM payments WHERE kind IN ('refund', 'reversal');
+
+ALTER TABLE payments
+DROP FOREIGN KEY fk_payments_original_payment_id,
+DROP COLUMN reason,
+DROP COLUMN original_payment_id,
+DROP COLUMN kind;
@@ -0,0 +1,11 @@
+-- refunds and reversals are kept as payments with a negative amount, linked
+-- to the payment they take money back from, so the sum of an invoice's
+-- payments is always what it has been paid
+ALTER TABLE payments
+ADD COLUMN kind ENUM('payment', 'refund', 'reversal') NOT NULL DEFAULT 'payment' AFTER invoice_id,
+ADD COLUMN original_payment_id BIGINT UNSIGNED NULL AFTER kind,
+ADD COLUMN reason VARCHAR(500) NOT NULL DEFAULT '' AFTER notes,
+ADD CONSTRAINT fk_payments_original_payment_id FOREIGN KEY (original_payment_id) REFERENCES payments(id);
+
+ALTER TABLE audit_trails
+MODIFY COLUMN event_type ENUM('invoice_created', 'invoice_duplicated', 'invoice_updated', 'invoice_deleted', 'payment_confirmed', 'invoice_status_changed', 'recurring_invoice_generated', 'share_link_created', 'share_link_revoked', 'invoice_viewed', 'credit_note_issued', 'quote_converted', 'payment_refunded', 'payment_reversed') NOT NULL;
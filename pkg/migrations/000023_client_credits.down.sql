@@ -0,0 +1,11 @@
+DELETE FROM audit_trails WHERE event_type IN ('client_credited', 'client_credit_applied');
+
+ALTER TABLE audit_trails
+MODIFY COLUMN event_type ENUM('invoice_created', 'invoice_duplicated', 'invoice_updated', 'invoice_deleted', 'payment_confirmed', 'invoice_status_changed', 'recurring_invoice_generated', 'share_link_created', 'share_link_revoked', 'invoice_viewed', 'credit_note_issued', 'quote_converted', 'payment_refunded', 'payment_reversed') NOT NULL;
+
+DROP TABLE IF EXISTS client_credits;
+
+DELETE FROM payments WHERE kind = 'credit';
+
+ALTER TABLE payments
+MODIFY COLUMN kind ENUM('payment', 'refund', 'reversal') NOT NULL DEFAULT 'payment';
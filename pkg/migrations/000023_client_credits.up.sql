@@ -0,0 +1,30 @@
+-- a client's credit is kept as a ledger: what they overpay is added to it and
+-- what is applied to their invoices taken off, so their balance in each
+-- currency is the sum of its entries
+CREATE TABLE IF NOT EXISTS client_credits (
+    id BIGINT UNSIGNED AUTO_INCREMENT PRIMARY KEY,
+    customer_id BIGINT UNSIGNED NOT NULL,
+    client_id BIGINT UNSIGNED NOT NULL,
+    kind ENUM('overpayment', 'applied') NOT NULL,
+    amount DECIMAL(15,2) NOT NULL,
+    currency VARCHAR(3) NOT NULL,
+    payment_id BIGINT UNSIGNED NOT NULL,
+    invoice_id BIGINT UNSIGNED NOT NULL,
+    description VARCHAR(500) NOT NULL DEFAULT '',
+    created_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP,
+    updated_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP ON UPDATE CURRENT_TIMESTAMP,
+    FOREIGN KEY (customer_id) REFERENCES customers(id),
+    FOREIGN KEY (client_id) REFERENCES clients(id),
+    FOREIGN KEY (payment_id) REFERENCES payments(id),
+    FOREIGN KEY (invoice_id) REFERENCES invoices(id)
+);
+
+CREATE INDEX idx_client_credits_client_currency ON client_credits(client_id, currency);
+CREATE INDEX idx_client_credits_customer_id ON client_credits(customer_id);
+
+-- credit applied to an invoice is recorded as one of its payments
+ALTER TABLE payments
+MODIFY COLUMN kind ENUM('payment', 'refund', 'reversal', 'credit') NOT NULL DEFAULT 'payment';
+
+ALTER TABLE audit_trails
+MODIFY COLUMN event_type ENUM('invoice_created', 'invoice_duplicated', 'invoice_updated', 'invoice_deleted', 'payment_confirmed', 'invoice_status_changed', 'recurring_invoice_generated', 'share_link_created', 'share_link_revoked', 'invoice_viewed', 'credit_note_issued', 'quote_converted', 'payment_refunded', 'payment_reversed', 'client_credited', 'client_credit_applied') NOT NULL;
@@ -0,0 +1,4 @@
+DELETE FROM client_credits WHERE kind = 'reversal';
+
+ALTER TABLE client_credits
+MODIFY COLUMN kind ENUM('overpayment', 'applied') NOT NULL;
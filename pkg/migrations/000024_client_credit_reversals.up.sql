@@ -0,0 +1,4 @@
+-- reversing a payment takes back the credit its overpayment left, and
+-- reversing credit applied to an invoice gives it back to the client
+ALTER TABLE client_credits
+MODIFY COLUMN kind ENUM('overpayment', 'applied', 'reversal') NOT NULL;
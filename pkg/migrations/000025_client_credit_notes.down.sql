@@ -0,0 +1,7 @@
+DELETE FROM client_credits WHERE kind = 'credit_note';
+
+ALTER TABLE client_credits
+DROP FOREIGN KEY fk_client_credits_credit_note_id,
+DROP COLUMN credit_note_id,
+MODIFY COLUMN payment_id BIGINT UNSIGNED NOT NULL,
+MODIFY COLUMN kind ENUM('overpayment', 'applied', 'reversal') NOT NULL;
@@ -0,0 +1,7 @@
+-- a credit note on an invoice that was already paid gives what it credits
+-- back to the client as credit, with no payment behind the entry
+ALTER TABLE client_credits
+MODIFY COLUMN kind ENUM('overpayment', 'applied', 'reversal', 'credit_note') NOT NULL,
+MODIFY COLUMN payment_id BIGINT UNSIGNED NULL,
+ADD COLUMN credit_note_id BIGINT UNSIGNED NULL AFTER payment_id,
+ADD CONSTRAINT fk_client_credits_credit_note_id FOREIGN KEY (credit_note_id) REFERENCES credit_notes(id);
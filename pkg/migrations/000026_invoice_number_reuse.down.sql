@@ -0,0 +1,12 @@
+-- deleted drafts whose number was given out again are renamed out of the way
+UPDATE invoices deleted
+JOIN invoices live
+    ON live.customer_id = deleted.customer_id
+    AND live.invoice_number = deleted.invoice_number
+    AND live.deleted_at IS NULL
+SET deleted.invoice_number = CONCAT(deleted.invoice_number, '-deleted-', deleted.id)
+WHERE deleted.deleted_at IS NOT NULL;
+
+ALTER TABLE invoices
+DROP INDEX uq_invoices_customer_invoice_number,
+ADD UNIQUE KEY uq_invoices_customer_invoice_number (customer_id, invoice_number);
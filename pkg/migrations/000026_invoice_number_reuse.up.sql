@@ -0,0 +1,5 @@
+-- a deleted draft gives its number back, so only invoices that are not
+-- deleted have to keep their numbers unique
+ALTER TABLE invoices
+DROP INDEX uq_invoices_customer_invoice_number,
+ADD UNIQUE KEY uq_invoices_customer_invoice_number (customer_id, (IF(deleted_at IS NULL, invoice_number, NULL)));
@@ -0,0 +1,8 @@
+-- a key is held by the request running it only until locked_until, so a key
+-- whose request died with the server can be taken over by a retry instead of
+-- being refused until it expires. Keys still processing from before this are
+-- taken over straight away.
+ALTER TABLE idempotency_keys
+ADD COLUMN locked_until TIMESTAMP NULL AFTER status;
+
+UPDATE idempotency_keys SET locked_until = updated_at WHERE status = 'processing';
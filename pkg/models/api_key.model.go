@@ -0,0 +1,25 @@
+package models
+
+import "time"
+
+// APIKey lets a customer's integrations authenticate without a user token.
+// Only a SHA-256 hash of the key is stored; Prefix is kept so the key can be
+// recognised in listings.
+type APIKey struct {
+	ID         uint       `db:"id" json:"id"`
+	CustomerID uint       `db:"customer_id" json:"customer_id"`
+	Name       string     `db:"name" json:"name"`
+	Role       UserRole   `db:"role" json:"role"`
+	Prefix     string     `db:"prefix" json:"prefix"`
+	KeyHash    string     `db:"key_hash" json:"-"`
+	LastUsedAt *time.Time `db:"last_used_at" json:"last_used_at"`
+	ExpiresAt  *time.Time `db:"expires_at" json:"expires_at"`
+	RevokedAt  *time.Time `db:"revoked_at" json:"revoked_at"`
+	CreatedAt  time.Time  `db:"created_at" json:"created_at"`
+	UpdatedAt  time.Time  `db:"updated_at" json:"updated_at"`
+}
+
+// IsActive reports whether the key can still be used to authenticate
+func (k *APIKey) IsActive(now time.Time) bool {
+	return k.RevokedAt == nil && (k.ExpiresAt == nil || now.Before(*k.ExpiresAt))
+}
@@ -5,9 +5,22 @@ import "time"
 type EventType string
 
 const (
-	EventTypeInvoiceCreated    EventType = "invoice_created"
-	EventTypeInvoiceDuplicated EventType = "invoice_duplicated"
-	EventTypePaymentConfirmed  EventType = "payment_confirmed"
+	EventTypeInvoiceCreated            EventType = "invoice_created"
+	EventTypeInvoiceDuplicated         EventType = "invoice_duplicated"
+	EventTypeInvoiceUpdated            EventType = "invoice_updated"
+	EventTypeInvoiceDeleted            EventType = "invoice_deleted"
+	EventTypePaymentConfirmed          EventType = "payment_confirmed"
+	EventTypeInvoiceStatusChanged      EventType = "invoice_status_changed"
+	EventTypeRecurringInvoiceGenerated EventType = "recurring_invoice_generated"
+	EventTypeShareLinkCreated          EventType = "share_link_created"
+	EventTypeShareLinkRevoked          EventType = "share_link_revoked"
+	EventTypeInvoiceViewed             EventType = "invoice_viewed"
+	EventTypeCreditNoteIssued          EventType = "credit_note_issued"
+	EventTypeQuoteConverted            EventType = "quote_converted"
+	EventTypePaymentRefunded           EventType = "payment_refunded"
+	EventTypePaymentReversed           EventType = "payment_reversed"
+	EventTypeClientCredited            EventType = "client_credited"
+	EventTypeClientCreditApplied       EventType = "client_credit_applied"
 )
 
 type LogLevel string
@@ -18,15 +31,29 @@ const (
 	LogLevelError   LogLevel = "error"
 )
 
+// AuditActorType says who performed an audited action
+type AuditActorType string
+
+const (
+	AuditActorSystem AuditActorType = "system"
+	AuditActorUser   AuditActorType = "user"
+	AuditActorAPIKey AuditActorType = "api_key"
+)
+
 // AuditTrail struct represents an audit trail entity used to log actions performed on invoices by customers
 type AuditTrail struct {
-	ID         uint       `db:"id" json:"id"`
-	EventType  EventType  `db:"event_type" json:"event_type"`
-	LogLevel   LogLevel   `db:"log_level" json:"log_level"`
-	Message    string     `db:"message" json:"message"`
-	InvoiceID  uint       `db:"invoice_id" json:"invoice_id"`
-	CustomerID uint       `db:"customer_id" json:"customer_id"`
-	CreatedAt  time.Time  `db:"created_at" json:"created_at"`
-	UpdatedAt  time.Time  `db:"updated_at" json:"updated_at"`
-	DeletedAt  *time.Time `db:"deleted_at" json:"deleted_at"`
+	ID         uint      `db:"id" json:"id"`
+	EventType  EventType `db:"event_type" json:"event_type"`
+	LogLevel   LogLevel  `db:"log_level" json:"log_level"`
+	Message    string    `db:"message" json:"message"`
+	InvoiceID  uint      `db:"invoice_id" json:"invoice_id"`
+	QuoteID    *uint     `db:"quote_id" json:"quote_id,omitempty"`
+	CustomerID uint      `db:"customer_id" json:"customer_id"`
+	// ActorType and the matching actor ID record who performed the action
+	ActorType     AuditActorType `db:"actor_type" json:"actor_type"`
+	ActorUserID   *uint          `db:"actor_user_id" json:"actor_user_id"`
+	ActorAPIKeyID *uint          `db:"actor_api_key_id" json:"actor_api_key_id"`
+	CreatedAt     time.Time      `db:"created_at" json:"created_at"`
+	UpdatedAt     time.Time      `db:"updated_at" json:"updated_at"`
+	DeletedAt     *time.Time     `db:"deleted_at" json:"deleted_at"`
 }
@@ -0,0 +1,31 @@
+package models
+
+import "time"
+
+// BankAccount is an account a customer asks to be paid into. Invoices issued
+// without payment details use the account marked as the default.
+type BankAccount struct {
+	ID            uint       `db:"id" json:"id"`
+	CustomerID    uint       `db:"customer_id" json:"customer_id"`
+	BankName      string     `db:"bank_name" json:"bank_name"`
+	AccountNumber string     `db:"account_number" json:"account_number"`
+	AccountName   string     `db:"account_name" json:"account_name"`
+	AchRoutingNo  string     `db:"ach_routing_no" json:"ach_routing_no"`
+	BankAddress   string     `db:"bank_address" json:"bank_address"`
+	IsDefault     bool       `db:"is_default" json:"is_default"`
+	CreatedAt     time.Time  `db:"created_at" json:"created_at"`
+	UpdatedAt     time.Time  `db:"updated_at" json:"updated_at"`
+	DeletedAt     *time.Time `db:"deleted_at" json:"deleted_at"`
+}
+
+// Snapshot copies the account's current details for an invoice being issued
+func (b *BankAccount) Snapshot() *PaymentInfo {
+	return &PaymentInfo{
+		BankAccountID: &b.ID,
+		BankName:      b.BankName,
+		AccountNumber: b.AccountNumber,
+		AccountName:   b.AccountName,
+		AchRoutingNo:  b.AchRoutingNo,
+		BankAddress:   b.BankAddress,
+	}
+}
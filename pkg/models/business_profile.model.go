@@ -0,0 +1,51 @@
+package models
+
+import "time"
+
+// BusinessProfile is a name a customer issues invoices under, e.g. a trading
+// name or a branch. Invoices issued without one use the customer's own
+// details, or the profile marked as the default.
+type BusinessProfile struct {
+	ID         uint       `db:"id" json:"id"`
+	CustomerID uint       `db:"customer_id" json:"customer_id"`
+	Name       string     `db:"name" json:"name"`
+	Email      string     `db:"email" json:"email"`
+	Phone      string     `db:"phone" json:"phone"`
+	Address    string     `db:"address" json:"address"`
+	IsDefault  bool       `db:"is_default" json:"is_default"`
+	CreatedAt  time.Time  `db:"created_at" json:"created_at"`
+	UpdatedAt  time.Time  `db:"updated_at" json:"updated_at"`
+	DeletedAt  *time.Time `db:"deleted_at" json:"deleted_at"`
+}
+
+// InvoiceIssuer is the copy of the issuer's details kept on an invoice, so the
+// invoice still shows who issued it after the profile is edited or deleted
+type InvoiceIssuer struct {
+	BusinessProfileID *uint  `db:"business_profile_id" json:"business_profile_id"`
+	Name              string `db:"issuer_name" json:"name"`
+	Email             string `db:"issuer_email" json:"email"`
+	Phone             string `db:"issuer_phone" json:"phone"`
+	Address           string `db:"issuer_address" json:"address"`
+}
+
+// Snapshot copies the profile's current details for an invoice being issued
+func (b *BusinessProfile) Snapshot() InvoiceIssuer {
+	return InvoiceIssuer{
+		BusinessProfileID: &b.ID,
+		Name:              b.Name,
+		Email:             b.Email,
+		Phone:             b.Phone,
+		Address:           b.Address,
+	}
+}
+
+// IssuerSnapshot copies the customer's own details for an invoice issued
+// without a business profile
+func (c *Customer) IssuerSnapshot() InvoiceIssuer {
+	return InvoiceIssuer{
+		Name:    c.Name,
+		Email:   c.Email,
+		Phone:   c.Phone,
+		Address: c.Address,
+	}
+}
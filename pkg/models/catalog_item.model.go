@@ -0,0 +1,35 @@
+package models
+
+import (
+	"time"
+
+	"github.com/Adebayobenjamin/numerisbook/pkg/common/money"
+)
+
+// CatalogItem is a product or service a customer sells. Invoice lines that
+// reference it take its name, price, unit and tax unless they give their own.
+type CatalogItem struct {
+	ID          uint        `db:"id" json:"id"`
+	CustomerID  uint        `db:"customer_id" json:"customer_id"`
+	SKU         string      `db:"sku" json:"sku"`
+	Name        string      `db:"name" json:"name"`
+	Description string      `db:"description" json:"description"`
+	UnitPrice   money.Money `db:"unit_price" json:"unit_price"`
+	Currency    string      `db:"currency" json:"currency"`
+	// Unit is the unit of measure the item is sold in, e.g. "hour" or "kg"
+	Unit string `db:"unit" json:"unit"`
+	// the tax charged on the item by default, none when TaxName is empty
+	TaxName        string     `db:"tax_name" json:"tax_name"`
+	TaxRate        money.Rate `db:"tax_rate" json:"tax_rate"`
+	TaxIsInclusive bool       `db:"tax_is_inclusive" json:"tax_is_inclusive"`
+	CreatedAt      time.Time  `db:"created_at" json:"created_at"`
+	UpdatedAt      time.Time  `db:"updated_at" json:"updated_at"`
+	DeletedAt      *time.Time `db:"deleted_at" json:"deleted_at"`
+}
+
+// AssignCurrency tags the item's price with the currency it is sold in
+func (c *CatalogItem) AssignCurrency() error {
+	var err error
+	c.UnitPrice, err = c.UnitPrice.WithCurrency(c.Currency)
+	return err
+}
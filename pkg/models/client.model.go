@@ -0,0 +1,39 @@
+package models
+
+import "time"
+
+// Client is an entry in a customer's directory of the parties they bill.
+// Deleting a client only archives it, invoices already issued to it keep
+// their own copy of its details.
+type Client struct {
+	ID         uint       `db:"id" json:"id"`
+	CustomerID uint       `db:"customer_id" json:"customer_id"`
+	Name       string     `db:"name" json:"name"`
+	Email      string     `db:"email" json:"email"`
+	Phone      string     `db:"phone" json:"phone"`
+	Address    string     `db:"address" json:"address"`
+	CreatedAt  time.Time  `db:"created_at" json:"created_at"`
+	UpdatedAt  time.Time  `db:"updated_at" json:"updated_at"`
+	DeletedAt  *time.Time `db:"deleted_at" json:"deleted_at"`
+}
+
+// InvoiceClient is the copy of a client's details kept on an invoice, so the
+// invoice still shows who was billed after the client is edited or deleted
+type InvoiceClient struct {
+	ClientID *uint  `db:"client_id" json:"id"`
+	Name     string `db:"client_name" json:"name"`
+	Email    string `db:"client_email" json:"email"`
+	Phone    string `db:"client_phone" json:"phone"`
+	Address  string `db:"client_address" json:"address"`
+}
+
+// Snapshot copies the client's current details for an invoice being issued
+func (c *Client) Snapshot() InvoiceClient {
+	return InvoiceClient{
+		ClientID: &c.ID,
+		Name:     c.Name,
+		Email:    c.Email,
+		Phone:    c.Phone,
+		Address:  c.Address,
+	}
+}
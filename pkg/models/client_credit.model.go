@@ -0,0 +1,44 @@
+package models
+
+import (
+	"time"
+
+	"github.com/Adebayobenjamin/numerisbook/pkg/common/money"
+)
+
+type ClientCreditKind string
+
+const (
+	// ClientCreditKindOverpayment adds what a payment brought in over what
+	// its invoice asked for
+	ClientCreditKindOverpayment ClientCreditKind = "overpayment"
+	// ClientCreditKindApplied takes off credit used to pay another invoice
+	ClientCreditKindApplied ClientCreditKind = "applied"
+	// ClientCreditKindReversal undoes the entry of a payment that was
+	// reversed: it takes back what the payment overpaid, or gives back credit
+	// that was applied to an invoice
+	ClientCreditKindReversal ClientCreditKind = "reversal"
+	// ClientCreditKindCreditNote adds what a credit note took off an invoice
+	// that had already been paid
+	ClientCreditKindCreditNote ClientCreditKind = "credit_note"
+)
+
+// ClientCredit is an entry in a client's credit ledger. Overpayments are
+// positive and credit applied to an invoice negative, so the client's balance
+// in a currency is the sum of their entries in it. PaymentID is the payment
+// that overpaid, the one the credit was applied as, or the reversal of either;
+// entries made by a credit note have its CreditNoteID instead.
+type ClientCredit struct {
+	ID           uint             `db:"id" json:"id"`
+	CustomerID   uint             `db:"customer_id" json:"customer_id"`
+	ClientID     uint             `db:"client_id" json:"client_id"`
+	Kind         ClientCreditKind `db:"kind" json:"kind"`
+	Amount       money.Money      `db:"amount" json:"amount"`
+	Currency     string           `db:"currency" json:"currency"`
+	PaymentID    *uint            `db:"payment_id" json:"payment_id"`
+	CreditNoteID *uint            `db:"credit_note_id" json:"credit_note_id,omitempty"`
+	InvoiceID    uint             `db:"invoice_id" json:"invoice_id"`
+	Description  string           `db:"description" json:"description"`
+	CreatedAt    time.Time        `db:"created_at" json:"created_at"`
+	UpdatedAt    time.Time        `db:"updated_at" json:"updated_at"`
+}
@@ -0,0 +1,82 @@
+package models
+
+import (
+	"time"
+
+	"github.com/Adebayobenjamin/numerisbook/pkg/common/money"
+)
+
+// CreditNote takes some or all of an issued invoice back. It has its own
+// number and reduces what the invoice asks to be paid by its Total.
+type CreditNote struct {
+	ID               uint             `db:"id" json:"id"`
+	CustomerID       uint             `db:"customer_id" json:"customer_id"`
+	InvoiceID        uint             `db:"invoice_id" json:"invoice_id"`
+	CreditNoteNumber string           `db:"credit_note_number" json:"credit_note_number"`
+	Reason           string           `db:"reason" json:"reason"`
+	IssueDate        time.Time        `db:"issue_date" json:"issue_date"`
+	BillingCurrency  string           `db:"billing_currency" json:"billing_currency"`
+	NetTotal         money.Money      `db:"net_total" json:"net_total"`
+	TaxTotal         money.Money      `db:"tax_total" json:"tax_total"`
+	Total            money.Money      `db:"total" json:"total"`
+	Items            []CreditNoteItem `db:"items" json:"items,omitempty"`
+	// ClientCredit is what the note credits of what was already paid on the
+	// invoice, which is given back to the invoice's client as credit. It is
+	// only set on the note as it is issued.
+	ClientCredit *money.Money `db:"-" json:"client_credit,omitempty"`
+	CreatedAt    time.Time    `db:"created_at" json:"created_at"`
+	UpdatedAt    time.Time    `db:"updated_at" json:"updated_at"`
+	DeletedAt    *time.Time   `db:"deleted_at" json:"deleted_at,omitempty"`
+}
+
+// CreditNoteItem credits a quantity of one line of the invoice, with the
+// same share of the line's net amount and taxes
+type CreditNoteItem struct {
+	ID            uint        `db:"id" json:"id"`
+	CreditNoteID  uint        `db:"credit_note_id" json:"credit_note_id"`
+	InvoiceItemID uint        `db:"invoice_item_id" json:"invoice_item_id"`
+	Description   string      `db:"description" json:"description"`
+	Quantity      int         `db:"quantity" json:"quantity"`
+	UnitPrice     money.Money `db:"unit_price" json:"unit_price"`
+	NetAmount     money.Money `db:"net_amount" json:"net_amount"`
+	TaxTotal      money.Money `db:"tax_total" json:"tax_total"`
+	Total         money.Money `db:"total" json:"total"`
+	CreatedAt     time.Time   `db:"created_at" json:"created_at"`
+	UpdatedAt     time.Time   `db:"updated_at" json:"updated_at"`
+	DeletedAt     *time.Time  `db:"deleted_at" json:"deleted_at,omitempty"`
+}
+
+// AssignCurrency tags every amount on the credit note, including its items,
+// with the currency of the invoice it credits
+func (c *CreditNote) AssignCurrency() error {
+	var err error
+	currency := c.BillingCurrency
+
+	if c.NetTotal, err = c.NetTotal.WithCurrency(currency); err != nil {
+		return err
+	}
+	if c.TaxTotal, err = c.TaxTotal.WithCurrency(currency); err != nil {
+		return err
+	}
+	if c.Total, err = c.Total.WithCurrency(currency); err != nil {
+		return err
+	}
+
+	for idx := range c.Items {
+		item := &c.Items[idx]
+		if item.UnitPrice, err = item.UnitPrice.WithCurrency(currency); err != nil {
+			return err
+		}
+		if item.NetAmount, err = item.NetAmount.WithCurrency(currency); err != nil {
+			return err
+		}
+		if item.TaxTotal, err = item.TaxTotal.WithCurrency(currency); err != nil {
+			return err
+		}
+		if item.Total, err = item.Total.WithCurrency(currency); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
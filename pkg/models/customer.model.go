@@ -2,14 +2,18 @@ package models
 
 import "time"
 
-// Customer struct represents a customer entity
+// Customer struct represents a customer entity. The branding fields are used
+// to style the emails sent on the customer's behalf.
 type Customer struct {
-	ID        uint       `db:"id" json:"id"`
-	Name      string     `db:"name" json:"name"`
-	Phone     string     `db:"phone" json:"phone"`
-	Address   string     `db:"address" json:"address"`
-	Email     string     `db:"email" json:"email"`
-	CreatedAt time.Time  `db:"created_at" json:"created_at"`
-	UpdatedAt time.Time  `db:"updated_at" json:"updated_at"`
-	DeletedAt *time.Time `db:"deleted_at" json:"deleted_at"`
+	ID          uint       `db:"id" json:"id"`
+	Name        string     `db:"name" json:"name"`
+	Phone       string     `db:"phone" json:"phone"`
+	Address     string     `db:"address" json:"address"`
+	Email       string     `db:"email" json:"email"`
+	BrandColor  *string    `db:"brand_color" json:"brand_color,omitempty"`
+	LogoURL     *string    `db:"logo_url" json:"logo_url,omitempty"`
+	EmailFooter *string    `db:"email_footer" json:"email_footer,omitempty"`
+	CreatedAt   time.Time  `db:"created_at" json:"created_at"`
+	UpdatedAt   time.Time  `db:"updated_at" json:"updated_at"`
+	DeletedAt   *time.Time `db:"deleted_at" json:"deleted_at"`
 }
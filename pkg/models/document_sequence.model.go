@@ -0,0 +1,36 @@
+package models
+
+import "time"
+
+// DocumentType is the kind of numbered document a sequence hands out numbers for
+type DocumentType string
+
+const (
+	DocumentTypeInvoice    DocumentType = "invoice"
+	DocumentTypeCreditNote DocumentType = "credit_note"
+	DocumentTypeQuote      DocumentType = "quote"
+)
+
+type SequenceResetPolicy string
+
+const (
+	SequenceResetPolicyNever  SequenceResetPolicy = "never"
+	SequenceResetPolicyYearly SequenceResetPolicy = "yearly"
+)
+
+// DocumentSequence is a customer's counter for one type of document and the
+// template its numbers are formatted with
+type DocumentSequence struct {
+	ID           uint                `db:"id" json:"id"`
+	CustomerID   uint                `db:"customer_id" json:"customer_id"`
+	DocumentType DocumentType        `db:"document_type" json:"document_type"`
+	Prefix       string              `db:"prefix" json:"prefix"`
+	Format       string              `db:"format" json:"format"`
+	ResetPolicy  SequenceResetPolicy `db:"reset_policy" json:"reset_policy"`
+	NextValue    uint64              `db:"next_value" json:"next_value"`
+	CurrentYear  int                 `db:"current_year" json:"current_year"`
+	// NextNumber previews the number the next document will get
+	NextNumber string    `db:"-" json:"next_number,omitempty"`
+	CreatedAt  time.Time `db:"created_at" json:"created_at"`
+	UpdatedAt  time.Time `db:"updated_at" json:"updated_at"`
+}
@@ -0,0 +1,54 @@
+package models
+
+import (
+	"database/sql/driver"
+	"encoding/json"
+	"net/http"
+	"time"
+)
+
+type IdempotencyKeyStatus string
+
+const (
+	// IdempotencyKeyStatusProcessing is a key whose first request has not
+	// finished yet
+	IdempotencyKeyStatusProcessing IdempotencyKeyStatus = "processing"
+	// IdempotencyKeyStatusCompleted is a key whose response is kept for replay
+	IdempotencyKeyStatusCompleted IdempotencyKeyStatus = "completed"
+)
+
+// IdempotencyKey remembers the response a customer's request was given, so a
+// retry sent with the same key is answered with it instead of being run again.
+// RequestHash is a SHA-256 of the request, so the key cannot be reused for a
+// different one. A key being processed is held by its request until
+// LockedUntil, after which a retry of the same request may take it over.
+type IdempotencyKey struct {
+	ID              uint                 `db:"id" json:"id"`
+	CustomerID      uint                 `db:"customer_id" json:"customer_id"`
+	Key             string               `db:"idempotency_key" json:"idempotency_key"`
+	RequestHash     string               `db:"request_hash" json:"-"`
+	Status          IdempotencyKeyStatus `db:"status" json:"status"`
+	LockedUntil     *time.Time           `db:"locked_until" json:"-"`
+	ResponseStatus  int                  `db:"response_status" json:"-"`
+	ResponseHeaders ResponseHeaders      `db:"response_headers" json:"-"`
+	ResponseBody    []byte               `db:"response_body" json:"-"`
+	ExpiresAt       time.Time            `db:"expires_at" json:"expires_at"`
+	CreatedAt       time.Time            `db:"created_at" json:"created_at"`
+	UpdatedAt       time.Time            `db:"updated_at" json:"updated_at"`
+}
+
+// ResponseHeaders are the headers of a kept response, stored as JSON
+type ResponseHeaders http.Header
+
+// Value implements driver.Valuer.
+func (h ResponseHeaders) Value() (driver.Value, error) {
+	if h == nil {
+		return []byte("{}"), nil
+	}
+	return json.Marshal(h)
+}
+
+// Scan implements sql.Scanner.
+func (h *ResponseHeaders) Scan(src interface{}) error {
+	return scanJSON(src, h)
+}
@@ -1,38 +1,112 @@
 package models
 
-import "time"
+import (
+	"time"
+
+	"github.com/Adebayobenjamin/numerisbook/pkg/common/money"
+)
 
 type InvoiceStatus string
 
 const (
-	InvoiceStatusDraft          InvoiceStatus = "draft"
-	InvoiceStatusSent           InvoiceStatus = "sent"
-	InvoiceStatusPaid           InvoiceStatus = "paid"
-	InvoiceStatusPendingPayment InvoiceStatus = "pending payment"
+	InvoiceStatusDraft         InvoiceStatus = "draft"
+	InvoiceStatusSent          InvoiceStatus = "sent"
+	InvoiceStatusPartiallyPaid InvoiceStatus = "partially_paid"
+	InvoiceStatusPaid          InvoiceStatus = "paid"
+	InvoiceStatusOverdue       InvoiceStatus = "overdue"
+	InvoiceStatusVoid          InvoiceStatus = "void"
+	InvoiceStatusCancelled     InvoiceStatus = "cancelled"
+	InvoiceStatusWrittenOff    InvoiceStatus = "written_off"
 )
 
-// Invoice represents an invoice entity
+type DiscountType string
+
+const (
+	DiscountTypeFixed      DiscountType = "fixed"
+	DiscountTypePercentage DiscountType = "percentage"
+)
+
+// Invoice represents an invoice entity. It is issued under the details
+// copied into InvoiceIssuer and billed to the client copied into InvoiceClient.
 type Invoice struct {
 	ID              uint              `db:"id" json:"id,omitempty"`
 	InvoiceNumber   string            `db:"invoice_number" json:"invoice_number,omitempty"`
-	Sender          *Sender           `db:"sender" json:"sender,omitempty"`
 	CustomerID      uint              `db:"customer_id" json:"customer_id,omitempty"`
 	Customer        *Customer         `db:"customer" json:"customer,omitempty"`
 	IssueDate       time.Time         `db:"issue_date" json:"issue_date,omitempty"`
 	DueDate         time.Time         `db:"due_date" json:"due_date,omitempty"`
-	TotalAmountDue  float64           `db:"total_amount_due" json:"total_amount_due,omitempty"`
-	Subtotal        float64           `db:"subtotal" json:"subtotal,omitempty"`
+	TotalAmountDue  money.Money       `db:"total_amount_due" json:"total_amount_due,omitempty"`
+	CreditedTotal   money.Money       `db:"credited_total" json:"credited_total"`
+	Subtotal        money.Money       `db:"subtotal" json:"subtotal,omitempty"`
+	TaxTotal        money.Money       `db:"tax_total" json:"tax_total"`
 	IsFullyPaid     bool              `db:"is_fully_paid" json:"is_fully_paid,omitempty"`
 	BillingCurrency string            `db:"billing_currency" json:"billing_currency,omitempty"`
 	Items           []InvoiceItem     `db:"items" json:"items,omitempty"`
-	Discount        float64           `db:"discount" json:"discount,omitempty"`
+	Discount        money.Money       `db:"discount" json:"discount,omitempty"`
+	DiscountType    DiscountType      `db:"discount_type" json:"discount_type,omitempty"`
+	DiscountRate    money.Rate        `db:"discount_rate" json:"discount_rate,omitempty"`
+	DiscountTotal   money.Money       `db:"discount_total" json:"discount_total"`
 	Payments        []Payment         `db:"payments" json:"payments,omitempty"`
 	Reminders       []InvoiceReminder `db:"reminders" json:"reminders,omitempty"`
 	Status          InvoiceStatus     `db:"status" json:"status,omitempty"`
+	Version         uint              `db:"version" json:"version"`
 	PaymentInfo     *PaymentInfo      `db:"payment_info" json:"payment_info,omitempty"`
 	ShareableLink   *string           `db:"shareable_link" json:"shareable_link,omitempty"`
 	Notes           string            `db:"notes" json:"notes,omitempty"`
 	CreatedAt       time.Time         `db:"created_at" json:"created_at,omitempty"`
 	UpdatedAt       time.Time         `db:"updated_at" json:"updated_at,omitempty"`
 	DeletedAt       *time.Time        `db:"deleted_at" json:"deleted_at,omitempty"`
+	// InvoiceIssuer and InvoiceClient are flattened so their columns are read
+	// straight off the row
+	InvoiceIssuer `json:"issuer"`
+	InvoiceClient `json:"client"`
+}
+
+// AmountPayable is what the invoice asks to be paid once its credit notes
+// are taken off the amount due
+func (i *Invoice) AmountPayable() (money.Money, error) {
+	if i.CreditedTotal.IsZero() {
+		return i.TotalAmountDue, nil
+	}
+	return i.TotalAmountDue.Sub(i.CreditedTotal)
+}
+
+// AssignCurrency tags every amount on the invoice, including its items and
+// payments, with the invoice's billing currency
+func (i *Invoice) AssignCurrency() error {
+	var err error
+	currency := i.BillingCurrency
+
+	if i.TotalAmountDue, err = i.TotalAmountDue.WithCurrency(currency); err != nil {
+		return err
+	}
+	if i.CreditedTotal, err = i.CreditedTotal.WithCurrency(currency); err != nil {
+		return err
+	}
+	if i.Subtotal, err = i.Subtotal.WithCurrency(currency); err != nil {
+		return err
+	}
+	if i.TaxTotal, err = i.TaxTotal.WithCurrency(currency); err != nil {
+		return err
+	}
+	if i.Discount, err = i.Discount.WithCurrency(currency); err != nil {
+		return err
+	}
+	if i.DiscountTotal, err = i.DiscountTotal.WithCurrency(currency); err != nil {
+		return err
+	}
+
+	for idx := range i.Items {
+		if err = i.Items[idx].AssignCurrency(currency); err != nil {
+			return err
+		}
+	}
+
+	for idx := range i.Payments {
+		if i.Payments[idx].Amount, err = i.Payments[idx].Amount.WithCurrency(currency); err != nil {
+			return err
+		}
+	}
+
+	return nil
 }
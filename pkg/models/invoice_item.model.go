@@ -1,15 +1,63 @@
 package models
 
-import "time"
+import (
+	"time"
+
+	"github.com/Adebayobenjamin/numerisbook/pkg/common/money"
+)
 
 type InvoiceItem struct {
-	ID          uint       `db:"id" json:"id"`
-	InvoiceID   uint       `db:"invoice_id" json:"invoice_id"`
-	Description string     `db:"description" json:"description"`
-	Quantity    int        `db:"quantity" json:"quantity"`
-	UnitPrice   float64    `db:"unit_price" json:"unit_price"`
-	TotalPrice  float64    `db:"total_price" json:"total_price"`
-	CreatedAt   time.Time  `db:"created_at" json:"created_at"`
-	UpdatedAt   time.Time  `db:"updated_at" json:"updated_at"`
-	DeletedAt   *time.Time `db:"deleted_at" json:"deleted_at"`
+	ID             uint         `db:"id" json:"id"`
+	InvoiceID      uint         `db:"invoice_id" json:"invoice_id"`
+	CatalogItemID  *uint        `db:"catalog_item_id" json:"catalog_item_id"`
+	Description    string       `db:"description" json:"description"`
+	Quantity       int          `db:"quantity" json:"quantity"`
+	Unit           string       `db:"unit" json:"unit"`
+	UnitPrice      money.Money  `db:"unit_price" json:"unit_price"`
+	DiscountType   DiscountType `db:"discount_type" json:"discount_type"`
+	DiscountRate   money.Rate   `db:"discount_rate" json:"discount_rate"`
+	DiscountAmount money.Money  `db:"discount_amount" json:"discount_amount"`
+	// InvoiceDiscountAmount is this line's share of the invoice-level discount
+	InvoiceDiscountAmount money.Money      `db:"invoice_discount_amount" json:"invoice_discount_amount"`
+	NetAmount             money.Money      `db:"net_amount" json:"net_amount"`
+	TaxTotal              money.Money      `db:"tax_total" json:"tax_total"`
+	TotalPrice            money.Money      `db:"total_price" json:"total_price"`
+	Taxes                 []InvoiceItemTax `db:"taxes" json:"taxes,omitempty"`
+	CreatedAt             time.Time        `db:"created_at" json:"created_at"`
+	UpdatedAt             time.Time        `db:"updated_at" json:"updated_at"`
+	DeletedAt             *time.Time       `db:"deleted_at" json:"deleted_at"`
+}
+
+// AssignCurrency tags the item's amounts, including its discounts and taxes, with the invoice's billing currency
+func (i *InvoiceItem) AssignCurrency(currency string) error {
+	var err error
+	if i.UnitPrice, err = i.UnitPrice.WithCurrency(currency); err != nil {
+		return err
+	}
+	if i.DiscountAmount, err = i.DiscountAmount.WithCurrency(currency); err != nil {
+		return err
+	}
+	if i.InvoiceDiscountAmount, err = i.InvoiceDiscountAmount.WithCurrency(currency); err != nil {
+		return err
+	}
+	if i.NetAmount, err = i.NetAmount.WithCurrency(currency); err != nil {
+		return err
+	}
+	if i.TaxTotal, err = i.TaxTotal.WithCurrency(currency); err != nil {
+		return err
+	}
+	if i.TotalPrice, err = i.TotalPrice.WithCurrency(currency); err != nil {
+		return err
+	}
+
+	for idx := range i.Taxes {
+		tax := &i.Taxes[idx]
+		if tax.TaxableAmount, err = tax.TaxableAmount.WithCurrency(currency); err != nil {
+			return err
+		}
+		if tax.Amount, err = tax.Amount.WithCurrency(currency); err != nil {
+			return err
+		}
+	}
+	return nil
 }
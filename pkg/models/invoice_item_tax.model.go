@@ -0,0 +1,33 @@
+package models
+
+import (
+	"time"
+
+	"github.com/Adebayobenjamin/numerisbook/pkg/common/money"
+)
+
+// InvoiceItemTax is a single tax (VAT, GST, sales tax...) charged on an invoice line
+type InvoiceItemTax struct {
+	ID            uint        `db:"id" json:"id"`
+	InvoiceItemID uint        `db:"invoice_item_id" json:"invoice_item_id"`
+	InvoiceID     uint        `db:"invoice_id" json:"invoice_id"`
+	Name          string      `db:"name" json:"name"`
+	Rate          money.Rate  `db:"rate" json:"rate"`
+	IsInclusive   bool        `db:"is_inclusive" json:"is_inclusive"`
+	IsCompound    bool        `db:"is_compound" json:"is_compound"`
+	TaxableAmount money.Money `db:"taxable_amount" json:"taxable_amount"`
+	Amount        money.Money `db:"amount" json:"amount"`
+	CreatedAt     time.Time   `db:"created_at" json:"created_at"`
+	UpdatedAt     time.Time   `db:"updated_at" json:"updated_at"`
+	DeletedAt     *time.Time  `db:"deleted_at" json:"deleted_at"`
+}
+
+// TaxBreakdown is the total charged for one tax rate across all lines of an invoice
+type TaxBreakdown struct {
+	Name          string      `json:"name"`
+	Rate          money.Rate  `json:"rate"`
+	IsInclusive   bool        `json:"is_inclusive"`
+	IsCompound    bool        `json:"is_compound"`
+	TaxableAmount money.Money `json:"taxable_amount"`
+	Amount        money.Money `json:"amount"`
+}
@@ -12,13 +12,20 @@ const (
 	InvoiceReminderScheduleOnDue           InvoiceReminderSchedule = "on_due"
 )
 
+// InvoiceReminder is a scheduled payment reminder for an invoice. SentAt is
+// set once it has been delivered; until then Attempts, LastError and
+// NextAttemptAt track the dispatcher's retries.
 type InvoiceReminder struct {
-	ID           uint                    `db:"id" json:"id"`
-	InvoiceID    uint                    `db:"invoice_id" json:"invoice_id"`
-	CustomerID   uint                    `db:"customer_id" json:"customer_id"`
-	Schedule     InvoiceReminderSchedule `db:"schedule" json:"schedule"`
-	ReminderDate time.Time               `db:"reminder_date" json:"reminder_date"`
-	CreatedAt    time.Time               `db:"created_at" json:"created_at"`
-	UpdatedAt    time.Time               `db:"updated_at" json:"updated_at"`
-	DeletedAt    *time.Time              `db:"deleted_at" json:"deleted_at"`
+	ID            uint                    `db:"id" json:"id"`
+	InvoiceID     uint                    `db:"invoice_id" json:"invoice_id"`
+	CustomerID    uint                    `db:"customer_id" json:"customer_id"`
+	Schedule      InvoiceReminderSchedule `db:"schedule" json:"schedule"`
+	ReminderDate  time.Time               `db:"reminder_date" json:"reminder_date"`
+	SentAt        *time.Time              `db:"sent_at" json:"sent_at"`
+	Attempts      int                     `db:"attempts" json:"attempts"`
+	LastError     *string                 `db:"last_error" json:"last_error,omitempty"`
+	NextAttemptAt *time.Time              `db:"next_attempt_at" json:"next_attempt_at,omitempty"`
+	CreatedAt     time.Time               `db:"created_at" json:"created_at"`
+	UpdatedAt     time.Time               `db:"updated_at" json:"updated_at"`
+	DeletedAt     *time.Time              `db:"deleted_at" json:"deleted_at"`
 }
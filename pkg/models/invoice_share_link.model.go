@@ -0,0 +1,24 @@
+package models
+
+import "time"
+
+// InvoiceShareLink grants read-only access to an invoice to whoever holds its
+// signed token. TokenID is the random part of the token the signature covers.
+type InvoiceShareLink struct {
+	ID            uint       `db:"id" json:"id"`
+	InvoiceID     uint       `db:"invoice_id" json:"invoice_id"`
+	CustomerID    uint       `db:"customer_id" json:"customer_id"`
+	TokenID       string     `db:"token_id" json:"-"`
+	ExpiresAt     time.Time  `db:"expires_at" json:"expires_at"`
+	RevokedAt     *time.Time `db:"revoked_at" json:"revoked_at"`
+	FirstViewedAt *time.Time `db:"first_viewed_at" json:"first_viewed_at"`
+	LastViewedAt  *time.Time `db:"last_viewed_at" json:"last_viewed_at"`
+	ViewCount     int        `db:"view_count" json:"view_count"`
+	CreatedAt     time.Time  `db:"created_at" json:"created_at"`
+	UpdatedAt     time.Time  `db:"updated_at" json:"updated_at"`
+}
+
+// IsActive reports whether the link can still be used to view the invoice
+func (l *InvoiceShareLink) IsActive(now time.Time) bool {
+	return l.RevokedAt == nil && now.Before(l.ExpiresAt)
+}
@@ -1,14 +1,84 @@
 package models
 
-import "time"
+import (
+	"database/sql/driver"
+	"encoding/json"
+	"time"
 
+	"github.com/Adebayobenjamin/numerisbook/pkg/common/money"
+)
+
+type PaymentMethod string
+
+const (
+	PaymentMethodBankTransfer PaymentMethod = "bank_transfer"
+	PaymentMethodCard         PaymentMethod = "card"
+	PaymentMethodCash         PaymentMethod = "cash"
+	PaymentMethodCheque       PaymentMethod = "cheque"
+	PaymentMethodMobileMoney  PaymentMethod = "mobile_money"
+	PaymentMethodOther        PaymentMethod = "other"
+)
+
+type PaymentKind string
+
+const (
+	PaymentKindPayment PaymentKind = "payment"
+	// PaymentKindRefund gives back some or all of a payment to the payer
+	PaymentKindRefund PaymentKind = "refund"
+	// PaymentKindReversal undoes a payment that was recorded by mistake
+	PaymentKindReversal PaymentKind = "reversal"
+	// PaymentKindCredit pays an invoice out of the client's credit balance
+	PaymentKindCredit PaymentKind = "credit"
+)
+
+// Payment is money received for an invoice. Refunds and reversals are
+// payments too, with a negative amount and the payment they take back from
+// as OriginalPaymentID.
 type Payment struct {
-	ID        uint       `db:"id" json:"id"`
-	InvoiceID uint       `db:"invoice_id" json:"invoice_id"`
-	Amount    float64    `db:"amount" json:"amount"`
-	IsPartial bool       `db:"is_partial" json:"is_partial"`
-	Date      time.Time  `db:"date" json:"date"`
-	CreatedAt time.Time  `db:"created_at" json:"created_at"`
-	UpdatedAt time.Time  `db:"updated_at" json:"updated_at"`
-	DeletedAt *time.Time `db:"deleted_at" json:"deleted_at"`
+	ID                uint          `db:"id" json:"id"`
+	InvoiceID         uint          `db:"invoice_id" json:"invoice_id"`
+	Kind              PaymentKind   `db:"kind" json:"kind"`
+	OriginalPaymentID *uint         `db:"original_payment_id" json:"original_payment_id,omitempty"`
+	Amount            money.Money   `db:"amount" json:"amount"`
+	IsPartial         bool          `db:"is_partial" json:"is_partial"`
+	Date              time.Time     `db:"date" json:"date"`
+	Method            PaymentMethod `db:"method" json:"method"`
+	// Reference is what identifies the payment outside the system, e.g. the
+	// bank transfer reference or card transaction id
+	Reference string `db:"reference" json:"reference"`
+	PayerName string `db:"payer_name" json:"payer_name"`
+	Notes     string `db:"notes" json:"notes"`
+	// Reason is why a refund or reversal was made
+	Reason      string             `db:"reason" json:"reason,omitempty"`
+	Attachments PaymentAttachments `db:"attachments" json:"attachments"`
+	CreatedAt   time.Time          `db:"created_at" json:"created_at"`
+	UpdatedAt   time.Time          `db:"updated_at" json:"updated_at"`
+	DeletedAt   *time.Time         `db:"deleted_at" json:"deleted_at"`
+	// Overpayment is what the payment brought in over what was left to pay on
+	// its invoice. It is not part of Amount, it is added to the client's
+	// credit when the payment is recorded.
+	Overpayment *money.Money `db:"-" json:"overpayment,omitempty"`
+}
+
+// PaymentAttachment links a document backing a payment, such as a remittance
+// advice or a photo of a cheque
+type PaymentAttachment struct {
+	Name string `json:"name"`
+	URL  string `json:"url"`
+}
+
+// PaymentAttachments are a payment's attachments, stored as JSON
+type PaymentAttachments []PaymentAttachment
+
+// Value implements driver.Valuer.
+func (p PaymentAttachments) Value() (driver.Value, error) {
+	if p == nil {
+		return []byte("[]"), nil
+	}
+	return json.Marshal(p)
+}
+
+// Scan implements sql.Scanner.
+func (p *PaymentAttachments) Scan(src interface{}) error {
+	return scanJSON(src, p)
 }
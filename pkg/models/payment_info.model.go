@@ -2,9 +2,11 @@ package models
 
 import "time"
 
+// PaymentInfo is the copy of the payment details printed on an invoice
 type PaymentInfo struct {
 	ID            uint       `db:"id" json:"id"`
 	InvoiceID     uint       `db:"invoice_id" json:"invoice_id"`
+	BankAccountID *uint      `db:"bank_account_id" json:"bank_account_id"`
 	BankName      string     `db:"bank_name" json:"bank_name"`
 	AccountNumber string     `db:"account_number" json:"account_number"`
 	AccountName   string     `db:"account_name" json:"account_name"`
@@ -0,0 +1,134 @@
+package models
+
+import (
+	"database/sql/driver"
+	"encoding/json"
+	"fmt"
+	"time"
+
+	"github.com/Adebayobenjamin/numerisbook/pkg/common/money"
+)
+
+type QuoteStatus string
+
+const (
+	QuoteStatusDraft    QuoteStatus = "draft"
+	QuoteStatusSent     QuoteStatus = "sent"
+	QuoteStatusAccepted QuoteStatus = "accepted"
+	QuoteStatusDeclined QuoteStatus = "declined"
+	QuoteStatusExpired  QuoteStatus = "expired"
+)
+
+// Quote is an estimate sent before the work starts. It is priced exactly like
+// an invoice and, once accepted, converted into one with the same lines.
+type Quote struct {
+	ID          uint        `db:"id" json:"id"`
+	CustomerID  uint        `db:"customer_id" json:"customer_id"`
+	QuoteNumber string      `db:"quote_number" json:"quote_number"`
+	Status      QuoteStatus `db:"status" json:"status"`
+	IssueDate   time.Time   `db:"issue_date" json:"issue_date"`
+	// ExpiryDate is when the quote stops being open to acceptance
+	ExpiryDate time.Time `db:"expiry_date" json:"expiry_date"`
+	// DueInDays is how long after the conversion the invoice is due
+	DueInDays       int              `db:"due_in_days" json:"due_in_days"`
+	BillingCurrency string           `db:"billing_currency" json:"billing_currency"`
+	Subtotal        money.Money      `db:"subtotal" json:"subtotal"`
+	Discount        money.Money      `db:"discount" json:"discount"`
+	DiscountType    DiscountType     `db:"discount_type" json:"discount_type"`
+	DiscountRate    money.Rate       `db:"discount_rate" json:"discount_rate"`
+	DiscountTotal   money.Money      `db:"discount_total" json:"discount_total"`
+	TaxTotal        money.Money      `db:"tax_total" json:"tax_total"`
+	TotalAmount     money.Money      `db:"total_amount" json:"total_amount"`
+	Notes           string           `db:"notes" json:"notes"`
+	Items           QuoteItems       `db:"items" json:"items"`
+	PaymentInfo     QuotePaymentInfo `db:"payment_info" json:"payment_info"`
+	ShareTokenID    *string          `db:"share_token_id" json:"-"`
+	// ShareURL is where the client can accept or decline the quote, it is
+	// signed from ShareTokenID when the quote is read
+	ShareURL      string     `db:"-" json:"share_url,omitempty"`
+	SentAt        *time.Time `db:"sent_at" json:"sent_at"`
+	RespondedAt   *time.Time `db:"responded_at" json:"responded_at"`
+	DeclineReason *string    `db:"decline_reason" json:"decline_reason"`
+	ConvertedAt   *time.Time `db:"converted_at" json:"converted_at"`
+	// InvoiceID is the invoice the quote was converted into
+	InvoiceID *uint      `db:"invoice_id" json:"invoice_id"`
+	CreatedAt time.Time  `db:"created_at" json:"created_at"`
+	UpdatedAt time.Time  `db:"updated_at" json:"updated_at"`
+	DeletedAt *time.Time `db:"deleted_at" json:"deleted_at,omitempty"`
+	// the issuer and client are copied like they are on invoices
+	InvoiceIssuer `json:"issuer"`
+	InvoiceClient `json:"client"`
+}
+
+// HasLapsed reports whether the quote was left unanswered past its expiry date
+func (q *Quote) HasLapsed(now time.Time) bool {
+	return q.Status == QuoteStatusSent && !now.Before(q.ExpiryDate)
+}
+
+// AssignCurrency tags every amount on the quote, including its items, with
+// the quote's billing currency
+func (q *Quote) AssignCurrency() error {
+	var err error
+	currency := q.BillingCurrency
+
+	if q.Subtotal, err = q.Subtotal.WithCurrency(currency); err != nil {
+		return err
+	}
+	if q.Discount, err = q.Discount.WithCurrency(currency); err != nil {
+		return err
+	}
+	if q.DiscountTotal, err = q.DiscountTotal.WithCurrency(currency); err != nil {
+		return err
+	}
+	if q.TaxTotal, err = q.TaxTotal.WithCurrency(currency); err != nil {
+		return err
+	}
+	if q.TotalAmount, err = q.TotalAmount.WithCurrency(currency); err != nil {
+		return err
+	}
+
+	for idx := range q.Items {
+		if err = q.Items[idx].AssignCurrency(currency); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// QuoteItems are the priced lines of a quote, stored as JSON
+type QuoteItems []InvoiceItem
+
+// Value implements driver.Valuer.
+func (q QuoteItems) Value() (driver.Value, error) {
+	return json.Marshal(q)
+}
+
+// Scan implements sql.Scanner.
+func (q *QuoteItems) Scan(src interface{}) error {
+	return scanJSON(src, q)
+}
+
+// QuotePaymentInfo are the payment details a quote will be invoiced with, stored as JSON
+type QuotePaymentInfo PaymentInfo
+
+// Value implements driver.Valuer.
+func (q QuotePaymentInfo) Value() (driver.Value, error) {
+	return json.Marshal(q)
+}
+
+// Scan implements sql.Scanner.
+func (q *QuotePaymentInfo) Scan(src interface{}) error {
+	return scanJSON(src, q)
+}
+
+func scanJSON(src interface{}, dest interface{}) error {
+	switch value := src.(type) {
+	case []byte:
+		return json.Unmarshal(value, dest)
+	case string:
+		return json.Unmarshal([]byte(value), dest)
+	default:
+		return fmt.Errorf("cannot scan %T as JSON", src)
+	}
+}
@@ -0,0 +1,51 @@
+package models
+
+import (
+	"encoding/json"
+	"time"
+)
+
+type RecurringFrequency string
+
+const (
+	RecurringFrequencyWeekly    RecurringFrequency = "weekly"
+	RecurringFrequencyMonthly   RecurringFrequency = "monthly"
+	RecurringFrequencyQuarterly RecurringFrequency = "quarterly"
+	RecurringFrequencyYearly    RecurringFrequency = "yearly"
+	RecurringFrequencyCustom    RecurringFrequency = "custom"
+)
+
+type RecurringProfileStatus string
+
+const (
+	RecurringProfileStatusActive    RecurringProfileStatus = "active"
+	RecurringProfileStatusPaused    RecurringProfileStatus = "paused"
+	RecurringProfileStatusCompleted RecurringProfileStatus = "completed"
+	RecurringProfileStatusCancelled RecurringProfileStatus = "cancelled"
+)
+
+// RecurringInvoiceProfile generates a new invoice from its template every time its schedule comes round
+type RecurringInvoiceProfile struct {
+	ID         uint               `db:"id" json:"id"`
+	CustomerID uint               `db:"customer_id" json:"customer_id"`
+	Name       string             `db:"name" json:"name"`
+	Frequency  RecurringFrequency `db:"frequency" json:"frequency"`
+	// RRule is the recurrence rule the schedule follows; preset frequencies are stored as their rule too
+	RRule     string     `db:"rrule" json:"rrule"`
+	StartDate time.Time  `db:"start_date" json:"start_date"`
+	EndDate   *time.Time `db:"end_date" json:"end_date"`
+	// MaxOccurrences caps how many invoices the profile generates, nil for no cap
+	MaxOccurrences       *int `db:"max_occurrences" json:"max_occurrences"`
+	OccurrencesGenerated int  `db:"occurrences_generated" json:"occurrences_generated"`
+	// DueInDays is how long after its issue date each generated invoice is due
+	DueInDays int  `db:"due_in_days" json:"due_in_days"`
+	AutoSend  bool `db:"auto_send" json:"auto_send"`
+	// Template is the invoice every run generates, without its dates
+	Template  json.RawMessage        `db:"template" json:"template"`
+	Status    RecurringProfileStatus `db:"status" json:"status"`
+	NextRunAt *time.Time             `db:"next_run_at" json:"next_run_at"`
+	LastRunAt *time.Time             `db:"last_run_at" json:"last_run_at"`
+	CreatedAt time.Time              `db:"created_at" json:"created_at"`
+	UpdatedAt time.Time              `db:"updated_at" json:"updated_at"`
+	DeletedAt *time.Time             `db:"deleted_at" json:"deleted_at"`
+}
@@ -0,0 +1,24 @@
+package models
+
+import "time"
+
+type UserRole string
+
+const (
+	UserRoleOwner      UserRole = "owner"
+	UserRoleAccountant UserRole = "accountant"
+	UserRoleViewer     UserRole = "viewer"
+)
+
+// User is a member of a customer's organisation. Subject is the sub claim
+// of the tokens the user signs in with.
+type User struct {
+	ID         uint      `db:"id" json:"id"`
+	CustomerID uint      `db:"customer_id" json:"customer_id"`
+	Subject    string    `db:"subject" json:"subject"`
+	Email      string    `db:"email" json:"email"`
+	Name       string    `db:"name" json:"name"`
+	Role       UserRole  `db:"role" json:"role"`
+	CreatedAt  time.Time `db:"created_at" json:"created_at"`
+	UpdatedAt  time.Time `db:"updated_at" json:"updated_at"`
+}
@@ -0,0 +1,29 @@
+package notifiers
+
+import (
+	"context"
+
+	"github.com/Adebayobenjamin/numerisbook/pkg/email"
+)
+
+type emailNotifier struct {
+	mailer email.Mailer
+}
+
+// Notify implements Notifier.
+func (e *emailNotifier) Notify(ctx context.Context, notification Notification) error {
+	return e.mailer.Send(ctx, email.Message{
+		FromName: notification.FromName,
+		To:       notification.To,
+		ToName:   notification.Name,
+		ReplyTo:  notification.ReplyTo,
+		Subject:  notification.Subject,
+		Text:     notification.Body,
+		HTML:     notification.HTML,
+	})
+}
+
+// NewEmailNotifier delivers notifications as emails through mailer
+func NewEmailNotifier(mailer email.Mailer) Notifier {
+	return &emailNotifier{mailer: mailer}
+}
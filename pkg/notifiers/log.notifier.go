@@ -0,0 +1,27 @@
+package notifiers
+
+import (
+	"context"
+
+	"github.com/rs/zerolog"
+)
+
+type logNotifier struct {
+	logger *zerolog.Logger
+}
+
+// Notify implements Notifier.
+func (l *logNotifier) Notify(ctx context.Context, notification Notification) error {
+	l.logger.Info().
+		Str("to", notification.To).
+		Str("subject", notification.Subject).
+		Uint("invoice_id", notification.InvoiceID).
+		Uint("customer_id", notification.CustomerID).
+		Msg("notification")
+	return nil
+}
+
+// NewLogNotifier only logs notifications. It is meant for local development and tests.
+func NewLogNotifier(logger *zerolog.Logger) Notifier {
+	return &logNotifier{logger: logger}
+}
@@ -0,0 +1,43 @@
+package notifiers
+
+import (
+	"context"
+	"os"
+	"strings"
+
+	"github.com/Adebayobenjamin/numerisbook/pkg/email"
+	"github.com/rs/zerolog"
+)
+
+// Notification is a message addressed to the person an invoice is billed to.
+// Body is plain text; HTML, when set, is an alternative rendering of it.
+type Notification struct {
+	To         string `json:"to"`
+	Name       string `json:"name"`
+	FromName   string `json:"from_name"`
+	ReplyTo    string `json:"reply_to"`
+	Subject    string `json:"subject"`
+	Body       string `json:"body"`
+	HTML       string `json:"html,omitempty"`
+	InvoiceID  uint   `json:"invoice_id"`
+	CustomerID uint   `json:"customer_id"`
+}
+
+// Notifier delivers notifications over a single channel
+type Notifier interface {
+	Notify(ctx context.Context, notification Notification) error
+}
+
+// NewNotifier returns the notifier selected by NOTIFIER_DRIVER ("email",
+// "webhook" or "log"). Anything else falls back to the log notifier so that
+// nothing is sent by accident outside of a configured environment.
+func NewNotifier(logger *zerolog.Logger, mailer email.Mailer) Notifier {
+	switch strings.ToLower(os.Getenv("NOTIFIER_DRIVER")) {
+	case "email", "smtp":
+		return NewEmailNotifier(mailer)
+	case "webhook":
+		return NewWebhookNotifier(os.Getenv("NOTIFIER_WEBHOOK_URL"), os.Getenv("NOTIFIER_WEBHOOK_SECRET"))
+	default:
+		return NewLogNotifier(logger)
+	}
+}
@@ -0,0 +1,213 @@
+package pdf
+
+import (
+	"bytes"
+	"fmt"
+	"strconv"
+	"strings"
+)
+
+// A4 page size in points
+const (
+	PageWidth  = 595.28
+	PageHeight = 841.89
+)
+
+// Font is one of the standard PDF fonts. Standard fonts are built into every
+// PDF reader, so nothing has to be embedded in the document.
+type Font int
+
+const (
+	FontRegular Font = iota
+	FontBold
+)
+
+var fontNames = map[Font]string{
+	FontRegular: "Helvetica",
+	FontBold:    "Helvetica-Bold",
+}
+
+// Color is an RGB colour with components between 0 and 1
+type Color struct {
+	R, G, B float64
+}
+
+var (
+	Black     = Color{0, 0, 0}
+	DarkGray  = Color{0.29, 0.33, 0.39}
+	LightGray = Color{0.95, 0.96, 0.96}
+	LineGray  = Color{0.82, 0.84, 0.86}
+)
+
+// ParseHexColor reads a colour such as "#1f2937", falling back when it is not valid
+func ParseHexColor(hex string, fallback Color) Color {
+	hex = strings.TrimPrefix(hex, "#")
+	if len(hex) != 6 {
+		return fallback
+	}
+	value, err := strconv.ParseUint(hex, 16, 32)
+	if err != nil {
+		return fallback
+	}
+	return Color{
+		R: float64(value>>16&0xff) / 255,
+		G: float64(value>>8&0xff) / 255,
+		B: float64(value&0xff) / 255,
+	}
+}
+
+// Document is a minimal PDF writer for text, lines and filled rectangles.
+// Coordinates are in points with the origin at the top left of the page.
+// The output is deterministic: the same drawing calls always produce the
+// same bytes.
+type Document struct {
+	title   string
+	pages   []*bytes.Buffer
+	current *bytes.Buffer
+}
+
+// NewDocument returns an empty document with the given title
+func NewDocument(title string) *Document {
+	return &Document{title: title}
+}
+
+// AddPage starts a new page; drawing calls go to it until the next AddPage
+func (d *Document) AddPage() {
+	d.current = &bytes.Buffer{}
+	d.pages = append(d.pages, d.current)
+}
+
+// PageCount returns the number of pages added so far
+func (d *Document) PageCount() int {
+	return len(d.pages)
+}
+
+// SetPage sends further drawing calls to an earlier page, counting from 1
+func (d *Document) SetPage(number int) {
+	d.current = d.pages[number-1]
+}
+
+// Text draws text with its baseline at y
+func (d *Document) Text(x, y float64, font Font, size float64, color Color, text string) {
+	fmt.Fprintf(d.current, "BT %s rg /F%d %s Tf %s %s Td (%s) Tj ET\n",
+		formatColor(color), font+1, formatNumber(size), formatNumber(x), formatNumber(PageHeight-y), escapeText(text))
+}
+
+// TextRight draws text so that it ends at x
+func (d *Document) TextRight(x, y float64, font Font, size float64, color Color, text string) {
+	d.Text(x-TextWidth(text, font, size), y, font, size, color, text)
+}
+
+// Line draws a straight line
+func (d *Document) Line(x1, y1, x2, y2, width float64, color Color) {
+	fmt.Fprintf(d.current, "%s RG %s w %s %s m %s %s l S\n",
+		formatColor(color), formatNumber(width),
+		formatNumber(x1), formatNumber(PageHeight-y1), formatNumber(x2), formatNumber(PageHeight-y2))
+}
+
+// FillRect draws a filled rectangle whose top left corner is at x, y
+func (d *Document) FillRect(x, y, width, height float64, color Color) {
+	fmt.Fprintf(d.current, "%s rg %s %s %s %s re f\n",
+		formatColor(color), formatNumber(x), formatNumber(PageHeight-y-height), formatNumber(width), formatNumber(height))
+}
+
+// Bytes serialises the document
+func (d *Document) Bytes() []byte {
+	var out bytes.Buffer
+	var offsets []int
+
+	writeObject := func(body string) {
+		offsets = append(offsets, out.Len())
+		fmt.Fprintf(&out, "%d 0 obj\n%s\nendobj\n", len(offsets), body)
+	}
+
+	// fixed objects: 1 catalog, 2 page tree, 3 and 4 fonts, 5 info; every
+	// page then takes two objects, the page itself and its content stream
+	const firstPageObject = 6
+	kids := make([]string, len(d.pages))
+	for idx := range d.pages {
+		kids[idx] = fmt.Sprintf("%d 0 R", firstPageObject+idx*2)
+	}
+
+	out.WriteString("%PDF-1.4\n%\xe2\xe3\xcf\xd3\n")
+	writeObject("<< /Type /Catalog /Pages 2 0 R >>")
+	writeObject(fmt.Sprintf("<< /Type /Pages /Kids [%s] /Count %d >>", strings.Join(kids, " "), len(d.pages)))
+	writeObject(fmt.Sprintf("<< /Type /Font /Subtype /Type1 /BaseFont /%s /Encoding /WinAnsiEncoding >>", fontNames[FontRegular]))
+	writeObject(fmt.Sprintf("<< /Type /Font /Subtype /Type1 /BaseFont /%s /Encoding /WinAnsiEncoding >>", fontNames[FontBold]))
+	writeObject(fmt.Sprintf("<< /Title (%s) /Producer (NumerisBook) >>", escapeText(d.title)))
+
+	for idx, page := range d.pages {
+		contentObject := firstPageObject + idx*2 + 1
+		writeObject(fmt.Sprintf("<< /Type /Page /Parent 2 0 R /MediaBox [0 0 %s %s] /Resources << /Font << /F1 3 0 R /F2 4 0 R >> >> /Contents %d 0 R >>",
+			formatNumber(PageWidth), formatNumber(PageHeight), contentObject))
+		writeObject(fmt.Sprintf("<< /Length %d >>\nstream\n%sendstream", page.Len(), page.String()))
+	}
+
+	xref := out.Len()
+	fmt.Fprintf(&out, "xref\n0 %d\n0000000000 65535 f \n", len(offsets)+1)
+	for _, offset := range offsets {
+		fmt.Fprintf(&out, "%010d 00000 n \n", offset)
+	}
+	fmt.Fprintf(&out, "trailer\n<< /Size %d /Root 1 0 R /Info 5 0 R >>\nstartxref\n%d\n%%%%EOF\n", len(offsets)+1, xref)
+
+	return out.Bytes()
+}
+
+// WrapText breaks text into lines no wider than width, splitting on spaces
+// and honouring line breaks already in the text
+func WrapText(text string, font Font, size float64, width float64) []string {
+	var lines []string
+	for _, paragraph := range strings.Split(strings.ReplaceAll(text, "\r\n", "\n"), "\n") {
+		words := strings.Fields(paragraph)
+		if len(words) == 0 {
+			lines = append(lines, "")
+			continue
+		}
+
+		line := words[0]
+		for _, word := range words[1:] {
+			if TextWidth(line+" "+word, font, size) > width {
+				lines = append(lines, line)
+				line = word
+				continue
+			}
+			line += " " + word
+		}
+		lines = append(lines, line)
+	}
+	return lines
+}
+
+func formatNumber(value float64) string {
+	formatted := strconv.FormatFloat(value, 'f', 2, 64)
+	formatted = strings.TrimRight(formatted, "0")
+	return strings.TrimSuffix(formatted, ".")
+}
+
+func formatColor(color Color) string {
+	return fmt.Sprintf("%s %s %s", formatNumber(color.R), formatNumber(color.G), formatNumber(color.B))
+}
+
+// escapeText encodes text as WinAnsi and escapes it for a PDF string literal.
+// Characters the encoding cannot represent are replaced with '?'.
+func escapeText(text string) string {
+	var out strings.Builder
+	for _, r := range text {
+		b, ok := winAnsiByte(r)
+		if !ok {
+			b = '?'
+		}
+		switch b {
+		case '(', ')', '\\':
+			out.WriteByte('\\')
+			out.WriteByte(b)
+		default:
+			if b < 32 {
+				out.WriteByte(' ')
+				continue
+			}
+			out.WriteByte(b)
+		}
+	}
+	return out.String()
+}
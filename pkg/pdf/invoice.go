@@ -0,0 +1,367 @@
+package pdf
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/Adebayobenjamin/numerisbook/pkg/common/money"
+	response_dto "github.com/Adebayobenjamin/numerisbook/pkg/dtos/response"
+	"github.com/Adebayobenjamin/numerisbook/pkg/models"
+)
+
+const (
+	marginX      = 50.0
+	contentRight = PageWidth - marginX
+	// contentBottom is the lowest point content may reach before a new page is started
+	contentBottom = PageHeight - 70
+	totalsLabelX  = 340.0
+	dateLayout    = "Jan 2, 2006"
+)
+
+// defaultAccent is used for the header and totals when the customer has not set a brand colour
+var defaultAccent = Color{0.12, 0.16, 0.22}
+
+// item table columns: the description is left aligned, every other column
+// is right aligned to the x given here
+var itemColumns = []struct {
+	title string
+	x     float64
+}{
+	{"Qty", 300},
+	{"Unit price", 370},
+	{"Discount", 430},
+	{"Tax", 485},
+	{"Amount", contentRight - 6},
+}
+
+const descriptionWidth = 200.0
+
+// invoiceLayout keeps track of where the next block goes while an invoice is laid out
+type invoiceLayout struct {
+	doc     *Document
+	invoice *response_dto.GetInvoiceDetailsResponse
+	accent  Color
+	y       float64
+}
+
+// RenderInvoice lays out an invoice as an A4 PDF: the issuing customer and
+// the billed party, the items with their discounts and taxes, the totals,
+// the bank details to pay into and any notes
+func RenderInvoice(invoice *response_dto.GetInvoiceDetailsResponse) ([]byte, error) {
+	layout := &invoiceLayout{
+		doc:     NewDocument(fmt.Sprintf("Invoice %s", invoice.InvoiceNumber)),
+		invoice: invoice,
+		accent:  defaultAccent,
+	}
+	if invoice.Issuer != nil && invoice.Issuer.BrandColor != nil {
+		layout.accent = ParseHexColor(*invoice.Issuer.BrandColor, defaultAccent)
+	}
+
+	layout.newPage()
+	layout.header()
+	layout.parties()
+	layout.items()
+	if err := layout.totals(); err != nil {
+		return nil, err
+	}
+	layout.paymentDetails()
+	layout.notes()
+	layout.footers()
+
+	return layout.doc.Bytes(), nil
+}
+
+func (l *invoiceLayout) newPage() {
+	l.doc.AddPage()
+	l.doc.FillRect(0, 0, PageWidth, 8, l.accent)
+	l.y = 50
+}
+
+// ensureSpace moves to a new page when height no longer fits on this one and reports whether it did
+func (l *invoiceLayout) ensureSpace(height float64) bool {
+	if l.y+height <= contentBottom {
+		return false
+	}
+	l.newPage()
+	return true
+}
+
+func (l *invoiceLayout) header() {
+	invoice := l.invoice
+
+	issuer := "Invoice"
+	if invoice.Issuer != nil && invoice.Issuer.Name != "" {
+		issuer = invoice.Issuer.Name
+	}
+	l.doc.Text(marginX, l.y+18, FontBold, 18, Black, issuer)
+	l.doc.TextRight(contentRight, l.y+20, FontBold, 26, l.accent, "INVOICE")
+
+	left := l.y + 40
+	if account := invoice.Issuer; account != nil {
+		left = l.contactLines(marginX, left, 250, account.Address, account.Email, account.Phone)
+	}
+
+	right := l.y + 44
+	meta := [][2]string{
+		{"Invoice number", invoice.InvoiceNumber},
+		{"Issue date", invoice.IssueDate.Format(dateLayout)},
+		{"Due date", invoice.DueDate.Format(dateLayout)},
+		{"Status", statusLabel(invoice.Status)},
+	}
+	for _, row := range meta {
+		l.doc.Text(totalsLabelX, right, FontRegular, 9, DarkGray, row[0])
+		l.doc.TextRight(contentRight, right, FontBold, 9, Black, row[1])
+		right += 14
+	}
+
+	l.y = max(left, right) + 20
+}
+
+func (l *invoiceLayout) parties() {
+	client := l.invoice.InvoiceClient
+	if client.Name == "" {
+		return
+	}
+
+	l.doc.Text(marginX, l.y, FontBold, 8, DarkGray, "BILL TO")
+	l.doc.Text(marginX, l.y+16, FontBold, 11, Black, client.Name)
+	l.y = l.contactLines(marginX, l.y+30, 250, client.Address, client.Email, client.Phone) + 16
+}
+
+// contactLines writes the non-empty lines of an address block and returns the y below them
+func (l *invoiceLayout) contactLines(x, y, width float64, lines ...string) float64 {
+	for _, line := range lines {
+		if strings.TrimSpace(line) == "" {
+			continue
+		}
+		for _, wrapped := range WrapText(line, FontRegular, 9, width) {
+			l.doc.Text(x, y, FontRegular, 9, DarkGray, wrapped)
+			y += 12
+		}
+	}
+	return y
+}
+
+func (l *invoiceLayout) itemsHeader() {
+	l.doc.FillRect(marginX, l.y, contentRight-marginX, 20, LightGray)
+	l.doc.Text(marginX+6, l.y+13, FontBold, 8, DarkGray, "DESCRIPTION")
+	for idx, column := range itemColumns {
+		title := strings.ToUpper(column.title)
+		if idx == len(itemColumns)-1 {
+			title = fmt.Sprintf("%s (%s)", title, l.invoice.BillingCurrency)
+		}
+		l.doc.TextRight(column.x, l.y+13, FontBold, 8, DarkGray, title)
+	}
+	l.y += 26
+}
+
+func (l *invoiceLayout) items() {
+	l.ensureSpace(60)
+	l.itemsHeader()
+
+	for _, item := range l.invoice.Items {
+		lines := WrapText(item.Description, FontRegular, 9, descriptionWidth)
+		height := float64(len(lines))*12 + 8
+		if l.ensureSpace(height) {
+			l.itemsHeader()
+		}
+
+		baseline := l.y + 9
+		for idx, line := range lines {
+			l.doc.Text(marginX+6, baseline+float64(idx)*12, FontRegular, 9, Black, line)
+		}
+
+		lineAmount := item.UnitPrice.Mul(int64(item.Quantity))
+		net, err := lineAmount.Sub(item.DiscountAmount)
+		if err != nil {
+			net = lineAmount
+		}
+		values := []string{
+			fmt.Sprintf("%d", item.Quantity),
+			FormatAmount(item.UnitPrice),
+			l.discountLabel(item),
+			optionalAmount(item.TaxTotal),
+			FormatAmount(net),
+		}
+		for idx, column := range itemColumns {
+			l.doc.TextRight(column.x, baseline, FontRegular, 9, Black, values[idx])
+		}
+
+		l.y += height
+		l.doc.Line(marginX, l.y, contentRight, l.y, 0.5, LineGray)
+		l.y += 6
+	}
+}
+
+func (l *invoiceLayout) discountLabel(item models.InvoiceItem) string {
+	if item.DiscountAmount.IsZero() {
+		return "-"
+	}
+	if item.DiscountType == models.DiscountTypePercentage {
+		return fmt.Sprintf("%s%%", item.DiscountRate)
+	}
+	return FormatAmount(item.DiscountAmount)
+}
+
+func (l *invoiceLayout) totals() error {
+	invoice := l.invoice
+
+	type row struct {
+		label string
+		value string
+		bold  bool
+	}
+	rows := []row{{"Subtotal", FormatAmount(invoice.Subtotal), false}}
+
+	if !invoice.DiscountTotal.IsZero() {
+		label := "Discount"
+		if invoice.DiscountType == models.DiscountTypePercentage && !invoice.Discount.IsZero() {
+			label = fmt.Sprintf("Discount (%s%% off)", invoice.DiscountRate)
+		}
+		rows = append(rows, row{label, "-" + FormatAmount(invoice.DiscountTotal), false})
+	}
+
+	for _, tax := range invoice.TaxSummary {
+		label := fmt.Sprintf("%s %s%%", tax.Name, tax.Rate)
+		if tax.IsInclusive {
+			label += " (included)"
+		}
+		rows = append(rows, row{label, FormatAmount(tax.Amount), false})
+	}
+
+	paid := money.Zero(invoice.BillingCurrency)
+	for _, payment := range invoice.Payments {
+		var err error
+		if paid, err = paid.Add(payment.Amount); err != nil {
+			return fmt.Errorf("failed to total invoice payments: %w", err)
+		}
+	}
+
+	total := fmt.Sprintf("%s %s", invoice.BillingCurrency, FormatAmount(invoice.TotalAmountDue))
+	height := float64(len(rows)+3) * 16
+	l.ensureSpace(height + 20)
+	l.y += 10
+
+	for _, r := range rows {
+		l.doc.Text(totalsLabelX, l.y, FontRegular, 9, DarkGray, r.label)
+		l.doc.TextRight(contentRight-6, l.y, FontRegular, 9, Black, r.value)
+		l.y += 16
+	}
+
+	l.doc.Line(totalsLabelX, l.y-8, contentRight, l.y-8, 0.75, LineGray)
+	l.y += 4
+	l.doc.Text(totalsLabelX, l.y, FontBold, 11, Black, "Total")
+	l.doc.TextRight(contentRight-6, l.y, FontBold, 11, Black, total)
+	l.y += 18
+
+	if !paid.IsZero() {
+		balance, err := invoice.TotalAmountDue.Sub(paid)
+		if err != nil {
+			return fmt.Errorf("failed to calculate outstanding balance: %w", err)
+		}
+		l.doc.Text(totalsLabelX, l.y, FontRegular, 9, DarkGray, "Amount paid")
+		l.doc.TextRight(contentRight-6, l.y, FontRegular, 9, Black, "-"+FormatAmount(paid))
+		l.y += 18
+
+		l.doc.FillRect(totalsLabelX-6, l.y-14, contentRight-totalsLabelX+6, 22, LightGray)
+		l.doc.Text(totalsLabelX, l.y+1, FontBold, 11, l.accent, "Balance due")
+		l.doc.TextRight(contentRight-6, l.y+1, FontBold, 11, l.accent, fmt.Sprintf("%s %s", invoice.BillingCurrency, FormatAmount(balance)))
+		l.y += 18
+	}
+
+	l.y += 20
+	return nil
+}
+
+func (l *invoiceLayout) paymentDetails() {
+	info := l.invoice.PaymentInformation
+	if info == nil || (info.BankName == "" && info.AccountNumber == "") {
+		return
+	}
+
+	rows := [][2]string{
+		{"Bank", info.BankName},
+		{"Account name", info.AccountName},
+		{"Account number", info.AccountNumber},
+		{"Routing number", info.AchRoutingNo},
+		{"Bank address", info.BankAddress},
+	}
+
+	l.ensureSpace(float64(len(rows))*14 + 30)
+	l.doc.Text(marginX, l.y, FontBold, 8, DarkGray, "PAYMENT DETAILS")
+	l.y += 16
+	for _, row := range rows {
+		if row[1] == "" {
+			continue
+		}
+		l.doc.Text(marginX, l.y, FontRegular, 9, DarkGray, row[0])
+		l.doc.Text(marginX+90, l.y, FontRegular, 9, Black, row[1])
+		l.y += 14
+	}
+	l.y += 16
+}
+
+func (l *invoiceLayout) notes() {
+	if strings.TrimSpace(l.invoice.Notes) == "" {
+		return
+	}
+
+	lines := WrapText(l.invoice.Notes, FontRegular, 9, contentRight-marginX)
+	l.ensureSpace(30)
+	l.doc.Text(marginX, l.y, FontBold, 8, DarkGray, "NOTES")
+	l.y += 16
+	for _, line := range lines {
+		l.ensureSpace(12)
+		l.doc.Text(marginX, l.y, FontRegular, 9, Black, line)
+		l.y += 12
+	}
+}
+
+// footers numbers every page once the page count is known
+func (l *invoiceLayout) footers() {
+	count := l.doc.PageCount()
+	for page := 1; page <= count; page++ {
+		l.doc.SetPage(page)
+		l.doc.Line(marginX, PageHeight-45, contentRight, PageHeight-45, 0.5, LineGray)
+		l.doc.Text(marginX, PageHeight-32, FontRegular, 8, DarkGray, l.invoice.InvoiceNumber)
+		l.doc.TextRight(contentRight, PageHeight-32, FontRegular, 8, DarkGray, fmt.Sprintf("Page %d of %d", page, count))
+	}
+}
+
+// FormatAmount formats an amount with thousands separators, e.g. "1,250.50"
+func FormatAmount(amount money.Money) string {
+	value := amount.String()
+	sign := ""
+	if strings.HasPrefix(value, "-") {
+		sign, value = "-", value[1:]
+	}
+
+	whole, fraction, hasFraction := strings.Cut(value, ".")
+	var grouped strings.Builder
+	for idx, digit := range whole {
+		if idx > 0 && (len(whole)-idx)%3 == 0 {
+			grouped.WriteByte(',')
+		}
+		grouped.WriteRune(digit)
+	}
+
+	if hasFraction {
+		return sign + grouped.String() + "." + fraction
+	}
+	return sign + grouped.String()
+}
+
+func optionalAmount(amount money.Money) string {
+	if amount.IsZero() {
+		return "-"
+	}
+	return FormatAmount(amount)
+}
+
+func statusLabel(status models.InvoiceStatus) string {
+	label := strings.ReplaceAll(string(status), "_", " ")
+	if label == "" {
+		return ""
+	}
+	return strings.ToUpper(label[:1]) + label[1:]
+}
@@ -0,0 +1,204 @@
+package pdf
+
+import (
+	"bytes"
+	"flag"
+	"fmt"
+	"os"
+	"path/filepath"
+	"regexp"
+	"strconv"
+	"testing"
+	"time"
+
+	"github.com/Adebayobenjamin/numerisbook/pkg/common/money"
+	response_dto "github.com/Adebayobenjamin/numerisbook/pkg/dtos/response"
+	"github.com/Adebayobenjamin/numerisbook/pkg/models"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+var update = flag.Bool("update", false, "rewrite the golden files in testdata")
+
+func goldenInvoice() *response_dto.GetInvoiceDetailsResponse {
+	brandColor := "#0f766e"
+	return &response_dto.GetInvoiceDetailsResponse{
+		ID:            1,
+		InvoiceNumber: "INV-2024-00042",
+		Issuer: &models.Customer{
+			Name:       "Acme Studio Ltd",
+			Address:    "12 Harbour Road, Lagos",
+			Email:      "accounts@acme.test",
+			Phone:      "+234 801 234 5678",
+			BrandColor: &brandColor,
+		},
+		InvoiceClient: models.InvoiceClient{
+			Name:    "Globex Corporation",
+			Address: "500 Market Street, Suite 1200, San Francisco, CA 94105",
+			Email:   "ap@globex.test",
+		},
+		IssueDate:       time.Date(2024, 3, 1, 0, 0, 0, 0, time.UTC),
+		DueDate:         time.Date(2024, 3, 31, 0, 0, 0, 0, time.UTC),
+		BillingCurrency: "USD",
+		Status:          models.InvoiceStatusPartiallyPaid,
+		Items: []models.InvoiceItem{
+			{
+				Description: "Brand identity design, including logo, colour palette and typography guidelines",
+				Quantity:    1,
+				UnitPrice:   money.New(450000, "USD"),
+				TaxTotal:    money.New(30375, "USD"),
+			},
+			{
+				Description:    "Website pages",
+				Quantity:       6,
+				UnitPrice:      money.New(35000, "USD"),
+				DiscountType:   models.DiscountTypePercentage,
+				DiscountRate:   money.MustParseRate("10"),
+				DiscountAmount: money.New(21000, "USD"),
+				TaxTotal:       money.New(12757, "USD"),
+			},
+		},
+		Subtotal:      money.New(660000, "USD"),
+		DiscountType:  models.DiscountTypeFixed,
+		Discount:      money.New(9000, "USD"),
+		DiscountTotal: money.New(30000, "USD"),
+		TaxSummary: []models.TaxBreakdown{
+			{Name: "VAT", Rate: money.MustParseRate("7.5"), Amount: money.New(43132, "USD")},
+		},
+		TaxTotal:       money.New(43132, "USD"),
+		TotalAmountDue: money.New(673132, "USD"),
+		Payments: []models.Payment{
+			{Amount: money.New(200000, "USD")},
+		},
+		PaymentInformation: &models.PaymentInfo{
+			BankName:      "First Bank",
+			AccountName:   "Acme Studio Ltd",
+			AccountNumber: "0123456789",
+			AchRoutingNo:  "021000021",
+		},
+		Notes: "Thank you for your business. Please include the invoice number with your payment.",
+	}
+}
+
+func TestRenderInvoiceGolden(t *testing.T) {
+	long := goldenInvoice()
+	long.InvoiceNumber = "INV-2024-00043"
+	long.Status = models.InvoiceStatusSent
+	long.Payments = nil
+	long.Items = nil
+	for idx := 1; idx <= 30; idx++ {
+		long.Items = append(long.Items, models.InvoiceItem{
+			Description: fmt.Sprintf("Consulting session %d", idx),
+			Quantity:    2,
+			UnitPrice:   money.New(7500, "USD"),
+		})
+	}
+
+	tests := []struct {
+		name    string
+		invoice *response_dto.GetInvoiceDetailsResponse
+		pages   int
+	}{
+		{name: "invoice", invoice: goldenInvoice(), pages: 1},
+		{name: "invoice_multipage", invoice: long, pages: 2},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := RenderInvoice(tt.invoice)
+			require.NoError(t, err)
+
+			golden := filepath.Join("testdata", tt.name+".golden.pdf")
+			if *update {
+				require.NoError(t, os.WriteFile(golden, got, 0o644))
+			}
+
+			want, err := os.ReadFile(golden)
+			require.NoError(t, err, "run go test ./pkg/pdf -update to create the golden files")
+			assert.True(t, bytes.Equal(want, got), "rendered PDF differs from %s; run go test ./pkg/pdf -update if the change is intended", golden)
+
+			assert.Contains(t, string(got), fmt.Sprintf("/Count %d", tt.pages))
+			assertValidXref(t, got)
+		})
+	}
+}
+
+func TestRenderInvoiceContent(t *testing.T) {
+	got, err := RenderInvoice(goldenInvoice())
+	require.NoError(t, err)
+
+	content := string(got)
+	for _, text := range []string{
+		"(Acme Studio Ltd)",
+		"(BILL TO)",
+		"(Globex Corporation)",
+		"(10%)",
+		"(Discount)",
+		"(VAT 7.5%)",
+		"(USD 6,731.32)",
+		"(Balance due)",
+		"(USD 4,731.32)",
+		"(0123456789)",
+		"(Page 1 of 1)",
+	} {
+		assert.Contains(t, content, text)
+	}
+	// the brand colour is used for the accent bar
+	assert.Contains(t, content, "0.06 0.46 0.43 rg")
+}
+
+// assertValidXref checks that every cross-reference entry points at the object it names
+func assertValidXref(t *testing.T, document []byte) {
+	t.Helper()
+
+	startxref := regexp.MustCompile(`startxref\n(\d+)\n`).FindSubmatch(document)
+	require.NotNil(t, startxref)
+	offset, _ := strconv.Atoi(string(startxref[1]))
+	require.True(t, bytes.HasPrefix(document[offset:], []byte("xref\n")))
+
+	entries := regexp.MustCompile(`(\d{10}) 00000 n `).FindAllSubmatch(document[offset:], -1)
+	require.NotEmpty(t, entries)
+	for idx, entry := range entries {
+		position, _ := strconv.Atoi(string(entry[1]))
+		assert.True(t, bytes.HasPrefix(document[position:], []byte(fmt.Sprintf("%d 0 obj", idx+1))), "object %d", idx+1)
+	}
+}
+
+func TestFormatAmount(t *testing.T) {
+	assert.Equal(t, "0.00", FormatAmount(money.New(0, "USD")))
+	assert.Equal(t, "999.99", FormatAmount(money.New(99999, "USD")))
+	assert.Equal(t, "1,250.50", FormatAmount(money.New(125050, "USD")))
+	assert.Equal(t, "-1,234,567.89", FormatAmount(money.New(-123456789, "USD")))
+	assert.Equal(t, "15,000", FormatAmount(money.New(15000, "JPY")))
+}
+
+func TestEscapeText(t *testing.T) {
+	assert.Equal(t, `Fees \(net\) a\\b`, escapeText(`Fees (net) a\b`))
+	assert.Equal(t, "Caf\xe9 \x80 5 ?", escapeText("Café € 5 ₦"))
+}
+
+func TestWrapText(t *testing.T) {
+	lines := WrapText("the quick brown fox jumps over the lazy dog", FontRegular, 10, 80)
+	for _, line := range lines {
+		assert.LessOrEqual(t, TextWidth(line, FontRegular, 10), 80.0)
+	}
+	assert.Equal(t, "the quick brown fox jumps over the lazy dog", joinLines(lines))
+
+	assert.Equal(t, []string{"first", "", "second"}, WrapText("first\n\nsecond", FontRegular, 10, 200))
+}
+
+func joinLines(lines []string) string {
+	var out bytes.Buffer
+	for idx, line := range lines {
+		if idx > 0 {
+			out.WriteByte(' ')
+		}
+		out.WriteString(line)
+	}
+	return out.String()
+}
+
+func TestParseHexColor(t *testing.T) {
+	assert.Equal(t, Color{1, 0, 0}, ParseHexColor("#ff0000", Black))
+	assert.Equal(t, Black, ParseHexColor("red", Black))
+}
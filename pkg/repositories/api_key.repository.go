@@ -0,0 +1,118 @@
+package repositories
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/Adebayobenjamin/numerisbook/pkg/common/exceptions"
+	"github.com/Adebayobenjamin/numerisbook/pkg/models"
+	repositories_interfaces "github.com/Adebayobenjamin/numerisbook/pkg/repositories/interfaces"
+	"github.com/Adebayobenjamin/numerisbook/pkg/tenant"
+	"github.com/jmoiron/sqlx"
+	"github.com/rs/zerolog"
+)
+
+type apiKeyRepository struct {
+	db     *sqlx.DB
+	logger *zerolog.Logger
+}
+
+// Create implements repositories_interfaces.APIKeyRepository.
+func (a *apiKeyRepository) Create(ctx context.Context, key *models.APIKey) (*models.APIKey, error) {
+	query := `
+		INSERT INTO api_keys (
+			customer_id, name, role, prefix, key_hash, expires_at, created_at, updated_at
+		) VALUES (?, ?, ?, ?, ?, ?, CURRENT_TIMESTAMP, CURRENT_TIMESTAMP)`
+
+	_, err := a.db.ExecContext(ctx, query, key.CustomerID, key.Name, key.Role, key.Prefix, key.KeyHash, key.ExpiresAt)
+	if err != nil {
+		return nil, fmt.Errorf("failed to create api key: %w", err)
+	}
+
+	return a.GetByHash(ctx, key.KeyHash)
+}
+
+// GetByHash implements repositories_interfaces.APIKeyRepository.
+func (a *apiKeyRepository) GetByHash(ctx context.Context, keyHash string) (*models.APIKey, error) {
+	query := `SELECT * FROM api_keys WHERE key_hash = ?`
+
+	var key models.APIKey
+	err := a.db.GetContext(ctx, &key, query, keyHash)
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return nil, fmt.Errorf("api key %w", exceptions.ErrNotFound)
+		}
+		return nil, fmt.Errorf("failed to get api key: %w", err)
+	}
+
+	return &key, nil
+}
+
+// GetAllCustomerKeys implements repositories_interfaces.APIKeyRepository.
+func (a *apiKeyRepository) GetAllCustomerKeys(ctx context.Context, customerID uint) ([]models.APIKey, error) {
+	query := `
+		SELECT * FROM api_keys 
+		WHERE customer_id = ? 
+		ORDER BY created_at DESC`
+
+	keys := []models.APIKey{}
+	err := a.db.SelectContext(ctx, &keys, query, customerID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get api keys: %w", err)
+	}
+
+	return keys, nil
+}
+
+// Revoke implements repositories_interfaces.APIKeyRepository.
+func (a *apiKeyRepository) Revoke(ctx context.Context, id uint, customerID uint, revokedAt time.Time) error {
+	query := `
+		UPDATE api_keys 
+		SET revoked_at = ?, updated_at = CURRENT_TIMESTAMP 
+		WHERE id = ? AND customer_id = ? AND revoked_at IS NULL`
+
+	result, err := a.db.ExecContext(ctx, query, revokedAt, id, customerID)
+	if err != nil {
+		return fmt.Errorf("failed to revoke api key: %w", err)
+	}
+
+	rows, err := result.RowsAffected()
+	if err != nil {
+		return fmt.Errorf("failed to get affected rows: %w", err)
+	}
+	if rows == 0 {
+		return fmt.Errorf("api key %w", exceptions.ErrNotFound)
+	}
+
+	return nil
+}
+
+// TouchLastUsed implements repositories_interfaces.APIKeyRepository.
+func (a *apiKeyRepository) TouchLastUsed(ctx context.Context, id uint, usedAt time.Time) error {
+	customerID, err := tenant.CustomerIDFromContext(ctx)
+	if err != nil {
+		return err
+	}
+
+	query := `UPDATE api_keys SET last_used_at = ? WHERE id = ? AND customer_id = ?`
+
+	_, err = a.db.ExecContext(ctx, query, usedAt, id, customerID)
+	if err != nil {
+		return fmt.Errorf("failed to update api key usage: %w", err)
+	}
+
+	return nil
+}
+
+func NewAPIKeyRepository(
+	db *sqlx.DB,
+	logger *zerolog.Logger,
+) repositories_interfaces.APIKeyRepository {
+	return &apiKeyRepository{
+		db:     db,
+		logger: logger,
+	}
+}
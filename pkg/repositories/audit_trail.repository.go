@@ -5,6 +5,7 @@ import (
 	"database/sql"
 	"fmt"
 
+	"github.com/Adebayobenjamin/numerisbook/pkg/auth"
 	"github.com/Adebayobenjamin/numerisbook/pkg/models"
 	repositories_interfaces "github.com/Adebayobenjamin/numerisbook/pkg/repositories/interfaces"
 	"github.com/jmoiron/sqlx"
@@ -53,8 +54,43 @@ func (a *auditTrailRepository) GetByInvoiceIDAndCustomerID(ctx context.Context,
 	return auditTrails, nil
 }
 
-// LogEvent creates a new audit trail entry
+// LogEvent creates a new audit trail entry, attributed to the user or API key
+// the request was authenticated as, or to the system when there is none
 func (a *auditTrailRepository) LogEvent(ctx context.Context, eventType models.EventType, logLevel models.LogLevel, message string, invoiceID uint, customerID uint) error {
+	return insertAuditTrail(ctx, a.db, eventType, logLevel, message, invoiceID, customerID)
+}
+
+// LogQuoteEvent creates an audit trail entry of an invoice that also points
+// at the quote it came from
+func (a *auditTrailRepository) LogQuoteEvent(ctx context.Context, eventType models.EventType, logLevel models.LogLevel, message string, invoiceID uint, quoteID uint, customerID uint) error {
+	query := `
+        INSERT INTO audit_trails (
+            event_type,
+            log_level,
+            message,
+            invoice_id,
+            quote_id,
+            customer_id,
+            actor_type,
+            actor_user_id,
+            actor_api_key_id,
+            created_at,
+            updated_at
+        ) VALUES (?, ?, ?, ?, ?, ?, ?, ?, ?, CURRENT_TIMESTAMP, CURRENT_TIMESTAMP)`
+
+	actorType, actorUserID, actorAPIKeyID := auditActor(ctx)
+
+	_, err := a.db.ExecContext(ctx, query, eventType, logLevel, message, invoiceID, quoteID, customerID, actorType, actorUserID, actorAPIKeyID)
+	if err != nil {
+		return fmt.Errorf("failed to log audit trail event: %w", err)
+	}
+
+	return nil
+}
+
+// insertAuditTrail writes an audit trail entry through exec, so entries can
+// also be written inside the transaction of the change they record
+func insertAuditTrail(ctx context.Context, exec sqlx.ExecerContext, eventType models.EventType, logLevel models.LogLevel, message string, invoiceID uint, customerID uint) error {
 	query := `
         INSERT INTO audit_trails (
             event_type,
@@ -62,11 +98,16 @@ func (a *auditTrailRepository) LogEvent(ctx context.Context, eventType models.Ev
             message,
             invoice_id,
             customer_id,
+            actor_type,
+            actor_user_id,
+            actor_api_key_id,
             created_at,
             updated_at
-        ) VALUES (?, ?, ?, ?, ?, CURRENT_TIMESTAMP, CURRENT_TIMESTAMP)`
+        ) VALUES (?, ?, ?, ?, ?, ?, ?, ?, CURRENT_TIMESTAMP, CURRENT_TIMESTAMP)`
 
-	_, err := a.db.ExecContext(ctx, query, eventType, logLevel, message, invoiceID, customerID)
+	actorType, actorUserID, actorAPIKeyID := auditActor(ctx)
+
+	_, err := exec.ExecContext(ctx, query, eventType, logLevel, message, invoiceID, customerID, actorType, actorUserID, actorAPIKeyID)
 	if err != nil {
 		return fmt.Errorf("failed to log audit trail event: %w", err)
 	}
@@ -74,6 +115,22 @@ func (a *auditTrailRepository) LogEvent(ctx context.Context, eventType models.Ev
 	return nil
 }
 
+// auditActor reads who is performing an action from the request's principal
+func auditActor(ctx context.Context) (models.AuditActorType, *uint, *uint) {
+	principal, ok := auth.PrincipalFromContext(ctx)
+	if !ok {
+		return models.AuditActorSystem, nil, nil
+	}
+
+	switch {
+	case principal.UserID != nil:
+		return models.AuditActorUser, principal.UserID, nil
+	case principal.APIKeyID != nil:
+		return models.AuditActorAPIKey, nil, principal.APIKeyID
+	}
+	return models.AuditActorSystem, nil, nil
+}
+
 func NewAuditTrailRepository(
 	db *sqlx.DB,
 	logger *zerolog.Logger,
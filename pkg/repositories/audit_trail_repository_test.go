@@ -7,9 +7,12 @@ import (
 	"testing"
 	"time"
 
+	"github.com/Adebayobenjamin/numerisbook/pkg/auth"
+	"github.com/Adebayobenjamin/numerisbook/pkg/models"
 	"github.com/DATA-DOG/go-sqlmock"
 	"github.com/jmoiron/sqlx"
 	"github.com/rs/zerolog"
+	"github.com/stretchr/testify/assert"
 )
 
 func getMockDB() (*sqlx.DB, sqlmock.Sqlmock, *auditTrailRepository) {
@@ -44,3 +47,42 @@ func TestAuditTrailRepository_GetAllCustomerAuditTrails(t *testing.T) {
 
 	repo.GetAllCustomerAuditTrails(context.Background(), customerID, limit, offset)
 }
+
+func TestAuditTrailRepository_LogEventRecordsTheActor(t *testing.T) {
+	db, mock, repo := getMockDB()
+	defer db.Close()
+
+	userID := uint(8)
+	apiKeyID := uint(5)
+
+	tests := []struct {
+		name      string
+		principal *auth.Principal
+		actorType models.AuditActorType
+		userID    *uint
+		apiKeyID  *uint
+	}{
+		{name: "background job", actorType: models.AuditActorSystem},
+		{name: "signed in member", principal: &auth.Principal{CustomerID: 1, UserID: &userID}, actorType: models.AuditActorUser, userID: &userID},
+		{name: "api key", principal: &auth.Principal{CustomerID: 1, APIKeyID: &apiKeyID}, actorType: models.AuditActorAPIKey, apiKeyID: &apiKeyID},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ctx := context.Background()
+			if tt.principal != nil {
+				ctx = auth.WithPrincipal(ctx, tt.principal)
+			}
+
+			mock.ExpectExec(regexp.QuoteMeta(`INSERT INTO audit_trails`)).
+				WithArgs(models.EventTypePaymentConfirmed, models.LogLevelInfo, "paid", uint(3), uint(1), tt.actorType, tt.userID, tt.apiKeyID).
+				WillReturnResult(sqlmock.NewResult(1, 1))
+
+			err := repo.LogEvent(ctx, models.EventTypePaymentConfirmed, models.LogLevelInfo, "paid", 3, 1)
+
+			assert.NoError(t, err)
+		})
+	}
+
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
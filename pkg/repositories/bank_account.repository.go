@@ -0,0 +1,171 @@
+package repositories
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+
+	"github.com/Adebayobenjamin/numerisbook/pkg/common/exceptions"
+	"github.com/Adebayobenjamin/numerisbook/pkg/models"
+	repositories_interfaces "github.com/Adebayobenjamin/numerisbook/pkg/repositories/interfaces"
+	"github.com/jmoiron/sqlx"
+	"github.com/rs/zerolog"
+)
+
+type bankAccountRepository struct {
+	db     *sqlx.DB
+	logger *zerolog.Logger
+}
+
+// Create implements repositories_interfaces.BankAccountRepository.
+func (b *bankAccountRepository) Create(ctx context.Context, account *models.BankAccount) (*models.BankAccount, error) {
+	// accounts are made the default through SetDefault, which also clears the
+	// previous default
+	query := `
+		INSERT INTO bank_accounts (
+			customer_id, bank_name, account_number, account_name, ach_routing_no, bank_address, created_at, updated_at
+		) VALUES (?, ?, ?, ?, ?, ?, CURRENT_TIMESTAMP, CURRENT_TIMESTAMP)`
+
+	result, err := b.db.ExecContext(ctx, query, account.CustomerID, account.BankName, account.AccountNumber, account.AccountName, account.AchRoutingNo, account.BankAddress)
+	if err != nil {
+		return nil, fmt.Errorf("failed to create bank account: %w", err)
+	}
+
+	accountID, _ := result.LastInsertId()
+
+	return b.GetByIDAndCustomerID(ctx, uint(accountID), account.CustomerID)
+}
+
+// GetByIDAndCustomerID implements repositories_interfaces.BankAccountRepository.
+func (b *bankAccountRepository) GetByIDAndCustomerID(ctx context.Context, id uint, customerID uint) (*models.BankAccount, error) {
+	query := `
+		SELECT * FROM bank_accounts 
+		WHERE id = ? AND customer_id = ? AND deleted_at IS NULL`
+
+	var account models.BankAccount
+	err := b.db.GetContext(ctx, &account, query, id, customerID)
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return nil, fmt.Errorf("bank account %w", exceptions.ErrNotFound)
+		}
+		return nil, fmt.Errorf("failed to get bank account: %w", err)
+	}
+
+	return &account, nil
+}
+
+// GetDefault implements repositories_interfaces.BankAccountRepository.
+func (b *bankAccountRepository) GetDefault(ctx context.Context, customerID uint) (*models.BankAccount, error) {
+	query := `
+		SELECT * FROM bank_accounts 
+		WHERE customer_id = ? AND is_default = TRUE AND deleted_at IS NULL 
+		LIMIT 1`
+
+	var account models.BankAccount
+	err := b.db.GetContext(ctx, &account, query, customerID)
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return nil, fmt.Errorf("default bank account %w", exceptions.ErrNotFound)
+		}
+		return nil, fmt.Errorf("failed to get default bank account: %w", err)
+	}
+
+	return &account, nil
+}
+
+// GetAllCustomerBankAccounts implements repositories_interfaces.BankAccountRepository.
+func (b *bankAccountRepository) GetAllCustomerBankAccounts(ctx context.Context, customerID uint) ([]models.BankAccount, error) {
+	query := `
+		SELECT * FROM bank_accounts 
+		WHERE customer_id = ? AND deleted_at IS NULL 
+		ORDER BY is_default DESC, bank_name ASC, id ASC`
+
+	accounts := []models.BankAccount{}
+	err := b.db.SelectContext(ctx, &accounts, query, customerID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get bank accounts: %w", err)
+	}
+
+	return accounts, nil
+}
+
+// Update implements repositories_interfaces.BankAccountRepository.
+func (b *bankAccountRepository) Update(ctx context.Context, account *models.BankAccount) error {
+	query := `
+		UPDATE bank_accounts 
+		SET bank_name = ?, account_number = ?, account_name = ?, ach_routing_no = ?, bank_address = ?, updated_at = CURRENT_TIMESTAMP 
+		WHERE id = ? AND customer_id = ? AND deleted_at IS NULL`
+
+	_, err := b.db.ExecContext(ctx, query, account.BankName, account.AccountNumber, account.AccountName, account.AchRoutingNo, account.BankAddress, account.ID, account.CustomerID)
+	if err != nil {
+		return fmt.Errorf("failed to update bank account: %w", err)
+	}
+
+	return nil
+}
+
+// Delete implements repositories_interfaces.BankAccountRepository.
+func (b *bankAccountRepository) Delete(ctx context.Context, id uint, customerID uint) error {
+	query := `
+		UPDATE bank_accounts 
+		SET deleted_at = CURRENT_TIMESTAMP, is_default = FALSE 
+		WHERE id = ? AND customer_id = ? AND deleted_at IS NULL`
+
+	result, err := b.db.ExecContext(ctx, query, id, customerID)
+	if err != nil {
+		return fmt.Errorf("failed to delete bank account: %w", err)
+	}
+
+	if rows, _ := result.RowsAffected(); rows == 0 {
+		return fmt.Errorf("bank account %w", exceptions.ErrNotFound)
+	}
+
+	return nil
+}
+
+// SetDefault implements repositories_interfaces.BankAccountRepository.
+func (b *bankAccountRepository) SetDefault(ctx context.Context, id uint, customerID uint) error {
+	tx, err := b.db.BeginTxx(ctx, nil)
+	if err != nil {
+		return fmt.Errorf("failed to begin transaction: %w", err)
+	}
+	defer tx.Rollback()
+
+	var accountID uint
+	err = tx.GetContext(ctx, &accountID, `
+		SELECT id FROM bank_accounts 
+		WHERE id = ? AND customer_id = ? AND deleted_at IS NULL 
+		FOR UPDATE`, id, customerID)
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return fmt.Errorf("bank account %w", exceptions.ErrNotFound)
+		}
+		return fmt.Errorf("failed to get bank account: %w", err)
+	}
+
+	// one statement moves the flag, so there is never more than one default
+	_, err = tx.ExecContext(ctx, `
+		UPDATE bank_accounts 
+		SET is_default = (id = ?) 
+		WHERE customer_id = ? AND deleted_at IS NULL`, id, customerID)
+	if err != nil {
+		return fmt.Errorf("failed to set default bank account: %w", err)
+	}
+
+	if err := tx.Commit(); err != nil {
+		return fmt.Errorf("failed to commit transaction: %w", err)
+	}
+
+	return nil
+}
+
+func NewBankAccountRepository(
+	db *sqlx.DB,
+	logger *zerolog.Logger,
+) repositories_interfaces.BankAccountRepository {
+	return &bankAccountRepository{
+		db:     db,
+		logger: logger,
+	}
+}
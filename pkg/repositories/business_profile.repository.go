@@ -0,0 +1,171 @@
+package repositories
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+
+	"github.com/Adebayobenjamin/numerisbook/pkg/common/exceptions"
+	"github.com/Adebayobenjamin/numerisbook/pkg/models"
+	repositories_interfaces "github.com/Adebayobenjamin/numerisbook/pkg/repositories/interfaces"
+	"github.com/jmoiron/sqlx"
+	"github.com/rs/zerolog"
+)
+
+type businessProfileRepository struct {
+	db     *sqlx.DB
+	logger *zerolog.Logger
+}
+
+// Create implements repositories_interfaces.BusinessProfileRepository.
+func (b *businessProfileRepository) Create(ctx context.Context, profile *models.BusinessProfile) (*models.BusinessProfile, error) {
+	// profiles are made the default through SetDefault, which also clears the
+	// previous default
+	query := `
+		INSERT INTO business_profiles (
+			customer_id, name, email, phone, address, created_at, updated_at
+		) VALUES (?, ?, ?, ?, ?, CURRENT_TIMESTAMP, CURRENT_TIMESTAMP)`
+
+	result, err := b.db.ExecContext(ctx, query, profile.CustomerID, profile.Name, profile.Email, profile.Phone, profile.Address)
+	if err != nil {
+		return nil, fmt.Errorf("failed to create business profile: %w", err)
+	}
+
+	profileID, _ := result.LastInsertId()
+
+	return b.GetByIDAndCustomerID(ctx, uint(profileID), profile.CustomerID)
+}
+
+// GetByIDAndCustomerID implements repositories_interfaces.BusinessProfileRepository.
+func (b *businessProfileRepository) GetByIDAndCustomerID(ctx context.Context, id uint, customerID uint) (*models.BusinessProfile, error) {
+	query := `
+		SELECT * FROM business_profiles 
+		WHERE id = ? AND customer_id = ? AND deleted_at IS NULL`
+
+	var profile models.BusinessProfile
+	err := b.db.GetContext(ctx, &profile, query, id, customerID)
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return nil, fmt.Errorf("business profile %w", exceptions.ErrNotFound)
+		}
+		return nil, fmt.Errorf("failed to get business profile: %w", err)
+	}
+
+	return &profile, nil
+}
+
+// GetDefault implements repositories_interfaces.BusinessProfileRepository.
+func (b *businessProfileRepository) GetDefault(ctx context.Context, customerID uint) (*models.BusinessProfile, error) {
+	query := `
+		SELECT * FROM business_profiles 
+		WHERE customer_id = ? AND is_default = TRUE AND deleted_at IS NULL 
+		LIMIT 1`
+
+	var profile models.BusinessProfile
+	err := b.db.GetContext(ctx, &profile, query, customerID)
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return nil, fmt.Errorf("default business profile %w", exceptions.ErrNotFound)
+		}
+		return nil, fmt.Errorf("failed to get default business profile: %w", err)
+	}
+
+	return &profile, nil
+}
+
+// GetAllCustomerBusinessProfiles implements repositories_interfaces.BusinessProfileRepository.
+func (b *businessProfileRepository) GetAllCustomerBusinessProfiles(ctx context.Context, customerID uint) ([]models.BusinessProfile, error) {
+	query := `
+		SELECT * FROM business_profiles 
+		WHERE customer_id = ? AND deleted_at IS NULL 
+		ORDER BY is_default DESC, name ASC, id ASC`
+
+	profiles := []models.BusinessProfile{}
+	err := b.db.SelectContext(ctx, &profiles, query, customerID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get business profiles: %w", err)
+	}
+
+	return profiles, nil
+}
+
+// Update implements repositories_interfaces.BusinessProfileRepository.
+func (b *businessProfileRepository) Update(ctx context.Context, profile *models.BusinessProfile) error {
+	query := `
+		UPDATE business_profiles 
+		SET name = ?, email = ?, phone = ?, address = ?, updated_at = CURRENT_TIMESTAMP 
+		WHERE id = ? AND customer_id = ? AND deleted_at IS NULL`
+
+	_, err := b.db.ExecContext(ctx, query, profile.Name, profile.Email, profile.Phone, profile.Address, profile.ID, profile.CustomerID)
+	if err != nil {
+		return fmt.Errorf("failed to update business profile: %w", err)
+	}
+
+	return nil
+}
+
+// Delete implements repositories_interfaces.BusinessProfileRepository.
+func (b *businessProfileRepository) Delete(ctx context.Context, id uint, customerID uint) error {
+	query := `
+		UPDATE business_profiles 
+		SET deleted_at = CURRENT_TIMESTAMP, is_default = FALSE 
+		WHERE id = ? AND customer_id = ? AND deleted_at IS NULL`
+
+	result, err := b.db.ExecContext(ctx, query, id, customerID)
+	if err != nil {
+		return fmt.Errorf("failed to delete business profile: %w", err)
+	}
+
+	if rows, _ := result.RowsAffected(); rows == 0 {
+		return fmt.Errorf("business profile %w", exceptions.ErrNotFound)
+	}
+
+	return nil
+}
+
+// SetDefault implements repositories_interfaces.BusinessProfileRepository.
+func (b *businessProfileRepository) SetDefault(ctx context.Context, id uint, customerID uint) error {
+	tx, err := b.db.BeginTxx(ctx, nil)
+	if err != nil {
+		return fmt.Errorf("failed to begin transaction: %w", err)
+	}
+	defer tx.Rollback()
+
+	var profileID uint
+	err = tx.GetContext(ctx, &profileID, `
+		SELECT id FROM business_profiles 
+		WHERE id = ? AND customer_id = ? AND deleted_at IS NULL 
+		FOR UPDATE`, id, customerID)
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return fmt.Errorf("business profile %w", exceptions.ErrNotFound)
+		}
+		return fmt.Errorf("failed to get business profile: %w", err)
+	}
+
+	// one statement moves the flag, so there is never more than one default
+	_, err = tx.ExecContext(ctx, `
+		UPDATE business_profiles 
+		SET is_default = (id = ?) 
+		WHERE customer_id = ? AND deleted_at IS NULL`, id, customerID)
+	if err != nil {
+		return fmt.Errorf("failed to set default business profile: %w", err)
+	}
+
+	if err := tx.Commit(); err != nil {
+		return fmt.Errorf("failed to commit transaction: %w", err)
+	}
+
+	return nil
+}
+
+func NewBusinessProfileRepository(
+	db *sqlx.DB,
+	logger *zerolog.Logger,
+) repositories_interfaces.BusinessProfileRepository {
+	return &businessProfileRepository{
+		db:     db,
+		logger: logger,
+	}
+}
@@ -0,0 +1,63 @@
+package repositories
+
+import (
+	"context"
+	"regexp"
+	"testing"
+
+	"github.com/Adebayobenjamin/numerisbook/pkg/common/exceptions"
+	"github.com/DATA-DOG/go-sqlmock"
+	"github.com/rs/zerolog"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestBusinessProfileRepository_SetDefault(t *testing.T) {
+	db, mock, _ := getMockDB()
+	defer db.Close()
+
+	repo := &businessProfileRepository{db: db, logger: &zerolog.Logger{}}
+	ctx := context.Background()
+
+	t.Run("the flag moves to the profile in one statement", func(t *testing.T) {
+		mock.ExpectBegin()
+		mock.ExpectQuery(regexp.QuoteMeta(`WHERE id = ? AND customer_id = ? AND deleted_at IS NULL 
+		FOR UPDATE`)).
+			WithArgs(uint(4), uint(1)).
+			WillReturnRows(sqlmock.NewRows([]string{"id"}).AddRow(4))
+		mock.ExpectExec(regexp.QuoteMeta(`SET is_default = (id = ?) 
+		WHERE customer_id = ? AND deleted_at IS NULL`)).
+			WithArgs(uint(4), uint(1)).
+			WillReturnResult(sqlmock.NewResult(0, 2))
+		mock.ExpectCommit()
+
+		assert.NoError(t, repo.SetDefault(ctx, 4, 1))
+	})
+
+	t.Run("another customer's profile is not made the default", func(t *testing.T) {
+		mock.ExpectBegin()
+		mock.ExpectQuery(regexp.QuoteMeta(`FOR UPDATE`)).
+			WithArgs(uint(4), uint(2)).
+			WillReturnRows(sqlmock.NewRows([]string{"id"}))
+		mock.ExpectRollback()
+
+		assert.ErrorIs(t, repo.SetDefault(ctx, 4, 2), exceptions.ErrNotFound)
+	})
+
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
+
+func TestBusinessProfileRepository_Delete(t *testing.T) {
+	db, mock, _ := getMockDB()
+	defer db.Close()
+
+	repo := &businessProfileRepository{db: db, logger: &zerolog.Logger{}}
+
+	// a deleted profile stops being the default, leaving the account's own
+	// details to fall back on
+	mock.ExpectExec(regexp.QuoteMeta(`SET deleted_at = CURRENT_TIMESTAMP, is_default = FALSE`)).
+		WithArgs(uint(4), uint(1)).
+		WillReturnResult(sqlmock.NewResult(0, 1))
+
+	assert.NoError(t, repo.Delete(context.Background(), 4, 1))
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
@@ -0,0 +1,222 @@
+package repositories
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/Adebayobenjamin/numerisbook/pkg/common/exceptions"
+	response_dto "github.com/Adebayobenjamin/numerisbook/pkg/dtos/response"
+	"github.com/Adebayobenjamin/numerisbook/pkg/models"
+	repositories_interfaces "github.com/Adebayobenjamin/numerisbook/pkg/repositories/interfaces"
+	"github.com/jmoiron/sqlx"
+	"github.com/rs/zerolog"
+)
+
+type catalogItemRepository struct {
+	db     *sqlx.DB
+	logger *zerolog.Logger
+}
+
+// Create implements repositories_interfaces.CatalogItemRepository.
+func (c *catalogItemRepository) Create(ctx context.Context, item *models.CatalogItem) (*models.CatalogItem, error) {
+	query := `
+		INSERT INTO catalog_items (
+			customer_id, sku, name, description, unit_price, currency, unit,
+			tax_name, tax_rate, tax_is_inclusive, created_at, updated_at
+		) VALUES (?, ?, ?, ?, ?, ?, ?, ?, ?, ?, CURRENT_TIMESTAMP, CURRENT_TIMESTAMP)`
+
+	result, err := c.db.ExecContext(ctx, query,
+		item.CustomerID,
+		item.SKU,
+		item.Name,
+		item.Description,
+		item.UnitPrice,
+		item.Currency,
+		item.Unit,
+		item.TaxName,
+		item.TaxRate,
+		item.TaxIsInclusive)
+	if err != nil {
+		return nil, fmt.Errorf("failed to create catalog item: %w", err)
+	}
+
+	itemID, _ := result.LastInsertId()
+
+	return c.GetByIDAndCustomerID(ctx, uint(itemID), item.CustomerID)
+}
+
+// GetByIDAndCustomerID implements repositories_interfaces.CatalogItemRepository.
+func (c *catalogItemRepository) GetByIDAndCustomerID(ctx context.Context, id uint, customerID uint) (*models.CatalogItem, error) {
+	query := `
+		SELECT * FROM catalog_items 
+		WHERE id = ? AND customer_id = ? AND deleted_at IS NULL`
+
+	return c.getOne(ctx, query, id, customerID)
+}
+
+// GetBySKU implements repositories_interfaces.CatalogItemRepository.
+func (c *catalogItemRepository) GetBySKU(ctx context.Context, customerID uint, sku string) (*models.CatalogItem, error) {
+	query := `
+		SELECT * FROM catalog_items 
+		WHERE customer_id = ? AND sku = ? AND deleted_at IS NULL 
+		ORDER BY id ASC 
+		LIMIT 1`
+
+	return c.getOne(ctx, query, customerID, sku)
+}
+
+// GetAllCustomerCatalogItems implements repositories_interfaces.CatalogItemRepository.
+func (c *catalogItemRepository) GetAllCustomerCatalogItems(ctx context.Context, customerID uint, search string, limit int, offset int) ([]models.CatalogItem, error) {
+	query := `SELECT * FROM catalog_items WHERE customer_id = ? AND deleted_at IS NULL`
+	args := []any{customerID}
+
+	if search != "" {
+		pattern := "%" + likeEscaper.Replace(search) + "%"
+		query += ` AND (sku LIKE ? OR name LIKE ?)`
+		args = append(args, pattern, pattern)
+	}
+
+	query += ` ORDER BY name ASC, id ASC LIMIT ? OFFSET ?`
+	args = append(args, limit, offset)
+
+	items := []models.CatalogItem{}
+	err := c.db.SelectContext(ctx, &items, query, args...)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get catalog items: %w", err)
+	}
+
+	for idx := range items {
+		if err := items[idx].AssignCurrency(); err != nil {
+			return nil, fmt.Errorf("failed to read catalog item price: %w", err)
+		}
+	}
+
+	return items, nil
+}
+
+// Update implements repositories_interfaces.CatalogItemRepository.
+func (c *catalogItemRepository) Update(ctx context.Context, item *models.CatalogItem) error {
+	query := `
+		UPDATE catalog_items 
+		SET sku = ?, name = ?, description = ?, unit_price = ?, currency = ?, unit = ?, 
+			tax_name = ?, tax_rate = ?, tax_is_inclusive = ?, updated_at = CURRENT_TIMESTAMP 
+		WHERE id = ? AND customer_id = ? AND deleted_at IS NULL`
+
+	_, err := c.db.ExecContext(ctx, query,
+		item.SKU,
+		item.Name,
+		item.Description,
+		item.UnitPrice,
+		item.Currency,
+		item.Unit,
+		item.TaxName,
+		item.TaxRate,
+		item.TaxIsInclusive,
+		item.ID,
+		item.CustomerID)
+	if err != nil {
+		return fmt.Errorf("failed to update catalog item: %w", err)
+	}
+
+	return nil
+}
+
+// Delete implements repositories_interfaces.CatalogItemRepository.
+func (c *catalogItemRepository) Delete(ctx context.Context, id uint, customerID uint) error {
+	query := `
+		UPDATE catalog_items 
+		SET deleted_at = CURRENT_TIMESTAMP 
+		WHERE id = ? AND customer_id = ? AND deleted_at IS NULL`
+
+	result, err := c.db.ExecContext(ctx, query, id, customerID)
+	if err != nil {
+		return fmt.Errorf("failed to delete catalog item: %w", err)
+	}
+
+	if rows, _ := result.RowsAffected(); rows == 0 {
+		return fmt.Errorf("catalog item %w", exceptions.ErrNotFound)
+	}
+
+	return nil
+}
+
+// GetRevenue implements repositories_interfaces.CatalogItemRepository.
+func (c *catalogItemRepository) GetRevenue(ctx context.Context, customerID uint, from *time.Time, to *time.Time) ([]response_dto.CatalogItemRevenue, error) {
+	// only invoices that were actually issued count, drafts and invoices
+	// voided or cancelled never earned anything. Deleted catalog items are
+	// still reported, their sales happened.
+	query := `
+		SELECT 
+			ii.catalog_item_id,
+			ci.sku,
+			ci.name,
+			i.billing_currency as currency,
+			COUNT(DISTINCT i.id) as invoice_count,
+			SUM(ii.quantity) as quantity,
+			SUM(ii.net_amount) as net_revenue,
+			SUM(ii.tax_total) as tax_total
+		FROM invoice_items ii
+		JOIN invoices i ON i.id = ii.invoice_id
+		JOIN catalog_items ci ON ci.id = ii.catalog_item_id
+		WHERE i.customer_id = ? AND i.deleted_at IS NULL AND ii.deleted_at IS NULL 
+			AND i.status IN ('sent', 'partially_paid', 'paid', 'overdue')`
+	args := []any{customerID}
+
+	if from != nil {
+		query += ` AND i.issue_date >= ?`
+		args = append(args, *from)
+	}
+	if to != nil {
+		query += ` AND i.issue_date < ?`
+		args = append(args, *to)
+	}
+
+	query += `
+		GROUP BY ii.catalog_item_id, ci.sku, ci.name, i.billing_currency
+		ORDER BY ci.name ASC, i.billing_currency ASC`
+
+	revenue := []response_dto.CatalogItemRevenue{}
+	err := c.db.SelectContext(ctx, &revenue, query, args...)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get catalog revenue: %w", err)
+	}
+
+	for idx := range revenue {
+		if err := revenue[idx].AssignCurrency(); err != nil {
+			return nil, fmt.Errorf("failed to read catalog revenue: %w", err)
+		}
+	}
+
+	return revenue, nil
+}
+
+// getOne loads a single catalog item with its price tagged with its currency
+func (c *catalogItemRepository) getOne(ctx context.Context, query string, args ...any) (*models.CatalogItem, error) {
+	var item models.CatalogItem
+	err := c.db.GetContext(ctx, &item, query, args...)
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return nil, fmt.Errorf("catalog item %w", exceptions.ErrNotFound)
+		}
+		return nil, fmt.Errorf("failed to get catalog item: %w", err)
+	}
+
+	if err := item.AssignCurrency(); err != nil {
+		return nil, fmt.Errorf("failed to read catalog item price: %w", err)
+	}
+
+	return &item, nil
+}
+
+func NewCatalogItemRepository(
+	db *sqlx.DB,
+	logger *zerolog.Logger,
+) repositories_interfaces.CatalogItemRepository {
+	return &catalogItemRepository{
+		db:     db,
+		logger: logger,
+	}
+}
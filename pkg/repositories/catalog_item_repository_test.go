@@ -0,0 +1,38 @@
+package repositories
+
+import (
+	"context"
+	"regexp"
+	"testing"
+	"time"
+
+	"github.com/Adebayobenjamin/numerisbook/pkg/common/money"
+	"github.com/DATA-DOG/go-sqlmock"
+	"github.com/rs/zerolog"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestCatalogItemRepository_GetRevenue(t *testing.T) {
+	db, mock, _ := getMockDB()
+	defer db.Close()
+
+	repo := &catalogItemRepository{db: db, logger: &zerolog.Logger{}}
+	from := time.Date(2025, 1, 1, 0, 0, 0, 0, time.UTC)
+	to := time.Date(2025, 4, 1, 0, 0, 0, 0, time.UTC)
+
+	// revenue is split by currency, amounts in different currencies are never added up
+	mock.ExpectQuery(regexp.QuoteMeta(`AND i.status IN ('sent', 'partially_paid', 'paid', 'overdue') AND i.issue_date >= ? AND i.issue_date < ?
+		GROUP BY ii.catalog_item_id, ci.sku, ci.name, i.billing_currency`)).
+		WithArgs(uint(1), from, to).
+		WillReturnRows(sqlmock.NewRows([]string{"catalog_item_id", "sku", "name", "currency", "invoice_count", "quantity", "net_revenue", "tax_total"}).
+			AddRow(11, "DSG-01", "Design hour", "EUR", 1, 3, "360.00", "27.00").
+			AddRow(11, "DSG-01", "Design hour", "USD", 4, 10, "1200.00", "90.00"))
+
+	revenue, err := repo.GetRevenue(context.Background(), 1, &from, &to)
+
+	assert.NoError(t, err)
+	assert.Len(t, revenue, 2)
+	assert.Equal(t, money.New(36000, "EUR"), revenue[0].NetRevenue)
+	assert.Equal(t, money.New(9000, "USD"), revenue[1].TaxTotal)
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
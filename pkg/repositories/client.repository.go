@@ -0,0 +1,170 @@
+package repositories
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+	"strings"
+
+	"github.com/Adebayobenjamin/numerisbook/pkg/common/exceptions"
+	"github.com/Adebayobenjamin/numerisbook/pkg/models"
+	repositories_interfaces "github.com/Adebayobenjamin/numerisbook/pkg/repositories/interfaces"
+	"github.com/jmoiron/sqlx"
+	"github.com/rs/zerolog"
+)
+
+// likeEscaper escapes the LIKE wildcards so searches match them literally
+var likeEscaper = strings.NewReplacer(`\`, `\\`, `%`, `\%`, `_`, `\_`)
+
+type clientRepository struct {
+	db     *sqlx.DB
+	logger *zerolog.Logger
+}
+
+// Create implements repositories_interfaces.ClientRepository.
+func (c *clientRepository) Create(ctx context.Context, client *models.Client) (*models.Client, error) {
+	query := `
+		INSERT INTO clients (
+			customer_id, name, email, phone, address, created_at, updated_at
+		) VALUES (?, ?, ?, ?, ?, CURRENT_TIMESTAMP, CURRENT_TIMESTAMP)`
+
+	result, err := c.db.ExecContext(ctx, query, client.CustomerID, client.Name, client.Email, client.Phone, client.Address)
+	if err != nil {
+		return nil, fmt.Errorf("failed to create client: %w", err)
+	}
+
+	clientID, _ := result.LastInsertId()
+
+	return c.GetByIDAndCustomerID(ctx, uint(clientID), client.CustomerID)
+}
+
+// GetByIDAndCustomerID implements repositories_interfaces.ClientRepository.
+func (c *clientRepository) GetByIDAndCustomerID(ctx context.Context, id uint, customerID uint) (*models.Client, error) {
+	query := `
+		SELECT * FROM clients 
+		WHERE id = ? AND customer_id = ? AND deleted_at IS NULL`
+
+	var client models.Client
+	err := c.db.GetContext(ctx, &client, query, id, customerID)
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return nil, fmt.Errorf("client %w", exceptions.ErrNotFound)
+		}
+		return nil, fmt.Errorf("failed to get client: %w", err)
+	}
+
+	return &client, nil
+}
+
+// GetByEmail implements repositories_interfaces.ClientRepository.
+func (c *clientRepository) GetByEmail(ctx context.Context, customerID uint, email string) (*models.Client, error) {
+	query := `
+		SELECT * FROM clients 
+		WHERE customer_id = ? AND email = ? AND deleted_at IS NULL 
+		ORDER BY id ASC 
+		LIMIT 1`
+
+	var client models.Client
+	err := c.db.GetContext(ctx, &client, query, customerID, email)
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return nil, fmt.Errorf("client %w", exceptions.ErrNotFound)
+		}
+		return nil, fmt.Errorf("failed to get client: %w", err)
+	}
+
+	return &client, nil
+}
+
+// GetAllCustomerClients implements repositories_interfaces.ClientRepository.
+func (c *clientRepository) GetAllCustomerClients(ctx context.Context, customerID uint, search string, deleted bool, limit int, offset int) ([]models.Client, error) {
+	query := `SELECT * FROM clients WHERE customer_id = ?`
+	args := []any{customerID}
+
+	if deleted {
+		query += ` AND deleted_at IS NOT NULL`
+	} else {
+		query += ` AND deleted_at IS NULL`
+	}
+
+	if search != "" {
+		pattern := "%" + likeEscaper.Replace(search) + "%"
+		query += ` AND (name LIKE ? OR email LIKE ? OR phone LIKE ?)`
+		args = append(args, pattern, pattern, pattern)
+	}
+
+	query += ` ORDER BY name ASC, id ASC LIMIT ? OFFSET ?`
+	args = append(args, limit, offset)
+
+	clients := []models.Client{}
+	err := c.db.SelectContext(ctx, &clients, query, args...)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get clients: %w", err)
+	}
+
+	return clients, nil
+}
+
+// Update implements repositories_interfaces.ClientRepository.
+func (c *clientRepository) Update(ctx context.Context, client *models.Client) error {
+	query := `
+		UPDATE clients 
+		SET name = ?, email = ?, phone = ?, address = ?, updated_at = CURRENT_TIMESTAMP 
+		WHERE id = ? AND customer_id = ? AND deleted_at IS NULL`
+
+	_, err := c.db.ExecContext(ctx, query, client.Name, client.Email, client.Phone, client.Address, client.ID, client.CustomerID)
+	if err != nil {
+		return fmt.Errorf("failed to update client: %w", err)
+	}
+
+	return nil
+}
+
+// Delete implements repositories_interfaces.ClientRepository.
+func (c *clientRepository) Delete(ctx context.Context, id uint, customerID uint) error {
+	query := `
+		UPDATE clients 
+		SET deleted_at = CURRENT_TIMESTAMP 
+		WHERE id = ? AND customer_id = ? AND deleted_at IS NULL`
+
+	result, err := c.db.ExecContext(ctx, query, id, customerID)
+	if err != nil {
+		return fmt.Errorf("failed to delete client: %w", err)
+	}
+
+	if rows, _ := result.RowsAffected(); rows == 0 {
+		return fmt.Errorf("client %w", exceptions.ErrNotFound)
+	}
+
+	return nil
+}
+
+// Restore implements repositories_interfaces.ClientRepository.
+func (c *clientRepository) Restore(ctx context.Context, id uint, customerID uint) error {
+	query := `
+		UPDATE clients 
+		SET deleted_at = NULL, updated_at = CURRENT_TIMESTAMP 
+		WHERE id = ? AND customer_id = ? AND deleted_at IS NOT NULL`
+
+	result, err := c.db.ExecContext(ctx, query, id, customerID)
+	if err != nil {
+		return fmt.Errorf("failed to restore client: %w", err)
+	}
+
+	if rows, _ := result.RowsAffected(); rows == 0 {
+		return fmt.Errorf("deleted client %w", exceptions.ErrNotFound)
+	}
+
+	return nil
+}
+
+func NewClientRepository(
+	db *sqlx.DB,
+	logger *zerolog.Logger,
+) repositories_interfaces.ClientRepository {
+	return &clientRepository{
+		db:     db,
+		logger: logger,
+	}
+}
@@ -0,0 +1,74 @@
+package repositories
+
+import (
+	"context"
+	"fmt"
+
+	response_dto "github.com/Adebayobenjamin/numerisbook/pkg/dtos/response"
+	"github.com/Adebayobenjamin/numerisbook/pkg/models"
+	repositories_interfaces "github.com/Adebayobenjamin/numerisbook/pkg/repositories/interfaces"
+	"github.com/jmoiron/sqlx"
+	"github.com/rs/zerolog"
+)
+
+type clientCreditRepository struct {
+	db     *sqlx.DB
+	logger *zerolog.Logger
+}
+
+// GetClientCredits implements repositories_interfaces.ClientCreditRepository.
+func (c *clientCreditRepository) GetClientCredits(ctx context.Context, clientID uint, customerID uint, limit int, offset int) ([]models.ClientCredit, error) {
+	query := `
+		SELECT * FROM client_credits 
+		WHERE client_id = ? AND customer_id = ? 
+		ORDER BY created_at DESC, id DESC 
+		LIMIT ? OFFSET ?`
+
+	credits := []models.ClientCredit{}
+	if err := c.db.SelectContext(ctx, &credits, query, clientID, customerID, limit, offset); err != nil {
+		return nil, fmt.Errorf("failed to get client credits: %w", err)
+	}
+
+	for i := range credits {
+		var err error
+		if credits[i].Amount, err = credits[i].Amount.WithCurrency(credits[i].Currency); err != nil {
+			return nil, fmt.Errorf("failed to read client credit amounts: %w", err)
+		}
+	}
+
+	return credits, nil
+}
+
+// GetClientBalances implements repositories_interfaces.ClientCreditRepository.
+func (c *clientCreditRepository) GetClientBalances(ctx context.Context, clientID uint, customerID uint) ([]response_dto.ClientCreditBalance, error) {
+	query := `
+		SELECT currency, SUM(amount) as balance 
+		FROM client_credits 
+		WHERE client_id = ? AND customer_id = ? 
+		GROUP BY currency 
+		HAVING SUM(amount) <> 0 
+		ORDER BY currency`
+
+	balances := []response_dto.ClientCreditBalance{}
+	if err := c.db.SelectContext(ctx, &balances, query, clientID, customerID); err != nil {
+		return nil, fmt.Errorf("failed to get client credit balances: %w", err)
+	}
+
+	for i := range balances {
+		var err error
+		if balances[i].Balance, err = balances[i].Balance.WithCurrency(balances[i].Currency); err != nil {
+			return nil, fmt.Errorf("failed to read client credit balances: %w", err)
+		}
+	}
+
+	return balances, nil
+}
+
+func NewClientCreditRepository(
+	db *sqlx.DB, logger *zerolog.Logger,
+) repositories_interfaces.ClientCreditRepository {
+	return &clientCreditRepository{
+		db:     db,
+		logger: logger,
+	}
+}
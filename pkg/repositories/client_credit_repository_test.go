@@ -0,0 +1,36 @@
+package repositories
+
+import (
+	"context"
+	"regexp"
+	"testing"
+
+	"github.com/Adebayobenjamin/numerisbook/pkg/common/money"
+	response_dto "github.com/Adebayobenjamin/numerisbook/pkg/dtos/response"
+	"github.com/DATA-DOG/go-sqlmock"
+	"github.com/rs/zerolog"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestClientCreditRepository_GetClientBalances(t *testing.T) {
+	db, mock, _ := getMockDB()
+	defer db.Close()
+
+	repo := &clientCreditRepository{db: db, logger: &zerolog.Logger{}}
+
+	mock.ExpectQuery(regexp.QuoteMeta(`SELECT currency, SUM(amount) as balance FROM client_credits WHERE client_id = ? AND customer_id = ? GROUP BY currency HAVING SUM(amount) <> 0`)).
+		WithArgs(uint(9), uint(4)).
+		WillReturnRows(sqlmock.NewRows([]string{"currency", "balance"}).
+			AddRow("JPY", []byte("1500")).
+			AddRow("USD", []byte("20.50")))
+
+	balances, err := repo.GetClientBalances(context.Background(), 9, 4)
+
+	assert.NoError(t, err)
+	// each balance is read in its own currency's minor units
+	assert.Equal(t, []response_dto.ClientCreditBalance{
+		{Currency: "JPY", Balance: money.New(1500, "JPY")},
+		{Currency: "USD", Balance: money.New(2050, "USD")},
+	}, balances)
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
@@ -0,0 +1,103 @@
+package repositories
+
+import (
+	"context"
+	"regexp"
+	"testing"
+
+	"github.com/Adebayobenjamin/numerisbook/pkg/common/exceptions"
+	"github.com/DATA-DOG/go-sqlmock"
+	"github.com/rs/zerolog"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestClientRepository_GetAllCustomerClients(t *testing.T) {
+	db, mock, _ := getMockDB()
+	defer db.Close()
+
+	repo := &clientRepository{db: db, logger: &zerolog.Logger{}}
+	ctx := context.Background()
+
+	t.Run("search matches name, email or phone with wildcards escaped", func(t *testing.T) {
+		rows := sqlmock.NewRows([]string{"id", "customer_id", "name", "email", "phone"}).
+			AddRow(3, 1, "100% Acme", "billing@acme.test", "+14155550123")
+		mock.ExpectQuery(regexp.QuoteMeta(`SELECT * FROM clients WHERE customer_id = ? AND deleted_at IS NULL AND (name LIKE ? OR email LIKE ? OR phone LIKE ?) ORDER BY name ASC, id ASC LIMIT ? OFFSET ?`)).
+			WithArgs(uint(1), `%100\%%`, `%100\%%`, `%100\%%`, 10, 0).
+			WillReturnRows(rows)
+
+		clients, err := repo.GetAllCustomerClients(ctx, 1, "100%", false, 10, 0)
+
+		assert.NoError(t, err)
+		assert.Len(t, clients, 1)
+		assert.Equal(t, "100% Acme", clients[0].Name)
+	})
+
+	t.Run("deleted clients are listed separately", func(t *testing.T) {
+		mock.ExpectQuery(regexp.QuoteMeta(`SELECT * FROM clients WHERE customer_id = ? AND deleted_at IS NOT NULL ORDER BY name ASC, id ASC LIMIT ? OFFSET ?`)).
+			WithArgs(uint(1), 10, 10).
+			WillReturnRows(sqlmock.NewRows([]string{"id"}))
+
+		clients, err := repo.GetAllCustomerClients(ctx, 1, "", true, 10, 10)
+
+		assert.NoError(t, err)
+		assert.Empty(t, clients)
+	})
+
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
+
+func TestClientRepository_DeleteAndRestore(t *testing.T) {
+	db, mock, _ := getMockDB()
+	defer db.Close()
+
+	repo := &clientRepository{db: db, logger: &zerolog.Logger{}}
+	ctx := context.Background()
+
+	t.Run("deleting archives the client", func(t *testing.T) {
+		mock.ExpectExec(regexp.QuoteMeta(`SET deleted_at = CURRENT_TIMESTAMP 
+		WHERE id = ? AND customer_id = ? AND deleted_at IS NULL`)).
+			WithArgs(uint(3), uint(1)).
+			WillReturnResult(sqlmock.NewResult(0, 1))
+
+		assert.NoError(t, repo.Delete(ctx, 3, 1))
+	})
+
+	t.Run("another customer's client is not deleted", func(t *testing.T) {
+		mock.ExpectExec(regexp.QuoteMeta(`SET deleted_at = CURRENT_TIMESTAMP`)).
+			WithArgs(uint(3), uint(2)).
+			WillReturnResult(sqlmock.NewResult(0, 0))
+
+		assert.ErrorIs(t, repo.Delete(ctx, 3, 2), exceptions.ErrNotFound)
+	})
+
+	t.Run("only archived clients can be restored", func(t *testing.T) {
+		mock.ExpectExec(regexp.QuoteMeta(`WHERE id = ? AND customer_id = ? AND deleted_at IS NOT NULL`)).
+			WithArgs(uint(3), uint(1)).
+			WillReturnResult(sqlmock.NewResult(0, 0))
+
+		assert.ErrorIs(t, repo.Restore(ctx, 3, 1), exceptions.ErrNotFound)
+	})
+
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
+
+func TestClientRepository_GetByEmail(t *testing.T) {
+	db, mock, _ := getMockDB()
+	defer db.Close()
+
+	repo := &clientRepository{db: db, logger: &zerolog.Logger{}}
+	ctx := context.Background()
+
+	t.Run("archived clients are not matched", func(t *testing.T) {
+		mock.ExpectQuery(regexp.QuoteMeta(`WHERE customer_id = ? AND email = ? AND deleted_at IS NULL`)).
+			WithArgs(uint(1), "billing@acme.test").
+			WillReturnRows(sqlmock.NewRows([]string{"id"}))
+
+		client, err := repo.GetByEmail(ctx, 1, "billing@acme.test")
+
+		assert.Nil(t, client)
+		assert.ErrorIs(t, err, exceptions.ErrNotFound)
+	})
+
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
@@ -0,0 +1,259 @@
+package repositories
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+
+	"github.com/Adebayobenjamin/numerisbook/pkg/common/exceptions"
+	"github.com/Adebayobenjamin/numerisbook/pkg/models"
+	repositories_interfaces "github.com/Adebayobenjamin/numerisbook/pkg/repositories/interfaces"
+	"github.com/Adebayobenjamin/numerisbook/pkg/tenant"
+	"github.com/jmoiron/sqlx"
+	"github.com/rs/zerolog"
+)
+
+type creditNoteRepository struct {
+	db     *sqlx.DB
+	logger *zerolog.Logger
+}
+
+// CreateCreditNote implements repositories_interfaces.CreditNoteRepository.
+func (c *creditNoteRepository) CreateCreditNote(ctx context.Context, note *models.CreditNote, invoiceVersion uint, settle repositories_interfaces.SettleCreditNote) (*models.CreditNote, error) {
+	tx, err := c.db.BeginTxx(ctx, nil)
+	if err != nil {
+		return nil, fmt.Errorf("failed to begin transaction: %w", err)
+	}
+	defer tx.Rollback()
+
+	note.CreditNoteNumber, err = allocateDocumentNumber(ctx, tx, note.CustomerID, models.DocumentTypeCreditNote, note.IssueDate)
+	if err != nil {
+		return nil, err
+	}
+
+	invoice, err := lockInvoice(ctx, tx, note.InvoiceID, note.CustomerID)
+	if err != nil {
+		return nil, err
+	}
+
+	// the amounts were worked out against the invoice at invoiceVersion; if
+	// another credit note or a payment got there first, nothing is written
+	// and the caller has to start again from the current invoice
+	if invoice.Version != invoiceVersion {
+		return nil, fmt.Errorf("invoice %w", exceptions.ErrVersionMismatch)
+	}
+
+	if invoice.CreditedTotal, err = invoice.CreditedTotal.Add(note.Total); err != nil {
+		return nil, fmt.Errorf("credit note currency does not match invoice: %w", err)
+	}
+
+	paid, err := sumInvoicePayments(ctx, tx, invoice)
+	if err != nil {
+		return nil, err
+	}
+
+	auditTrails, err := settle(invoice, paid, note)
+	if err != nil {
+		return nil, err
+	}
+
+	invoiceQuery := `
+		UPDATE invoices 
+		SET credited_total = ?, status = ?, is_fully_paid = ?, version = version + 1, updated_at = CURRENT_TIMESTAMP 
+		WHERE id = ? AND customer_id = ?`
+
+	_, err = tx.ExecContext(ctx, invoiceQuery, invoice.CreditedTotal, invoice.Status, invoice.IsFullyPaid, invoice.ID, invoice.CustomerID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to update invoice credited total: %w", err)
+	}
+
+	noteQuery := `
+		INSERT INTO credit_notes (
+			customer_id, invoice_id, credit_note_number, reason, issue_date, billing_currency,
+			net_total, tax_total, total, created_at, updated_at
+		) VALUES (?, ?, ?, ?, ?, ?, ?, ?, ?, CURRENT_TIMESTAMP, CURRENT_TIMESTAMP)`
+
+	noteResult, err := tx.ExecContext(ctx, noteQuery,
+		note.CustomerID,
+		note.InvoiceID,
+		note.CreditNoteNumber,
+		note.Reason,
+		note.IssueDate,
+		note.BillingCurrency,
+		note.NetTotal,
+		note.TaxTotal,
+		note.Total)
+	if err != nil {
+		return nil, fmt.Errorf("failed to create credit note: %w", err)
+	}
+
+	noteID, _ := noteResult.LastInsertId()
+
+	itemQuery := `
+		INSERT INTO credit_note_items (
+			credit_note_id, invoice_item_id, description, quantity, unit_price,
+			net_amount, tax_total, total, created_at, updated_at
+		) VALUES (?, ?, ?, ?, ?, ?, ?, ?, CURRENT_TIMESTAMP, CURRENT_TIMESTAMP)`
+
+	for _, item := range note.Items {
+		_, err = tx.ExecContext(ctx, itemQuery,
+			noteID,
+			item.InvoiceItemID,
+			item.Description,
+			item.Quantity,
+			item.UnitPrice,
+			item.NetAmount,
+			item.TaxTotal,
+			item.Total)
+		if err != nil {
+			return nil, fmt.Errorf("failed to create credit note item: %w", err)
+		}
+	}
+
+	if note.ClientCredit != nil {
+		if invoice.ClientID == nil {
+			return nil, fmt.Errorf("invoice %s has no client to credit", invoice.InvoiceNumber)
+		}
+		_, err = tx.ExecContext(ctx, `
+			INSERT INTO client_credits (
+				customer_id, client_id, kind, amount, currency, credit_note_id, invoice_id, description, created_at, updated_at
+			) VALUES (?, ?, ?, ?, ?, ?, ?, ?, CURRENT_TIMESTAMP, CURRENT_TIMESTAMP)`,
+			invoice.CustomerID, *invoice.ClientID, models.ClientCreditKindCreditNote, *note.ClientCredit, invoice.BillingCurrency, noteID, invoice.ID,
+			fmt.Sprintf("Credit Note %s for Invoice %s", note.CreditNoteNumber, invoice.InvoiceNumber))
+		if err != nil {
+			return nil, fmt.Errorf("failed to record client credit: %w", err)
+		}
+	}
+
+	for _, trail := range auditTrails {
+		if err := insertAuditTrail(ctx, tx, trail.EventType, trail.LogLevel, trail.Message, invoice.ID, invoice.CustomerID); err != nil {
+			return nil, err
+		}
+	}
+
+	if err := tx.Commit(); err != nil {
+		return nil, fmt.Errorf("failed to commit transaction: %w", err)
+	}
+
+	created, err := c.GetByIDAndCustomerID(ctx, uint(noteID), note.CustomerID)
+	if err != nil {
+		return nil, err
+	}
+	created.ClientCredit = note.ClientCredit
+
+	return created, nil
+}
+
+// GetByIDAndCustomerID implements repositories_interfaces.CreditNoteRepository.
+func (c *creditNoteRepository) GetByIDAndCustomerID(ctx context.Context, id uint, customerID uint) (*models.CreditNote, error) {
+	query := `
+		SELECT * FROM credit_notes 
+		WHERE id = ? AND customer_id = ? AND deleted_at IS NULL`
+
+	var note models.CreditNote
+	err := c.db.GetContext(ctx, &note, query, id, customerID)
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return nil, fmt.Errorf("credit note %w", exceptions.ErrNotFound)
+		}
+		return nil, fmt.Errorf("failed to get credit note: %w", err)
+	}
+
+	itemsQuery := `
+		SELECT * FROM credit_note_items 
+		WHERE credit_note_id = ? AND deleted_at IS NULL 
+		ORDER BY id ASC`
+
+	if err := c.db.SelectContext(ctx, &note.Items, itemsQuery, id); err != nil {
+		return nil, fmt.Errorf("failed to get credit note items: %w", err)
+	}
+
+	if err := note.AssignCurrency(); err != nil {
+		return nil, fmt.Errorf("failed to read credit note amounts: %w", err)
+	}
+
+	return &note, nil
+}
+
+// GetInvoiceCreditNotes implements repositories_interfaces.CreditNoteRepository.
+func (c *creditNoteRepository) GetInvoiceCreditNotes(ctx context.Context, invoiceID uint) ([]models.CreditNote, error) {
+	customerID, err := tenant.CustomerIDFromContext(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	query := `
+		SELECT * FROM credit_notes 
+		WHERE invoice_id = ? AND customer_id = ? AND deleted_at IS NULL 
+		ORDER BY issue_date ASC, id ASC`
+
+	return c.getMany(ctx, query, invoiceID, customerID)
+}
+
+// GetAllCustomerCreditNotes implements repositories_interfaces.CreditNoteRepository.
+func (c *creditNoteRepository) GetAllCustomerCreditNotes(ctx context.Context, customerID uint, limit int, offset int) ([]models.CreditNote, error) {
+	query := `
+		SELECT * FROM credit_notes 
+		WHERE customer_id = ? AND deleted_at IS NULL 
+		ORDER BY issue_date DESC, id DESC 
+		LIMIT ? OFFSET ?`
+
+	return c.getMany(ctx, query, customerID, limit, offset)
+}
+
+// GetCreditedQuantities implements repositories_interfaces.CreditNoteRepository.
+func (c *creditNoteRepository) GetCreditedQuantities(ctx context.Context, invoiceID uint) (map[uint]int, error) {
+	customerID, err := tenant.CustomerIDFromContext(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	query := `
+		SELECT cni.invoice_item_id, SUM(cni.quantity) as quantity
+		FROM credit_note_items cni
+		JOIN credit_notes cn ON cn.id = cni.credit_note_id
+		WHERE cn.invoice_id = ? AND cn.customer_id = ? AND cn.deleted_at IS NULL AND cni.deleted_at IS NULL
+		GROUP BY cni.invoice_item_id`
+
+	var rows []struct {
+		InvoiceItemID uint `db:"invoice_item_id"`
+		Quantity      int  `db:"quantity"`
+	}
+	if err := c.db.SelectContext(ctx, &rows, query, invoiceID, customerID); err != nil {
+		return nil, fmt.Errorf("failed to get credited quantities: %w", err)
+	}
+
+	credited := make(map[uint]int, len(rows))
+	for _, row := range rows {
+		credited[row.InvoiceItemID] = row.Quantity
+	}
+
+	return credited, nil
+}
+
+// getMany loads credit notes without their items, with their amounts tagged with their currency
+func (c *creditNoteRepository) getMany(ctx context.Context, query string, args ...any) ([]models.CreditNote, error) {
+	notes := []models.CreditNote{}
+	if err := c.db.SelectContext(ctx, &notes, query, args...); err != nil {
+		return nil, fmt.Errorf("failed to get credit notes: %w", err)
+	}
+
+	for idx := range notes {
+		if err := notes[idx].AssignCurrency(); err != nil {
+			return nil, fmt.Errorf("failed to read credit note amounts: %w", err)
+		}
+	}
+
+	return notes, nil
+}
+
+func NewCreditNoteRepository(
+	db *sqlx.DB,
+	logger *zerolog.Logger,
+) repositories_interfaces.CreditNoteRepository {
+	return &creditNoteRepository{
+		db:     db,
+		logger: logger,
+	}
+}
@@ -0,0 +1,171 @@
+package repositories
+
+import (
+	"context"
+	"fmt"
+	"regexp"
+	"testing"
+	"time"
+
+	"github.com/Adebayobenjamin/numerisbook/pkg/common/exceptions"
+	"github.com/Adebayobenjamin/numerisbook/pkg/common/money"
+	"github.com/Adebayobenjamin/numerisbook/pkg/models"
+	"github.com/Adebayobenjamin/numerisbook/pkg/tenant"
+	"github.com/DATA-DOG/go-sqlmock"
+	"github.com/rs/zerolog"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestCreditNoteRepository_CreateCreditNote(t *testing.T) {
+	db, mock, _ := getMockDB()
+	defer db.Close()
+
+	repo := &creditNoteRepository{db: db, logger: &zerolog.Logger{}}
+	issued := time.Date(2025, time.June, 2, 0, 0, 0, 0, time.UTC)
+	year := issued.Year()
+	columns := []string{"id", "customer_id", "document_type", "prefix", "format", "reset_policy", "next_value", "current_year", "created_at", "updated_at"}
+	invoiceColumns := []string{"id", "customer_id", "invoice_number", "billing_currency", "total_amount_due", "credited_total", "status", "version"}
+	expectNumber := func() {
+		mock.ExpectExec(regexp.QuoteMeta(`INSERT IGNORE INTO document_sequences`)).
+			WillReturnResult(sqlmock.NewResult(0, 0))
+		mock.ExpectQuery(regexp.QuoteMeta(`SELECT * FROM document_sequences`)).
+			WithArgs(uint(2), models.DocumentTypeCreditNote).
+			WillReturnRows(sqlmock.NewRows(columns).
+				AddRow(8, 2, "credit_note", "CN", "{PREFIX}-{YYYY}-{SEQ:05}", "yearly", 3, year, time.Now(), time.Now()))
+		mock.ExpectExec(regexp.QuoteMeta(`UPDATE document_sequences`)).
+			WillReturnResult(sqlmock.NewResult(0, 1))
+	}
+	note := func() *models.CreditNote {
+		return &models.CreditNote{
+			CustomerID:      2,
+			InvoiceID:       5,
+			IssueDate:       issued,
+			BillingCurrency: "USD",
+			Total:           money.New(5000, "USD"),
+		}
+	}
+
+	t.Run("the invoice's status and audit trail change with the note", func(t *testing.T) {
+		mock.ExpectBegin()
+		expectNumber()
+		mock.ExpectQuery(regexp.QuoteMeta(`FOR UPDATE`)).
+			WithArgs(uint(5), uint(2)).
+			WillReturnRows(sqlmock.NewRows(invoiceColumns).AddRow(5, 2, "INV-001", "USD", []byte("100.00"), []byte("0"), "partially_paid", 3))
+		mock.ExpectQuery(regexp.QuoteMeta(`SELECT COALESCE(SUM(amount), 0) FROM payments`)).
+			WithArgs(uint(5)).
+			WillReturnRows(sqlmock.NewRows([]string{"total"}).AddRow([]byte("50.00")))
+		mock.ExpectExec(regexp.QuoteMeta(`SET credited_total = ?, status = ?, is_fully_paid = ?, version = version + 1`)).
+			WithArgs(money.New(5000, "USD"), models.InvoiceStatusPaid, true, uint(5), uint(2)).
+			WillReturnResult(sqlmock.NewResult(0, 1))
+		mock.ExpectExec(regexp.QuoteMeta(`INSERT INTO credit_notes`)).
+			WithArgs(uint(2), uint(5), fmt.Sprintf("CN-%d-00003", year), "", issued, "USD", money.Money{}, money.Money{}, money.New(5000, "USD")).
+			WillReturnResult(sqlmock.NewResult(12, 1))
+		mock.ExpectExec(regexp.QuoteMeta(`INSERT INTO audit_trails`)).
+			WithArgs(models.EventTypeCreditNoteIssued, models.LogLevelInfo, "issued", uint(5), uint(2), sqlmock.AnyArg(), sqlmock.AnyArg(), sqlmock.AnyArg()).
+			WillReturnResult(sqlmock.NewResult(1, 1))
+		mock.ExpectCommit()
+		mock.ExpectQuery(regexp.QuoteMeta(`SELECT * FROM credit_notes`)).
+			WithArgs(uint(12), uint(2)).
+			WillReturnRows(sqlmock.NewRows([]string{"id", "customer_id", "invoice_id", "billing_currency", "total"}).AddRow(12, 2, 5, "USD", []byte("50.00")))
+		mock.ExpectQuery(regexp.QuoteMeta(`SELECT * FROM credit_note_items`)).
+			WithArgs(uint(12)).
+			WillReturnRows(sqlmock.NewRows([]string{"id"}))
+
+		created, err := repo.CreateCreditNote(context.Background(), note(), 3, func(invoice *models.Invoice, paid money.Money, note *models.CreditNote) ([]models.AuditTrail, error) {
+			assert.Equal(t, money.New(5000, "USD"), invoice.CreditedTotal)
+			assert.Equal(t, money.New(5000, "USD"), paid)
+			invoice.Status = models.InvoiceStatusPaid
+			invoice.IsFullyPaid = true
+			return []models.AuditTrail{{EventType: models.EventTypeCreditNoteIssued, LogLevel: models.LogLevelInfo, Message: "issued"}}, nil
+		})
+
+		assert.NoError(t, err)
+		assert.Equal(t, uint(12), created.ID)
+		assert.NoError(t, mock.ExpectationsWereMet())
+	})
+
+	t.Run("what was already paid is credited to the invoice's client", func(t *testing.T) {
+		clientColumns := append(invoiceColumns, "client_id")
+		mock.ExpectBegin()
+		expectNumber()
+		mock.ExpectQuery(regexp.QuoteMeta(`FOR UPDATE`)).
+			WithArgs(uint(5), uint(2)).
+			WillReturnRows(sqlmock.NewRows(clientColumns).AddRow(5, 2, "INV-001", "USD", []byte("100.00"), []byte("0"), "paid", 3, 9))
+		mock.ExpectQuery(regexp.QuoteMeta(`SELECT COALESCE(SUM(amount), 0) FROM payments`)).
+			WithArgs(uint(5)).
+			WillReturnRows(sqlmock.NewRows([]string{"total"}).AddRow([]byte("100.00")))
+		mock.ExpectExec(regexp.QuoteMeta(`SET credited_total = ?`)).
+			WillReturnResult(sqlmock.NewResult(0, 1))
+		mock.ExpectExec(regexp.QuoteMeta(`INSERT INTO credit_notes`)).
+			WillReturnResult(sqlmock.NewResult(12, 1))
+		mock.ExpectExec(regexp.QuoteMeta(`INSERT INTO client_credits`)).
+			WithArgs(uint(2), uint(9), models.ClientCreditKindCreditNote, money.New(5000, "USD"), "USD", int64(12), uint(5), fmt.Sprintf("Credit Note CN-%d-00003 for Invoice INV-001", year)).
+			WillReturnResult(sqlmock.NewResult(3, 1))
+		mock.ExpectCommit()
+		mock.ExpectQuery(regexp.QuoteMeta(`SELECT * FROM credit_notes`)).
+			WithArgs(uint(12), uint(2)).
+			WillReturnRows(sqlmock.NewRows([]string{"id", "customer_id", "invoice_id", "billing_currency", "total"}).AddRow(12, 2, 5, "USD", []byte("50.00")))
+		mock.ExpectQuery(regexp.QuoteMeta(`SELECT * FROM credit_note_items`)).
+			WithArgs(uint(12)).
+			WillReturnRows(sqlmock.NewRows([]string{"id"}))
+
+		created, err := repo.CreateCreditNote(context.Background(), note(), 3, func(invoice *models.Invoice, paid money.Money, note *models.CreditNote) ([]models.AuditTrail, error) {
+			credit := money.New(5000, "USD")
+			note.ClientCredit = &credit
+			return nil, nil
+		})
+
+		assert.NoError(t, err)
+		assert.Equal(t, money.New(5000, "USD"), *created.ClientCredit)
+		assert.NoError(t, mock.ExpectationsWereMet())
+	})
+
+	// the invoice changed after the credit note was worked out, so nothing
+	// is written and the number goes back to the sequence
+	t.Run("a changed invoice is a version mismatch", func(t *testing.T) {
+		mock.ExpectBegin()
+		expectNumber()
+		mock.ExpectQuery(regexp.QuoteMeta(`FOR UPDATE`)).
+			WithArgs(uint(5), uint(2)).
+			WillReturnRows(sqlmock.NewRows(invoiceColumns).AddRow(5, 2, "INV-001", "USD", []byte("100.00"), []byte("0"), "sent", 4))
+		mock.ExpectRollback()
+
+		created, err := repo.CreateCreditNote(context.Background(), note(), 3, func(*models.Invoice, money.Money, *models.CreditNote) ([]models.AuditTrail, error) {
+			t.Fatal("settle must not be called")
+			return nil, nil
+		})
+
+		assert.Nil(t, created)
+		assert.ErrorIs(t, err, exceptions.ErrVersionMismatch)
+		assert.NoError(t, mock.ExpectationsWereMet())
+	})
+}
+
+func TestCreditNoteRepository_GetCreditedQuantities(t *testing.T) {
+	db, mock, _ := getMockDB()
+	defer db.Close()
+
+	repo := &creditNoteRepository{db: db, logger: &zerolog.Logger{}}
+
+	t.Run("quantities are added up per invoice line", func(t *testing.T) {
+		mock.ExpectQuery(regexp.QuoteMeta(`WHERE cn.invoice_id = ? AND cn.customer_id = ? AND cn.deleted_at IS NULL AND cni.deleted_at IS NULL`)).
+			WithArgs(uint(5), uint(2)).
+			WillReturnRows(sqlmock.NewRows([]string{"invoice_item_id", "quantity"}).
+				AddRow(10, 2).
+				AddRow(11, 1))
+
+		credited, err := repo.GetCreditedQuantities(tenant.WithCustomerID(context.Background(), 2), 5)
+
+		assert.NoError(t, err)
+		assert.Equal(t, map[uint]int{10: 2, 11: 1}, credited)
+	})
+
+	t.Run("an unscoped context is refused before querying", func(t *testing.T) {
+		credited, err := repo.GetCreditedQuantities(context.Background(), 5)
+
+		assert.Nil(t, credited)
+		assert.ErrorIs(t, err, tenant.ErrMissingTenant)
+	})
+
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
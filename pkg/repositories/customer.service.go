@@ -5,6 +5,7 @@ import (
 	"database/sql"
 	"fmt"
 
+	"github.com/Adebayobenjamin/numerisbook/pkg/common/exceptions"
 	"github.com/Adebayobenjamin/numerisbook/pkg/models"
 	repositories_interfaces "github.com/Adebayobenjamin/numerisbook/pkg/repositories/interfaces"
 	"github.com/jmoiron/sqlx"
@@ -24,7 +25,7 @@ func (c *customerRepository) GetCustomerByID(ctx context.Context, customerID uin
 	err := c.db.GetContext(ctx, &customer, query, customerID)
 	if err != nil {
 		if err == sql.ErrNoRows {
-			return nil, fmt.Errorf("customer not found")
+			return nil, fmt.Errorf("customer %w", exceptions.ErrNotFound)
 		}
 		return nil, fmt.Errorf("failed to get customer: %w", err)
 	}
@@ -32,6 +33,21 @@ func (c *customerRepository) GetCustomerByID(ctx context.Context, customerID uin
 	return &customer, nil
 }
 
+// UpdateBranding implements repositories_interfaces.CustomerRepository.
+func (c *customerRepository) UpdateBranding(ctx context.Context, customer *models.Customer) error {
+	query := `
+		UPDATE customers 
+		SET brand_color = ?, logo_url = ?, email_footer = ?, updated_at = CURRENT_TIMESTAMP 
+		WHERE id = ? AND deleted_at IS NULL`
+
+	_, err := c.db.ExecContext(ctx, query, customer.BrandColor, customer.LogoURL, customer.EmailFooter, customer.ID)
+	if err != nil {
+		return fmt.Errorf("failed to update customer branding: %w", err)
+	}
+
+	return nil
+}
+
 func NewCustomerRepository(db *sqlx.DB) repositories_interfaces.CustomerRepository {
 	return &customerRepository{
 		db: db,
@@ -0,0 +1,210 @@
+package repositories
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+	"time"
+
+	"github.com/Adebayobenjamin/numerisbook/pkg/common/exceptions"
+	"github.com/Adebayobenjamin/numerisbook/pkg/helper"
+	"github.com/Adebayobenjamin/numerisbook/pkg/models"
+	repositories_interfaces "github.com/Adebayobenjamin/numerisbook/pkg/repositories/interfaces"
+	"github.com/jmoiron/sqlx"
+	"github.com/rs/zerolog"
+)
+
+// defaultDocumentNumberFormat is used for sequences a customer has not configured
+const defaultDocumentNumberFormat = "{PREFIX}-{YYYY}-{SEQ:05}"
+
+var defaultDocumentPrefixes = map[models.DocumentType]string{
+	models.DocumentTypeInvoice:    "INV",
+	models.DocumentTypeCreditNote: "CN",
+	models.DocumentTypeQuote:      "QT",
+}
+
+type documentSequenceRepository struct {
+	db     *sqlx.DB
+	logger *zerolog.Logger
+}
+
+// GetSequence implements repositories_interfaces.DocumentSequenceRepository.
+func (d *documentSequenceRepository) GetSequence(ctx context.Context, customerID uint, documentType models.DocumentType) (*models.DocumentSequence, error) {
+	query := `
+		SELECT * FROM document_sequences 
+		WHERE customer_id = ? AND document_type = ?`
+
+	var sequence models.DocumentSequence
+	err := d.db.GetContext(ctx, &sequence, query, customerID, documentType)
+	if err != nil {
+		if err == sql.ErrNoRows {
+			// sequences are created on first use, until then the defaults apply
+			return defaultDocumentSequence(customerID, documentType, time.Now()), nil
+		}
+		return nil, fmt.Errorf("failed to get document sequence: %w", err)
+	}
+
+	return &sequence, nil
+}
+
+// UpdateSequenceSettings implements repositories_interfaces.DocumentSequenceRepository.
+func (d *documentSequenceRepository) UpdateSequenceSettings(ctx context.Context, sequence *models.DocumentSequence) (*models.DocumentSequence, error) {
+	// only the template and reset policy can be changed, the counter itself is
+	// never rewound so numbers already handed out cannot be reused
+	query := `
+		INSERT INTO document_sequences (
+			customer_id, document_type, prefix, format, reset_policy,
+			next_value, current_year, created_at, updated_at
+		) VALUES (?, ?, ?, ?, ?, 1, ?, CURRENT_TIMESTAMP, CURRENT_TIMESTAMP)
+		ON DUPLICATE KEY UPDATE 
+			prefix = VALUES(prefix),
+			format = VALUES(format),
+			reset_policy = VALUES(reset_policy),
+			updated_at = CURRENT_TIMESTAMP`
+
+	_, err := d.db.ExecContext(ctx, query,
+		sequence.CustomerID,
+		sequence.DocumentType,
+		sequence.Prefix,
+		sequence.Format,
+		sequence.ResetPolicy,
+		time.Now().Year())
+	if err != nil {
+		return nil, fmt.Errorf("failed to update document sequence: %w", err)
+	}
+
+	return d.GetSequence(ctx, sequence.CustomerID, sequence.DocumentType)
+}
+
+// allocateDocumentNumber hands out the next number of a customer's sequence.
+// It must run inside the transaction that stores the document: the counter row
+// stays locked until that transaction ends, so concurrent documents are
+// numbered one after the other, and a rolled back document gives its number
+// back, which keeps the sequence free of gaps. The document's issue date, not
+// the clock, decides the year a yearly sequence counts in and the {YYYY} and
+// {YY} of the number.
+func allocateDocumentNumber(ctx context.Context, tx *sqlx.Tx, customerID uint, documentType models.DocumentType, issued time.Time) (string, error) {
+	defaults := defaultDocumentSequence(customerID, documentType, issued)
+
+	// create the counter on first use; IGNORE lets a concurrent first document win the insert
+	insertQuery := `
+		INSERT IGNORE INTO document_sequences (
+			customer_id, document_type, prefix, format, reset_policy,
+			next_value, current_year, created_at, updated_at
+		) VALUES (?, ?, ?, ?, ?, ?, ?, CURRENT_TIMESTAMP, CURRENT_TIMESTAMP)`
+
+	_, err := tx.ExecContext(ctx, insertQuery,
+		defaults.CustomerID,
+		defaults.DocumentType,
+		defaults.Prefix,
+		defaults.Format,
+		defaults.ResetPolicy,
+		defaults.NextValue,
+		defaults.CurrentYear)
+	if err != nil {
+		return "", fmt.Errorf("failed to create document sequence: %w", err)
+	}
+
+	selectQuery := `
+		SELECT * FROM document_sequences 
+		WHERE customer_id = ? AND document_type = ?
+		FOR UPDATE`
+
+	var sequence models.DocumentSequence
+	if err = tx.GetContext(ctx, &sequence, selectQuery, customerID, documentType); err != nil {
+		return "", fmt.Errorf("failed to lock document sequence: %w", err)
+	}
+
+	if sequence.ResetPolicy == models.SequenceResetPolicyYearly {
+		switch {
+		case issued.Year() > sequence.CurrentYear:
+			sequence.NextValue = 1
+			sequence.CurrentYear = issued.Year()
+		case issued.Year() < sequence.CurrentYear:
+			// the counter only remembers the current year, so an earlier year would reuse its numbers
+			return "", fmt.Errorf("%s numbers for %d %w, the sequence has moved on to %d",
+				documentType, issued.Year(), exceptions.ErrLocked, sequence.CurrentYear)
+		}
+	}
+
+	number, err := helper.FormatDocumentNumber(sequence.Format, sequence.Prefix, issued, sequence.NextValue)
+	if err != nil {
+		return "", fmt.Errorf("failed to format document number: %w", err)
+	}
+
+	updateQuery := `
+		UPDATE document_sequences 
+		SET next_value = ?, current_year = ?, updated_at = CURRENT_TIMESTAMP
+		WHERE id = ?`
+
+	_, err = tx.ExecContext(ctx, updateQuery, sequence.NextValue+1, sequence.CurrentYear, sequence.ID)
+	if err != nil {
+		return "", fmt.Errorf("failed to advance document sequence: %w", err)
+	}
+
+	return number, nil
+}
+
+// releaseDocumentNumber gives a deleted document's number back to its sequence.
+// Only the number the sequence handed out last can be given back; any other
+// would leave a gap, so deleting that document is refused.
+func releaseDocumentNumber(ctx context.Context, tx *sqlx.Tx, customerID uint, documentType models.DocumentType, number string, issued time.Time) error {
+	selectQuery := `
+		SELECT * FROM document_sequences 
+		WHERE customer_id = ? AND document_type = ?
+		FOR UPDATE`
+
+	var sequence models.DocumentSequence
+	if err := tx.GetContext(ctx, &sequence, selectQuery, customerID, documentType); err != nil {
+		return fmt.Errorf("failed to lock document sequence: %w", err)
+	}
+
+	latest := sequence.NextValue > 1
+	if sequence.ResetPolicy == models.SequenceResetPolicyYearly && issued.Year() != sequence.CurrentYear {
+		latest = false
+	}
+	if latest {
+		last, err := helper.FormatDocumentNumber(sequence.Format, sequence.Prefix, issued, sequence.NextValue-1)
+		if err != nil {
+			return fmt.Errorf("failed to format document number: %w", err)
+		}
+		latest = last == number
+	}
+	if !latest {
+		return fmt.Errorf("%s %s %w, only the latest number can be deleted without leaving a gap",
+			documentType, number, exceptions.ErrLocked)
+	}
+
+	updateQuery := `
+		UPDATE document_sequences 
+		SET next_value = next_value - 1, updated_at = CURRENT_TIMESTAMP
+		WHERE id = ?`
+
+	if _, err := tx.ExecContext(ctx, updateQuery, sequence.ID); err != nil {
+		return fmt.Errorf("failed to give back document number: %w", err)
+	}
+
+	return nil
+}
+
+func defaultDocumentSequence(customerID uint, documentType models.DocumentType, now time.Time) *models.DocumentSequence {
+	return &models.DocumentSequence{
+		CustomerID:   customerID,
+		DocumentType: documentType,
+		Prefix:       defaultDocumentPrefixes[documentType],
+		Format:       defaultDocumentNumberFormat,
+		ResetPolicy:  models.SequenceResetPolicyYearly,
+		NextValue:    1,
+		CurrentYear:  now.Year(),
+	}
+}
+
+func NewDocumentSequenceRepository(
+	db *sqlx.DB,
+	logger *zerolog.Logger,
+) repositories_interfaces.DocumentSequenceRepository {
+	return &documentSequenceRepository{
+		db:     db,
+		logger: logger,
+	}
+}
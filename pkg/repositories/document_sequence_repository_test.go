@@ -0,0 +1,115 @@
+package repositories
+
+import (
+	"context"
+	"fmt"
+	"regexp"
+	"testing"
+	"time"
+
+	"github.com/Adebayobenjamin/numerisbook/pkg/common/exceptions"
+	"github.com/Adebayobenjamin/numerisbook/pkg/models"
+	"github.com/DATA-DOG/go-sqlmock"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestAllocateDocumentNumber(t *testing.T) {
+	issued := time.Date(2025, time.March, 14, 0, 0, 0, 0, time.UTC)
+	year := issued.Year()
+	columns := []string{"id", "customer_id", "document_type", "prefix", "format", "reset_policy", "next_value", "current_year", "created_at", "updated_at"}
+
+	t.Run("hands out the locked counter and advances it", func(t *testing.T) {
+		db, mock, _ := getMockDB()
+		defer db.Close()
+
+		mock.ExpectBegin()
+		mock.ExpectExec(regexp.QuoteMeta(`INSERT IGNORE INTO document_sequences`)).
+			WithArgs(uint(1), models.DocumentTypeInvoice, "INV", defaultDocumentNumberFormat, models.SequenceResetPolicyYearly, uint64(1), year).
+			WillReturnResult(sqlmock.NewResult(0, 0))
+		mock.ExpectQuery(regexp.QuoteMeta(`SELECT * FROM document_sequences WHERE customer_id = ? AND document_type = ? FOR UPDATE`)).
+			WithArgs(uint(1), models.DocumentTypeInvoice).
+			WillReturnRows(sqlmock.NewRows(columns).
+				AddRow(7, 1, "invoice", "ACME", "{PREFIX}-{YYYY}-{SEQ:05}", "yearly", 42, year, time.Now(), time.Now()))
+		mock.ExpectExec(regexp.QuoteMeta(`UPDATE document_sequences SET next_value = ?, current_year = ?`)).
+			WithArgs(uint64(43), year, uint(7)).
+			WillReturnResult(sqlmock.NewResult(0, 1))
+		mock.ExpectCommit()
+
+		tx, _ := db.Beginx()
+		number, err := allocateDocumentNumber(context.Background(), tx, 1, models.DocumentTypeInvoice, issued)
+		assert.NoError(t, tx.Commit())
+
+		assert.NoError(t, err)
+		assert.Equal(t, fmt.Sprintf("ACME-%d-00042", year), number)
+		assert.NoError(t, mock.ExpectationsWereMet())
+	})
+
+	t.Run("yearly sequences restart in a new year", func(t *testing.T) {
+		db, mock, _ := getMockDB()
+		defer db.Close()
+
+		mock.ExpectBegin()
+		mock.ExpectExec(regexp.QuoteMeta(`INSERT IGNORE INTO document_sequences`)).
+			WillReturnResult(sqlmock.NewResult(0, 0))
+		mock.ExpectQuery(regexp.QuoteMeta(`SELECT * FROM document_sequences`)).
+			WillReturnRows(sqlmock.NewRows(columns).
+				AddRow(7, 1, "invoice", "INV", "{PREFIX}-{YY}-{SEQ}", "yearly", 318, year-1, time.Now(), time.Now()))
+		mock.ExpectExec(regexp.QuoteMeta(`UPDATE document_sequences`)).
+			WithArgs(uint64(2), year, uint(7)).
+			WillReturnResult(sqlmock.NewResult(0, 1))
+		mock.ExpectCommit()
+
+		tx, _ := db.Beginx()
+		number, err := allocateDocumentNumber(context.Background(), tx, 1, models.DocumentTypeInvoice, issued)
+		assert.NoError(t, tx.Commit())
+
+		assert.NoError(t, err)
+		assert.Equal(t, fmt.Sprintf("INV-%02d-1", year%100), number)
+		assert.NoError(t, mock.ExpectationsWereMet())
+	})
+
+	t.Run("yearly sequences refuse an issue date in a year they have left", func(t *testing.T) {
+		db, mock, _ := getMockDB()
+		defer db.Close()
+
+		mock.ExpectBegin()
+		mock.ExpectExec(regexp.QuoteMeta(`INSERT IGNORE INTO document_sequences`)).
+			WillReturnResult(sqlmock.NewResult(0, 0))
+		mock.ExpectQuery(regexp.QuoteMeta(`SELECT * FROM document_sequences`)).
+			WillReturnRows(sqlmock.NewRows(columns).
+				AddRow(7, 1, "invoice", "INV", "{PREFIX}-{YYYY}-{SEQ}", "yearly", 12, year+1, time.Now(), time.Now()))
+		mock.ExpectRollback()
+
+		tx, _ := db.Beginx()
+		number, err := allocateDocumentNumber(context.Background(), tx, 1, models.DocumentTypeInvoice, issued)
+		assert.NoError(t, tx.Rollback())
+
+		assert.ErrorIs(t, err, exceptions.ErrLocked)
+		assert.Empty(t, number)
+		assert.NoError(t, mock.ExpectationsWereMet())
+	})
+
+	t.Run("sequences that never reset keep counting", func(t *testing.T) {
+		db, mock, _ := getMockDB()
+		defer db.Close()
+
+		mock.ExpectBegin()
+		mock.ExpectExec(regexp.QuoteMeta(`INSERT IGNORE INTO document_sequences`)).
+			WillReturnResult(sqlmock.NewResult(0, 0))
+		mock.ExpectQuery(regexp.QuoteMeta(`SELECT * FROM document_sequences`)).
+			WillReturnRows(sqlmock.NewRows(columns).
+				AddRow(7, 1, "invoice", "INV", "{PREFIX}{SEQ:04}", "never", 318, year-1, time.Now(), time.Now()))
+		mock.ExpectExec(regexp.QuoteMeta(`UPDATE document_sequences`)).
+			WithArgs(uint64(319), year-1, uint(7)).
+			WillReturnResult(sqlmock.NewResult(0, 1))
+		mock.ExpectCommit()
+
+		tx, _ := db.Beginx()
+		number, err := allocateDocumentNumber(context.Background(), tx, 1, models.DocumentTypeInvoice, issued)
+		assert.NoError(t, tx.Commit())
+
+		assert.NoError(t, err)
+		assert.Equal(t, "INV0318", number)
+		assert.NoError(t, mock.ExpectationsWereMet())
+	})
+}
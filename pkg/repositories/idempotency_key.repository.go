@@ -0,0 +1,141 @@
+package repositories
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/Adebayobenjamin/numerisbook/pkg/common/exceptions"
+	"github.com/Adebayobenjamin/numerisbook/pkg/models"
+	repositories_interfaces "github.com/Adebayobenjamin/numerisbook/pkg/repositories/interfaces"
+	"github.com/Adebayobenjamin/numerisbook/pkg/tenant"
+	"github.com/jmoiron/sqlx"
+	"github.com/rs/zerolog"
+)
+
+type idempotencyKeyRepository struct {
+	db     *sqlx.DB
+	logger *zerolog.Logger
+}
+
+// Reserve implements repositories_interfaces.IdempotencyKeyRepository.
+func (i *idempotencyKeyRepository) Reserve(ctx context.Context, key *models.IdempotencyKey, now time.Time) (*models.IdempotencyKey, bool, error) {
+	customerID, err := tenant.CustomerIDFromContext(ctx)
+	if err != nil {
+		return nil, false, err
+	}
+
+	_, err = i.db.ExecContext(ctx, `
+		DELETE FROM idempotency_keys 
+		WHERE customer_id = ? AND idempotency_key = ? AND expires_at <= ?`,
+		customerID, key.Key, now)
+	if err != nil {
+		return nil, false, fmt.Errorf("failed to clear expired idempotency key: %w", err)
+	}
+
+	// a key whose request stopped holding it, most likely because the server
+	// died while running it, goes to the first retry of that same request
+	result, err := i.db.ExecContext(ctx, `
+		UPDATE idempotency_keys 
+		SET locked_until = ?, expires_at = ?, updated_at = CURRENT_TIMESTAMP 
+		WHERE customer_id = ? AND idempotency_key = ? AND request_hash = ? AND status = ? AND locked_until <= ?`,
+		key.LockedUntil, key.ExpiresAt, customerID, key.Key, key.RequestHash, models.IdempotencyKeyStatusProcessing, now)
+	if err != nil {
+		return nil, false, fmt.Errorf("failed to take over idempotency key: %w", err)
+	}
+
+	takenOver, err := result.RowsAffected()
+	if err != nil {
+		return nil, false, fmt.Errorf("failed to get affected rows: %w", err)
+	}
+
+	// the unique key on (customer_id, idempotency_key) lets only one of two
+	// concurrent requests with the same key reserve it
+	result, err = i.db.ExecContext(ctx, `
+		INSERT IGNORE INTO idempotency_keys (
+			customer_id, idempotency_key, request_hash, status, locked_until, response_headers, expires_at, created_at, updated_at
+		) VALUES (?, ?, ?, ?, ?, ?, ?, CURRENT_TIMESTAMP, CURRENT_TIMESTAMP)`,
+		customerID, key.Key, key.RequestHash, models.IdempotencyKeyStatusProcessing, key.LockedUntil, models.ResponseHeaders{}, key.ExpiresAt)
+	if err != nil {
+		return nil, false, fmt.Errorf("failed to reserve idempotency key: %w", err)
+	}
+
+	inserted, err := result.RowsAffected()
+	if err != nil {
+		return nil, false, fmt.Errorf("failed to get affected rows: %w", err)
+	}
+
+	var stored models.IdempotencyKey
+	err = i.db.GetContext(ctx, &stored, `
+		SELECT * FROM idempotency_keys 
+		WHERE customer_id = ? AND idempotency_key = ?`, customerID, key.Key)
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return nil, false, fmt.Errorf("idempotency key %w", exceptions.ErrNotFound)
+		}
+		return nil, false, fmt.Errorf("failed to get idempotency key: %w", err)
+	}
+
+	return &stored, takenOver+inserted == 1, nil
+}
+
+// Complete implements repositories_interfaces.IdempotencyKeyRepository.
+func (i *idempotencyKeyRepository) Complete(ctx context.Context, id uint, status int, headers models.ResponseHeaders, body []byte) error {
+	customerID, err := tenant.CustomerIDFromContext(ctx)
+	if err != nil {
+		return err
+	}
+
+	query := `
+		UPDATE idempotency_keys 
+		SET status = ?, locked_until = NULL, response_status = ?, response_headers = ?, response_body = ?, updated_at = CURRENT_TIMESTAMP 
+		WHERE id = ? AND customer_id = ? AND status = ?`
+
+	_, err = i.db.ExecContext(ctx, query, models.IdempotencyKeyStatusCompleted, status, headers, body, id, customerID, models.IdempotencyKeyStatusProcessing)
+	if err != nil {
+		return fmt.Errorf("failed to store idempotent response: %w", err)
+	}
+
+	return nil
+}
+
+// Release implements repositories_interfaces.IdempotencyKeyRepository.
+func (i *idempotencyKeyRepository) Release(ctx context.Context, id uint) error {
+	customerID, err := tenant.CustomerIDFromContext(ctx)
+	if err != nil {
+		return err
+	}
+
+	query := `DELETE FROM idempotency_keys WHERE id = ? AND customer_id = ? AND status = ?`
+
+	_, err = i.db.ExecContext(ctx, query, id, customerID, models.IdempotencyKeyStatusProcessing)
+	if err != nil {
+		return fmt.Errorf("failed to release idempotency key: %w", err)
+	}
+
+	return nil
+}
+
+// DeleteExpired implements repositories_interfaces.IdempotencyKeyRepository.
+func (i *idempotencyKeyRepository) DeleteExpired(ctx context.Context, now time.Time) (int64, error) {
+	query := `DELETE FROM idempotency_keys WHERE expires_at <= ?`
+
+	result, err := i.db.ExecContext(ctx, query, now)
+	if err != nil {
+		return 0, fmt.Errorf("failed to delete expired idempotency keys: %w", err)
+	}
+
+	return result.RowsAffected()
+}
+
+func NewIdempotencyKeyRepository(
+	db *sqlx.DB,
+	logger *zerolog.Logger,
+) repositories_interfaces.IdempotencyKeyRepository {
+	return &idempotencyKeyRepository{
+		db:     db,
+		logger: logger,
+	}
+}
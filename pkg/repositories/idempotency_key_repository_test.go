@@ -0,0 +1,91 @@
+package repositories
+
+import (
+	"context"
+	"database/sql/driver"
+	"regexp"
+	"testing"
+	"time"
+
+	"github.com/Adebayobenjamin/numerisbook/pkg/models"
+	"github.com/Adebayobenjamin/numerisbook/pkg/tenant"
+	"github.com/DATA-DOG/go-sqlmock"
+	"github.com/rs/zerolog"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestIdempotencyKeyRepository_Reserve(t *testing.T) {
+	db, mock, _ := getMockDB()
+	defer db.Close()
+
+	repo := &idempotencyKeyRepository{db: db, logger: &zerolog.Logger{}}
+	ctx := tenant.WithCustomerID(context.Background(), 4)
+	now := time.Date(2025, 3, 8, 12, 0, 0, 0, time.UTC)
+	lockedUntil := now.Add(5 * time.Minute)
+	key := &models.IdempotencyKey{Key: "key-1", RequestHash: "hash-1", LockedUntil: &lockedUntil, ExpiresAt: now.Add(24 * time.Hour)}
+	columns := []string{"id", "customer_id", "idempotency_key", "request_hash", "status", "response_status", "response_headers", "response_body", "expires_at"}
+
+	expectReserve := func(takenOver, inserted int64, row ...driver.Value) {
+		mock.ExpectExec(regexp.QuoteMeta(`WHERE customer_id = ? AND idempotency_key = ? AND expires_at <= ?`)).
+			WithArgs(uint(4), "key-1", now).
+			WillReturnResult(sqlmock.NewResult(0, 0))
+		mock.ExpectExec(regexp.QuoteMeta(`WHERE customer_id = ? AND idempotency_key = ? AND request_hash = ? AND status = ? AND locked_until <= ?`)).
+			WithArgs(key.LockedUntil, key.ExpiresAt, uint(4), "key-1", "hash-1", models.IdempotencyKeyStatusProcessing, now).
+			WillReturnResult(sqlmock.NewResult(0, takenOver))
+		mock.ExpectExec(regexp.QuoteMeta(`INSERT IGNORE INTO idempotency_keys`)).
+			WithArgs(uint(4), "key-1", "hash-1", models.IdempotencyKeyStatusProcessing, key.LockedUntil, sqlmock.AnyArg(), key.ExpiresAt).
+			WillReturnResult(sqlmock.NewResult(9, inserted))
+		mock.ExpectQuery(regexp.QuoteMeta(`SELECT * FROM idempotency_keys`)).
+			WithArgs(uint(4), "key-1").
+			WillReturnRows(sqlmock.NewRows(columns).AddRow(row...))
+	}
+
+	t.Run("unused key is reserved", func(t *testing.T) {
+		expectReserve(0, 1, 9, 4, "key-1", "hash-1", "processing", 0, []byte("{}"), nil, key.ExpiresAt)
+
+		record, reserved, err := repo.Reserve(ctx, key, now)
+
+		assert.NoError(t, err)
+		assert.True(t, reserved)
+		assert.Equal(t, models.IdempotencyKeyStatusProcessing, record.Status)
+	})
+
+	t.Run("a key whose lease ran out is taken over by a retry", func(t *testing.T) {
+		expectReserve(1, 0, 9, 4, "key-1", "hash-1", "processing", 0, []byte("{}"), nil, key.ExpiresAt)
+
+		record, reserved, err := repo.Reserve(ctx, key, now)
+
+		assert.NoError(t, err)
+		assert.True(t, reserved)
+		assert.Equal(t, uint(9), record.ID)
+	})
+
+	t.Run("a key still leased to its first request is not reserved", func(t *testing.T) {
+		expectReserve(0, 0, 9, 4, "key-1", "hash-1", "processing", 0, []byte("{}"), nil, key.ExpiresAt)
+
+		_, reserved, err := repo.Reserve(ctx, key, now)
+
+		assert.NoError(t, err)
+		assert.False(t, reserved)
+	})
+
+	t.Run("used key returns the kept response", func(t *testing.T) {
+		expectReserve(0, 0, 9, 4, "key-1", "hash-1", "completed", 201, []byte(`{"Etag":["\"1\""]}`), []byte(`{"id":1}`), key.ExpiresAt)
+
+		record, reserved, err := repo.Reserve(ctx, key, now)
+
+		assert.NoError(t, err)
+		assert.False(t, reserved)
+		assert.Equal(t, 201, record.ResponseStatus)
+		assert.Equal(t, models.ResponseHeaders{"Etag": {`"1"`}}, record.ResponseHeaders)
+		assert.Equal(t, []byte(`{"id":1}`), record.ResponseBody)
+	})
+
+	t.Run("unscoped context is refused", func(t *testing.T) {
+		_, _, err := repo.Reserve(context.Background(), key, now)
+
+		assert.ErrorIs(t, err, tenant.ErrMissingTenant)
+	})
+
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
@@ -0,0 +1,16 @@
+package repositories_interfaces
+
+import (
+	"context"
+	"time"
+
+	"github.com/Adebayobenjamin/numerisbook/pkg/models"
+)
+
+type APIKeyRepository interface {
+	Create(ctx context.Context, key *models.APIKey) (*models.APIKey, error)
+	GetByHash(ctx context.Context, keyHash string) (*models.APIKey, error)
+	GetAllCustomerKeys(ctx context.Context, customerID uint) ([]models.APIKey, error)
+	Revoke(ctx context.Context, id uint, customerID uint, revokedAt time.Time) error
+	TouchLastUsed(ctx context.Context, id uint, usedAt time.Time) error
+}
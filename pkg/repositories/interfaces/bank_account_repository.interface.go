@@ -0,0 +1,17 @@
+package repositories_interfaces
+
+import (
+	"context"
+
+	"github.com/Adebayobenjamin/numerisbook/pkg/models"
+)
+
+type BankAccountRepository interface {
+	Create(ctx context.Context, account *models.BankAccount) (*models.BankAccount, error)
+	GetByIDAndCustomerID(ctx context.Context, id uint, customerID uint) (*models.BankAccount, error)
+	GetDefault(ctx context.Context, customerID uint) (*models.BankAccount, error)
+	GetAllCustomerBankAccounts(ctx context.Context, customerID uint) ([]models.BankAccount, error)
+	Update(ctx context.Context, account *models.BankAccount) error
+	Delete(ctx context.Context, id uint, customerID uint) error
+	SetDefault(ctx context.Context, id uint, customerID uint) error
+}
@@ -0,0 +1,17 @@
+package repositories_interfaces
+
+import (
+	"context"
+
+	"github.com/Adebayobenjamin/numerisbook/pkg/models"
+)
+
+type BusinessProfileRepository interface {
+	Create(ctx context.Context, profile *models.BusinessProfile) (*models.BusinessProfile, error)
+	GetByIDAndCustomerID(ctx context.Context, id uint, customerID uint) (*models.BusinessProfile, error)
+	GetDefault(ctx context.Context, customerID uint) (*models.BusinessProfile, error)
+	GetAllCustomerBusinessProfiles(ctx context.Context, customerID uint) ([]models.BusinessProfile, error)
+	Update(ctx context.Context, profile *models.BusinessProfile) error
+	Delete(ctx context.Context, id uint, customerID uint) error
+	SetDefault(ctx context.Context, id uint, customerID uint) error
+}
@@ -0,0 +1,19 @@
+package repositories_interfaces
+
+import (
+	"context"
+	"time"
+
+	response_dto "github.com/Adebayobenjamin/numerisbook/pkg/dtos/response"
+	"github.com/Adebayobenjamin/numerisbook/pkg/models"
+)
+
+type CatalogItemRepository interface {
+	Create(ctx context.Context, item *models.CatalogItem) (*models.CatalogItem, error)
+	GetByIDAndCustomerID(ctx context.Context, id uint, customerID uint) (*models.CatalogItem, error)
+	GetBySKU(ctx context.Context, customerID uint, sku string) (*models.CatalogItem, error)
+	GetAllCustomerCatalogItems(ctx context.Context, customerID uint, search string, limit int, offset int) ([]models.CatalogItem, error)
+	Update(ctx context.Context, item *models.CatalogItem) error
+	Delete(ctx context.Context, id uint, customerID uint) error
+	GetRevenue(ctx context.Context, customerID uint, from *time.Time, to *time.Time) ([]response_dto.CatalogItemRevenue, error)
+}
@@ -0,0 +1,15 @@
+package repositories_interfaces
+
+import (
+	"context"
+
+	response_dto "github.com/Adebayobenjamin/numerisbook/pkg/dtos/response"
+	"github.com/Adebayobenjamin/numerisbook/pkg/models"
+)
+
+// ClientCreditRepository reads clients' credit ledgers. Entries are written
+// with the payments that make them, by the PaymentRepository.
+type ClientCreditRepository interface {
+	GetClientCredits(ctx context.Context, clientID uint, customerID uint, limit int, offset int) ([]models.ClientCredit, error)
+	GetClientBalances(ctx context.Context, clientID uint, customerID uint) ([]response_dto.ClientCreditBalance, error)
+}
@@ -0,0 +1,17 @@
+package repositories_interfaces
+
+import (
+	"context"
+
+	"github.com/Adebayobenjamin/numerisbook/pkg/models"
+)
+
+type ClientRepository interface {
+	Create(ctx context.Context, client *models.Client) (*models.Client, error)
+	GetByIDAndCustomerID(ctx context.Context, id uint, customerID uint) (*models.Client, error)
+	GetByEmail(ctx context.Context, customerID uint, email string) (*models.Client, error)
+	GetAllCustomerClients(ctx context.Context, customerID uint, search string, deleted bool, limit int, offset int) ([]models.Client, error)
+	Update(ctx context.Context, client *models.Client) error
+	Delete(ctx context.Context, id uint, customerID uint) error
+	Restore(ctx context.Context, id uint, customerID uint) error
+}
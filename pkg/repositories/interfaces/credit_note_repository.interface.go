@@ -0,0 +1,24 @@
+package repositories_interfaces
+
+import (
+	"context"
+
+	"github.com/Adebayobenjamin/numerisbook/pkg/common/money"
+	"github.com/Adebayobenjamin/numerisbook/pkg/models"
+)
+
+// SettleCreditNote is called with the invoice locked, its credited total
+// already including the note, and the total paid on it. It refuses the credit
+// note with an error, or sets the invoice's new status and returns the audit
+// trail entries to record with the note. It can set the note's ClientCredit
+// to credit it to the invoice's client.
+type SettleCreditNote func(invoice *models.Invoice, paid money.Money, note *models.CreditNote) ([]models.AuditTrail, error)
+
+type CreditNoteRepository interface {
+	// CreateCreditNote issues note against the invoice as it was at invoiceVersion
+	CreateCreditNote(ctx context.Context, note *models.CreditNote, invoiceVersion uint, settle SettleCreditNote) (*models.CreditNote, error)
+	GetByIDAndCustomerID(ctx context.Context, id uint, customerID uint) (*models.CreditNote, error)
+	GetInvoiceCreditNotes(ctx context.Context, invoiceID uint) ([]models.CreditNote, error)
+	GetAllCustomerCreditNotes(ctx context.Context, customerID uint, limit int, offset int) ([]models.CreditNote, error)
+	GetCreditedQuantities(ctx context.Context, invoiceID uint) (map[uint]int, error)
+}
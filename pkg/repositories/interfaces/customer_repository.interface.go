@@ -8,4 +8,5 @@ import (
 
 type CustomerRepository interface {
 	GetCustomerByID(ctx context.Context, customerID uint) (*models.Customer, error)
+	UpdateBranding(ctx context.Context, customer *models.Customer) error
 }
@@ -0,0 +1,12 @@
+package repositories_interfaces
+
+import (
+	"context"
+
+	"github.com/Adebayobenjamin/numerisbook/pkg/models"
+)
+
+type DocumentSequenceRepository interface {
+	GetSequence(ctx context.Context, customerID uint, documentType models.DocumentType) (*models.DocumentSequence, error)
+	UpdateSequenceSettings(ctx context.Context, sequence *models.DocumentSequence) (*models.DocumentSequence, error)
+}
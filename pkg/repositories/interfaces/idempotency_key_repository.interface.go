@@ -0,0 +1,20 @@
+package repositories_interfaces
+
+import (
+	"context"
+	"time"
+
+	"github.com/Adebayobenjamin/numerisbook/pkg/models"
+)
+
+type IdempotencyKeyRepository interface {
+	// Reserve stores key for the caller's customer unless the customer holds
+	// it already. It returns the stored key and whether it was reserved by
+	// this call. A key that expired before now is replaced, and one still
+	// processing whose lease ran out before now is taken over by the same
+	// request.
+	Reserve(ctx context.Context, key *models.IdempotencyKey, now time.Time) (*models.IdempotencyKey, bool, error)
+	Complete(ctx context.Context, id uint, status int, headers models.ResponseHeaders, body []byte) error
+	Release(ctx context.Context, id uint) error
+	DeleteExpired(ctx context.Context, now time.Time) (int64, error)
+}
@@ -2,6 +2,7 @@ package repositories_interfaces
 
 import (
 	"context"
+	"time"
 
 	response_dto "github.com/Adebayobenjamin/numerisbook/pkg/dtos/response"
 	"github.com/Adebayobenjamin/numerisbook/pkg/models"
@@ -9,11 +10,21 @@ import (
 
 type InvoiceRepository interface {
 	CreateInvoiceWithItems(ctx context.Context, invoice *models.Invoice) (*models.Invoice, error)
-	GetByIDAndCutomerID(ctx context.Context, id, customerID uint) (*models.Invoice, error)
-	UpdateShareableLink(ctx context.Context, invoiceID uint, link string) error
-	GetStatistics(ctx context.Context, customerID uint) (*response_dto.GetInvoiceStatisticsResponse, error)
+	GetByIDAndCutomerID(ctx context.Context, id uint) (*models.Invoice, error)
+	UpdateShareableLink(ctx context.Context, invoiceID uint, link *string) error
+	GetStatistics(ctx context.Context) (*response_dto.GetInvoiceStatisticsResponse, error)
 	GetDetails(ctx context.Context, invoiceID uint) (*response_dto.GetInvoiceDetailsResponse, error)
-	DuplicateInvoice(ctx context.Context, invoice *models.Invoice) (*models.Invoice, error)
-	GetAllCustomerInvoices(ctx context.Context, customerID uint, limit int, offset int) ([]models.Invoice, error)
-	UpdateInvoiceStatus(ctx context.Context, invoiceID uint, status models.InvoiceStatus) error
+	// DuplicateInvoice copies invoice into a new draft issued on issueDate and
+	// due on dueDate, numbered from the sequence for issueDate's year
+	DuplicateInvoice(ctx context.Context, invoice *models.Invoice, issueDate time.Time, dueDate time.Time) (*models.Invoice, error)
+	GetAllCustomerInvoices(ctx context.Context, limit int, offset int) ([]models.Invoice, error)
+	// GetPastDueInvoices returns, across customers, the sent and partially paid
+	// invoices whose due date is before now
+	GetPastDueInvoices(ctx context.Context, now time.Time, limit int) ([]models.Invoice, error)
+	// UpdateInvoiceStatus, UpdateDraftInvoice and DeleteDraftInvoice record auditTrails along with the change
+	UpdateInvoiceStatus(ctx context.Context, invoiceID uint, from models.InvoiceStatus, to models.InvoiceStatus, auditTrails []models.AuditTrail) error
+	UpdateDraftInvoice(ctx context.Context, invoice *models.Invoice, auditTrails []models.AuditTrail) (*models.Invoice, error)
+	// DeleteDraftInvoice gives the draft's number back to the sequence, so only
+	// the latest invoice number can be deleted
+	DeleteDraftInvoice(ctx context.Context, invoice *models.Invoice, version uint, auditTrails []models.AuditTrail) error
 }
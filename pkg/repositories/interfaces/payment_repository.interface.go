@@ -3,10 +3,37 @@ package repositories_interfaces
 import (
 	"context"
 
+	"github.com/Adebayobenjamin/numerisbook/pkg/common/money"
 	"github.com/Adebayobenjamin/numerisbook/pkg/models"
 )
 
+// SettlePayment is called with the invoice locked and the total already paid
+// on it. It refuses the payment with an error, or sets the invoice's new
+// status and returns the audit trail entries to record with the payment. It
+// can set the payment's Overpayment to credit it to the invoice's client.
+type SettlePayment func(invoice *models.Invoice, paid money.Money) ([]models.AuditTrail, error)
+
+// SettleReturn is called with the invoice locked, the total paid on it, the
+// payment money is being taken back from and what earlier refunds and
+// reversals already took back from it. It refuses the refund or reversal with
+// an error, or fills in its amount, sets the invoice's new status and returns
+// the audit trail entries to record with it.
+type SettleReturn func(invoice *models.Invoice, paid money.Money, original *models.Payment, returned money.Money) ([]models.AuditTrail, error)
+
+// SettleCredit is called with the invoice and the client's credit locked, the
+// total paid on the invoice and the client's credit available in its
+// currency. It refuses applying the credit with an error, or fills in the
+// amount applied, sets the invoice's new status and returns the audit trail
+// entries to record with it.
+type SettleCredit func(invoice *models.Invoice, paid money.Money, available money.Money) ([]models.AuditTrail, error)
+
 type PaymentRepository interface {
-	CreatePayment(ctx context.Context, payment *models.Payment) error
-	GetTotalInvoicePayments(ctx context.Context, invoiceID uint) (float64, error)
+	RecordPayment(ctx context.Context, payment *models.Payment, settle SettlePayment) (*models.Invoice, error)
+	// RecordReturn records a refund or reversal of the payment entry.OriginalPaymentID
+	RecordReturn(ctx context.Context, entry *models.Payment, settle SettleReturn) (*models.Invoice, error)
+	// ApplyCredit pays payment.InvoiceID out of the credit of the client it is billed to
+	ApplyCredit(ctx context.Context, clientID uint, payment *models.Payment, settle SettleCredit) (*models.Invoice, error)
+	GetTotalInvoicePayments(ctx context.Context, invoiceID uint) (money.Money, error)
+	GetByIDAndCustomerID(ctx context.Context, id uint, customerID uint) (*models.Payment, error)
+	GetAllCustomerPayments(ctx context.Context, customerID uint, method models.PaymentMethod, invoiceID uint, limit int, offset int) ([]models.Payment, error)
 }
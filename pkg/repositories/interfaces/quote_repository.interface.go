@@ -0,0 +1,20 @@
+package repositories_interfaces
+
+import (
+	"context"
+	"time"
+
+	"github.com/Adebayobenjamin/numerisbook/pkg/models"
+)
+
+type QuoteRepository interface {
+	Create(ctx context.Context, quote *models.Quote) (*models.Quote, error)
+	GetByIDAndCustomerID(ctx context.Context, id uint, customerID uint) (*models.Quote, error)
+	GetByShareTokenID(ctx context.Context, tokenID string) (*models.Quote, error)
+	GetAllCustomerQuotes(ctx context.Context, customerID uint, limit int, offset int) ([]models.Quote, error)
+	UpdateStatus(ctx context.Context, quote *models.Quote, from models.QuoteStatus) error
+	ClaimForConversion(ctx context.Context, id uint, customerID uint, claimedAt time.Time) error
+	ReleaseConversion(ctx context.Context, id uint, customerID uint) error
+	SetInvoice(ctx context.Context, id uint, customerID uint, invoiceID uint) error
+	ExpireLapsed(ctx context.Context, now time.Time) (int64, error)
+}
@@ -0,0 +1,16 @@
+package repositories_interfaces
+
+import (
+	"context"
+	"time"
+
+	"github.com/Adebayobenjamin/numerisbook/pkg/models"
+)
+
+type RecurringInvoiceRepository interface {
+	CreateProfile(ctx context.Context, profile *models.RecurringInvoiceProfile) (*models.RecurringInvoiceProfile, error)
+	GetByIDAndCustomerID(ctx context.Context, id uint, customerID uint) (*models.RecurringInvoiceProfile, error)
+	GetAllCustomerProfiles(ctx context.Context, customerID uint, limit int, offset int) ([]models.RecurringInvoiceProfile, error)
+	GetDueProfiles(ctx context.Context, now time.Time, limit int) ([]models.RecurringInvoiceProfile, error)
+	UpdateSchedule(ctx context.Context, profile *models.RecurringInvoiceProfile, expectedNextRunAt *time.Time) (bool, error)
+}
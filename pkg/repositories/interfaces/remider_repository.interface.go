@@ -2,10 +2,14 @@ package repositories_interfaces
 
 import (
 	"context"
+	"time"
 
 	"github.com/Adebayobenjamin/numerisbook/pkg/models"
 )
 
 type ReminderRepository interface {
 	UpsertReminders(ctx context.Context, reminders []models.InvoiceReminder) error
+	ClaimDueReminders(ctx context.Context, now time.Time, lease time.Duration, maxAttempts int, limit int) ([]models.InvoiceReminder, error)
+	MarkReminderSent(ctx context.Context, reminderID uint, sentAt time.Time) error
+	MarkReminderFailed(ctx context.Context, reminderID uint, lastError string, nextAttemptAt *time.Time) error
 }
@@ -0,0 +1,16 @@
+package repositories_interfaces
+
+import (
+	"context"
+	"time"
+
+	"github.com/Adebayobenjamin/numerisbook/pkg/models"
+)
+
+type ShareLinkRepository interface {
+	Create(ctx context.Context, link *models.InvoiceShareLink) (*models.InvoiceShareLink, error)
+	GetByTokenID(ctx context.Context, tokenID string) (*models.InvoiceShareLink, error)
+	GetActiveByInvoiceID(ctx context.Context, invoiceID uint, now time.Time) (*models.InvoiceShareLink, error)
+	RevokeByInvoiceID(ctx context.Context, invoiceID uint, revokedAt time.Time) (int64, error)
+	RecordView(ctx context.Context, id uint, viewedAt time.Time) error
+}
@@ -0,0 +1,17 @@
+package repositories_interfaces
+
+import (
+	"context"
+
+	"github.com/Adebayobenjamin/numerisbook/pkg/models"
+)
+
+type UserRepository interface {
+	Create(ctx context.Context, user *models.User) (*models.User, error)
+	GetByIDAndCustomerID(ctx context.Context, id uint, customerID uint) (*models.User, error)
+	GetBySubject(ctx context.Context, customerID uint, subject string) (*models.User, error)
+	GetAllCustomerUsers(ctx context.Context, customerID uint) ([]models.User, error)
+	CountOwners(ctx context.Context, customerID uint) (int, error)
+	UpdateRole(ctx context.Context, id uint, customerID uint, role models.UserRole) error
+	Delete(ctx context.Context, id uint, customerID uint) error
+}
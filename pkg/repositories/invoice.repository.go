@@ -4,10 +4,14 @@ import (
 	"context"
 	"database/sql"
 	"fmt"
+	"sort"
+	"time"
 
+	"github.com/Adebayobenjamin/numerisbook/pkg/common/exceptions"
 	response_dto "github.com/Adebayobenjamin/numerisbook/pkg/dtos/response"
 	"github.com/Adebayobenjamin/numerisbook/pkg/models"
 	repositories_interfaces "github.com/Adebayobenjamin/numerisbook/pkg/repositories/interfaces"
+	"github.com/Adebayobenjamin/numerisbook/pkg/tenant"
 	"github.com/jmoiron/sqlx"
 	"github.com/rs/zerolog"
 )
@@ -18,47 +22,108 @@ type invoiceRepository struct {
 }
 
 // UpdateInvoiceStatus implements repositories_interfaces.InvoiceRepository.
-func (i *invoiceRepository) UpdateInvoiceStatus(ctx context.Context, invoiceID uint, status models.InvoiceStatus) error {
+func (i *invoiceRepository) UpdateInvoiceStatus(ctx context.Context, invoiceID uint, from models.InvoiceStatus, to models.InvoiceStatus, auditTrails []models.AuditTrail) error {
+	customerID, err := tenant.CustomerIDFromContext(ctx)
+	if err != nil {
+		return err
+	}
+
+	tx, err := i.db.BeginTxx(ctx, nil)
+	if err != nil {
+		return fmt.Errorf("failed to begin transaction: %w", err)
+	}
+	defer tx.Rollback()
+
+	// the current status is part of the filter so two concurrent transitions
+	// cannot both succeed from the same starting point
 	query := `
 		UPDATE invoices 
-		SET status = ?, updated_at = CURRENT_TIMESTAMP
-		WHERE id = ? AND deleted_at IS NULL`
+		SET status = ?, version = version + 1, updated_at = CURRENT_TIMESTAMP
+		WHERE id = ? AND customer_id = ? AND status = ? AND deleted_at IS NULL`
 
-	_, err := i.db.ExecContext(ctx, query, status, invoiceID)
+	result, err := tx.ExecContext(ctx, query, to, invoiceID, customerID, from)
 	if err != nil {
 		return fmt.Errorf("failed to update invoice status: %w", err)
 	}
 
+	rows, err := result.RowsAffected()
+	if err != nil {
+		return fmt.Errorf("failed to get affected rows: %w", err)
+	}
+
+	if rows == 0 {
+		return fmt.Errorf("invoice status was changed by another request")
+	}
+
+	for _, trail := range auditTrails {
+		if err := insertAuditTrail(ctx, tx, trail.EventType, trail.LogLevel, trail.Message, invoiceID, customerID); err != nil {
+			return err
+		}
+	}
+
+	if err := tx.Commit(); err != nil {
+		return fmt.Errorf("failed to commit transaction: %w", err)
+	}
+
 	return nil
 }
 
 // CreateInvoiceWithItems implements repositories_interfaces.InvoiceRepository.
 func (i *invoiceRepository) CreateInvoiceWithItems(ctx context.Context, invoice *models.Invoice) (*models.Invoice, error) {
+	// the invoice is read back in the caller's scope once it is written, so an
+	// unscoped context is refused before anything is
+	if _, err := tenant.CustomerIDFromContext(ctx); err != nil {
+		return nil, err
+	}
+
 	tx, err := i.db.BeginTxx(ctx, nil)
 	if err != nil {
 		return nil, fmt.Errorf("failed to begin transaction: %w", err)
 	}
 	defer tx.Rollback()
 
+	invoice.InvoiceNumber, err = allocateDocumentNumber(ctx, tx, invoice.CustomerID, models.DocumentTypeInvoice, invoice.IssueDate)
+	if err != nil {
+		return nil, err
+	}
+
 	// Insert invoice first
 	invoiceQuery := `
 		INSERT INTO invoices (
-			invoice_number, customer_id, issue_date, due_date,
-			total_amount_due, subtotal, is_fully_paid, billing_currency,
-			discount, status, notes, created_at, updated_at
-		) VALUES (?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, CURRENT_TIMESTAMP, CURRENT_TIMESTAMP)`
+			invoice_number, customer_id,
+			business_profile_id, issuer_name, issuer_email, issuer_phone, issuer_address,
+			client_id, client_name, client_email, client_phone, client_address,
+			issue_date, due_date,
+			total_amount_due, subtotal, tax_total, is_fully_paid, billing_currency,
+			discount, discount_type, discount_rate, discount_total, status, notes,
+			created_at, updated_at
+		) VALUES (?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, CURRENT_TIMESTAMP, CURRENT_TIMESTAMP)`
 
 	invoiceResult, err := tx.ExecContext(ctx, invoiceQuery,
 		invoice.InvoiceNumber,
 		invoice.CustomerID,
+		invoice.BusinessProfileID,
+		invoice.InvoiceIssuer.Name,
+		invoice.InvoiceIssuer.Email,
+		invoice.InvoiceIssuer.Phone,
+		invoice.InvoiceIssuer.Address,
+		invoice.ClientID,
+		invoice.InvoiceClient.Name,
+		invoice.InvoiceClient.Email,
+		invoice.InvoiceClient.Phone,
+		invoice.InvoiceClient.Address,
 		invoice.IssueDate,
 		invoice.DueDate,
 		invoice.TotalAmountDue,
 		invoice.Subtotal,
+		invoice.TaxTotal,
 		invoice.IsFullyPaid,
 		invoice.BillingCurrency,
 		invoice.Discount,
-		models.InvoiceStatusPendingPayment,
+		invoice.DiscountType,
+		invoice.DiscountRate,
+		invoice.DiscountTotal,
+		invoice.Status,
 		invoice.Notes)
 	if err != nil {
 		return nil, fmt.Errorf("failed to create invoice: %w", err)
@@ -66,47 +131,19 @@ func (i *invoiceRepository) CreateInvoiceWithItems(ctx context.Context, invoice
 
 	invoiceID, _ := invoiceResult.LastInsertId()
 
-	// Insert sender
-	senderQuery := `
-		INSERT INTO senders (name, phone, address, email, invoice_id, created_at, updated_at)
-		VALUES (?, ?, ?, ?, ?, CURRENT_TIMESTAMP, CURRENT_TIMESTAMP)`
-	_, err = tx.ExecContext(ctx, senderQuery,
-		invoice.Sender.Name,
-		invoice.Sender.Phone,
-		invoice.Sender.Address,
-		invoice.Sender.Email,
-		invoiceID,
-	)
-	if err != nil {
-		return nil, fmt.Errorf("failed to create sender: %w", err)
-	}
-
 	// Insert invoice items
-	itemQuery := `
-		INSERT INTO invoice_items (
-			invoice_id, description, quantity, unit_price, total_price,
-			created_at, updated_at
-		) VALUES (?, ?, ?, ?, ?, CURRENT_TIMESTAMP, CURRENT_TIMESTAMP)`
-
-	for _, item := range invoice.Items {
-		_, err = tx.ExecContext(ctx, itemQuery,
-			invoiceID,
-			item.Description,
-			item.Quantity,
-			item.UnitPrice,
-			item.TotalPrice)
-		if err != nil {
-			return nil, fmt.Errorf("failed to create invoice item: %w", err)
-		}
+	if err = i.insertItems(ctx, tx, uint(invoiceID), invoice.Items); err != nil {
+		return nil, err
 	}
 
 	// Insert payment info
 	paymentInfoQuery := `
-		INSERT INTO payment_info (invoice_id, bank_name, account_number, account_name, ach_routing_no, bank_address, created_at, updated_at)
-		VALUES (?, ?, ?, ?, ?, ?, CURRENT_TIMESTAMP, CURRENT_TIMESTAMP)`
+		INSERT INTO payment_info (invoice_id, bank_account_id, bank_name, account_number, account_name, ach_routing_no, bank_address, created_at, updated_at)
+		VALUES (?, ?, ?, ?, ?, ?, ?, CURRENT_TIMESTAMP, CURRENT_TIMESTAMP)`
 
 	_, err = tx.ExecContext(ctx, paymentInfoQuery,
 		invoiceID,
+		invoice.PaymentInfo.BankAccountID,
 		invoice.PaymentInfo.BankName,
 		invoice.PaymentInfo.AccountNumber,
 		invoice.PaymentInfo.AccountName,
@@ -121,75 +158,252 @@ func (i *invoiceRepository) CreateInvoiceWithItems(ctx context.Context, invoice
 	}
 
 	// Fetch the created invoice
-	return i.GetByIDAndCutomerID(ctx, uint(invoiceID), invoice.CustomerID)
+	return i.GetByIDAndCutomerID(ctx, uint(invoiceID))
+}
+
+// UpdateDraftInvoice implements repositories_interfaces.InvoiceRepository.
+func (i *invoiceRepository) UpdateDraftInvoice(ctx context.Context, invoice *models.Invoice, auditTrails []models.AuditTrail) (*models.Invoice, error) {
+	customerID, err := tenant.CustomerIDFromContext(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	tx, err := i.db.BeginTxx(ctx, nil)
+	if err != nil {
+		return nil, fmt.Errorf("failed to begin transaction: %w", err)
+	}
+	defer tx.Rollback()
+
+	// reminders are set relative to the due date, so they move with it. This
+	// runs first, while the invoice still has its old due date.
+	reminderQuery := `
+		UPDATE invoice_reminders r
+		JOIN invoices i ON i.id = r.invoice_id
+		SET r.reminder_date = DATE_ADD(r.reminder_date, INTERVAL TIMESTAMPDIFF(SECOND, i.due_date, ?) SECOND)
+		WHERE r.invoice_id = ? AND i.customer_id = ? AND r.deleted_at IS NULL`
+
+	_, err = tx.ExecContext(ctx, reminderQuery, invoice.DueDate, invoice.ID, customerID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to move invoice reminders: %w", err)
+	}
+
+	// the version the caller read is part of the filter, so an edit made
+	// against an older copy of the invoice changes nothing
+	invoiceQuery := `
+		UPDATE invoices SET
+			business_profile_id = ?, issuer_name = ?, issuer_email = ?, issuer_phone = ?, issuer_address = ?,
+			client_id = ?, client_name = ?, client_email = ?, client_phone = ?, client_address = ?,
+			issue_date = ?, due_date = ?,
+			total_amount_due = ?, subtotal = ?, tax_total = ?, billing_currency = ?,
+			discount = ?, discount_type = ?, discount_rate = ?, discount_total = ?, notes = ?,
+			version = version + 1, updated_at = CURRENT_TIMESTAMP
+		WHERE id = ? AND customer_id = ? AND status = 'draft' AND version = ? AND deleted_at IS NULL`
+
+	result, err := tx.ExecContext(ctx, invoiceQuery,
+		invoice.BusinessProfileID,
+		invoice.InvoiceIssuer.Name,
+		invoice.InvoiceIssuer.Email,
+		invoice.InvoiceIssuer.Phone,
+		invoice.InvoiceIssuer.Address,
+		invoice.ClientID,
+		invoice.InvoiceClient.Name,
+		invoice.InvoiceClient.Email,
+		invoice.InvoiceClient.Phone,
+		invoice.InvoiceClient.Address,
+		invoice.IssueDate,
+		invoice.DueDate,
+		invoice.TotalAmountDue,
+		invoice.Subtotal,
+		invoice.TaxTotal,
+		invoice.BillingCurrency,
+		invoice.Discount,
+		invoice.DiscountType,
+		invoice.DiscountRate,
+		invoice.DiscountTotal,
+		invoice.Notes,
+		invoice.ID,
+		customerID,
+		invoice.Version)
+	if err != nil {
+		return nil, fmt.Errorf("failed to update invoice: %w", err)
+	}
+
+	if updated, _ := result.RowsAffected(); updated == 0 {
+		return nil, fmt.Errorf("invoice %w", exceptions.ErrVersionMismatch)
+	}
+
+	// the items are replaced as a whole, the old ones are kept soft deleted
+	_, err = tx.ExecContext(ctx, `
+		UPDATE invoice_item_taxes SET deleted_at = CURRENT_TIMESTAMP
+		WHERE invoice_id = ? AND deleted_at IS NULL`, invoice.ID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to remove invoice item taxes: %w", err)
+	}
+
+	_, err = tx.ExecContext(ctx, `
+		UPDATE invoice_items SET deleted_at = CURRENT_TIMESTAMP
+		WHERE invoice_id = ? AND deleted_at IS NULL`, invoice.ID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to remove invoice items: %w", err)
+	}
+
+	if err = i.insertItems(ctx, tx, invoice.ID, invoice.Items); err != nil {
+		return nil, err
+	}
+
+	paymentInfoQuery := `
+		UPDATE payment_info SET
+			bank_account_id = ?, bank_name = ?, account_number = ?, account_name = ?,
+			ach_routing_no = ?, bank_address = ?, updated_at = CURRENT_TIMESTAMP
+		WHERE invoice_id = ? AND deleted_at IS NULL`
+
+	_, err = tx.ExecContext(ctx, paymentInfoQuery,
+		invoice.PaymentInfo.BankAccountID,
+		invoice.PaymentInfo.BankName,
+		invoice.PaymentInfo.AccountNumber,
+		invoice.PaymentInfo.AccountName,
+		invoice.PaymentInfo.AchRoutingNo,
+		invoice.PaymentInfo.BankAddress,
+		invoice.ID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to update payment info: %w", err)
+	}
+
+	for _, trail := range auditTrails {
+		if err := insertAuditTrail(ctx, tx, trail.EventType, trail.LogLevel, trail.Message, invoice.ID, customerID); err != nil {
+			return nil, err
+		}
+	}
+
+	if err = tx.Commit(); err != nil {
+		return nil, fmt.Errorf("failed to commit transaction: %w", err)
+	}
+
+	return i.GetByIDAndCutomerID(ctx, invoice.ID)
+}
+
+// DeleteDraftInvoice implements repositories_interfaces.InvoiceRepository.
+func (i *invoiceRepository) DeleteDraftInvoice(ctx context.Context, invoice *models.Invoice, version uint, auditTrails []models.AuditTrail) error {
+	customerID, err := tenant.CustomerIDFromContext(ctx)
+	if err != nil {
+		return err
+	}
+
+	tx, err := i.db.BeginTxx(ctx, nil)
+	if err != nil {
+		return fmt.Errorf("failed to begin transaction: %w", err)
+	}
+	defer tx.Rollback()
+
+	// its items, payment info and reminders are only ever read through the
+	// invoice, so they go with it
+	query := `
+		UPDATE invoices 
+		SET deleted_at = CURRENT_TIMESTAMP, version = version + 1
+		WHERE id = ? AND customer_id = ? AND status = 'draft' AND version = ? AND deleted_at IS NULL`
+
+	result, err := tx.ExecContext(ctx, query, invoice.ID, customerID, version)
+	if err != nil {
+		return fmt.Errorf("failed to delete invoice: %w", err)
+	}
+
+	rows, err := result.RowsAffected()
+	if err != nil {
+		return fmt.Errorf("failed to get affected rows: %w", err)
+	}
+
+	if rows == 0 {
+		return fmt.Errorf("invoice %w", exceptions.ErrVersionMismatch)
+	}
+
+	// the version matched, so the number and issue date read with it are still the draft's
+	err = releaseDocumentNumber(ctx, tx, customerID, models.DocumentTypeInvoice, invoice.InvoiceNumber, invoice.IssueDate)
+	if err != nil {
+		return err
+	}
+
+	for _, trail := range auditTrails {
+		if err := insertAuditTrail(ctx, tx, trail.EventType, trail.LogLevel, trail.Message, invoice.ID, customerID); err != nil {
+			return err
+		}
+	}
+
+	if err := tx.Commit(); err != nil {
+		return fmt.Errorf("failed to commit transaction: %w", err)
+	}
+
+	return nil
 }
 
 // DuplicateInvoice implements repositories_interfaces.InvoiceRepository.
-func (i *invoiceRepository) DuplicateInvoice(ctx context.Context, invoice *models.Invoice) (*models.Invoice, error) {
+func (i *invoiceRepository) DuplicateInvoice(ctx context.Context, invoice *models.Invoice, issueDate time.Time, dueDate time.Time) (*models.Invoice, error) {
+	customerID, err := tenant.CustomerIDFromContext(ctx)
+	if err != nil {
+		return nil, err
+	}
+
 	tx, err := i.db.BeginTxx(ctx, nil)
 	if err != nil {
 		return nil, fmt.Errorf("failed to begin transaction: %w", err)
 	}
 	defer tx.Rollback()
 
+	invoiceNumber, err := allocateDocumentNumber(ctx, tx, customerID, models.DocumentTypeInvoice, issueDate)
+	if err != nil {
+		return nil, err
+	}
+
 	// Insert new invoice
 	invoiceQuery := `
 		INSERT INTO invoices (
-			invoice_number, customer_id, issue_date, due_date,
-			total_amount_due, subtotal, is_fully_paid, billing_currency,
-			discount, status, notes, created_at, updated_at
+			invoice_number, customer_id,
+			business_profile_id, issuer_name, issuer_email, issuer_phone, issuer_address,
+			client_id, client_name, client_email, client_phone, client_address,
+			issue_date, due_date,
+			total_amount_due, subtotal, tax_total, is_fully_paid, billing_currency,
+			discount, discount_type, discount_rate, discount_total, status, notes,
+			created_at, updated_at
 		)
 		SELECT 
-			CONCAT(invoice_number, '-copy'), customer_id, issue_date, due_date,
-			total_amount_due, subtotal, FALSE, billing_currency,
-			discount, 'draft', notes, CURRENT_TIMESTAMP, CURRENT_TIMESTAMP
+			?, customer_id,
+			business_profile_id, issuer_name, issuer_email, issuer_phone, issuer_address,
+			client_id, client_name, client_email, client_phone, client_address,
+			?, ?,
+			total_amount_due, subtotal, tax_total, FALSE, billing_currency,
+			discount, discount_type, discount_rate, discount_total, 'draft', notes,
+			CURRENT_TIMESTAMP, CURRENT_TIMESTAMP
 		FROM invoices 
-		WHERE id = ? AND deleted_at IS NULL`
+		WHERE id = ? AND customer_id = ? AND deleted_at IS NULL`
 
-	invoiceResult, err := tx.ExecContext(ctx, invoiceQuery, invoice.ID)
+	invoiceResult, err := tx.ExecContext(ctx, invoiceQuery, invoiceNumber, issueDate, dueDate, invoice.ID, customerID)
 	if err != nil {
 		return nil, fmt.Errorf("failed to duplicate invoice: %w", err)
 	}
 
-	newInvoiceID, _ := invoiceResult.LastInsertId()
+	if copied, _ := invoiceResult.RowsAffected(); copied == 0 {
+		return nil, fmt.Errorf("invoice %w", exceptions.ErrNotFound)
+	}
 
-	// Duplicate sender
-	senderQuery := `
-		INSERT INTO senders (name, phone, address, email, invoice_id, created_at, updated_at)
-		SELECT name, phone, address, email, ?, CURRENT_TIMESTAMP, CURRENT_TIMESTAMP
-		FROM senders
-		WHERE invoice_id = ?`
+	newInvoiceID, _ := invoiceResult.LastInsertId()
 
-	_, err = tx.ExecContext(ctx, senderQuery, newInvoiceID, invoice.ID)
+	// Duplicate invoice items together with their taxes
+	items, err := i.getItems(ctx, invoice.ID)
 	if err != nil {
-		return nil, fmt.Errorf("failed to duplicate sender: %w", err)
+		return nil, err
 	}
 
-	// Duplicate invoice items
-	itemsQuery := `
-		INSERT INTO invoice_items (
-			invoice_id, description, quantity, unit_price, total_price,
-			created_at, updated_at
-		)
-		SELECT 
-			?, description, quantity, unit_price, total_price,
-			CURRENT_TIMESTAMP, CURRENT_TIMESTAMP
-		FROM invoice_items
-		WHERE invoice_id = ? AND deleted_at IS NULL`
-
-	_, err = tx.ExecContext(ctx, itemsQuery, newInvoiceID, invoice.ID)
-	if err != nil {
+	if err = i.insertItems(ctx, tx, uint(newInvoiceID), items); err != nil {
 		return nil, fmt.Errorf("failed to duplicate invoice items: %w", err)
 	}
 
 	// Duplicate payment info
 	paymentInfoQuery := `
 		INSERT INTO payment_info (
-			invoice_id, bank_name, account_number, account_name,
+			invoice_id, bank_account_id, bank_name, account_number, account_name,
 			ach_routing_no, bank_address, created_at, updated_at
 		)
 		SELECT 
-			?, bank_name, account_number, account_name,
+			?, bank_account_id, bank_name, account_number, account_name,
 			ach_routing_no, bank_address, CURRENT_TIMESTAMP, CURRENT_TIMESTAMP
 		FROM payment_info
 		WHERE invoice_id = ? AND deleted_at IS NULL`
@@ -204,18 +418,25 @@ func (i *invoiceRepository) DuplicateInvoice(ctx context.Context, invoice *model
 	}
 
 	// Get the newly created invoice with all its relations
-	return i.GetByIDAndCutomerID(ctx, uint(newInvoiceID), invoice.CustomerID)
+	return i.GetByIDAndCutomerID(ctx, uint(newInvoiceID))
 }
 
 // GetAllCustomerInvoices implements repositories_interfaces.InvoiceRepository.
-func (i *invoiceRepository) GetAllCustomerInvoices(ctx context.Context, customerID uint, limit int, offset int) ([]models.Invoice, error) {
+func (i *invoiceRepository) GetAllCustomerInvoices(ctx context.Context, limit int, offset int) ([]models.Invoice, error) {
+	customerID, err := tenant.CustomerIDFromContext(ctx)
+	if err != nil {
+		return nil, err
+	}
+
 	query := `
 		SELECT 
+			id,
 			invoice_number,
 			issue_date,
 			due_date,
 			total_amount_due,
 			subtotal,
+			billing_currency,
 			status
 		FROM invoices
 		WHERE customer_id = ? AND deleted_at IS NULL
@@ -223,50 +444,73 @@ func (i *invoiceRepository) GetAllCustomerInvoices(ctx context.Context, customer
 		LIMIT ? OFFSET ?`
 
 	var invoices []models.Invoice
-	err := i.db.SelectContext(ctx, &invoices, query, customerID, limit, offset)
+	err = i.db.SelectContext(ctx, &invoices, query, customerID, limit, offset)
 	if err != nil {
 		return nil, fmt.Errorf("failed to get customer invoices: %w", err)
 	}
 
+	for idx := range invoices {
+		if err := invoices[idx].AssignCurrency(); err != nil {
+			return nil, fmt.Errorf("failed to read invoice amounts: %w", err)
+		}
+	}
+
+	return invoices, nil
+}
+
+// GetPastDueInvoices implements repositories_interfaces.InvoiceRepository.
+func (i *invoiceRepository) GetPastDueInvoices(ctx context.Context, now time.Time, limit int) ([]models.Invoice, error) {
+	query := `
+		SELECT id, customer_id, invoice_number, due_date, status
+		FROM invoices
+		WHERE status IN (?, ?) AND due_date < ? AND deleted_at IS NULL
+		ORDER BY due_date ASC
+		LIMIT ?`
+
+	var invoices []models.Invoice
+	err := i.db.SelectContext(ctx, &invoices, query, models.InvoiceStatusSent, models.InvoiceStatusPartiallyPaid, now, limit)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get past due invoices: %w", err)
+	}
+
 	return invoices, nil
 }
 
 // GetByIDAndCutomerID implements repositories_interfaces.InvoiceRepository.
-func (i *invoiceRepository) GetByIDAndCutomerID(ctx context.Context, id uint, customerID uint) (*models.Invoice, error) {
+func (i *invoiceRepository) GetByIDAndCutomerID(ctx context.Context, id uint) (*models.Invoice, error) {
+	customerID, err := tenant.CustomerIDFromContext(ctx)
+	if err != nil {
+		return nil, err
+	}
+
 	query := `
 		SELECT 
 			i.*,
-			s.id as "sender.id",
-			s.name as "sender.name",
-			s.phone as "sender.phone",
-			s.address as "sender.address",
-			s.email as "sender.email",
 			c.id as "customer.id",
 			c.name as "customer.name",
 			c.phone as "customer.phone",
 			c.address as "customer.address",
 			c.email as "customer.email"
 		FROM invoices i
-		LEFT JOIN senders s ON i.id = s.invoice_id
 		LEFT JOIN customers c ON i.customer_id = c.id
 		WHERE i.id = ? AND i.customer_id = ? AND i.deleted_at IS NULL`
 
 	var invoice models.Invoice
-	err := i.db.GetContext(ctx, &invoice, query, id, customerID)
+	err = i.db.GetContext(ctx, &invoice, query, id, customerID)
 	if err != nil {
 		if err == sql.ErrNoRows {
-			return nil, fmt.Errorf("invoice not found")
+			return nil, fmt.Errorf("invoice %w", exceptions.ErrNotFound)
 		}
 		return nil, fmt.Errorf("failed to get invoice: %w", err)
 	}
 
 	// Get invoice items
-	itemsQuery := `
-		SELECT * FROM invoice_items 
-		WHERE invoice_id = ? AND deleted_at IS NULL`
+	if invoice.Items, err = i.getItems(ctx, id); err != nil {
+		return nil, err
+	}
 
-	if err := i.db.SelectContext(ctx, &invoice.Items, itemsQuery, id); err != nil {
-		return nil, fmt.Errorf("failed to get invoice items: %w", err)
+	if err := invoice.AssignCurrency(); err != nil {
+		return nil, fmt.Errorf("failed to read invoice amounts: %w", err)
 	}
 
 	return &invoice, nil
@@ -274,47 +518,49 @@ func (i *invoiceRepository) GetByIDAndCutomerID(ctx context.Context, id uint, cu
 
 // GetDetails implements repositories_interfaces.InvoiceRepository.
 func (i *invoiceRepository) GetDetails(ctx context.Context, invoiceID uint) (*response_dto.GetInvoiceDetailsResponse, error) {
-	// Get main invoice details with sender, customer and payment info
+	customerID, err := tenant.CustomerIDFromContext(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	// Get main invoice details with the issuing account's branding and
+	// payment info, the issuer and client are read from the invoice's own
+	// columns
 	query := `
 		SELECT 
 			i.*,
-			s.id as "sender.id",
-			s.name as "sender.name",
-			s.phone as "sender.phone",
-			s.address as "sender.address",
-			s.email as "sender.email",
-			c.id as "customer.id",
-			c.name as "customer.name",
-			c.phone as "customer.phone",
-			c.address as "customer.address",
-			c.email as "customer.email",
+			c.id as "issuer.id",
+			i.issuer_name as "issuer.name",
+			i.issuer_phone as "issuer.phone",
+			i.issuer_address as "issuer.address",
+			i.issuer_email as "issuer.email",
+			c.brand_color as "issuer.brand_color",
+			c.logo_url as "issuer.logo_url",
+			c.email_footer as "issuer.email_footer",
 			p.id as "payment_information.id",
+			p.bank_account_id as "payment_information.bank_account_id",
 			p.bank_name as "payment_information.bank_name",
 			p.account_number as "payment_information.account_number",
 			p.account_name as "payment_information.account_name",
 			p.ach_routing_no as "payment_information.ach_routing_no",
 			p.bank_address as "payment_information.bank_address"
 		FROM invoices i
-		LEFT JOIN senders s ON i.id = s.invoice_id
 		LEFT JOIN customers c ON i.customer_id = c.id
 		LEFT JOIN payment_info p ON i.id = p.invoice_id
-		WHERE i.id = ? AND i.deleted_at IS NULL`
+		WHERE i.id = ? AND i.customer_id = ? AND i.deleted_at IS NULL`
 
 	details := &response_dto.GetInvoiceDetailsResponse{}
-	err := i.db.GetContext(ctx, details, query, invoiceID)
+	err = i.db.GetContext(ctx, details, query, invoiceID, customerID)
 	if err != nil {
 		if err == sql.ErrNoRows {
-			return nil, fmt.Errorf("invoice not found")
+			return nil, fmt.Errorf("invoice %w", exceptions.ErrNotFound)
 		}
 		return nil, fmt.Errorf("failed to get invoice details: %w", err)
 	}
 
 	// Get invoice items
-	itemsQuery := `
-		SELECT * FROM invoice_items 
-		WHERE invoice_id = ? AND deleted_at IS NULL`
-	if err := i.db.SelectContext(ctx, &details.Items, itemsQuery, invoiceID); err != nil {
-		return nil, fmt.Errorf("failed to get invoice items: %w", err)
+	if details.Items, err = i.getItems(ctx, invoiceID); err != nil {
+		return nil, err
 	}
 
 	// Get payments
@@ -335,41 +581,118 @@ func (i *invoiceRepository) GetDetails(ctx context.Context, invoiceID uint) (*re
 		return nil, fmt.Errorf("failed to get reminders: %w", err)
 	}
 
+	// Get credit notes
+	creditNotesQuery := `
+		SELECT * FROM credit_notes 
+		WHERE invoice_id = ? AND deleted_at IS NULL 
+		ORDER BY issue_date ASC, id ASC`
+	if err := i.db.SelectContext(ctx, &details.CreditNotes, creditNotesQuery, invoiceID); err != nil {
+		return nil, fmt.Errorf("failed to get credit notes: %w", err)
+	}
+
+	if err := details.AssignCurrency(); err != nil {
+		return nil, fmt.Errorf("failed to read invoice amounts: %w", err)
+	}
+
 	return details, nil
 }
 
 // GetStatistics implements repositories_interfaces.InvoiceRepository.
-func (i *invoiceRepository) GetStatistics(ctx context.Context, customerID uint) (*response_dto.GetInvoiceStatisticsResponse, error) {
-	query := `
+func (i *invoiceRepository) GetStatistics(ctx context.Context) (*response_dto.GetInvoiceStatisticsResponse, error) {
+	customerID, err := tenant.CustomerIDFromContext(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	countQuery := `
 		SELECT
 			SUM(CASE WHEN status = 'paid' THEN 1 ELSE 0 END) as total_paid,
-			SUM(CASE WHEN status = 'paid' THEN total_amount_due ELSE 0 END) as total_paid_amount,
-			SUM(CASE WHEN status != 'paid' AND due_date < CURRENT_TIMESTAMP THEN 1 ELSE 0 END) as total_over_due,
-			SUM(CASE WHEN status != 'paid' AND due_date < CURRENT_TIMESTAMP THEN total_amount_due ELSE 0 END) as total_over_due_amount,
+			SUM(CASE WHEN status IN ('sent', 'partially_paid', 'overdue') AND due_date < CURRENT_TIMESTAMP THEN 1 ELSE 0 END) as total_over_due,
 			SUM(CASE WHEN status = 'draft' THEN 1 ELSE 0 END) as total_draft,
-			SUM(CASE WHEN status = 'draft' THEN total_amount_due ELSE 0 END) as total_draft_amount,
-			SUM(CASE WHEN status = 'pending payment' THEN 1 ELSE 0 END) as total_unpaid,
-			SUM(CASE WHEN status = 'pending payment' THEN total_amount_due ELSE 0 END) as total_unpaid_amount
+			SUM(CASE WHEN status IN ('sent', 'partially_paid', 'overdue') THEN 1 ELSE 0 END) as total_unpaid,
+			(SELECT COUNT(*) FROM credit_notes WHERE customer_id = ? AND deleted_at IS NULL) as total_credit_notes
 		FROM invoices
 		WHERE customer_id = ? AND deleted_at IS NULL`
 
 	stats := &response_dto.GetInvoiceStatisticsResponse{}
-	err := i.db.GetContext(ctx, stats, query, customerID)
+	err = i.db.GetContext(ctx, stats, countQuery, customerID, customerID)
 	if err != nil {
 		return nil, fmt.Errorf("failed to get invoice statistics: %w", err)
 	}
 
+	// amounts of issued invoices are what they ask to be paid, after their
+	// credit notes are taken off
+	amountQuery := `
+		SELECT
+			billing_currency as currency,
+			SUM(CASE WHEN status = 'paid' THEN total_amount_due - credited_total ELSE 0 END) as total_paid_amount,
+			SUM(CASE WHEN status IN ('sent', 'partially_paid', 'overdue') AND due_date < CURRENT_TIMESTAMP THEN total_amount_due - credited_total ELSE 0 END) as total_over_due_amount,
+			SUM(CASE WHEN status = 'draft' THEN total_amount_due ELSE 0 END) as total_draft_amount,
+			SUM(CASE WHEN status IN ('sent', 'partially_paid', 'overdue') THEN total_amount_due - credited_total ELSE 0 END) as total_unpaid_amount,
+			SUM(credited_total) as total_credited_amount
+		FROM invoices
+		WHERE customer_id = ? AND deleted_at IS NULL
+		GROUP BY billing_currency
+		ORDER BY billing_currency`
+
+	stats.Currencies = []response_dto.InvoiceCurrencyStatistics{}
+	if err = i.db.SelectContext(ctx, &stats.Currencies, amountQuery, customerID); err != nil {
+		return nil, fmt.Errorf("failed to get invoice statistics: %w", err)
+	}
+
+	creditQuery := `
+		SELECT currency, SUM(amount) as total_client_credit
+		FROM client_credits
+		WHERE customer_id = ?
+		GROUP BY currency
+		HAVING SUM(amount) <> 0
+		ORDER BY currency`
+
+	var credits []response_dto.InvoiceCurrencyStatistics
+	if err = i.db.SelectContext(ctx, &credits, creditQuery, customerID); err != nil {
+		return nil, fmt.Errorf("failed to get client credit statistics: %w", err)
+	}
+
+	// credit can be held in a currency no invoice is billed in any more
+	for _, credit := range credits {
+		found := false
+		for j := range stats.Currencies {
+			if stats.Currencies[j].Currency == credit.Currency {
+				stats.Currencies[j].TotalClientCredit = credit.TotalClientCredit
+				found = true
+				break
+			}
+		}
+		if !found {
+			stats.Currencies = append(stats.Currencies, credit)
+		}
+	}
+	sort.Slice(stats.Currencies, func(a, b int) bool {
+		return stats.Currencies[a].Currency < stats.Currencies[b].Currency
+	})
+
+	for j := range stats.Currencies {
+		if err := stats.Currencies[j].AssignCurrency(); err != nil {
+			return nil, fmt.Errorf("failed to read invoice statistics: %w", err)
+		}
+	}
+
 	return stats, nil
 }
 
 // UpdateShareableLink implements repositories_interfaces.InvoiceRepository.
-func (i *invoiceRepository) UpdateShareableLink(ctx context.Context, invoiceID uint, link string) error {
+func (i *invoiceRepository) UpdateShareableLink(ctx context.Context, invoiceID uint, link *string) error {
+	customerID, err := tenant.CustomerIDFromContext(ctx)
+	if err != nil {
+		return err
+	}
+
 	query := `
 		UPDATE invoices 
 		SET shareable_link = ?, updated_at = CURRENT_TIMESTAMP
-		WHERE id = ? AND deleted_at IS NULL`
+		WHERE id = ? AND customer_id = ? AND deleted_at IS NULL`
 
-	result, err := i.db.ExecContext(ctx, query, link, invoiceID)
+	result, err := i.db.ExecContext(ctx, query, link, invoiceID, customerID)
 	if err != nil {
 		return fmt.Errorf("failed to update shareable link: %w", err)
 	}
@@ -380,12 +703,100 @@ func (i *invoiceRepository) UpdateShareableLink(ctx context.Context, invoiceID u
 	}
 
 	if rows == 0 {
-		return fmt.Errorf("invoice not found")
+		return fmt.Errorf("invoice %w", exceptions.ErrNotFound)
 	}
 
 	return nil
 }
 
+// insertItems writes the items of an invoice, with their discounts and the taxes charged on each of them
+func (i *invoiceRepository) insertItems(ctx context.Context, tx *sqlx.Tx, invoiceID uint, items []models.InvoiceItem) error {
+	itemQuery := `
+		INSERT INTO invoice_items (
+			invoice_id, catalog_item_id, description, quantity, unit, unit_price, discount_type,
+			discount_rate, discount_amount, invoice_discount_amount, net_amount,
+			tax_total, total_price, created_at, updated_at
+		) VALUES (?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, CURRENT_TIMESTAMP, CURRENT_TIMESTAMP)`
+
+	taxQuery := `
+		INSERT INTO invoice_item_taxes (
+			invoice_item_id, invoice_id, name, rate, is_inclusive, is_compound,
+			taxable_amount, amount, created_at, updated_at
+		) VALUES (?, ?, ?, ?, ?, ?, ?, ?, CURRENT_TIMESTAMP, CURRENT_TIMESTAMP)`
+
+	for _, item := range items {
+		itemResult, err := tx.ExecContext(ctx, itemQuery,
+			invoiceID,
+			item.CatalogItemID,
+			item.Description,
+			item.Quantity,
+			item.Unit,
+			item.UnitPrice,
+			item.DiscountType,
+			item.DiscountRate,
+			item.DiscountAmount,
+			item.InvoiceDiscountAmount,
+			item.NetAmount,
+			item.TaxTotal,
+			item.TotalPrice)
+		if err != nil {
+			return fmt.Errorf("failed to create invoice item: %w", err)
+		}
+
+		itemID, _ := itemResult.LastInsertId()
+		for _, tax := range item.Taxes {
+			_, err = tx.ExecContext(ctx, taxQuery,
+				itemID,
+				invoiceID,
+				tax.Name,
+				tax.Rate,
+				tax.IsInclusive,
+				tax.IsCompound,
+				tax.TaxableAmount,
+				tax.Amount)
+			if err != nil {
+				return fmt.Errorf("failed to create invoice item tax: %w", err)
+			}
+		}
+	}
+
+	return nil
+}
+
+// getItems loads the items of an invoice with the taxes charged on each of them
+func (i *invoiceRepository) getItems(ctx context.Context, invoiceID uint) ([]models.InvoiceItem, error) {
+	itemsQuery := `
+		SELECT * FROM invoice_items 
+		WHERE invoice_id = ? AND deleted_at IS NULL
+		ORDER BY id ASC`
+
+	var items []models.InvoiceItem
+	if err := i.db.SelectContext(ctx, &items, itemsQuery, invoiceID); err != nil {
+		return nil, fmt.Errorf("failed to get invoice items: %w", err)
+	}
+
+	taxesQuery := `
+		SELECT * FROM invoice_item_taxes 
+		WHERE invoice_id = ? AND deleted_at IS NULL
+		ORDER BY id ASC`
+
+	var taxes []models.InvoiceItemTax
+	if err := i.db.SelectContext(ctx, &taxes, taxesQuery, invoiceID); err != nil {
+		return nil, fmt.Errorf("failed to get invoice item taxes: %w", err)
+	}
+
+	for _, tax := range taxes {
+		for idx := range items {
+			if items[idx].ID == tax.InvoiceItemID {
+				items[idx].Taxes = append(items[idx].Taxes, tax)
+				break
+			}
+		}
+	}
+
+	return items, nil
+}
+
 func NewInvoiceRepository(
 	db *sqlx.DB,
 	logger *zerolog.Logger,
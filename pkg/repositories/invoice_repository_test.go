@@ -0,0 +1,534 @@
+package repositories
+
+import (
+	"context"
+	"database/sql/driver"
+	"errors"
+	"fmt"
+	"regexp"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/Adebayobenjamin/numerisbook/pkg/common/exceptions"
+	"github.com/Adebayobenjamin/numerisbook/pkg/common/money"
+	response_dto "github.com/Adebayobenjamin/numerisbook/pkg/dtos/response"
+	"github.com/Adebayobenjamin/numerisbook/pkg/models"
+	"github.com/Adebayobenjamin/numerisbook/pkg/tenant"
+	"github.com/DATA-DOG/go-sqlmock"
+	"github.com/jmoiron/sqlx"
+	"github.com/rs/zerolog"
+	"github.com/stretchr/testify/assert"
+)
+
+// insertColumnsPattern splits an INSERT into its column and value lists
+var insertColumnsPattern = regexp.MustCompile(`(?s)INSERT INTO \w+ \((.*?)\)\s*VALUES\s*\((.*)\)`)
+
+// splitSQLList splits a comma separated column or value list, trimming each entry
+func splitSQLList(list string) []string {
+	entries := strings.Split(list, ",")
+	for i := range entries {
+		entries[i] = strings.TrimSpace(entries[i])
+	}
+	return entries
+}
+
+// insertColumnsMatcher matches queries like sqlmock's default matcher, and
+// also refuses INSERTs naming a different number of columns than values
+var insertColumnsMatcher = sqlmock.QueryMatcherFunc(func(expectedSQL, actualSQL string) error {
+	if parts := insertColumnsPattern.FindStringSubmatch(actualSQL); parts != nil {
+		columns, values := splitSQLList(parts[1]), splitSQLList(parts[2])
+		if len(columns) != len(values) {
+			return fmt.Errorf("insert names %d columns for %d values", len(columns), len(values))
+		}
+	}
+	return sqlmock.QueryMatcherRegexp.Match(expectedSQL, actualSQL)
+})
+
+func TestInvoiceRepository_GetDetails(t *testing.T) {
+	db, mock, _ := getMockDB()
+	defer db.Close()
+
+	repo := &invoiceRepository{db: db, logger: &zerolog.Logger{}}
+
+	t.Run("another customer's invoice is reported as not found", func(t *testing.T) {
+		mock.ExpectQuery(regexp.QuoteMeta(`WHERE i.id = ? AND i.customer_id = ? AND i.deleted_at IS NULL`)).
+			WithArgs(uint(1), uint(2)).
+			WillReturnRows(sqlmock.NewRows([]string{"id"}))
+
+		details, err := repo.GetDetails(tenant.WithCustomerID(context.Background(), 2), 1)
+
+		assert.Nil(t, details)
+		assert.ErrorIs(t, err, exceptions.ErrNotFound)
+	})
+
+	t.Run("an unscoped context is refused before querying", func(t *testing.T) {
+		details, err := repo.GetDetails(context.Background(), 1)
+
+		assert.Nil(t, details)
+		assert.ErrorIs(t, err, tenant.ErrMissingTenant)
+	})
+
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
+
+func TestInvoiceRepository_GetByIDAndCutomerID(t *testing.T) {
+	db, mock, _ := getMockDB()
+	defer db.Close()
+
+	repo := &invoiceRepository{db: db, logger: &zerolog.Logger{}}
+
+	t.Run("another customer's invoice is reported as not found", func(t *testing.T) {
+		mock.ExpectQuery(regexp.QuoteMeta(`WHERE i.id = ? AND i.customer_id = ? AND i.deleted_at IS NULL`)).
+			WithArgs(uint(1), uint(2)).
+			WillReturnRows(sqlmock.NewRows([]string{"id"}))
+
+		invoice, err := repo.GetByIDAndCutomerID(tenant.WithCustomerID(context.Background(), 2), 1)
+
+		assert.Nil(t, invoice)
+		assert.ErrorIs(t, err, exceptions.ErrNotFound)
+	})
+
+	t.Run("an unscoped context is refused before querying", func(t *testing.T) {
+		invoice, err := repo.GetByIDAndCutomerID(context.Background(), 1)
+
+		assert.Nil(t, invoice)
+		assert.ErrorIs(t, err, tenant.ErrMissingTenant)
+	})
+
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
+
+func TestInvoiceRepository_GetAllCustomerInvoices(t *testing.T) {
+	db, mock, _ := getMockDB()
+	defer db.Close()
+
+	repo := &invoiceRepository{db: db, logger: &zerolog.Logger{}}
+
+	t.Run("only the caller's customer's invoices are listed", func(t *testing.T) {
+		mock.ExpectQuery(regexp.QuoteMeta(`WHERE customer_id = ? AND deleted_at IS NULL`)).
+			WithArgs(uint(2), 10, 20).
+			WillReturnRows(sqlmock.NewRows([]string{"id", "invoice_number", "billing_currency", "status"}).
+				AddRow(7, "INV-7", "USD", "sent"))
+
+		invoices, err := repo.GetAllCustomerInvoices(tenant.WithCustomerID(context.Background(), 2), 10, 20)
+
+		assert.NoError(t, err)
+		assert.Len(t, invoices, 1)
+	})
+
+	t.Run("an unscoped context is refused before querying", func(t *testing.T) {
+		invoices, err := repo.GetAllCustomerInvoices(context.Background(), 10, 0)
+
+		assert.Nil(t, invoices)
+		assert.ErrorIs(t, err, tenant.ErrMissingTenant)
+	})
+
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
+
+func TestInvoiceRepository_UpdateInvoiceStatus(t *testing.T) {
+	db, mock, _ := getMockDB()
+	defer db.Close()
+
+	repo := &invoiceRepository{db: db, logger: &zerolog.Logger{}}
+	ctx := tenant.WithCustomerID(context.Background(), 2)
+
+	voided := []models.AuditTrail{{EventType: models.EventTypeInvoiceStatusChanged, LogLevel: models.LogLevelInfo, Message: "Invoice INV-1 moved from sent to void"}}
+
+	t.Run("the status and its audit trail change together for the caller's customer", func(t *testing.T) {
+		mock.ExpectBegin()
+		mock.ExpectExec(regexp.QuoteMeta(`WHERE id = ? AND customer_id = ? AND status = ? AND deleted_at IS NULL`)).
+			WithArgs(models.InvoiceStatusVoid, uint(1), uint(2), models.InvoiceStatusSent).
+			WillReturnResult(sqlmock.NewResult(0, 1))
+		mock.ExpectExec(regexp.QuoteMeta(`INSERT INTO audit_trails`)).
+			WithArgs(models.EventTypeInvoiceStatusChanged, models.LogLevelInfo, "Invoice INV-1 moved from sent to void", uint(1), uint(2), sqlmock.AnyArg(), sqlmock.AnyArg(), sqlmock.AnyArg()).
+			WillReturnResult(sqlmock.NewResult(1, 1))
+		mock.ExpectCommit()
+
+		err := repo.UpdateInvoiceStatus(ctx, 1, models.InvoiceStatusSent, models.InvoiceStatusVoid, voided)
+
+		assert.NoError(t, err)
+	})
+
+	t.Run("the status stays when the audit trail cannot be written", func(t *testing.T) {
+		mock.ExpectBegin()
+		mock.ExpectExec(regexp.QuoteMeta(`UPDATE invoices`)).
+			WithArgs(models.InvoiceStatusVoid, uint(1), uint(2), models.InvoiceStatusSent).
+			WillReturnResult(sqlmock.NewResult(0, 1))
+		mock.ExpectExec(regexp.QuoteMeta(`INSERT INTO audit_trails`)).
+			WillReturnError(errors.New("connection reset"))
+		mock.ExpectRollback()
+
+		err := repo.UpdateInvoiceStatus(ctx, 1, models.InvoiceStatusSent, models.InvoiceStatusVoid, voided)
+
+		assert.ErrorContains(t, err, "failed to log audit trail event")
+	})
+
+	t.Run("nothing is audited when another request changed the status first", func(t *testing.T) {
+		mock.ExpectBegin()
+		mock.ExpectExec(regexp.QuoteMeta(`UPDATE invoices`)).
+			WillReturnResult(sqlmock.NewResult(0, 0))
+		mock.ExpectRollback()
+
+		err := repo.UpdateInvoiceStatus(ctx, 1, models.InvoiceStatusSent, models.InvoiceStatusVoid, voided)
+
+		assert.EqualError(t, err, "invoice status was changed by another request")
+	})
+
+	t.Run("an unscoped context is refused before updating", func(t *testing.T) {
+		err := repo.UpdateInvoiceStatus(context.Background(), 1, models.InvoiceStatusSent, models.InvoiceStatusVoid, voided)
+
+		assert.ErrorIs(t, err, tenant.ErrMissingTenant)
+	})
+
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
+
+func TestInvoiceRepository_GetPastDueInvoices(t *testing.T) {
+	db, mock, _ := getMockDB()
+	defer db.Close()
+
+	repo := &invoiceRepository{db: db, logger: &zerolog.Logger{}}
+	now := time.Date(2025, 3, 1, 9, 0, 0, 0, time.UTC)
+
+	t.Run("sent and partially paid invoices due before now are returned across customers", func(t *testing.T) {
+		mock.ExpectQuery(regexp.QuoteMeta(`WHERE status IN (?, ?) AND due_date < ? AND deleted_at IS NULL`)).
+			WithArgs(models.InvoiceStatusSent, models.InvoiceStatusPartiallyPaid, now, 100).
+			WillReturnRows(sqlmock.NewRows([]string{"id", "customer_id", "invoice_number", "due_date", "status"}).
+				AddRow(1, 10, "INV-1", now.AddDate(0, 0, -3), models.InvoiceStatusSent).
+				AddRow(2, 20, "INV-2", now.AddDate(0, 0, -1), models.InvoiceStatusPartiallyPaid))
+
+		invoices, err := repo.GetPastDueInvoices(context.Background(), now, 100)
+
+		assert.NoError(t, err)
+		assert.Len(t, invoices, 2)
+		assert.Equal(t, uint(10), invoices[0].CustomerID)
+		assert.Equal(t, models.InvoiceStatusPartiallyPaid, invoices[1].Status)
+	})
+
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
+
+func TestInvoiceRepository_DuplicateInvoice(t *testing.T) {
+	db, mock, _ := getMockDB()
+	defer db.Close()
+
+	repo := &invoiceRepository{db: db, logger: &zerolog.Logger{}}
+	ctx := tenant.WithCustomerID(context.Background(), 2)
+	source := &models.Invoice{ID: 5, InvoiceNumber: "INV-2024-00031", IssueDate: time.Date(2024, 11, 20, 0, 0, 0, 0, time.UTC)}
+	columns := []string{"id", "customer_id", "document_type", "prefix", "format", "reset_policy", "next_value", "current_year"}
+	expectSequence := func() {
+		mock.ExpectExec(regexp.QuoteMeta(`INSERT IGNORE INTO document_sequences`)).
+			WillReturnResult(sqlmock.NewResult(0, 0))
+		mock.ExpectQuery(regexp.QuoteMeta(`SELECT * FROM document_sequences`)).
+			WithArgs(uint(2), models.DocumentTypeInvoice).
+			WillReturnRows(sqlmock.NewRows(columns).AddRow(1, 2, "invoice", "INV", "{PREFIX}-{YYYY}-{SEQ:05}", "yearly", 4, 2026))
+	}
+
+	t.Run("a prior year's invoice is copied into the current year's sequence", func(t *testing.T) {
+		issueDate := time.Date(2026, 10, 18, 9, 0, 0, 0, time.UTC)
+		dueDate := issueDate.AddDate(0, 0, 30)
+
+		mock.ExpectBegin()
+		expectSequence()
+		mock.ExpectExec(regexp.QuoteMeta(`UPDATE document_sequences`)).
+			WithArgs(5, 2026, uint(1)).
+			WillReturnResult(sqlmock.NewResult(0, 1))
+		mock.ExpectExec(regexp.QuoteMeta(`INSERT INTO invoices`)).
+			WithArgs("INV-2026-00004", issueDate, dueDate, uint(5), uint(2)).
+			WillReturnResult(sqlmock.NewResult(9, 1))
+		mock.ExpectQuery(regexp.QuoteMeta(`SELECT * FROM invoice_items`)).
+			WithArgs(uint(5)).
+			WillReturnRows(sqlmock.NewRows([]string{"id"}))
+		mock.ExpectQuery(regexp.QuoteMeta(`SELECT * FROM invoice_item_taxes`)).
+			WithArgs(uint(5)).
+			WillReturnRows(sqlmock.NewRows([]string{"id"}))
+		mock.ExpectExec(regexp.QuoteMeta(`INSERT INTO payment_info`)).
+			WithArgs(int64(9), uint(5)).
+			WillReturnResult(sqlmock.NewResult(1, 1))
+		mock.ExpectCommit()
+		mock.ExpectQuery(regexp.QuoteMeta(`WHERE i.id = ? AND i.customer_id = ? AND i.deleted_at IS NULL`)).
+			WithArgs(uint(9), uint(2)).
+			WillReturnRows(sqlmock.NewRows([]string{"id", "customer_id", "invoice_number", "issue_date", "due_date", "billing_currency", "status"}).
+				AddRow(9, 2, "INV-2026-00004", issueDate, dueDate, "USD", "draft"))
+		mock.ExpectQuery(regexp.QuoteMeta(`SELECT * FROM invoice_items`)).
+			WithArgs(uint(9)).
+			WillReturnRows(sqlmock.NewRows([]string{"id"}))
+		mock.ExpectQuery(regexp.QuoteMeta(`SELECT * FROM invoice_item_taxes`)).
+			WithArgs(uint(9)).
+			WillReturnRows(sqlmock.NewRows([]string{"id"}))
+
+		duplicate, err := repo.DuplicateInvoice(ctx, source, issueDate, dueDate)
+
+		assert.NoError(t, err)
+		assert.Equal(t, "INV-2026-00004", duplicate.InvoiceNumber)
+		assert.Equal(t, issueDate, duplicate.IssueDate)
+	})
+
+	t.Run("a copy dated in a year the sequence has moved on from is refused", func(t *testing.T) {
+		mock.ExpectBegin()
+		expectSequence()
+		mock.ExpectRollback()
+
+		duplicate, err := repo.DuplicateInvoice(ctx, source, source.IssueDate, source.IssueDate.AddDate(0, 0, 30))
+
+		assert.Nil(t, duplicate)
+		assert.ErrorIs(t, err, exceptions.ErrLocked)
+	})
+
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
+
+func TestInvoiceRepository_CreateInvoiceWithItems(t *testing.T) {
+	mockDB, mock, err := sqlmock.New(sqlmock.QueryMatcherOption(insertColumnsMatcher))
+	assert.NoError(t, err)
+	db := sqlx.NewDb(mockDB, "sqlmock")
+	defer db.Close()
+
+	repo := &invoiceRepository{db: db, logger: &zerolog.Logger{}}
+	profileID, clientID := uint(3), uint(7)
+	issueDate := time.Date(2025, 3, 8, 0, 0, 0, 0, time.UTC)
+	dueDate := issueDate.AddDate(0, 0, 30)
+	invoice := &models.Invoice{
+		CustomerID:      2,
+		InvoiceIssuer:   models.InvoiceIssuer{BusinessProfileID: &profileID, Name: "Numeris Studio", Email: "studio@numeris.test", Phone: "+14155550100", Address: "1 Main St"},
+		InvoiceClient:   models.InvoiceClient{ClientID: &clientID, Name: "Acme", Email: "billing@acme.test", Phone: "+14155550123", Address: "2 Side St"},
+		IssueDate:       issueDate,
+		DueDate:         dueDate,
+		TotalAmountDue:  money.New(11000, "USD"),
+		Subtotal:        money.New(10000, "USD"),
+		TaxTotal:        money.New(1000, "USD"),
+		BillingCurrency: "USD",
+		Discount:        money.Zero("USD"),
+		DiscountTotal:   money.Zero("USD"),
+		Status:          models.InvoiceStatusDraft,
+		Notes:           "Thanks",
+		PaymentInfo:     &models.PaymentInfo{},
+	}
+
+	// every column the insert names is paired with the argument it must be given
+	columns := []struct {
+		name  string
+		value driver.Value
+	}{
+		{"invoice_number", "INV-2025-00001"},
+		{"customer_id", invoice.CustomerID},
+		{"business_profile_id", invoice.BusinessProfileID},
+		{"issuer_name", "Numeris Studio"},
+		{"issuer_email", "studio@numeris.test"},
+		{"issuer_phone", "+14155550100"},
+		{"issuer_address", "1 Main St"},
+		{"client_id", invoice.ClientID},
+		{"client_name", "Acme"},
+		{"client_email", "billing@acme.test"},
+		{"client_phone", "+14155550123"},
+		{"client_address", "2 Side St"},
+		{"issue_date", issueDate},
+		{"due_date", dueDate},
+		{"total_amount_due", invoice.TotalAmountDue},
+		{"subtotal", invoice.Subtotal},
+		{"tax_total", invoice.TaxTotal},
+		{"is_fully_paid", false},
+		{"billing_currency", "USD"},
+		{"discount", invoice.Discount},
+		{"discount_type", invoice.DiscountType},
+		{"discount_rate", invoice.DiscountRate},
+		{"discount_total", invoice.DiscountTotal},
+		{"status", models.InvoiceStatusDraft},
+		{"notes", "Thanks"},
+	}
+	names := make([]string, 0, len(columns))
+	args := make([]driver.Value, 0, len(columns))
+	for _, column := range columns {
+		names = append(names, column.name)
+		args = append(args, column.value)
+	}
+	insertPattern := `INSERT INTO invoices \(\s*` + strings.Join(names, `,\s*`) + `,\s*created_at, updated_at\s*\)`
+
+	mock.ExpectBegin()
+	mock.ExpectExec(regexp.QuoteMeta(`INSERT IGNORE INTO document_sequences`)).
+		WillReturnResult(sqlmock.NewResult(0, 0))
+	mock.ExpectQuery(regexp.QuoteMeta(`FROM document_sequences`)).
+		WillReturnRows(sqlmock.NewRows([]string{"id", "customer_id", "document_type", "prefix", "format", "reset_policy", "next_value", "current_year"}).
+			AddRow(1, 2, "invoice", "INV", "{PREFIX}-{YYYY}-{SEQ:05}", "never", 1, 2025))
+	mock.ExpectExec(regexp.QuoteMeta(`UPDATE document_sequences`)).
+		WillReturnResult(sqlmock.NewResult(0, 1))
+	mock.ExpectExec(insertPattern).
+		WithArgs(args...).
+		WillReturnResult(sqlmock.NewResult(9, 1))
+	mock.ExpectExec(regexp.QuoteMeta(`INSERT INTO payment_info`)).
+		WillReturnResult(sqlmock.NewResult(1, 1))
+	mock.ExpectCommit()
+	mock.ExpectQuery(regexp.QuoteMeta(`WHERE i.id = ? AND i.customer_id = ? AND i.deleted_at IS NULL`)).
+		WithArgs(uint(9), uint(2)).
+		WillReturnRows(sqlmock.NewRows([]string{"id", "customer_id", "invoice_number", "billing_currency"}).AddRow(9, 2, "INV-2025-00001", "USD"))
+	mock.ExpectQuery(regexp.QuoteMeta(`FROM invoice_items`)).
+		WillReturnRows(sqlmock.NewRows([]string{"id"}))
+	mock.ExpectQuery(regexp.QuoteMeta(`FROM invoice_item_taxes`)).
+		WillReturnRows(sqlmock.NewRows([]string{"id"}))
+
+	created, err := repo.CreateInvoiceWithItems(tenant.WithCustomerID(context.Background(), 2), invoice)
+
+	if assert.NoError(t, err) {
+		assert.Equal(t, uint(9), created.ID)
+	}
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
+
+func TestInvoiceRepository_UpdateDraftInvoice(t *testing.T) {
+	db, mock, _ := getMockDB()
+	defer db.Close()
+
+	repo := &invoiceRepository{db: db, logger: &zerolog.Logger{}}
+	ctx := tenant.WithCustomerID(context.Background(), 2)
+
+	// another request got there first, so nothing is replaced
+	mock.ExpectBegin()
+	mock.ExpectExec(regexp.QuoteMeta(`UPDATE invoice_reminders r`)).
+		WillReturnResult(sqlmock.NewResult(0, 1))
+	mock.ExpectExec(regexp.QuoteMeta(`WHERE id = ? AND customer_id = ? AND status = 'draft' AND version = ? AND deleted_at IS NULL`)).
+		WillReturnResult(sqlmock.NewResult(0, 0))
+	mock.ExpectRollback()
+
+	invoice, err := repo.UpdateDraftInvoice(ctx, &models.Invoice{ID: 5, Version: 3, PaymentInfo: &models.PaymentInfo{}}, []models.AuditTrail{{EventType: models.EventTypeInvoiceUpdated}})
+
+	assert.Nil(t, invoice)
+	assert.ErrorIs(t, err, exceptions.ErrVersionMismatch)
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
+
+func TestInvoiceRepository_DeleteDraftInvoice(t *testing.T) {
+	db, mock, _ := getMockDB()
+	defer db.Close()
+
+	repo := &invoiceRepository{db: db, logger: &zerolog.Logger{}}
+	ctx := tenant.WithCustomerID(context.Background(), 2)
+	draft := &models.Invoice{ID: 5, InvoiceNumber: "INV-2025-00005", IssueDate: time.Date(2025, 3, 8, 0, 0, 0, 0, time.UTC)}
+	columns := []string{"id", "customer_id", "document_type", "prefix", "format", "reset_policy", "next_value", "current_year"}
+	expectDelete := func() {
+		mock.ExpectExec(regexp.QuoteMeta(`SET deleted_at = CURRENT_TIMESTAMP, version = version + 1
+		WHERE id = ? AND customer_id = ? AND status = 'draft' AND version = ? AND deleted_at IS NULL`)).
+			WithArgs(uint(5), uint(2), uint(3)).
+			WillReturnResult(sqlmock.NewResult(0, 1))
+	}
+
+	t.Run("the latest draft gives its number back along with its audit trail", func(t *testing.T) {
+		mock.ExpectBegin()
+		expectDelete()
+		mock.ExpectQuery(regexp.QuoteMeta(`SELECT * FROM document_sequences WHERE customer_id = ? AND document_type = ? FOR UPDATE`)).
+			WithArgs(uint(2), models.DocumentTypeInvoice).
+			WillReturnRows(sqlmock.NewRows(columns).AddRow(1, 2, "invoice", "INV", "{PREFIX}-{YYYY}-{SEQ:05}", "yearly", 6, 2025))
+		mock.ExpectExec(regexp.QuoteMeta(`UPDATE document_sequences SET next_value = next_value - 1`)).
+			WithArgs(uint(1)).
+			WillReturnResult(sqlmock.NewResult(0, 1))
+		mock.ExpectExec(regexp.QuoteMeta(`INSERT INTO audit_trails`)).
+			WithArgs(models.EventTypeInvoiceDeleted, models.LogLevelInfo, "Deleted Invoice INV-2025-00005", uint(5), uint(2), sqlmock.AnyArg(), sqlmock.AnyArg(), sqlmock.AnyArg()).
+			WillReturnResult(sqlmock.NewResult(1, 1))
+		mock.ExpectCommit()
+
+		err := repo.DeleteDraftInvoice(ctx, draft, 3, []models.AuditTrail{{EventType: models.EventTypeInvoiceDeleted, LogLevel: models.LogLevelInfo, Message: "Deleted Invoice INV-2025-00005"}})
+
+		assert.NoError(t, err)
+		assert.NoError(t, mock.ExpectationsWereMet())
+	})
+
+	t.Run("a draft numbered before the latest is kept so no gap is left", func(t *testing.T) {
+		mock.ExpectBegin()
+		expectDelete()
+		mock.ExpectQuery(regexp.QuoteMeta(`SELECT * FROM document_sequences`)).
+			WithArgs(uint(2), models.DocumentTypeInvoice).
+			WillReturnRows(sqlmock.NewRows(columns).AddRow(1, 2, "invoice", "INV", "{PREFIX}-{YYYY}-{SEQ:05}", "yearly", 7, 2025))
+		mock.ExpectRollback()
+
+		err := repo.DeleteDraftInvoice(ctx, draft, 3, []models.AuditTrail{{EventType: models.EventTypeInvoiceDeleted}})
+
+		assert.ErrorIs(t, err, exceptions.ErrLocked)
+		assert.NoError(t, mock.ExpectationsWereMet())
+	})
+
+	t.Run("a draft from a year the sequence has left is kept", func(t *testing.T) {
+		mock.ExpectBegin()
+		expectDelete()
+		mock.ExpectQuery(regexp.QuoteMeta(`SELECT * FROM document_sequences`)).
+			WithArgs(uint(2), models.DocumentTypeInvoice).
+			WillReturnRows(sqlmock.NewRows(columns).AddRow(1, 2, "invoice", "INV", "{PREFIX}-{YYYY}-{SEQ:05}", "yearly", 6, 2026))
+		mock.ExpectRollback()
+
+		err := repo.DeleteDraftInvoice(ctx, draft, 3, []models.AuditTrail{{EventType: models.EventTypeInvoiceDeleted}})
+
+		assert.ErrorIs(t, err, exceptions.ErrLocked)
+		assert.NoError(t, mock.ExpectationsWereMet())
+	})
+
+	t.Run("nothing is audited when another request got there first", func(t *testing.T) {
+		mock.ExpectBegin()
+		mock.ExpectExec(regexp.QuoteMeta(`SET deleted_at = CURRENT_TIMESTAMP`)).
+			WithArgs(uint(5), uint(2), uint(3)).
+			WillReturnResult(sqlmock.NewResult(0, 0))
+		mock.ExpectRollback()
+
+		err := repo.DeleteDraftInvoice(ctx, draft, 3, []models.AuditTrail{{EventType: models.EventTypeInvoiceDeleted}})
+
+		assert.ErrorIs(t, err, exceptions.ErrVersionMismatch)
+		assert.NoError(t, mock.ExpectationsWereMet())
+	})
+}
+
+func TestInvoiceRepository_GetStatistics(t *testing.T) {
+	db, mock, _ := getMockDB()
+	defer db.Close()
+
+	repo := &invoiceRepository{db: db, logger: &zerolog.Logger{}}
+
+	mock.ExpectQuery(regexp.QuoteMeta(`as total_credit_notes FROM invoices WHERE customer_id = ? AND deleted_at IS NULL`)).
+		WithArgs(uint(2), uint(2)).
+		WillReturnRows(sqlmock.NewRows([]string{"total_paid", "total_over_due", "total_draft", "total_unpaid", "total_credit_notes"}).
+			AddRow(3, 1, 2, 4, 1))
+	mock.ExpectQuery(regexp.QuoteMeta(`GROUP BY billing_currency ORDER BY billing_currency`)).
+		WithArgs(uint(2)).
+		WillReturnRows(sqlmock.NewRows([]string{"currency", "total_paid_amount", "total_over_due_amount", "total_draft_amount", "total_unpaid_amount", "total_credited_amount"}).
+			AddRow("JPY", []byte("15000"), []byte("0"), []byte("0"), []byte("3000"), []byte("0")).
+			AddRow("USD", []byte("120.50"), []byte("40.00"), []byte("75.00"), []byte("90.00"), []byte("10.00")))
+	mock.ExpectQuery(regexp.QuoteMeta(`SELECT currency, SUM(amount) as total_client_credit FROM client_credits WHERE customer_id = ? GROUP BY currency`)).
+		WithArgs(uint(2)).
+		WillReturnRows(sqlmock.NewRows([]string{"currency", "total_client_credit"}).
+			AddRow("EUR", []byte("5.00")).
+			AddRow("USD", []byte("12.25")))
+
+	stats, err := repo.GetStatistics(tenant.WithCustomerID(context.Background(), 2))
+
+	assert.NoError(t, err)
+	assert.Equal(t, 3, stats.TotalPaid)
+	assert.Equal(t, 1, stats.TotalCreditNotes)
+	assert.Equal(t, []response_dto.InvoiceCurrencyStatistics{
+		{
+			Currency:            "EUR",
+			TotalPaidAmount:     money.Zero("EUR"),
+			TotalOverDueAmount:  money.Zero("EUR"),
+			TotalDraftAmount:    money.Zero("EUR"),
+			TotalUnpaidAmount:   money.Zero("EUR"),
+			TotalCreditedAmount: money.Zero("EUR"),
+			TotalClientCredit:   money.New(500, "EUR"),
+		},
+		{
+			Currency:            "JPY",
+			TotalPaidAmount:     money.New(15000, "JPY"),
+			TotalOverDueAmount:  money.Zero("JPY"),
+			TotalDraftAmount:    money.Zero("JPY"),
+			TotalUnpaidAmount:   money.New(3000, "JPY"),
+			TotalCreditedAmount: money.Zero("JPY"),
+			TotalClientCredit:   money.Zero("JPY"),
+		},
+		{
+			Currency:            "USD",
+			TotalPaidAmount:     money.New(12050, "USD"),
+			TotalOverDueAmount:  money.New(4000, "USD"),
+			TotalDraftAmount:    money.New(7500, "USD"),
+			TotalUnpaidAmount:   money.New(9000, "USD"),
+			TotalCreditedAmount: money.New(1000, "USD"),
+			TotalClientCredit:   money.New(1225, "USD"),
+		},
+	}, stats.Currencies)
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
@@ -84,3 +84,17 @@ func (mr *MockAuditTrailRepositoryMockRecorder) LogEvent(ctx, eventType, logLeve
 	mr.mock.ctrl.T.Helper()
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "LogEvent", reflect.TypeOf((*MockAuditTrailRepository)(nil).LogEvent), ctx, eventType, logLevel, message, invoiceID, customerID)
 }
+
+// LogQuoteEvent mocks base method.
+func (m *MockAuditTrailRepository) LogQuoteEvent(ctx context.Context, eventType models.EventType, logLevel models.LogLevel, message string, invoiceID, quoteID, customerID uint) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "LogQuoteEvent", ctx, eventType, logLevel, message, invoiceID, quoteID, customerID)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// LogQuoteEvent indicates an expected call of LogQuoteEvent.
+func (mr *MockAuditTrailRepositoryMockRecorder) LogQuoteEvent(ctx, eventType, logLevel, message, invoiceID, quoteID, customerID any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "LogQuoteEvent", reflect.TypeOf((*MockAuditTrailRepository)(nil).LogQuoteEvent), ctx, eventType, logLevel, message, invoiceID, quoteID, customerID)
+}
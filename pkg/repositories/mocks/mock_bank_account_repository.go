@@ -0,0 +1,144 @@
+// Code generated by MockGen. DO NOT EDIT.
+// Source: pkg/repositories/interfaces/bank_account_repository.interface.go
+//
+// Generated by this command:
+//
+//	mockgen -source=pkg/repositories/interfaces/bank_account_repository.interface.go -destination=pkg/repositories/mocks/mock_bank_account_repository.go -package=repository_mocks
+//
+
+// Package repository_mocks is a generated GoMock package.
+package repository_mocks
+
+import (
+	context "context"
+	reflect "reflect"
+
+	models "github.com/Adebayobenjamin/numerisbook/pkg/models"
+	gomock "go.uber.org/mock/gomock"
+)
+
+// MockBankAccountRepository is a mock of BankAccountRepository interface.
+type MockBankAccountRepository struct {
+	ctrl     *gomock.Controller
+	recorder *MockBankAccountRepositoryMockRecorder
+	isgomock struct{}
+}
+
+// MockBankAccountRepositoryMockRecorder is the mock recorder for MockBankAccountRepository.
+type MockBankAccountRepositoryMockRecorder struct {
+	mock *MockBankAccountRepository
+}
+
+// NewMockBankAccountRepository creates a new mock instance.
+func NewMockBankAccountRepository(ctrl *gomock.Controller) *MockBankAccountRepository {
+	mock := &MockBankAccountRepository{ctrl: ctrl}
+	mock.recorder = &MockBankAccountRepositoryMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockBankAccountRepository) EXPECT() *MockBankAccountRepositoryMockRecorder {
+	return m.recorder
+}
+
+// Create mocks base method.
+func (m *MockBankAccountRepository) Create(ctx context.Context, account *models.BankAccount) (*models.BankAccount, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Create", ctx, account)
+	ret0, _ := ret[0].(*models.BankAccount)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Create indicates an expected call of Create.
+func (mr *MockBankAccountRepositoryMockRecorder) Create(ctx, account any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Create", reflect.TypeOf((*MockBankAccountRepository)(nil).Create), ctx, account)
+}
+
+// Delete mocks base method.
+func (m *MockBankAccountRepository) Delete(ctx context.Context, id, customerID uint) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Delete", ctx, id, customerID)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// Delete indicates an expected call of Delete.
+func (mr *MockBankAccountRepositoryMockRecorder) Delete(ctx, id, customerID any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Delete", reflect.TypeOf((*MockBankAccountRepository)(nil).Delete), ctx, id, customerID)
+}
+
+// GetAllCustomerBankAccounts mocks base method.
+func (m *MockBankAccountRepository) GetAllCustomerBankAccounts(ctx context.Context, customerID uint) ([]models.BankAccount, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetAllCustomerBankAccounts", ctx, customerID)
+	ret0, _ := ret[0].([]models.BankAccount)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetAllCustomerBankAccounts indicates an expected call of GetAllCustomerBankAccounts.
+func (mr *MockBankAccountRepositoryMockRecorder) GetAllCustomerBankAccounts(ctx, customerID any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetAllCustomerBankAccounts", reflect.TypeOf((*MockBankAccountRepository)(nil).GetAllCustomerBankAccounts), ctx, customerID)
+}
+
+// GetByIDAndCustomerID mocks base method.
+func (m *MockBankAccountRepository) GetByIDAndCustomerID(ctx context.Context, id, customerID uint) (*models.BankAccount, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetByIDAndCustomerID", ctx, id, customerID)
+	ret0, _ := ret[0].(*models.BankAccount)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetByIDAndCustomerID indicates an expected call of GetByIDAndCustomerID.
+func (mr *MockBankAccountRepositoryMockRecorder) GetByIDAndCustomerID(ctx, id, customerID any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetByIDAndCustomerID", reflect.TypeOf((*MockBankAccountRepository)(nil).GetByIDAndCustomerID), ctx, id, customerID)
+}
+
+// GetDefault mocks base method.
+func (m *MockBankAccountRepository) GetDefault(ctx context.Context, customerID uint) (*models.BankAccount, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetDefault", ctx, customerID)
+	ret0, _ := ret[0].(*models.BankAccount)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetDefault indicates an expected call of GetDefault.
+func (mr *MockBankAccountRepositoryMockRecorder) GetDefault(ctx, customerID any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetDefault", reflect.TypeOf((*MockBankAccountRepository)(nil).GetDefault), ctx, customerID)
+}
+
+// SetDefault mocks base method.
+func (m *MockBankAccountRepository) SetDefault(ctx context.Context, id, customerID uint) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "SetDefault", ctx, id, customerID)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// SetDefault indicates an expected call of SetDefault.
+func (mr *MockBankAccountRepositoryMockRecorder) SetDefault(ctx, id, customerID any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SetDefault", reflect.TypeOf((*MockBankAccountRepository)(nil).SetDefault), ctx, id, customerID)
+}
+
+// Update mocks base method.
+func (m *MockBankAccountRepository) Update(ctx context.Context, account *models.BankAccount) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Update", ctx, account)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// Update indicates an expected call of Update.
+func (mr *MockBankAccountRepositoryMockRecorder) Update(ctx, account any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Update", reflect.TypeOf((*MockBankAccountRepository)(nil).Update), ctx, account)
+}
@@ -0,0 +1,144 @@
+// Code generated by MockGen. DO NOT EDIT.
+// Source: pkg/repositories/interfaces/business_profile_repository.interface.go
+//
+// Generated by this command:
+//
+//	mockgen -source=pkg/repositories/interfaces/business_profile_repository.interface.go -destination=pkg/repositories/mocks/mock_business_profile_repository.go -package=repository_mocks
+//
+
+// Package repository_mocks is a generated GoMock package.
+package repository_mocks
+
+import (
+	context "context"
+	reflect "reflect"
+
+	models "github.com/Adebayobenjamin/numerisbook/pkg/models"
+	gomock "go.uber.org/mock/gomock"
+)
+
+// MockBusinessProfileRepository is a mock of BusinessProfileRepository interface.
+type MockBusinessProfileRepository struct {
+	ctrl     *gomock.Controller
+	recorder *MockBusinessProfileRepositoryMockRecorder
+	isgomock struct{}
+}
+
+// MockBusinessProfileRepositoryMockRecorder is the mock recorder for MockBusinessProfileRepository.
+type MockBusinessProfileRepositoryMockRecorder struct {
+	mock *MockBusinessProfileRepository
+}
+
+// NewMockBusinessProfileRepository creates a new mock instance.
+func NewMockBusinessProfileRepository(ctrl *gomock.Controller) *MockBusinessProfileRepository {
+	mock := &MockBusinessProfileRepository{ctrl: ctrl}
+	mock.recorder = &MockBusinessProfileRepositoryMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockBusinessProfileRepository) EXPECT() *MockBusinessProfileRepositoryMockRecorder {
+	return m.recorder
+}
+
+// Create mocks base method.
+func (m *MockBusinessProfileRepository) Create(ctx context.Context, profile *models.BusinessProfile) (*models.BusinessProfile, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Create", ctx, profile)
+	ret0, _ := ret[0].(*models.BusinessProfile)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Create indicates an expected call of Create.
+func (mr *MockBusinessProfileRepositoryMockRecorder) Create(ctx, profile any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Create", reflect.TypeOf((*MockBusinessProfileRepository)(nil).Create), ctx, profile)
+}
+
+// Delete mocks base method.
+func (m *MockBusinessProfileRepository) Delete(ctx context.Context, id, customerID uint) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Delete", ctx, id, customerID)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// Delete indicates an expected call of Delete.
+func (mr *MockBusinessProfileRepositoryMockRecorder) Delete(ctx, id, customerID any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Delete", reflect.TypeOf((*MockBusinessProfileRepository)(nil).Delete), ctx, id, customerID)
+}
+
+// GetAllCustomerBusinessProfiles mocks base method.
+func (m *MockBusinessProfileRepository) GetAllCustomerBusinessProfiles(ctx context.Context, customerID uint) ([]models.BusinessProfile, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetAllCustomerBusinessProfiles", ctx, customerID)
+	ret0, _ := ret[0].([]models.BusinessProfile)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetAllCustomerBusinessProfiles indicates an expected call of GetAllCustomerBusinessProfiles.
+func (mr *MockBusinessProfileRepositoryMockRecorder) GetAllCustomerBusinessProfiles(ctx, customerID any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetAllCustomerBusinessProfiles", reflect.TypeOf((*MockBusinessProfileRepository)(nil).GetAllCustomerBusinessProfiles), ctx, customerID)
+}
+
+// GetByIDAndCustomerID mocks base method.
+func (m *MockBusinessProfileRepository) GetByIDAndCustomerID(ctx context.Context, id, customerID uint) (*models.BusinessProfile, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetByIDAndCustomerID", ctx, id, customerID)
+	ret0, _ := ret[0].(*models.BusinessProfile)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetByIDAndCustomerID indicates an expected call of GetByIDAndCustomerID.
+func (mr *MockBusinessProfileRepositoryMockRecorder) GetByIDAndCustomerID(ctx, id, customerID any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetByIDAndCustomerID", reflect.TypeOf((*MockBusinessProfileRepository)(nil).GetByIDAndCustomerID), ctx, id, customerID)
+}
+
+// GetDefault mocks base method.
+func (m *MockBusinessProfileRepository) GetDefault(ctx context.Context, customerID uint) (*models.BusinessProfile, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetDefault", ctx, customerID)
+	ret0, _ := ret[0].(*models.BusinessProfile)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetDefault indicates an expected call of GetDefault.
+func (mr *MockBusinessProfileRepositoryMockRecorder) GetDefault(ctx, customerID any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetDefault", reflect.TypeOf((*MockBusinessProfileRepository)(nil).GetDefault), ctx, customerID)
+}
+
+// SetDefault mocks base method.
+func (m *MockBusinessProfileRepository) SetDefault(ctx context.Context, id, customerID uint) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "SetDefault", ctx, id, customerID)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// SetDefault indicates an expected call of SetDefault.
+func (mr *MockBusinessProfileRepositoryMockRecorder) SetDefault(ctx, id, customerID any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SetDefault", reflect.TypeOf((*MockBusinessProfileRepository)(nil).SetDefault), ctx, id, customerID)
+}
+
+// Update mocks base method.
+func (m *MockBusinessProfileRepository) Update(ctx context.Context, profile *models.BusinessProfile) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Update", ctx, profile)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// Update indicates an expected call of Update.
+func (mr *MockBusinessProfileRepositoryMockRecorder) Update(ctx, profile any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Update", reflect.TypeOf((*MockBusinessProfileRepository)(nil).Update), ctx, profile)
+}
@@ -0,0 +1,147 @@
+// Code generated by MockGen. DO NOT EDIT.
+// Source: pkg/repositories/interfaces/catalog_item_repository.interface.go
+//
+// Generated by this command:
+//
+//	mockgen -source=pkg/repositories/interfaces/catalog_item_repository.interface.go -destination=pkg/repositories/mocks/mock_catalog_item_repository.go -package=repository_mocks
+//
+
+// Package repository_mocks is a generated GoMock package.
+package repository_mocks
+
+import (
+	context "context"
+	reflect "reflect"
+	time "time"
+
+	response_dto "github.com/Adebayobenjamin/numerisbook/pkg/dtos/response"
+	models "github.com/Adebayobenjamin/numerisbook/pkg/models"
+	gomock "go.uber.org/mock/gomock"
+)
+
+// MockCatalogItemRepository is a mock of CatalogItemRepository interface.
+type MockCatalogItemRepository struct {
+	ctrl     *gomock.Controller
+	recorder *MockCatalogItemRepositoryMockRecorder
+	isgomock struct{}
+}
+
+// MockCatalogItemRepositoryMockRecorder is the mock recorder for MockCatalogItemRepository.
+type MockCatalogItemRepositoryMockRecorder struct {
+	mock *MockCatalogItemRepository
+}
+
+// NewMockCatalogItemRepository creates a new mock instance.
+func NewMockCatalogItemRepository(ctrl *gomock.Controller) *MockCatalogItemRepository {
+	mock := &MockCatalogItemRepository{ctrl: ctrl}
+	mock.recorder = &MockCatalogItemRepositoryMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockCatalogItemRepository) EXPECT() *MockCatalogItemRepositoryMockRecorder {
+	return m.recorder
+}
+
+// Create mocks base method.
+func (m *MockCatalogItemRepository) Create(ctx context.Context, item *models.CatalogItem) (*models.CatalogItem, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Create", ctx, item)
+	ret0, _ := ret[0].(*models.CatalogItem)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Create indicates an expected call of Create.
+func (mr *MockCatalogItemRepositoryMockRecorder) Create(ctx, item any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Create", reflect.TypeOf((*MockCatalogItemRepository)(nil).Create), ctx, item)
+}
+
+// Delete mocks base method.
+func (m *MockCatalogItemRepository) Delete(ctx context.Context, id, customerID uint) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Delete", ctx, id, customerID)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// Delete indicates an expected call of Delete.
+func (mr *MockCatalogItemRepositoryMockRecorder) Delete(ctx, id, customerID any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Delete", reflect.TypeOf((*MockCatalogItemRepository)(nil).Delete), ctx, id, customerID)
+}
+
+// GetAllCustomerCatalogItems mocks base method.
+func (m *MockCatalogItemRepository) GetAllCustomerCatalogItems(ctx context.Context, customerID uint, search string, limit, offset int) ([]models.CatalogItem, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetAllCustomerCatalogItems", ctx, customerID, search, limit, offset)
+	ret0, _ := ret[0].([]models.CatalogItem)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetAllCustomerCatalogItems indicates an expected call of GetAllCustomerCatalogItems.
+func (mr *MockCatalogItemRepositoryMockRecorder) GetAllCustomerCatalogItems(ctx, customerID, search, limit, offset any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetAllCustomerCatalogItems", reflect.TypeOf((*MockCatalogItemRepository)(nil).GetAllCustomerCatalogItems), ctx, customerID, search, limit, offset)
+}
+
+// GetByIDAndCustomerID mocks base method.
+func (m *MockCatalogItemRepository) GetByIDAndCustomerID(ctx context.Context, id, customerID uint) (*models.CatalogItem, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetByIDAndCustomerID", ctx, id, customerID)
+	ret0, _ := ret[0].(*models.CatalogItem)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetByIDAndCustomerID indicates an expected call of GetByIDAndCustomerID.
+func (mr *MockCatalogItemRepositoryMockRecorder) GetByIDAndCustomerID(ctx, id, customerID any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetByIDAndCustomerID", reflect.TypeOf((*MockCatalogItemRepository)(nil).GetByIDAndCustomerID), ctx, id, customerID)
+}
+
+// GetBySKU mocks base method.
+func (m *MockCatalogItemRepository) GetBySKU(ctx context.Context, customerID uint, sku string) (*models.CatalogItem, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetBySKU", ctx, customerID, sku)
+	ret0, _ := ret[0].(*models.CatalogItem)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetBySKU indicates an expected call of GetBySKU.
+func (mr *MockCatalogItemRepositoryMockRecorder) GetBySKU(ctx, customerID, sku any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetBySKU", reflect.TypeOf((*MockCatalogItemRepository)(nil).GetBySKU), ctx, customerID, sku)
+}
+
+// GetRevenue mocks base method.
+func (m *MockCatalogItemRepository) GetRevenue(ctx context.Context, customerID uint, from, to *time.Time) ([]response_dto.CatalogItemRevenue, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetRevenue", ctx, customerID, from, to)
+	ret0, _ := ret[0].([]response_dto.CatalogItemRevenue)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetRevenue indicates an expected call of GetRevenue.
+func (mr *MockCatalogItemRepositoryMockRecorder) GetRevenue(ctx, customerID, from, to any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetRevenue", reflect.TypeOf((*MockCatalogItemRepository)(nil).GetRevenue), ctx, customerID, from, to)
+}
+
+// Update mocks base method.
+func (m *MockCatalogItemRepository) Update(ctx context.Context, item *models.CatalogItem) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Update", ctx, item)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// Update indicates an expected call of Update.
+func (mr *MockCatalogItemRepositoryMockRecorder) Update(ctx, item any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Update", reflect.TypeOf((*MockCatalogItemRepository)(nil).Update), ctx, item)
+}
@@ -0,0 +1,73 @@
+// Code generated by MockGen. DO NOT EDIT.
+// Source: pkg/repositories/interfaces/client_credit_repository.interface.go
+//
+// Generated by this command:
+//
+//	mockgen -source=pkg/repositories/interfaces/client_credit_repository.interface.go -destination=pkg/repositories/mocks/mock_client_credit_repository.go -package=repository_mocks
+//
+
+// Package repository_mocks is a generated GoMock package.
+package repository_mocks
+
+import (
+	context "context"
+	reflect "reflect"
+
+	response_dto "github.com/Adebayobenjamin/numerisbook/pkg/dtos/response"
+	models "github.com/Adebayobenjamin/numerisbook/pkg/models"
+	gomock "go.uber.org/mock/gomock"
+)
+
+// MockClientCreditRepository is a mock of ClientCreditRepository interface.
+type MockClientCreditRepository struct {
+	ctrl     *gomock.Controller
+	recorder *MockClientCreditRepositoryMockRecorder
+	isgomock struct{}
+}
+
+// MockClientCreditRepositoryMockRecorder is the mock recorder for MockClientCreditRepository.
+type MockClientCreditRepositoryMockRecorder struct {
+	mock *MockClientCreditRepository
+}
+
+// NewMockClientCreditRepository creates a new mock instance.
+func NewMockClientCreditRepository(ctrl *gomock.Controller) *MockClientCreditRepository {
+	mock := &MockClientCreditRepository{ctrl: ctrl}
+	mock.recorder = &MockClientCreditRepositoryMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockClientCreditRepository) EXPECT() *MockClientCreditRepositoryMockRecorder {
+	return m.recorder
+}
+
+// GetClientBalances mocks base method.
+func (m *MockClientCreditRepository) GetClientBalances(ctx context.Context, clientID, customerID uint) ([]response_dto.ClientCreditBalance, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetClientBalances", ctx, clientID, customerID)
+	ret0, _ := ret[0].([]response_dto.ClientCreditBalance)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetClientBalances indicates an expected call of GetClientBalances.
+func (mr *MockClientCreditRepositoryMockRecorder) GetClientBalances(ctx, clientID, customerID any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetClientBalances", reflect.TypeOf((*MockClientCreditRepository)(nil).GetClientBalances), ctx, clientID, customerID)
+}
+
+// GetClientCredits mocks base method.
+func (m *MockClientCreditRepository) GetClientCredits(ctx context.Context, clientID, customerID uint, limit, offset int) ([]models.ClientCredit, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetClientCredits", ctx, clientID, customerID, limit, offset)
+	ret0, _ := ret[0].([]models.ClientCredit)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetClientCredits indicates an expected call of GetClientCredits.
+func (mr *MockClientCreditRepositoryMockRecorder) GetClientCredits(ctx, clientID, customerID, limit, offset any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetClientCredits", reflect.TypeOf((*MockClientCreditRepository)(nil).GetClientCredits), ctx, clientID, customerID, limit, offset)
+}
@@ -0,0 +1,118 @@
+// Code generated by MockGen. DO NOT EDIT.
+// Source: pkg/repositories/interfaces/credit_note_repository.interface.go
+//
+// Generated by this command:
+//
+//	mockgen -source=pkg/repositories/interfaces/credit_note_repository.interface.go -destination=pkg/repositories/mocks/mock_credit_note_repository.go -package=repository_mocks
+//
+
+// Package repository_mocks is a generated GoMock package.
+package repository_mocks
+
+import (
+	context "context"
+	reflect "reflect"
+
+	models "github.com/Adebayobenjamin/numerisbook/pkg/models"
+	repositories_interfaces "github.com/Adebayobenjamin/numerisbook/pkg/repositories/interfaces"
+	gomock "go.uber.org/mock/gomock"
+)
+
+// MockCreditNoteRepository is a mock of CreditNoteRepository interface.
+type MockCreditNoteRepository struct {
+	ctrl     *gomock.Controller
+	recorder *MockCreditNoteRepositoryMockRecorder
+	isgomock struct{}
+}
+
+// MockCreditNoteRepositoryMockRecorder is the mock recorder for MockCreditNoteRepository.
+type MockCreditNoteRepositoryMockRecorder struct {
+	mock *MockCreditNoteRepository
+}
+
+// NewMockCreditNoteRepository creates a new mock instance.
+func NewMockCreditNoteRepository(ctrl *gomock.Controller) *MockCreditNoteRepository {
+	mock := &MockCreditNoteRepository{ctrl: ctrl}
+	mock.recorder = &MockCreditNoteRepositoryMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockCreditNoteRepository) EXPECT() *MockCreditNoteRepositoryMockRecorder {
+	return m.recorder
+}
+
+// CreateCreditNote mocks base method.
+func (m *MockCreditNoteRepository) CreateCreditNote(ctx context.Context, note *models.CreditNote, invoiceVersion uint, settle repositories_interfaces.SettleCreditNote) (*models.CreditNote, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CreateCreditNote", ctx, note, invoiceVersion, settle)
+	ret0, _ := ret[0].(*models.CreditNote)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// CreateCreditNote indicates an expected call of CreateCreditNote.
+func (mr *MockCreditNoteRepositoryMockRecorder) CreateCreditNote(ctx, note, invoiceVersion, settle any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CreateCreditNote", reflect.TypeOf((*MockCreditNoteRepository)(nil).CreateCreditNote), ctx, note, invoiceVersion, settle)
+}
+
+// GetAllCustomerCreditNotes mocks base method.
+func (m *MockCreditNoteRepository) GetAllCustomerCreditNotes(ctx context.Context, customerID uint, limit, offset int) ([]models.CreditNote, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetAllCustomerCreditNotes", ctx, customerID, limit, offset)
+	ret0, _ := ret[0].([]models.CreditNote)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetAllCustomerCreditNotes indicates an expected call of GetAllCustomerCreditNotes.
+func (mr *MockCreditNoteRepositoryMockRecorder) GetAllCustomerCreditNotes(ctx, customerID, limit, offset any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetAllCustomerCreditNotes", reflect.TypeOf((*MockCreditNoteRepository)(nil).GetAllCustomerCreditNotes), ctx, customerID, limit, offset)
+}
+
+// GetByIDAndCustomerID mocks base method.
+func (m *MockCreditNoteRepository) GetByIDAndCustomerID(ctx context.Context, id, customerID uint) (*models.CreditNote, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetByIDAndCustomerID", ctx, id, customerID)
+	ret0, _ := ret[0].(*models.CreditNote)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetByIDAndCustomerID indicates an expected call of GetByIDAndCustomerID.
+func (mr *MockCreditNoteRepositoryMockRecorder) GetByIDAndCustomerID(ctx, id, customerID any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetByIDAndCustomerID", reflect.TypeOf((*MockCreditNoteRepository)(nil).GetByIDAndCustomerID), ctx, id, customerID)
+}
+
+// GetCreditedQuantities mocks base method.
+func (m *MockCreditNoteRepository) GetCreditedQuantities(ctx context.Context, invoiceID uint) (map[uint]int, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetCreditedQuantities", ctx, invoiceID)
+	ret0, _ := ret[0].(map[uint]int)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetCreditedQuantities indicates an expected call of GetCreditedQuantities.
+func (mr *MockCreditNoteRepositoryMockRecorder) GetCreditedQuantities(ctx, invoiceID any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetCreditedQuantities", reflect.TypeOf((*MockCreditNoteRepository)(nil).GetCreditedQuantities), ctx, invoiceID)
+}
+
+// GetInvoiceCreditNotes mocks base method.
+func (m *MockCreditNoteRepository) GetInvoiceCreditNotes(ctx context.Context, invoiceID uint) ([]models.CreditNote, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetInvoiceCreditNotes", ctx, invoiceID)
+	ret0, _ := ret[0].([]models.CreditNote)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetInvoiceCreditNotes indicates an expected call of GetInvoiceCreditNotes.
+func (mr *MockCreditNoteRepositoryMockRecorder) GetInvoiceCreditNotes(ctx, invoiceID any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetInvoiceCreditNotes", reflect.TypeOf((*MockCreditNoteRepository)(nil).GetInvoiceCreditNotes), ctx, invoiceID)
+}
@@ -55,3 +55,17 @@ func (mr *MockCustomerRepositoryMockRecorder) GetCustomerByID(ctx, customerID an
 	mr.mock.ctrl.T.Helper()
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetCustomerByID", reflect.TypeOf((*MockCustomerRepository)(nil).GetCustomerByID), ctx, customerID)
 }
+
+// UpdateBranding mocks base method.
+func (m *MockCustomerRepository) UpdateBranding(ctx context.Context, customer *models.Customer) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "UpdateBranding", ctx, customer)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// UpdateBranding indicates an expected call of UpdateBranding.
+func (mr *MockCustomerRepositoryMockRecorder) UpdateBranding(ctx, customer any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UpdateBranding", reflect.TypeOf((*MockCustomerRepository)(nil).UpdateBranding), ctx, customer)
+}
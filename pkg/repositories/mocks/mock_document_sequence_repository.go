@@ -0,0 +1,72 @@
+// Code generated by MockGen. DO NOT EDIT.
+// Source: pkg/repositories/interfaces/document_sequence_repository.interface.go
+//
+// Generated by this command:
+//
+//	mockgen -source=pkg/repositories/interfaces/document_sequence_repository.interface.go -destination=pkg/repositories/mocks/mock_document_sequence_repository.go -package=repository_mocks
+//
+
+// Package repository_mocks is a generated GoMock package.
+package repository_mocks
+
+import (
+	context "context"
+	reflect "reflect"
+
+	models "github.com/Adebayobenjamin/numerisbook/pkg/models"
+	gomock "go.uber.org/mock/gomock"
+)
+
+// MockDocumentSequenceRepository is a mock of DocumentSequenceRepository interface.
+type MockDocumentSequenceRepository struct {
+	ctrl     *gomock.Controller
+	recorder *MockDocumentSequenceRepositoryMockRecorder
+	isgomock struct{}
+}
+
+// MockDocumentSequenceRepositoryMockRecorder is the mock recorder for MockDocumentSequenceRepository.
+type MockDocumentSequenceRepositoryMockRecorder struct {
+	mock *MockDocumentSequenceRepository
+}
+
+// NewMockDocumentSequenceRepository creates a new mock instance.
+func NewMockDocumentSequenceRepository(ctrl *gomock.Controller) *MockDocumentSequenceRepository {
+	mock := &MockDocumentSequenceRepository{ctrl: ctrl}
+	mock.recorder = &MockDocumentSequenceRepositoryMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockDocumentSequenceRepository) EXPECT() *MockDocumentSequenceRepositoryMockRecorder {
+	return m.recorder
+}
+
+// GetSequence mocks base method.
+func (m *MockDocumentSequenceRepository) GetSequence(ctx context.Context, customerID uint, documentType models.DocumentType) (*models.DocumentSequence, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetSequence", ctx, customerID, documentType)
+	ret0, _ := ret[0].(*models.DocumentSequence)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetSequence indicates an expected call of GetSequence.
+func (mr *MockDocumentSequenceRepositoryMockRecorder) GetSequence(ctx, customerID, documentType any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetSequence", reflect.TypeOf((*MockDocumentSequenceRepository)(nil).GetSequence), ctx, customerID, documentType)
+}
+
+// UpdateSequenceSettings mocks base method.
+func (m *MockDocumentSequenceRepository) UpdateSequenceSettings(ctx context.Context, sequence *models.DocumentSequence) (*models.DocumentSequence, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "UpdateSequenceSettings", ctx, sequence)
+	ret0, _ := ret[0].(*models.DocumentSequence)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// UpdateSequenceSettings indicates an expected call of UpdateSequenceSettings.
+func (mr *MockDocumentSequenceRepositoryMockRecorder) UpdateSequenceSettings(ctx, sequence any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UpdateSequenceSettings", reflect.TypeOf((*MockDocumentSequenceRepository)(nil).UpdateSequenceSettings), ctx, sequence)
+}
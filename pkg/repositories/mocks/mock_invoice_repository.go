@@ -12,6 +12,7 @@ package repository_mocks
 import (
 	context "context"
 	reflect "reflect"
+	time "time"
 
 	response_dto "github.com/Adebayobenjamin/numerisbook/pkg/dtos/response"
 	models "github.com/Adebayobenjamin/numerisbook/pkg/models"
@@ -57,49 +58,63 @@ func (mr *MockInvoiceRepositoryMockRecorder) CreateInvoiceWithItems(ctx, invoice
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CreateInvoiceWithItems", reflect.TypeOf((*MockInvoiceRepository)(nil).CreateInvoiceWithItems), ctx, invoice)
 }
 
+// DeleteDraftInvoice mocks base method.
+func (m *MockInvoiceRepository) DeleteDraftInvoice(ctx context.Context, invoice *models.Invoice, version uint, auditTrails []models.AuditTrail) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "DeleteDraftInvoice", ctx, invoice, version, auditTrails)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// DeleteDraftInvoice indicates an expected call of DeleteDraftInvoice.
+func (mr *MockInvoiceRepositoryMockRecorder) DeleteDraftInvoice(ctx, invoice, version, auditTrails any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeleteDraftInvoice", reflect.TypeOf((*MockInvoiceRepository)(nil).DeleteDraftInvoice), ctx, invoice, version, auditTrails)
+}
+
 // DuplicateInvoice mocks base method.
-func (m *MockInvoiceRepository) DuplicateInvoice(ctx context.Context, invoice *models.Invoice) (*models.Invoice, error) {
+func (m *MockInvoiceRepository) DuplicateInvoice(ctx context.Context, invoice *models.Invoice, issueDate, dueDate time.Time) (*models.Invoice, error) {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "DuplicateInvoice", ctx, invoice)
+	ret := m.ctrl.Call(m, "DuplicateInvoice", ctx, invoice, issueDate, dueDate)
 	ret0, _ := ret[0].(*models.Invoice)
 	ret1, _ := ret[1].(error)
 	return ret0, ret1
 }
 
 // DuplicateInvoice indicates an expected call of DuplicateInvoice.
-func (mr *MockInvoiceRepositoryMockRecorder) DuplicateInvoice(ctx, invoice any) *gomock.Call {
+func (mr *MockInvoiceRepositoryMockRecorder) DuplicateInvoice(ctx, invoice, issueDate, dueDate any) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DuplicateInvoice", reflect.TypeOf((*MockInvoiceRepository)(nil).DuplicateInvoice), ctx, invoice)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DuplicateInvoice", reflect.TypeOf((*MockInvoiceRepository)(nil).DuplicateInvoice), ctx, invoice, issueDate, dueDate)
 }
 
 // GetAllCustomerInvoices mocks base method.
-func (m *MockInvoiceRepository) GetAllCustomerInvoices(ctx context.Context, customerID uint, limit, offset int) ([]models.Invoice, error) {
+func (m *MockInvoiceRepository) GetAllCustomerInvoices(ctx context.Context, limit, offset int) ([]models.Invoice, error) {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "GetAllCustomerInvoices", ctx, customerID, limit, offset)
+	ret := m.ctrl.Call(m, "GetAllCustomerInvoices", ctx, limit, offset)
 	ret0, _ := ret[0].([]models.Invoice)
 	ret1, _ := ret[1].(error)
 	return ret0, ret1
 }
 
 // GetAllCustomerInvoices indicates an expected call of GetAllCustomerInvoices.
-func (mr *MockInvoiceRepositoryMockRecorder) GetAllCustomerInvoices(ctx, customerID, limit, offset any) *gomock.Call {
+func (mr *MockInvoiceRepositoryMockRecorder) GetAllCustomerInvoices(ctx, limit, offset any) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetAllCustomerInvoices", reflect.TypeOf((*MockInvoiceRepository)(nil).GetAllCustomerInvoices), ctx, customerID, limit, offset)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetAllCustomerInvoices", reflect.TypeOf((*MockInvoiceRepository)(nil).GetAllCustomerInvoices), ctx, limit, offset)
 }
 
 // GetByIDAndCutomerID mocks base method.
-func (m *MockInvoiceRepository) GetByIDAndCutomerID(ctx context.Context, id, customerID uint) (*models.Invoice, error) {
+func (m *MockInvoiceRepository) GetByIDAndCutomerID(ctx context.Context, id uint) (*models.Invoice, error) {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "GetByIDAndCutomerID", ctx, id, customerID)
+	ret := m.ctrl.Call(m, "GetByIDAndCutomerID", ctx, id)
 	ret0, _ := ret[0].(*models.Invoice)
 	ret1, _ := ret[1].(error)
 	return ret0, ret1
 }
 
 // GetByIDAndCutomerID indicates an expected call of GetByIDAndCutomerID.
-func (mr *MockInvoiceRepositoryMockRecorder) GetByIDAndCutomerID(ctx, id, customerID any) *gomock.Call {
+func (mr *MockInvoiceRepositoryMockRecorder) GetByIDAndCutomerID(ctx, id any) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetByIDAndCutomerID", reflect.TypeOf((*MockInvoiceRepository)(nil).GetByIDAndCutomerID), ctx, id, customerID)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetByIDAndCutomerID", reflect.TypeOf((*MockInvoiceRepository)(nil).GetByIDAndCutomerID), ctx, id)
 }
 
 // GetDetails mocks base method.
@@ -117,37 +132,67 @@ func (mr *MockInvoiceRepositoryMockRecorder) GetDetails(ctx, invoiceID any) *gom
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetDetails", reflect.TypeOf((*MockInvoiceRepository)(nil).GetDetails), ctx, invoiceID)
 }
 
+// GetPastDueInvoices mocks base method.
+func (m *MockInvoiceRepository) GetPastDueInvoices(ctx context.Context, now time.Time, limit int) ([]models.Invoice, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetPastDueInvoices", ctx, now, limit)
+	ret0, _ := ret[0].([]models.Invoice)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetPastDueInvoices indicates an expected call of GetPastDueInvoices.
+func (mr *MockInvoiceRepositoryMockRecorder) GetPastDueInvoices(ctx, now, limit any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetPastDueInvoices", reflect.TypeOf((*MockInvoiceRepository)(nil).GetPastDueInvoices), ctx, now, limit)
+}
+
 // GetStatistics mocks base method.
-func (m *MockInvoiceRepository) GetStatistics(ctx context.Context, customerID uint) (*response_dto.GetInvoiceStatisticsResponse, error) {
+func (m *MockInvoiceRepository) GetStatistics(ctx context.Context) (*response_dto.GetInvoiceStatisticsResponse, error) {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "GetStatistics", ctx, customerID)
+	ret := m.ctrl.Call(m, "GetStatistics", ctx)
 	ret0, _ := ret[0].(*response_dto.GetInvoiceStatisticsResponse)
 	ret1, _ := ret[1].(error)
 	return ret0, ret1
 }
 
 // GetStatistics indicates an expected call of GetStatistics.
-func (mr *MockInvoiceRepositoryMockRecorder) GetStatistics(ctx, customerID any) *gomock.Call {
+func (mr *MockInvoiceRepositoryMockRecorder) GetStatistics(ctx any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetStatistics", reflect.TypeOf((*MockInvoiceRepository)(nil).GetStatistics), ctx)
+}
+
+// UpdateDraftInvoice mocks base method.
+func (m *MockInvoiceRepository) UpdateDraftInvoice(ctx context.Context, invoice *models.Invoice, auditTrails []models.AuditTrail) (*models.Invoice, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "UpdateDraftInvoice", ctx, invoice, auditTrails)
+	ret0, _ := ret[0].(*models.Invoice)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// UpdateDraftInvoice indicates an expected call of UpdateDraftInvoice.
+func (mr *MockInvoiceRepositoryMockRecorder) UpdateDraftInvoice(ctx, invoice, auditTrails any) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetStatistics", reflect.TypeOf((*MockInvoiceRepository)(nil).GetStatistics), ctx, customerID)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UpdateDraftInvoice", reflect.TypeOf((*MockInvoiceRepository)(nil).UpdateDraftInvoice), ctx, invoice, auditTrails)
 }
 
 // UpdateInvoiceStatus mocks base method.
-func (m *MockInvoiceRepository) UpdateInvoiceStatus(ctx context.Context, invoiceID uint, status models.InvoiceStatus) error {
+func (m *MockInvoiceRepository) UpdateInvoiceStatus(ctx context.Context, invoiceID uint, from, to models.InvoiceStatus, auditTrails []models.AuditTrail) error {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "UpdateInvoiceStatus", ctx, invoiceID, status)
+	ret := m.ctrl.Call(m, "UpdateInvoiceStatus", ctx, invoiceID, from, to, auditTrails)
 	ret0, _ := ret[0].(error)
 	return ret0
 }
 
 // UpdateInvoiceStatus indicates an expected call of UpdateInvoiceStatus.
-func (mr *MockInvoiceRepositoryMockRecorder) UpdateInvoiceStatus(ctx, invoiceID, status any) *gomock.Call {
+func (mr *MockInvoiceRepositoryMockRecorder) UpdateInvoiceStatus(ctx, invoiceID, from, to, auditTrails any) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UpdateInvoiceStatus", reflect.TypeOf((*MockInvoiceRepository)(nil).UpdateInvoiceStatus), ctx, invoiceID, status)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UpdateInvoiceStatus", reflect.TypeOf((*MockInvoiceRepository)(nil).UpdateInvoiceStatus), ctx, invoiceID, from, to, auditTrails)
 }
 
 // UpdateShareableLink mocks base method.
-func (m *MockInvoiceRepository) UpdateShareableLink(ctx context.Context, invoiceID uint, link string) error {
+func (m *MockInvoiceRepository) UpdateShareableLink(ctx context.Context, invoiceID uint, link *string) error {
 	m.ctrl.T.Helper()
 	ret := m.ctrl.Call(m, "UpdateShareableLink", ctx, invoiceID, link)
 	ret0, _ := ret[0].(error)
@@ -13,7 +13,9 @@ import (
 	context "context"
 	reflect "reflect"
 
+	money "github.com/Adebayobenjamin/numerisbook/pkg/common/money"
 	models "github.com/Adebayobenjamin/numerisbook/pkg/models"
+	repositories_interfaces "github.com/Adebayobenjamin/numerisbook/pkg/repositories/interfaces"
 	gomock "go.uber.org/mock/gomock"
 )
 
@@ -41,25 +43,56 @@ func (m *MockPaymentRepository) EXPECT() *MockPaymentRepositoryMockRecorder {
 	return m.recorder
 }
 
-// CreatePayment mocks base method.
-func (m *MockPaymentRepository) CreatePayment(ctx context.Context, payment *models.Payment) error {
+// ApplyCredit mocks base method.
+func (m *MockPaymentRepository) ApplyCredit(ctx context.Context, clientID uint, payment *models.Payment, settle repositories_interfaces.SettleCredit) (*models.Invoice, error) {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "CreatePayment", ctx, payment)
-	ret0, _ := ret[0].(error)
-	return ret0
+	ret := m.ctrl.Call(m, "ApplyCredit", ctx, clientID, payment, settle)
+	ret0, _ := ret[0].(*models.Invoice)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ApplyCredit indicates an expected call of ApplyCredit.
+func (mr *MockPaymentRepositoryMockRecorder) ApplyCredit(ctx, clientID, payment, settle any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ApplyCredit", reflect.TypeOf((*MockPaymentRepository)(nil).ApplyCredit), ctx, clientID, payment, settle)
+}
+
+// GetAllCustomerPayments mocks base method.
+func (m *MockPaymentRepository) GetAllCustomerPayments(ctx context.Context, customerID uint, method models.PaymentMethod, invoiceID uint, limit, offset int) ([]models.Payment, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetAllCustomerPayments", ctx, customerID, method, invoiceID, limit, offset)
+	ret0, _ := ret[0].([]models.Payment)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetAllCustomerPayments indicates an expected call of GetAllCustomerPayments.
+func (mr *MockPaymentRepositoryMockRecorder) GetAllCustomerPayments(ctx, customerID, method, invoiceID, limit, offset any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetAllCustomerPayments", reflect.TypeOf((*MockPaymentRepository)(nil).GetAllCustomerPayments), ctx, customerID, method, invoiceID, limit, offset)
+}
+
+// GetByIDAndCustomerID mocks base method.
+func (m *MockPaymentRepository) GetByIDAndCustomerID(ctx context.Context, id, customerID uint) (*models.Payment, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetByIDAndCustomerID", ctx, id, customerID)
+	ret0, _ := ret[0].(*models.Payment)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
 }
 
-// CreatePayment indicates an expected call of CreatePayment.
-func (mr *MockPaymentRepositoryMockRecorder) CreatePayment(ctx, payment any) *gomock.Call {
+// GetByIDAndCustomerID indicates an expected call of GetByIDAndCustomerID.
+func (mr *MockPaymentRepositoryMockRecorder) GetByIDAndCustomerID(ctx, id, customerID any) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CreatePayment", reflect.TypeOf((*MockPaymentRepository)(nil).CreatePayment), ctx, payment)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetByIDAndCustomerID", reflect.TypeOf((*MockPaymentRepository)(nil).GetByIDAndCustomerID), ctx, id, customerID)
 }
 
 // GetTotalInvoicePayments mocks base method.
-func (m *MockPaymentRepository) GetTotalInvoicePayments(ctx context.Context, invoiceID uint) (float64, error) {
+func (m *MockPaymentRepository) GetTotalInvoicePayments(ctx context.Context, invoiceID uint) (money.Money, error) {
 	m.ctrl.T.Helper()
 	ret := m.ctrl.Call(m, "GetTotalInvoicePayments", ctx, invoiceID)
-	ret0, _ := ret[0].(float64)
+	ret0, _ := ret[0].(money.Money)
 	ret1, _ := ret[1].(error)
 	return ret0, ret1
 }
@@ -69,3 +102,33 @@ func (mr *MockPaymentRepositoryMockRecorder) GetTotalInvoicePayments(ctx, invoic
 	mr.mock.ctrl.T.Helper()
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetTotalInvoicePayments", reflect.TypeOf((*MockPaymentRepository)(nil).GetTotalInvoicePayments), ctx, invoiceID)
 }
+
+// RecordPayment mocks base method.
+func (m *MockPaymentRepository) RecordPayment(ctx context.Context, payment *models.Payment, settle repositories_interfaces.SettlePayment) (*models.Invoice, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "RecordPayment", ctx, payment, settle)
+	ret0, _ := ret[0].(*models.Invoice)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// RecordPayment indicates an expected call of RecordPayment.
+func (mr *MockPaymentRepositoryMockRecorder) RecordPayment(ctx, payment, settle any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "RecordPayment", reflect.TypeOf((*MockPaymentRepository)(nil).RecordPayment), ctx, payment, settle)
+}
+
+// RecordReturn mocks base method.
+func (m *MockPaymentRepository) RecordReturn(ctx context.Context, entry *models.Payment, settle repositories_interfaces.SettleReturn) (*models.Invoice, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "RecordReturn", ctx, entry, settle)
+	ret0, _ := ret[0].(*models.Invoice)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// RecordReturn indicates an expected call of RecordReturn.
+func (mr *MockPaymentRepositoryMockRecorder) RecordReturn(ctx, entry, settle any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "RecordReturn", reflect.TypeOf((*MockPaymentRepository)(nil).RecordReturn), ctx, entry, settle)
+}
@@ -0,0 +1,174 @@
+// Code generated by MockGen. DO NOT EDIT.
+// Source: pkg/repositories/interfaces/quote_repository.interface.go
+//
+// Generated by this command:
+//
+//	mockgen -source=pkg/repositories/interfaces/quote_repository.interface.go -destination=pkg/repositories/mocks/mock_quote_repository.go -package=repository_mocks
+//
+
+// Package repository_mocks is a generated GoMock package.
+package repository_mocks
+
+import (
+	context "context"
+	reflect "reflect"
+	time "time"
+
+	models "github.com/Adebayobenjamin/numerisbook/pkg/models"
+	gomock "go.uber.org/mock/gomock"
+)
+
+// MockQuoteRepository is a mock of QuoteRepository interface.
+type MockQuoteRepository struct {
+	ctrl     *gomock.Controller
+	recorder *MockQuoteRepositoryMockRecorder
+	isgomock struct{}
+}
+
+// MockQuoteRepositoryMockRecorder is the mock recorder for MockQuoteRepository.
+type MockQuoteRepositoryMockRecorder struct {
+	mock *MockQuoteRepository
+}
+
+// NewMockQuoteRepository creates a new mock instance.
+func NewMockQuoteRepository(ctrl *gomock.Controller) *MockQuoteRepository {
+	mock := &MockQuoteRepository{ctrl: ctrl}
+	mock.recorder = &MockQuoteRepositoryMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockQuoteRepository) EXPECT() *MockQuoteRepositoryMockRecorder {
+	return m.recorder
+}
+
+// ClaimForConversion mocks base method.
+func (m *MockQuoteRepository) ClaimForConversion(ctx context.Context, id, customerID uint, claimedAt time.Time) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ClaimForConversion", ctx, id, customerID, claimedAt)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// ClaimForConversion indicates an expected call of ClaimForConversion.
+func (mr *MockQuoteRepositoryMockRecorder) ClaimForConversion(ctx, id, customerID, claimedAt any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ClaimForConversion", reflect.TypeOf((*MockQuoteRepository)(nil).ClaimForConversion), ctx, id, customerID, claimedAt)
+}
+
+// Create mocks base method.
+func (m *MockQuoteRepository) Create(ctx context.Context, quote *models.Quote) (*models.Quote, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Create", ctx, quote)
+	ret0, _ := ret[0].(*models.Quote)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Create indicates an expected call of Create.
+func (mr *MockQuoteRepositoryMockRecorder) Create(ctx, quote any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Create", reflect.TypeOf((*MockQuoteRepository)(nil).Create), ctx, quote)
+}
+
+// ExpireLapsed mocks base method.
+func (m *MockQuoteRepository) ExpireLapsed(ctx context.Context, now time.Time) (int64, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ExpireLapsed", ctx, now)
+	ret0, _ := ret[0].(int64)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ExpireLapsed indicates an expected call of ExpireLapsed.
+func (mr *MockQuoteRepositoryMockRecorder) ExpireLapsed(ctx, now any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ExpireLapsed", reflect.TypeOf((*MockQuoteRepository)(nil).ExpireLapsed), ctx, now)
+}
+
+// GetAllCustomerQuotes mocks base method.
+func (m *MockQuoteRepository) GetAllCustomerQuotes(ctx context.Context, customerID uint, limit, offset int) ([]models.Quote, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetAllCustomerQuotes", ctx, customerID, limit, offset)
+	ret0, _ := ret[0].([]models.Quote)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetAllCustomerQuotes indicates an expected call of GetAllCustomerQuotes.
+func (mr *MockQuoteRepositoryMockRecorder) GetAllCustomerQuotes(ctx, customerID, limit, offset any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetAllCustomerQuotes", reflect.TypeOf((*MockQuoteRepository)(nil).GetAllCustomerQuotes), ctx, customerID, limit, offset)
+}
+
+// GetByIDAndCustomerID mocks base method.
+func (m *MockQuoteRepository) GetByIDAndCustomerID(ctx context.Context, id, customerID uint) (*models.Quote, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetByIDAndCustomerID", ctx, id, customerID)
+	ret0, _ := ret[0].(*models.Quote)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetByIDAndCustomerID indicates an expected call of GetByIDAndCustomerID.
+func (mr *MockQuoteRepositoryMockRecorder) GetByIDAndCustomerID(ctx, id, customerID any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetByIDAndCustomerID", reflect.TypeOf((*MockQuoteRepository)(nil).GetByIDAndCustomerID), ctx, id, customerID)
+}
+
+// GetByShareTokenID mocks base method.
+func (m *MockQuoteRepository) GetByShareTokenID(ctx context.Context, tokenID string) (*models.Quote, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetByShareTokenID", ctx, tokenID)
+	ret0, _ := ret[0].(*models.Quote)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetByShareTokenID indicates an expected call of GetByShareTokenID.
+func (mr *MockQuoteRepositoryMockRecorder) GetByShareTokenID(ctx, tokenID any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetByShareTokenID", reflect.TypeOf((*MockQuoteRepository)(nil).GetByShareTokenID), ctx, tokenID)
+}
+
+// ReleaseConversion mocks base method.
+func (m *MockQuoteRepository) ReleaseConversion(ctx context.Context, id, customerID uint) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ReleaseConversion", ctx, id, customerID)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// ReleaseConversion indicates an expected call of ReleaseConversion.
+func (mr *MockQuoteRepositoryMockRecorder) ReleaseConversion(ctx, id, customerID any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ReleaseConversion", reflect.TypeOf((*MockQuoteRepository)(nil).ReleaseConversion), ctx, id, customerID)
+}
+
+// SetInvoice mocks base method.
+func (m *MockQuoteRepository) SetInvoice(ctx context.Context, id, customerID, invoiceID uint) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "SetInvoice", ctx, id, customerID, invoiceID)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// SetInvoice indicates an expected call of SetInvoice.
+func (mr *MockQuoteRepositoryMockRecorder) SetInvoice(ctx, id, customerID, invoiceID any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SetInvoice", reflect.TypeOf((*MockQuoteRepository)(nil).SetInvoice), ctx, id, customerID, invoiceID)
+}
+
+// UpdateStatus mocks base method.
+func (m *MockQuoteRepository) UpdateStatus(ctx context.Context, quote *models.Quote, from models.QuoteStatus) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "UpdateStatus", ctx, quote, from)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// UpdateStatus indicates an expected call of UpdateStatus.
+func (mr *MockQuoteRepositoryMockRecorder) UpdateStatus(ctx, quote, from any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UpdateStatus", reflect.TypeOf((*MockQuoteRepository)(nil).UpdateStatus), ctx, quote, from)
+}
@@ -0,0 +1,118 @@
+// Code generated by MockGen. DO NOT EDIT.
+// Source: pkg/repositories/interfaces/recurring_invoice_repository.interface.go
+//
+// Generated by this command:
+//
+//	mockgen -source=pkg/repositories/interfaces/recurring_invoice_repository.interface.go -destination=pkg/repositories/mocks/mock_recurring_invoice_repository.go -package=repository_mocks
+//
+
+// Package repository_mocks is a generated GoMock package.
+package repository_mocks
+
+import (
+	context "context"
+	reflect "reflect"
+	time "time"
+
+	models "github.com/Adebayobenjamin/numerisbook/pkg/models"
+	gomock "go.uber.org/mock/gomock"
+)
+
+// MockRecurringInvoiceRepository is a mock of RecurringInvoiceRepository interface.
+type MockRecurringInvoiceRepository struct {
+	ctrl     *gomock.Controller
+	recorder *MockRecurringInvoiceRepositoryMockRecorder
+	isgomock struct{}
+}
+
+// MockRecurringInvoiceRepositoryMockRecorder is the mock recorder for MockRecurringInvoiceRepository.
+type MockRecurringInvoiceRepositoryMockRecorder struct {
+	mock *MockRecurringInvoiceRepository
+}
+
+// NewMockRecurringInvoiceRepository creates a new mock instance.
+func NewMockRecurringInvoiceRepository(ctrl *gomock.Controller) *MockRecurringInvoiceRepository {
+	mock := &MockRecurringInvoiceRepository{ctrl: ctrl}
+	mock.recorder = &MockRecurringInvoiceRepositoryMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockRecurringInvoiceRepository) EXPECT() *MockRecurringInvoiceRepositoryMockRecorder {
+	return m.recorder
+}
+
+// CreateProfile mocks base method.
+func (m *MockRecurringInvoiceRepository) CreateProfile(ctx context.Context, profile *models.RecurringInvoiceProfile) (*models.RecurringInvoiceProfile, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CreateProfile", ctx, profile)
+	ret0, _ := ret[0].(*models.RecurringInvoiceProfile)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// CreateProfile indicates an expected call of CreateProfile.
+func (mr *MockRecurringInvoiceRepositoryMockRecorder) CreateProfile(ctx, profile any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CreateProfile", reflect.TypeOf((*MockRecurringInvoiceRepository)(nil).CreateProfile), ctx, profile)
+}
+
+// GetAllCustomerProfiles mocks base method.
+func (m *MockRecurringInvoiceRepository) GetAllCustomerProfiles(ctx context.Context, customerID uint, limit, offset int) ([]models.RecurringInvoiceProfile, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetAllCustomerProfiles", ctx, customerID, limit, offset)
+	ret0, _ := ret[0].([]models.RecurringInvoiceProfile)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetAllCustomerProfiles indicates an expected call of GetAllCustomerProfiles.
+func (mr *MockRecurringInvoiceRepositoryMockRecorder) GetAllCustomerProfiles(ctx, customerID, limit, offset any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetAllCustomerProfiles", reflect.TypeOf((*MockRecurringInvoiceRepository)(nil).GetAllCustomerProfiles), ctx, customerID, limit, offset)
+}
+
+// GetByIDAndCustomerID mocks base method.
+func (m *MockRecurringInvoiceRepository) GetByIDAndCustomerID(ctx context.Context, id, customerID uint) (*models.RecurringInvoiceProfile, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetByIDAndCustomerID", ctx, id, customerID)
+	ret0, _ := ret[0].(*models.RecurringInvoiceProfile)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetByIDAndCustomerID indicates an expected call of GetByIDAndCustomerID.
+func (mr *MockRecurringInvoiceRepositoryMockRecorder) GetByIDAndCustomerID(ctx, id, customerID any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetByIDAndCustomerID", reflect.TypeOf((*MockRecurringInvoiceRepository)(nil).GetByIDAndCustomerID), ctx, id, customerID)
+}
+
+// GetDueProfiles mocks base method.
+func (m *MockRecurringInvoiceRepository) GetDueProfiles(ctx context.Context, now time.Time, limit int) ([]models.RecurringInvoiceProfile, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetDueProfiles", ctx, now, limit)
+	ret0, _ := ret[0].([]models.RecurringInvoiceProfile)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetDueProfiles indicates an expected call of GetDueProfiles.
+func (mr *MockRecurringInvoiceRepositoryMockRecorder) GetDueProfiles(ctx, now, limit any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetDueProfiles", reflect.TypeOf((*MockRecurringInvoiceRepository)(nil).GetDueProfiles), ctx, now, limit)
+}
+
+// UpdateSchedule mocks base method.
+func (m *MockRecurringInvoiceRepository) UpdateSchedule(ctx context.Context, profile *models.RecurringInvoiceProfile, expectedNextRunAt *time.Time) (bool, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "UpdateSchedule", ctx, profile, expectedNextRunAt)
+	ret0, _ := ret[0].(bool)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// UpdateSchedule indicates an expected call of UpdateSchedule.
+func (mr *MockRecurringInvoiceRepositoryMockRecorder) UpdateSchedule(ctx, profile, expectedNextRunAt any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UpdateSchedule", reflect.TypeOf((*MockRecurringInvoiceRepository)(nil).UpdateSchedule), ctx, profile, expectedNextRunAt)
+}
@@ -12,6 +12,7 @@ package repository_mocks
 import (
 	context "context"
 	reflect "reflect"
+	time "time"
 
 	models "github.com/Adebayobenjamin/numerisbook/pkg/models"
 	gomock "go.uber.org/mock/gomock"
@@ -41,6 +42,49 @@ func (m *MockReminderRepository) EXPECT() *MockReminderRepositoryMockRecorder {
 	return m.recorder
 }
 
+// ClaimDueReminders mocks base method.
+func (m *MockReminderRepository) ClaimDueReminders(ctx context.Context, now time.Time, lease time.Duration, maxAttempts, limit int) ([]models.InvoiceReminder, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ClaimDueReminders", ctx, now, lease, maxAttempts, limit)
+	ret0, _ := ret[0].([]models.InvoiceReminder)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ClaimDueReminders indicates an expected call of ClaimDueReminders.
+func (mr *MockReminderRepositoryMockRecorder) ClaimDueReminders(ctx, now, lease, maxAttempts, limit any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ClaimDueReminders", reflect.TypeOf((*MockReminderRepository)(nil).ClaimDueReminders), ctx, now, lease, maxAttempts, limit)
+}
+
+// MarkReminderFailed mocks base method.
+func (m *MockReminderRepository) MarkReminderFailed(ctx context.Context, reminderID uint, lastError string, nextAttemptAt *time.Time) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "MarkReminderFailed", ctx, reminderID, lastError, nextAttemptAt)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// MarkReminderFailed indicates an expected call of MarkReminderFailed.
+func (mr *MockReminderRepositoryMockRecorder) MarkReminderFailed(ctx, reminderID, lastError, nextAttemptAt any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "MarkReminderFailed", reflect.TypeOf((*MockReminderRepository)(nil).MarkReminderFailed), ctx, reminderID, lastError, nextAttemptAt)
+}
+
+// MarkReminderSent mocks base method.
+func (m *MockReminderRepository) MarkReminderSent(ctx context.Context, reminderID uint, sentAt time.Time) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "MarkReminderSent", ctx, reminderID, sentAt)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// MarkReminderSent indicates an expected call of MarkReminderSent.
+func (mr *MockReminderRepositoryMockRecorder) MarkReminderSent(ctx, reminderID, sentAt any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "MarkReminderSent", reflect.TypeOf((*MockReminderRepository)(nil).MarkReminderSent), ctx, reminderID, sentAt)
+}
+
 // UpsertReminders mocks base method.
 func (m *MockReminderRepository) UpsertReminders(ctx context.Context, reminders []models.InvoiceReminder) error {
 	m.ctrl.T.Helper()
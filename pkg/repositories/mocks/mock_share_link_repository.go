@@ -0,0 +1,117 @@
+// Code generated by MockGen. DO NOT EDIT.
+// Source: pkg/repositories/interfaces/share_link_repository.interface.go
+//
+// Generated by this command:
+//
+//	mockgen -source=pkg/repositories/interfaces/share_link_repository.interface.go -destination=pkg/repositories/mocks/mock_share_link_repository.go -package=repository_mocks
+//
+
+// Package repository_mocks is a generated GoMock package.
+package repository_mocks
+
+import (
+	context "context"
+	reflect "reflect"
+	time "time"
+
+	models "github.com/Adebayobenjamin/numerisbook/pkg/models"
+	gomock "go.uber.org/mock/gomock"
+)
+
+// MockShareLinkRepository is a mock of ShareLinkRepository interface.
+type MockShareLinkRepository struct {
+	ctrl     *gomock.Controller
+	recorder *MockShareLinkRepositoryMockRecorder
+	isgomock struct{}
+}
+
+// MockShareLinkRepositoryMockRecorder is the mock recorder for MockShareLinkRepository.
+type MockShareLinkRepositoryMockRecorder struct {
+	mock *MockShareLinkRepository
+}
+
+// NewMockShareLinkRepository creates a new mock instance.
+func NewMockShareLinkRepository(ctrl *gomock.Controller) *MockShareLinkRepository {
+	mock := &MockShareLinkRepository{ctrl: ctrl}
+	mock.recorder = &MockShareLinkRepositoryMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockShareLinkRepository) EXPECT() *MockShareLinkRepositoryMockRecorder {
+	return m.recorder
+}
+
+// Create mocks base method.
+func (m *MockShareLinkRepository) Create(ctx context.Context, link *models.InvoiceShareLink) (*models.InvoiceShareLink, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Create", ctx, link)
+	ret0, _ := ret[0].(*models.InvoiceShareLink)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Create indicates an expected call of Create.
+func (mr *MockShareLinkRepositoryMockRecorder) Create(ctx, link any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Create", reflect.TypeOf((*MockShareLinkRepository)(nil).Create), ctx, link)
+}
+
+// GetActiveByInvoiceID mocks base method.
+func (m *MockShareLinkRepository) GetActiveByInvoiceID(ctx context.Context, invoiceID uint, now time.Time) (*models.InvoiceShareLink, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetActiveByInvoiceID", ctx, invoiceID, now)
+	ret0, _ := ret[0].(*models.InvoiceShareLink)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetActiveByInvoiceID indicates an expected call of GetActiveByInvoiceID.
+func (mr *MockShareLinkRepositoryMockRecorder) GetActiveByInvoiceID(ctx, invoiceID, now any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetActiveByInvoiceID", reflect.TypeOf((*MockShareLinkRepository)(nil).GetActiveByInvoiceID), ctx, invoiceID, now)
+}
+
+// GetByTokenID mocks base method.
+func (m *MockShareLinkRepository) GetByTokenID(ctx context.Context, tokenID string) (*models.InvoiceShareLink, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetByTokenID", ctx, tokenID)
+	ret0, _ := ret[0].(*models.InvoiceShareLink)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetByTokenID indicates an expected call of GetByTokenID.
+func (mr *MockShareLinkRepositoryMockRecorder) GetByTokenID(ctx, tokenID any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetByTokenID", reflect.TypeOf((*MockShareLinkRepository)(nil).GetByTokenID), ctx, tokenID)
+}
+
+// RecordView mocks base method.
+func (m *MockShareLinkRepository) RecordView(ctx context.Context, id uint, viewedAt time.Time) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "RecordView", ctx, id, viewedAt)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// RecordView indicates an expected call of RecordView.
+func (mr *MockShareLinkRepositoryMockRecorder) RecordView(ctx, id, viewedAt any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "RecordView", reflect.TypeOf((*MockShareLinkRepository)(nil).RecordView), ctx, id, viewedAt)
+}
+
+// RevokeByInvoiceID mocks base method.
+func (m *MockShareLinkRepository) RevokeByInvoiceID(ctx context.Context, invoiceID uint, revokedAt time.Time) (int64, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "RevokeByInvoiceID", ctx, invoiceID, revokedAt)
+	ret0, _ := ret[0].(int64)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// RevokeByInvoiceID indicates an expected call of RevokeByInvoiceID.
+func (mr *MockShareLinkRepositoryMockRecorder) RevokeByInvoiceID(ctx, invoiceID, revokedAt any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "RevokeByInvoiceID", reflect.TypeOf((*MockShareLinkRepository)(nil).RevokeByInvoiceID), ctx, invoiceID, revokedAt)
+}
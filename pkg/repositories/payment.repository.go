@@ -2,9 +2,15 @@ package repositories
 
 import (
 	"context"
+	"database/sql"
+	"errors"
+	"fmt"
 
+	"github.com/Adebayobenjamin/numerisbook/pkg/common/exceptions"
+	"github.com/Adebayobenjamin/numerisbook/pkg/common/money"
 	"github.com/Adebayobenjamin/numerisbook/pkg/models"
 	repositories_interfaces "github.com/Adebayobenjamin/numerisbook/pkg/repositories/interfaces"
+	"github.com/Adebayobenjamin/numerisbook/pkg/tenant"
 	"github.com/jmoiron/sqlx"
 	"github.com/rs/zerolog"
 )
@@ -14,28 +20,484 @@ type paymentRepository struct {
 	logger *zerolog.Logger
 }
 
-// CreatePayment implements repositories_interfaces.PaymentRepository.
-func (p *paymentRepository) CreatePayment(ctx context.Context, payment *models.Payment) error {
-	query := `INSERT INTO payments (invoice_id, amount, is_partial, date, created_at, updated_at) VALUES (?, ?, ?, ?, CURRENT_TIMESTAMP, CURRENT_TIMESTAMP)`
+// RecordPayment implements repositories_interfaces.PaymentRepository.
+func (p *paymentRepository) RecordPayment(ctx context.Context, payment *models.Payment, settle repositories_interfaces.SettlePayment) (*models.Invoice, error) {
+	customerID, err := tenant.CustomerIDFromContext(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	tx, err := p.db.BeginTxx(ctx, nil)
+	if err != nil {
+		return nil, fmt.Errorf("failed to begin transaction: %w", err)
+	}
+	defer tx.Rollback()
+
+	// the row lock holds any other payment on the invoice until this one is
+	// committed, so each payment is checked against everything paid before it
+	invoice, err := lockInvoice(ctx, tx, payment.InvoiceID, customerID)
+	if err != nil {
+		return nil, err
+	}
+
+	paid, err := sumInvoicePayments(ctx, tx, invoice)
+	if err != nil {
+		return nil, err
+	}
+
+	auditTrails, err := settle(invoice, paid)
+	if err != nil {
+		return nil, err
+	}
+
+	// what was paid over the invoice's amount is kept as the client's credit
+	var credit *models.ClientCredit
+	if payment.Overpayment != nil && payment.Overpayment.IsPositive() {
+		if invoice.ClientID == nil {
+			return nil, fmt.Errorf("invoice %s has no client to credit the overpayment to", invoice.InvoiceNumber)
+		}
+		credit = &models.ClientCredit{
+			ClientID:    *invoice.ClientID,
+			Kind:        models.ClientCreditKindOverpayment,
+			Amount:      *payment.Overpayment,
+			Description: fmt.Sprintf("Overpayment of Invoice %s", invoice.InvoiceNumber),
+		}
+	}
+
+	if err := commitPayment(ctx, tx, invoice, payment, credit, auditTrails); err != nil {
+		return nil, err
+	}
+
+	return invoice, nil
+}
+
+// ApplyCredit implements repositories_interfaces.PaymentRepository.
+func (p *paymentRepository) ApplyCredit(ctx context.Context, clientID uint, payment *models.Payment, settle repositories_interfaces.SettleCredit) (*models.Invoice, error) {
+	customerID, err := tenant.CustomerIDFromContext(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	tx, err := p.db.BeginTxx(ctx, nil)
+	if err != nil {
+		return nil, fmt.Errorf("failed to begin transaction: %w", err)
+	}
+	defer tx.Rollback()
+
+	// the invoice is locked before the client, in the same order as an
+	// overpayment adds to the client's credit
+	invoice, err := lockInvoice(ctx, tx, payment.InvoiceID, customerID)
+	if err != nil {
+		return nil, err
+	}
+	if invoice.ClientID == nil || *invoice.ClientID != clientID {
+		return nil, fmt.Errorf("invoice %s is not billed to client %d", invoice.InvoiceNumber, clientID)
+	}
+
+	available, err := lockClientCredit(ctx, tx, clientID, customerID, invoice.BillingCurrency)
+	if err != nil {
+		return nil, err
+	}
+
+	paid, err := sumInvoicePayments(ctx, tx, invoice)
+	if err != nil {
+		return nil, err
+	}
+
+	auditTrails, err := settle(invoice, paid, available)
+	if err != nil {
+		return nil, err
+	}
+
+	credit := &models.ClientCredit{
+		ClientID:    clientID,
+		Kind:        models.ClientCreditKindApplied,
+		Amount:      payment.Amount.Neg(),
+		Description: fmt.Sprintf("Applied to Invoice %s", invoice.InvoiceNumber),
+	}
+	if err := commitPayment(ctx, tx, invoice, payment, credit, auditTrails); err != nil {
+		return nil, err
+	}
+
+	return invoice, nil
+}
+
+// RecordReturn implements repositories_interfaces.PaymentRepository.
+func (p *paymentRepository) RecordReturn(ctx context.Context, entry *models.Payment, settle repositories_interfaces.SettleReturn) (*models.Invoice, error) {
+	customerID, err := tenant.CustomerIDFromContext(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	if entry.OriginalPaymentID == nil {
+		return nil, fmt.Errorf("payment %w", exceptions.ErrNotFound)
+	}
+
+	tx, err := p.db.BeginTxx(ctx, nil)
+	if err != nil {
+		return nil, fmt.Errorf("failed to begin transaction: %w", err)
+	}
+	defer tx.Rollback()
+
+	var invoiceID uint
+	err = tx.GetContext(ctx, &invoiceID, `
+		SELECT invoice_id FROM payments 
+		WHERE id = ? AND deleted_at IS NULL`, *entry.OriginalPaymentID)
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return nil, fmt.Errorf("payment %w", exceptions.ErrNotFound)
+		}
+		return nil, fmt.Errorf("failed to get payment: %w", err)
+	}
+
+	// the invoice is locked before anything is read from the payment, in the
+	// same order as RecordPayment, so money cannot be taken back twice
+	invoice, err := lockInvoice(ctx, tx, invoiceID, customerID)
+	if err != nil {
+		if errors.Is(err, exceptions.ErrNotFound) {
+			return nil, fmt.Errorf("payment %w", exceptions.ErrNotFound)
+		}
+		return nil, err
+	}
+
+	var original models.Payment
+	err = tx.GetContext(ctx, &original, `SELECT * FROM payments WHERE id = ?`, *entry.OriginalPaymentID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get payment: %w", err)
+	}
+	if original.Amount, err = original.Amount.WithCurrency(invoice.BillingCurrency); err != nil {
+		return nil, fmt.Errorf("failed to read payment amounts: %w", err)
+	}
+
+	var returned money.Money
+	err = tx.GetContext(ctx, &returned, `
+		SELECT COALESCE(SUM(amount), 0) FROM payments 
+		WHERE original_payment_id = ? AND deleted_at IS NULL`, original.ID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get returned payments: %w", err)
+	}
+	if returned, err = returned.WithCurrency(invoice.BillingCurrency); err != nil {
+		return nil, fmt.Errorf("failed to read returned payments: %w", err)
+	}
+
+	paid, err := sumInvoicePayments(ctx, tx, invoice)
+	if err != nil {
+		return nil, err
+	}
+
+	// a reversal also undoes what the payment left in the client's credit,
+	// so settle is told what the payment overpaid
+	var overpaid *models.ClientCredit
+	if entry.Kind == models.PaymentKindReversal && original.Kind == models.PaymentKindPayment {
+		if overpaid, err = getOverpaymentCredit(ctx, tx, invoice, original.ID); err != nil {
+			return nil, err
+		}
+		if overpaid != nil {
+			original.Overpayment = &overpaid.Amount
+		}
+	}
+
+	// refunds and reversals are stored negative, settle is given what they took back
+	auditTrails, err := settle(invoice, paid, &original, returned.Neg())
+	if err != nil {
+		return nil, err
+	}
+
+	credit, err := reverseClientCredit(ctx, tx, invoice, entry, &original, overpaid)
+	if err != nil {
+		return nil, err
+	}
 
-	_, err := p.db.ExecContext(ctx, query, payment.InvoiceID, payment.Amount, payment.IsPartial, payment.Date)
+	if err := commitPayment(ctx, tx, invoice, entry, credit, auditTrails); err != nil {
+		return nil, err
+	}
+
+	return invoice, nil
+}
+
+// getOverpaymentCredit is the credit the payment's overpayment left with the
+// invoice's client, or nil when it did not overpay
+func getOverpaymentCredit(ctx context.Context, tx *sqlx.Tx, invoice *models.Invoice, paymentID uint) (*models.ClientCredit, error) {
+	var credit models.ClientCredit
+	err := tx.GetContext(ctx, &credit, `
+		SELECT * FROM client_credits 
+		WHERE payment_id = ? AND kind = 'overpayment'`, paymentID)
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return nil, nil
+		}
+		return nil, fmt.Errorf("failed to get overpayment credit: %w", err)
+	}
+	if credit.Amount, err = credit.Amount.WithCurrency(invoice.BillingCurrency); err != nil {
+		return nil, fmt.Errorf("failed to read overpayment credit: %w", err)
+	}
+
+	return &credit, nil
+}
+
+// reverseClientCredit is the entry a reversal makes in the client's credit
+// ledger: it takes back what the reversed payment overpaid, which must not
+// have been spent yet, or gives back credit the reversed payment applied
+func reverseClientCredit(ctx context.Context, tx *sqlx.Tx, invoice *models.Invoice, entry *models.Payment, original *models.Payment, overpaid *models.ClientCredit) (*models.ClientCredit, error) {
+	if entry.Kind != models.PaymentKindReversal {
+		return nil, nil
+	}
+
+	switch {
+	case original.Kind == models.PaymentKindPayment && overpaid != nil:
+		available, err := lockClientCredit(ctx, tx, overpaid.ClientID, invoice.CustomerID, invoice.BillingCurrency)
+		if err != nil {
+			return nil, err
+		}
+		comparison, err := available.Cmp(overpaid.Amount)
+		if err != nil {
+			return nil, fmt.Errorf("client credit currency does not match invoice: %w", err)
+		}
+		if comparison < 0 {
+			return nil, fmt.Errorf("payment %d cannot be reversed, the %s it overpaid was already applied to other invoices", original.ID, overpaid.Amount)
+		}
+
+		return &models.ClientCredit{
+			ClientID:    overpaid.ClientID,
+			Kind:        models.ClientCreditKindReversal,
+			Amount:      overpaid.Amount.Neg(),
+			Description: fmt.Sprintf("Reversal of Payment %d on Invoice %s", original.ID, invoice.InvoiceNumber),
+		}, nil
+
+	case original.Kind == models.PaymentKindCredit:
+		if invoice.ClientID == nil {
+			return nil, fmt.Errorf("invoice %s has no client to give the credit back to", invoice.InvoiceNumber)
+		}
+		if _, err := lockClientCredit(ctx, tx, *invoice.ClientID, invoice.CustomerID, invoice.BillingCurrency); err != nil {
+			return nil, err
+		}
+
+		return &models.ClientCredit{
+			ClientID:    *invoice.ClientID,
+			Kind:        models.ClientCreditKindReversal,
+			Amount:      entry.Amount.Neg(),
+			Description: fmt.Sprintf("Reversal of credit applied to Invoice %s", invoice.InvoiceNumber),
+		}, nil
+	}
+
+	return nil, nil
+}
+
+// lockInvoice reads the customer's invoice and holds its row lock until the
+// transaction ends
+func lockInvoice(ctx context.Context, tx *sqlx.Tx, invoiceID uint, customerID uint) (*models.Invoice, error) {
+	var invoice models.Invoice
+	err := tx.GetContext(ctx, &invoice, `
+		SELECT * FROM invoices 
+		WHERE id = ? AND customer_id = ? AND deleted_at IS NULL 
+		FOR UPDATE`, invoiceID, customerID)
 	if err != nil {
-		return err
+		if errors.Is(err, sql.ErrNoRows) {
+			return nil, fmt.Errorf("invoice %w", exceptions.ErrNotFound)
+		}
+		return nil, fmt.Errorf("failed to lock invoice: %w", err)
+	}
+	if err := invoice.AssignCurrency(); err != nil {
+		return nil, fmt.Errorf("failed to read invoice amounts: %w", err)
 	}
+
+	return &invoice, nil
+}
+
+// lockClientCredit reads the client's credit in currency and holds the
+// client's row lock until the transaction ends, so the same credit cannot be
+// used twice. It must run after the invoice is locked, in the same order
+// everywhere, so credit and payments cannot wait on each other. Deleted
+// clients are locked too, so a reversal can still take back their credit.
+func lockClientCredit(ctx context.Context, tx *sqlx.Tx, clientID uint, customerID uint, currency string) (money.Money, error) {
+	var lockedClientID uint
+	err := tx.GetContext(ctx, &lockedClientID, `
+		SELECT id FROM clients 
+		WHERE id = ? AND customer_id = ? 
+		FOR UPDATE`, clientID, customerID)
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return money.Money{}, fmt.Errorf("client %w", exceptions.ErrNotFound)
+		}
+		return money.Money{}, fmt.Errorf("failed to lock client: %w", err)
+	}
+
+	var available money.Money
+	err = tx.GetContext(ctx, &available, `
+		SELECT COALESCE(SUM(amount), 0) FROM client_credits 
+		WHERE client_id = ? AND currency = ?`, clientID, currency)
+	if err != nil {
+		return money.Money{}, fmt.Errorf("failed to get client credit: %w", err)
+	}
+	if available, err = available.WithCurrency(currency); err != nil {
+		return money.Money{}, fmt.Errorf("failed to read client credit: %w", err)
+	}
+
+	return available, nil
+}
+
+// sumInvoicePayments is what the invoice has been paid, after refunds and reversals
+func sumInvoicePayments(ctx context.Context, tx *sqlx.Tx, invoice *models.Invoice) (money.Money, error) {
+	var paid money.Money
+	err := tx.GetContext(ctx, &paid, `
+		SELECT COALESCE(SUM(amount), 0) FROM payments 
+		WHERE invoice_id = ? AND deleted_at IS NULL`, invoice.ID)
+	if err != nil {
+		return money.Money{}, fmt.Errorf("failed to get total invoice payments: %w", err)
+	}
+	if paid, err = paid.WithCurrency(invoice.BillingCurrency); err != nil {
+		return money.Money{}, fmt.Errorf("failed to read invoice payments: %w", err)
+	}
+
+	return paid, nil
+}
+
+// commitPayment stores payment against the locked invoice together with the
+// invoice's new status, the entry it makes in the client's credit ledger if
+// any, and the audit trail entries, and commits them
+func commitPayment(ctx context.Context, tx *sqlx.Tx, invoice *models.Invoice, payment *models.Payment, credit *models.ClientCredit, auditTrails []models.AuditTrail) error {
+	result, err := tx.ExecContext(ctx, `
+		INSERT INTO payments (
+			invoice_id, kind, original_payment_id, amount, is_partial, date, method, reference, payer_name, notes, reason, attachments, created_at, updated_at
+		) VALUES (?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, CURRENT_TIMESTAMP, CURRENT_TIMESTAMP)`,
+		invoice.ID, payment.Kind, payment.OriginalPaymentID, payment.Amount, payment.IsPartial, payment.Date, payment.Method,
+		payment.Reference, payment.PayerName, payment.Notes, payment.Reason, payment.Attachments)
+	if err != nil {
+		return fmt.Errorf("failed to create payment: %w", err)
+	}
+	paymentID, _ := result.LastInsertId()
+
+	if credit != nil {
+		_, err = tx.ExecContext(ctx, `
+			INSERT INTO client_credits (
+				customer_id, client_id, kind, amount, currency, payment_id, invoice_id, description, created_at, updated_at
+			) VALUES (?, ?, ?, ?, ?, ?, ?, ?, CURRENT_TIMESTAMP, CURRENT_TIMESTAMP)`,
+			invoice.CustomerID, credit.ClientID, credit.Kind, credit.Amount, invoice.BillingCurrency, paymentID, invoice.ID, credit.Description)
+		if err != nil {
+			return fmt.Errorf("failed to record client credit: %w", err)
+		}
+	}
+
+	_, err = tx.ExecContext(ctx, `
+		UPDATE invoices 
+		SET status = ?, is_fully_paid = ?, version = version + 1, updated_at = CURRENT_TIMESTAMP 
+		WHERE id = ? AND customer_id = ?`,
+		invoice.Status, invoice.IsFullyPaid, invoice.ID, invoice.CustomerID)
+	if err != nil {
+		return fmt.Errorf("failed to update invoice status: %w", err)
+	}
+
+	for _, trail := range auditTrails {
+		if err := insertAuditTrail(ctx, tx, trail.EventType, trail.LogLevel, trail.Message, invoice.ID, invoice.CustomerID); err != nil {
+			return err
+		}
+	}
+
+	if err := tx.Commit(); err != nil {
+		return fmt.Errorf("failed to commit transaction: %w", err)
+	}
+
+	payment.ID = uint(paymentID)
+	payment.InvoiceID = invoice.ID
+	invoice.Version++
 	return nil
 }
 
 // GetTotalInvoicePayments implements repositories_interfaces.PaymentRepository.
-func (p *paymentRepository) GetTotalInvoicePayments(ctx context.Context, invoiceID uint) (float64, error) {
-	query := `SELECT SUM(amount) as amount FROM payments WHERE invoice_id = ?`
+func (p *paymentRepository) GetTotalInvoicePayments(ctx context.Context, invoiceID uint) (money.Money, error) {
+	customerID, err := tenant.CustomerIDFromContext(ctx)
+	if err != nil {
+		return money.Money{}, err
+	}
+
+	query := `
+		SELECT i.billing_currency as currency, SUM(p.amount) as amount
+		FROM invoices i
+		LEFT JOIN payments p ON p.invoice_id = i.id AND p.deleted_at IS NULL
+		WHERE i.id = ? AND i.customer_id = ?
+		GROUP BY i.id, i.billing_currency`
+
+	var totalPayments struct {
+		Currency string      `db:"currency"`
+		Amount   money.Money `db:"amount"`
+	}
+	err = p.db.GetContext(ctx, &totalPayments, query, invoiceID, customerID)
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return money.Money{}, fmt.Errorf("invoice %w", exceptions.ErrNotFound)
+		}
+		return money.Money{}, fmt.Errorf("failed to get total invoice payments: %w", err)
+	}
+
+	return totalPayments.Amount.WithCurrency(totalPayments.Currency)
+}
+
+// GetByIDAndCustomerID implements repositories_interfaces.PaymentRepository.
+func (p *paymentRepository) GetByIDAndCustomerID(ctx context.Context, id uint, customerID uint) (*models.Payment, error) {
+	query := `
+		SELECT p.*, i.billing_currency 
+		FROM payments p 
+		JOIN invoices i ON i.id = p.invoice_id 
+		WHERE p.id = ? AND i.customer_id = ? AND p.deleted_at IS NULL`
+
+	payments, err := p.getMany(ctx, query, id, customerID)
+	if err != nil {
+		return nil, err
+	}
+	if len(payments) == 0 {
+		return nil, fmt.Errorf("payment %w", exceptions.ErrNotFound)
+	}
+
+	return &payments[0], nil
+}
+
+// GetAllCustomerPayments implements repositories_interfaces.PaymentRepository.
+func (p *paymentRepository) GetAllCustomerPayments(ctx context.Context, customerID uint, method models.PaymentMethod, invoiceID uint, limit int, offset int) ([]models.Payment, error) {
+	query := `
+		SELECT p.*, i.billing_currency 
+		FROM payments p 
+		JOIN invoices i ON i.id = p.invoice_id 
+		WHERE i.customer_id = ? AND p.deleted_at IS NULL`
+	args := []any{customerID}
+
+	if method != "" {
+		query += ` AND p.method = ?`
+		args = append(args, method)
+	}
+
+	if invoiceID != 0 {
+		query += ` AND p.invoice_id = ?`
+		args = append(args, invoiceID)
+	}
+
+	query += ` ORDER BY p.date DESC, p.id DESC LIMIT ? OFFSET ?`
+	args = append(args, limit, offset)
+
+	return p.getMany(ctx, query, args...)
+}
+
+// getMany loads payments joined with their invoice's billing_currency, and
+// tags their amounts with it
+func (p *paymentRepository) getMany(ctx context.Context, query string, args ...any) ([]models.Payment, error) {
+	var rows []struct {
+		models.Payment
+		BillingCurrency string `db:"billing_currency"`
+	}
+	if err := p.db.SelectContext(ctx, &rows, query, args...); err != nil {
+		return nil, fmt.Errorf("failed to get payments: %w", err)
+	}
 
-	var totalPayments *float64
-	err := p.db.GetContext(ctx, &totalPayments, query, invoiceID)
-	if err != nil || totalPayments == nil {
-		return 0, err
+	payments := make([]models.Payment, 0, len(rows))
+	for _, row := range rows {
+		payment := row.Payment
+		var err error
+		if payment.Amount, err = payment.Amount.WithCurrency(row.BillingCurrency); err != nil {
+			return nil, fmt.Errorf("failed to read payment amounts: %w", err)
+		}
+		payments = append(payments, payment)
 	}
 
-	return *totalPayments, nil
+	return payments, nil
 }
 
 func NewPaymentRepository(
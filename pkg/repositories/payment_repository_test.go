@@ -0,0 +1,563 @@
+package repositories
+
+import (
+	"context"
+	"errors"
+	"regexp"
+	"testing"
+	"time"
+
+	"github.com/Adebayobenjamin/numerisbook/pkg/common/exceptions"
+	"github.com/Adebayobenjamin/numerisbook/pkg/common/money"
+	"github.com/Adebayobenjamin/numerisbook/pkg/models"
+	repositories_interfaces "github.com/Adebayobenjamin/numerisbook/pkg/repositories/interfaces"
+	"github.com/Adebayobenjamin/numerisbook/pkg/tenant"
+	"github.com/DATA-DOG/go-sqlmock"
+	"github.com/rs/zerolog"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestPaymentRepository_GetTotalInvoicePayments(t *testing.T) {
+	db, mock, _ := getMockDB()
+	defer db.Close()
+
+	repo := &paymentRepository{db: db, logger: &zerolog.Logger{}}
+	ctx := tenant.WithCustomerID(context.Background(), 4)
+
+	t.Run("sums decimals exactly in the invoice currency", func(t *testing.T) {
+		rows := sqlmock.NewRows([]string{"currency", "amount"}).AddRow("USD", []byte("0.30"))
+		mock.ExpectQuery(regexp.QuoteMeta(`SELECT i.billing_currency as currency, SUM(p.amount) as amount`)).
+			WithArgs(uint(1), uint(4)).
+			WillReturnRows(rows)
+
+		total, err := repo.GetTotalInvoicePayments(ctx, 1)
+
+		assert.NoError(t, err)
+		assert.Equal(t, money.New(30, "USD"), total)
+	})
+
+	t.Run("no payments scans as zero", func(t *testing.T) {
+		rows := sqlmock.NewRows([]string{"currency", "amount"}).AddRow("JPY", nil)
+		mock.ExpectQuery(regexp.QuoteMeta(`SELECT i.billing_currency as currency, SUM(p.amount) as amount`)).
+			WithArgs(uint(2), uint(4)).
+			WillReturnRows(rows)
+
+		total, err := repo.GetTotalInvoicePayments(ctx, 2)
+
+		assert.NoError(t, err)
+		assert.Equal(t, money.Zero("JPY"), total)
+	})
+
+	t.Run("another customer's invoice is not found", func(t *testing.T) {
+		mock.ExpectQuery(regexp.QuoteMeta(`WHERE i.id = ? AND i.customer_id = ?`)).
+			WithArgs(uint(3), uint(4)).
+			WillReturnRows(sqlmock.NewRows([]string{"currency", "amount"}))
+
+		_, err := repo.GetTotalInvoicePayments(ctx, 3)
+
+		assert.ErrorIs(t, err, exceptions.ErrNotFound)
+	})
+
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
+
+func TestPaymentRepository_RecordPayment(t *testing.T) {
+	db, mock, _ := getMockDB()
+	defer db.Close()
+
+	repo := &paymentRepository{db: db, logger: &zerolog.Logger{}}
+	ctx := tenant.WithCustomerID(context.Background(), 4)
+	date := time.Date(2024, 3, 8, 0, 0, 0, 0, time.UTC)
+	columns := []string{"id", "customer_id", "billing_currency", "total_amount_due", "status", "version"}
+
+	t.Run("settles against the locked invoice in one transaction", func(t *testing.T) {
+		payment := &models.Payment{
+			InvoiceID:   1,
+			Kind:        models.PaymentKindPayment,
+			Amount:      money.New(5000, "USD"),
+			Date:        date,
+			Method:      models.PaymentMethodBankTransfer,
+			Reference:   "TRF-001",
+			PayerName:   "Acme Ltd",
+			Attachments: models.PaymentAttachments{{Name: "advice.pdf", URL: "https://files.example.com/advice.pdf"}},
+		}
+
+		mock.ExpectBegin()
+		mock.ExpectQuery(regexp.QuoteMeta(`WHERE id = ? AND customer_id = ? AND deleted_at IS NULL 
+		FOR UPDATE`)).
+			WithArgs(uint(1), uint(4)).
+			WillReturnRows(sqlmock.NewRows(columns).AddRow(1, 4, "USD", []byte("100.00"), "sent", 2))
+		mock.ExpectQuery(regexp.QuoteMeta(`SELECT COALESCE(SUM(amount), 0) FROM payments`)).
+			WithArgs(uint(1)).
+			WillReturnRows(sqlmock.NewRows([]string{"total"}).AddRow([]byte("50.00")))
+		mock.ExpectExec(regexp.QuoteMeta(`INSERT INTO payments`)).
+			WithArgs(uint(1), models.PaymentKindPayment, nil, payment.Amount, false, date, models.PaymentMethodBankTransfer, "TRF-001", "Acme Ltd", "", "", payment.Attachments).
+			WillReturnResult(sqlmock.NewResult(7, 1))
+		mock.ExpectExec(regexp.QuoteMeta(`SET status = ?, is_fully_paid = ?, version = version + 1`)).
+			WithArgs(models.InvoiceStatusPaid, true, uint(1), uint(4)).
+			WillReturnResult(sqlmock.NewResult(0, 1))
+		mock.ExpectExec(regexp.QuoteMeta(`INSERT INTO audit_trails`)).
+			WillReturnResult(sqlmock.NewResult(1, 1))
+		mock.ExpectCommit()
+
+		invoice, err := repo.RecordPayment(ctx, payment, func(invoice *models.Invoice, paid money.Money) ([]models.AuditTrail, error) {
+			assert.Equal(t, money.New(10000, "USD"), invoice.TotalAmountDue)
+			assert.Equal(t, money.New(5000, "USD"), paid)
+			invoice.Status = models.InvoiceStatusPaid
+			invoice.IsFullyPaid = true
+			return []models.AuditTrail{{EventType: models.EventTypePaymentConfirmed, LogLevel: models.LogLevelInfo, Message: "paid"}}, nil
+		})
+
+		assert.NoError(t, err)
+		assert.Equal(t, uint(7), payment.ID)
+		assert.Equal(t, uint(3), invoice.Version)
+	})
+
+	t.Run("an overpayment is credited to the invoice's client", func(t *testing.T) {
+		overpayment := money.New(2000, "USD")
+		payment := &models.Payment{InvoiceID: 1, Kind: models.PaymentKindPayment, Amount: money.New(7000, "USD"), Date: date, Method: models.PaymentMethodCash}
+
+		mock.ExpectBegin()
+		mock.ExpectQuery(regexp.QuoteMeta(`FOR UPDATE`)).
+			WithArgs(uint(1), uint(4)).
+			WillReturnRows(sqlmock.NewRows(append(columns, "invoice_number", "client_id")).AddRow(1, 4, "USD", []byte("100.00"), "partially_paid", 2, "INV-001", 9))
+		mock.ExpectQuery(regexp.QuoteMeta(`SELECT COALESCE(SUM(amount), 0) FROM payments`)).
+			WithArgs(uint(1)).
+			WillReturnRows(sqlmock.NewRows([]string{"total"}).AddRow([]byte("50.00")))
+		mock.ExpectExec(regexp.QuoteMeta(`INSERT INTO payments`)).
+			WillReturnResult(sqlmock.NewResult(8, 1))
+		mock.ExpectExec(regexp.QuoteMeta(`INSERT INTO client_credits`)).
+			WithArgs(uint(4), uint(9), models.ClientCreditKindOverpayment, overpayment, "USD", int64(8), uint(1), "Overpayment of Invoice INV-001").
+			WillReturnResult(sqlmock.NewResult(1, 1))
+		mock.ExpectExec(regexp.QuoteMeta(`SET status = ?, is_fully_paid = ?, version = version + 1`)).
+			WillReturnResult(sqlmock.NewResult(0, 1))
+		mock.ExpectCommit()
+
+		_, err := repo.RecordPayment(ctx, payment, func(invoice *models.Invoice, paid money.Money) ([]models.AuditTrail, error) {
+			payment.Amount = money.New(5000, "USD")
+			payment.Overpayment = &overpayment
+			invoice.Status = models.InvoiceStatusPaid
+			invoice.IsFullyPaid = true
+			return nil, nil
+		})
+
+		assert.NoError(t, err)
+		assert.NoError(t, mock.ExpectationsWereMet())
+	})
+
+	t.Run("refused settlement writes nothing", func(t *testing.T) {
+		payment := &models.Payment{InvoiceID: 1, Amount: money.New(9000, "USD"), Date: date}
+
+		mock.ExpectBegin()
+		mock.ExpectQuery(regexp.QuoteMeta(`FOR UPDATE`)).
+			WithArgs(uint(1), uint(4)).
+			WillReturnRows(sqlmock.NewRows(columns).AddRow(1, 4, "USD", []byte("100.00"), "partially_paid", 3))
+		mock.ExpectQuery(regexp.QuoteMeta(`SELECT COALESCE(SUM(amount), 0) FROM payments`)).
+			WithArgs(uint(1)).
+			WillReturnRows(sqlmock.NewRows([]string{"total"}).AddRow([]byte("50.00")))
+		mock.ExpectRollback()
+
+		invoice, err := repo.RecordPayment(ctx, payment, func(*models.Invoice, money.Money) ([]models.AuditTrail, error) {
+			return nil, errors.New("payment amount exceeds invoice total amount")
+		})
+
+		assert.Nil(t, invoice)
+		assert.EqualError(t, err, "payment amount exceeds invoice total amount")
+	})
+
+	t.Run("another customer's invoice is not found", func(t *testing.T) {
+		payment := &models.Payment{InvoiceID: 1, Amount: money.New(5000, "USD"), Date: date}
+
+		mock.ExpectBegin()
+		mock.ExpectQuery(regexp.QuoteMeta(`FOR UPDATE`)).
+			WithArgs(uint(1), uint(9)).
+			WillReturnRows(sqlmock.NewRows(columns))
+		mock.ExpectRollback()
+
+		_, err := repo.RecordPayment(tenant.WithCustomerID(context.Background(), 9), payment, nil)
+
+		assert.ErrorIs(t, err, exceptions.ErrNotFound)
+	})
+
+	t.Run("unscoped context is refused", func(t *testing.T) {
+		_, err := repo.RecordPayment(context.Background(), &models.Payment{InvoiceID: 1}, nil)
+
+		assert.ErrorIs(t, err, tenant.ErrMissingTenant)
+	})
+
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
+
+// TestPaymentRepository_RecordPaymentOrdering pins down the order the
+// statements of a payment run in. The invoice row lock has to be taken before
+// anything paid on it is read, and hold until the payment, the invoice's new
+// status and the audit trail are committed together, or two payments can
+// both be checked against the same total.
+func TestPaymentRepository_RecordPaymentOrdering(t *testing.T) {
+	db, mock, _ := getMockDB()
+	defer db.Close()
+	mock.MatchExpectationsInOrder(true)
+
+	repo := &paymentRepository{db: db, logger: &zerolog.Logger{}}
+	ctx := tenant.WithCustomerID(context.Background(), 4)
+	date := time.Date(2024, 3, 8, 0, 0, 0, 0, time.UTC)
+	columns := []string{"id", "customer_id", "invoice_number", "billing_currency", "total_amount_due", "status", "version"}
+
+	settle := func(t *testing.T) repositories_interfaces.SettlePayment {
+		return func(invoice *models.Invoice, paid money.Money) ([]models.AuditTrail, error) {
+			// settling sees the locked row and what was paid while holding it
+			assert.Equal(t, models.InvoiceStatusPartiallyPaid, invoice.Status)
+			assert.Equal(t, money.New(9000, "USD"), paid)
+			invoice.Status = models.InvoiceStatusPaid
+			invoice.IsFullyPaid = true
+			return []models.AuditTrail{{EventType: models.EventTypePaymentConfirmed, LogLevel: models.LogLevelInfo, Message: "Payment of 10.00 confirmed"}}, nil
+		}
+	}
+	expectLockedWrites := func(payment *models.Payment) {
+		mock.ExpectBegin()
+		mock.ExpectQuery(regexp.QuoteMeta(`SELECT * FROM invoices 
+		WHERE id = ? AND customer_id = ? AND deleted_at IS NULL 
+		FOR UPDATE`)).
+			WithArgs(uint(1), uint(4)).
+			WillReturnRows(sqlmock.NewRows(columns).AddRow(1, 4, "INV-001", "USD", []byte("100.00"), "partially_paid", 5))
+		mock.ExpectQuery(regexp.QuoteMeta(`SELECT COALESCE(SUM(amount), 0) FROM payments 
+		WHERE invoice_id = ? AND deleted_at IS NULL`)).
+			WithArgs(uint(1)).
+			WillReturnRows(sqlmock.NewRows([]string{"total"}).AddRow([]byte("90.00")))
+		mock.ExpectExec(regexp.QuoteMeta(`INSERT INTO payments`)).
+			WithArgs(uint(1), models.PaymentKindPayment, nil, payment.Amount, false, date, models.PaymentMethodCard, "", "", "", "", payment.Attachments).
+			WillReturnResult(sqlmock.NewResult(11, 1))
+		mock.ExpectExec(regexp.QuoteMeta(`UPDATE invoices 
+		SET status = ?, is_fully_paid = ?, version = version + 1`)).
+			WithArgs(models.InvoiceStatusPaid, true, uint(1), uint(4)).
+			WillReturnResult(sqlmock.NewResult(0, 1))
+	}
+
+	t.Run("begin, lock, sum, insert, status, audit and commit run in that order", func(t *testing.T) {
+		payment := &models.Payment{InvoiceID: 1, Kind: models.PaymentKindPayment, Amount: money.New(1000, "USD"), Date: date, Method: models.PaymentMethodCard}
+
+		expectLockedWrites(payment)
+		mock.ExpectExec(regexp.QuoteMeta(`INSERT INTO audit_trails`)).
+			WithArgs(models.EventTypePaymentConfirmed, models.LogLevelInfo, "Payment of 10.00 confirmed", uint(1), uint(4), sqlmock.AnyArg(), sqlmock.AnyArg(), sqlmock.AnyArg()).
+			WillReturnResult(sqlmock.NewResult(1, 1))
+		mock.ExpectCommit()
+
+		invoice, err := repo.RecordPayment(ctx, payment, settle(t))
+
+		assert.NoError(t, err)
+		assert.Equal(t, uint(11), payment.ID)
+		assert.Equal(t, models.InvoiceStatusPaid, invoice.Status)
+		assert.Equal(t, uint(6), invoice.Version)
+		assert.NoError(t, mock.ExpectationsWereMet())
+	})
+
+	t.Run("a failed audit trail rolls back the payment and the status", func(t *testing.T) {
+		payment := &models.Payment{InvoiceID: 1, Kind: models.PaymentKindPayment, Amount: money.New(1000, "USD"), Date: date, Method: models.PaymentMethodCard}
+
+		expectLockedWrites(payment)
+		mock.ExpectExec(regexp.QuoteMeta(`INSERT INTO audit_trails`)).
+			WillReturnError(errors.New("connection reset"))
+		mock.ExpectRollback()
+
+		invoice, err := repo.RecordPayment(ctx, payment, settle(t))
+
+		assert.Nil(t, invoice)
+		assert.ErrorContains(t, err, "failed to log audit trail event")
+		assert.Zero(t, payment.ID)
+		assert.NoError(t, mock.ExpectationsWereMet())
+	})
+}
+
+func TestPaymentRepository_RecordReturn(t *testing.T) {
+	db, mock, _ := getMockDB()
+	defer db.Close()
+
+	repo := &paymentRepository{db: db, logger: &zerolog.Logger{}}
+	ctx := tenant.WithCustomerID(context.Background(), 4)
+	date := time.Date(2024, 3, 9, 0, 0, 0, 0, time.UTC)
+	originalID := uint(7)
+	invoiceColumns := []string{"id", "customer_id", "billing_currency", "total_amount_due", "status", "version"}
+	paymentColumns := []string{"id", "invoice_id", "kind", "amount", "date", "method"}
+
+	t.Run("takes back from the payment with the invoice locked", func(t *testing.T) {
+		entry := &models.Payment{Kind: models.PaymentKindRefund, OriginalPaymentID: &originalID, Date: date, Method: models.PaymentMethodCard, Reason: "Returned goods"}
+
+		mock.ExpectBegin()
+		mock.ExpectQuery(regexp.QuoteMeta(`SELECT invoice_id FROM payments`)).
+			WithArgs(uint(7)).
+			WillReturnRows(sqlmock.NewRows([]string{"invoice_id"}).AddRow(1))
+		mock.ExpectQuery(regexp.QuoteMeta(`FOR UPDATE`)).
+			WithArgs(uint(1), uint(4)).
+			WillReturnRows(sqlmock.NewRows(invoiceColumns).AddRow(1, 4, "USD", []byte("100.00"), "paid", 3))
+		mock.ExpectQuery(regexp.QuoteMeta(`SELECT * FROM payments WHERE id = ?`)).
+			WithArgs(uint(7)).
+			WillReturnRows(sqlmock.NewRows(paymentColumns).AddRow(7, 1, "payment", []byte("100.00"), date, "card"))
+		mock.ExpectQuery(regexp.QuoteMeta(`WHERE original_payment_id = ? AND deleted_at IS NULL`)).
+			WithArgs(uint(7)).
+			WillReturnRows(sqlmock.NewRows([]string{"total"}).AddRow([]byte("-30.00")))
+		mock.ExpectQuery(regexp.QuoteMeta(`WHERE invoice_id = ? AND deleted_at IS NULL`)).
+			WithArgs(uint(1)).
+			WillReturnRows(sqlmock.NewRows([]string{"total"}).AddRow([]byte("70.00")))
+		mock.ExpectExec(regexp.QuoteMeta(`INSERT INTO payments`)).
+			WithArgs(uint(1), models.PaymentKindRefund, uint(7), money.New(-2000, "USD"), false, date, models.PaymentMethodCard, "", "", "", "Returned goods", sqlmock.AnyArg()).
+			WillReturnResult(sqlmock.NewResult(8, 1))
+		mock.ExpectExec(regexp.QuoteMeta(`SET status = ?, is_fully_paid = ?, version = version + 1`)).
+			WithArgs(models.InvoiceStatusPartiallyPaid, false, uint(1), uint(4)).
+			WillReturnResult(sqlmock.NewResult(0, 1))
+		mock.ExpectExec(regexp.QuoteMeta(`INSERT INTO audit_trails`)).
+			WillReturnResult(sqlmock.NewResult(1, 1))
+		mock.ExpectCommit()
+
+		invoice, err := repo.RecordReturn(ctx, entry, func(invoice *models.Invoice, paid money.Money, original *models.Payment, returned money.Money) ([]models.AuditTrail, error) {
+			assert.Equal(t, money.New(7000, "USD"), paid)
+			assert.Equal(t, money.New(10000, "USD"), original.Amount)
+			assert.Equal(t, money.New(3000, "USD"), returned)
+			entry.Amount = money.New(-2000, "USD")
+			invoice.Status = models.InvoiceStatusPartiallyPaid
+			return []models.AuditTrail{{EventType: models.EventTypePaymentRefunded, LogLevel: models.LogLevelInfo, Message: "refunded"}}, nil
+		})
+
+		assert.NoError(t, err)
+		assert.Equal(t, uint(8), entry.ID)
+		assert.Equal(t, uint(1), entry.InvoiceID)
+		assert.Equal(t, uint(4), invoice.Version)
+	})
+
+	creditColumns := []string{"id", "customer_id", "client_id", "kind", "amount", "currency", "payment_id", "invoice_id"}
+	clientInvoiceColumns := []string{"id", "customer_id", "invoice_number", "client_id", "billing_currency", "total_amount_due", "status", "version"}
+	expectReversalOf := func(kind models.PaymentKind, amount string) {
+		mock.ExpectBegin()
+		mock.ExpectQuery(regexp.QuoteMeta(`SELECT invoice_id FROM payments`)).
+			WithArgs(uint(7)).
+			WillReturnRows(sqlmock.NewRows([]string{"invoice_id"}).AddRow(1))
+		mock.ExpectQuery(regexp.QuoteMeta(`FOR UPDATE`)).
+			WithArgs(uint(1), uint(4)).
+			WillReturnRows(sqlmock.NewRows(clientInvoiceColumns).AddRow(1, 4, "INV-001", 9, "USD", []byte("100.00"), "paid", 3))
+		mock.ExpectQuery(regexp.QuoteMeta(`SELECT * FROM payments WHERE id = ?`)).
+			WithArgs(uint(7)).
+			WillReturnRows(sqlmock.NewRows(paymentColumns).AddRow(7, 1, kind, []byte(amount), date, "other"))
+		mock.ExpectQuery(regexp.QuoteMeta(`WHERE original_payment_id = ? AND deleted_at IS NULL`)).
+			WithArgs(uint(7)).
+			WillReturnRows(sqlmock.NewRows([]string{"total"}).AddRow([]byte("0")))
+		mock.ExpectQuery(regexp.QuoteMeta(`WHERE invoice_id = ? AND deleted_at IS NULL`)).
+			WithArgs(uint(1)).
+			WillReturnRows(sqlmock.NewRows([]string{"total"}).AddRow([]byte("100.00")))
+	}
+
+	t.Run("reversing an overpaying payment takes its credit back", func(t *testing.T) {
+		entry := &models.Payment{Kind: models.PaymentKindReversal, OriginalPaymentID: &originalID, Date: date, Reason: "Cheque bounced"}
+
+		expectReversalOf(models.PaymentKindPayment, "100.00")
+		mock.ExpectQuery(regexp.QuoteMeta(`SELECT * FROM client_credits`)).
+			WithArgs(uint(7)).
+			WillReturnRows(sqlmock.NewRows(creditColumns).AddRow(3, 4, 9, "overpayment", []byte("25.00"), "USD", 7, 1))
+		mock.ExpectQuery(regexp.QuoteMeta(`SELECT id FROM clients`)).
+			WithArgs(uint(9), uint(4)).
+			WillReturnRows(sqlmock.NewRows([]string{"id"}).AddRow(9))
+		mock.ExpectQuery(regexp.QuoteMeta(`FROM client_credits`)).
+			WithArgs(uint(9), "USD").
+			WillReturnRows(sqlmock.NewRows([]string{"total"}).AddRow([]byte("25.00")))
+		mock.ExpectExec(regexp.QuoteMeta(`INSERT INTO payments`)).
+			WillReturnResult(sqlmock.NewResult(8, 1))
+		mock.ExpectExec(regexp.QuoteMeta(`INSERT INTO client_credits`)).
+			WithArgs(uint(4), uint(9), models.ClientCreditKindReversal, money.New(-2500, "USD"), "USD", int64(8), uint(1), "Reversal of Payment 7 on Invoice INV-001").
+			WillReturnResult(sqlmock.NewResult(4, 1))
+		mock.ExpectExec(regexp.QuoteMeta(`SET status = ?, is_fully_paid = ?, version = version + 1`)).
+			WithArgs(models.InvoiceStatusSent, false, uint(1), uint(4)).
+			WillReturnResult(sqlmock.NewResult(0, 1))
+		mock.ExpectCommit()
+
+		_, err := repo.RecordReturn(ctx, entry, func(invoice *models.Invoice, paid money.Money, original *models.Payment, returned money.Money) ([]models.AuditTrail, error) {
+			overpayment := money.New(2500, "USD")
+			assert.Equal(t, &overpayment, original.Overpayment)
+			entry.Amount = money.New(-10000, "USD")
+			invoice.Status = models.InvoiceStatusSent
+			return nil, nil
+		})
+
+		assert.NoError(t, err)
+		assert.NoError(t, mock.ExpectationsWereMet())
+	})
+
+	t.Run("a payment whose overpaid credit was spent cannot be reversed", func(t *testing.T) {
+		entry := &models.Payment{Kind: models.PaymentKindReversal, OriginalPaymentID: &originalID, Date: date, Reason: "Cheque bounced"}
+
+		expectReversalOf(models.PaymentKindPayment, "100.00")
+		mock.ExpectQuery(regexp.QuoteMeta(`SELECT * FROM client_credits`)).
+			WithArgs(uint(7)).
+			WillReturnRows(sqlmock.NewRows(creditColumns).AddRow(3, 4, 9, "overpayment", []byte("25.00"), "USD", 7, 1))
+		mock.ExpectQuery(regexp.QuoteMeta(`SELECT id FROM clients`)).
+			WithArgs(uint(9), uint(4)).
+			WillReturnRows(sqlmock.NewRows([]string{"id"}).AddRow(9))
+		mock.ExpectQuery(regexp.QuoteMeta(`FROM client_credits`)).
+			WithArgs(uint(9), "USD").
+			WillReturnRows(sqlmock.NewRows([]string{"total"}).AddRow([]byte("10.00")))
+		mock.ExpectRollback()
+
+		_, err := repo.RecordReturn(ctx, entry, func(invoice *models.Invoice, paid money.Money, original *models.Payment, returned money.Money) ([]models.AuditTrail, error) {
+			entry.Amount = money.New(-10000, "USD")
+			return nil, nil
+		})
+
+		assert.EqualError(t, err, "payment 7 cannot be reversed, the 25.00 it overpaid was already applied to other invoices")
+		assert.NoError(t, mock.ExpectationsWereMet())
+	})
+
+	t.Run("reversing applied credit gives it back to the client", func(t *testing.T) {
+		entry := &models.Payment{Kind: models.PaymentKindReversal, OriginalPaymentID: &originalID, Date: date, Reason: "Applied to the wrong invoice"}
+
+		expectReversalOf(models.PaymentKindCredit, "30.00")
+		mock.ExpectQuery(regexp.QuoteMeta(`SELECT id FROM clients`)).
+			WithArgs(uint(9), uint(4)).
+			WillReturnRows(sqlmock.NewRows([]string{"id"}).AddRow(9))
+		mock.ExpectQuery(regexp.QuoteMeta(`FROM client_credits`)).
+			WithArgs(uint(9), "USD").
+			WillReturnRows(sqlmock.NewRows([]string{"total"}).AddRow([]byte("0")))
+		mock.ExpectExec(regexp.QuoteMeta(`INSERT INTO payments`)).
+			WillReturnResult(sqlmock.NewResult(8, 1))
+		mock.ExpectExec(regexp.QuoteMeta(`INSERT INTO client_credits`)).
+			WithArgs(uint(4), uint(9), models.ClientCreditKindReversal, money.New(3000, "USD"), "USD", int64(8), uint(1), "Reversal of credit applied to Invoice INV-001").
+			WillReturnResult(sqlmock.NewResult(4, 1))
+		mock.ExpectExec(regexp.QuoteMeta(`SET status = ?, is_fully_paid = ?, version = version + 1`)).
+			WithArgs(models.InvoiceStatusPartiallyPaid, false, uint(1), uint(4)).
+			WillReturnResult(sqlmock.NewResult(0, 1))
+		mock.ExpectCommit()
+
+		_, err := repo.RecordReturn(ctx, entry, func(invoice *models.Invoice, paid money.Money, original *models.Payment, returned money.Money) ([]models.AuditTrail, error) {
+			assert.Equal(t, models.PaymentKindCredit, original.Kind)
+			entry.Amount = money.New(-3000, "USD")
+			invoice.Status = models.InvoiceStatusPartiallyPaid
+			return nil, nil
+		})
+
+		assert.NoError(t, err)
+		assert.NoError(t, mock.ExpectationsWereMet())
+	})
+
+	t.Run("another customer's payment is not found", func(t *testing.T) {
+		entry := &models.Payment{Kind: models.PaymentKindReversal, OriginalPaymentID: &originalID}
+
+		mock.ExpectBegin()
+		mock.ExpectQuery(regexp.QuoteMeta(`SELECT invoice_id FROM payments`)).
+			WithArgs(uint(7)).
+			WillReturnRows(sqlmock.NewRows([]string{"invoice_id"}).AddRow(1))
+		mock.ExpectQuery(regexp.QuoteMeta(`FOR UPDATE`)).
+			WithArgs(uint(1), uint(9)).
+			WillReturnRows(sqlmock.NewRows(invoiceColumns))
+		mock.ExpectRollback()
+
+		_, err := repo.RecordReturn(tenant.WithCustomerID(context.Background(), 9), entry, nil)
+
+		assert.ErrorIs(t, err, exceptions.ErrNotFound)
+		assert.ErrorContains(t, err, "payment")
+	})
+
+	t.Run("unscoped context is refused", func(t *testing.T) {
+		_, err := repo.RecordReturn(context.Background(), &models.Payment{OriginalPaymentID: &originalID}, nil)
+
+		assert.ErrorIs(t, err, tenant.ErrMissingTenant)
+	})
+
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
+
+func TestPaymentRepository_ApplyCredit(t *testing.T) {
+	db, mock, _ := getMockDB()
+	defer db.Close()
+
+	repo := &paymentRepository{db: db, logger: &zerolog.Logger{}}
+	ctx := tenant.WithCustomerID(context.Background(), 4)
+	columns := []string{"id", "customer_id", "invoice_number", "client_id", "billing_currency", "total_amount_due", "status", "version"}
+
+	t.Run("pays the invoice out of the locked client credit", func(t *testing.T) {
+		payment := &models.Payment{InvoiceID: 2, Kind: models.PaymentKindCredit, Method: models.PaymentMethodOther}
+
+		mock.ExpectBegin()
+		mock.ExpectQuery(regexp.QuoteMeta(`FROM invoices`)).
+			WithArgs(uint(2), uint(4)).
+			WillReturnRows(sqlmock.NewRows(columns).AddRow(2, 4, "INV-002", 9, "USD", []byte("100.00"), "sent", 1))
+		mock.ExpectQuery(regexp.QuoteMeta(`SELECT id FROM clients`)).
+			WithArgs(uint(9), uint(4)).
+			WillReturnRows(sqlmock.NewRows([]string{"id"}).AddRow(9))
+		mock.ExpectQuery(regexp.QuoteMeta(`FROM client_credits`)).
+			WithArgs(uint(9), "USD").
+			WillReturnRows(sqlmock.NewRows([]string{"total"}).AddRow([]byte("30.00")))
+		mock.ExpectQuery(regexp.QuoteMeta(`SELECT COALESCE(SUM(amount), 0) FROM payments`)).
+			WithArgs(uint(2)).
+			WillReturnRows(sqlmock.NewRows([]string{"total"}).AddRow([]byte("0")))
+		mock.ExpectExec(regexp.QuoteMeta(`INSERT INTO payments`)).
+			WillReturnResult(sqlmock.NewResult(11, 1))
+		mock.ExpectExec(regexp.QuoteMeta(`INSERT INTO client_credits`)).
+			WithArgs(uint(4), uint(9), models.ClientCreditKindApplied, money.New(-3000, "USD"), "USD", int64(11), uint(2), "Applied to Invoice INV-002").
+			WillReturnResult(sqlmock.NewResult(2, 1))
+		mock.ExpectExec(regexp.QuoteMeta(`SET status = ?, is_fully_paid = ?, version = version + 1`)).
+			WithArgs(models.InvoiceStatusPartiallyPaid, false, uint(2), uint(4)).
+			WillReturnResult(sqlmock.NewResult(0, 1))
+		mock.ExpectCommit()
+
+		_, err := repo.ApplyCredit(ctx, 9, payment, func(invoice *models.Invoice, paid money.Money, available money.Money) ([]models.AuditTrail, error) {
+			assert.Equal(t, money.Zero("USD"), paid)
+			assert.Equal(t, money.New(3000, "USD"), available)
+			payment.Amount = available
+			invoice.Status = models.InvoiceStatusPartiallyPaid
+			return nil, nil
+		})
+
+		assert.NoError(t, err)
+		assert.Equal(t, uint(11), payment.ID)
+		assert.NoError(t, mock.ExpectationsWereMet())
+	})
+
+	t.Run("an invoice billed to another client is refused", func(t *testing.T) {
+		mock.ExpectBegin()
+		mock.ExpectQuery(regexp.QuoteMeta(`FROM invoices`)).
+			WithArgs(uint(2), uint(4)).
+			WillReturnRows(sqlmock.NewRows(columns).AddRow(2, 4, "INV-002", 5, "USD", []byte("100.00"), "sent", 1))
+		mock.ExpectRollback()
+
+		_, err := repo.ApplyCredit(ctx, 9, &models.Payment{InvoiceID: 2}, func(*models.Invoice, money.Money, money.Money) ([]models.AuditTrail, error) {
+			t.Fatal("settle must not be called")
+			return nil, nil
+		})
+
+		assert.EqualError(t, err, "invoice INV-002 is not billed to client 9")
+		assert.NoError(t, mock.ExpectationsWereMet())
+	})
+}
+
+func TestPaymentRepository_GetAllCustomerPayments(t *testing.T) {
+	db, mock, _ := getMockDB()
+	defer db.Close()
+
+	repo := &paymentRepository{db: db, logger: &zerolog.Logger{}}
+	columns := []string{"id", "invoice_id", "amount", "is_partial", "date", "method", "reference", "payer_name", "notes", "attachments", "billing_currency"}
+	date := time.Date(2024, 3, 8, 0, 0, 0, 0, time.UTC)
+
+	t.Run("filters by method and invoice", func(t *testing.T) {
+		mock.ExpectQuery(regexp.QuoteMeta(`WHERE i.customer_id = ? AND p.deleted_at IS NULL AND p.method = ? AND p.invoice_id = ? ORDER BY p.date DESC, p.id DESC LIMIT ? OFFSET ?`)).
+			WithArgs(uint(4), models.PaymentMethodCard, uint(1), 10, 0).
+			WillReturnRows(sqlmock.NewRows(columns).
+				AddRow(7, 1, []byte("50.00"), true, date, "card", "ch_3PxYz", "Jane Doe", "", []byte(`[{"name":"receipt.pdf","url":"https://files.example.com/receipt.pdf"}]`), "EUR"))
+
+		payments, err := repo.GetAllCustomerPayments(context.Background(), 4, models.PaymentMethodCard, 1, 10, 0)
+
+		assert.NoError(t, err)
+		assert.Len(t, payments, 1)
+		assert.Equal(t, money.New(5000, "EUR"), payments[0].Amount)
+		assert.Equal(t, models.PaymentMethodCard, payments[0].Method)
+		assert.Equal(t, models.PaymentAttachments{{Name: "receipt.pdf", URL: "https://files.example.com/receipt.pdf"}}, payments[0].Attachments)
+	})
+
+	t.Run("lists every payment without filters", func(t *testing.T) {
+		mock.ExpectQuery(regexp.QuoteMeta(`WHERE i.customer_id = ? AND p.deleted_at IS NULL ORDER BY`)).
+			WithArgs(uint(4), 10, 0).
+			WillReturnRows(sqlmock.NewRows(columns))
+
+		payments, err := repo.GetAllCustomerPayments(context.Background(), 4, "", 0, 10, 0)
+
+		assert.NoError(t, err)
+		assert.Empty(t, payments)
+	})
+
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
@@ -0,0 +1,243 @@
+package repositories
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/Adebayobenjamin/numerisbook/pkg/common/exceptions"
+	"github.com/Adebayobenjamin/numerisbook/pkg/models"
+	repositories_interfaces "github.com/Adebayobenjamin/numerisbook/pkg/repositories/interfaces"
+	"github.com/jmoiron/sqlx"
+	"github.com/rs/zerolog"
+)
+
+type quoteRepository struct {
+	db     *sqlx.DB
+	logger *zerolog.Logger
+}
+
+// Create implements repositories_interfaces.QuoteRepository.
+func (q *quoteRepository) Create(ctx context.Context, quote *models.Quote) (*models.Quote, error) {
+	tx, err := q.db.BeginTxx(ctx, nil)
+	if err != nil {
+		return nil, fmt.Errorf("failed to begin transaction: %w", err)
+	}
+	defer tx.Rollback()
+
+	quote.QuoteNumber, err = allocateDocumentNumber(ctx, tx, quote.CustomerID, models.DocumentTypeQuote, quote.IssueDate)
+	if err != nil {
+		return nil, err
+	}
+
+	query := `
+		INSERT INTO quotes (
+			customer_id, quote_number, status, business_profile_id,
+			issuer_name, issuer_email, issuer_phone, issuer_address,
+			client_id, client_name, client_email, client_phone, client_address,
+			issue_date, expiry_date, due_in_days, billing_currency,
+			subtotal, discount, discount_type, discount_rate, discount_total, tax_total, total_amount,
+			notes, items, payment_info, created_at, updated_at
+		) VALUES (
+			?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?,
+			CURRENT_TIMESTAMP, CURRENT_TIMESTAMP
+		)`
+
+	result, err := tx.ExecContext(ctx, query,
+		quote.CustomerID,
+		quote.QuoteNumber,
+		quote.Status,
+		quote.InvoiceIssuer.BusinessProfileID,
+		quote.InvoiceIssuer.Name,
+		quote.InvoiceIssuer.Email,
+		quote.InvoiceIssuer.Phone,
+		quote.InvoiceIssuer.Address,
+		quote.InvoiceClient.ClientID,
+		quote.InvoiceClient.Name,
+		quote.InvoiceClient.Email,
+		quote.InvoiceClient.Phone,
+		quote.InvoiceClient.Address,
+		quote.IssueDate,
+		quote.ExpiryDate,
+		quote.DueInDays,
+		quote.BillingCurrency,
+		quote.Subtotal,
+		quote.Discount,
+		quote.DiscountType,
+		quote.DiscountRate,
+		quote.DiscountTotal,
+		quote.TaxTotal,
+		quote.TotalAmount,
+		quote.Notes,
+		quote.Items,
+		quote.PaymentInfo)
+	if err != nil {
+		return nil, fmt.Errorf("failed to create quote: %w", err)
+	}
+
+	quoteID, _ := result.LastInsertId()
+
+	if err := tx.Commit(); err != nil {
+		return nil, fmt.Errorf("failed to commit transaction: %w", err)
+	}
+
+	return q.GetByIDAndCustomerID(ctx, uint(quoteID), quote.CustomerID)
+}
+
+// GetByIDAndCustomerID implements repositories_interfaces.QuoteRepository.
+func (q *quoteRepository) GetByIDAndCustomerID(ctx context.Context, id uint, customerID uint) (*models.Quote, error) {
+	query := `
+		SELECT * FROM quotes 
+		WHERE id = ? AND customer_id = ? AND deleted_at IS NULL`
+
+	return q.getOne(ctx, query, id, customerID)
+}
+
+// GetByShareTokenID implements repositories_interfaces.QuoteRepository.
+func (q *quoteRepository) GetByShareTokenID(ctx context.Context, tokenID string) (*models.Quote, error) {
+	query := `
+		SELECT * FROM quotes 
+		WHERE share_token_id = ? AND deleted_at IS NULL`
+
+	return q.getOne(ctx, query, tokenID)
+}
+
+// GetAllCustomerQuotes implements repositories_interfaces.QuoteRepository.
+func (q *quoteRepository) GetAllCustomerQuotes(ctx context.Context, customerID uint, limit int, offset int) ([]models.Quote, error) {
+	query := `
+		SELECT * FROM quotes 
+		WHERE customer_id = ? AND deleted_at IS NULL 
+		ORDER BY issue_date DESC, id DESC 
+		LIMIT ? OFFSET ?`
+
+	quotes := []models.Quote{}
+	if err := q.db.SelectContext(ctx, &quotes, query, customerID, limit, offset); err != nil {
+		return nil, fmt.Errorf("failed to get quotes: %w", err)
+	}
+
+	for idx := range quotes {
+		if err := quotes[idx].AssignCurrency(); err != nil {
+			return nil, fmt.Errorf("failed to read quote amounts: %w", err)
+		}
+	}
+
+	return quotes, nil
+}
+
+// UpdateStatus implements repositories_interfaces.QuoteRepository.
+func (q *quoteRepository) UpdateStatus(ctx context.Context, quote *models.Quote, from models.QuoteStatus) error {
+	// the status only moves if nobody else moved it first, so a client
+	// accepting a quote at the moment it expires gets one answer, not both
+	query := `
+		UPDATE quotes 
+		SET status = ?, share_token_id = ?, sent_at = ?, responded_at = ?, decline_reason = ?, updated_at = CURRENT_TIMESTAMP 
+		WHERE id = ? AND customer_id = ? AND status = ? AND deleted_at IS NULL`
+
+	result, err := q.db.ExecContext(ctx, query,
+		quote.Status,
+		quote.ShareTokenID,
+		quote.SentAt,
+		quote.RespondedAt,
+		quote.DeclineReason,
+		quote.ID,
+		quote.CustomerID,
+		from)
+	if err != nil {
+		return fmt.Errorf("failed to update quote status: %w", err)
+	}
+	if rows, _ := result.RowsAffected(); rows == 0 {
+		return fmt.Errorf("quote %w", exceptions.ErrVersionMismatch)
+	}
+
+	return nil
+}
+
+// ClaimForConversion implements repositories_interfaces.QuoteRepository.
+func (q *quoteRepository) ClaimForConversion(ctx context.Context, id uint, customerID uint, claimedAt time.Time) error {
+	query := `
+		UPDATE quotes 
+		SET converted_at = ?, updated_at = CURRENT_TIMESTAMP 
+		WHERE id = ? AND customer_id = ? AND status = ? AND converted_at IS NULL AND deleted_at IS NULL`
+
+	result, err := q.db.ExecContext(ctx, query, claimedAt, id, customerID, models.QuoteStatusAccepted)
+	if err != nil {
+		return fmt.Errorf("failed to claim quote for conversion: %w", err)
+	}
+	if rows, _ := result.RowsAffected(); rows == 0 {
+		return fmt.Errorf("quote has already been converted: %w", exceptions.ErrLocked)
+	}
+
+	return nil
+}
+
+// ReleaseConversion implements repositories_interfaces.QuoteRepository.
+func (q *quoteRepository) ReleaseConversion(ctx context.Context, id uint, customerID uint) error {
+	query := `
+		UPDATE quotes 
+		SET converted_at = NULL, updated_at = CURRENT_TIMESTAMP 
+		WHERE id = ? AND customer_id = ? AND invoice_id IS NULL`
+
+	if _, err := q.db.ExecContext(ctx, query, id, customerID); err != nil {
+		return fmt.Errorf("failed to release quote conversion: %w", err)
+	}
+
+	return nil
+}
+
+// SetInvoice implements repositories_interfaces.QuoteRepository.
+func (q *quoteRepository) SetInvoice(ctx context.Context, id uint, customerID uint, invoiceID uint) error {
+	query := `
+		UPDATE quotes 
+		SET invoice_id = ?, updated_at = CURRENT_TIMESTAMP 
+		WHERE id = ? AND customer_id = ?`
+
+	if _, err := q.db.ExecContext(ctx, query, invoiceID, id, customerID); err != nil {
+		return fmt.Errorf("failed to link quote to invoice: %w", err)
+	}
+
+	return nil
+}
+
+// ExpireLapsed implements repositories_interfaces.QuoteRepository.
+func (q *quoteRepository) ExpireLapsed(ctx context.Context, now time.Time) (int64, error) {
+	query := `
+		UPDATE quotes 
+		SET status = ?, updated_at = CURRENT_TIMESTAMP 
+		WHERE status = ? AND expiry_date <= ? AND deleted_at IS NULL`
+
+	result, err := q.db.ExecContext(ctx, query, models.QuoteStatusExpired, models.QuoteStatusSent, now)
+	if err != nil {
+		return 0, fmt.Errorf("failed to expire quotes: %w", err)
+	}
+
+	return result.RowsAffected()
+}
+
+func (q *quoteRepository) getOne(ctx context.Context, query string, args ...any) (*models.Quote, error) {
+	var quote models.Quote
+	err := q.db.GetContext(ctx, &quote, query, args...)
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return nil, fmt.Errorf("quote %w", exceptions.ErrNotFound)
+		}
+		return nil, fmt.Errorf("failed to get quote: %w", err)
+	}
+
+	if err := quote.AssignCurrency(); err != nil {
+		return nil, fmt.Errorf("failed to read quote amounts: %w", err)
+	}
+
+	return &quote, nil
+}
+
+func NewQuoteRepository(
+	db *sqlx.DB,
+	logger *zerolog.Logger,
+) repositories_interfaces.QuoteRepository {
+	return &quoteRepository{
+		db:     db,
+		logger: logger,
+	}
+}
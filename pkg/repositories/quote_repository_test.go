@@ -0,0 +1,65 @@
+package repositories
+
+import (
+	"context"
+	"regexp"
+	"testing"
+	"time"
+
+	"github.com/Adebayobenjamin/numerisbook/pkg/common/exceptions"
+	"github.com/Adebayobenjamin/numerisbook/pkg/models"
+	"github.com/DATA-DOG/go-sqlmock"
+	"github.com/rs/zerolog"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestQuoteRepository_ClaimForConversion(t *testing.T) {
+	db, mock, _ := getMockDB()
+	defer db.Close()
+
+	repo := &quoteRepository{db: db, logger: &zerolog.Logger{}}
+	claimedAt := time.Date(2025, 3, 1, 9, 0, 0, 0, time.UTC)
+	query := regexp.QuoteMeta(`SET converted_at = ?, updated_at = CURRENT_TIMESTAMP 
+		WHERE id = ? AND customer_id = ? AND status = ? AND converted_at IS NULL AND deleted_at IS NULL`)
+
+	t.Run("an accepted quote is claimed once", func(t *testing.T) {
+		mock.ExpectExec(query).
+			WithArgs(claimedAt, uint(9), uint(2), models.QuoteStatusAccepted).
+			WillReturnResult(sqlmock.NewResult(0, 1))
+
+		err := repo.ClaimForConversion(context.Background(), 9, 2, claimedAt)
+
+		assert.NoError(t, err)
+		assert.NoError(t, mock.ExpectationsWereMet())
+	})
+
+	t.Run("a quote claimed by another request is locked", func(t *testing.T) {
+		mock.ExpectExec(query).
+			WithArgs(claimedAt, uint(9), uint(2), models.QuoteStatusAccepted).
+			WillReturnResult(sqlmock.NewResult(0, 0))
+
+		err := repo.ClaimForConversion(context.Background(), 9, 2, claimedAt)
+
+		assert.ErrorIs(t, err, exceptions.ErrLocked)
+		assert.NoError(t, mock.ExpectationsWereMet())
+	})
+}
+
+func TestQuoteRepository_ExpireLapsed(t *testing.T) {
+	db, mock, _ := getMockDB()
+	defer db.Close()
+
+	repo := &quoteRepository{db: db, logger: &zerolog.Logger{}}
+	now := time.Date(2025, 3, 1, 9, 0, 0, 0, time.UTC)
+
+	// only quotes still waiting for an answer expire
+	mock.ExpectExec(regexp.QuoteMeta(`WHERE status = ? AND expiry_date <= ? AND deleted_at IS NULL`)).
+		WithArgs(models.QuoteStatusExpired, models.QuoteStatusSent, now).
+		WillReturnResult(sqlmock.NewResult(0, 3))
+
+	expired, err := repo.ExpireLapsed(context.Background(), now)
+
+	assert.NoError(t, err)
+	assert.Equal(t, int64(3), expired)
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
@@ -0,0 +1,148 @@
+package repositories
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+	"time"
+
+	"github.com/Adebayobenjamin/numerisbook/pkg/common/exceptions"
+	"github.com/Adebayobenjamin/numerisbook/pkg/models"
+	repositories_interfaces "github.com/Adebayobenjamin/numerisbook/pkg/repositories/interfaces"
+	"github.com/Adebayobenjamin/numerisbook/pkg/tenant"
+	"github.com/jmoiron/sqlx"
+	"github.com/rs/zerolog"
+)
+
+type recurringInvoiceRepository struct {
+	db     *sqlx.DB
+	logger *zerolog.Logger
+}
+
+// CreateProfile implements repositories_interfaces.RecurringInvoiceRepository.
+func (r *recurringInvoiceRepository) CreateProfile(ctx context.Context, profile *models.RecurringInvoiceProfile) (*models.RecurringInvoiceProfile, error) {
+	query := `
+		INSERT INTO recurring_invoice_profiles (
+			customer_id, name, frequency, rrule, start_date, end_date,
+			max_occurrences, occurrences_generated, due_in_days, auto_send,
+			template, status, next_run_at, created_at, updated_at
+		) VALUES (?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, CURRENT_TIMESTAMP, CURRENT_TIMESTAMP)`
+
+	result, err := r.db.ExecContext(ctx, query,
+		profile.CustomerID,
+		profile.Name,
+		profile.Frequency,
+		profile.RRule,
+		profile.StartDate,
+		profile.EndDate,
+		profile.MaxOccurrences,
+		profile.OccurrencesGenerated,
+		profile.DueInDays,
+		profile.AutoSend,
+		profile.Template,
+		profile.Status,
+		profile.NextRunAt)
+	if err != nil {
+		return nil, fmt.Errorf("failed to create recurring invoice profile: %w", err)
+	}
+
+	profileID, _ := result.LastInsertId()
+
+	return r.GetByIDAndCustomerID(ctx, uint(profileID), profile.CustomerID)
+}
+
+// GetByIDAndCustomerID implements repositories_interfaces.RecurringInvoiceRepository.
+func (r *recurringInvoiceRepository) GetByIDAndCustomerID(ctx context.Context, id uint, customerID uint) (*models.RecurringInvoiceProfile, error) {
+	query := `
+		SELECT * FROM recurring_invoice_profiles 
+		WHERE id = ? AND customer_id = ? AND deleted_at IS NULL`
+
+	var profile models.RecurringInvoiceProfile
+	err := r.db.GetContext(ctx, &profile, query, id, customerID)
+	if err != nil {
+		if err == sql.ErrNoRows {
+			return nil, fmt.Errorf("recurring invoice profile %w", exceptions.ErrNotFound)
+		}
+		return nil, fmt.Errorf("failed to get recurring invoice profile: %w", err)
+	}
+
+	return &profile, nil
+}
+
+// GetAllCustomerProfiles implements repositories_interfaces.RecurringInvoiceRepository.
+func (r *recurringInvoiceRepository) GetAllCustomerProfiles(ctx context.Context, customerID uint, limit int, offset int) ([]models.RecurringInvoiceProfile, error) {
+	query := `
+		SELECT * FROM recurring_invoice_profiles 
+		WHERE customer_id = ? AND deleted_at IS NULL 
+		ORDER BY created_at DESC 
+		LIMIT ? OFFSET ?`
+
+	var profiles []models.RecurringInvoiceProfile
+	err := r.db.SelectContext(ctx, &profiles, query, customerID, limit, offset)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get recurring invoice profiles: %w", err)
+	}
+
+	return profiles, nil
+}
+
+// GetDueProfiles implements repositories_interfaces.RecurringInvoiceRepository.
+func (r *recurringInvoiceRepository) GetDueProfiles(ctx context.Context, now time.Time, limit int) ([]models.RecurringInvoiceProfile, error) {
+	query := `
+		SELECT * FROM recurring_invoice_profiles 
+		WHERE status = 'active' AND next_run_at <= ? AND deleted_at IS NULL 
+		ORDER BY next_run_at ASC 
+		LIMIT ?`
+
+	var profiles []models.RecurringInvoiceProfile
+	err := r.db.SelectContext(ctx, &profiles, query, now, limit)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get due recurring invoice profiles: %w", err)
+	}
+
+	return profiles, nil
+}
+
+// UpdateSchedule implements repositories_interfaces.RecurringInvoiceRepository.
+func (r *recurringInvoiceRepository) UpdateSchedule(ctx context.Context, profile *models.RecurringInvoiceProfile, expectedNextRunAt *time.Time) (bool, error) {
+	customerID, err := tenant.CustomerIDFromContext(ctx)
+	if err != nil {
+		return false, err
+	}
+
+	// next_run_at is compared as well so that when several workers pick up the
+	// same run only the first one to move the schedule on gets to generate it
+	query := `
+		UPDATE recurring_invoice_profiles 
+		SET status = ?, next_run_at = ?, last_run_at = ?, occurrences_generated = ?, updated_at = CURRENT_TIMESTAMP
+		WHERE id = ? AND customer_id = ? AND deleted_at IS NULL AND next_run_at <=> ?`
+
+	result, err := r.db.ExecContext(ctx, query,
+		profile.Status,
+		profile.NextRunAt,
+		profile.LastRunAt,
+		profile.OccurrencesGenerated,
+		profile.ID,
+		customerID,
+		expectedNextRunAt)
+	if err != nil {
+		return false, fmt.Errorf("failed to update recurring invoice schedule: %w", err)
+	}
+
+	rows, err := result.RowsAffected()
+	if err != nil {
+		return false, fmt.Errorf("failed to get affected rows: %w", err)
+	}
+
+	return rows > 0, nil
+}
+
+func NewRecurringInvoiceRepository(
+	db *sqlx.DB,
+	logger *zerolog.Logger,
+) repositories_interfaces.RecurringInvoiceRepository {
+	return &recurringInvoiceRepository{
+		db:     db,
+		logger: logger,
+	}
+}
@@ -3,9 +3,11 @@ package repositories
 import (
 	"context"
 	"fmt"
+	"time"
 
 	"github.com/Adebayobenjamin/numerisbook/pkg/models"
 	repositories_interfaces "github.com/Adebayobenjamin/numerisbook/pkg/repositories/interfaces"
+	"github.com/Adebayobenjamin/numerisbook/pkg/tenant"
 	"github.com/jmoiron/sqlx"
 	"github.com/rs/zerolog"
 )
@@ -34,6 +36,10 @@ func (r *reminderRepository) UpsertReminders(ctx context.Context, reminders []mo
 		)
 		ON DUPLICATE KEY UPDATE 
 			customer_id = VALUES(customer_id),
+			sent_at = IF(reminder_date <=> VALUES(reminder_date), sent_at, NULL),
+			attempts = IF(reminder_date <=> VALUES(reminder_date), attempts, 0),
+			last_error = IF(reminder_date <=> VALUES(reminder_date), last_error, NULL),
+			next_attempt_at = IF(reminder_date <=> VALUES(reminder_date), next_attempt_at, NULL),
 			reminder_date = VALUES(reminder_date),
 			deleted_at = VALUES(deleted_at),
 			updated_at = CURRENT_TIMESTAMP`
@@ -46,6 +52,104 @@ func (r *reminderRepository) UpsertReminders(ctx context.Context, reminders []mo
 	return nil
 }
 
+// ClaimDueReminders implements repositories_interfaces.ReminderRepository.
+func (r *reminderRepository) ClaimDueReminders(ctx context.Context, now time.Time, lease time.Duration, maxAttempts int, limit int) ([]models.InvoiceReminder, error) {
+	tx, err := r.db.BeginTxx(ctx, nil)
+	if err != nil {
+		return nil, fmt.Errorf("failed to begin transaction: %w", err)
+	}
+	defer tx.Rollback()
+
+	// SKIP LOCKED lets every replica claim a different batch. Only invoices
+	// still waiting on payment are reminded about.
+	query := `
+		SELECT r.* FROM invoice_reminders r
+		INNER JOIN invoices i ON i.id = r.invoice_id
+		WHERE r.sent_at IS NULL 
+			AND r.deleted_at IS NULL 
+			AND r.reminder_date <= ? 
+			AND (r.next_attempt_at IS NULL OR r.next_attempt_at <= ?) 
+			AND r.attempts < ? 
+			AND i.deleted_at IS NULL 
+			AND i.status IN ('sent', 'partially_paid', 'overdue')
+		ORDER BY r.reminder_date ASC 
+		LIMIT ? 
+		FOR UPDATE OF r SKIP LOCKED`
+
+	var reminders []models.InvoiceReminder
+	if err := tx.SelectContext(ctx, &reminders, query, now, now, maxAttempts, limit); err != nil {
+		return nil, fmt.Errorf("failed to get due reminders: %w", err)
+	}
+	if len(reminders) == 0 {
+		return reminders, nil
+	}
+
+	// the claim counts as an attempt and holds the reminder for the length of
+	// the lease, so a worker that dies mid-send does not block it for good
+	leaseUntil := now.Add(lease)
+	ids := make([]uint, len(reminders))
+	for idx := range reminders {
+		ids[idx] = reminders[idx].ID
+		reminders[idx].Attempts++
+		reminders[idx].NextAttemptAt = &leaseUntil
+	}
+
+	claimQuery, args, err := sqlx.In(`
+		UPDATE invoice_reminders 
+		SET attempts = attempts + 1, next_attempt_at = ?, updated_at = CURRENT_TIMESTAMP 
+		WHERE id IN (?)`, leaseUntil, ids)
+	if err != nil {
+		return nil, fmt.Errorf("failed to build claim query: %w", err)
+	}
+	if _, err := tx.ExecContext(ctx, tx.Rebind(claimQuery), args...); err != nil {
+		return nil, fmt.Errorf("failed to claim reminders: %w", err)
+	}
+
+	if err := tx.Commit(); err != nil {
+		return nil, fmt.Errorf("failed to commit transaction: %w", err)
+	}
+
+	return reminders, nil
+}
+
+// MarkReminderSent implements repositories_interfaces.ReminderRepository.
+func (r *reminderRepository) MarkReminderSent(ctx context.Context, reminderID uint, sentAt time.Time) error {
+	customerID, err := tenant.CustomerIDFromContext(ctx)
+	if err != nil {
+		return err
+	}
+
+	query := `
+		UPDATE invoice_reminders 
+		SET sent_at = ?, last_error = NULL, next_attempt_at = NULL, updated_at = CURRENT_TIMESTAMP 
+		WHERE id = ? AND customer_id = ?`
+
+	if _, err := r.db.ExecContext(ctx, query, sentAt, reminderID, customerID); err != nil {
+		return fmt.Errorf("failed to mark reminder as sent: %w", err)
+	}
+
+	return nil
+}
+
+// MarkReminderFailed implements repositories_interfaces.ReminderRepository.
+func (r *reminderRepository) MarkReminderFailed(ctx context.Context, reminderID uint, lastError string, nextAttemptAt *time.Time) error {
+	customerID, err := tenant.CustomerIDFromContext(ctx)
+	if err != nil {
+		return err
+	}
+
+	query := `
+		UPDATE invoice_reminders 
+		SET last_error = ?, next_attempt_at = ?, updated_at = CURRENT_TIMESTAMP 
+		WHERE id = ? AND customer_id = ?`
+
+	if _, err := r.db.ExecContext(ctx, query, lastError, nextAttemptAt, reminderID, customerID); err != nil {
+		return fmt.Errorf("failed to mark reminder as failed: %w", err)
+	}
+
+	return nil
+}
+
 func NewReminderRepository(
 	db *sqlx.DB, logger *zerolog.Logger,
 ) repositories_interfaces.ReminderRepository {
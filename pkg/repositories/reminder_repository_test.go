@@ -0,0 +1,59 @@
+package repositories
+
+import (
+	"context"
+	"regexp"
+	"testing"
+	"time"
+
+	"github.com/DATA-DOG/go-sqlmock"
+	"github.com/rs/zerolog"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestReminderRepository_ClaimDueReminders(t *testing.T) {
+	db, mock, _ := getMockDB()
+	defer db.Close()
+
+	repo := &reminderRepository{db: db, logger: &zerolog.Logger{}}
+	now := time.Date(2024, 3, 8, 9, 0, 0, 0, time.UTC)
+	lease := 5 * time.Minute
+
+	t.Run("locks the due rows and counts the claim as an attempt", func(t *testing.T) {
+		rows := sqlmock.NewRows([]string{"id", "invoice_id", "customer_id", "schedule", "reminder_date", "attempts"}).
+			AddRow(3, 1, 2, "7_days_before_due", now.Add(-time.Hour), 0).
+			AddRow(4, 5, 2, "on_due", now.Add(-time.Minute), 1)
+
+		mock.ExpectBegin()
+		mock.ExpectQuery(regexp.QuoteMeta(`FOR UPDATE OF r SKIP LOCKED`)).
+			WithArgs(now, now, 5, 50).
+			WillReturnRows(rows)
+		mock.ExpectExec(regexp.QuoteMeta(`SET attempts = attempts + 1, next_attempt_at = ?`)).
+			WithArgs(now.Add(lease), uint(3), uint(4)).
+			WillReturnResult(sqlmock.NewResult(0, 2))
+		mock.ExpectCommit()
+
+		reminders, err := repo.ClaimDueReminders(context.Background(), now, lease, 5, 50)
+
+		assert.NoError(t, err)
+		assert.Len(t, reminders, 2)
+		assert.Equal(t, 1, reminders[0].Attempts)
+		assert.Equal(t, 2, reminders[1].Attempts)
+		assert.Equal(t, now.Add(lease), *reminders[1].NextAttemptAt)
+	})
+
+	t.Run("nothing due", func(t *testing.T) {
+		mock.ExpectBegin()
+		mock.ExpectQuery(regexp.QuoteMeta(`FOR UPDATE OF r SKIP LOCKED`)).
+			WithArgs(now, now, 5, 50).
+			WillReturnRows(sqlmock.NewRows([]string{"id"}))
+		mock.ExpectRollback()
+
+		reminders, err := repo.ClaimDueReminders(context.Background(), now, lease, 5, 50)
+
+		assert.NoError(t, err)
+		assert.Empty(t, reminders)
+	})
+
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
@@ -0,0 +1,131 @@
+package repositories
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/Adebayobenjamin/numerisbook/pkg/common/exceptions"
+	"github.com/Adebayobenjamin/numerisbook/pkg/models"
+	repositories_interfaces "github.com/Adebayobenjamin/numerisbook/pkg/repositories/interfaces"
+	"github.com/Adebayobenjamin/numerisbook/pkg/tenant"
+	"github.com/jmoiron/sqlx"
+	"github.com/rs/zerolog"
+)
+
+type shareLinkRepository struct {
+	db     *sqlx.DB
+	logger *zerolog.Logger
+}
+
+// Create implements repositories_interfaces.ShareLinkRepository.
+func (s *shareLinkRepository) Create(ctx context.Context, link *models.InvoiceShareLink) (*models.InvoiceShareLink, error) {
+	query := `
+		INSERT INTO invoice_share_links (
+			invoice_id, customer_id, token_id, expires_at, created_at, updated_at
+		) VALUES (?, ?, ?, ?, CURRENT_TIMESTAMP, CURRENT_TIMESTAMP)`
+
+	_, err := s.db.ExecContext(ctx, query, link.InvoiceID, link.CustomerID, link.TokenID, link.ExpiresAt)
+	if err != nil {
+		return nil, fmt.Errorf("failed to create share link: %w", err)
+	}
+
+	return s.GetByTokenID(ctx, link.TokenID)
+}
+
+// GetByTokenID implements repositories_interfaces.ShareLinkRepository.
+func (s *shareLinkRepository) GetByTokenID(ctx context.Context, tokenID string) (*models.InvoiceShareLink, error) {
+	query := `SELECT * FROM invoice_share_links WHERE token_id = ?`
+
+	var link models.InvoiceShareLink
+	err := s.db.GetContext(ctx, &link, query, tokenID)
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return nil, fmt.Errorf("share link %w", exceptions.ErrNotFound)
+		}
+		return nil, fmt.Errorf("failed to get share link: %w", err)
+	}
+
+	return &link, nil
+}
+
+// GetActiveByInvoiceID implements repositories_interfaces.ShareLinkRepository.
+func (s *shareLinkRepository) GetActiveByInvoiceID(ctx context.Context, invoiceID uint, now time.Time) (*models.InvoiceShareLink, error) {
+	customerID, err := tenant.CustomerIDFromContext(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	query := `
+		SELECT * FROM invoice_share_links 
+		WHERE invoice_id = ? AND customer_id = ? AND revoked_at IS NULL AND expires_at > ? 
+		ORDER BY expires_at DESC 
+		LIMIT 1`
+
+	var link models.InvoiceShareLink
+	err = s.db.GetContext(ctx, &link, query, invoiceID, customerID, now)
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return nil, fmt.Errorf("share link %w", exceptions.ErrNotFound)
+		}
+		return nil, fmt.Errorf("failed to get share link: %w", err)
+	}
+
+	return &link, nil
+}
+
+// RevokeByInvoiceID implements repositories_interfaces.ShareLinkRepository.
+func (s *shareLinkRepository) RevokeByInvoiceID(ctx context.Context, invoiceID uint, revokedAt time.Time) (int64, error) {
+	customerID, err := tenant.CustomerIDFromContext(ctx)
+	if err != nil {
+		return 0, err
+	}
+
+	query := `
+		UPDATE invoice_share_links 
+		SET revoked_at = ?, updated_at = CURRENT_TIMESTAMP 
+		WHERE invoice_id = ? AND customer_id = ? AND revoked_at IS NULL`
+
+	result, err := s.db.ExecContext(ctx, query, revokedAt, invoiceID, customerID)
+	if err != nil {
+		return 0, fmt.Errorf("failed to revoke share links: %w", err)
+	}
+
+	revoked, _ := result.RowsAffected()
+	return revoked, nil
+}
+
+// RecordView implements repositories_interfaces.ShareLinkRepository.
+func (s *shareLinkRepository) RecordView(ctx context.Context, id uint, viewedAt time.Time) error {
+	customerID, err := tenant.CustomerIDFromContext(ctx)
+	if err != nil {
+		return err
+	}
+
+	query := `
+		UPDATE invoice_share_links 
+		SET view_count = view_count + 1, 
+			first_viewed_at = COALESCE(first_viewed_at, ?), 
+			last_viewed_at = ?, 
+			updated_at = CURRENT_TIMESTAMP 
+		WHERE id = ? AND customer_id = ?`
+
+	_, err = s.db.ExecContext(ctx, query, viewedAt, viewedAt, id, customerID)
+	if err != nil {
+		return fmt.Errorf("failed to record share link view: %w", err)
+	}
+
+	return nil
+}
+
+func NewShareLinkRepository(
+	db *sqlx.DB,
+	logger *zerolog.Logger,
+) repositories_interfaces.ShareLinkRepository {
+	return &shareLinkRepository{
+		db:     db,
+		logger: logger,
+	}
+}
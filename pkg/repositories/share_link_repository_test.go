@@ -0,0 +1,69 @@
+package repositories
+
+import (
+	"context"
+	"regexp"
+	"testing"
+	"time"
+
+	"github.com/Adebayobenjamin/numerisbook/pkg/common/exceptions"
+	"github.com/Adebayobenjamin/numerisbook/pkg/tenant"
+	"github.com/DATA-DOG/go-sqlmock"
+	"github.com/rs/zerolog"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestShareLinkRepository_GetActiveByInvoiceID(t *testing.T) {
+	db, mock, _ := getMockDB()
+	defer db.Close()
+
+	repo := &shareLinkRepository{db: db, logger: &zerolog.Logger{}}
+	now := time.Date(2024, 3, 8, 9, 0, 0, 0, time.UTC)
+	ctx := tenant.WithCustomerID(context.Background(), 2)
+
+	t.Run("returns the link that expires last", func(t *testing.T) {
+		rows := sqlmock.NewRows([]string{"id", "invoice_id", "customer_id", "token_id", "expires_at", "view_count"}).
+			AddRow(7, 1, 2, "0123456789abcdef0123456789abcdef", now.Add(24*time.Hour), 3)
+		mock.ExpectQuery(regexp.QuoteMeta(`WHERE invoice_id = ? AND customer_id = ? AND revoked_at IS NULL AND expires_at > ?`)).
+			WithArgs(uint(1), uint(2), now).
+			WillReturnRows(rows)
+
+		link, err := repo.GetActiveByInvoiceID(ctx, 1, now)
+
+		assert.NoError(t, err)
+		assert.Equal(t, uint(7), link.ID)
+		assert.Equal(t, 3, link.ViewCount)
+	})
+
+	t.Run("no active link is reported as not found", func(t *testing.T) {
+		mock.ExpectQuery(regexp.QuoteMeta(`WHERE invoice_id = ? AND customer_id = ? AND revoked_at IS NULL AND expires_at > ?`)).
+			WithArgs(uint(2), uint(2), now).
+			WillReturnRows(sqlmock.NewRows([]string{"id"}))
+
+		link, err := repo.GetActiveByInvoiceID(ctx, 2, now)
+
+		assert.Nil(t, link)
+		assert.ErrorIs(t, err, exceptions.ErrNotFound)
+	})
+
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
+
+func TestShareLinkRepository_RecordView(t *testing.T) {
+	db, mock, _ := getMockDB()
+	defer db.Close()
+
+	repo := &shareLinkRepository{db: db, logger: &zerolog.Logger{}}
+	viewedAt := time.Date(2024, 3, 8, 9, 0, 0, 0, time.UTC)
+
+	mock.ExpectExec(regexp.QuoteMeta(`SET view_count = view_count + 1, 
+			first_viewed_at = COALESCE(first_viewed_at, ?), 
+			last_viewed_at = ?`)).
+		WithArgs(viewedAt, viewedAt, uint(7), uint(2)).
+		WillReturnResult(sqlmock.NewResult(0, 1))
+
+	err := repo.RecordView(tenant.WithCustomerID(context.Background(), 2), 7, viewedAt)
+
+	assert.NoError(t, err)
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
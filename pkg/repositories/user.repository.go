@@ -0,0 +1,139 @@
+package repositories
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+
+	"github.com/Adebayobenjamin/numerisbook/pkg/common/exceptions"
+	"github.com/Adebayobenjamin/numerisbook/pkg/models"
+	repositories_interfaces "github.com/Adebayobenjamin/numerisbook/pkg/repositories/interfaces"
+	"github.com/go-sql-driver/mysql"
+	"github.com/jmoiron/sqlx"
+	"github.com/rs/zerolog"
+)
+
+// mysqlDuplicateEntry is the error number MySQL reports for unique key violations
+const mysqlDuplicateEntry = 1062
+
+type userRepository struct {
+	db     *sqlx.DB
+	logger *zerolog.Logger
+}
+
+// Create implements repositories_interfaces.UserRepository.
+func (u *userRepository) Create(ctx context.Context, user *models.User) (*models.User, error) {
+	query := `
+		INSERT INTO users (
+			customer_id, subject, email, name, role, created_at, updated_at
+		) VALUES (?, ?, ?, ?, ?, CURRENT_TIMESTAMP, CURRENT_TIMESTAMP)`
+
+	result, err := u.db.ExecContext(ctx, query, user.CustomerID, user.Subject, user.Email, user.Name, user.Role)
+	if err != nil {
+		var mysqlErr *mysql.MySQLError
+		if errors.As(err, &mysqlErr) && mysqlErr.Number == mysqlDuplicateEntry {
+			return nil, fmt.Errorf("a member with this subject already exists")
+		}
+		return nil, fmt.Errorf("failed to create user: %w", err)
+	}
+
+	userID, _ := result.LastInsertId()
+
+	return u.GetByIDAndCustomerID(ctx, uint(userID), user.CustomerID)
+}
+
+// GetByIDAndCustomerID implements repositories_interfaces.UserRepository.
+func (u *userRepository) GetByIDAndCustomerID(ctx context.Context, id uint, customerID uint) (*models.User, error) {
+	query := `SELECT * FROM users WHERE id = ? AND customer_id = ?`
+
+	var user models.User
+	err := u.db.GetContext(ctx, &user, query, id, customerID)
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return nil, fmt.Errorf("user %w", exceptions.ErrNotFound)
+		}
+		return nil, fmt.Errorf("failed to get user: %w", err)
+	}
+
+	return &user, nil
+}
+
+// GetBySubject implements repositories_interfaces.UserRepository.
+func (u *userRepository) GetBySubject(ctx context.Context, customerID uint, subject string) (*models.User, error) {
+	query := `SELECT * FROM users WHERE customer_id = ? AND subject = ?`
+
+	var user models.User
+	err := u.db.GetContext(ctx, &user, query, customerID, subject)
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return nil, fmt.Errorf("user %w", exceptions.ErrNotFound)
+		}
+		return nil, fmt.Errorf("failed to get user: %w", err)
+	}
+
+	return &user, nil
+}
+
+// GetAllCustomerUsers implements repositories_interfaces.UserRepository.
+func (u *userRepository) GetAllCustomerUsers(ctx context.Context, customerID uint) ([]models.User, error) {
+	query := `SELECT * FROM users WHERE customer_id = ? ORDER BY created_at ASC`
+
+	users := []models.User{}
+	err := u.db.SelectContext(ctx, &users, query, customerID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get users: %w", err)
+	}
+
+	return users, nil
+}
+
+// CountOwners implements repositories_interfaces.UserRepository.
+func (u *userRepository) CountOwners(ctx context.Context, customerID uint) (int, error) {
+	query := `SELECT COUNT(*) FROM users WHERE customer_id = ? AND role = ?`
+
+	var owners int
+	err := u.db.GetContext(ctx, &owners, query, customerID, models.UserRoleOwner)
+	if err != nil {
+		return 0, fmt.Errorf("failed to count owners: %w", err)
+	}
+
+	return owners, nil
+}
+
+// UpdateRole implements repositories_interfaces.UserRepository.
+func (u *userRepository) UpdateRole(ctx context.Context, id uint, customerID uint, role models.UserRole) error {
+	query := `
+		UPDATE users 
+		SET role = ?, updated_at = CURRENT_TIMESTAMP 
+		WHERE id = ? AND customer_id = ?`
+
+	_, err := u.db.ExecContext(ctx, query, role, id, customerID)
+	if err != nil {
+		return fmt.Errorf("failed to update user role: %w", err)
+	}
+
+	return nil
+}
+
+// Delete implements repositories_interfaces.UserRepository.
+func (u *userRepository) Delete(ctx context.Context, id uint, customerID uint) error {
+	query := `DELETE FROM users WHERE id = ? AND customer_id = ?`
+
+	_, err := u.db.ExecContext(ctx, query, id, customerID)
+	if err != nil {
+		return fmt.Errorf("failed to remove user: %w", err)
+	}
+
+	return nil
+}
+
+func NewUserRepository(
+	db *sqlx.DB,
+	logger *zerolog.Logger,
+) repositories_interfaces.UserRepository {
+	return &userRepository{
+		db:     db,
+		logger: logger,
+	}
+}
@@ -0,0 +1,19 @@
+package router
+
+import (
+	"github.com/Adebayobenjamin/numerisbook/pkg/auth"
+	controller_interfaces "github.com/Adebayobenjamin/numerisbook/pkg/controllers/interfaces"
+	"github.com/Adebayobenjamin/numerisbook/pkg/middleware"
+	"github.com/gin-gonic/gin"
+)
+
+func NewAPIKeyRouter(apiKeyController controller_interfaces.APIKeyController, router *gin.RouterGroup, requiresAuth gin.HandlerFunc) *gin.RouterGroup {
+	apiKeyRouter := router.Group("/api-keys")
+	apiKeyRouter.Use(requiresAuth, middlewares.RequiresPermission(auth.PermissionAPIKeysManage))
+
+	apiKeyRouter.POST("", apiKeyController.Create)
+	apiKeyRouter.GET("", apiKeyController.GetCustomerKeys)
+	apiKeyRouter.DELETE("/:api_key_id", apiKeyController.Revoke)
+
+	return apiKeyRouter
+}
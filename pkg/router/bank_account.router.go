@@ -0,0 +1,28 @@
+package router
+
+import (
+	"github.com/Adebayobenjamin/numerisbook/pkg/auth"
+	controller_interfaces "github.com/Adebayobenjamin/numerisbook/pkg/controllers/interfaces"
+	"github.com/Adebayobenjamin/numerisbook/pkg/middleware"
+	"github.com/gin-gonic/gin"
+)
+
+func NewBankAccountRouter(bankAccountController controller_interfaces.BankAccountController, router *gin.RouterGroup, requiresAuth gin.HandlerFunc, idempotent gin.HandlerFunc) *gin.RouterGroup {
+	bankAccountRouter := router.Group("/bank-accounts")
+	bankAccountRouter.Use(requiresAuth, idempotent)
+
+	canRead := middlewares.RequiresPermission(auth.PermissionSettingsRead)
+	canManage := middlewares.RequiresPermission(auth.PermissionSettingsManage)
+
+	// The accounts the customer asks to be paid into
+	bankAccountRouter.POST("", canManage, bankAccountController.Create)
+	bankAccountRouter.GET("", canRead, bankAccountController.GetCustomerBankAccounts)
+	bankAccountRouter.GET("/:bank_account_id", canRead, bankAccountController.GetDetails)
+	bankAccountRouter.PUT("/:bank_account_id", canManage, bankAccountController.Update)
+	bankAccountRouter.DELETE("/:bank_account_id", canManage, bankAccountController.Delete)
+
+	// Invoices without payment details ask to be paid into the default
+	bankAccountRouter.POST("/:bank_account_id/default", canManage, bankAccountController.SetDefault)
+
+	return bankAccountRouter
+}
@@ -0,0 +1,28 @@
+package router
+
+import (
+	"github.com/Adebayobenjamin/numerisbook/pkg/auth"
+	controller_interfaces "github.com/Adebayobenjamin/numerisbook/pkg/controllers/interfaces"
+	"github.com/Adebayobenjamin/numerisbook/pkg/middleware"
+	"github.com/gin-gonic/gin"
+)
+
+func NewBusinessProfileRouter(businessProfileController controller_interfaces.BusinessProfileController, router *gin.RouterGroup, requiresAuth gin.HandlerFunc, idempotent gin.HandlerFunc) *gin.RouterGroup {
+	businessProfileRouter := router.Group("/business-profiles")
+	businessProfileRouter.Use(requiresAuth, idempotent)
+
+	canRead := middlewares.RequiresPermission(auth.PermissionSettingsRead)
+	canManage := middlewares.RequiresPermission(auth.PermissionSettingsManage)
+
+	// The names the customer issues invoices under
+	businessProfileRouter.POST("", canManage, businessProfileController.Create)
+	businessProfileRouter.GET("", canRead, businessProfileController.GetCustomerBusinessProfiles)
+	businessProfileRouter.GET("/:business_profile_id", canRead, businessProfileController.GetDetails)
+	businessProfileRouter.PUT("/:business_profile_id", canManage, businessProfileController.Update)
+	businessProfileRouter.DELETE("/:business_profile_id", canManage, businessProfileController.Delete)
+
+	// Invoices that do not pick a profile are issued under the default
+	businessProfileRouter.POST("/:business_profile_id/default", canManage, businessProfileController.SetDefault)
+
+	return businessProfileRouter
+}
@@ -0,0 +1,26 @@
+package router
+
+import (
+	"github.com/Adebayobenjamin/numerisbook/pkg/auth"
+	controller_interfaces "github.com/Adebayobenjamin/numerisbook/pkg/controllers/interfaces"
+	"github.com/Adebayobenjamin/numerisbook/pkg/middleware"
+	"github.com/gin-gonic/gin"
+)
+
+func NewCatalogItemRouter(catalogItemController controller_interfaces.CatalogItemController, router *gin.RouterGroup, requiresAuth gin.HandlerFunc, idempotent gin.HandlerFunc) *gin.RouterGroup {
+	catalogRouter := router.Group("/catalog-items")
+	catalogRouter.Use(requiresAuth, idempotent)
+
+	canRead := middlewares.RequiresPermission(auth.PermissionInvoicesRead)
+	canWrite := middlewares.RequiresPermission(auth.PermissionInvoicesWrite)
+
+	// The products and services invoice lines can be billed from
+	catalogRouter.POST("", canWrite, catalogItemController.Create)
+	catalogRouter.GET("", canRead, catalogItemController.GetCustomerCatalogItems)
+	catalogRouter.GET("/revenue", canRead, catalogItemController.GetRevenue)
+	catalogRouter.GET("/:catalog_item_id", canRead, catalogItemController.GetDetails)
+	catalogRouter.PUT("/:catalog_item_id", canWrite, catalogItemController.Update)
+	catalogRouter.DELETE("/:catalog_item_id", canWrite, catalogItemController.Delete)
+
+	return catalogRouter
+}
@@ -0,0 +1,28 @@
+package router
+
+import (
+	"github.com/Adebayobenjamin/numerisbook/pkg/auth"
+	controller_interfaces "github.com/Adebayobenjamin/numerisbook/pkg/controllers/interfaces"
+	"github.com/Adebayobenjamin/numerisbook/pkg/middleware"
+	"github.com/gin-gonic/gin"
+)
+
+func NewClientRouter(clientController controller_interfaces.ClientController, router *gin.RouterGroup, requiresAuth gin.HandlerFunc, idempotent gin.HandlerFunc) *gin.RouterGroup {
+	clientRouter := router.Group("/clients")
+	clientRouter.Use(requiresAuth, idempotent)
+
+	canRead := middlewares.RequiresPermission(auth.PermissionInvoicesRead)
+	canWrite := middlewares.RequiresPermission(auth.PermissionInvoicesWrite)
+
+	// The directory of parties the customer bills
+	clientRouter.POST("", canWrite, clientController.Create)
+	clientRouter.GET("", canRead, clientController.GetCustomerClients)
+	clientRouter.GET("/:client_id", canRead, clientController.GetDetails)
+	clientRouter.PUT("/:client_id", canWrite, clientController.Update)
+
+	// Deleting archives the client, it can be restored later
+	clientRouter.DELETE("/:client_id", canWrite, clientController.Delete)
+	clientRouter.POST("/:client_id/restore", canWrite, clientController.Restore)
+
+	return clientRouter
+}
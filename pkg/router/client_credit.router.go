@@ -0,0 +1,21 @@
+package router
+
+import (
+	"github.com/Adebayobenjamin/numerisbook/pkg/auth"
+	controller_interfaces "github.com/Adebayobenjamin/numerisbook/pkg/controllers/interfaces"
+	"github.com/Adebayobenjamin/numerisbook/pkg/middleware"
+	"github.com/gin-gonic/gin"
+)
+
+func NewClientCreditRouter(clientCreditController controller_interfaces.ClientCreditController, router *gin.RouterGroup, requiresAuth gin.HandlerFunc, idempotent gin.HandlerFunc) *gin.RouterGroup {
+	canRead := middlewares.RequiresPermission(auth.PermissionInvoicesRead)
+	canConfirm := middlewares.RequiresPermission(auth.PermissionPaymentsConfirm)
+
+	// What clients overpaid is kept as their credit and can pay their other invoices
+	clientCreditRouter := router.Group("/clients/:client_id/credits")
+	clientCreditRouter.Use(requiresAuth, idempotent)
+	clientCreditRouter.GET("", canRead, clientCreditController.GetClientCredits)
+	clientCreditRouter.POST("/apply", canConfirm, clientCreditController.ApplyCredit)
+
+	return clientCreditRouter
+}
@@ -0,0 +1,27 @@
+package router
+
+import (
+	"github.com/Adebayobenjamin/numerisbook/pkg/auth"
+	controller_interfaces "github.com/Adebayobenjamin/numerisbook/pkg/controllers/interfaces"
+	"github.com/Adebayobenjamin/numerisbook/pkg/middleware"
+	"github.com/gin-gonic/gin"
+)
+
+func NewCreditNoteRouter(creditNoteController controller_interfaces.CreditNoteController, router *gin.RouterGroup, requiresAuth gin.HandlerFunc, idempotent gin.HandlerFunc) *gin.RouterGroup {
+	canRead := middlewares.RequiresPermission(auth.PermissionInvoicesRead)
+	canWrite := middlewares.RequiresPermission(auth.PermissionInvoicesWrite)
+
+	// Credit notes are issued against an invoice
+	invoiceCreditNoteRouter := router.Group("/invoices/:invoice_id/credit-notes")
+	invoiceCreditNoteRouter.Use(requiresAuth, idempotent)
+	invoiceCreditNoteRouter.POST("", canWrite, creditNoteController.Create)
+	invoiceCreditNoteRouter.GET("", canRead, creditNoteController.GetInvoiceCreditNotes)
+
+	// and listed across all of the customer's invoices
+	creditNoteRouter := router.Group("/credit-notes")
+	creditNoteRouter.Use(requiresAuth)
+	creditNoteRouter.GET("", canRead, creditNoteController.GetCustomerCreditNotes)
+	creditNoteRouter.GET("/:credit_note_id", canRead, creditNoteController.GetDetails)
+
+	return creditNoteRouter
+}
@@ -1,36 +1,50 @@
 package router
 
 import (
+	"github.com/Adebayobenjamin/numerisbook/pkg/auth"
 	controller_interfaces "github.com/Adebayobenjamin/numerisbook/pkg/controllers/interfaces"
 	"github.com/Adebayobenjamin/numerisbook/pkg/middleware"
 	"github.com/gin-gonic/gin"
 )
 
-func NewInvoiceRouter(invoiceController controller_interfaces.InvoiceController, router *gin.RouterGroup) *gin.RouterGroup {
+func NewInvoiceRouter(invoiceController controller_interfaces.InvoiceController, router *gin.RouterGroup, requiresAuth gin.HandlerFunc, idempotent gin.HandlerFunc) *gin.RouterGroup {
 	invoiceRouter := router.Group("/invoices")
-	invoiceRouter.Use(middlewares.RequiresAuthHeader())
+	invoiceRouter.Use(requiresAuth, idempotent)
+
+	canRead := middlewares.RequiresPermission(auth.PermissionInvoicesRead)
+	canWrite := middlewares.RequiresPermission(auth.PermissionInvoicesWrite)
 
 	// Create and manage invoices
-	invoiceRouter.POST("", invoiceController.Create)
-	invoiceRouter.GET("/:invoice_id", invoiceController.GetDetails)
-	invoiceRouter.GET("/statistics", invoiceController.GetStatistics)
-	invoiceRouter.GET("", invoiceController.GetCustomerInvoices)
+	invoiceRouter.POST("", canWrite, invoiceController.Create)
+	invoiceRouter.GET("/:invoice_id", canRead, invoiceController.GetDetails)
+	invoiceRouter.GET("/:invoice_id/pdf", canRead, invoiceController.GetPDF)
+	invoiceRouter.GET("/statistics", canRead, invoiceController.GetStatistics)
+	invoiceRouter.GET("", canRead, invoiceController.GetCustomerInvoices)
+
+	// Drafts can be edited and deleted, against the ETag they were last read at.
+	// PATCH is the same as PUT: the request replaces the whole draft.
+	invoiceRouter.PUT("/:invoice_id", canWrite, invoiceController.Update)
+	invoiceRouter.PATCH("/:invoice_id", canWrite, invoiceController.Update)
+	invoiceRouter.DELETE("/:invoice_id", canWrite, invoiceController.Delete)
+
+	// Lifecycle transitions
+	invoiceRouter.POST("/:invoice_id/send", canWrite, invoiceController.Send)
+	invoiceRouter.POST("/:invoice_id/void", canWrite, invoiceController.Void)
+	invoiceRouter.POST("/:invoice_id/cancel", canWrite, invoiceController.Cancel)
+	invoiceRouter.POST("/:invoice_id/write-off", canWrite, invoiceController.WriteOff)
 
 	// Payment confirmation
-	invoiceRouter.POST("/:invoice_id/confirm-payment", invoiceController.ConfirmPayment)
+	invoiceRouter.POST("/:invoice_id/confirm-payment", middlewares.RequiresPermission(auth.PermissionPaymentsConfirm), invoiceController.ConfirmPayment)
 
 	// Reminders
-	invoiceRouter.POST("/:invoice_id/reminders", invoiceController.SetReminder)
-
-	// Shareable link
-	invoiceRouter.GET("/:invoice_id/shareable-link", invoiceController.GetShareableLink)
+	invoiceRouter.POST("/:invoice_id/reminders", canWrite, invoiceController.SetReminder)
 
 	// Invoice duplication
-	invoiceRouter.POST("/:invoice_id/duplicate", invoiceController.Duplicate)
+	invoiceRouter.POST("/:invoice_id/duplicate", canWrite, invoiceController.Duplicate)
 
 	// Audit trails
-	invoiceRouter.GET("/audit-trails", invoiceController.GetCustomerAuditTrails)
-	invoiceRouter.GET("/:invoice_id/audit-trails", invoiceController.GetSingleInvoiceAuditTrails)
+	invoiceRouter.GET("/audit-trails", canRead, invoiceController.GetCustomerAuditTrails)
+	invoiceRouter.GET("/:invoice_id/audit-trails", canRead, invoiceController.GetSingleInvoiceAuditTrails)
 
 	return invoiceRouter
 }
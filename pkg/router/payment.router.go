@@ -0,0 +1,24 @@
+package router
+
+import (
+	"github.com/Adebayobenjamin/numerisbook/pkg/auth"
+	controller_interfaces "github.com/Adebayobenjamin/numerisbook/pkg/controllers/interfaces"
+	"github.com/Adebayobenjamin/numerisbook/pkg/middleware"
+	"github.com/gin-gonic/gin"
+)
+
+func NewPaymentRouter(paymentController controller_interfaces.PaymentController, router *gin.RouterGroup, requiresAuth gin.HandlerFunc, idempotent gin.HandlerFunc) *gin.RouterGroup {
+	canRead := middlewares.RequiresPermission(auth.PermissionInvoicesRead)
+	canConfirm := middlewares.RequiresPermission(auth.PermissionPaymentsConfirm)
+
+	// Payments are confirmed on their invoice and listed across all of the customer's invoices
+	paymentRouter := router.Group("/payments")
+	paymentRouter.Use(requiresAuth, idempotent)
+	paymentRouter.GET("", canRead, paymentController.GetCustomerPayments)
+	paymentRouter.GET("/:payment_id", canRead, paymentController.GetDetails)
+	// refunds and reversals are recorded as new entries against the payment they return
+	paymentRouter.POST("/:payment_id/refund", canConfirm, paymentController.Refund)
+	paymentRouter.POST("/:payment_id/reverse", canConfirm, paymentController.Reverse)
+
+	return paymentRouter
+}
@@ -0,0 +1,32 @@
+package router
+
+import (
+	"github.com/Adebayobenjamin/numerisbook/pkg/auth"
+	controller_interfaces "github.com/Adebayobenjamin/numerisbook/pkg/controllers/interfaces"
+	"github.com/Adebayobenjamin/numerisbook/pkg/middleware"
+	"github.com/gin-gonic/gin"
+)
+
+func NewQuoteRouter(quoteController controller_interfaces.QuoteController, router *gin.RouterGroup, requiresAuth gin.HandlerFunc, idempotent gin.HandlerFunc) *gin.RouterGroup {
+	canRead := middlewares.RequiresPermission(auth.PermissionInvoicesRead)
+	canWrite := middlewares.RequiresPermission(auth.PermissionInvoicesWrite)
+
+	quoteRouter := router.Group("/quotes")
+	quoteRouter.Use(requiresAuth, idempotent)
+	quoteRouter.POST("", canWrite, quoteController.Create)
+	quoteRouter.GET("", canRead, quoteController.GetCustomerQuotes)
+	quoteRouter.GET("/:quote_id", canRead, quoteController.GetDetails)
+	quoteRouter.POST("/:quote_id/send", canWrite, quoteController.Send)
+	// answers the client gave some other way, e.g. over the phone
+	quoteRouter.POST("/:quote_id/accept", canWrite, quoteController.Accept)
+	quoteRouter.POST("/:quote_id/decline", canWrite, quoteController.Decline)
+	quoteRouter.POST("/:quote_id/convert", canWrite, quoteController.Convert)
+
+	// Public view where the client answers the quote, the signed token is the only credential
+	publicRouter := router.Group("/public/quotes/:token")
+	publicRouter.GET("", quoteController.GetSharedQuote)
+	publicRouter.POST("/accept", quoteController.AcceptSharedQuote)
+	publicRouter.POST("/decline", quoteController.DeclineSharedQuote)
+
+	return quoteRouter
+}
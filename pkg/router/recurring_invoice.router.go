@@ -0,0 +1,28 @@
+package router
+
+import (
+	"github.com/Adebayobenjamin/numerisbook/pkg/auth"
+	controller_interfaces "github.com/Adebayobenjamin/numerisbook/pkg/controllers/interfaces"
+	"github.com/Adebayobenjamin/numerisbook/pkg/middleware"
+	"github.com/gin-gonic/gin"
+)
+
+func NewRecurringInvoiceRouter(recurringInvoiceController controller_interfaces.RecurringInvoiceController, router *gin.RouterGroup, requiresAuth gin.HandlerFunc, idempotent gin.HandlerFunc) *gin.RouterGroup {
+	recurringRouter := router.Group("/recurring-invoices")
+	recurringRouter.Use(requiresAuth, idempotent)
+
+	canRead := middlewares.RequiresPermission(auth.PermissionInvoicesRead)
+	canWrite := middlewares.RequiresPermission(auth.PermissionInvoicesWrite)
+
+	// Create and view recurring profiles
+	recurringRouter.POST("", canWrite, recurringInvoiceController.Create)
+	recurringRouter.GET("", canRead, recurringInvoiceController.GetCustomerProfiles)
+	recurringRouter.GET("/:profile_id", canRead, recurringInvoiceController.GetDetails)
+
+	// Schedule control
+	recurringRouter.POST("/:profile_id/pause", canWrite, recurringInvoiceController.Pause)
+	recurringRouter.POST("/:profile_id/resume", canWrite, recurringInvoiceController.Resume)
+	recurringRouter.POST("/:profile_id/cancel", canWrite, recurringInvoiceController.Cancel)
+
+	return recurringRouter
+}
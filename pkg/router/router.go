@@ -4,35 +4,77 @@ import (
 	"time"
 
 	controller_interfaces "github.com/Adebayobenjamin/numerisbook/pkg/controllers/interfaces"
+	"github.com/Adebayobenjamin/numerisbook/pkg/middleware"
+	services_interfaces "github.com/Adebayobenjamin/numerisbook/pkg/services/interfaces"
 	"github.com/gin-contrib/cors"
 	"github.com/gin-gonic/gin"
 )
 
 func NewApplicationRouter(
 	uploadController controller_interfaces.InvoiceController,
+	settingsController controller_interfaces.SettingsController,
+	recurringInvoiceController controller_interfaces.RecurringInvoiceController,
+	shareLinkController controller_interfaces.ShareLinkController,
+	apiKeyController controller_interfaces.APIKeyController,
+	userController controller_interfaces.UserController,
+	clientController controller_interfaces.ClientController,
+	businessProfileController controller_interfaces.BusinessProfileController,
+	bankAccountController controller_interfaces.BankAccountController,
+	catalogItemController controller_interfaces.CatalogItemController,
+	creditNoteController controller_interfaces.CreditNoteController,
+	quoteController controller_interfaces.QuoteController,
+	paymentController controller_interfaces.PaymentController,
+	clientCreditController controller_interfaces.ClientCreditController,
+	authService services_interfaces.AuthService,
+	idempotencyService services_interfaces.IdempotencyService,
 ) *gin.Engine {
 	router := gin.Default()
+	// let handlers pass the gin context to services, which read the caller from it
+	router.ContextWithFallback = true
 
-	// Configure CORS middleware
-	config := cors.DefaultConfig()
-	config.AllowOrigins = []string{"*"}
-	config.AllowMethods = []string{"GET", "POST", "PUT", "DELETE", "OPTIONS"}
-	config.AllowHeaders = []string{"Origin", "Authorization", "Content-Type", "Accept"}
-	config.AllowCredentials = true
-	config.MaxAge = 12 * time.Hour
-
-	router.Use(cors.New(config))
+	router.Use(cors.New(corsConfig()))
 	router.Use(gin.Recovery())
 
 	router.GET("/ping", PingHandler())
 	// Group routes (api/v1/uploads
 	apiRoutes := router.Group("/api/v1")
-	NewInvoiceRouter(uploadController, apiRoutes)
+	requiresAuth := middlewares.RequiresAuth(authService)
+	// retries of requests that create or change records are answered from
+	// the first response; API keys are left out so their secrets are not kept
+	idempotent := middlewares.Idempotent(idempotencyService)
+	NewInvoiceRouter(uploadController, apiRoutes, requiresAuth, idempotent)
+	NewSettingsRouter(settingsController, apiRoutes, requiresAuth)
+	NewRecurringInvoiceRouter(recurringInvoiceController, apiRoutes, requiresAuth, idempotent)
+	NewShareLinkRouter(shareLinkController, apiRoutes, requiresAuth)
+	NewAPIKeyRouter(apiKeyController, apiRoutes, requiresAuth)
+	NewUserRouter(userController, apiRoutes, requiresAuth)
+	NewClientRouter(clientController, apiRoutes, requiresAuth, idempotent)
+	NewBusinessProfileRouter(businessProfileController, apiRoutes, requiresAuth, idempotent)
+	NewBankAccountRouter(bankAccountController, apiRoutes, requiresAuth, idempotent)
+	NewCatalogItemRouter(catalogItemController, apiRoutes, requiresAuth, idempotent)
+	NewCreditNoteRouter(creditNoteController, apiRoutes, requiresAuth, idempotent)
+	NewQuoteRouter(quoteController, apiRoutes, requiresAuth, idempotent)
+	NewPaymentRouter(paymentController, apiRoutes, requiresAuth, idempotent)
+	NewClientCreditRouter(clientCreditController, apiRoutes, requiresAuth, idempotent)
 
 	return router
 
 }
 
+// corsConfig lets browser clients call the API from any origin
+func corsConfig() cors.Config {
+	config := cors.DefaultConfig()
+	config.AllowOrigins = []string{"*"}
+	config.AllowMethods = []string{"GET", "POST", "PUT", "PATCH", "DELETE", "OPTIONS"}
+	config.AllowHeaders = []string{"Origin", "Authorization", "X-API-Key", "Content-Type", "Accept", "Idempotency-Key", "If-Match"}
+	// browsers only let scripts read an invoice's ETag, to send it back in
+	// If-Match, if it is exposed
+	config.ExposeHeaders = []string{"ETag"}
+	config.AllowCredentials = true
+	config.MaxAge = 12 * time.Hour
+	return config
+}
+
 func PingHandler() gin.HandlerFunc {
 
 	return func(c *gin.Context) {
@@ -0,0 +1,46 @@
+package router
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/gin-contrib/cors"
+	"github.com/gin-gonic/gin"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestCORS(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+	engine := gin.New()
+	engine.Use(cors.New(corsConfig()))
+	engine.PATCH("/api/v1/invoices/:invoice_id", func(ctx *gin.Context) {
+		ctx.Header("ETag", `"4"`)
+		ctx.Status(http.StatusOK)
+	})
+
+	t.Run("browsers may send edits with the version they read", func(t *testing.T) {
+		req := httptest.NewRequest(http.MethodOptions, "/api/v1/invoices/5", nil)
+		req.Header.Set("Origin", "https://app.numeris.test")
+		req.Header.Set("Access-Control-Request-Method", http.MethodPatch)
+		req.Header.Set("Access-Control-Request-Headers", "If-Match, Content-Type")
+		resp := httptest.NewRecorder()
+
+		engine.ServeHTTP(resp, req)
+
+		assert.Equal(t, http.StatusNoContent, resp.Code)
+		assert.Contains(t, resp.Header().Get("Access-Control-Allow-Methods"), http.MethodPatch)
+		assert.Contains(t, resp.Header().Get("Access-Control-Allow-Headers"), "If-Match")
+	})
+
+	t.Run("browsers may read the version of a response", func(t *testing.T) {
+		req := httptest.NewRequest(http.MethodPatch, "/api/v1/invoices/5", nil)
+		req.Header.Set("Origin", "https://app.numeris.test")
+		resp := httptest.NewRecorder()
+
+		engine.ServeHTTP(resp, req)
+
+		assert.Equal(t, http.StatusOK, resp.Code)
+		assert.Contains(t, resp.Header().Get("Access-Control-Expose-Headers"), "Etag")
+	})
+}
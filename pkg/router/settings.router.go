@@ -0,0 +1,32 @@
+package router
+
+import (
+	"github.com/Adebayobenjamin/numerisbook/pkg/auth"
+	controller_interfaces "github.com/Adebayobenjamin/numerisbook/pkg/controllers/interfaces"
+	"github.com/Adebayobenjamin/numerisbook/pkg/middleware"
+	"github.com/gin-gonic/gin"
+)
+
+func NewSettingsRouter(settingsController controller_interfaces.SettingsController, router *gin.RouterGroup, requiresAuth gin.HandlerFunc) *gin.RouterGroup {
+	settingsRouter := router.Group("/settings")
+	settingsRouter.Use(requiresAuth)
+
+	canRead := middlewares.RequiresPermission(auth.PermissionSettingsRead)
+	canManage := middlewares.RequiresPermission(auth.PermissionSettingsManage)
+
+	// Invoice numbering
+	settingsRouter.GET("/invoice-numbering", canRead, settingsController.GetInvoiceNumbering)
+	settingsRouter.PUT("/invoice-numbering", canManage, settingsController.UpdateInvoiceNumbering)
+
+	// Credit note numbering
+	settingsRouter.GET("/credit-note-numbering", canRead, settingsController.GetCreditNoteNumbering)
+	settingsRouter.PUT("/credit-note-numbering", canManage, settingsController.UpdateCreditNoteNumbering)
+	settingsRouter.GET("/quote-numbering", canRead, settingsController.GetQuoteNumbering)
+	settingsRouter.PUT("/quote-numbering", canManage, settingsController.UpdateQuoteNumbering)
+
+	// Email branding
+	settingsRouter.GET("/branding", canRead, settingsController.GetBranding)
+	settingsRouter.PUT("/branding", canManage, settingsController.UpdateBranding)
+
+	return settingsRouter
+}
@@ -0,0 +1,27 @@
+package router
+
+import (
+	"github.com/Adebayobenjamin/numerisbook/pkg/auth"
+	controller_interfaces "github.com/Adebayobenjamin/numerisbook/pkg/controllers/interfaces"
+	"github.com/Adebayobenjamin/numerisbook/pkg/middleware"
+	"github.com/gin-gonic/gin"
+)
+
+func NewShareLinkRouter(shareLinkController controller_interfaces.ShareLinkController, router *gin.RouterGroup, requiresAuth gin.HandlerFunc) *gin.RouterGroup {
+	shareLinkRouter := router.Group("/invoices/:invoice_id/shareable-link")
+	shareLinkRouter.Use(requiresAuth)
+
+	canRead := middlewares.RequiresPermission(auth.PermissionInvoicesRead)
+	canWrite := middlewares.RequiresPermission(auth.PermissionInvoicesWrite)
+
+	// Manage the links an invoice is shared through
+	shareLinkRouter.GET("", canRead, shareLinkController.Get)
+	shareLinkRouter.POST("/regenerate", canWrite, shareLinkController.Regenerate)
+	shareLinkRouter.DELETE("", canWrite, shareLinkController.Revoke)
+
+	// Public read-only view, the signed token is the only credential
+	publicRouter := router.Group("/public")
+	publicRouter.GET("/invoices/:token", shareLinkController.GetSharedInvoice)
+
+	return shareLinkRouter
+}
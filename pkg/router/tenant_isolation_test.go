@@ -0,0 +1,306 @@
+package router
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/Adebayobenjamin/numerisbook/pkg/auth"
+	"github.com/Adebayobenjamin/numerisbook/pkg/common/exceptions"
+	"github.com/Adebayobenjamin/numerisbook/pkg/controllers"
+	request_dto "github.com/Adebayobenjamin/numerisbook/pkg/dtos/request"
+	response_dto "github.com/Adebayobenjamin/numerisbook/pkg/dtos/response"
+	"github.com/Adebayobenjamin/numerisbook/pkg/models"
+	services_mocks "github.com/Adebayobenjamin/numerisbook/pkg/services/mocks"
+	"github.com/Adebayobenjamin/numerisbook/pkg/tenant"
+	"github.com/gin-gonic/gin"
+	"github.com/rs/zerolog"
+	"github.com/stretchr/testify/assert"
+	"go.uber.org/mock/gomock"
+)
+
+const (
+	callerCustomerID = uint(2)
+	// foreignID belongs to another customer, so every lookup scoped to the
+	// caller misses it
+	foreignID = uint(99)
+)
+
+// bodies for the routes that bind a payload before looking the record up
+var tenantIsolationBodies = map[string]string{
+	"PUT /api/v1/invoices/:invoice_id":                   `{"issue_date":"2025-01-01T00:00:00Z","due_date":"2099-01-01T00:00:00Z","billing_currency":"USD","client_id":7,"items":[{"description":"Design","quantity":1,"unit_price":"10.00"}]}`,
+	"PATCH /api/v1/invoices/:invoice_id":                 `{"issue_date":"2025-01-01T00:00:00Z","due_date":"2099-01-01T00:00:00Z","billing_currency":"USD","client_id":7,"items":[{"description":"Design","quantity":1,"unit_price":"10.00"}]}`,
+	"POST /api/v1/invoices/:invoice_id/confirm-payment":  `{"amount":"10.00","payment_date":"2025-01-01T00:00:00Z"}`,
+	"POST /api/v1/invoices/:invoice_id/reminders":        `{"schedules":{"7_days_before_due":true}}`,
+	"PUT /api/v1/users/:user_id/role":                    `{"role":"viewer"}`,
+	"PUT /api/v1/clients/:client_id":                     `{"name":"Acme","email":"billing@acme.test","phone":"+14155550123"}`,
+	"PUT /api/v1/business-profiles/:business_profile_id": `{"name":"Numeris Studio","email":"studio@numeris.test","phone":"+14155550123"}`,
+	"PUT /api/v1/bank-accounts/:bank_account_id":         `{"bank_name":"Chase","account_number":"987654321","account_name":"Numeris Studio"}`,
+	"PUT /api/v1/catalog-items/:catalog_item_id":         `{"sku":"DSG-01","name":"Design hour","unit_price":"120.00","currency":"USD"}`,
+	"POST /api/v1/invoices/:invoice_id/credit-notes":     `{"reason":"Overcharged"}`,
+	"POST /api/v1/payments/:payment_id/refund":           `{"reason":"Returned goods"}`,
+	"POST /api/v1/payments/:payment_id/reverse":          `{"reason":"Cheque bounced"}`,
+	"POST /api/v1/clients/:client_id/credits/apply":      `{"invoice_id":7}`,
+}
+
+func scopedNotFound(t *testing.T, resource string) func(ctx context.Context, id, customerID uint) error {
+	return func(ctx context.Context, id, customerID uint) error {
+		scope, err := tenant.CustomerIDFromContext(ctx)
+		assert.NoError(t, err)
+		assert.Equal(t, callerCustomerID, scope)
+		assert.Equal(t, foreignID, id)
+		assert.Equal(t, callerCustomerID, customerID)
+		return fmt.Errorf("%s %w", resource, exceptions.ErrNotFound)
+	}
+}
+
+func TestTenantIsolation_ForeignIDsReturnNotFound(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockInvoiceService := services_mocks.NewMockInvoiceService(ctrl)
+	mockAuditService := services_mocks.NewMockAuditService(ctrl)
+	mockReminderService := services_mocks.NewMockRemiderService(ctrl)
+	mockCustomerService := services_mocks.NewMockCustomerService(ctrl)
+	mockEmailService := services_mocks.NewMockInvoiceEmailService(ctrl)
+	mockSequenceService := services_mocks.NewMockDocumentSequenceService(ctrl)
+	mockRecurringService := services_mocks.NewMockRecurringInvoiceService(ctrl)
+	mockShareLinkService := services_mocks.NewMockShareLinkService(ctrl)
+	mockAPIKeyService := services_mocks.NewMockAPIKeyService(ctrl)
+	mockUserService := services_mocks.NewMockUserService(ctrl)
+	mockClientService := services_mocks.NewMockClientService(ctrl)
+	mockBusinessProfileService := services_mocks.NewMockBusinessProfileService(ctrl)
+	mockBankAccountService := services_mocks.NewMockBankAccountService(ctrl)
+	mockCatalogItemService := services_mocks.NewMockCatalogItemService(ctrl)
+	mockCreditNoteService := services_mocks.NewMockCreditNoteService(ctrl)
+	mockQuoteService := services_mocks.NewMockQuoteService(ctrl)
+	mockPaymentService := services_mocks.NewMockPaymentService(ctrl)
+	mockClientCreditService := services_mocks.NewMockClientCreditService(ctrl)
+	mockAuthService := services_mocks.NewMockAuthService(ctrl)
+	mockIdempotencyService := services_mocks.NewMockIdempotencyService(ctrl)
+
+	logger := zerolog.New(nil)
+	engine := NewApplicationRouter(
+		controllers.NewInvoiceController(&logger, mockInvoiceService, mockAuditService, mockReminderService, mockCustomerService, mockEmailService),
+		controllers.NewSettingsController(&logger, mockSequenceService, mockCustomerService),
+		controllers.NewRecurringInvoiceController(&logger, mockRecurringService),
+		controllers.NewShareLinkController(&logger, mockShareLinkService, mockInvoiceService),
+		controllers.NewAPIKeyController(&logger, mockAPIKeyService),
+		controllers.NewUserController(&logger, mockUserService),
+		controllers.NewClientController(&logger, mockClientService),
+		controllers.NewBusinessProfileController(&logger, mockBusinessProfileService),
+		controllers.NewBankAccountController(&logger, mockBankAccountService),
+		controllers.NewCatalogItemController(&logger, mockCatalogItemService),
+		controllers.NewCreditNoteController(&logger, mockCreditNoteService, mockInvoiceService),
+		controllers.NewQuoteController(&logger, mockQuoteService),
+		controllers.NewPaymentController(&logger, mockPaymentService),
+		controllers.NewClientCreditController(&logger, mockClientCreditService),
+		mockAuthService,
+		mockIdempotencyService,
+	)
+
+	// the caller is an owner, so permissions never mask the ownership check
+	mockAuthService.EXPECT().
+		Authenticate(gomock.Any(), "token").
+		Return(&auth.Principal{CustomerID: callerCustomerID, Subject: "2", Method: auth.MethodJWT, Role: models.UserRoleOwner}, nil).
+		AnyTimes()
+	mockCustomerService.EXPECT().
+		GetCustomerByID(gomock.Any(), callerCustomerID).
+		Return(&models.Customer{ID: callerCustomerID, Name: "Caller"}, nil).
+		AnyTimes()
+
+	invoiceNotFound := scopedNotFound(t, "invoice")
+	mockInvoiceService.EXPECT().
+		GetInvoiceByIDandCustomer(gomock.Any(), gomock.Any()).
+		DoAndReturn(func(ctx context.Context, id uint) (*models.Invoice, error) {
+			return nil, invoiceNotFound(ctx, id, callerCustomerID)
+		}).
+		AnyTimes()
+	recurringProfileNotFound := scopedNotFound(t, "recurring invoice profile")
+	mockRecurringService.EXPECT().
+		GetProfileByIDAndCustomer(gomock.Any(), gomock.Any(), gomock.Any()).
+		DoAndReturn(func(ctx context.Context, id, customerID uint) (*models.RecurringInvoiceProfile, error) {
+			return nil, recurringProfileNotFound(ctx, id, customerID)
+		}).
+		AnyTimes()
+	mockAPIKeyService.EXPECT().
+		RevokeKey(gomock.Any(), gomock.Any(), gomock.Any()).
+		DoAndReturn(scopedNotFound(t, "api key")).
+		AnyTimes()
+	userNotFound := scopedNotFound(t, "user")
+	mockUserService.EXPECT().
+		ChangeRole(gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any()).
+		DoAndReturn(func(ctx context.Context, id, customerID uint, _ models.UserRole) (*models.User, error) {
+			return nil, userNotFound(ctx, id, customerID)
+		}).
+		AnyTimes()
+	mockUserService.EXPECT().
+		RemoveUser(gomock.Any(), gomock.Any(), gomock.Any()).
+		DoAndReturn(userNotFound).
+		AnyTimes()
+	clientNotFound := scopedNotFound(t, "client")
+	mockClientService.EXPECT().
+		GetClientByIDAndCustomer(gomock.Any(), gomock.Any(), gomock.Any()).
+		DoAndReturn(func(ctx context.Context, id, customerID uint) (*models.Client, error) {
+			return nil, clientNotFound(ctx, id, customerID)
+		}).
+		AnyTimes()
+	mockClientService.EXPECT().
+		UpdateClient(gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any()).
+		DoAndReturn(func(ctx context.Context, id, customerID uint, _ *request_dto.UpdateClientRequest) (*models.Client, error) {
+			return nil, clientNotFound(ctx, id, customerID)
+		}).
+		AnyTimes()
+	mockClientService.EXPECT().
+		DeleteClient(gomock.Any(), gomock.Any(), gomock.Any()).
+		DoAndReturn(clientNotFound).
+		AnyTimes()
+	mockClientService.EXPECT().
+		RestoreClient(gomock.Any(), gomock.Any(), gomock.Any()).
+		DoAndReturn(func(ctx context.Context, id, customerID uint) (*models.Client, error) {
+			return nil, clientNotFound(ctx, id, customerID)
+		}).
+		AnyTimes()
+
+	businessProfileNotFound := scopedNotFound(t, "business profile")
+	mockBusinessProfileService.EXPECT().
+		GetBusinessProfileByIDAndCustomer(gomock.Any(), gomock.Any(), gomock.Any()).
+		DoAndReturn(func(ctx context.Context, id, customerID uint) (*models.BusinessProfile, error) {
+			return nil, businessProfileNotFound(ctx, id, customerID)
+		}).
+		AnyTimes()
+	mockBusinessProfileService.EXPECT().
+		UpdateBusinessProfile(gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any()).
+		DoAndReturn(func(ctx context.Context, id, customerID uint, _ *request_dto.UpdateBusinessProfileRequest) (*models.BusinessProfile, error) {
+			return nil, businessProfileNotFound(ctx, id, customerID)
+		}).
+		AnyTimes()
+	mockBusinessProfileService.EXPECT().
+		DeleteBusinessProfile(gomock.Any(), gomock.Any(), gomock.Any()).
+		DoAndReturn(businessProfileNotFound).
+		AnyTimes()
+	mockBusinessProfileService.EXPECT().
+		SetDefaultBusinessProfile(gomock.Any(), gomock.Any(), gomock.Any()).
+		DoAndReturn(func(ctx context.Context, id, customerID uint) (*models.BusinessProfile, error) {
+			return nil, businessProfileNotFound(ctx, id, customerID)
+		}).
+		AnyTimes()
+	bankAccountNotFound := scopedNotFound(t, "bank account")
+	mockBankAccountService.EXPECT().
+		GetBankAccountByIDAndCustomer(gomock.Any(), gomock.Any(), gomock.Any()).
+		DoAndReturn(func(ctx context.Context, id, customerID uint) (*models.BankAccount, error) {
+			return nil, bankAccountNotFound(ctx, id, customerID)
+		}).
+		AnyTimes()
+	mockBankAccountService.EXPECT().
+		UpdateBankAccount(gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any()).
+		DoAndReturn(func(ctx context.Context, id, customerID uint, _ *request_dto.UpdateBankAccountRequest) (*models.BankAccount, error) {
+			return nil, bankAccountNotFound(ctx, id, customerID)
+		}).
+		AnyTimes()
+	mockBankAccountService.EXPECT().
+		DeleteBankAccount(gomock.Any(), gomock.Any(), gomock.Any()).
+		DoAndReturn(bankAccountNotFound).
+		AnyTimes()
+	mockBankAccountService.EXPECT().
+		SetDefaultBankAccount(gomock.Any(), gomock.Any(), gomock.Any()).
+		DoAndReturn(func(ctx context.Context, id, customerID uint) (*models.BankAccount, error) {
+			return nil, bankAccountNotFound(ctx, id, customerID)
+		}).
+		AnyTimes()
+	catalogItemNotFound := scopedNotFound(t, "catalog item")
+	mockCatalogItemService.EXPECT().
+		GetCatalogItemByIDAndCustomer(gomock.Any(), gomock.Any(), gomock.Any()).
+		DoAndReturn(func(ctx context.Context, id, customerID uint) (*models.CatalogItem, error) {
+			return nil, catalogItemNotFound(ctx, id, customerID)
+		}).
+		AnyTimes()
+	mockCatalogItemService.EXPECT().
+		UpdateCatalogItem(gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any()).
+		DoAndReturn(func(ctx context.Context, id, customerID uint, _ *request_dto.UpdateCatalogItemRequest) (*models.CatalogItem, error) {
+			return nil, catalogItemNotFound(ctx, id, customerID)
+		}).
+		AnyTimes()
+	mockCatalogItemService.EXPECT().
+		DeleteCatalogItem(gomock.Any(), gomock.Any(), gomock.Any()).
+		DoAndReturn(catalogItemNotFound).
+		AnyTimes()
+	creditNoteNotFound := scopedNotFound(t, "credit note")
+	mockCreditNoteService.EXPECT().
+		GetCreditNoteByIDAndCustomer(gomock.Any(), gomock.Any(), gomock.Any()).
+		DoAndReturn(func(ctx context.Context, id, customerID uint) (*models.CreditNote, error) {
+			return nil, creditNoteNotFound(ctx, id, customerID)
+		}).
+		AnyTimes()
+	quoteNotFound := scopedNotFound(t, "quote")
+	mockQuoteService.EXPECT().
+		GetQuoteByIDAndCustomer(gomock.Any(), gomock.Any(), gomock.Any()).
+		DoAndReturn(func(ctx context.Context, id, customerID uint) (*models.Quote, error) {
+			return nil, quoteNotFound(ctx, id, customerID)
+		}).
+		AnyTimes()
+	paymentNotFound := scopedNotFound(t, "payment")
+	mockPaymentService.EXPECT().
+		GetPaymentByIDAndCustomer(gomock.Any(), gomock.Any(), gomock.Any()).
+		DoAndReturn(func(ctx context.Context, id, customerID uint) (*models.Payment, error) {
+			return nil, paymentNotFound(ctx, id, customerID)
+		}).
+		AnyTimes()
+	// refunds and reversals look the payment up in the tenant scope alone
+	mockPaymentService.EXPECT().
+		RefundPayment(gomock.Any(), gomock.Any(), gomock.Any()).
+		DoAndReturn(func(ctx context.Context, id uint, _ *request_dto.RefundPaymentRequest) (*models.Payment, error) {
+			return nil, paymentNotFound(ctx, id, callerCustomerID)
+		}).
+		AnyTimes()
+	mockPaymentService.EXPECT().
+		ReversePayment(gomock.Any(), gomock.Any(), gomock.Any()).
+		DoAndReturn(func(ctx context.Context, id uint, _ *request_dto.ReversePaymentRequest) (*models.Payment, error) {
+			return nil, paymentNotFound(ctx, id, callerCustomerID)
+		}).
+		AnyTimes()
+
+	mockClientCreditService.EXPECT().
+		GetClientCredits(gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any()).
+		DoAndReturn(func(ctx context.Context, id, customerID uint, _ *request_dto.GetClientCreditsRequest) (*response_dto.GetClientCreditsResponse, error) {
+			return nil, clientNotFound(ctx, id, customerID)
+		}).
+		AnyTimes()
+	mockClientCreditService.EXPECT().
+		ApplyCredit(gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any()).
+		DoAndReturn(func(ctx context.Context, id, customerID uint, _ *request_dto.ApplyClientCreditRequest) (*models.Payment, error) {
+			return nil, clientNotFound(ctx, id, customerID)
+		}).
+		AnyTimes()
+
+	// every authenticated route addressing a record by id is exercised, so a
+	// new route cannot skip the ownership check unnoticed
+	tested := 0
+	for _, route := range engine.Routes() {
+		if !strings.Contains(route.Path, "_id") {
+			continue
+		}
+		tested++
+		key := route.Method + " " + route.Path
+
+		t.Run(key, func(t *testing.T) {
+			path := route.Path
+			for _, param := range []string{":invoice_id", ":profile_id", ":api_key_id", ":user_id", ":client_id", ":business_profile_id", ":bank_account_id", ":catalog_item_id", ":credit_note_id", ":quote_id", ":payment_id"} {
+				path = strings.ReplaceAll(path, param, fmt.Sprint(foreignID))
+			}
+
+			req := httptest.NewRequest(route.Method, path, strings.NewReader(tenantIsolationBodies[key]))
+			req.Header.Set("Authorization", "Bearer token")
+			req.Header.Set("Content-Type", "application/json")
+			w := httptest.NewRecorder()
+			engine.ServeHTTP(w, req)
+
+			assert.Equal(t, http.StatusNotFound, w.Code, w.Body.String())
+		})
+	}
+	assert.Equal(t, 51, tested)
+}
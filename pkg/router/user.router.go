@@ -0,0 +1,20 @@
+package router
+
+import (
+	"github.com/Adebayobenjamin/numerisbook/pkg/auth"
+	controller_interfaces "github.com/Adebayobenjamin/numerisbook/pkg/controllers/interfaces"
+	"github.com/Adebayobenjamin/numerisbook/pkg/middleware"
+	"github.com/gin-gonic/gin"
+)
+
+func NewUserRouter(userController controller_interfaces.UserController, router *gin.RouterGroup, requiresAuth gin.HandlerFunc) *gin.RouterGroup {
+	userRouter := router.Group("/users")
+	userRouter.Use(requiresAuth, middlewares.RequiresPermission(auth.PermissionMembersManage))
+
+	userRouter.POST("", userController.Add)
+	userRouter.GET("", userController.GetCustomerUsers)
+	userRouter.PUT("/:user_id/role", userController.ChangeRole)
+	userRouter.DELETE("/:user_id", userController.Remove)
+
+	return userRouter
+}
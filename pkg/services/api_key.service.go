@@ -0,0 +1,83 @@
+package services
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/Adebayobenjamin/numerisbook/pkg/auth"
+	request_dto "github.com/Adebayobenjamin/numerisbook/pkg/dtos/request"
+	response_dto "github.com/Adebayobenjamin/numerisbook/pkg/dtos/response"
+	"github.com/Adebayobenjamin/numerisbook/pkg/models"
+	repositories_interfaces "github.com/Adebayobenjamin/numerisbook/pkg/repositories/interfaces"
+	services_interfaces "github.com/Adebayobenjamin/numerisbook/pkg/services/interfaces"
+	"github.com/rs/zerolog"
+)
+
+type apiKeyService struct {
+	logger           *zerolog.Logger
+	apiKeyRepository repositories_interfaces.APIKeyRepository
+}
+
+// CreateKey implements services_interfaces.APIKeyService.
+func (a *apiKeyService) CreateKey(ctx context.Context, customerID uint, request *request_dto.CreateAPIKeyRequest) (*response_dto.CreateAPIKeyResponse, error) {
+	if err := auth.Authorize(ctx, auth.PermissionAPIKeysManage); err != nil {
+		return nil, err
+	}
+
+	role := request.Role
+	if role == "" {
+		role = models.UserRoleViewer
+	}
+
+	if request.ExpiresAt != nil && !request.ExpiresAt.After(time.Now()) {
+		return nil, fmt.Errorf("expiry must be in the future")
+	}
+
+	key, prefix, hash, err := auth.GenerateAPIKey()
+	if err != nil {
+		return nil, err
+	}
+
+	created, err := a.apiKeyRepository.Create(ctx, &models.APIKey{
+		CustomerID: customerID,
+		Name:       request.Name,
+		Role:       role,
+		Prefix:     prefix,
+		KeyHash:    hash,
+		ExpiresAt:  request.ExpiresAt,
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return &response_dto.CreateAPIKeyResponse{APIKey: *created, Key: key}, nil
+}
+
+// GetCustomerKeys implements services_interfaces.APIKeyService.
+func (a *apiKeyService) GetCustomerKeys(ctx context.Context, customerID uint) ([]models.APIKey, error) {
+	if err := auth.Authorize(ctx, auth.PermissionAPIKeysManage); err != nil {
+		return nil, err
+	}
+
+	return a.apiKeyRepository.GetAllCustomerKeys(ctx, customerID)
+}
+
+// RevokeKey implements services_interfaces.APIKeyService.
+func (a *apiKeyService) RevokeKey(ctx context.Context, keyID uint, customerID uint) error {
+	if err := auth.Authorize(ctx, auth.PermissionAPIKeysManage); err != nil {
+		return err
+	}
+
+	return a.apiKeyRepository.Revoke(ctx, keyID, customerID, time.Now().UTC())
+}
+
+func NewAPIKeyService(
+	logger *zerolog.Logger,
+	apiKeyRepository repositories_interfaces.APIKeyRepository,
+) services_interfaces.APIKeyService {
+	return &apiKeyService{
+		logger:           logger,
+		apiKeyRepository: apiKeyRepository,
+	}
+}
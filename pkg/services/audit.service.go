@@ -20,6 +20,12 @@ func (a *auditService) CreateAuditTrail(ctx context.Context, eventType models.Ev
 		LogEvent(ctx, eventType, logLevel, message, invoiceID, customerID)
 }
 
+// CreateQuoteAuditTrail implements services_interfaces.AuditService.
+func (a *auditService) CreateQuoteAuditTrail(ctx context.Context, eventType models.EventType, logLevel models.LogLevel, message string, invoiceID uint, quoteID uint, customerID uint) error {
+	return a.auditRepository.
+		LogQuoteEvent(ctx, eventType, logLevel, message, invoiceID, quoteID, customerID)
+}
+
 // GetAuditTrailsByInvoiceID implements services_interfaces.AuditService.
 func (a *auditService) GetAuditTrailsByInvoiceID(ctx context.Context, invoiceID uint, customerID uint, limit int, page int) ([]models.AuditTrail, error) {
 	offset := helper.GetOffset(page, limit)
@@ -0,0 +1,94 @@
+package services
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/Adebayobenjamin/numerisbook/pkg/auth"
+	"github.com/Adebayobenjamin/numerisbook/pkg/common/exceptions"
+	repositories_interfaces "github.com/Adebayobenjamin/numerisbook/pkg/repositories/interfaces"
+	services_interfaces "github.com/Adebayobenjamin/numerisbook/pkg/services/interfaces"
+	"github.com/Adebayobenjamin/numerisbook/pkg/tenant"
+	"github.com/rs/zerolog"
+)
+
+// apiKeyUsageResolution is how stale last_used_at may get before a request
+// using the key updates it, so busy keys do not write on every request
+const apiKeyUsageResolution = time.Minute
+
+type authService struct {
+	logger           *zerolog.Logger
+	verifier         *auth.TokenVerifier
+	apiKeyRepository repositories_interfaces.APIKeyRepository
+	userRepository   repositories_interfaces.UserRepository
+}
+
+// Authenticate implements services_interfaces.AuthService.
+func (a *authService) Authenticate(ctx context.Context, credential string) (*auth.Principal, error) {
+	now := time.Now().UTC()
+	if !auth.IsAPIKey(credential) {
+		return a.authenticateMember(ctx, credential, now)
+	}
+
+	key, err := a.apiKeyRepository.GetByHash(ctx, auth.HashAPIKey(credential))
+	if err != nil {
+		if errors.Is(err, exceptions.ErrNotFound) {
+			return nil, fmt.Errorf("%w: unknown api key", auth.ErrUnauthenticated)
+		}
+		return nil, err
+	}
+	if !key.IsActive(now) {
+		return nil, fmt.Errorf("%w: api key is revoked or expired", auth.ErrUnauthenticated)
+	}
+
+	if key.LastUsedAt == nil || now.Sub(*key.LastUsedAt) >= apiKeyUsageResolution {
+		if err := a.apiKeyRepository.TouchLastUsed(tenant.WithCustomerID(ctx, key.CustomerID), key.ID, now); err != nil {
+			a.logger.Warn().Err(err).Uint("api_key_id", key.ID).Msg("failed to record api key usage")
+		}
+	}
+
+	return &auth.Principal{
+		CustomerID: key.CustomerID,
+		Subject:    key.Prefix,
+		Method:     auth.MethodAPIKey,
+		Role:       key.Role,
+		APIKeyID:   &key.ID,
+	}, nil
+}
+
+// authenticateMember verifies a JWT and resolves its subject to a member of
+// the customer's organisation, whose role decides what the token may do
+func (a *authService) authenticateMember(ctx context.Context, token string, now time.Time) (*auth.Principal, error) {
+	principal, err := a.verifier.Verify(token, now)
+	if err != nil {
+		return nil, err
+	}
+
+	user, err := a.userRepository.GetBySubject(ctx, principal.CustomerID, principal.Subject)
+	if err != nil {
+		if errors.Is(err, exceptions.ErrNotFound) {
+			return nil, fmt.Errorf("%w: %s is not a member of customer %d", auth.ErrUnauthenticated, principal.Subject, principal.CustomerID)
+		}
+		return nil, err
+	}
+
+	principal.UserID = &user.ID
+	principal.Role = user.Role
+	return principal, nil
+}
+
+func NewAuthService(
+	logger *zerolog.Logger,
+	verifier *auth.TokenVerifier,
+	apiKeyRepository repositories_interfaces.APIKeyRepository,
+	userRepository repositories_interfaces.UserRepository,
+) services_interfaces.AuthService {
+	return &authService{
+		logger:           logger,
+		verifier:         verifier,
+		apiKeyRepository: apiKeyRepository,
+		userRepository:   userRepository,
+	}
+}
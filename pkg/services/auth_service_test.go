@@ -0,0 +1,192 @@
+package services
+
+import (
+	"context"
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/base64"
+	"errors"
+	"fmt"
+	"testing"
+	"time"
+
+	"github.com/Adebayobenjamin/numerisbook/pkg/auth"
+	"github.com/Adebayobenjamin/numerisbook/pkg/common/exceptions"
+	request_dto "github.com/Adebayobenjamin/numerisbook/pkg/dtos/request"
+	"github.com/Adebayobenjamin/numerisbook/pkg/models"
+	repository_mocks "github.com/Adebayobenjamin/numerisbook/pkg/repositories/mocks"
+	"github.com/Adebayobenjamin/numerisbook/pkg/tenant"
+	"github.com/rs/zerolog"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/mock/gomock"
+)
+
+func setupAuthTest(t *testing.T) (*repository_mocks.MockAPIKeyRepository, *repository_mocks.MockUserRepository, *authService) {
+	ctrl := gomock.NewController(t)
+	mockAPIKeyRepo := repository_mocks.NewMockAPIKeyRepository(ctrl)
+	mockUserRepo := repository_mocks.NewMockUserRepository(ctrl)
+	logger := zerolog.New(nil)
+	verifier := auth.NewVerifier(auth.VerifierConfig{HMACSecret: []byte("secret")})
+	service := NewAuthService(&logger, verifier, mockAPIKeyRepo, mockUserRepo).(*authService)
+	return mockAPIKeyRepo, mockUserRepo, service
+}
+
+func signTestJWT(claims string) string {
+	signed := base64.RawURLEncoding.EncodeToString([]byte(`{"alg":"HS256"}`)) + "." +
+		base64.RawURLEncoding.EncodeToString([]byte(claims))
+	mac := hmac.New(sha256.New, []byte("secret"))
+	mac.Write([]byte(signed))
+	return signed + "." + base64.RawURLEncoding.EncodeToString(mac.Sum(nil))
+}
+
+func TestAuthenticate(t *testing.T) {
+	ctx := context.Background()
+	key, prefix, hash, err := auth.GenerateAPIKey()
+	require.NoError(t, err)
+
+	expiry := time.Now().Add(time.Hour).Unix()
+
+	t.Run("jwt resolves the member and their role", func(t *testing.T) {
+		_, mockUserRepo, service := setupAuthTest(t)
+		token := signTestJWT(fmt.Sprintf(`{"customer_id":3,"sub":"user-42","exp":%d}`, expiry))
+		mockUserRepo.EXPECT().GetBySubject(ctx, uint(3), "user-42").Return(&models.User{ID: 8, CustomerID: 3, Role: models.UserRoleAccountant}, nil)
+
+		principal, err := service.Authenticate(ctx, token)
+
+		require.NoError(t, err)
+		assert.Equal(t, uint(3), principal.CustomerID)
+		assert.Equal(t, auth.MethodJWT, principal.Method)
+		assert.Equal(t, uint(8), *principal.UserID)
+		assert.Equal(t, models.UserRoleAccountant, principal.Role)
+	})
+
+	t.Run("jwt without a sub acts as the account holder", func(t *testing.T) {
+		_, mockUserRepo, service := setupAuthTest(t)
+		token := signTestJWT(fmt.Sprintf(`{"customer_id":3,"exp":%d}`, expiry))
+		mockUserRepo.EXPECT().GetBySubject(ctx, uint(3), "3").Return(&models.User{ID: 1, CustomerID: 3, Role: models.UserRoleOwner}, nil)
+
+		principal, err := service.Authenticate(ctx, token)
+
+		require.NoError(t, err)
+		assert.Equal(t, models.UserRoleOwner, principal.Role)
+	})
+
+	t.Run("jwt for someone outside the organisation", func(t *testing.T) {
+		_, mockUserRepo, service := setupAuthTest(t)
+		token := signTestJWT(fmt.Sprintf(`{"customer_id":3,"sub":"stranger","exp":%d}`, expiry))
+		mockUserRepo.EXPECT().GetBySubject(ctx, uint(3), "stranger").Return(nil, fmt.Errorf("user %w", exceptions.ErrNotFound))
+
+		principal, err := service.Authenticate(ctx, token)
+
+		assert.Nil(t, principal)
+		assert.ErrorIs(t, err, auth.ErrUnauthenticated)
+	})
+
+	t.Run("api key records its first use", func(t *testing.T) {
+		mockAPIKeyRepo, _, service := setupAuthTest(t)
+		mockAPIKeyRepo.EXPECT().GetByHash(ctx, hash).Return(&models.APIKey{ID: 5, CustomerID: 2, Prefix: prefix, Role: models.UserRoleViewer}, nil)
+		mockAPIKeyRepo.EXPECT().TouchLastUsed(tenant.WithCustomerID(ctx, 2), uint(5), gomock.Any()).Return(nil)
+
+		principal, err := service.Authenticate(ctx, key)
+
+		require.NoError(t, err)
+		assert.Equal(t, uint(2), principal.CustomerID)
+		assert.Equal(t, auth.MethodAPIKey, principal.Method)
+		assert.Equal(t, uint(5), *principal.APIKeyID)
+		assert.Equal(t, models.UserRoleViewer, principal.Role)
+	})
+
+	t.Run("recently used api key is not touched again", func(t *testing.T) {
+		mockAPIKeyRepo, _, service := setupAuthTest(t)
+		lastUsed := time.Now().UTC().Add(-10 * time.Second)
+		mockAPIKeyRepo.EXPECT().GetByHash(ctx, hash).Return(&models.APIKey{ID: 5, CustomerID: 2, LastUsedAt: &lastUsed}, nil)
+
+		_, err := service.Authenticate(ctx, key)
+
+		assert.NoError(t, err)
+	})
+
+	revokedAt := time.Now().Add(-time.Hour)
+	expiredAt := time.Now().Add(-time.Minute)
+	tests := []struct {
+		name    string
+		key     *models.APIKey
+		repoErr error
+		wantErr error
+	}{
+		{name: "unknown key", repoErr: fmt.Errorf("api key %w", exceptions.ErrNotFound), wantErr: auth.ErrUnauthenticated},
+		{name: "revoked key", key: &models.APIKey{ID: 5, RevokedAt: &revokedAt}, wantErr: auth.ErrUnauthenticated},
+		{name: "expired key", key: &models.APIKey{ID: 5, ExpiresAt: &expiredAt}, wantErr: auth.ErrUnauthenticated},
+		{name: "database failure", repoErr: errors.New("connection refused")},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			mockAPIKeyRepo, _, service := setupAuthTest(t)
+			mockAPIKeyRepo.EXPECT().GetByHash(ctx, hash).Return(tt.key, tt.repoErr)
+
+			principal, err := service.Authenticate(ctx, key)
+
+			assert.Nil(t, principal)
+			assert.Error(t, err)
+			if tt.wantErr != nil {
+				assert.ErrorIs(t, err, tt.wantErr)
+			} else {
+				assert.NotErrorIs(t, err, auth.ErrUnauthenticated)
+			}
+		})
+	}
+}
+
+func TestCreateAPIKey(t *testing.T) {
+	ctx := auth.WithPrincipal(context.Background(), &auth.Principal{CustomerID: 2, Role: models.UserRoleOwner})
+	ctrl := gomock.NewController(t)
+	mockAPIKeyRepo := repository_mocks.NewMockAPIKeyRepository(ctrl)
+	logger := zerolog.New(nil)
+	service := NewAPIKeyService(&logger, mockAPIKeyRepo)
+
+	t.Run("stores only the hash and returns the key once", func(t *testing.T) {
+		mockAPIKeyRepo.EXPECT().Create(ctx, gomock.Any()).DoAndReturn(
+			func(ctx context.Context, key *models.APIKey) (*models.APIKey, error) {
+				key.ID = 9
+				return key, nil
+			})
+
+		created, err := service.CreateKey(ctx, 2, &request_dto.CreateAPIKeyRequest{Name: "CI"})
+
+		require.NoError(t, err)
+		assert.True(t, auth.IsAPIKey(created.Key))
+		assert.Equal(t, auth.HashAPIKey(created.Key), created.KeyHash)
+		assert.Contains(t, created.Key, created.Prefix+"_")
+		assert.Equal(t, uint(2), created.CustomerID)
+	})
+
+	t.Run("new keys default to the viewer role", func(t *testing.T) {
+		mockAPIKeyRepo.EXPECT().Create(ctx, gomock.Any()).DoAndReturn(
+			func(ctx context.Context, key *models.APIKey) (*models.APIKey, error) {
+				return key, nil
+			})
+
+		created, err := service.CreateKey(ctx, 2, &request_dto.CreateAPIKeyRequest{Name: "CI"})
+
+		require.NoError(t, err)
+		assert.Equal(t, models.UserRoleViewer, created.Role)
+	})
+
+	t.Run("only owners manage keys", func(t *testing.T) {
+		accountant := auth.WithPrincipal(ctx, &auth.Principal{CustomerID: 2, Role: models.UserRoleAccountant})
+
+		_, err := service.CreateKey(accountant, 2, &request_dto.CreateAPIKeyRequest{Name: "CI", Role: models.UserRoleOwner})
+
+		assert.ErrorIs(t, err, auth.ErrForbidden)
+	})
+
+	t.Run("expiry in the past", func(t *testing.T) {
+		past := time.Now().Add(-time.Hour)
+
+		_, err := service.CreateKey(ctx, 2, &request_dto.CreateAPIKeyRequest{Name: "CI", ExpiresAt: &past})
+
+		assert.EqualError(t, err, "expiry must be in the future")
+	})
+}
@@ -0,0 +1,140 @@
+package services
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strings"
+
+	"github.com/Adebayobenjamin/numerisbook/pkg/auth"
+	"github.com/Adebayobenjamin/numerisbook/pkg/common/exceptions"
+	request_dto "github.com/Adebayobenjamin/numerisbook/pkg/dtos/request"
+	"github.com/Adebayobenjamin/numerisbook/pkg/models"
+	repositories_interfaces "github.com/Adebayobenjamin/numerisbook/pkg/repositories/interfaces"
+	services_interfaces "github.com/Adebayobenjamin/numerisbook/pkg/services/interfaces"
+	"github.com/rs/zerolog"
+)
+
+type bankAccountService struct {
+	logger                *zerolog.Logger
+	bankAccountRepository repositories_interfaces.BankAccountRepository
+}
+
+// CreateBankAccount implements services_interfaces.BankAccountService.
+func (b *bankAccountService) CreateBankAccount(ctx context.Context, customerID uint, request *request_dto.CreateBankAccountRequest) (*models.BankAccount, error) {
+	if err := auth.Authorize(ctx, auth.PermissionSettingsManage); err != nil {
+		return nil, err
+	}
+
+	account := &models.BankAccount{CustomerID: customerID}
+	if err := applyBankAccountDetails(account, request.BankName, request.AccountNumber, request.AccountName, request.AchRoutingNo, request.BankAddress); err != nil {
+		return nil, err
+	}
+
+	// the first account becomes the default, so there is always one to fall back on
+	makeDefault := request.IsDefault
+	if !makeDefault {
+		_, err := b.bankAccountRepository.GetDefault(ctx, customerID)
+		switch {
+		case errors.Is(err, exceptions.ErrNotFound):
+			makeDefault = true
+		case err != nil:
+			return nil, err
+		}
+	}
+
+	account, err := b.bankAccountRepository.Create(ctx, account)
+	if err != nil {
+		return nil, err
+	}
+	if !makeDefault {
+		return account, nil
+	}
+
+	if err := b.bankAccountRepository.SetDefault(ctx, account.ID, customerID); err != nil {
+		return nil, err
+	}
+	account.IsDefault = true
+
+	return account, nil
+}
+
+// GetBankAccounts implements services_interfaces.BankAccountService.
+func (b *bankAccountService) GetBankAccounts(ctx context.Context, customerID uint) ([]models.BankAccount, error) {
+	return b.bankAccountRepository.GetAllCustomerBankAccounts(ctx, customerID)
+}
+
+// GetBankAccountByIDAndCustomer implements services_interfaces.BankAccountService.
+func (b *bankAccountService) GetBankAccountByIDAndCustomer(ctx context.Context, accountID uint, customerID uint) (*models.BankAccount, error) {
+	return b.bankAccountRepository.GetByIDAndCustomerID(ctx, accountID, customerID)
+}
+
+// UpdateBankAccount implements services_interfaces.BankAccountService.
+func (b *bankAccountService) UpdateBankAccount(ctx context.Context, accountID uint, customerID uint, request *request_dto.UpdateBankAccountRequest) (*models.BankAccount, error) {
+	if err := auth.Authorize(ctx, auth.PermissionSettingsManage); err != nil {
+		return nil, err
+	}
+
+	account, err := b.bankAccountRepository.GetByIDAndCustomerID(ctx, accountID, customerID)
+	if err != nil {
+		return nil, err
+	}
+
+	if err := applyBankAccountDetails(account, request.BankName, request.AccountNumber, request.AccountName, request.AchRoutingNo, request.BankAddress); err != nil {
+		return nil, err
+	}
+
+	if err := b.bankAccountRepository.Update(ctx, account); err != nil {
+		return nil, err
+	}
+
+	return account, nil
+}
+
+// DeleteBankAccount implements services_interfaces.BankAccountService.
+func (b *bankAccountService) DeleteBankAccount(ctx context.Context, accountID uint, customerID uint) error {
+	if err := auth.Authorize(ctx, auth.PermissionSettingsManage); err != nil {
+		return err
+	}
+
+	return b.bankAccountRepository.Delete(ctx, accountID, customerID)
+}
+
+// SetDefaultBankAccount implements services_interfaces.BankAccountService.
+func (b *bankAccountService) SetDefaultBankAccount(ctx context.Context, accountID uint, customerID uint) (*models.BankAccount, error) {
+	if err := auth.Authorize(ctx, auth.PermissionSettingsManage); err != nil {
+		return nil, err
+	}
+
+	if err := b.bankAccountRepository.SetDefault(ctx, accountID, customerID); err != nil {
+		return nil, err
+	}
+
+	return b.bankAccountRepository.GetByIDAndCustomerID(ctx, accountID, customerID)
+}
+
+// applyBankAccountDetails trims the details as typed. Spaces are dropped from
+// the account number, which banks print in groups.
+func applyBankAccountDetails(account *models.BankAccount, bankName, accountNumber, accountName, achRoutingNo, bankAddress string) error {
+	account.BankName = strings.TrimSpace(bankName)
+	account.AccountNumber = strings.Join(strings.Fields(accountNumber), "")
+	account.AccountName = strings.TrimSpace(accountName)
+	account.AchRoutingNo = strings.TrimSpace(achRoutingNo)
+	account.BankAddress = strings.TrimSpace(bankAddress)
+
+	if account.BankName == "" || account.AccountNumber == "" || account.AccountName == "" {
+		return fmt.Errorf("bank name, account number and account name cannot be blank")
+	}
+
+	return nil
+}
+
+func NewBankAccountService(
+	logger *zerolog.Logger,
+	bankAccountRepository repositories_interfaces.BankAccountRepository,
+) services_interfaces.BankAccountService {
+	return &bankAccountService{
+		logger:                logger,
+		bankAccountRepository: bankAccountRepository,
+	}
+}
@@ -0,0 +1,67 @@
+package services
+
+import (
+	"context"
+	"fmt"
+	"testing"
+
+	"github.com/Adebayobenjamin/numerisbook/pkg/auth"
+	"github.com/Adebayobenjamin/numerisbook/pkg/common/exceptions"
+	request_dto "github.com/Adebayobenjamin/numerisbook/pkg/dtos/request"
+	"github.com/Adebayobenjamin/numerisbook/pkg/models"
+	repository_mocks "github.com/Adebayobenjamin/numerisbook/pkg/repositories/mocks"
+	"github.com/rs/zerolog"
+	"github.com/stretchr/testify/assert"
+	"go.uber.org/mock/gomock"
+)
+
+func setupBankAccountTest(t *testing.T) (*repository_mocks.MockBankAccountRepository, *bankAccountService) {
+	ctrl := gomock.NewController(t)
+	mockRepo := repository_mocks.NewMockBankAccountRepository(ctrl)
+	logger := zerolog.New(nil)
+	service := NewBankAccountService(&logger, mockRepo).(*bankAccountService)
+	return mockRepo, service
+}
+
+func TestCreateBankAccount(t *testing.T) {
+	ctx := auth.WithPrincipal(context.Background(), &auth.Principal{CustomerID: 1, Role: models.UserRoleOwner})
+
+	t.Run("details are tidied and the first account becomes the default", func(t *testing.T) {
+		mockRepo, service := setupBankAccountTest(t)
+		mockRepo.EXPECT().GetDefault(ctx, uint(1)).Return(nil, fmt.Errorf("default bank account %w", exceptions.ErrNotFound))
+		mockRepo.EXPECT().
+			Create(ctx, &models.BankAccount{CustomerID: 1, BankName: "First Bank", AccountNumber: "0123456789", AccountName: "Numeris Ltd"}).
+			Return(&models.BankAccount{ID: 3, CustomerID: 1}, nil)
+		mockRepo.EXPECT().SetDefault(ctx, uint(3), uint(1)).Return(nil)
+
+		account, err := service.CreateBankAccount(ctx, 1, &request_dto.CreateBankAccountRequest{
+			BankName:      " First Bank",
+			AccountNumber: "0123 4567 89",
+			AccountName:   "Numeris Ltd ",
+		})
+
+		assert.NoError(t, err)
+		assert.True(t, account.IsDefault)
+	})
+
+	t.Run("blank details", func(t *testing.T) {
+		_, service := setupBankAccountTest(t)
+
+		account, err := service.CreateBankAccount(ctx, 1, &request_dto.CreateBankAccountRequest{BankName: "First Bank", AccountNumber: "   ", AccountName: "Numeris Ltd"})
+
+		assert.EqualError(t, err, "bank name, account number and account name cannot be blank")
+		assert.Nil(t, account)
+	})
+}
+
+func TestSetDefaultBankAccount(t *testing.T) {
+	mockRepo, service := setupBankAccountTest(t)
+	ctx := auth.WithPrincipal(context.Background(), &auth.Principal{CustomerID: 1, Role: models.UserRoleOwner})
+
+	mockRepo.EXPECT().SetDefault(ctx, uint(9), uint(1)).Return(fmt.Errorf("bank account %w", exceptions.ErrNotFound))
+
+	account, err := service.SetDefaultBankAccount(ctx, 9, 1)
+
+	assert.ErrorIs(t, err, exceptions.ErrNotFound)
+	assert.Nil(t, account)
+}
@@ -0,0 +1,145 @@
+package services
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strings"
+
+	"github.com/Adebayobenjamin/numerisbook/pkg/auth"
+	"github.com/Adebayobenjamin/numerisbook/pkg/common/exceptions"
+	request_dto "github.com/Adebayobenjamin/numerisbook/pkg/dtos/request"
+	"github.com/Adebayobenjamin/numerisbook/pkg/helper"
+	"github.com/Adebayobenjamin/numerisbook/pkg/models"
+	repositories_interfaces "github.com/Adebayobenjamin/numerisbook/pkg/repositories/interfaces"
+	services_interfaces "github.com/Adebayobenjamin/numerisbook/pkg/services/interfaces"
+	"github.com/rs/zerolog"
+)
+
+type businessProfileService struct {
+	logger                    *zerolog.Logger
+	businessProfileRepository repositories_interfaces.BusinessProfileRepository
+}
+
+// CreateBusinessProfile implements services_interfaces.BusinessProfileService.
+func (b *businessProfileService) CreateBusinessProfile(ctx context.Context, customerID uint, request *request_dto.CreateBusinessProfileRequest) (*models.BusinessProfile, error) {
+	if err := auth.Authorize(ctx, auth.PermissionSettingsManage); err != nil {
+		return nil, err
+	}
+
+	profile := &models.BusinessProfile{CustomerID: customerID}
+	if err := applyBusinessProfileDetails(profile, request.Name, request.Email, request.Phone, request.Address); err != nil {
+		return nil, err
+	}
+
+	// the first profile becomes the default, so there is always one to fall back on
+	makeDefault := request.IsDefault
+	if !makeDefault {
+		_, err := b.businessProfileRepository.GetDefault(ctx, customerID)
+		switch {
+		case errors.Is(err, exceptions.ErrNotFound):
+			makeDefault = true
+		case err != nil:
+			return nil, err
+		}
+	}
+
+	profile, err := b.businessProfileRepository.Create(ctx, profile)
+	if err != nil {
+		return nil, err
+	}
+	if !makeDefault {
+		return profile, nil
+	}
+
+	if err := b.businessProfileRepository.SetDefault(ctx, profile.ID, customerID); err != nil {
+		return nil, err
+	}
+	profile.IsDefault = true
+
+	return profile, nil
+}
+
+// GetBusinessProfiles implements services_interfaces.BusinessProfileService.
+func (b *businessProfileService) GetBusinessProfiles(ctx context.Context, customerID uint) ([]models.BusinessProfile, error) {
+	return b.businessProfileRepository.GetAllCustomerBusinessProfiles(ctx, customerID)
+}
+
+// GetBusinessProfileByIDAndCustomer implements services_interfaces.BusinessProfileService.
+func (b *businessProfileService) GetBusinessProfileByIDAndCustomer(ctx context.Context, profileID uint, customerID uint) (*models.BusinessProfile, error) {
+	return b.businessProfileRepository.GetByIDAndCustomerID(ctx, profileID, customerID)
+}
+
+// UpdateBusinessProfile implements services_interfaces.BusinessProfileService.
+func (b *businessProfileService) UpdateBusinessProfile(ctx context.Context, profileID uint, customerID uint, request *request_dto.UpdateBusinessProfileRequest) (*models.BusinessProfile, error) {
+	if err := auth.Authorize(ctx, auth.PermissionSettingsManage); err != nil {
+		return nil, err
+	}
+
+	profile, err := b.businessProfileRepository.GetByIDAndCustomerID(ctx, profileID, customerID)
+	if err != nil {
+		return nil, err
+	}
+
+	if err := applyBusinessProfileDetails(profile, request.Name, request.Email, request.Phone, request.Address); err != nil {
+		return nil, err
+	}
+
+	if err := b.businessProfileRepository.Update(ctx, profile); err != nil {
+		return nil, err
+	}
+
+	return profile, nil
+}
+
+// DeleteBusinessProfile implements services_interfaces.BusinessProfileService.
+func (b *businessProfileService) DeleteBusinessProfile(ctx context.Context, profileID uint, customerID uint) error {
+	if err := auth.Authorize(ctx, auth.PermissionSettingsManage); err != nil {
+		return err
+	}
+
+	return b.businessProfileRepository.Delete(ctx, profileID, customerID)
+}
+
+// SetDefaultBusinessProfile implements services_interfaces.BusinessProfileService.
+func (b *businessProfileService) SetDefaultBusinessProfile(ctx context.Context, profileID uint, customerID uint) (*models.BusinessProfile, error) {
+	if err := auth.Authorize(ctx, auth.PermissionSettingsManage); err != nil {
+		return nil, err
+	}
+
+	if err := b.businessProfileRepository.SetDefault(ctx, profileID, customerID); err != nil {
+		return nil, err
+	}
+
+	return b.businessProfileRepository.GetByIDAndCustomerID(ctx, profileID, customerID)
+}
+
+// applyBusinessProfileDetails tidies up the details as typed, the same way
+// client details are, since both end up printed on invoices
+func applyBusinessProfileDetails(profile *models.BusinessProfile, name, email, phone, address string) error {
+	if strings.TrimSpace(name) == "" {
+		return fmt.Errorf("business profile name cannot be blank")
+	}
+
+	normalizedPhone, err := helper.NormalizePhone(phone)
+	if err != nil {
+		return err
+	}
+
+	profile.Name = strings.TrimSpace(name)
+	profile.Email = strings.ToLower(strings.TrimSpace(email))
+	profile.Phone = normalizedPhone
+	profile.Address = strings.TrimSpace(address)
+
+	return nil
+}
+
+func NewBusinessProfileService(
+	logger *zerolog.Logger,
+	businessProfileRepository repositories_interfaces.BusinessProfileRepository,
+) services_interfaces.BusinessProfileService {
+	return &businessProfileService{
+		logger:                    logger,
+		businessProfileRepository: businessProfileRepository,
+	}
+}
@@ -0,0 +1,91 @@
+package services
+
+import (
+	"context"
+	"fmt"
+	"testing"
+
+	"github.com/Adebayobenjamin/numerisbook/pkg/auth"
+	"github.com/Adebayobenjamin/numerisbook/pkg/common/exceptions"
+	request_dto "github.com/Adebayobenjamin/numerisbook/pkg/dtos/request"
+	"github.com/Adebayobenjamin/numerisbook/pkg/models"
+	repository_mocks "github.com/Adebayobenjamin/numerisbook/pkg/repositories/mocks"
+	"github.com/rs/zerolog"
+	"github.com/stretchr/testify/assert"
+	"go.uber.org/mock/gomock"
+)
+
+func setupBusinessProfileTest(t *testing.T) (*repository_mocks.MockBusinessProfileRepository, *businessProfileService) {
+	ctrl := gomock.NewController(t)
+	mockRepo := repository_mocks.NewMockBusinessProfileRepository(ctrl)
+	logger := zerolog.New(nil)
+	service := NewBusinessProfileService(&logger, mockRepo).(*businessProfileService)
+	return mockRepo, service
+}
+
+func TestCreateBusinessProfile(t *testing.T) {
+	ctx := auth.WithPrincipal(context.Background(), &auth.Principal{CustomerID: 1, Role: models.UserRoleOwner})
+	request := &request_dto.CreateBusinessProfileRequest{Name: " Numeris Studio ", Email: "Studio@Numeris.test", Phone: "+234 801 234 5678"}
+	tidied := &models.BusinessProfile{CustomerID: 1, Name: "Numeris Studio", Email: "studio@numeris.test", Phone: "+2348012345678"}
+
+	tests := []struct {
+		name        string
+		isDefault   bool
+		mockSetup   func(mockRepo *repository_mocks.MockBusinessProfileRepository)
+		wantDefault bool
+	}{
+		{
+			name: "the first profile becomes the default",
+			mockSetup: func(mockRepo *repository_mocks.MockBusinessProfileRepository) {
+				mockRepo.EXPECT().GetDefault(ctx, uint(1)).Return(nil, fmt.Errorf("default business profile %w", exceptions.ErrNotFound))
+				mockRepo.EXPECT().Create(ctx, tidied).Return(&models.BusinessProfile{ID: 4, CustomerID: 1}, nil)
+				mockRepo.EXPECT().SetDefault(ctx, uint(4), uint(1)).Return(nil)
+			},
+			wantDefault: true,
+		},
+		{
+			name: "later profiles leave the default alone",
+			mockSetup: func(mockRepo *repository_mocks.MockBusinessProfileRepository) {
+				mockRepo.EXPECT().GetDefault(ctx, uint(1)).Return(&models.BusinessProfile{ID: 2, IsDefault: true}, nil)
+				mockRepo.EXPECT().Create(ctx, tidied).Return(&models.BusinessProfile{ID: 4, CustomerID: 1}, nil)
+			},
+			wantDefault: false,
+		},
+		{
+			name:      "a profile can take over as the default",
+			isDefault: true,
+			mockSetup: func(mockRepo *repository_mocks.MockBusinessProfileRepository) {
+				mockRepo.EXPECT().Create(ctx, tidied).Return(&models.BusinessProfile{ID: 4, CustomerID: 1}, nil)
+				mockRepo.EXPECT().SetDefault(ctx, uint(4), uint(1)).Return(nil)
+			},
+			wantDefault: true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			mockRepo, service := setupBusinessProfileTest(t)
+			tt.mockSetup(mockRepo)
+
+			withDefault := *request
+			withDefault.IsDefault = tt.isDefault
+			profile, err := service.CreateBusinessProfile(ctx, 1, &withDefault)
+
+			assert.NoError(t, err)
+			assert.Equal(t, tt.wantDefault, profile.IsDefault)
+		})
+	}
+}
+
+func TestBusinessProfileService_AccountantsCannotChangeProfiles(t *testing.T) {
+	_, service := setupBusinessProfileTest(t)
+	ctx := auth.WithPrincipal(context.Background(), &auth.Principal{CustomerID: 1, Role: models.UserRoleAccountant})
+
+	_, err := service.CreateBusinessProfile(ctx, 1, &request_dto.CreateBusinessProfileRequest{Name: "Numeris Studio", Email: "studio@numeris.test", Phone: "+14155550123"})
+	assert.ErrorIs(t, err, auth.ErrForbidden)
+
+	_, err = service.SetDefaultBusinessProfile(ctx, 4, 1)
+	assert.ErrorIs(t, err, auth.ErrForbidden)
+
+	assert.ErrorIs(t, service.DeleteBusinessProfile(ctx, 4, 1), auth.ErrForbidden)
+}
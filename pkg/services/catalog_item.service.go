@@ -0,0 +1,166 @@
+package services
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strings"
+
+	"github.com/Adebayobenjamin/numerisbook/pkg/auth"
+	"github.com/Adebayobenjamin/numerisbook/pkg/common/exceptions"
+	"github.com/Adebayobenjamin/numerisbook/pkg/common/money"
+	request_dto "github.com/Adebayobenjamin/numerisbook/pkg/dtos/request"
+	response_dto "github.com/Adebayobenjamin/numerisbook/pkg/dtos/response"
+	"github.com/Adebayobenjamin/numerisbook/pkg/helper"
+	"github.com/Adebayobenjamin/numerisbook/pkg/models"
+	repositories_interfaces "github.com/Adebayobenjamin/numerisbook/pkg/repositories/interfaces"
+	services_interfaces "github.com/Adebayobenjamin/numerisbook/pkg/services/interfaces"
+	"github.com/rs/zerolog"
+)
+
+type catalogItemService struct {
+	logger                *zerolog.Logger
+	catalogItemRepository repositories_interfaces.CatalogItemRepository
+}
+
+// CreateCatalogItem implements services_interfaces.CatalogItemService.
+func (c *catalogItemService) CreateCatalogItem(ctx context.Context, customerID uint, request *request_dto.CreateCatalogItemRequest) (*models.CatalogItem, error) {
+	if err := auth.Authorize(ctx, auth.PermissionInvoicesWrite); err != nil {
+		return nil, err
+	}
+
+	item := &models.CatalogItem{CustomerID: customerID}
+	err := applyCatalogItemDetails(item, request.SKU, request.Name, request.Description, request.UnitPrice, request.Currency, request.Unit, request.Tax)
+	if err != nil {
+		return nil, err
+	}
+
+	if err := c.ensureSKUIsFree(ctx, item); err != nil {
+		return nil, err
+	}
+
+	return c.catalogItemRepository.Create(ctx, item)
+}
+
+// GetCatalogItems implements services_interfaces.CatalogItemService.
+func (c *catalogItemService) GetCatalogItems(ctx context.Context, customerID uint, request *request_dto.GetAllCatalogItemsRequest) ([]models.CatalogItem, error) {
+	offset := helper.GetOffset(request.Page, request.Limit)
+	return c.catalogItemRepository.GetAllCustomerCatalogItems(ctx, customerID, strings.TrimSpace(request.Search), request.Limit, offset)
+}
+
+// GetCatalogItemByIDAndCustomer implements services_interfaces.CatalogItemService.
+func (c *catalogItemService) GetCatalogItemByIDAndCustomer(ctx context.Context, itemID uint, customerID uint) (*models.CatalogItem, error) {
+	return c.catalogItemRepository.GetByIDAndCustomerID(ctx, itemID, customerID)
+}
+
+// UpdateCatalogItem implements services_interfaces.CatalogItemService.
+func (c *catalogItemService) UpdateCatalogItem(ctx context.Context, itemID uint, customerID uint, request *request_dto.UpdateCatalogItemRequest) (*models.CatalogItem, error) {
+	if err := auth.Authorize(ctx, auth.PermissionInvoicesWrite); err != nil {
+		return nil, err
+	}
+
+	item, err := c.catalogItemRepository.GetByIDAndCustomerID(ctx, itemID, customerID)
+	if err != nil {
+		return nil, err
+	}
+
+	err = applyCatalogItemDetails(item, request.SKU, request.Name, request.Description, request.UnitPrice, request.Currency, request.Unit, request.Tax)
+	if err != nil {
+		return nil, err
+	}
+
+	if err := c.ensureSKUIsFree(ctx, item); err != nil {
+		return nil, err
+	}
+
+	if err := c.catalogItemRepository.Update(ctx, item); err != nil {
+		return nil, err
+	}
+
+	return item, nil
+}
+
+// DeleteCatalogItem implements services_interfaces.CatalogItemService.
+func (c *catalogItemService) DeleteCatalogItem(ctx context.Context, itemID uint, customerID uint) error {
+	if err := auth.Authorize(ctx, auth.PermissionInvoicesWrite); err != nil {
+		return err
+	}
+
+	return c.catalogItemRepository.Delete(ctx, itemID, customerID)
+}
+
+// GetCatalogRevenue implements services_interfaces.CatalogItemService.
+func (c *catalogItemService) GetCatalogRevenue(ctx context.Context, customerID uint, request *request_dto.GetCatalogRevenueRequest) ([]response_dto.CatalogItemRevenue, error) {
+	from, to := request.From, request.To
+	if to != nil {
+		// the last day is included, so the report runs up to the next midnight
+		end := to.AddDate(0, 0, 1)
+		to = &end
+	}
+
+	if from != nil && to != nil && !to.After(*from) {
+		return nil, fmt.Errorf("the report cannot end before it starts")
+	}
+
+	return c.catalogItemRepository.GetRevenue(ctx, customerID, from, to)
+}
+
+// ensureSKUIsFree stops two active catalog items of a customer sharing a SKU
+func (c *catalogItemService) ensureSKUIsFree(ctx context.Context, item *models.CatalogItem) error {
+	existing, err := c.catalogItemRepository.GetBySKU(ctx, item.CustomerID, item.SKU)
+	switch {
+	case errors.Is(err, exceptions.ErrNotFound):
+		return nil
+	case err != nil:
+		return err
+	case existing.ID != item.ID:
+		return fmt.Errorf("sku %q is already used by another catalog item", item.SKU)
+	default:
+		return nil
+	}
+}
+
+// applyCatalogItemDetails tidies up the details as typed and tags the price
+// with the currency it is sold in
+func applyCatalogItemDetails(item *models.CatalogItem, sku, name, description string, unitPrice money.Money, currency, unit string, tax *request_dto.CatalogTax) error {
+	item.SKU = strings.TrimSpace(sku)
+	item.Name = strings.TrimSpace(name)
+	if item.SKU == "" || item.Name == "" {
+		return fmt.Errorf("catalog item sku and name cannot be blank")
+	}
+
+	item.Currency = strings.ToUpper(strings.TrimSpace(currency))
+	price, err := unitPrice.WithCurrency(item.Currency)
+	if err != nil {
+		return fmt.Errorf("invalid currency: %w", err)
+	}
+	if price.IsNegative() {
+		return fmt.Errorf("unit price cannot be negative")
+	}
+
+	item.UnitPrice = price
+	item.Description = strings.TrimSpace(description)
+	item.Unit = strings.TrimSpace(unit)
+
+	item.TaxName, item.TaxRate, item.TaxIsInclusive = "", 0, false
+	if tax != nil {
+		if tax.Rate < 0 {
+			return fmt.Errorf("tax rate cannot be negative")
+		}
+		item.TaxName = strings.TrimSpace(tax.Name)
+		item.TaxRate = tax.Rate
+		item.TaxIsInclusive = tax.IsInclusive
+	}
+
+	return nil
+}
+
+func NewCatalogItemService(
+	logger *zerolog.Logger,
+	catalogItemRepository repositories_interfaces.CatalogItemRepository,
+) services_interfaces.CatalogItemService {
+	return &catalogItemService{
+		logger:                logger,
+		catalogItemRepository: catalogItemRepository,
+	}
+}
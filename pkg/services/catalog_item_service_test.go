@@ -0,0 +1,137 @@
+package services
+
+import (
+	"context"
+	"fmt"
+	"testing"
+	"time"
+
+	"github.com/Adebayobenjamin/numerisbook/pkg/auth"
+	"github.com/Adebayobenjamin/numerisbook/pkg/common/exceptions"
+	"github.com/Adebayobenjamin/numerisbook/pkg/common/money"
+	request_dto "github.com/Adebayobenjamin/numerisbook/pkg/dtos/request"
+	"github.com/Adebayobenjamin/numerisbook/pkg/models"
+	repository_mocks "github.com/Adebayobenjamin/numerisbook/pkg/repositories/mocks"
+	"github.com/rs/zerolog"
+	"github.com/stretchr/testify/assert"
+	"go.uber.org/mock/gomock"
+)
+
+func setupCatalogItemTest(t *testing.T) (*repository_mocks.MockCatalogItemRepository, *catalogItemService) {
+	ctrl := gomock.NewController(t)
+	mockRepo := repository_mocks.NewMockCatalogItemRepository(ctrl)
+	logger := zerolog.New(nil)
+	service := NewCatalogItemService(&logger, mockRepo).(*catalogItemService)
+	return mockRepo, service
+}
+
+func TestCreateCatalogItem(t *testing.T) {
+	ctx := auth.WithPrincipal(context.Background(), &auth.Principal{CustomerID: 1, Role: models.UserRoleOwner})
+
+	t.Run("details are tidied and the price tagged with its currency", func(t *testing.T) {
+		mockRepo, service := setupCatalogItemTest(t)
+		mockRepo.EXPECT().GetBySKU(ctx, uint(1), "DSG-01").Return(nil, fmt.Errorf("catalog item %w", exceptions.ErrNotFound))
+		mockRepo.EXPECT().
+			Create(ctx, &models.CatalogItem{
+				CustomerID: 1,
+				SKU:        "DSG-01",
+				Name:       "Design hour",
+				UnitPrice:  money.New(12000, "USD"),
+				Currency:   "USD",
+				Unit:       "hour",
+				TaxName:    "VAT",
+				TaxRate:    money.MustParseRate("7.5"),
+			}).
+			Return(&models.CatalogItem{ID: 11, CustomerID: 1}, nil)
+
+		item, err := service.CreateCatalogItem(ctx, 1, &request_dto.CreateCatalogItemRequest{
+			SKU:       " DSG-01 ",
+			Name:      "Design hour",
+			UnitPrice: money.MustParse("120.00", ""),
+			Currency:  "usd",
+			Unit:      "hour",
+			Tax:       &request_dto.CatalogTax{Name: "VAT", Rate: money.MustParseRate("7.5")},
+		})
+
+		assert.NoError(t, err)
+		assert.Equal(t, uint(11), item.ID)
+	})
+
+	t.Run("sku already in use", func(t *testing.T) {
+		mockRepo, service := setupCatalogItemTest(t)
+		mockRepo.EXPECT().GetBySKU(ctx, uint(1), "DSG-01").Return(&models.CatalogItem{ID: 4, CustomerID: 1, SKU: "DSG-01"}, nil)
+
+		item, err := service.CreateCatalogItem(ctx, 1, &request_dto.CreateCatalogItemRequest{SKU: "DSG-01", Name: "Design hour", Currency: "USD"})
+
+		assert.EqualError(t, err, `sku "DSG-01" is already used by another catalog item`)
+		assert.Nil(t, item)
+	})
+
+	t.Run("negative price", func(t *testing.T) {
+		_, service := setupCatalogItemTest(t)
+
+		item, err := service.CreateCatalogItem(ctx, 1, &request_dto.CreateCatalogItemRequest{SKU: "DSG-01", Name: "Design hour", Currency: "USD", UnitPrice: money.MustParse("-1.00", "")})
+
+		assert.EqualError(t, err, "unit price cannot be negative")
+		assert.Nil(t, item)
+	})
+
+	t.Run("viewers cannot change the catalog", func(t *testing.T) {
+		_, service := setupCatalogItemTest(t)
+		viewer := auth.WithPrincipal(context.Background(), &auth.Principal{CustomerID: 1, Role: models.UserRoleViewer})
+
+		item, err := service.CreateCatalogItem(viewer, 1, &request_dto.CreateCatalogItemRequest{SKU: "DSG-01", Name: "Design hour", Currency: "USD"})
+
+		assert.ErrorIs(t, err, auth.ErrForbidden)
+		assert.Nil(t, item)
+	})
+}
+
+func TestUpdateCatalogItem(t *testing.T) {
+	mockRepo, service := setupCatalogItemTest(t)
+	ctx := auth.WithPrincipal(context.Background(), &auth.Principal{CustomerID: 1, Role: models.UserRoleOwner})
+
+	existing := &models.CatalogItem{ID: 11, CustomerID: 1, SKU: "DSG-01", Name: "Design hour", Currency: "USD", TaxName: "VAT", TaxRate: money.MustParseRate("7.5")}
+	mockRepo.EXPECT().GetByIDAndCustomerID(ctx, uint(11), uint(1)).Return(existing, nil)
+	// keeping its own sku is not a clash
+	mockRepo.EXPECT().GetBySKU(ctx, uint(1), "DSG-01").Return(existing, nil)
+	mockRepo.EXPECT().Update(ctx, existing).Return(nil)
+
+	item, err := service.UpdateCatalogItem(ctx, 11, 1, &request_dto.UpdateCatalogItemRequest{
+		SKU:       "DSG-01",
+		Name:      "Senior design hour",
+		UnitPrice: money.MustParse("150.00", ""),
+		Currency:  "USD",
+	})
+
+	assert.NoError(t, err)
+	assert.Equal(t, "Senior design hour", item.Name)
+	assert.Equal(t, money.New(15000, "USD"), item.UnitPrice)
+	// leaving the tax out removes it
+	assert.Empty(t, item.TaxName)
+	assert.Zero(t, item.TaxRate)
+}
+
+func TestGetCatalogRevenue(t *testing.T) {
+	ctx := context.Background()
+	from := time.Date(2025, 1, 1, 0, 0, 0, 0, time.UTC)
+	to := time.Date(2025, 3, 31, 0, 0, 0, 0, time.UTC)
+
+	t.Run("the last day of the period is included", func(t *testing.T) {
+		mockRepo, service := setupCatalogItemTest(t)
+		end := time.Date(2025, 4, 1, 0, 0, 0, 0, time.UTC)
+		mockRepo.EXPECT().GetRevenue(ctx, uint(1), &from, &end).Return(nil, nil)
+
+		_, err := service.GetCatalogRevenue(ctx, 1, &request_dto.GetCatalogRevenueRequest{From: &from, To: &to})
+
+		assert.NoError(t, err)
+	})
+
+	t.Run("period ending before it starts", func(t *testing.T) {
+		_, service := setupCatalogItemTest(t)
+
+		_, err := service.GetCatalogRevenue(ctx, 1, &request_dto.GetCatalogRevenueRequest{From: &to, To: &from})
+
+		assert.EqualError(t, err, "the report cannot end before it starts")
+	})
+}
@@ -0,0 +1,119 @@
+package services
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	"github.com/Adebayobenjamin/numerisbook/pkg/auth"
+	request_dto "github.com/Adebayobenjamin/numerisbook/pkg/dtos/request"
+	"github.com/Adebayobenjamin/numerisbook/pkg/helper"
+	"github.com/Adebayobenjamin/numerisbook/pkg/models"
+	repositories_interfaces "github.com/Adebayobenjamin/numerisbook/pkg/repositories/interfaces"
+	services_interfaces "github.com/Adebayobenjamin/numerisbook/pkg/services/interfaces"
+	"github.com/rs/zerolog"
+)
+
+type clientService struct {
+	logger           *zerolog.Logger
+	clientRepository repositories_interfaces.ClientRepository
+}
+
+// CreateClient implements services_interfaces.ClientService.
+func (c *clientService) CreateClient(ctx context.Context, customerID uint, request *request_dto.CreateClientRequest) (*models.Client, error) {
+	if err := auth.Authorize(ctx, auth.PermissionInvoicesWrite); err != nil {
+		return nil, err
+	}
+
+	client := &models.Client{CustomerID: customerID}
+	if err := applyClientDetails(client, request.Name, request.Email, request.Phone, request.Address); err != nil {
+		return nil, err
+	}
+
+	return c.clientRepository.Create(ctx, client)
+}
+
+// GetClients implements services_interfaces.ClientService.
+func (c *clientService) GetClients(ctx context.Context, customerID uint, request *request_dto.GetAllClientsRequest) ([]models.Client, error) {
+	offset := helper.GetOffset(request.Page, request.Limit)
+	return c.clientRepository.GetAllCustomerClients(ctx, customerID, strings.TrimSpace(request.Search), request.Deleted, request.Limit, offset)
+}
+
+// GetClientByIDAndCustomer implements services_interfaces.ClientService.
+func (c *clientService) GetClientByIDAndCustomer(ctx context.Context, clientID uint, customerID uint) (*models.Client, error) {
+	return c.clientRepository.GetByIDAndCustomerID(ctx, clientID, customerID)
+}
+
+// UpdateClient implements services_interfaces.ClientService.
+func (c *clientService) UpdateClient(ctx context.Context, clientID uint, customerID uint, request *request_dto.UpdateClientRequest) (*models.Client, error) {
+	if err := auth.Authorize(ctx, auth.PermissionInvoicesWrite); err != nil {
+		return nil, err
+	}
+
+	client, err := c.clientRepository.GetByIDAndCustomerID(ctx, clientID, customerID)
+	if err != nil {
+		return nil, err
+	}
+
+	if err := applyClientDetails(client, request.Name, request.Email, request.Phone, request.Address); err != nil {
+		return nil, err
+	}
+
+	if err := c.clientRepository.Update(ctx, client); err != nil {
+		return nil, err
+	}
+
+	return client, nil
+}
+
+// DeleteClient implements services_interfaces.ClientService.
+func (c *clientService) DeleteClient(ctx context.Context, clientID uint, customerID uint) error {
+	if err := auth.Authorize(ctx, auth.PermissionInvoicesWrite); err != nil {
+		return err
+	}
+
+	return c.clientRepository.Delete(ctx, clientID, customerID)
+}
+
+// RestoreClient implements services_interfaces.ClientService.
+func (c *clientService) RestoreClient(ctx context.Context, clientID uint, customerID uint) (*models.Client, error) {
+	if err := auth.Authorize(ctx, auth.PermissionInvoicesWrite); err != nil {
+		return nil, err
+	}
+
+	if err := c.clientRepository.Restore(ctx, clientID, customerID); err != nil {
+		return nil, err
+	}
+
+	return c.clientRepository.GetByIDAndCustomerID(ctx, clientID, customerID)
+}
+
+// applyClientDetails tidies up the details as typed before storing them, so
+// the same client is not saved under differently formatted emails or phones
+func applyClientDetails(client *models.Client, name, email, phone, address string) error {
+	if strings.TrimSpace(name) == "" {
+		return fmt.Errorf("client name cannot be blank")
+	}
+
+	normalizedPhone, err := helper.NormalizePhone(phone)
+	if err != nil {
+		return err
+	}
+
+	client.Name = strings.TrimSpace(name)
+	client.Email = strings.ToLower(strings.TrimSpace(email))
+	client.Phone = normalizedPhone
+	client.Address = strings.TrimSpace(address)
+
+	return nil
+}
+
+func NewClientService(
+	logger *zerolog.Logger,
+	clientRepository repositories_interfaces.ClientRepository,
+) services_interfaces.ClientService {
+	return &clientService{
+		logger:           logger,
+		clientRepository: clientRepository,
+	}
+}
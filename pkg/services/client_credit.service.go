@@ -0,0 +1,177 @@
+package services
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/Adebayobenjamin/numerisbook/pkg/auth"
+	"github.com/Adebayobenjamin/numerisbook/pkg/common/money"
+	request_dto "github.com/Adebayobenjamin/numerisbook/pkg/dtos/request"
+	response_dto "github.com/Adebayobenjamin/numerisbook/pkg/dtos/response"
+	"github.com/Adebayobenjamin/numerisbook/pkg/helper"
+	"github.com/Adebayobenjamin/numerisbook/pkg/models"
+	repositories_interfaces "github.com/Adebayobenjamin/numerisbook/pkg/repositories/interfaces"
+	services_interfaces "github.com/Adebayobenjamin/numerisbook/pkg/services/interfaces"
+	"github.com/rs/zerolog"
+)
+
+type clientCreditService struct {
+	logger                 *zerolog.Logger
+	clientRepository       repositories_interfaces.ClientRepository
+	clientCreditRepository repositories_interfaces.ClientCreditRepository
+	paymentRepository      repositories_interfaces.PaymentRepository
+}
+
+// GetClientCredits implements services_interfaces.ClientCreditService.
+func (c *clientCreditService) GetClientCredits(ctx context.Context, clientID uint, customerID uint, request *request_dto.GetClientCreditsRequest) (*response_dto.GetClientCreditsResponse, error) {
+	if _, err := c.clientRepository.GetByIDAndCustomerID(ctx, clientID, customerID); err != nil {
+		return nil, err
+	}
+
+	balances, err := c.clientCreditRepository.GetClientBalances(ctx, clientID, customerID)
+	if err != nil {
+		return nil, err
+	}
+
+	offset := helper.GetOffset(request.Page, request.Limit)
+	entries, err := c.clientCreditRepository.GetClientCredits(ctx, clientID, customerID, request.Limit, offset)
+	if err != nil {
+		return nil, err
+	}
+
+	return &response_dto.GetClientCreditsResponse{Balances: balances, Entries: entries}, nil
+}
+
+// ApplyCredit implements services_interfaces.ClientCreditService.
+func (c *clientCreditService) ApplyCredit(ctx context.Context, clientID uint, customerID uint, request *request_dto.ApplyClientCreditRequest) (*models.Payment, error) {
+	if err := auth.Authorize(ctx, auth.PermissionPaymentsConfirm); err != nil {
+		return nil, err
+	}
+
+	client, err := c.clientRepository.GetByIDAndCustomerID(ctx, clientID, customerID)
+	if err != nil {
+		return nil, err
+	}
+
+	payment := &models.Payment{
+		InvoiceID: request.InvoiceID,
+		Kind:      models.PaymentKindCredit,
+		Date:      time.Now().UTC(),
+		Method:    models.PaymentMethodOther,
+	}
+
+	// the credit is checked and applied with the invoice and the client's
+	// credit locked, so the same credit cannot pay two invoices
+	_, err = c.paymentRepository.ApplyCredit(ctx, client.ID, payment, func(invoice *models.Invoice, paid money.Money, available money.Money) ([]models.AuditTrail, error) {
+		amount, err := creditToApply(invoice, paid, available, request.Amount)
+		if err != nil {
+			return nil, err
+		}
+
+		status, err := settlePayment(invoice, paid, amount, true)
+		if err != nil {
+			return nil, err
+		}
+
+		payment.Amount = amount
+		payment.IsPartial = status != models.InvoiceStatusPaid
+
+		auditTrails := []models.AuditTrail{{
+			EventType: models.EventTypeClientCreditApplied,
+			LogLevel:  models.LogLevelInfo,
+			Message:   fmt.Sprintf("Applied %s of Client %s's credit to Invoice %s", amount, client.Name, invoice.InvoiceNumber),
+		}}
+		if status != invoice.Status {
+			auditTrails = append(auditTrails, models.AuditTrail{
+				EventType: models.EventTypeInvoiceStatusChanged,
+				LogLevel:  models.LogLevelInfo,
+				Message:   fmt.Sprintf("Invoice %s moved from %s to %s", invoice.InvoiceNumber, invoice.Status, status),
+			})
+		}
+
+		invoice.Status = status
+		invoice.IsFullyPaid = status == models.InvoiceStatusPaid
+		return auditTrails, nil
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return payment, nil
+}
+
+// creditToApply works out how much of the client's available credit goes to
+// the invoice. A nil requested amount applies as much as the invoice can take.
+func creditToApply(invoice *models.Invoice, paid money.Money, available money.Money, requested *money.Money) (money.Money, error) {
+	// credit can only be applied to invoices that could still move to paid
+	if err := validateInvoiceTransition(invoice.Status, models.InvoiceStatusPaid); err != nil {
+		return money.Money{}, err
+	}
+
+	if !available.IsPositive() {
+		return money.Money{}, fmt.Errorf("client has no credit in %s", invoice.BillingCurrency)
+	}
+
+	payable, err := invoice.AmountPayable()
+	if err != nil {
+		return money.Money{}, fmt.Errorf("failed to work out the amount payable: %w", err)
+	}
+
+	left, err := payable.Sub(paid)
+	if err != nil {
+		return money.Money{}, fmt.Errorf("payment currency does not match invoice: %w", err)
+	}
+
+	if requested == nil {
+		comparison, err := available.Cmp(left)
+		if err != nil {
+			return money.Money{}, fmt.Errorf("credit currency does not match invoice: %w", err)
+		}
+		if comparison < 0 {
+			return available, nil
+		}
+		return left, nil
+	}
+
+	// the request only carries a number, the currency is always the invoice's
+	amount, err := requested.WithCurrency(invoice.BillingCurrency)
+	if err != nil {
+		return money.Money{}, err
+	}
+	if !amount.IsPositive() {
+		return money.Money{}, fmt.Errorf("credit amount must be greater than zero")
+	}
+
+	comparison, err := amount.Cmp(available)
+	if err != nil {
+		return money.Money{}, fmt.Errorf("credit currency does not match invoice: %w", err)
+	}
+	if comparison > 0 {
+		return money.Money{}, fmt.Errorf("credit amount exceeds the %s %s of credit the client has", available, invoice.BillingCurrency)
+	}
+
+	comparison, err = amount.Cmp(left)
+	if err != nil {
+		return money.Money{}, fmt.Errorf("credit currency does not match invoice: %w", err)
+	}
+	if comparison > 0 {
+		return money.Money{}, fmt.Errorf("credit amount exceeds the %s left to pay on Invoice %s", left, invoice.InvoiceNumber)
+	}
+
+	return amount, nil
+}
+
+func NewClientCreditService(
+	logger *zerolog.Logger,
+	clientRepository repositories_interfaces.ClientRepository,
+	clientCreditRepository repositories_interfaces.ClientCreditRepository,
+	paymentRepository repositories_interfaces.PaymentRepository,
+) services_interfaces.ClientCreditService {
+	return &clientCreditService{
+		logger:                 logger,
+		clientRepository:       clientRepository,
+		clientCreditRepository: clientCreditRepository,
+		paymentRepository:      paymentRepository,
+	}
+}
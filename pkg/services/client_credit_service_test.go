@@ -0,0 +1,116 @@
+package services
+
+import (
+	"context"
+	"fmt"
+	"testing"
+
+	"github.com/Adebayobenjamin/numerisbook/pkg/auth"
+	"github.com/Adebayobenjamin/numerisbook/pkg/common/exceptions"
+	"github.com/Adebayobenjamin/numerisbook/pkg/common/money"
+	request_dto "github.com/Adebayobenjamin/numerisbook/pkg/dtos/request"
+	"github.com/Adebayobenjamin/numerisbook/pkg/models"
+	repositories_interfaces "github.com/Adebayobenjamin/numerisbook/pkg/repositories/interfaces"
+	repository_mocks "github.com/Adebayobenjamin/numerisbook/pkg/repositories/mocks"
+	"github.com/rs/zerolog"
+	"github.com/stretchr/testify/assert"
+	"go.uber.org/mock/gomock"
+)
+
+func setupClientCreditTest(t *testing.T) (*repository_mocks.MockClientRepository, *repository_mocks.MockClientCreditRepository, *repository_mocks.MockPaymentRepository, *clientCreditService) {
+	ctrl := gomock.NewController(t)
+	logger := zerolog.Nop()
+	mockClientRepo := repository_mocks.NewMockClientRepository(ctrl)
+	mockClientCreditRepo := repository_mocks.NewMockClientCreditRepository(ctrl)
+	mockPaymentRepo := repository_mocks.NewMockPaymentRepository(ctrl)
+	service := NewClientCreditService(&logger, mockClientRepo, mockClientCreditRepo, mockPaymentRepo).(*clientCreditService)
+	return mockClientRepo, mockClientCreditRepo, mockPaymentRepo, service
+}
+
+func TestApplyCredit(t *testing.T) {
+	ctx := auth.WithPrincipal(context.Background(), &auth.Principal{CustomerID: 1, Role: models.UserRoleAccountant})
+	client := &models.Client{ID: 9, CustomerID: 1, Name: "Acme Ltd"}
+	openInvoice := func() *models.Invoice {
+		return &models.Invoice{ID: 2, InvoiceNumber: "INV-002", BillingCurrency: "USD", TotalAmountDue: money.New(10000, "USD"), Status: models.InvoiceStatusSent}
+	}
+
+	t.Run("applies as much credit as the invoice takes", func(t *testing.T) {
+		mockClientRepo, _, mockPaymentRepo, service := setupClientCreditTest(t)
+		mockClientRepo.EXPECT().GetByIDAndCustomerID(ctx, uint(9), uint(1)).Return(client, nil)
+		mockPaymentRepo.EXPECT().
+			ApplyCredit(ctx, uint(9), gomock.Any(), gomock.Any()).
+			DoAndReturn(func(_ context.Context, _ uint, payment *models.Payment, settle repositories_interfaces.SettleCredit) (*models.Invoice, error) {
+				assert.Equal(t, models.PaymentKindCredit, payment.Kind)
+				assert.Equal(t, uint(2), payment.InvoiceID)
+
+				invoice := openInvoice()
+				auditTrails, err := settle(invoice, money.New(7000, "USD"), money.New(5000, "USD"))
+
+				assert.NoError(t, err)
+				assert.Equal(t, models.InvoiceStatusPaid, invoice.Status)
+				assert.True(t, invoice.IsFullyPaid)
+				assert.Len(t, auditTrails, 2)
+				assert.Equal(t, models.EventTypeClientCreditApplied, auditTrails[0].EventType)
+				assert.Equal(t, "Applied 30.00 of Client Acme Ltd's credit to Invoice INV-002", auditTrails[0].Message)
+				return invoice, nil
+			})
+
+		payment, err := service.ApplyCredit(ctx, 9, 1, &request_dto.ApplyClientCreditRequest{InvoiceID: 2})
+
+		assert.NoError(t, err)
+		assert.Equal(t, money.New(3000, "USD"), payment.Amount)
+		assert.False(t, payment.IsPartial)
+	})
+
+	t.Run("cannot apply more credit than the client has", func(t *testing.T) {
+		mockClientRepo, _, mockPaymentRepo, service := setupClientCreditTest(t)
+		amount := money.New(6000, "")
+		mockClientRepo.EXPECT().GetByIDAndCustomerID(ctx, uint(9), uint(1)).Return(client, nil)
+		mockPaymentRepo.EXPECT().
+			ApplyCredit(ctx, uint(9), gomock.Any(), gomock.Any()).
+			DoAndReturn(func(_ context.Context, _ uint, _ *models.Payment, settle repositories_interfaces.SettleCredit) (*models.Invoice, error) {
+				_, err := settle(openInvoice(), money.Zero("USD"), money.New(5000, "USD"))
+				return nil, err
+			})
+
+		_, err := service.ApplyCredit(ctx, 9, 1, &request_dto.ApplyClientCreditRequest{InvoiceID: 2, Amount: &amount})
+
+		assert.EqualError(t, err, "credit amount exceeds the 50.00 USD of credit the client has")
+	})
+
+	t.Run("a paid invoice cannot take credit", func(t *testing.T) {
+		mockClientRepo, _, mockPaymentRepo, service := setupClientCreditTest(t)
+		mockClientRepo.EXPECT().GetByIDAndCustomerID(ctx, uint(9), uint(1)).Return(client, nil)
+		mockPaymentRepo.EXPECT().
+			ApplyCredit(ctx, uint(9), gomock.Any(), gomock.Any()).
+			DoAndReturn(func(_ context.Context, _ uint, _ *models.Payment, settle repositories_interfaces.SettleCredit) (*models.Invoice, error) {
+				invoice := openInvoice()
+				invoice.Status = models.InvoiceStatusPaid
+				_, err := settle(invoice, money.New(10000, "USD"), money.New(5000, "USD"))
+				return nil, err
+			})
+
+		_, err := service.ApplyCredit(ctx, 9, 1, &request_dto.ApplyClientCreditRequest{InvoiceID: 2})
+
+		var transitionErr *exceptions.InvalidStatusTransitionError
+		assert.ErrorAs(t, err, &transitionErr)
+	})
+
+	t.Run("another customer's client is not found", func(t *testing.T) {
+		mockClientRepo, _, _, service := setupClientCreditTest(t)
+		mockClientRepo.EXPECT().GetByIDAndCustomerID(ctx, uint(9), uint(1)).Return(nil, fmt.Errorf("client %w", exceptions.ErrNotFound))
+
+		_, err := service.ApplyCredit(ctx, 9, 1, &request_dto.ApplyClientCreditRequest{InvoiceID: 2})
+
+		assert.ErrorIs(t, err, exceptions.ErrNotFound)
+	})
+
+	t.Run("viewers cannot apply credit", func(t *testing.T) {
+		_, _, _, service := setupClientCreditTest(t)
+		viewer := auth.WithPrincipal(context.Background(), &auth.Principal{CustomerID: 1, Role: models.UserRoleViewer})
+
+		_, err := service.ApplyCredit(viewer, 9, 1, &request_dto.ApplyClientCreditRequest{InvoiceID: 2})
+
+		assert.ErrorIs(t, err, auth.ErrForbidden)
+	})
+}
@@ -0,0 +1,117 @@
+package services
+
+import (
+	"context"
+	"testing"
+
+	"github.com/Adebayobenjamin/numerisbook/pkg/auth"
+	request_dto "github.com/Adebayobenjamin/numerisbook/pkg/dtos/request"
+	"github.com/Adebayobenjamin/numerisbook/pkg/models"
+	repository_mocks "github.com/Adebayobenjamin/numerisbook/pkg/repositories/mocks"
+	"github.com/rs/zerolog"
+	"github.com/stretchr/testify/assert"
+	"go.uber.org/mock/gomock"
+)
+
+func setupClientTest(t *testing.T) (*repository_mocks.MockClientRepository, *clientService) {
+	ctrl := gomock.NewController(t)
+	mockRepo := repository_mocks.NewMockClientRepository(ctrl)
+	logger := zerolog.New(nil)
+	service := NewClientService(&logger, mockRepo).(*clientService)
+	return mockRepo, service
+}
+
+func TestCreateClient(t *testing.T) {
+	ctx := auth.WithPrincipal(context.Background(), &auth.Principal{CustomerID: 1, Role: models.UserRoleAccountant})
+
+	tests := []struct {
+		name       string
+		request    *request_dto.CreateClientRequest
+		wantClient *models.Client
+		wantErr    string
+	}{
+		{
+			name:    "details are tidied before saving",
+			request: &request_dto.CreateClientRequest{Name: " Acme Ltd ", Email: " Billing@Acme.TEST", Phone: "+1 (415) 555-0123", Address: "1 Main St "},
+			wantClient: &models.Client{
+				CustomerID: 1,
+				Name:       "Acme Ltd",
+				Email:      "billing@acme.test",
+				Phone:      "+14155550123",
+				Address:    "1 Main St",
+			},
+		},
+		{
+			name:    "phone without a country code",
+			request: &request_dto.CreateClientRequest{Name: "Acme Ltd", Email: "billing@acme.test", Phone: "415 555 0123"},
+			wantErr: `phone "415 555 0123" must be an international number starting with a country code, e.g. +14155550123`,
+		},
+		{
+			name:    "blank name",
+			request: &request_dto.CreateClientRequest{Name: "   ", Email: "billing@acme.test", Phone: "+14155550123"},
+			wantErr: "client name cannot be blank",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			mockRepo, service := setupClientTest(t)
+			if tt.wantClient != nil {
+				mockRepo.EXPECT().Create(ctx, tt.wantClient).Return(tt.wantClient, nil)
+			}
+
+			client, err := service.CreateClient(ctx, 1, tt.request)
+
+			if tt.wantErr != "" {
+				assert.EqualError(t, err, tt.wantErr)
+				assert.Nil(t, client)
+				return
+			}
+			assert.NoError(t, err)
+			assert.Equal(t, tt.wantClient, client)
+		})
+	}
+}
+
+func TestClientService_ViewersCannotChangeClients(t *testing.T) {
+	mockRepo, service := setupClientTest(t)
+	ctx := auth.WithPrincipal(context.Background(), &auth.Principal{CustomerID: 1, Role: models.UserRoleViewer})
+	request := &request_dto.CreateClientRequest{Name: "Acme Ltd", Email: "billing@acme.test", Phone: "+14155550123"}
+
+	// viewers can still list clients
+	mockRepo.EXPECT().GetAllCustomerClients(ctx, uint(1), "acme", false, 10, 0).Return([]models.Client{}, nil)
+	_, err := service.GetClients(ctx, 1, &request_dto.GetAllClientsRequest{Limit: 10, Page: 1, Search: " acme "})
+	assert.NoError(t, err)
+
+	_, err = service.CreateClient(ctx, 1, request)
+	assert.ErrorIs(t, err, auth.ErrForbidden)
+
+	_, err = service.UpdateClient(ctx, 2, 1, &request_dto.UpdateClientRequest{Name: "Acme Ltd", Email: "billing@acme.test", Phone: "+14155550123"})
+	assert.ErrorIs(t, err, auth.ErrForbidden)
+
+	assert.ErrorIs(t, service.DeleteClient(ctx, 2, 1), auth.ErrForbidden)
+
+	_, err = service.RestoreClient(ctx, 2, 1)
+	assert.ErrorIs(t, err, auth.ErrForbidden)
+}
+
+func TestUpdateClient(t *testing.T) {
+	mockRepo, service := setupClientTest(t)
+	ctx := auth.WithPrincipal(context.Background(), &auth.Principal{CustomerID: 1, Role: models.UserRoleOwner})
+
+	existing := &models.Client{ID: 2, CustomerID: 1, Name: "Acme", Email: "old@acme.test", Phone: "+14155550123"}
+	mockRepo.EXPECT().GetByIDAndCustomerID(ctx, uint(2), uint(1)).Return(existing, nil)
+	mockRepo.EXPECT().
+		Update(ctx, &models.Client{ID: 2, CustomerID: 1, Name: "Acme Ltd", Email: "new@acme.test", Phone: "+442079460958", Address: "2 High St"}).
+		Return(nil)
+
+	client, err := service.UpdateClient(ctx, 2, 1, &request_dto.UpdateClientRequest{
+		Name:    "Acme Ltd",
+		Email:   "new@acme.test",
+		Phone:   "+44 20 7946 0958",
+		Address: "2 High St",
+	})
+
+	assert.NoError(t, err)
+	assert.Equal(t, "new@acme.test", client.Email)
+}
@@ -0,0 +1,263 @@
+package services
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/Adebayobenjamin/numerisbook/pkg/auth"
+	"github.com/Adebayobenjamin/numerisbook/pkg/common/money"
+	request_dto "github.com/Adebayobenjamin/numerisbook/pkg/dtos/request"
+	"github.com/Adebayobenjamin/numerisbook/pkg/helper"
+	"github.com/Adebayobenjamin/numerisbook/pkg/models"
+	repositories_interfaces "github.com/Adebayobenjamin/numerisbook/pkg/repositories/interfaces"
+	services_interfaces "github.com/Adebayobenjamin/numerisbook/pkg/services/interfaces"
+	"github.com/rs/zerolog"
+)
+
+// creditableInvoiceStatuses are the statuses of invoices that were issued and
+// still stand; drafts can simply be edited and void or written off invoices
+// have nothing left to take back
+var creditableInvoiceStatuses = map[models.InvoiceStatus]bool{
+	models.InvoiceStatusSent:          true,
+	models.InvoiceStatusPartiallyPaid: true,
+	models.InvoiceStatusPaid:          true,
+	models.InvoiceStatusOverdue:       true,
+}
+
+type creditNoteService struct {
+	logger               *zerolog.Logger
+	creditNoteRepository repositories_interfaces.CreditNoteRepository
+}
+
+// CreateCreditNote implements services_interfaces.CreditNoteService.
+func (c *creditNoteService) CreateCreditNote(ctx context.Context, invoice *models.Invoice, request *request_dto.CreateCreditNoteRequest) (*models.CreditNote, error) {
+	if err := auth.Authorize(ctx, auth.PermissionInvoicesWrite); err != nil {
+		return nil, err
+	}
+
+	if !creditableInvoiceStatuses[invoice.Status] {
+		return nil, fmt.Errorf("invoice %s is %s and cannot be credited", invoice.InvoiceNumber, invoice.Status)
+	}
+
+	credited, err := c.creditNoteRepository.GetCreditedQuantities(ctx, invoice.ID)
+	if err != nil {
+		return nil, err
+	}
+
+	requested := request.Items
+	if len(requested) == 0 {
+		// crediting the whole invoice takes back whatever is left of every line
+		for _, item := range invoice.Items {
+			if remaining := item.Quantity - credited[item.ID]; remaining > 0 {
+				requested = append(requested, request_dto.CreditNoteItem{InvoiceItemID: item.ID, Quantity: remaining})
+			}
+		}
+		if len(requested) == 0 {
+			return nil, fmt.Errorf("invoice %s has already been fully credited", invoice.InvoiceNumber)
+		}
+	}
+
+	note := &models.CreditNote{
+		CustomerID:      invoice.CustomerID,
+		InvoiceID:       invoice.ID,
+		Reason:          request.Reason,
+		IssueDate:       time.Now(),
+		BillingCurrency: invoice.BillingCurrency,
+		NetTotal:        money.Zero(invoice.BillingCurrency),
+		TaxTotal:        money.Zero(invoice.BillingCurrency),
+		Total:           money.Zero(invoice.BillingCurrency),
+	}
+	if request.IssueDate != nil {
+		note.IssueDate = *request.IssueDate
+	}
+
+	for idx, line := range requested {
+		item := findInvoiceItem(invoice.Items, line.InvoiceItemID)
+		if item == nil {
+			return nil, fmt.Errorf("item %d: invoice item %d is not on invoice %s", idx+1, line.InvoiceItemID, invoice.InvoiceNumber)
+		}
+
+		// lines of the same invoice item add up, so the quantity check
+		// covers all of them
+		already := credited[item.ID]
+		if line.Quantity > item.Quantity-already {
+			return nil, fmt.Errorf("item %d: only %d of %d can still be credited", idx+1, item.Quantity-already, item.Quantity)
+		}
+		credited[item.ID] = already + line.Quantity
+
+		creditItem := creditInvoiceItem(item, already, line.Quantity)
+		note.Items = append(note.Items, creditItem)
+
+		if note.NetTotal, err = note.NetTotal.Add(creditItem.NetAmount); err != nil {
+			return nil, fmt.Errorf("failed to add up credit note: %w", err)
+		}
+		if note.TaxTotal, err = note.TaxTotal.Add(creditItem.TaxTotal); err != nil {
+			return nil, fmt.Errorf("failed to add up credit note: %w", err)
+		}
+		if note.Total, err = note.Total.Add(creditItem.Total); err != nil {
+			return nil, fmt.Errorf("failed to add up credit note: %w", err)
+		}
+	}
+
+	// the invoice's status is worked out again with the invoice locked, and
+	// changes along with the note or not at all
+	return c.creditNoteRepository.CreateCreditNote(ctx, note, invoice.Version, func(invoice *models.Invoice, paid money.Money, note *models.CreditNote) ([]models.AuditTrail, error) {
+		status, err := statusAfterCredit(invoice, paid)
+		if err != nil {
+			return nil, err
+		}
+
+		auditTrails := []models.AuditTrail{{
+			EventType: models.EventTypeCreditNoteIssued,
+			LogLevel:  models.LogLevelInfo,
+			Message:   fmt.Sprintf("Issued Credit Note %s of %s for Invoice %s", note.CreditNoteNumber, note.Total, invoice.InvoiceNumber),
+		}}
+
+		// what the note takes off an invoice that was already paid goes back
+		// to the client as credit; without a client it is left to be refunded
+		note.ClientCredit = nil
+		if invoice.ClientID != nil {
+			credit, err := paidAndCredited(invoice, paid, note)
+			if err != nil {
+				return nil, err
+			}
+			if credit.IsPositive() {
+				note.ClientCredit = &credit
+				auditTrails = append(auditTrails, models.AuditTrail{
+					EventType: models.EventTypeClientCredited,
+					LogLevel:  models.LogLevelInfo,
+					Message:   fmt.Sprintf("Credited %s paid on Invoice %s to Client %s by Credit Note %s", credit, invoice.InvoiceNumber, invoice.InvoiceClient.Name, note.CreditNoteNumber),
+				})
+			}
+		}
+
+		if status != invoice.Status {
+			auditTrails = append(auditTrails, models.AuditTrail{
+				EventType: models.EventTypeInvoiceStatusChanged,
+				LogLevel:  models.LogLevelInfo,
+				Message:   fmt.Sprintf("Invoice %s moved from %s to %s", invoice.InvoiceNumber, invoice.Status, status),
+			})
+		}
+
+		invoice.Status = status
+		invoice.IsFullyPaid = status == models.InvoiceStatusPaid
+		return auditTrails, nil
+	})
+}
+
+// statusAfterCredit is the invoice's status once a credit note has lowered
+// what it asks for: crediting what was left unpaid settles it, and what was
+// paid of a smaller amount payable may make it partially paid. A paid invoice
+// stays paid.
+func statusAfterCredit(invoice *models.Invoice, paid money.Money) (models.InvoiceStatus, error) {
+	payable, err := invoice.AmountPayable()
+	if err != nil {
+		return "", fmt.Errorf("failed to work out the amount payable: %w", err)
+	}
+
+	comparison, err := paid.Cmp(payable)
+	if err != nil {
+		return "", fmt.Errorf("payment currency does not match invoice: %w", err)
+	}
+
+	switch {
+	case comparison >= 0:
+		return models.InvoiceStatusPaid, nil
+	case paid.IsPositive():
+		return models.InvoiceStatusPartiallyPaid, nil
+	}
+
+	return invoice.Status, nil
+}
+
+// GetCreditNoteByIDAndCustomer implements services_interfaces.CreditNoteService.
+func (c *creditNoteService) GetCreditNoteByIDAndCustomer(ctx context.Context, creditNoteID uint, customerID uint) (*models.CreditNote, error) {
+	return c.creditNoteRepository.GetByIDAndCustomerID(ctx, creditNoteID, customerID)
+}
+
+// GetInvoiceCreditNotes implements services_interfaces.CreditNoteService.
+func (c *creditNoteService) GetInvoiceCreditNotes(ctx context.Context, invoiceID uint) ([]models.CreditNote, error) {
+	return c.creditNoteRepository.GetInvoiceCreditNotes(ctx, invoiceID)
+}
+
+// GetCustomerCreditNotes implements services_interfaces.CreditNoteService.
+func (c *creditNoteService) GetCustomerCreditNotes(ctx context.Context, customerID uint, request *request_dto.GetAllRequest) ([]models.CreditNote, error) {
+	offset := helper.GetOffset(request.Page, request.Limit)
+	return c.creditNoteRepository.GetAllCustomerCreditNotes(ctx, customerID, request.Limit, offset)
+}
+
+// paidAndCredited is how much of what note credits had already been paid on
+// the invoice, whose credited total includes the note. Only what was paid
+// over what the invoice asks for once the note is taken off counts, less
+// what was paid over it before, which earlier notes already credited.
+func paidAndCredited(invoice *models.Invoice, paid money.Money, note *models.CreditNote) (money.Money, error) {
+	payable, err := invoice.AmountPayable()
+	if err != nil {
+		return money.Money{}, fmt.Errorf("failed to work out the amount payable: %w", err)
+	}
+
+	overAfter, err := paid.Sub(payable)
+	if err != nil {
+		return money.Money{}, fmt.Errorf("payment currency does not match invoice: %w", err)
+	}
+	overBefore, err := overAfter.Sub(note.Total)
+	if err != nil {
+		return money.Money{}, fmt.Errorf("credit note currency does not match invoice: %w", err)
+	}
+
+	if !overAfter.IsPositive() {
+		return money.Zero(invoice.BillingCurrency), nil
+	}
+	if !overBefore.IsPositive() {
+		return overAfter, nil
+	}
+	return note.Total, nil
+}
+
+// findInvoiceItem returns the invoice line with the given id, or nil
+func findInvoiceItem(items []models.InvoiceItem, itemID uint) *models.InvoiceItem {
+	for idx := range items {
+		if items[idx].ID == itemID {
+			return &items[idx]
+		}
+	}
+	return nil
+}
+
+// creditInvoiceItem credits quantity units of a line of which already units
+// were credited before. Each amount is the difference between the share of the
+// line owed by already+quantity units and by already units, so crediting a
+// line bit by bit never takes back more or less than crediting it at once.
+func creditInvoiceItem(item *models.InvoiceItem, already int, quantity int) models.CreditNoteItem {
+	share := func(amount money.Money) money.Money {
+		before := amount.Allocate([]int64{int64(already), int64(item.Quantity - already)})[0]
+		after := amount.Allocate([]int64{int64(already + quantity), int64(item.Quantity - already - quantity)})[0]
+		credit, _ := after.Sub(before)
+		return credit
+	}
+
+	netAmount := share(item.NetAmount)
+	taxTotal := share(item.TaxTotal)
+	total, _ := netAmount.Add(taxTotal)
+
+	return models.CreditNoteItem{
+		InvoiceItemID: item.ID,
+		Description:   item.Description,
+		Quantity:      quantity,
+		UnitPrice:     item.UnitPrice,
+		NetAmount:     netAmount,
+		TaxTotal:      taxTotal,
+		Total:         total,
+	}
+}
+
+func NewCreditNoteService(
+	logger *zerolog.Logger,
+	creditNoteRepository repositories_interfaces.CreditNoteRepository,
+) services_interfaces.CreditNoteService {
+	return &creditNoteService{
+		logger:               logger,
+		creditNoteRepository: creditNoteRepository,
+	}
+}
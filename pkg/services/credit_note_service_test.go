@@ -0,0 +1,268 @@
+package services
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/Adebayobenjamin/numerisbook/pkg/auth"
+	"github.com/Adebayobenjamin/numerisbook/pkg/common/money"
+	request_dto "github.com/Adebayobenjamin/numerisbook/pkg/dtos/request"
+	"github.com/Adebayobenjamin/numerisbook/pkg/models"
+	repositories_interfaces "github.com/Adebayobenjamin/numerisbook/pkg/repositories/interfaces"
+	repository_mocks "github.com/Adebayobenjamin/numerisbook/pkg/repositories/mocks"
+	"github.com/rs/zerolog"
+	"github.com/stretchr/testify/assert"
+	"go.uber.org/mock/gomock"
+)
+
+func setupCreditNoteTest(t *testing.T) (*repository_mocks.MockCreditNoteRepository, *creditNoteService) {
+	ctrl := gomock.NewController(t)
+	mockRepo := repository_mocks.NewMockCreditNoteRepository(ctrl)
+	logger := zerolog.New(nil)
+	service := NewCreditNoteService(&logger, mockRepo).(*creditNoteService)
+	return mockRepo, service
+}
+
+// creditableInvoice has three design hours with 7.5% VAT and a licence without tax
+func creditableInvoice() *models.Invoice {
+	return &models.Invoice{
+		ID:              5,
+		CustomerID:      1,
+		InvoiceNumber:   "INV-2025-00005",
+		Status:          models.InvoiceStatusSent,
+		Version:         4,
+		BillingCurrency: "USD",
+		Items: []models.InvoiceItem{
+			{ID: 10, Description: "Design hour", Quantity: 3, UnitPrice: money.New(3333, "USD"), NetAmount: money.New(10000, "USD"), TaxTotal: money.New(750, "USD")},
+			{ID: 11, Description: "Licence", Quantity: 1, UnitPrice: money.New(5000, "USD"), NetAmount: money.New(5000, "USD"), TaxTotal: money.New(0, "USD")},
+		},
+	}
+}
+
+func TestCreateCreditNote(t *testing.T) {
+	ctx := auth.WithPrincipal(context.Background(), &auth.Principal{CustomerID: 1, Role: models.UserRoleOwner})
+
+	t.Run("without items whatever is left of every line is credited", func(t *testing.T) {
+		mockRepo, service := setupCreditNoteTest(t)
+		mockRepo.EXPECT().GetCreditedQuantities(ctx, uint(5)).Return(map[uint]int{10: 1}, nil)
+		mockRepo.EXPECT().
+			CreateCreditNote(ctx, gomock.Any(), uint(4), gomock.Any()).
+			DoAndReturn(func(_ context.Context, note *models.CreditNote, _ uint, _ repositories_interfaces.SettleCreditNote) (*models.CreditNote, error) {
+				assert.Equal(t, uint(5), note.InvoiceID)
+				assert.Equal(t, "Cancelled project", note.Reason)
+				assert.Len(t, note.Items, 2)
+				// the first hour took 33.33 and 2.50 already, the other two carry the rounding
+				assert.Equal(t, 2, note.Items[0].Quantity)
+				assert.Equal(t, money.New(6667, "USD"), note.Items[0].NetAmount)
+				assert.Equal(t, money.New(500, "USD"), note.Items[0].TaxTotal)
+				assert.Equal(t, money.New(7167, "USD"), note.Items[0].Total)
+				assert.Equal(t, money.New(5000, "USD"), note.Items[1].Total)
+				assert.Equal(t, money.New(11667, "USD"), note.NetTotal)
+				assert.Equal(t, money.New(500, "USD"), note.TaxTotal)
+				assert.Equal(t, money.New(12167, "USD"), note.Total)
+				return note, nil
+			})
+
+		note, err := service.CreateCreditNote(ctx, creditableInvoice(), &request_dto.CreateCreditNoteRequest{Reason: "Cancelled project"})
+
+		assert.NoError(t, err)
+		assert.NotNil(t, note)
+	})
+
+	t.Run("part of a line", func(t *testing.T) {
+		mockRepo, service := setupCreditNoteTest(t)
+		issueDate := time.Date(2025, 3, 1, 0, 0, 0, 0, time.UTC)
+		mockRepo.EXPECT().GetCreditedQuantities(ctx, uint(5)).Return(map[uint]int{}, nil)
+		mockRepo.EXPECT().
+			CreateCreditNote(ctx, gomock.Any(), uint(4), gomock.Any()).
+			DoAndReturn(func(_ context.Context, note *models.CreditNote, _ uint, _ repositories_interfaces.SettleCreditNote) (*models.CreditNote, error) {
+				assert.Equal(t, issueDate, note.IssueDate)
+				assert.Len(t, note.Items, 1)
+				assert.Equal(t, uint(10), note.Items[0].InvoiceItemID)
+				assert.Equal(t, money.New(3333, "USD"), note.NetTotal)
+				assert.Equal(t, money.New(250, "USD"), note.TaxTotal)
+				assert.Equal(t, money.New(3583, "USD"), note.Total)
+				return note, nil
+			})
+
+		_, err := service.CreateCreditNote(ctx, creditableInvoice(), &request_dto.CreateCreditNoteRequest{
+			Reason:    "One hour not worked",
+			IssueDate: &issueDate,
+			Items:     []request_dto.CreditNoteItem{{InvoiceItemID: 10, Quantity: 1}},
+		})
+
+		assert.NoError(t, err)
+	})
+
+	t.Run("crediting what is left unpaid settles the invoice with the note", func(t *testing.T) {
+		mockRepo, service := setupCreditNoteTest(t)
+		mockRepo.EXPECT().GetCreditedQuantities(ctx, uint(5)).Return(map[uint]int{}, nil)
+		mockRepo.EXPECT().
+			CreateCreditNote(ctx, gomock.Any(), uint(4), gomock.Any()).
+			DoAndReturn(func(_ context.Context, note *models.CreditNote, _ uint, settle repositories_interfaces.SettleCreditNote) (*models.CreditNote, error) {
+				// the licence was not delivered and everything else was paid
+				invoice := creditableInvoice()
+				invoice.Status = models.InvoiceStatusPartiallyPaid
+				invoice.TotalAmountDue = money.New(15750, "USD")
+				invoice.CreditedTotal = money.New(5000, "USD")
+				note.CreditNoteNumber = "CN-2025-00001"
+
+				auditTrails, err := settle(invoice, money.New(10750, "USD"), note)
+
+				assert.NoError(t, err)
+				assert.Equal(t, models.InvoiceStatusPaid, invoice.Status)
+				assert.True(t, invoice.IsFullyPaid)
+				assert.Len(t, auditTrails, 2)
+				assert.Equal(t, "Issued Credit Note CN-2025-00001 of 50.00 for Invoice INV-2025-00005", auditTrails[0].Message)
+				assert.Equal(t, "Invoice INV-2025-00005 moved from partially_paid to paid", auditTrails[1].Message)
+				return note, nil
+			})
+
+		_, err := service.CreateCreditNote(ctx, creditableInvoice(), &request_dto.CreateCreditNoteRequest{
+			Reason: "Licence not delivered",
+			Items:  []request_dto.CreditNoteItem{{InvoiceItemID: 11, Quantity: 1}},
+		})
+
+		assert.NoError(t, err)
+	})
+
+	t.Run("an unpaid invoice credited in part keeps its status", func(t *testing.T) {
+		mockRepo, service := setupCreditNoteTest(t)
+		mockRepo.EXPECT().GetCreditedQuantities(ctx, uint(5)).Return(map[uint]int{}, nil)
+		mockRepo.EXPECT().
+			CreateCreditNote(ctx, gomock.Any(), uint(4), gomock.Any()).
+			DoAndReturn(func(_ context.Context, note *models.CreditNote, _ uint, settle repositories_interfaces.SettleCreditNote) (*models.CreditNote, error) {
+				invoice := creditableInvoice()
+				invoice.TotalAmountDue = money.New(15750, "USD")
+				invoice.CreditedTotal = money.New(5000, "USD")
+
+				auditTrails, err := settle(invoice, money.Zero("USD"), note)
+
+				assert.NoError(t, err)
+				assert.Equal(t, models.InvoiceStatusSent, invoice.Status)
+				assert.Len(t, auditTrails, 1)
+				assert.Equal(t, models.EventTypeCreditNoteIssued, auditTrails[0].EventType)
+				return note, nil
+			})
+
+		_, err := service.CreateCreditNote(ctx, creditableInvoice(), &request_dto.CreateCreditNoteRequest{
+			Reason: "Licence not delivered",
+			Items:  []request_dto.CreditNoteItem{{InvoiceItemID: 11, Quantity: 1}},
+		})
+
+		assert.NoError(t, err)
+	})
+
+	t.Run("crediting a paid invoice gives what was paid back to the client as credit", func(t *testing.T) {
+		mockRepo, service := setupCreditNoteTest(t)
+		clientID := uint(9)
+		mockRepo.EXPECT().GetCreditedQuantities(ctx, uint(5)).Return(map[uint]int{}, nil)
+		mockRepo.EXPECT().
+			CreateCreditNote(ctx, gomock.Any(), uint(4), gomock.Any()).
+			DoAndReturn(func(_ context.Context, note *models.CreditNote, _ uint, settle repositories_interfaces.SettleCreditNote) (*models.CreditNote, error) {
+				invoice := creditableInvoice()
+				invoice.Status = models.InvoiceStatusPaid
+				invoice.IsFullyPaid = true
+				invoice.InvoiceClient = models.InvoiceClient{ClientID: &clientID, Name: "Acme"}
+				invoice.TotalAmountDue = money.New(15750, "USD")
+				invoice.CreditedTotal = money.New(5000, "USD")
+				note.CreditNoteNumber = "CN-2025-00001"
+
+				auditTrails, err := settle(invoice, money.New(15750, "USD"), note)
+
+				assert.NoError(t, err)
+				assert.Equal(t, models.InvoiceStatusPaid, invoice.Status)
+				assert.Equal(t, money.New(5000, "USD"), *note.ClientCredit)
+				assert.Len(t, auditTrails, 2)
+				assert.Equal(t, models.EventTypeClientCredited, auditTrails[1].EventType)
+				assert.Equal(t, "Credited 50.00 paid on Invoice INV-2025-00005 to Client Acme by Credit Note CN-2025-00001", auditTrails[1].Message)
+				return note, nil
+			})
+
+		_, err := service.CreateCreditNote(ctx, creditableInvoice(), &request_dto.CreateCreditNoteRequest{
+			Reason: "Licence not delivered",
+			Items:  []request_dto.CreditNoteItem{{InvoiceItemID: 11, Quantity: 1}},
+		})
+
+		assert.NoError(t, err)
+	})
+
+	t.Run("only the part of a note that was paid is credited to the client", func(t *testing.T) {
+		invoice := creditableInvoice()
+		invoice.TotalAmountDue = money.New(15750, "USD")
+		invoice.CreditedTotal = money.New(5000, "USD")
+		note := &models.CreditNote{Total: money.New(5000, "USD")}
+
+		// 120.00 was paid of the 157.50 asked for before the note, and 107.50 after
+		credit, err := paidAndCredited(invoice, money.New(12000, "USD"), note)
+
+		assert.NoError(t, err)
+		assert.Equal(t, money.New(1250, "USD"), credit)
+	})
+
+	t.Run("more than is left of a line", func(t *testing.T) {
+		mockRepo, service := setupCreditNoteTest(t)
+		mockRepo.EXPECT().GetCreditedQuantities(ctx, uint(5)).Return(map[uint]int{10: 1}, nil)
+
+		note, err := service.CreateCreditNote(ctx, creditableInvoice(), &request_dto.CreateCreditNoteRequest{
+			Reason: "Overcharged",
+			Items:  []request_dto.CreditNoteItem{{InvoiceItemID: 10, Quantity: 3}},
+		})
+
+		assert.EqualError(t, err, "item 1: only 2 of 3 can still be credited")
+		assert.Nil(t, note)
+	})
+
+	t.Run("repeated lines of the same item add up", func(t *testing.T) {
+		mockRepo, service := setupCreditNoteTest(t)
+		mockRepo.EXPECT().GetCreditedQuantities(ctx, uint(5)).Return(map[uint]int{}, nil)
+
+		_, err := service.CreateCreditNote(ctx, creditableInvoice(), &request_dto.CreateCreditNoteRequest{
+			Reason: "Overcharged",
+			Items:  []request_dto.CreditNoteItem{{InvoiceItemID: 10, Quantity: 2}, {InvoiceItemID: 10, Quantity: 2}},
+		})
+
+		assert.EqualError(t, err, "item 2: only 1 of 3 can still be credited")
+	})
+
+	t.Run("item from another invoice", func(t *testing.T) {
+		mockRepo, service := setupCreditNoteTest(t)
+		mockRepo.EXPECT().GetCreditedQuantities(ctx, uint(5)).Return(map[uint]int{}, nil)
+
+		_, err := service.CreateCreditNote(ctx, creditableInvoice(), &request_dto.CreateCreditNoteRequest{
+			Reason: "Overcharged",
+			Items:  []request_dto.CreditNoteItem{{InvoiceItemID: 99, Quantity: 1}},
+		})
+
+		assert.EqualError(t, err, "item 1: invoice item 99 is not on invoice INV-2025-00005")
+	})
+
+	t.Run("nothing left to credit", func(t *testing.T) {
+		mockRepo, service := setupCreditNoteTest(t)
+		mockRepo.EXPECT().GetCreditedQuantities(ctx, uint(5)).Return(map[uint]int{10: 3, 11: 1}, nil)
+
+		_, err := service.CreateCreditNote(ctx, creditableInvoice(), &request_dto.CreateCreditNoteRequest{Reason: "Overcharged"})
+
+		assert.EqualError(t, err, "invoice INV-2025-00005 has already been fully credited")
+	})
+
+	t.Run("drafts are edited, not credited", func(t *testing.T) {
+		_, service := setupCreditNoteTest(t)
+		invoice := creditableInvoice()
+		invoice.Status = models.InvoiceStatusDraft
+
+		_, err := service.CreateCreditNote(ctx, invoice, &request_dto.CreateCreditNoteRequest{Reason: "Overcharged"})
+
+		assert.EqualError(t, err, "invoice INV-2025-00005 is draft and cannot be credited")
+	})
+
+	t.Run("viewers cannot issue credit notes", func(t *testing.T) {
+		_, service := setupCreditNoteTest(t)
+		viewerCtx := auth.WithPrincipal(context.Background(), &auth.Principal{CustomerID: 1, Role: models.UserRoleViewer})
+
+		_, err := service.CreateCreditNote(viewerCtx, creditableInvoice(), &request_dto.CreateCreditNoteRequest{Reason: "Overcharged"})
+
+		assert.ErrorIs(t, err, auth.ErrForbidden)
+	})
+}
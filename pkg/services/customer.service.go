@@ -3,6 +3,8 @@ package services
 import (
 	"context"
 
+	"github.com/Adebayobenjamin/numerisbook/pkg/auth"
+	request_dto "github.com/Adebayobenjamin/numerisbook/pkg/dtos/request"
 	"github.com/Adebayobenjamin/numerisbook/pkg/models"
 	repositories_interfaces "github.com/Adebayobenjamin/numerisbook/pkg/repositories/interfaces"
 	services_interfaces "github.com/Adebayobenjamin/numerisbook/pkg/services/interfaces"
@@ -19,6 +21,36 @@ func (c *customerService) GetCustomerByID(ctx context.Context, customerID uint)
 	return c.customerRepository.GetCustomerByID(ctx, customerID)
 }
 
+// UpdateBranding implements services_interfaces.CustomerService.
+func (c *customerService) UpdateBranding(ctx context.Context, customerID uint, request *request_dto.UpdateBrandingRequest) (*models.Customer, error) {
+	if err := auth.Authorize(ctx, auth.PermissionSettingsManage); err != nil {
+		return nil, err
+	}
+
+	customer, err := c.customerRepository.GetCustomerByID(ctx, customerID)
+	if err != nil {
+		return nil, err
+	}
+
+	// branding is replaced as a whole, so leaving a field out clears it
+	customer.BrandColor = emptyToNil(request.BrandColor)
+	customer.LogoURL = emptyToNil(request.LogoURL)
+	customer.EmailFooter = emptyToNil(request.EmailFooter)
+
+	if err := c.customerRepository.UpdateBranding(ctx, customer); err != nil {
+		return nil, err
+	}
+
+	return customer, nil
+}
+
+func emptyToNil(value string) *string {
+	if value == "" {
+		return nil
+	}
+	return &value
+}
+
 func NewCustomerService(logger *zerolog.Logger, customerRepository repositories_interfaces.CustomerRepository) services_interfaces.CustomerService {
 	return &customerService{
 		logger:             logger,
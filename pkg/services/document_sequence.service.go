@@ -0,0 +1,95 @@
+package services
+
+import (
+	"context"
+	"fmt"
+	"regexp"
+	"time"
+
+	"github.com/Adebayobenjamin/numerisbook/pkg/auth"
+	request_dto "github.com/Adebayobenjamin/numerisbook/pkg/dtos/request"
+	"github.com/Adebayobenjamin/numerisbook/pkg/helper"
+	"github.com/Adebayobenjamin/numerisbook/pkg/models"
+	repositories_interfaces "github.com/Adebayobenjamin/numerisbook/pkg/repositories/interfaces"
+	services_interfaces "github.com/Adebayobenjamin/numerisbook/pkg/services/interfaces"
+)
+
+var yearToken = regexp.MustCompile(`\{YY(YY)?\}`)
+
+type documentSequenceService struct {
+	documentSequenceRepository repositories_interfaces.DocumentSequenceRepository
+}
+
+// GetSequence implements services_interfaces.DocumentSequenceService.
+func (d *documentSequenceService) GetSequence(ctx context.Context, customerID uint, documentType models.DocumentType) (*models.DocumentSequence, error) {
+	sequence, err := d.documentSequenceRepository.GetSequence(ctx, customerID, documentType)
+	if err != nil {
+		return nil, err
+	}
+
+	if err = previewNextNumber(sequence, time.Now()); err != nil {
+		return nil, err
+	}
+
+	return sequence, nil
+}
+
+// UpdateSequence implements services_interfaces.DocumentSequenceService.
+func (d *documentSequenceService) UpdateSequence(ctx context.Context, customerID uint, documentType models.DocumentType, request *request_dto.UpdateDocumentSequenceRequest) (*models.DocumentSequence, error) {
+	if err := auth.Authorize(ctx, auth.PermissionSettingsManage); err != nil {
+		return nil, err
+	}
+
+	sequence := &models.DocumentSequence{
+		CustomerID:   customerID,
+		DocumentType: documentType,
+		Prefix:       request.Prefix,
+		Format:       request.Format,
+		ResetPolicy:  request.ResetPolicy,
+	}
+
+	if _, err := helper.FormatDocumentNumber(sequence.Format, sequence.Prefix, time.Now(), 1); err != nil {
+		return nil, fmt.Errorf("invalid number format: %w", err)
+	}
+
+	// a counter that restarts every year would repeat last year's numbers
+	// unless the year is part of the number
+	if sequence.ResetPolicy == models.SequenceResetPolicyYearly && !yearToken.MatchString(sequence.Format) {
+		return nil, fmt.Errorf("invalid number format: a yearly reset needs a {YYYY} or {YY} token")
+	}
+
+	sequence, err := d.documentSequenceRepository.UpdateSequenceSettings(ctx, sequence)
+	if err != nil {
+		return nil, err
+	}
+
+	if err = previewNextNumber(sequence, time.Now()); err != nil {
+		return nil, err
+	}
+
+	return sequence, nil
+}
+
+// previewNextNumber fills in the number the next document would get if it were created now
+func previewNextNumber(sequence *models.DocumentSequence, now time.Time) error {
+	next := sequence.NextValue
+	if sequence.ResetPolicy == models.SequenceResetPolicyYearly && sequence.CurrentYear != now.Year() {
+		next = 1
+	}
+
+	number, err := helper.FormatDocumentNumber(sequence.Format, sequence.Prefix, now, next)
+	if err != nil {
+		return fmt.Errorf("failed to format document number: %w", err)
+	}
+	sequence.NextNumber = number
+
+	return nil
+}
+
+func NewDocumentSequenceService(
+	documentSequenceRepository repositories_interfaces.DocumentSequenceRepository,
+) services_interfaces.DocumentSequenceService {
+	return &documentSequenceService{
+		documentSequenceRepository: documentSequenceRepository,
+	}
+}
@@ -0,0 +1,96 @@
+package services
+
+import (
+	"context"
+	"fmt"
+	"testing"
+	"time"
+
+	"github.com/Adebayobenjamin/numerisbook/pkg/auth"
+	request_dto "github.com/Adebayobenjamin/numerisbook/pkg/dtos/request"
+	"github.com/Adebayobenjamin/numerisbook/pkg/models"
+	repository_mocks "github.com/Adebayobenjamin/numerisbook/pkg/repositories/mocks"
+	"github.com/stretchr/testify/assert"
+	"go.uber.org/mock/gomock"
+)
+
+func setupDocumentSequenceTest(t *testing.T) (*repository_mocks.MockDocumentSequenceRepository, *documentSequenceService) {
+	ctrl := gomock.NewController(t)
+	mockRepo := repository_mocks.NewMockDocumentSequenceRepository(ctrl)
+	service := NewDocumentSequenceService(mockRepo).(*documentSequenceService)
+	return mockRepo, service
+}
+
+func TestUpdateSequence(t *testing.T) {
+	mockRepo, service := setupDocumentSequenceTest(t)
+	ctx := auth.WithPrincipal(context.Background(), &auth.Principal{CustomerID: 1, Role: models.UserRoleOwner})
+	year := time.Now().Year()
+
+	tests := []struct {
+		name         string
+		request      *request_dto.UpdateDocumentSequenceRequest
+		mockSetup    func()
+		expectedNext string
+		wantErr      bool
+		errMsg       string
+	}{
+		{
+			name: "saves the template and previews the next number",
+			request: &request_dto.UpdateDocumentSequenceRequest{
+				Prefix:      "ACME",
+				Format:      "{PREFIX}/{YYYY}/{SEQ:04}",
+				ResetPolicy: models.SequenceResetPolicyYearly,
+			},
+			mockSetup: func() {
+				mockRepo.EXPECT().
+					UpdateSequenceSettings(ctx, gomock.Any()).
+					DoAndReturn(func(_ context.Context, sequence *models.DocumentSequence) (*models.DocumentSequence, error) {
+						assert.Equal(t, uint(1), sequence.CustomerID)
+						assert.Equal(t, models.DocumentTypeInvoice, sequence.DocumentType)
+						sequence.NextValue = 12
+						sequence.CurrentYear = year
+						return sequence, nil
+					})
+			},
+			expectedNext: fmt.Sprintf("ACME/%d/0012", year),
+		},
+		{
+			name: "format without a sequence",
+			request: &request_dto.UpdateDocumentSequenceRequest{
+				Format:      "{PREFIX}-{YYYY}",
+				ResetPolicy: models.SequenceResetPolicyNever,
+			},
+			mockSetup: func() {},
+			wantErr:   true,
+			errMsg:    "must contain a {SEQ} token",
+		},
+		{
+			name: "yearly reset without the year in the number",
+			request: &request_dto.UpdateDocumentSequenceRequest{
+				Prefix:      "INV",
+				Format:      "{PREFIX}-{SEQ:05}",
+				ResetPolicy: models.SequenceResetPolicyYearly,
+			},
+			mockSetup: func() {},
+			wantErr:   true,
+			errMsg:    "a yearly reset needs a {YYYY} or {YY} token",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			tt.mockSetup()
+
+			sequence, err := service.UpdateSequence(ctx, 1, models.DocumentTypeInvoice, tt.request)
+
+			if tt.wantErr {
+				assert.Error(t, err)
+				assert.Contains(t, err.Error(), tt.errMsg)
+				assert.Nil(t, sequence)
+			} else {
+				assert.NoError(t, err)
+				assert.Equal(t, tt.expectedNext, sequence.NextNumber)
+			}
+		})
+	}
+}
@@ -0,0 +1,101 @@
+package services
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"os"
+	"time"
+
+	"github.com/Adebayobenjamin/numerisbook/pkg/common/exceptions"
+	"github.com/Adebayobenjamin/numerisbook/pkg/helper"
+	"github.com/Adebayobenjamin/numerisbook/pkg/models"
+	repositories_interfaces "github.com/Adebayobenjamin/numerisbook/pkg/repositories/interfaces"
+	services_interfaces "github.com/Adebayobenjamin/numerisbook/pkg/services/interfaces"
+	"github.com/rs/zerolog"
+)
+
+const (
+	// defaultIdempotencyKeyTTL is how long responses are kept for replay when
+	// IDEMPOTENCY_KEY_TTL is not set
+	defaultIdempotencyKeyTTL = 24 * time.Hour
+	// defaultIdempotencyKeyLease is how long a request holds its key while it
+	// runs when IDEMPOTENCY_KEY_LEASE is not set. A retry of a request that
+	// died without releasing its key can run once the lease is over.
+	defaultIdempotencyKeyLease = 5 * time.Minute
+	// maxIdempotencyKeyLength matches the idempotency_key column
+	maxIdempotencyKeyLength = 255
+)
+
+type idempotencyService struct {
+	logger                   *zerolog.Logger
+	idempotencyKeyRepository repositories_interfaces.IdempotencyKeyRepository
+	ttl                      time.Duration
+	lease                    time.Duration
+}
+
+// Begin implements services_interfaces.IdempotencyService.
+func (i *idempotencyService) Begin(ctx context.Context, key string, requestHash string) (*models.IdempotencyKey, error) {
+	if len(key) > maxIdempotencyKeyLength {
+		return nil, fmt.Errorf("idempotency key must be at most %d characters", maxIdempotencyKeyLength)
+	}
+
+	now := time.Now().UTC()
+	record, reserved, err := i.idempotencyKeyRepository.Reserve(ctx, &models.IdempotencyKey{
+		Key:         key,
+		RequestHash: requestHash,
+		LockedUntil: helper.ReturnPointer(now.Add(i.lease)),
+		ExpiresAt:   now.Add(i.ttl),
+	}, now)
+	if err != nil {
+		return nil, err
+	}
+	if reserved {
+		return record, nil
+	}
+
+	if record.RequestHash != requestHash {
+		return nil, fmt.Errorf("idempotency key %w", exceptions.ErrIdempotencyKeyReused)
+	}
+	if record.Status != models.IdempotencyKeyStatusCompleted {
+		return nil, fmt.Errorf("idempotency key %w", exceptions.ErrIdempotencyKeyInProgress)
+	}
+
+	return record, nil
+}
+
+// Complete implements services_interfaces.IdempotencyService.
+func (i *idempotencyService) Complete(ctx context.Context, record *models.IdempotencyKey, status int, headers http.Header, body []byte) error {
+	return i.idempotencyKeyRepository.Complete(ctx, record.ID, status, models.ResponseHeaders(headers.Clone()), body)
+}
+
+// Release implements services_interfaces.IdempotencyService.
+func (i *idempotencyService) Release(ctx context.Context, record *models.IdempotencyKey) error {
+	return i.idempotencyKeyRepository.Release(ctx, record.ID)
+}
+
+// PurgeExpired implements services_interfaces.IdempotencyService.
+func (i *idempotencyService) PurgeExpired(ctx context.Context, now time.Time) (int64, error) {
+	return i.idempotencyKeyRepository.DeleteExpired(ctx, now)
+}
+
+func NewIdempotencyService(
+	logger *zerolog.Logger,
+	idempotencyKeyRepository repositories_interfaces.IdempotencyKeyRepository,
+) services_interfaces.IdempotencyService {
+	ttl, err := time.ParseDuration(os.Getenv("IDEMPOTENCY_KEY_TTL"))
+	if err != nil || ttl <= 0 {
+		ttl = defaultIdempotencyKeyTTL
+	}
+	lease, err := time.ParseDuration(os.Getenv("IDEMPOTENCY_KEY_LEASE"))
+	if err != nil || lease <= 0 {
+		lease = defaultIdempotencyKeyLease
+	}
+
+	return &idempotencyService{
+		logger:                   logger,
+		idempotencyKeyRepository: idempotencyKeyRepository,
+		ttl:                      ttl,
+		lease:                    lease,
+	}
+}
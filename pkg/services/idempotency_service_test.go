@@ -0,0 +1,111 @@
+package services
+
+import (
+	"context"
+	"net/http"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/Adebayobenjamin/numerisbook/pkg/common/exceptions"
+	"github.com/Adebayobenjamin/numerisbook/pkg/models"
+	repository_mocks "github.com/Adebayobenjamin/numerisbook/pkg/repositories/mocks"
+	"github.com/Adebayobenjamin/numerisbook/pkg/tenant"
+	"github.com/rs/zerolog"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/mock/gomock"
+)
+
+func setupIdempotencyTest(t *testing.T) (*repository_mocks.MockIdempotencyKeyRepository, *idempotencyService) {
+	ctrl := gomock.NewController(t)
+	mockIdempotencyKeyRepo := repository_mocks.NewMockIdempotencyKeyRepository(ctrl)
+	logger := zerolog.New(nil)
+	service := &idempotencyService{
+		logger:                   &logger,
+		idempotencyKeyRepository: mockIdempotencyKeyRepo,
+		ttl:                      defaultIdempotencyKeyTTL,
+		lease:                    defaultIdempotencyKeyLease,
+	}
+	return mockIdempotencyKeyRepo, service
+}
+
+func TestIdempotencyBegin(t *testing.T) {
+	ctx := tenant.WithCustomerID(context.Background(), 2)
+
+	t.Run("reserves an unused key until the ttl, held for the lease", func(t *testing.T) {
+		mockIdempotencyKeyRepo, service := setupIdempotencyTest(t)
+		mockIdempotencyKeyRepo.EXPECT().Reserve(ctx, gomock.Any(), gomock.Any()).
+			DoAndReturn(func(_ context.Context, key *models.IdempotencyKey, now time.Time) (*models.IdempotencyKey, bool, error) {
+				assert.Equal(t, "key-1", key.Key)
+				assert.Equal(t, "hash-1", key.RequestHash)
+				assert.Equal(t, now.Add(defaultIdempotencyKeyTTL), key.ExpiresAt)
+				assert.Equal(t, now.Add(defaultIdempotencyKeyLease), *key.LockedUntil)
+				return &models.IdempotencyKey{ID: 3, Key: key.Key, RequestHash: key.RequestHash, Status: models.IdempotencyKeyStatusProcessing}, true, nil
+			})
+
+		record, err := service.Begin(ctx, "key-1", "hash-1")
+
+		require.NoError(t, err)
+		assert.Equal(t, models.IdempotencyKeyStatusProcessing, record.Status)
+	})
+
+	t.Run("returns the kept response for the same request", func(t *testing.T) {
+		mockIdempotencyKeyRepo, service := setupIdempotencyTest(t)
+		stored := &models.IdempotencyKey{ID: 3, RequestHash: "hash-1", Status: models.IdempotencyKeyStatusCompleted, ResponseStatus: http.StatusCreated}
+		mockIdempotencyKeyRepo.EXPECT().Reserve(ctx, gomock.Any(), gomock.Any()).Return(stored, false, nil)
+
+		record, err := service.Begin(ctx, "key-1", "hash-1")
+
+		require.NoError(t, err)
+		assert.Equal(t, stored, record)
+	})
+
+	t.Run("refuses the key for a different request", func(t *testing.T) {
+		mockIdempotencyKeyRepo, service := setupIdempotencyTest(t)
+		stored := &models.IdempotencyKey{ID: 3, RequestHash: "hash-1", Status: models.IdempotencyKeyStatusCompleted}
+		mockIdempotencyKeyRepo.EXPECT().Reserve(ctx, gomock.Any(), gomock.Any()).Return(stored, false, nil)
+
+		_, err := service.Begin(ctx, "key-1", "hash-2")
+
+		assert.ErrorIs(t, err, exceptions.ErrIdempotencyKeyReused)
+	})
+
+	t.Run("refuses the key while the first request runs", func(t *testing.T) {
+		mockIdempotencyKeyRepo, service := setupIdempotencyTest(t)
+		stored := &models.IdempotencyKey{ID: 3, RequestHash: "hash-1", Status: models.IdempotencyKeyStatusProcessing}
+		mockIdempotencyKeyRepo.EXPECT().Reserve(ctx, gomock.Any(), gomock.Any()).Return(stored, false, nil)
+
+		_, err := service.Begin(ctx, "key-1", "hash-1")
+
+		assert.ErrorIs(t, err, exceptions.ErrIdempotencyKeyInProgress)
+	})
+
+	t.Run("refuses keys longer than the column", func(t *testing.T) {
+		_, service := setupIdempotencyTest(t)
+
+		_, err := service.Begin(ctx, strings.Repeat("k", maxIdempotencyKeyLength+1), "hash-1")
+
+		assert.EqualError(t, err, "idempotency key must be at most 255 characters")
+	})
+}
+
+func TestNewIdempotencyService_TTL(t *testing.T) {
+	logger := zerolog.New(nil)
+
+	t.Setenv("IDEMPOTENCY_KEY_TTL", "2h")
+	assert.Equal(t, 2*time.Hour, NewIdempotencyService(&logger, nil).(*idempotencyService).ttl)
+
+	t.Setenv("IDEMPOTENCY_KEY_TTL", "")
+	assert.Equal(t, defaultIdempotencyKeyTTL, NewIdempotencyService(&logger, nil).(*idempotencyService).ttl)
+}
+
+func TestNewIdempotencyService_Lease(t *testing.T) {
+	logger := zerolog.New(nil)
+
+	t.Setenv("IDEMPOTENCY_KEY_LEASE", "30s")
+	assert.Equal(t, 30*time.Second, NewIdempotencyService(&logger, nil).(*idempotencyService).lease)
+
+	t.Setenv("IDEMPOTENCY_KEY_LEASE", "")
+	assert.Equal(t, defaultIdempotencyKeyLease, NewIdempotencyService(&logger, nil).(*idempotencyService).lease)
+}
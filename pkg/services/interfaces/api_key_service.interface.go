@@ -0,0 +1,15 @@
+package services_interfaces
+
+import (
+	"context"
+
+	request_dto "github.com/Adebayobenjamin/numerisbook/pkg/dtos/request"
+	response_dto "github.com/Adebayobenjamin/numerisbook/pkg/dtos/response"
+	"github.com/Adebayobenjamin/numerisbook/pkg/models"
+)
+
+type APIKeyService interface {
+	CreateKey(ctx context.Context, customerID uint, request *request_dto.CreateAPIKeyRequest) (*response_dto.CreateAPIKeyResponse, error)
+	GetCustomerKeys(ctx context.Context, customerID uint) ([]models.APIKey, error)
+	RevokeKey(ctx context.Context, keyID uint, customerID uint) error
+}
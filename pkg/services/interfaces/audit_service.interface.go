@@ -16,6 +16,17 @@ type AuditService interface {
 		customerID uint,
 	) error
 
+	// CreateQuoteAuditTrail records an event of an invoice that came from a quote
+	CreateQuoteAuditTrail(
+		ctx context.Context,
+		eventType models.EventType,
+		logLevel models.LogLevel,
+		message string,
+		invoiceID uint,
+		quoteID uint,
+		customerID uint,
+	) error
+
 	GetCustomerAuditTrails(
 		ctx context.Context,
 		customerID uint,
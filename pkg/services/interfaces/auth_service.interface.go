@@ -0,0 +1,11 @@
+package services_interfaces
+
+import (
+	"context"
+
+	"github.com/Adebayobenjamin/numerisbook/pkg/auth"
+)
+
+type AuthService interface {
+	Authenticate(ctx context.Context, credential string) (*auth.Principal, error)
+}
@@ -0,0 +1,17 @@
+package services_interfaces
+
+import (
+	"context"
+
+	request_dto "github.com/Adebayobenjamin/numerisbook/pkg/dtos/request"
+	"github.com/Adebayobenjamin/numerisbook/pkg/models"
+)
+
+type BankAccountService interface {
+	CreateBankAccount(ctx context.Context, customerID uint, request *request_dto.CreateBankAccountRequest) (*models.BankAccount, error)
+	GetBankAccounts(ctx context.Context, customerID uint) ([]models.BankAccount, error)
+	GetBankAccountByIDAndCustomer(ctx context.Context, accountID uint, customerID uint) (*models.BankAccount, error)
+	UpdateBankAccount(ctx context.Context, accountID uint, customerID uint, request *request_dto.UpdateBankAccountRequest) (*models.BankAccount, error)
+	DeleteBankAccount(ctx context.Context, accountID uint, customerID uint) error
+	SetDefaultBankAccount(ctx context.Context, accountID uint, customerID uint) (*models.BankAccount, error)
+}
@@ -0,0 +1,17 @@
+package services_interfaces
+
+import (
+	"context"
+
+	request_dto "github.com/Adebayobenjamin/numerisbook/pkg/dtos/request"
+	"github.com/Adebayobenjamin/numerisbook/pkg/models"
+)
+
+type BusinessProfileService interface {
+	CreateBusinessProfile(ctx context.Context, customerID uint, request *request_dto.CreateBusinessProfileRequest) (*models.BusinessProfile, error)
+	GetBusinessProfiles(ctx context.Context, customerID uint) ([]models.BusinessProfile, error)
+	GetBusinessProfileByIDAndCustomer(ctx context.Context, profileID uint, customerID uint) (*models.BusinessProfile, error)
+	UpdateBusinessProfile(ctx context.Context, profileID uint, customerID uint, request *request_dto.UpdateBusinessProfileRequest) (*models.BusinessProfile, error)
+	DeleteBusinessProfile(ctx context.Context, profileID uint, customerID uint) error
+	SetDefaultBusinessProfile(ctx context.Context, profileID uint, customerID uint) (*models.BusinessProfile, error)
+}
@@ -0,0 +1,18 @@
+package services_interfaces
+
+import (
+	"context"
+
+	request_dto "github.com/Adebayobenjamin/numerisbook/pkg/dtos/request"
+	response_dto "github.com/Adebayobenjamin/numerisbook/pkg/dtos/response"
+	"github.com/Adebayobenjamin/numerisbook/pkg/models"
+)
+
+type CatalogItemService interface {
+	CreateCatalogItem(ctx context.Context, customerID uint, request *request_dto.CreateCatalogItemRequest) (*models.CatalogItem, error)
+	GetCatalogItems(ctx context.Context, customerID uint, request *request_dto.GetAllCatalogItemsRequest) ([]models.CatalogItem, error)
+	GetCatalogItemByIDAndCustomer(ctx context.Context, itemID uint, customerID uint) (*models.CatalogItem, error)
+	UpdateCatalogItem(ctx context.Context, itemID uint, customerID uint, request *request_dto.UpdateCatalogItemRequest) (*models.CatalogItem, error)
+	DeleteCatalogItem(ctx context.Context, itemID uint, customerID uint) error
+	GetCatalogRevenue(ctx context.Context, customerID uint, request *request_dto.GetCatalogRevenueRequest) ([]response_dto.CatalogItemRevenue, error)
+}
@@ -0,0 +1,14 @@
+package services_interfaces
+
+import (
+	"context"
+
+	request_dto "github.com/Adebayobenjamin/numerisbook/pkg/dtos/request"
+	response_dto "github.com/Adebayobenjamin/numerisbook/pkg/dtos/response"
+	"github.com/Adebayobenjamin/numerisbook/pkg/models"
+)
+
+type ClientCreditService interface {
+	GetClientCredits(ctx context.Context, clientID uint, customerID uint, request *request_dto.GetClientCreditsRequest) (*response_dto.GetClientCreditsResponse, error)
+	ApplyCredit(ctx context.Context, clientID uint, customerID uint, request *request_dto.ApplyClientCreditRequest) (*models.Payment, error)
+}
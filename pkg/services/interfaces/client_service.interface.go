@@ -0,0 +1,17 @@
+package services_interfaces
+
+import (
+	"context"
+
+	request_dto "github.com/Adebayobenjamin/numerisbook/pkg/dtos/request"
+	"github.com/Adebayobenjamin/numerisbook/pkg/models"
+)
+
+type ClientService interface {
+	CreateClient(ctx context.Context, customerID uint, request *request_dto.CreateClientRequest) (*models.Client, error)
+	GetClients(ctx context.Context, customerID uint, request *request_dto.GetAllClientsRequest) ([]models.Client, error)
+	GetClientByIDAndCustomer(ctx context.Context, clientID uint, customerID uint) (*models.Client, error)
+	UpdateClient(ctx context.Context, clientID uint, customerID uint, request *request_dto.UpdateClientRequest) (*models.Client, error)
+	DeleteClient(ctx context.Context, clientID uint, customerID uint) error
+	RestoreClient(ctx context.Context, clientID uint, customerID uint) (*models.Client, error)
+}
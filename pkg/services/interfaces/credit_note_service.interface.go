@@ -0,0 +1,15 @@
+package services_interfaces
+
+import (
+	"context"
+
+	request_dto "github.com/Adebayobenjamin/numerisbook/pkg/dtos/request"
+	"github.com/Adebayobenjamin/numerisbook/pkg/models"
+)
+
+type CreditNoteService interface {
+	CreateCreditNote(ctx context.Context, invoice *models.Invoice, request *request_dto.CreateCreditNoteRequest) (*models.CreditNote, error)
+	GetCreditNoteByIDAndCustomer(ctx context.Context, creditNoteID uint, customerID uint) (*models.CreditNote, error)
+	GetInvoiceCreditNotes(ctx context.Context, invoiceID uint) ([]models.CreditNote, error)
+	GetCustomerCreditNotes(ctx context.Context, customerID uint, request *request_dto.GetAllRequest) ([]models.CreditNote, error)
+}
@@ -3,9 +3,11 @@ package services_interfaces
 import (
 	"context"
 
+	request_dto "github.com/Adebayobenjamin/numerisbook/pkg/dtos/request"
 	"github.com/Adebayobenjamin/numerisbook/pkg/models"
 )
 
 type CustomerService interface {
 	GetCustomerByID(ctx context.Context, customerID uint) (*models.Customer, error)
+	UpdateBranding(ctx context.Context, customerID uint, request *request_dto.UpdateBrandingRequest) (*models.Customer, error)
 }
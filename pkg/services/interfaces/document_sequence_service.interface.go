@@ -0,0 +1,13 @@
+package services_interfaces
+
+import (
+	"context"
+
+	request_dto "github.com/Adebayobenjamin/numerisbook/pkg/dtos/request"
+	"github.com/Adebayobenjamin/numerisbook/pkg/models"
+)
+
+type DocumentSequenceService interface {
+	GetSequence(ctx context.Context, customerID uint, documentType models.DocumentType) (*models.DocumentSequence, error)
+	UpdateSequence(ctx context.Context, customerID uint, documentType models.DocumentType, request *request_dto.UpdateDocumentSequenceRequest) (*models.DocumentSequence, error)
+}
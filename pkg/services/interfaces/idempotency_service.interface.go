@@ -0,0 +1,19 @@
+package services_interfaces
+
+import (
+	"context"
+	"net/http"
+	"time"
+
+	"github.com/Adebayobenjamin/numerisbook/pkg/models"
+)
+
+type IdempotencyService interface {
+	// Begin claims key for the request whose hash is given. A completed key
+	// is returned when the request was already answered and the kept
+	// response should be replayed instead of running it again.
+	Begin(ctx context.Context, key string, requestHash string) (*models.IdempotencyKey, error)
+	Complete(ctx context.Context, record *models.IdempotencyKey, status int, headers http.Header, body []byte) error
+	Release(ctx context.Context, record *models.IdempotencyKey) error
+	PurgeExpired(ctx context.Context, now time.Time) (int64, error)
+}
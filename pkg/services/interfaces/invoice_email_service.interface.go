@@ -0,0 +1,14 @@
+package services_interfaces
+
+import (
+	"context"
+	"time"
+
+	"github.com/Adebayobenjamin/numerisbook/pkg/common/money"
+	"github.com/Adebayobenjamin/numerisbook/pkg/models"
+)
+
+type InvoiceEmailService interface {
+	SendInvoice(ctx context.Context, invoice *models.Invoice) error
+	SendPaymentReceipt(ctx context.Context, invoiceID uint, amount money.Money, paymentDate time.Time) error
+}
@@ -11,13 +11,18 @@ import (
 
 type InvoiceService interface {
 	CreateInvoice(ctx context.Context, customerID uint, request *request_dto.CreateInvoiceRequest) (*models.Invoice, error)
+	PreviewInvoice(ctx context.Context, customerID uint, request *request_dto.CreateInvoiceRequest) (*models.Invoice, error)
+	UpdateInvoice(ctx context.Context, invoice *models.Invoice, version uint, request *request_dto.CreateInvoiceRequest) (*models.Invoice, error)
+	DeleteInvoice(ctx context.Context, invoice *models.Invoice, version uint) error
 	DuplicateInvoice(ctx context.Context, invoice *models.Invoice) (*models.Invoice, error)
-	GetInvoiceByIDandCustomer(ctx context.Context, invoiceID uint, customerID uint) (*models.Invoice, error)
-	ConfirmPayment(ctx context.Context, invoiceID uint, amount float64, date time.Time, isPartial bool) error
-	ValidatePaymentAmount(ctx context.Context, amount float64, invoice *models.Invoice, isPartial bool) error
+	GetInvoiceByIDandCustomer(ctx context.Context, invoiceID uint) (*models.Invoice, error)
+	ConfirmPayment(ctx context.Context, payment *models.Payment) (*models.Invoice, error)
 	GetInvoiceDetails(ctx context.Context, invoiceID uint) (*response_dto.GetInvoiceDetailsResponse, error)
-	GetCustomerInvoices(ctx context.Context, limit int, page int, customerID uint) ([]models.Invoice, error)
-	GetShareableLink(ctx context.Context, invoice *models.Invoice) (string, error)
-	GetInvoiceStatistics(ctx context.Context, customerID uint) (*response_dto.GetInvoiceStatisticsResponse, error)
-	SetInvoiceStatusIfFullyPaid(ctx context.Context, invoice *models.Invoice) error
+	RenderInvoicePDF(ctx context.Context, invoiceID uint) ([]byte, error)
+	GetCustomerInvoices(ctx context.Context, limit int, page int) ([]models.Invoice, error)
+	GetInvoiceStatistics(ctx context.Context) (*response_dto.GetInvoiceStatisticsResponse, error)
+	ChangeInvoiceStatus(ctx context.Context, invoice *models.Invoice, status models.InvoiceStatus) error
+	// MarkOverdueInvoices moves the sent and partially paid invoices that are
+	// past their due date to overdue, returning how many were moved
+	MarkOverdueInvoices(ctx context.Context, now time.Time) (int, error)
 }
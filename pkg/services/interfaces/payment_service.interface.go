@@ -0,0 +1,15 @@
+package services_interfaces
+
+import (
+	"context"
+
+	request_dto "github.com/Adebayobenjamin/numerisbook/pkg/dtos/request"
+	"github.com/Adebayobenjamin/numerisbook/pkg/models"
+)
+
+type PaymentService interface {
+	GetPaymentByIDAndCustomer(ctx context.Context, paymentID uint, customerID uint) (*models.Payment, error)
+	GetCustomerPayments(ctx context.Context, customerID uint, request *request_dto.GetAllPaymentsRequest) ([]models.Payment, error)
+	RefundPayment(ctx context.Context, paymentID uint, request *request_dto.RefundPaymentRequest) (*models.Payment, error)
+	ReversePayment(ctx context.Context, paymentID uint, request *request_dto.ReversePaymentRequest) (*models.Payment, error)
+}
@@ -0,0 +1,23 @@
+package services_interfaces
+
+import (
+	"context"
+	"time"
+
+	request_dto "github.com/Adebayobenjamin/numerisbook/pkg/dtos/request"
+	response_dto "github.com/Adebayobenjamin/numerisbook/pkg/dtos/response"
+	"github.com/Adebayobenjamin/numerisbook/pkg/models"
+)
+
+type QuoteService interface {
+	CreateQuote(ctx context.Context, customerID uint, request *request_dto.CreateQuoteRequest) (*models.Quote, error)
+	GetQuoteByIDAndCustomer(ctx context.Context, quoteID uint, customerID uint) (*models.Quote, error)
+	GetCustomerQuotes(ctx context.Context, customerID uint, request *request_dto.GetAllRequest) ([]models.Quote, error)
+	SendQuote(ctx context.Context, quote *models.Quote) error
+	AcceptQuote(ctx context.Context, quote *models.Quote) error
+	DeclineQuote(ctx context.Context, quote *models.Quote, reason string) error
+	ConvertQuote(ctx context.Context, quote *models.Quote) (*models.Invoice, error)
+	GetSharedQuote(ctx context.Context, token string) (*response_dto.PublicQuoteResponse, error)
+	RespondToSharedQuote(ctx context.Context, token string, accept bool, reason string) (*response_dto.PublicQuoteResponse, error)
+	ExpireQuotes(ctx context.Context, now time.Time) (int64, error)
+}
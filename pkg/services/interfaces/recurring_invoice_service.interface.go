@@ -0,0 +1,17 @@
+package services_interfaces
+
+import (
+	"context"
+	"time"
+
+	request_dto "github.com/Adebayobenjamin/numerisbook/pkg/dtos/request"
+	"github.com/Adebayobenjamin/numerisbook/pkg/models"
+)
+
+type RecurringInvoiceService interface {
+	CreateProfile(ctx context.Context, customerID uint, request *request_dto.CreateRecurringInvoiceRequest) (*models.RecurringInvoiceProfile, error)
+	GetProfileByIDAndCustomer(ctx context.Context, profileID uint, customerID uint) (*models.RecurringInvoiceProfile, error)
+	GetCustomerProfiles(ctx context.Context, limit int, page int, customerID uint) ([]models.RecurringInvoiceProfile, error)
+	ChangeProfileStatus(ctx context.Context, profile *models.RecurringInvoiceProfile, status models.RecurringProfileStatus) error
+	GenerateDueInvoices(ctx context.Context, now time.Time) (int, error)
+}
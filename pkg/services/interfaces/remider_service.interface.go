@@ -2,10 +2,12 @@ package services_interfaces
 
 import (
 	"context"
+	"time"
 
 	"github.com/Adebayobenjamin/numerisbook/pkg/models"
 )
 
 type RemiderService interface {
 	SetInvoiceReminders(ctx context.Context, invoice *models.Invoice, customerID uint, shedules map[models.InvoiceReminderSchedule]bool) error
+	DispatchDueReminders(ctx context.Context, now time.Time) (int, error)
 }
@@ -0,0 +1,15 @@
+package services_interfaces
+
+import (
+	"context"
+
+	response_dto "github.com/Adebayobenjamin/numerisbook/pkg/dtos/response"
+	"github.com/Adebayobenjamin/numerisbook/pkg/models"
+)
+
+type ShareLinkService interface {
+	GetShareLink(ctx context.Context, invoice *models.Invoice) (*response_dto.ShareLinkResponse, error)
+	RegenerateShareLink(ctx context.Context, invoice *models.Invoice) (*response_dto.ShareLinkResponse, error)
+	RevokeShareLinks(ctx context.Context, invoice *models.Invoice) error
+	GetSharedInvoice(ctx context.Context, token string) (*response_dto.PublicInvoiceResponse, error)
+}
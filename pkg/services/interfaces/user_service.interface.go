@@ -0,0 +1,15 @@
+package services_interfaces
+
+import (
+	"context"
+
+	request_dto "github.com/Adebayobenjamin/numerisbook/pkg/dtos/request"
+	"github.com/Adebayobenjamin/numerisbook/pkg/models"
+)
+
+type UserService interface {
+	GetUsers(ctx context.Context, customerID uint) ([]models.User, error)
+	AddUser(ctx context.Context, customerID uint, request *request_dto.AddUserRequest) (*models.User, error)
+	ChangeRole(ctx context.Context, userID uint, customerID uint, role models.UserRole) (*models.User, error)
+	RemoveUser(ctx context.Context, userID uint, customerID uint) error
+}
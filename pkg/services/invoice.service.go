@@ -2,154 +2,617 @@ package services
 
 import (
 	"context"
+	"errors"
 	"fmt"
-	"os"
+	"strings"
 	"time"
 
+	"github.com/Adebayobenjamin/numerisbook/pkg/auth"
+	"github.com/Adebayobenjamin/numerisbook/pkg/common/exceptions"
+	"github.com/Adebayobenjamin/numerisbook/pkg/common/money"
 	request_dto "github.com/Adebayobenjamin/numerisbook/pkg/dtos/request"
 	response_dto "github.com/Adebayobenjamin/numerisbook/pkg/dtos/response"
 	"github.com/Adebayobenjamin/numerisbook/pkg/helper"
 	"github.com/Adebayobenjamin/numerisbook/pkg/models"
+	"github.com/Adebayobenjamin/numerisbook/pkg/pdf"
 	repositories_interfaces "github.com/Adebayobenjamin/numerisbook/pkg/repositories/interfaces"
 	services_interfaces "github.com/Adebayobenjamin/numerisbook/pkg/services/interfaces"
+	"github.com/Adebayobenjamin/numerisbook/pkg/tenant"
 )
 
+// pastDueInvoicesBatchSize caps how many invoices one overdue run moves
+const pastDueInvoicesBatchSize = 100
+
 type invoiceService struct {
-	invoiceRepository repositories_interfaces.InvoiceRepository
-	paymentRepository repositories_interfaces.PaymentRepository
+	invoiceRepository         repositories_interfaces.InvoiceRepository
+	paymentRepository         repositories_interfaces.PaymentRepository
+	clientRepository          repositories_interfaces.ClientRepository
+	customerRepository        repositories_interfaces.CustomerRepository
+	businessProfileRepository repositories_interfaces.BusinessProfileRepository
+	bankAccountRepository     repositories_interfaces.BankAccountRepository
+	catalogItemRepository     repositories_interfaces.CatalogItemRepository
 }
 
-// SetInvoiceStatusIfFullyPaid implements services_interfaces.InvoiceService.
-func (i *invoiceService) SetInvoiceStatusIfFullyPaid(ctx context.Context, invoice *models.Invoice) error {
-	totalPayments, err := i.paymentRepository.GetTotalInvoicePayments(ctx, invoice.ID)
-	if err != nil {
-		return fmt.Errorf("failed to get total invoice payments: %w", err)
+// ChangeInvoiceStatus implements services_interfaces.InvoiceService.
+func (i *invoiceService) ChangeInvoiceStatus(ctx context.Context, invoice *models.Invoice, status models.InvoiceStatus) error {
+	if err := auth.Authorize(ctx, auth.PermissionInvoicesWrite); err != nil {
+		return err
 	}
 
-	// Check if total payments equal the total amount due
-	if totalPayments >= invoice.TotalAmountDue {
-		invoice.Status = models.InvoiceStatusPaid
-		err = i.invoiceRepository.UpdateInvoiceStatus(ctx, invoice.ID, models.InvoiceStatusPaid)
-		if err != nil {
-			return fmt.Errorf("failed to update invoice status: %w", err)
-		}
+	from := invoice.Status
+	if err := validateInvoiceTransition(from, status); err != nil {
+		return err
+	}
+
+	// every transition is recorded so the lifecycle of an invoice can be replayed from the audit trail
+	err := i.invoiceRepository.UpdateInvoiceStatus(ctx, invoice.ID, from, status, []models.AuditTrail{{
+		EventType: models.EventTypeInvoiceStatusChanged,
+		LogLevel:  models.LogLevelInfo,
+		Message:   fmt.Sprintf("Invoice %s moved from %s to %s", invoice.InvoiceNumber, from, status),
+	}})
+	if err != nil {
+		return fmt.Errorf("failed to update invoice status: %w", err)
 	}
+	invoice.Status = status
 
 	return nil
 }
 
 // ConfirmPayment implements services_interfaces.InvoiceService.
-func (i *invoiceService) ConfirmPayment(ctx context.Context, invoiceID uint, amount float64, date time.Time, isPartial bool) error {
-	payment := &models.Payment{
-		InvoiceID: invoiceID,
-		Amount:    amount,
-		IsPartial: isPartial,
-		Date:      date,
+func (i *invoiceService) ConfirmPayment(ctx context.Context, payment *models.Payment) (*models.Invoice, error) {
+	if err := auth.Authorize(ctx, auth.PermissionPaymentsConfirm); err != nil {
+		return nil, err
 	}
 
-	err := i.paymentRepository.CreatePayment(ctx, payment)
-	if err != nil {
-		return fmt.Errorf("failed to create payment: %w", err)
+	payment.Kind = models.PaymentKindPayment
+	if payment.Method == "" {
+		payment.Method = models.PaymentMethodOther
 	}
 
-	return nil
+	// the payment is checked and recorded with the invoice locked, so two
+	// payments confirmed at the same time cannot both fit what is left to pay
+	received := payment.Amount
+	return i.paymentRepository.RecordPayment(ctx, payment, func(invoice *models.Invoice, paid money.Money) ([]models.AuditTrail, error) {
+		amount, overpayment, err := splitOverpayment(invoice, paid, received)
+		if err != nil {
+			return nil, err
+		}
+
+		status, err := settlePayment(invoice, paid, amount, payment.IsPartial)
+		if err != nil {
+			return nil, err
+		}
+
+		auditTrails := []models.AuditTrail{{
+			EventType: models.EventTypePaymentConfirmed,
+			LogLevel:  models.LogLevelInfo,
+			Message:   fmt.Sprintf("Confirmed Payment of %s by %s for Invoice %s", received, payment.Method, invoice.InvoiceNumber),
+		}}
+
+		payment.Amount, payment.Overpayment = amount, nil
+		if overpayment.IsPositive() {
+			payment.Overpayment = &overpayment
+			auditTrails = append(auditTrails, models.AuditTrail{
+				EventType: models.EventTypeClientCredited,
+				LogLevel:  models.LogLevelInfo,
+				Message:   fmt.Sprintf("Credited %s overpaid on Invoice %s to Client %s", overpayment, invoice.InvoiceNumber, invoice.InvoiceClient.Name),
+			})
+		}
+		if status != invoice.Status {
+			auditTrails = append(auditTrails, models.AuditTrail{
+				EventType: models.EventTypeInvoiceStatusChanged,
+				LogLevel:  models.LogLevelInfo,
+				Message:   fmt.Sprintf("Invoice %s moved from %s to %s", invoice.InvoiceNumber, invoice.Status, status),
+			})
+		}
+
+		invoice.Status = status
+		invoice.IsFullyPaid = status == models.InvoiceStatusPaid
+		return auditTrails, nil
+	})
 }
 
 // CreateInvoice implements services_interfaces.InvoiceService.
 func (i *invoiceService) CreateInvoice(ctx context.Context, customerID uint, request *request_dto.CreateInvoiceRequest) (*models.Invoice, error) {
-	var invoiceToBeCreated models.Invoice
+	if err := auth.Authorize(ctx, auth.PermissionInvoicesWrite); err != nil {
+		return nil, err
+	}
 
-	err := helper.JSONUnmarshalToType(request, &invoiceToBeCreated)
+	invoiceToBeCreated, err := i.prepareInvoice(ctx, customerID, request)
 	if err != nil {
-		return nil, fmt.Errorf("failed to unmarshal invoice: %w", err)
+		return nil, err
 	}
 
-	if invoiceToBeCreated.DueDate.Before(time.Now()) {
-		return nil, fmt.Errorf("due date cannot be in the past")
+	invoice, err := i.invoiceRepository.CreateInvoiceWithItems(ctx, invoiceToBeCreated)
+	if err != nil {
+		return nil, fmt.Errorf("failed to create invoice: %w", err)
 	}
 
-	invoiceToBeCreated.InvoiceNumber = helper.GenerateInvoiceNumber()
-	invoiceToBeCreated.CustomerID = customerID
+	return invoice, nil
+}
 
-	for _, item := range invoiceToBeCreated.Items {
-		item.TotalPrice = item.UnitPrice * float64(item.Quantity)
-		invoiceToBeCreated.TotalAmountDue += item.TotalPrice
-		invoiceToBeCreated.Subtotal += item.TotalPrice
+// PreviewInvoice implements services_interfaces.InvoiceService.
+func (i *invoiceService) PreviewInvoice(ctx context.Context, customerID uint, request *request_dto.CreateInvoiceRequest) (*models.Invoice, error) {
+	if err := auth.Authorize(ctx, auth.PermissionInvoicesWrite); err != nil {
+		return nil, err
 	}
 
-	invoiceToBeCreated.TotalAmountDue -= invoiceToBeCreated.Discount
+	// priced and resolved exactly like an invoice being created, but not stored
+	return i.prepareInvoice(ctx, customerID, request)
+}
+
+// UpdateInvoice implements services_interfaces.InvoiceService.
+func (i *invoiceService) UpdateInvoice(ctx context.Context, invoice *models.Invoice, version uint, request *request_dto.CreateInvoiceRequest) (*models.Invoice, error) {
+	if err := auth.Authorize(ctx, auth.PermissionInvoicesWrite); err != nil {
+		return nil, err
+	}
 
-	invoice, err := i.invoiceRepository.CreateInvoiceWithItems(ctx, &invoiceToBeCreated)
+	if err := ensureDraftAtVersion(invoice, version); err != nil {
+		return nil, err
+	}
+
+	updated, err := i.prepareInvoice(ctx, invoice.CustomerID, request)
 	if err != nil {
-		return nil, fmt.Errorf("failed to create invoice: %w", err)
+		return nil, err
 	}
+	updated.ID = invoice.ID
+	updated.InvoiceNumber = invoice.InvoiceNumber
+	updated.Version = version
 
-	return invoice, nil
+	return i.invoiceRepository.UpdateDraftInvoice(ctx, updated, []models.AuditTrail{{
+		EventType: models.EventTypeInvoiceUpdated,
+		LogLevel:  models.LogLevelInfo,
+		Message:   fmt.Sprintf("Updated Invoice %s", invoice.InvoiceNumber),
+	}})
+}
+
+// DeleteInvoice implements services_interfaces.InvoiceService.
+func (i *invoiceService) DeleteInvoice(ctx context.Context, invoice *models.Invoice, version uint) error {
+	if err := auth.Authorize(ctx, auth.PermissionInvoicesWrite); err != nil {
+		return err
+	}
+
+	if err := ensureDraftAtVersion(invoice, version); err != nil {
+		return err
+	}
+
+	return i.invoiceRepository.DeleteDraftInvoice(ctx, invoice, version, []models.AuditTrail{{
+		EventType: models.EventTypeInvoiceDeleted,
+		LogLevel:  models.LogLevelInfo,
+		Message:   fmt.Sprintf("Deleted Invoice %s", invoice.InvoiceNumber),
+	}})
 }
 
 // DuplicateInvoice implements services_interfaces.InvoiceService.
 func (i *invoiceService) DuplicateInvoice(ctx context.Context, invoice *models.Invoice) (*models.Invoice, error) {
-	return i.invoiceRepository.DuplicateInvoice(ctx, invoice)
+	if err := auth.Authorize(ctx, auth.PermissionInvoicesWrite); err != nil {
+		return nil, err
+	}
+
+	// the copy is a new invoice issued today, numbered in this year's sequence,
+	// and keeps the payment terms of the one it was copied from
+	issueDate := time.Now().UTC()
+	dueDate := issueDate.Add(invoice.DueDate.Sub(invoice.IssueDate))
+
+	return i.invoiceRepository.DuplicateInvoice(ctx, invoice, issueDate, dueDate)
 }
 
 // GetCustomerInvoices implements services_interfaces.InvoiceService.
-func (i *invoiceService) GetCustomerInvoices(ctx context.Context, limit int, page int, customerID uint) ([]models.Invoice, error) {
+func (i *invoiceService) GetCustomerInvoices(ctx context.Context, limit int, page int) ([]models.Invoice, error) {
 	offset := helper.GetOffset(page, limit)
-	return i.invoiceRepository.GetAllCustomerInvoices(ctx, customerID, limit, offset)
+	return i.invoiceRepository.GetAllCustomerInvoices(ctx, limit, offset)
 }
 
 // GetInvoiceByIDandCustomer implements services_interfaces.InvoiceService.
-func (i *invoiceService) GetInvoiceByIDandCustomer(ctx context.Context, invoiceID uint, customerID uint) (*models.Invoice, error) {
-	return i.invoiceRepository.GetByIDAndCutomerID(ctx, invoiceID, customerID)
+func (i *invoiceService) GetInvoiceByIDandCustomer(ctx context.Context, invoiceID uint) (*models.Invoice, error) {
+	return i.invoiceRepository.GetByIDAndCutomerID(ctx, invoiceID)
 }
 
 // GetInvoiceDetails implements services_interfaces.InvoiceService.
 func (i *invoiceService) GetInvoiceDetails(ctx context.Context, invoiceID uint) (*response_dto.GetInvoiceDetailsResponse, error) {
-	return i.invoiceRepository.GetDetails(ctx, invoiceID)
+	details, err := i.invoiceRepository.GetDetails(ctx, invoiceID)
+	if err != nil {
+		return nil, err
+	}
+
+	details.TaxSummary, err = summarizeTaxes(details.Items)
+	if err != nil {
+		return nil, fmt.Errorf("failed to summarize invoice taxes: %w", err)
+	}
+
+	// the balance is what is still owed once credit notes and payments are taken off
+	if details.BalanceDue, err = details.TotalAmountDue.Sub(details.CreditedTotal); err != nil {
+		return nil, fmt.Errorf("failed to work out the balance due: %w", err)
+	}
+	for _, payment := range details.Payments {
+		if details.BalanceDue, err = details.BalanceDue.Sub(payment.Amount); err != nil {
+			return nil, fmt.Errorf("failed to work out the balance due: %w", err)
+		}
+	}
+
+	return details, nil
+}
+
+// RenderInvoicePDF implements services_interfaces.InvoiceService.
+func (i *invoiceService) RenderInvoicePDF(ctx context.Context, invoiceID uint) ([]byte, error) {
+	details, err := i.GetInvoiceDetails(ctx, invoiceID)
+	if err != nil {
+		return nil, err
+	}
+
+	document, err := pdf.RenderInvoice(details)
+	if err != nil {
+		return nil, fmt.Errorf("failed to render invoice pdf: %w", err)
+	}
+
+	return document, nil
 }
 
 // GetInvoiceStatistics implements services_interfaces.InvoiceService.
-func (i *invoiceService) GetInvoiceStatistics(ctx context.Context, customerID uint) (*response_dto.GetInvoiceStatisticsResponse, error) {
-	return i.invoiceRepository.GetStatistics(ctx, customerID)
+func (i *invoiceService) GetInvoiceStatistics(ctx context.Context) (*response_dto.GetInvoiceStatisticsResponse, error) {
+	return i.invoiceRepository.GetStatistics(ctx)
+}
+
+// MarkOverdueInvoices implements services_interfaces.InvoiceService.
+func (i *invoiceService) MarkOverdueInvoices(ctx context.Context, now time.Time) (int, error) {
+	invoices, err := i.invoiceRepository.GetPastDueInvoices(ctx, now, pastDueInvoicesBatchSize)
+	if err != nil {
+		return 0, err
+	}
+
+	marked := 0
+	var errs []error
+	for idx := range invoices {
+		invoice := &invoices[idx]
+
+		// past due invoices span customers, each one is moved in its own
+		// customer's scope by the system on their behalf
+		invoiceCtx := auth.WithSystemPrincipal(tenant.WithCustomerID(ctx, invoice.CustomerID), invoice.CustomerID)
+		if err := i.ChangeInvoiceStatus(invoiceCtx, invoice, models.InvoiceStatusOverdue); err != nil {
+			// one failing invoice, say one paid in the meantime, must not hold up the others
+			errs = append(errs, fmt.Errorf("invoice %d: %w", invoice.ID, err))
+			continue
+		}
+		marked++
+	}
+
+	return marked, errors.Join(errs...)
+}
+
+// splitOverpayment splits what a payment brought in into the part that pays
+// what is left on the invoice and the part paid over it, which is kept as
+// credit for the invoice's client. Invoices without a client are not split,
+// so an overpayment on them is refused by settlePayment.
+func splitOverpayment(invoice *models.Invoice, paid money.Money, received money.Money) (money.Money, money.Money, error) {
+	none := money.Zero(received.Currency)
+	if invoice.ClientID == nil {
+		return received, none, nil
+	}
+
+	payable, err := invoice.AmountPayable()
+	if err != nil {
+		return money.Money{}, money.Money{}, fmt.Errorf("failed to work out the amount payable: %w", err)
+	}
+
+	left, err := payable.Sub(paid)
+	if err != nil {
+		return money.Money{}, money.Money{}, fmt.Errorf("payment currency does not match invoice: %w", err)
+	}
+
+	comparison, err := received.Cmp(left)
+	if err != nil {
+		return money.Money{}, money.Money{}, fmt.Errorf("payment currency does not match invoice: %w", err)
+	}
+	// nothing left to pay is refused as a payment on a paid invoice, not credited
+	if comparison <= 0 || !left.IsPositive() {
+		return received, none, nil
+	}
+
+	overpayment, err := received.Sub(left)
+	if err != nil {
+		return money.Money{}, money.Money{}, fmt.Errorf("payment currency does not match invoice: %w", err)
+	}
+	return left, overpayment, nil
 }
 
-// GetShareableLink implements services_interfaces.InvoiceService.
-func (i *invoiceService) GetShareableLink(ctx context.Context, invoice *models.Invoice) (string, error) {
-	link := fmt.Sprintf("%s/invoice/%d", os.Getenv("FRONTEND_URL"), invoice.ID)
+// settlePayment checks that a payment fits what is left to pay on the
+// invoice, given what was paid on it before, and returns the status the
+// invoice moves to once the payment is taken
+func settlePayment(invoice *models.Invoice, paid money.Money, amount money.Money, isPartial bool) (models.InvoiceStatus, error) {
+	// payments can only be taken on invoices that could still move to paid
+	if err := validateInvoiceTransition(invoice.Status, models.InvoiceStatusPaid); err != nil {
+		return "", err
+	}
+
+	if !amount.IsPositive() {
+		return "", fmt.Errorf("payment amount must be greater than zero")
+	}
+
+	totalAfterPayment, err := paid.Add(amount)
+	if err != nil {
+		return "", fmt.Errorf("payment currency does not match invoice: %w", err)
+	}
+
+	payable, err := invoice.AmountPayable()
+	if err != nil {
+		return "", fmt.Errorf("failed to work out the amount payable: %w", err)
+	}
 
-	err := i.invoiceRepository.UpdateShareableLink(ctx, invoice.ID, link)
+	comparison, err := totalAfterPayment.Cmp(payable)
 	if err != nil {
-		return "", fmt.Errorf("failed to update shareable link: %w", err)
+		return "", fmt.Errorf("payment currency does not match invoice: %w", err)
+	}
+
+	if comparison > 0 {
+		return "", fmt.Errorf("payment amount exceeds invoice total amount")
 	}
 
-	return link, nil
+	if !isPartial && comparison < 0 {
+		return "", fmt.Errorf("payment amount is less than invoice total amount")
+	}
+
+	if comparison == 0 {
+		return models.InvoiceStatusPaid, nil
+	}
+	return models.InvoiceStatusPartiallyPaid, nil
 }
 
-// ValidatePaymentAmount implements services_interfaces.InvoiceService.
-func (i *invoiceService) ValidatePaymentAmount(ctx context.Context, amount float64, invoice *models.Invoice, isPartial bool) error {
-	totalPayments, err := i.paymentRepository.GetTotalInvoicePayments(ctx, invoice.ID)
+// prepareInvoice builds the invoice a request describes, with its client,
+// issuer and payment details resolved and copied onto it
+func (i *invoiceService) prepareInvoice(ctx context.Context, customerID uint, request *request_dto.CreateInvoiceRequest) (*models.Invoice, error) {
+	request, err := i.applyCatalogItems(ctx, customerID, request)
+	if err != nil {
+		return nil, err
+	}
 
+	invoice, err := buildInvoice(customerID, request)
 	if err != nil {
-		return fmt.Errorf("failed to get total invoice payments: %w", err)
+		return nil, err
 	}
 
-	if totalPayments+amount > invoice.TotalAmountDue {
-		return fmt.Errorf("payment amount exceeds invoice total amount")
+	client, err := i.resolveClient(ctx, customerID, request)
+	if err != nil {
+		return nil, err
 	}
+	// the invoice keeps its own copy of the client's details, so editing
+	// the client later does not rewrite invoices already issued
+	invoice.InvoiceClient = client.Snapshot()
 
-	if !isPartial && totalPayments+amount < invoice.TotalAmountDue {
-		return fmt.Errorf("payment amount is less than invoice total amount")
+	// the issuer and payment details are copied the same way
+	if invoice.InvoiceIssuer, err = i.resolveIssuer(ctx, customerID, request); err != nil {
+		return nil, err
 	}
+	if invoice.PaymentInfo, err = i.resolvePaymentInfo(ctx, customerID, request); err != nil {
+		return nil, err
+	}
+
+	return invoice, nil
+}
 
+// ensureDraftAtVersion allows a change only to a draft still at the version
+// the caller last read
+func ensureDraftAtVersion(invoice *models.Invoice, version uint) error {
+	if invoice.Status != models.InvoiceStatusDraft {
+		return fmt.Errorf("invoice %s is %s and %w", invoice.InvoiceNumber, invoice.Status, exceptions.ErrLocked)
+	}
+	if invoice.Version != version {
+		return fmt.Errorf("invoice %w", exceptions.ErrVersionMismatch)
+	}
 	return nil
 }
 
+// buildInvoice turns a create request into a draft invoice with every line,
+// discount and tax worked out, ready to be stored
+func buildInvoice(customerID uint, request *request_dto.CreateInvoiceRequest) (*models.Invoice, error) {
+	// discounts are requested as a type and value but stored as separate
+	// columns, so they are read from the request once the currency is known
+	invoice := request.Invoice()
+
+	if invoice.DueDate.Before(time.Now()) {
+		return nil, fmt.Errorf("due date cannot be in the past")
+	}
+
+	// amounts arrive without a currency, tag them before doing any arithmetic
+	_, err := money.LookupCurrency(invoice.BillingCurrency)
+	if err != nil {
+		return nil, fmt.Errorf("invalid billing currency: %w", err)
+	}
+	if err = invoice.AssignCurrency(); err != nil {
+		return nil, err
+	}
+
+	currency := invoice.BillingCurrency
+	invoice.DiscountType, invoice.DiscountRate, invoice.Discount, err = parseRequestedDiscount(request.Discount, currency)
+	if err != nil {
+		return nil, err
+	}
+	for idx := range invoice.Items {
+		item := &invoice.Items[idx]
+		item.DiscountType, item.DiscountRate, item.DiscountAmount, err = parseRequestedDiscount(request.Items[idx].Discount, currency)
+		if err != nil {
+			return nil, fmt.Errorf("item %d: %w", idx+1, err)
+		}
+	}
+
+	invoice.CustomerID = customerID
+	// new invoices always start as drafts and have to be sent explicitly
+	invoice.Status = models.InvoiceStatusDraft
+
+	if err = calculateInvoiceTotals(invoice); err != nil {
+		return nil, fmt.Errorf("failed to calculate invoice totals: %w", err)
+	}
+
+	if request.ClientID == nil && request.InlineClient() == nil {
+		return nil, fmt.Errorf("either a client or the billed party's details are required")
+	}
+	if request.ClientID != nil && request.InlineClient() != nil {
+		return nil, fmt.Errorf("give either a client or the billed party's details, not both")
+	}
+	if request.BankAccountID != nil && request.PaymentInfo != nil {
+		return nil, fmt.Errorf("give either a bank account or payment details, not both")
+	}
+
+	return invoice, nil
+}
+
+// resolveClient finds the directory entry an invoice is billed to. Details
+// given inline are linked to the client with the same email, or saved as a
+// new client when there is none.
+func (i *invoiceService) resolveClient(ctx context.Context, customerID uint, request *request_dto.CreateInvoiceRequest) (*models.Client, error) {
+	if request.ClientID != nil {
+		client, err := i.clientRepository.GetByIDAndCustomerID(ctx, *request.ClientID, customerID)
+		if errors.Is(err, exceptions.ErrNotFound) {
+			return nil, fmt.Errorf("client %d does not exist or has been deleted", *request.ClientID)
+		}
+		return client, err
+	}
+
+	details := request.InlineClient()
+	client := &models.Client{CustomerID: customerID}
+	if err := applyClientDetails(client, details.Name, details.Email, details.Phone, details.Address); err != nil {
+		return nil, err
+	}
+
+	existing, err := i.clientRepository.GetByEmail(ctx, customerID, client.Email)
+	switch {
+	case err == nil:
+		// the invoice shows the details as they were typed, but is still
+		// filed under the client they belong to
+		client.ID = existing.ID
+		return client, nil
+	case errors.Is(err, exceptions.ErrNotFound):
+		return i.clientRepository.Create(ctx, client)
+	default:
+		return nil, err
+	}
+}
+
+// resolveIssuer picks the details an invoice is issued under: the business
+// profile asked for, else the default profile, else the customer's own details
+func (i *invoiceService) resolveIssuer(ctx context.Context, customerID uint, request *request_dto.CreateInvoiceRequest) (models.InvoiceIssuer, error) {
+	if request.BusinessProfileID != nil {
+		profile, err := i.businessProfileRepository.GetByIDAndCustomerID(ctx, *request.BusinessProfileID, customerID)
+		if errors.Is(err, exceptions.ErrNotFound) {
+			return models.InvoiceIssuer{}, fmt.Errorf("business profile %d does not exist or has been deleted", *request.BusinessProfileID)
+		}
+		if err != nil {
+			return models.InvoiceIssuer{}, err
+		}
+		return profile.Snapshot(), nil
+	}
+
+	profile, err := i.businessProfileRepository.GetDefault(ctx, customerID)
+	if err == nil {
+		return profile.Snapshot(), nil
+	}
+	if !errors.Is(err, exceptions.ErrNotFound) {
+		return models.InvoiceIssuer{}, err
+	}
+
+	customer, err := i.customerRepository.GetCustomerByID(ctx, customerID)
+	if err != nil {
+		return models.InvoiceIssuer{}, fmt.Errorf("failed to get customer: %w", err)
+	}
+	return customer.IssuerSnapshot(), nil
+}
+
+// resolvePaymentInfo picks the payment details printed on an invoice: the
+// bank account asked for, else the details given inline, else the default
+// bank account
+func (i *invoiceService) resolvePaymentInfo(ctx context.Context, customerID uint, request *request_dto.CreateInvoiceRequest) (*models.PaymentInfo, error) {
+	if request.BankAccountID != nil {
+		account, err := i.bankAccountRepository.GetByIDAndCustomerID(ctx, *request.BankAccountID, customerID)
+		if errors.Is(err, exceptions.ErrNotFound) {
+			return nil, fmt.Errorf("bank account %d does not exist or has been deleted", *request.BankAccountID)
+		}
+		if err != nil {
+			return nil, err
+		}
+		return account.Snapshot(), nil
+	}
+
+	if info := request.PaymentInfo; info != nil {
+		return &models.PaymentInfo{
+			BankName:      info.BankName,
+			AccountNumber: info.AccountNumber,
+			AccountName:   info.AccountName,
+			AchRoutingNo:  info.AchRoutingNo,
+			BankAddress:   info.BankAddress,
+		}, nil
+	}
+
+	account, err := i.bankAccountRepository.GetDefault(ctx, customerID)
+	if errors.Is(err, exceptions.ErrNotFound) {
+		return nil, fmt.Errorf("payment details are required when there is no default bank account")
+	}
+	if err != nil {
+		return nil, err
+	}
+	return account.Snapshot(), nil
+}
+
+// applyCatalogItems fills in the lines that reference a catalog item from
+// the catalog. The request is copied rather than changed in place, because
+// recurring profiles hand in the same template on every run.
+func (i *invoiceService) applyCatalogItems(ctx context.Context, customerID uint, request *request_dto.CreateInvoiceRequest) (*request_dto.CreateInvoiceRequest, error) {
+	filled := *request
+	filled.Items = make([]request_dto.InvoiceItem, len(request.Items))
+	copy(filled.Items, request.Items)
+
+	for idx := range filled.Items {
+		line := &filled.Items[idx]
+		if line.CatalogItemID == nil {
+			continue
+		}
+
+		catalogItem, err := i.catalogItemRepository.GetByIDAndCustomerID(ctx, *line.CatalogItemID, customerID)
+		if errors.Is(err, exceptions.ErrNotFound) {
+			return nil, fmt.Errorf("item %d: catalog item %d does not exist or has been deleted", idx+1, *line.CatalogItemID)
+		}
+		if err != nil {
+			return nil, err
+		}
+
+		if line.Description == "" {
+			line.Description = catalogItem.Name
+		}
+		if line.Unit == "" {
+			line.Unit = catalogItem.Unit
+		}
+		if line.UnitPrice.IsZero() {
+			if !strings.EqualFold(catalogItem.Currency, request.BillingCurrency) {
+				return nil, fmt.Errorf("item %d: catalog item %q is priced in %s, give a unit price to bill it in %s", idx+1, catalogItem.SKU, catalogItem.Currency, request.BillingCurrency)
+			}
+			line.UnitPrice = catalogItem.UnitPrice
+		}
+		if len(line.Taxes) == 0 && catalogItem.TaxName != "" {
+			line.Taxes = []request_dto.InvoiceItemTax{{
+				Name:        catalogItem.TaxName,
+				Rate:        catalogItem.TaxRate,
+				IsInclusive: catalogItem.TaxIsInclusive,
+			}}
+		}
+	}
+
+	return &filled, nil
+}
+
 func NewInvoiceService(
 	invoiceRepository repositories_interfaces.InvoiceRepository,
 	paymentRepository repositories_interfaces.PaymentRepository,
+	clientRepository repositories_interfaces.ClientRepository,
+	customerRepository repositories_interfaces.CustomerRepository,
+	businessProfileRepository repositories_interfaces.BusinessProfileRepository,
+	bankAccountRepository repositories_interfaces.BankAccountRepository,
+	catalogItemRepository repositories_interfaces.CatalogItemRepository,
 ) services_interfaces.InvoiceService {
 	return &invoiceService{
-		invoiceRepository: invoiceRepository,
-		paymentRepository: paymentRepository,
+		invoiceRepository:         invoiceRepository,
+		paymentRepository:         paymentRepository,
+		clientRepository:          clientRepository,
+		customerRepository:        customerRepository,
+		businessProfileRepository: businessProfileRepository,
+		bankAccountRepository:     bankAccountRepository,
+		catalogItemRepository:     catalogItemRepository,
 	}
 }
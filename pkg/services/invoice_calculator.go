@@ -0,0 +1,285 @@
+package services
+
+import (
+	"fmt"
+
+	"github.com/Adebayobenjamin/numerisbook/pkg/common/money"
+	request_dto "github.com/Adebayobenjamin/numerisbook/pkg/dtos/request"
+	"github.com/Adebayobenjamin/numerisbook/pkg/models"
+)
+
+// calculateInvoiceTotals works out every line of the invoice and rolls the
+// lines up into the subtotal, discount total, tax total and amount due.
+// Amounts must already be tagged with the billing currency.
+//
+// Discounts always come off before tax: line discounts first, then the
+// invoice discount, which is spread over the lines in proportion to what is
+// left of each of them. Taxes are then worked out on the discounted lines, so
+// the subtotal is the undiscounted sum of the lines and the amount due is the
+// sum of the discounted, taxed lines.
+func calculateInvoiceTotals(invoice *models.Invoice) error {
+	var err error
+	currency := invoice.BillingCurrency
+
+	invoice.Subtotal = money.Zero(currency)
+	invoice.DiscountTotal = money.Zero(currency)
+	invoice.TaxTotal = money.Zero(currency)
+	invoice.TotalAmountDue = money.Zero(currency)
+
+	discountedSubtotal := money.Zero(currency)
+	discountedLines := make([]money.Money, len(invoice.Items))
+	weights := make([]int64, len(invoice.Items))
+
+	for idx := range invoice.Items {
+		item := &invoice.Items[idx]
+		if item.Quantity <= 0 {
+			return fmt.Errorf("item %d: quantity must be greater than zero", idx+1)
+		}
+		if item.UnitPrice.IsNegative() {
+			return fmt.Errorf("item %d: unit price cannot be negative", idx+1)
+		}
+
+		lineAmount := item.UnitPrice.Mul(int64(item.Quantity))
+		if invoice.Subtotal, err = invoice.Subtotal.Add(lineAmount); err != nil {
+			return err
+		}
+
+		item.DiscountAmount, err = calculateDiscount(item.DiscountType, item.DiscountRate, item.DiscountAmount, lineAmount)
+		if err != nil {
+			return fmt.Errorf("item %d: %w", idx+1, err)
+		}
+		if discountedLines[idx], err = lineAmount.Sub(item.DiscountAmount); err != nil {
+			return err
+		}
+		if discountedSubtotal, err = discountedSubtotal.Add(discountedLines[idx]); err != nil {
+			return err
+		}
+		if invoice.DiscountTotal, err = invoice.DiscountTotal.Add(item.DiscountAmount); err != nil {
+			return err
+		}
+		weights[idx] = discountedLines[idx].Amount
+	}
+
+	invoice.Discount, err = calculateDiscount(invoice.DiscountType, invoice.DiscountRate, invoice.Discount, discountedSubtotal)
+	if err != nil {
+		return err
+	}
+	if invoice.DiscountTotal, err = invoice.DiscountTotal.Add(invoice.Discount); err != nil {
+		return err
+	}
+
+	shares := invoice.Discount.Allocate(weights)
+	for idx := range invoice.Items {
+		item := &invoice.Items[idx]
+		item.InvoiceDiscountAmount = shares[idx]
+
+		taxableLine, err := discountedLines[idx].Sub(item.InvoiceDiscountAmount)
+		if err != nil {
+			return err
+		}
+		if err = calculateLineTaxes(item, taxableLine); err != nil {
+			return fmt.Errorf("item %d: %w", idx+1, err)
+		}
+
+		if invoice.TaxTotal, err = invoice.TaxTotal.Add(item.TaxTotal); err != nil {
+			return err
+		}
+		if invoice.TotalAmountDue, err = invoice.TotalAmountDue.Add(item.TotalPrice); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// calculateDiscount returns the amount a discount takes off base. Fixed
+// discounts are given as an amount and percentage discounts as a rate; either
+// way the discount cannot be negative or larger than base.
+func calculateDiscount(discountType models.DiscountType, rate money.Rate, amount money.Money, base money.Money) (money.Money, error) {
+	switch discountType {
+	case models.DiscountTypePercentage:
+		if rate < 0 || rate > money.HundredPercent {
+			return money.Money{}, fmt.Errorf("discount must be between 0 and 100 percent")
+		}
+		return base.ApplyRate(rate), nil
+	case models.DiscountTypeFixed, "":
+		if amount.IsNegative() {
+			return money.Money{}, fmt.Errorf("discount cannot be negative")
+		}
+		comparison, err := amount.Cmp(base)
+		if err != nil {
+			return money.Money{}, err
+		}
+		if comparison > 0 {
+			return money.Money{}, fmt.Errorf("discount of %s exceeds the %s it applies to", amount, base)
+		}
+		return amount, nil
+	default:
+		return money.Money{}, fmt.Errorf("unknown discount type %q", discountType)
+	}
+}
+
+// parseRequestedDiscount reads a discount from a request in its stored form:
+// the type, the rate for percentage discounts and the amount for fixed ones.
+// No discount is a fixed discount of zero. A fixed amount with more decimals
+// than the currency has is refused rather than rounded.
+func parseRequestedDiscount(discount *request_dto.Discount, currency string) (models.DiscountType, money.Rate, money.Money, error) {
+	if discount == nil {
+		return models.DiscountTypeFixed, 0, money.Zero(currency), nil
+	}
+
+	switch discount.Type {
+	case models.DiscountTypePercentage:
+		rate, err := money.ParseRate(discount.Value.String())
+		if err != nil {
+			return "", 0, money.Money{}, fmt.Errorf("invalid discount: %w", err)
+		}
+		return discount.Type, rate, money.Zero(currency), nil
+	case models.DiscountTypeFixed:
+		amount, err := money.ParseExact(discount.Value.String(), currency)
+		if err != nil {
+			return "", 0, money.Money{}, fmt.Errorf("invalid discount: %w", err)
+		}
+		return discount.Type, 0, amount, nil
+	default:
+		return "", 0, money.Money{}, fmt.Errorf("unknown discount type %q", discount.Type)
+	}
+}
+
+// calculateLineTaxes fills in the net amount, taxes and total of a line.
+//
+// Inclusive taxes are already part of lineAmount and are extracted from it
+// first, so the net amount is lineAmount / (1 + sum of inclusive rates).
+// Exclusive taxes are then charged on the net amount in the order they were
+// given; a compound tax is charged on the net amount plus every tax worked out
+// before it.
+func calculateLineTaxes(item *models.InvoiceItem, lineAmount money.Money) error {
+	var err error
+	if err = validateLineTaxes(item.Taxes); err != nil {
+		return err
+	}
+
+	var inclusiveRate money.Rate
+	for _, tax := range item.Taxes {
+		if tax.IsInclusive {
+			inclusiveRate += tax.Rate
+		}
+	}
+
+	net := lineAmount.ExcludeRate(inclusiveRate)
+	taxTotal := money.Zero(lineAmount.Currency)
+
+	// inclusive taxes have to add back up to exactly the amount that was
+	// taken out, so the last one absorbs any rounding difference
+	inclusiveRemaining, err := lineAmount.Sub(net)
+	if err != nil {
+		return err
+	}
+	lastInclusive := -1
+	for idx, tax := range item.Taxes {
+		if tax.IsInclusive {
+			lastInclusive = idx
+		}
+	}
+
+	for idx := range item.Taxes {
+		tax := &item.Taxes[idx]
+		if !tax.IsInclusive {
+			continue
+		}
+
+		tax.TaxableAmount = net
+		tax.Amount = net.ApplyRate(tax.Rate)
+		if idx == lastInclusive {
+			tax.Amount = inclusiveRemaining
+		}
+		if inclusiveRemaining, err = inclusiveRemaining.Sub(tax.Amount); err != nil {
+			return err
+		}
+		if taxTotal, err = taxTotal.Add(tax.Amount); err != nil {
+			return err
+		}
+	}
+
+	for idx := range item.Taxes {
+		tax := &item.Taxes[idx]
+		if tax.IsInclusive {
+			continue
+		}
+
+		tax.TaxableAmount = net
+		if tax.IsCompound {
+			if tax.TaxableAmount, err = net.Add(taxTotal); err != nil {
+				return err
+			}
+		}
+		tax.Amount = tax.TaxableAmount.ApplyRate(tax.Rate)
+		if taxTotal, err = taxTotal.Add(tax.Amount); err != nil {
+			return err
+		}
+	}
+
+	item.NetAmount = net
+	item.TaxTotal = taxTotal
+	item.TotalPrice, err = net.Add(taxTotal)
+	return err
+}
+
+func validateLineTaxes(taxes []models.InvoiceItemTax) error {
+	for _, tax := range taxes {
+		if tax.Name == "" {
+			return fmt.Errorf("tax name is required")
+		}
+		if tax.Rate < 0 || tax.Rate > money.HundredPercent {
+			return fmt.Errorf("tax %s: rate must be between 0 and 100", tax.Name)
+		}
+		if tax.IsInclusive && tax.IsCompound {
+			return fmt.Errorf("tax %s: a compound tax cannot be inclusive", tax.Name)
+		}
+	}
+	return nil
+}
+
+type taxKey struct {
+	name      string
+	rate      money.Rate
+	inclusive bool
+	compound  bool
+}
+
+// summarizeTaxes groups the taxes of every line by rate, in the order the rates first appear
+func summarizeTaxes(items []models.InvoiceItem) ([]models.TaxBreakdown, error) {
+	var err error
+	summary := []models.TaxBreakdown{}
+	positions := map[taxKey]int{}
+
+	for _, item := range items {
+		for _, tax := range item.Taxes {
+			key := taxKey{name: tax.Name, rate: tax.Rate, inclusive: tax.IsInclusive, compound: tax.IsCompound}
+
+			position, ok := positions[key]
+			if !ok {
+				summary = append(summary, models.TaxBreakdown{
+					Name:          tax.Name,
+					Rate:          tax.Rate,
+					IsInclusive:   tax.IsInclusive,
+					IsCompound:    tax.IsCompound,
+					TaxableAmount: money.Zero(tax.Amount.Currency),
+					Amount:        money.Zero(tax.Amount.Currency),
+				})
+				position = len(summary) - 1
+				positions[key] = position
+			}
+
+			line := &summary[position]
+			if line.TaxableAmount, err = line.TaxableAmount.Add(tax.TaxableAmount); err != nil {
+				return nil, err
+			}
+			if line.Amount, err = line.Amount.Add(tax.Amount); err != nil {
+				return nil, err
+			}
+		}
+	}
+
+	return summary, nil
+}
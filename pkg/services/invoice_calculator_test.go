@@ -0,0 +1,352 @@
+package services
+
+import (
+	"testing"
+
+	"github.com/Adebayobenjamin/numerisbook/pkg/common/money"
+	request_dto "github.com/Adebayobenjamin/numerisbook/pkg/dtos/request"
+	"github.com/Adebayobenjamin/numerisbook/pkg/models"
+	"github.com/stretchr/testify/assert"
+)
+
+func usd(value string) money.Money {
+	return money.MustParse(value, "USD")
+}
+
+func TestCalculateLineTaxes(t *testing.T) {
+	tests := []struct {
+		name        string
+		lineAmount  money.Money
+		taxes       []models.InvoiceItemTax
+		wantNet     money.Money
+		wantTax     money.Money
+		wantTotal   money.Money
+		wantAmounts []money.Money
+		wantErr     bool
+		errMsg      string
+	}{
+		{
+			name:       "untaxed line",
+			lineAmount: usd("200.00"),
+			wantNet:    usd("200.00"),
+			wantTax:    usd("0"),
+			wantTotal:  usd("200.00"),
+		},
+		{
+			name:        "exclusive VAT",
+			lineAmount:  usd("200.00"),
+			taxes:       []models.InvoiceItemTax{{Name: "VAT", Rate: money.MustParseRate("7.5")}},
+			wantNet:     usd("200.00"),
+			wantTax:     usd("15.00"),
+			wantTotal:   usd("215.00"),
+			wantAmounts: []money.Money{usd("15.00")},
+		},
+		{
+			name:        "inclusive VAT is extracted from the line",
+			lineAmount:  usd("120.00"),
+			taxes:       []models.InvoiceItemTax{{Name: "VAT", Rate: money.MustParseRate("20"), IsInclusive: true}},
+			wantNet:     usd("100.00"),
+			wantTax:     usd("20.00"),
+			wantTotal:   usd("120.00"),
+			wantAmounts: []money.Money{usd("20.00")},
+		},
+		{
+			name:        "inclusive tax rounding never changes the line total",
+			lineAmount:  usd("10.00"),
+			taxes:       []models.InvoiceItemTax{{Name: "VAT", Rate: money.MustParseRate("7.5"), IsInclusive: true}},
+			wantNet:     usd("9.30"),
+			wantTax:     usd("0.70"),
+			wantTotal:   usd("10.00"),
+			wantAmounts: []money.Money{usd("0.70")},
+		},
+		{
+			name:       "several inclusive taxes",
+			lineAmount: usd("115.00"),
+			taxes: []models.InvoiceItemTax{
+				{Name: "GST", Rate: money.MustParseRate("10"), IsInclusive: true},
+				{Name: "Levy", Rate: money.MustParseRate("5"), IsInclusive: true},
+			},
+			wantNet:     usd("100.00"),
+			wantTax:     usd("15.00"),
+			wantTotal:   usd("115.00"),
+			wantAmounts: []money.Money{usd("10.00"), usd("5.00")},
+		},
+		{
+			name:       "compound tax is charged on earlier taxes",
+			lineAmount: usd("100.00"),
+			taxes: []models.InvoiceItemTax{
+				{Name: "GST", Rate: money.MustParseRate("5")},
+				{Name: "PST", Rate: money.MustParseRate("7"), IsCompound: true},
+			},
+			wantNet:     usd("100.00"),
+			wantTax:     usd("12.35"),
+			wantTotal:   usd("112.35"),
+			wantAmounts: []money.Money{usd("5.00"), usd("7.35")},
+		},
+		{
+			name:       "compound tax on top of an inclusive tax",
+			lineAmount: usd("110.00"),
+			taxes: []models.InvoiceItemTax{
+				{Name: "VAT", Rate: money.MustParseRate("10"), IsInclusive: true},
+				{Name: "Surcharge", Rate: money.MustParseRate("1"), IsCompound: true},
+			},
+			wantNet:     usd("100.00"),
+			wantTax:     usd("11.10"),
+			wantTotal:   usd("111.10"),
+			wantAmounts: []money.Money{usd("10.00"), usd("1.10")},
+		},
+		{
+			name:       "compound taxes cannot be inclusive",
+			lineAmount: usd("100.00"),
+			taxes:      []models.InvoiceItemTax{{Name: "PST", Rate: money.MustParseRate("7"), IsCompound: true, IsInclusive: true}},
+			wantErr:    true,
+			errMsg:     "a compound tax cannot be inclusive",
+		},
+		{
+			name:       "rate above 100 percent",
+			lineAmount: usd("100.00"),
+			taxes:      []models.InvoiceItemTax{{Name: "VAT", Rate: money.MustParseRate("150")}},
+			wantErr:    true,
+			errMsg:     "rate must be between 0 and 100",
+		},
+		{
+			name:       "missing tax name",
+			lineAmount: usd("100.00"),
+			taxes:      []models.InvoiceItemTax{{Rate: money.MustParseRate("5")}},
+			wantErr:    true,
+			errMsg:     "tax name is required",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			item := &models.InvoiceItem{Taxes: tt.taxes}
+
+			err := calculateLineTaxes(item, tt.lineAmount)
+
+			if tt.wantErr {
+				assert.Error(t, err)
+				assert.Contains(t, err.Error(), tt.errMsg)
+				return
+			}
+
+			assert.NoError(t, err)
+			assert.Equal(t, tt.wantNet, item.NetAmount)
+			assert.Equal(t, tt.wantTax, item.TaxTotal)
+			assert.Equal(t, tt.wantTotal, item.TotalPrice)
+			for idx, amount := range tt.wantAmounts {
+				assert.Equal(t, amount, item.Taxes[idx].Amount)
+			}
+		})
+	}
+}
+
+func TestCalculateInvoiceTotals(t *testing.T) {
+	vat := []models.InvoiceItemTax{{Name: "VAT", Rate: money.MustParseRate("7.5")}}
+
+	tests := []struct {
+		name          string
+		invoice       *models.Invoice
+		wantSubtotal  money.Money
+		wantDiscount  money.Money
+		wantTax       money.Money
+		wantTotal     money.Money
+		wantLineTotal []money.Money
+		wantErr       bool
+		errMsg        string
+	}{
+		{
+			name: "fixed invoice discount is spread over the lines before tax",
+			invoice: &models.Invoice{
+				Discount: usd("10.00"),
+				Items: []models.InvoiceItem{
+					{Quantity: 2, UnitPrice: usd("100.00"), Taxes: vat},
+					{Quantity: 1, UnitPrice: usd("50.00")},
+				},
+			},
+			wantSubtotal:  usd("250.00"),
+			wantDiscount:  usd("10.00"),
+			wantTax:       usd("14.40"), // 7.5% of (200 - 8)
+			wantTotal:     usd("254.40"),
+			wantLineTotal: []money.Money{usd("206.40"), usd("48.00")},
+		},
+		{
+			name: "percentage invoice discount",
+			invoice: &models.Invoice{
+				DiscountType: models.DiscountTypePercentage,
+				DiscountRate: money.MustParseRate("10"),
+				Items:        []models.InvoiceItem{{Quantity: 1, UnitPrice: usd("200.00"), Taxes: vat}},
+			},
+			wantSubtotal:  usd("200.00"),
+			wantDiscount:  usd("20.00"),
+			wantTax:       usd("13.50"),
+			wantTotal:     usd("193.50"),
+			wantLineTotal: []money.Money{usd("193.50")},
+		},
+		{
+			name: "line discounts come off before the invoice discount",
+			invoice: &models.Invoice{
+				DiscountType: models.DiscountTypePercentage,
+				DiscountRate: money.MustParseRate("50"),
+				Items: []models.InvoiceItem{
+					{Quantity: 1, UnitPrice: usd("100.00"), DiscountAmount: usd("20.00")},
+					{Quantity: 4, UnitPrice: usd("25.00"), DiscountType: models.DiscountTypePercentage, DiscountRate: money.MustParseRate("20")},
+				},
+			},
+			wantSubtotal:  usd("200.00"),
+			wantDiscount:  usd("120.00"), // 20 + 20 on the lines, then half of the remaining 160
+			wantTax:       usd("0"),
+			wantTotal:     usd("80.00"),
+			wantLineTotal: []money.Money{usd("40.00"), usd("40.00")},
+		},
+		{
+			name: "discount on a tax-inclusive price",
+			invoice: &models.Invoice{
+				Items: []models.InvoiceItem{{
+					Quantity:       1,
+					UnitPrice:      usd("132.00"),
+					DiscountAmount: usd("12.00"),
+					Taxes:          []models.InvoiceItemTax{{Name: "VAT", Rate: money.MustParseRate("20"), IsInclusive: true}},
+				}},
+			},
+			wantSubtotal:  usd("132.00"),
+			wantDiscount:  usd("12.00"),
+			wantTax:       usd("20.00"),
+			wantTotal:     usd("120.00"),
+			wantLineTotal: []money.Money{usd("120.00")},
+		},
+		{
+			name: "rounding of a spread discount never loses a cent",
+			invoice: &models.Invoice{
+				Discount: usd("0.10"),
+				Items: []models.InvoiceItem{
+					{Quantity: 1, UnitPrice: usd("1.00")},
+					{Quantity: 1, UnitPrice: usd("1.00")},
+					{Quantity: 1, UnitPrice: usd("1.00")},
+				},
+			},
+			wantSubtotal:  usd("3.00"),
+			wantDiscount:  usd("0.10"),
+			wantTax:       usd("0"),
+			wantTotal:     usd("2.90"),
+			wantLineTotal: []money.Money{usd("0.96"), usd("0.97"), usd("0.97")},
+		},
+		{
+			name: "invoice discount cannot exceed the discounted lines",
+			invoice: &models.Invoice{
+				Discount: usd("90.00"),
+				Items:    []models.InvoiceItem{{Quantity: 1, UnitPrice: usd("100.00"), DiscountAmount: usd("20.00")}},
+			},
+			wantErr: true,
+			errMsg:  "discount of 90.00 exceeds the 80.00 it applies to",
+		},
+		{
+			name: "line discount cannot exceed the line",
+			invoice: &models.Invoice{
+				Items: []models.InvoiceItem{{Quantity: 1, UnitPrice: usd("10.00"), DiscountAmount: usd("10.01")}},
+			},
+			wantErr: true,
+			errMsg:  "item 1: discount of 10.01 exceeds the 10.00 it applies to",
+		},
+		{
+			name: "percentage above 100",
+			invoice: &models.Invoice{
+				DiscountType: models.DiscountTypePercentage,
+				DiscountRate: money.MustParseRate("120"),
+				Items:        []models.InvoiceItem{{Quantity: 1, UnitPrice: usd("10.00")}},
+			},
+			wantErr: true,
+			errMsg:  "discount must be between 0 and 100 percent",
+		},
+		{
+			name: "negative discount",
+			invoice: &models.Invoice{
+				Discount: usd("-5.00"),
+				Items:    []models.InvoiceItem{{Quantity: 1, UnitPrice: usd("10.00")}},
+			},
+			wantErr: true,
+			errMsg:  "discount cannot be negative",
+		},
+		{
+			name: "empty quantity",
+			invoice: &models.Invoice{
+				Items: []models.InvoiceItem{{Quantity: 0, UnitPrice: usd("100.00")}},
+			},
+			wantErr: true,
+			errMsg:  "quantity must be greater than zero",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			tt.invoice.BillingCurrency = "USD"
+			if err := tt.invoice.AssignCurrency(); err != nil {
+				t.Fatal(err)
+			}
+
+			err := calculateInvoiceTotals(tt.invoice)
+
+			if tt.wantErr {
+				assert.Error(t, err)
+				assert.Contains(t, err.Error(), tt.errMsg)
+				return
+			}
+
+			assert.NoError(t, err)
+			assert.Equal(t, tt.wantSubtotal, tt.invoice.Subtotal)
+			assert.Equal(t, tt.wantDiscount, tt.invoice.DiscountTotal)
+			assert.Equal(t, tt.wantTax, tt.invoice.TaxTotal)
+			assert.Equal(t, tt.wantTotal, tt.invoice.TotalAmountDue)
+			for idx, total := range tt.wantLineTotal {
+				assert.Equal(t, total, tt.invoice.Items[idx].TotalPrice)
+			}
+		})
+	}
+}
+
+func TestParseRequestedDiscount(t *testing.T) {
+	t.Run("a fixed amount is taken in the currency's minor unit", func(t *testing.T) {
+		discountType, rate, amount, err := parseRequestedDiscount(&request_dto.Discount{Type: models.DiscountTypeFixed, Value: "1500"}, "JPY")
+
+		assert.NoError(t, err)
+		assert.Equal(t, models.DiscountTypeFixed, discountType)
+		assert.Equal(t, money.Rate(0), rate)
+		assert.Equal(t, money.New(1500, "JPY"), amount)
+	})
+
+	t.Run("a fixed amount with more decimals than the currency has is refused", func(t *testing.T) {
+		_, _, _, err := parseRequestedDiscount(&request_dto.Discount{Type: models.DiscountTypeFixed, Value: "10.5"}, "JPY")
+
+		assert.ErrorIs(t, err, money.ErrTooPrecise)
+		assert.EqualError(t, err, "invalid discount: amount 10.5 has more decimal places than its currency allows, JPY has 0")
+	})
+
+	t.Run("a percentage is read as a rate", func(t *testing.T) {
+		discountType, rate, amount, err := parseRequestedDiscount(&request_dto.Discount{Type: models.DiscountTypePercentage, Value: "12.5"}, "USD")
+
+		assert.NoError(t, err)
+		assert.Equal(t, models.DiscountTypePercentage, discountType)
+		assert.Equal(t, money.MustParseRate("12.5"), rate)
+		assert.Equal(t, money.Zero("USD"), amount)
+	})
+}
+
+func TestSummarizeTaxes(t *testing.T) {
+	items := []models.InvoiceItem{
+		{Taxes: []models.InvoiceItemTax{
+			{Name: "VAT", Rate: money.MustParseRate("7.5"), TaxableAmount: usd("100.00"), Amount: usd("7.50")},
+		}},
+		{Taxes: []models.InvoiceItemTax{
+			{Name: "VAT", Rate: money.MustParseRate("7.5"), TaxableAmount: usd("200.00"), Amount: usd("15.00")},
+			{Name: "Levy", Rate: money.MustParseRate("1"), TaxableAmount: usd("200.00"), Amount: usd("2.00")},
+		}},
+	}
+
+	summary, err := summarizeTaxes(items)
+
+	assert.NoError(t, err)
+	assert.Equal(t, []models.TaxBreakdown{
+		{Name: "VAT", Rate: money.MustParseRate("7.5"), TaxableAmount: usd("300.00"), Amount: usd("22.50")},
+		{Name: "Levy", Rate: money.MustParseRate("1"), TaxableAmount: usd("200.00"), Amount: usd("2.00")},
+	}, summary)
+}
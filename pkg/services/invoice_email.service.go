@@ -0,0 +1,190 @@
+package services
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/Adebayobenjamin/numerisbook/pkg/common/money"
+	response_dto "github.com/Adebayobenjamin/numerisbook/pkg/dtos/response"
+	"github.com/Adebayobenjamin/numerisbook/pkg/email"
+	"github.com/Adebayobenjamin/numerisbook/pkg/models"
+	"github.com/Adebayobenjamin/numerisbook/pkg/pdf"
+	services_interfaces "github.com/Adebayobenjamin/numerisbook/pkg/services/interfaces"
+	"github.com/rs/zerolog"
+)
+
+type invoiceEmailService struct {
+	logger         *zerolog.Logger
+	invoiceService services_interfaces.InvoiceService
+	mailer         email.Mailer
+}
+
+// SendInvoice implements services_interfaces.InvoiceEmailService.
+func (s *invoiceEmailService) SendInvoice(ctx context.Context, invoice *models.Invoice) error {
+	// invoices that are already out are simply sent again, anything else has
+	// to be allowed to move to sent before the email goes out
+	resend := remindableInvoiceStatuses[invoice.Status]
+	if !resend {
+		if err := validateInvoiceTransition(invoice.Status, models.InvoiceStatusSent); err != nil {
+			return err
+		}
+	}
+
+	details, err := s.invoiceService.GetInvoiceDetails(ctx, invoice.ID)
+	if err != nil {
+		return err
+	}
+
+	data, err := invoiceTemplateData(details)
+	if err != nil {
+		return err
+	}
+
+	message, err := invoiceEmailMessage(email.TemplateInvoiceSent, details, data)
+	if err != nil {
+		return err
+	}
+
+	document, err := pdf.RenderInvoice(details)
+	if err != nil {
+		return fmt.Errorf("failed to render invoice pdf: %w", err)
+	}
+	message.Attachments = append(message.Attachments, email.Attachment{
+		Filename:    details.InvoiceNumber + ".pdf",
+		ContentType: "application/pdf",
+		Data:        document,
+	})
+
+	if err := s.mailer.Send(ctx, *message); err != nil {
+		return fmt.Errorf("failed to email invoice: %w", err)
+	}
+
+	if resend {
+		return nil
+	}
+
+	return s.invoiceService.ChangeInvoiceStatus(ctx, invoice, models.InvoiceStatusSent)
+}
+
+// SendPaymentReceipt implements services_interfaces.InvoiceEmailService.
+func (s *invoiceEmailService) SendPaymentReceipt(ctx context.Context, invoiceID uint, amount money.Money, paymentDate time.Time) error {
+	details, err := s.invoiceService.GetInvoiceDetails(ctx, invoiceID)
+	if err != nil {
+		return err
+	}
+
+	data, err := invoiceTemplateData(details)
+	if err != nil {
+		return err
+	}
+	data.AmountPaid = amount.String()
+	data.PaymentDate = paymentDate
+
+	message, err := invoiceEmailMessage(email.TemplatePaymentReceived, details, data)
+	if err != nil {
+		return err
+	}
+
+	if err := s.mailer.Send(ctx, *message); err != nil {
+		return fmt.Errorf("failed to email payment receipt: %w", err)
+	}
+
+	return nil
+}
+
+// invoiceTemplateData fills the email template values from an invoice,
+// branded with the details of the customer who issued it
+func invoiceTemplateData(details *response_dto.GetInvoiceDetailsResponse) (email.TemplateData, error) {
+	paid, err := invoiceAmountPaid(details)
+	if err != nil {
+		return email.TemplateData{}, err
+	}
+
+	balance, err := details.TotalAmountDue.Sub(paid)
+	if err != nil {
+		return email.TemplateData{}, fmt.Errorf("failed to calculate outstanding balance: %w", err)
+	}
+
+	data := email.TemplateData{
+		InvoiceNumber: details.InvoiceNumber,
+		Currency:      details.BillingCurrency,
+		TotalAmount:   details.TotalAmountDue.String(),
+		AmountPaid:    paid.String(),
+		Balance:       balance.String(),
+		PaidInFull:    !balance.IsPositive(),
+		IssueDate:     details.IssueDate,
+		DueDate:       details.DueDate,
+		Notes:         details.Notes,
+	}
+	if details.ShareableLink != nil {
+		data.InvoiceURL = *details.ShareableLink
+	}
+	data.RecipientName = details.InvoiceClient.Name
+	if customer := details.Issuer; customer != nil {
+		data.Brand = email.Branding{
+			Name:    customer.Name,
+			Email:   customer.Email,
+			Phone:   customer.Phone,
+			Address: customer.Address,
+		}
+		if customer.BrandColor != nil {
+			data.Brand.Color = *customer.BrandColor
+		}
+		if customer.LogoURL != nil {
+			data.Brand.LogoURL = *customer.LogoURL
+		}
+		if customer.EmailFooter != nil {
+			data.Brand.Footer = *customer.EmailFooter
+		}
+	}
+
+	return data, nil
+}
+
+// invoiceAmountPaid totals the payments made against an invoice
+func invoiceAmountPaid(details *response_dto.GetInvoiceDetailsResponse) (money.Money, error) {
+	paid := money.Zero(details.BillingCurrency)
+	for _, payment := range details.Payments {
+		var err error
+		if paid, err = paid.Add(payment.Amount); err != nil {
+			return money.Money{}, fmt.Errorf("failed to total invoice payments: %w", err)
+		}
+	}
+	return paid, nil
+}
+
+// invoiceEmailMessage renders a template and addresses it to the person the
+// invoice is billed to, with replies going to the customer who issued it
+func invoiceEmailMessage(template email.Template, details *response_dto.GetInvoiceDetailsResponse, data email.TemplateData) (*email.Message, error) {
+	if details.InvoiceClient.Email == "" {
+		return nil, fmt.Errorf("invoice %s has no recipient email", details.InvoiceNumber)
+	}
+
+	rendered, err := email.Render(template, data)
+	if err != nil {
+		return nil, err
+	}
+
+	return &email.Message{
+		FromName: data.Brand.Name,
+		To:       details.InvoiceClient.Email,
+		ToName:   details.InvoiceClient.Name,
+		ReplyTo:  data.Brand.Email,
+		Subject:  rendered.Subject,
+		Text:     rendered.Text,
+		HTML:     rendered.HTML,
+	}, nil
+}
+
+func NewInvoiceEmailService(
+	logger *zerolog.Logger,
+	invoiceService services_interfaces.InvoiceService,
+	mailer email.Mailer,
+) services_interfaces.InvoiceEmailService {
+	return &invoiceEmailService{
+		logger:         logger,
+		invoiceService: invoiceService,
+		mailer:         mailer,
+	}
+}
@@ -0,0 +1,147 @@
+package services
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/Adebayobenjamin/numerisbook/pkg/common/exceptions"
+	"github.com/Adebayobenjamin/numerisbook/pkg/common/money"
+	response_dto "github.com/Adebayobenjamin/numerisbook/pkg/dtos/response"
+	"github.com/Adebayobenjamin/numerisbook/pkg/email"
+	"github.com/Adebayobenjamin/numerisbook/pkg/helper"
+	"github.com/Adebayobenjamin/numerisbook/pkg/models"
+	services_mocks "github.com/Adebayobenjamin/numerisbook/pkg/services/mocks"
+	"github.com/rs/zerolog"
+	"github.com/stretchr/testify/assert"
+	"go.uber.org/mock/gomock"
+)
+
+// recordingMailer keeps the messages it is asked to send
+type recordingMailer struct {
+	sent []email.Message
+	err  error
+}
+
+func (r *recordingMailer) Send(ctx context.Context, message email.Message) error {
+	if r.err != nil {
+		return r.err
+	}
+	r.sent = append(r.sent, message)
+	return nil
+}
+
+func setupInvoiceEmailTest(t *testing.T) (*services_mocks.MockInvoiceService, *recordingMailer, *invoiceEmailService) {
+	ctrl := gomock.NewController(t)
+	mockInvoiceService := services_mocks.NewMockInvoiceService(ctrl)
+	mailer := &recordingMailer{}
+	logger := zerolog.New(nil)
+	service := NewInvoiceEmailService(&logger, mockInvoiceService, mailer).(*invoiceEmailService)
+	return mockInvoiceService, mailer, service
+}
+
+func invoiceEmailDetails() *response_dto.GetInvoiceDetailsResponse {
+	return &response_dto.GetInvoiceDetailsResponse{
+		ID:              1,
+		InvoiceNumber:   "INV-2024-00001",
+		CustomerID:      2,
+		Issuer:          &models.Customer{Name: "Acme Ltd", Email: "accounts@acme.test", BrandColor: helper.ReturnPointer("#ff6600")},
+		InvoiceClient:   models.InvoiceClient{Name: "Ada", Email: "ada@example.com"},
+		IssueDate:       time.Date(2024, 3, 1, 0, 0, 0, 0, time.UTC),
+		DueDate:         time.Date(2024, 3, 15, 0, 0, 0, 0, time.UTC),
+		BillingCurrency: "USD",
+		TotalAmountDue:  money.New(100000, "USD"),
+	}
+}
+
+func TestSendInvoice(t *testing.T) {
+	ctx := context.Background()
+
+	t.Run("emails a draft and moves it to sent", func(t *testing.T) {
+		mockInvoiceService, mailer, service := setupInvoiceEmailTest(t)
+		invoice := &models.Invoice{ID: 1, Status: models.InvoiceStatusDraft}
+
+		mockInvoiceService.EXPECT().GetInvoiceDetails(ctx, uint(1)).Return(invoiceEmailDetails(), nil)
+		mockInvoiceService.EXPECT().ChangeInvoiceStatus(ctx, invoice, models.InvoiceStatusSent).Return(nil)
+
+		err := service.SendInvoice(ctx, invoice)
+
+		assert.NoError(t, err)
+		assert.Len(t, mailer.sent, 1)
+		assert.Equal(t, "ada@example.com", mailer.sent[0].To)
+		assert.Equal(t, "Acme Ltd", mailer.sent[0].FromName)
+		assert.Equal(t, "accounts@acme.test", mailer.sent[0].ReplyTo)
+		assert.Equal(t, "Invoice INV-2024-00001 from Acme Ltd", mailer.sent[0].Subject)
+		assert.Contains(t, mailer.sent[0].Text, "USD 1000.00")
+		assert.Contains(t, mailer.sent[0].HTML, "#ff6600")
+		if assert.Len(t, mailer.sent[0].Attachments, 1) {
+			attachment := mailer.sent[0].Attachments[0]
+			assert.Equal(t, "INV-2024-00001.pdf", attachment.Filename)
+			assert.Equal(t, "application/pdf", attachment.ContentType)
+			assert.True(t, bytes.HasPrefix(attachment.Data, []byte("%PDF-")))
+		}
+	})
+
+	t.Run("resends an invoice that is already out", func(t *testing.T) {
+		mockInvoiceService, mailer, service := setupInvoiceEmailTest(t)
+		invoice := &models.Invoice{ID: 1, Status: models.InvoiceStatusOverdue}
+
+		mockInvoiceService.EXPECT().GetInvoiceDetails(ctx, uint(1)).Return(invoiceEmailDetails(), nil)
+
+		err := service.SendInvoice(ctx, invoice)
+
+		assert.NoError(t, err)
+		assert.Len(t, mailer.sent, 1)
+	})
+
+	t.Run("paid invoices are not sent", func(t *testing.T) {
+		_, mailer, service := setupInvoiceEmailTest(t)
+
+		err := service.SendInvoice(ctx, &models.Invoice{ID: 1, Status: models.InvoiceStatusPaid})
+
+		var transitionErr *exceptions.InvalidStatusTransitionError
+		assert.ErrorAs(t, err, &transitionErr)
+		assert.Empty(t, mailer.sent)
+	})
+
+	t.Run("status is left alone when the email fails", func(t *testing.T) {
+		mockInvoiceService, mailer, service := setupInvoiceEmailTest(t)
+		mailer.err = errors.New("connection refused")
+
+		mockInvoiceService.EXPECT().GetInvoiceDetails(ctx, uint(1)).Return(invoiceEmailDetails(), nil)
+
+		err := service.SendInvoice(ctx, &models.Invoice{ID: 1, Status: models.InvoiceStatusDraft})
+
+		assert.EqualError(t, err, "failed to email invoice: connection refused")
+	})
+
+	t.Run("invoice without a recipient email", func(t *testing.T) {
+		mockInvoiceService, _, service := setupInvoiceEmailTest(t)
+		details := invoiceEmailDetails()
+		details.InvoiceClient.Email = ""
+
+		mockInvoiceService.EXPECT().GetInvoiceDetails(ctx, uint(1)).Return(details, nil)
+
+		err := service.SendInvoice(ctx, &models.Invoice{ID: 1, Status: models.InvoiceStatusDraft})
+
+		assert.EqualError(t, err, "invoice INV-2024-00001 has no recipient email")
+	})
+}
+
+func TestSendPaymentReceipt(t *testing.T) {
+	mockInvoiceService, mailer, service := setupInvoiceEmailTest(t)
+	ctx := context.Background()
+	details := invoiceEmailDetails()
+	details.Payments = []models.Payment{{Amount: money.New(40000, "USD")}, {Amount: money.New(60000, "USD")}}
+
+	mockInvoiceService.EXPECT().GetInvoiceDetails(ctx, uint(1)).Return(details, nil)
+
+	err := service.SendPaymentReceipt(ctx, 1, money.New(60000, "USD"), time.Date(2024, 3, 10, 0, 0, 0, 0, time.UTC))
+
+	assert.NoError(t, err)
+	assert.Len(t, mailer.sent, 1)
+	assert.Contains(t, mailer.sent[0].Text, "payment of USD 600.00 on March 10, 2024")
+	assert.Contains(t, mailer.sent[0].Text, "paid in full")
+}
@@ -2,143 +2,446 @@ package services
 
 import (
 	"context"
+	"encoding/json"
 	"errors"
-	"os"
+	"fmt"
+	"sync"
 	"testing"
 	"time"
 
+	"github.com/Adebayobenjamin/numerisbook/pkg/auth"
+	"github.com/Adebayobenjamin/numerisbook/pkg/common/exceptions"
+	"github.com/Adebayobenjamin/numerisbook/pkg/common/money"
 	request_dto "github.com/Adebayobenjamin/numerisbook/pkg/dtos/request"
+	"github.com/Adebayobenjamin/numerisbook/pkg/helper"
 	"github.com/Adebayobenjamin/numerisbook/pkg/models"
+	repositories_interfaces "github.com/Adebayobenjamin/numerisbook/pkg/repositories/interfaces"
 	repository_mocks "github.com/Adebayobenjamin/numerisbook/pkg/repositories/mocks"
+	"github.com/Adebayobenjamin/numerisbook/pkg/tenant"
 	"github.com/stretchr/testify/assert"
 	"go.uber.org/mock/gomock"
 )
 
-func setupInvoiceTest(t *testing.T) (*repository_mocks.MockInvoiceRepository, *repository_mocks.MockPaymentRepository, *invoiceService) {
+// invoiceIssueMocks are the repositories an invoice's parties, payment
+// details and catalog lines are read from when it is created
+type invoiceIssueMocks struct {
+	client          *repository_mocks.MockClientRepository
+	customer        *repository_mocks.MockCustomerRepository
+	businessProfile *repository_mocks.MockBusinessProfileRepository
+	bankAccount     *repository_mocks.MockBankAccountRepository
+	catalogItem     *repository_mocks.MockCatalogItemRepository
+}
+
+func setupInvoiceTest(t *testing.T) (*repository_mocks.MockInvoiceRepository, *repository_mocks.MockPaymentRepository, *invoiceIssueMocks, *invoiceService) {
 	ctrl := gomock.NewController(t)
 	mockInvoiceRepo := repository_mocks.NewMockInvoiceRepository(ctrl)
 	mockPaymentRepo := repository_mocks.NewMockPaymentRepository(ctrl)
-	service := NewInvoiceService(mockInvoiceRepo, mockPaymentRepo).(*invoiceService)
-	return mockInvoiceRepo, mockPaymentRepo, service
+	issue := &invoiceIssueMocks{
+		client:          repository_mocks.NewMockClientRepository(ctrl),
+		customer:        repository_mocks.NewMockCustomerRepository(ctrl),
+		businessProfile: repository_mocks.NewMockBusinessProfileRepository(ctrl),
+		bankAccount:     repository_mocks.NewMockBankAccountRepository(ctrl),
+		catalogItem:     repository_mocks.NewMockCatalogItemRepository(ctrl),
+	}
+	service := NewInvoiceService(mockInvoiceRepo, mockPaymentRepo, issue.client, issue.customer, issue.businessProfile, issue.bankAccount, issue.catalogItem).(*invoiceService)
+	return mockInvoiceRepo, mockPaymentRepo, issue, service
 }
 
-func TestSetInvoiceStatusIfFullyPaid(t *testing.T) {
-	mockInvoiceRepo, mockPaymentRepo, service := setupInvoiceTest(t)
-	ctx := context.Background()
+func TestCreateInvoice(t *testing.T) {
+	mockInvoiceRepo, _, issue, service := setupInvoiceTest(t)
+	ctx := auth.WithPrincipal(context.Background(), &auth.Principal{CustomerID: 1, Role: models.UserRoleOwner})
+
+	validRequest := &request_dto.CreateInvoiceRequest{
+		ClientID:        helper.ReturnPointer(uint(7)),
+		DueDate:         time.Now().Add(24 * time.Hour),
+		BillingCurrency: "USD",
+		Items: []request_dto.InvoiceItem{
+			{UnitPrice: money.MustParse("100.00", ""), Quantity: 2},
+			{UnitPrice: money.MustParse("50.00", ""), Quantity: 1},
+		},
+		Discount: &request_dto.Discount{Type: models.DiscountTypeFixed, Value: "20.00"},
+	}
+
+	// amounts are read off the request body, which keeps digits below a cent
+	var overPrecisePrice money.Money
+	assert.NoError(t, json.Unmarshal([]byte(`10.005`), &overPrecisePrice))
+
+	catalogRequest := &request_dto.CreateInvoiceRequest{
+		ClientID:        helper.ReturnPointer(uint(7)),
+		DueDate:         time.Now().Add(24 * time.Hour),
+		BillingCurrency: "USD",
+		Items: []request_dto.InvoiceItem{
+			{CatalogItemID: helper.ReturnPointer(uint(11)), Quantity: 2},
+			{CatalogItemID: helper.ReturnPointer(uint(11)), Description: "Rush design hour", UnitPrice: money.MustParse("150.00", ""), Quantity: 1},
+		},
+	}
+	designHour := &models.CatalogItem{
+		ID:         11,
+		CustomerID: 1,
+		SKU:        "DSG-01",
+		Name:       "Design hour",
+		UnitPrice:  money.New(12000, "USD"),
+		Currency:   "USD",
+		Unit:       "hour",
+		TaxName:    "VAT",
+		TaxRate:    money.MustParseRate("7.5"),
+	}
+
+	// without a profile or bank account picked, the invoice is issued under
+	// the account's own details and paid into the default bank account
+	issuedWithDefaults := func() {
+		issue.businessProfile.EXPECT().
+			GetDefault(ctx, uint(1)).
+			Return(nil, fmt.Errorf("default business profile %w", exceptions.ErrNotFound))
+		issue.customer.EXPECT().
+			GetCustomerByID(ctx, uint(1)).
+			Return(&models.Customer{ID: 1, Name: "Numeris Ltd", Email: "accounts@numeris.test"}, nil)
+		issue.bankAccount.EXPECT().
+			GetDefault(ctx, uint(1)).
+			Return(&models.BankAccount{ID: 3, CustomerID: 1, BankName: "First Bank", AccountNumber: "0123456789", AccountName: "Numeris Ltd", IsDefault: true}, nil)
+	}
 
 	tests := []struct {
-		name           string
-		invoice        *models.Invoice
-		totalPayments  float64
-		mockSetup      func()
-		expectedStatus models.InvoiceStatus
-		wantErr        bool
-		errMsg         string
+		name       string
+		customerID uint
+		request    *request_dto.CreateInvoiceRequest
+		mockSetup  func()
+		wantErr    bool
+		errMsg     string
 	}{
 		{
-			name: "fully paid invoice",
-			invoice: &models.Invoice{
-				ID:             1,
-				TotalAmountDue: 100.0,
+			name:       "successful creation",
+			customerID: 1,
+			request:    validRequest,
+			mockSetup: func() {
+				issue.client.EXPECT().
+					GetByIDAndCustomerID(ctx, uint(7), uint(1)).
+					Return(&models.Client{ID: 7, CustomerID: 1, Name: "Acme", Email: "billing@acme.test", Phone: "+14155550123"}, nil)
+				issuedWithDefaults()
+				mockInvoiceRepo.EXPECT().
+					CreateInvoiceWithItems(ctx, gomock.Any()).
+					DoAndReturn(func(_ context.Context, invoice *models.Invoice) (*models.Invoice, error) {
+						assert.Equal(t, money.New(25000, "USD"), invoice.Subtotal)       // (100*2 + 50*1)
+						assert.Equal(t, money.New(23000, "USD"), invoice.TotalAmountDue) // 250 - 20 (discount)
+						assert.Equal(t, money.New(2000, "USD"), invoice.DiscountTotal)
+						assert.Equal(t, money.New(18400, "USD"), invoice.Items[0].TotalPrice) // 200 - 16 (share of discount)
+						assert.Equal(t, models.InvoiceStatusDraft, invoice.Status)
+						assert.Equal(t, models.InvoiceIssuer{Name: "Numeris Ltd", Email: "accounts@numeris.test"}, invoice.InvoiceIssuer)
+						assert.Equal(t, uint(3), *invoice.PaymentInfo.BankAccountID)
+						assert.Equal(t, "0123456789", invoice.PaymentInfo.AccountNumber)
+						return invoice, nil
+					})
+			},
+			wantErr: false,
+		},
+		{
+			name:       "billing a stored client copies its details onto the invoice",
+			customerID: 1,
+			request: &request_dto.CreateInvoiceRequest{
+				ClientID:        helper.ReturnPointer(uint(7)),
+				DueDate:         time.Now().Add(24 * time.Hour),
+				BillingCurrency: "USD",
+				Items:           []request_dto.InvoiceItem{{UnitPrice: money.MustParse("100.00", ""), Quantity: 1}},
 			},
-			totalPayments: 100.0,
 			mockSetup: func() {
-				mockPaymentRepo.EXPECT().
-					GetTotalInvoicePayments(ctx, uint(1)).
-					Return(100.0, nil)
+				issue.client.EXPECT().
+					GetByIDAndCustomerID(ctx, uint(7), uint(1)).
+					Return(&models.Client{ID: 7, CustomerID: 1, Name: "Acme", Email: "billing@acme.test", Phone: "+14155550123", Address: "1 Main St"}, nil)
+				issuedWithDefaults()
 				mockInvoiceRepo.EXPECT().
-					UpdateInvoiceStatus(ctx, uint(1), models.InvoiceStatusPaid).
-					Return(nil)
+					CreateInvoiceWithItems(ctx, gomock.Any()).
+					DoAndReturn(func(_ context.Context, invoice *models.Invoice) (*models.Invoice, error) {
+						assert.Equal(t, models.InvoiceClient{ClientID: helper.ReturnPointer(uint(7)), Name: "Acme", Phone: "+14155550123", Address: "1 Main St", Email: "billing@acme.test"}, invoice.InvoiceClient)
+						return invoice, nil
+					})
 			},
-			expectedStatus: models.InvoiceStatusPaid,
-			wantErr:        false,
+			wantErr: false,
 		},
 		{
-			name: "partially paid invoice",
-			invoice: &models.Invoice{
-				ID:             1,
-				TotalAmountDue: 100.0,
+			name:       "inline details are linked to the client with the same email",
+			customerID: 1,
+			request: &request_dto.CreateInvoiceRequest{
+				Client:          &request_dto.ClientDetails{Name: "Acme Billing", Phone: "+1 415 555 0123", Email: " Billing@Acme.test"},
+				DueDate:         time.Now().Add(24 * time.Hour),
+				BillingCurrency: "USD",
+				Items:           []request_dto.InvoiceItem{{UnitPrice: money.MustParse("100.00", ""), Quantity: 1}},
 			},
-			totalPayments: 50.0,
 			mockSetup: func() {
-				mockPaymentRepo.EXPECT().
-					GetTotalInvoicePayments(ctx, uint(1)).
-					Return(50.0, nil)
+				issue.client.EXPECT().
+					GetByEmail(ctx, uint(1), "billing@acme.test").
+					Return(&models.Client{ID: 7, CustomerID: 1, Name: "Acme", Email: "billing@acme.test", Phone: "+14155550123"}, nil)
+				issuedWithDefaults()
+				mockInvoiceRepo.EXPECT().
+					CreateInvoiceWithItems(ctx, gomock.Any()).
+					DoAndReturn(func(_ context.Context, invoice *models.Invoice) (*models.Invoice, error) {
+						assert.Equal(t, models.InvoiceClient{ClientID: helper.ReturnPointer(uint(7)), Name: "Acme Billing", Phone: "+14155550123", Email: "billing@acme.test"}, invoice.InvoiceClient)
+						return invoice, nil
+					})
 			},
 			wantErr: false,
 		},
 		{
-			name: "error getting payments",
-			invoice: &models.Invoice{
-				ID:             1,
-				TotalAmountDue: 100.0,
+			name:       "inline details for a new party are saved as a client",
+			customerID: 1,
+			request: &request_dto.CreateInvoiceRequest{
+				Sender:          &request_dto.ClientDetails{Name: "Globex", Phone: "+14155550199", Email: "ap@globex.test"},
+				DueDate:         time.Now().Add(24 * time.Hour),
+				BillingCurrency: "USD",
+				Items:           []request_dto.InvoiceItem{{UnitPrice: money.MustParse("100.00", ""), Quantity: 1}},
+			},
+			mockSetup: func() {
+				issue.client.EXPECT().
+					GetByEmail(ctx, uint(1), "ap@globex.test").
+					Return(nil, fmt.Errorf("client %w", exceptions.ErrNotFound))
+				issue.client.EXPECT().
+					Create(ctx, &models.Client{CustomerID: 1, Name: "Globex", Phone: "+14155550199", Email: "ap@globex.test"}).
+					DoAndReturn(func(_ context.Context, client *models.Client) (*models.Client, error) {
+						client.ID = 9
+						return client, nil
+					})
+				issuedWithDefaults()
+				mockInvoiceRepo.EXPECT().
+					CreateInvoiceWithItems(ctx, gomock.Any()).
+					DoAndReturn(func(_ context.Context, invoice *models.Invoice) (*models.Invoice, error) {
+						assert.Equal(t, uint(9), *invoice.ClientID)
+						assert.Equal(t, "Globex", invoice.InvoiceClient.Name)
+						return invoice, nil
+					})
+			},
+			wantErr: false,
+		},
+		{
+			name:       "a picked business profile and bank account are copied onto the invoice",
+			customerID: 1,
+			request: &request_dto.CreateInvoiceRequest{
+				ClientID:          helper.ReturnPointer(uint(7)),
+				BusinessProfileID: helper.ReturnPointer(uint(4)),
+				BankAccountID:     helper.ReturnPointer(uint(5)),
+				DueDate:           time.Now().Add(24 * time.Hour),
+				BillingCurrency:   "USD",
+				Items:             []request_dto.InvoiceItem{{UnitPrice: money.MustParse("100.00", ""), Quantity: 1}},
+			},
+			mockSetup: func() {
+				issue.client.EXPECT().
+					GetByIDAndCustomerID(ctx, uint(7), uint(1)).
+					Return(&models.Client{ID: 7, CustomerID: 1, Name: "Acme", Email: "billing@acme.test", Phone: "+14155550123"}, nil)
+				issue.businessProfile.EXPECT().
+					GetByIDAndCustomerID(ctx, uint(4), uint(1)).
+					Return(&models.BusinessProfile{ID: 4, CustomerID: 1, Name: "Numeris Studio", Email: "studio@numeris.test", Phone: "+2348012345678"}, nil)
+				issue.bankAccount.EXPECT().
+					GetByIDAndCustomerID(ctx, uint(5), uint(1)).
+					Return(&models.BankAccount{ID: 5, CustomerID: 1, BankName: "Chase", AccountNumber: "987654321", AccountName: "Numeris Studio", AchRoutingNo: "021000021"}, nil)
+				mockInvoiceRepo.EXPECT().
+					CreateInvoiceWithItems(ctx, gomock.Any()).
+					DoAndReturn(func(_ context.Context, invoice *models.Invoice) (*models.Invoice, error) {
+						assert.Equal(t, models.InvoiceIssuer{BusinessProfileID: helper.ReturnPointer(uint(4)), Name: "Numeris Studio", Email: "studio@numeris.test", Phone: "+2348012345678"}, invoice.InvoiceIssuer)
+						assert.Equal(t, &models.PaymentInfo{BankAccountID: helper.ReturnPointer(uint(5)), BankName: "Chase", AccountNumber: "987654321", AccountName: "Numeris Studio", AchRoutingNo: "021000021"}, invoice.PaymentInfo)
+						return invoice, nil
+					})
+			},
+			wantErr: false,
+		},
+		{
+			name:       "deleted or another customer's business profile",
+			customerID: 1,
+			request: &request_dto.CreateInvoiceRequest{
+				ClientID:          helper.ReturnPointer(uint(7)),
+				BusinessProfileID: helper.ReturnPointer(uint(8)),
+				DueDate:           time.Now().Add(24 * time.Hour),
+				BillingCurrency:   "USD",
+				Items:             []request_dto.InvoiceItem{{UnitPrice: money.MustParse("100.00", ""), Quantity: 1}},
 			},
 			mockSetup: func() {
-				mockPaymentRepo.EXPECT().
-					GetTotalInvoicePayments(ctx, uint(1)).
-					Return(0.0, errors.New("database error"))
+				issue.client.EXPECT().
+					GetByIDAndCustomerID(ctx, uint(7), uint(1)).
+					Return(&models.Client{ID: 7, CustomerID: 1, Name: "Acme"}, nil)
+				issue.businessProfile.EXPECT().
+					GetByIDAndCustomerID(ctx, uint(8), uint(1)).
+					Return(nil, fmt.Errorf("business profile %w", exceptions.ErrNotFound))
 			},
 			wantErr: true,
-			errMsg:  "failed to get total invoice payments",
+			errMsg:  "business profile 8 does not exist or has been deleted",
 		},
-	}
-
-	for _, tt := range tests {
-		t.Run(tt.name, func(t *testing.T) {
-			tt.mockSetup()
-
-			err := service.SetInvoiceStatusIfFullyPaid(ctx, tt.invoice)
-
-			if tt.wantErr {
-				assert.Error(t, err)
-				assert.Contains(t, err.Error(), tt.errMsg)
-			} else {
-				assert.NoError(t, err)
-				if tt.totalPayments >= tt.invoice.TotalAmountDue {
-					assert.Equal(t, tt.expectedStatus, tt.invoice.Status)
-				}
-			}
-		})
-	}
-}
-
-func TestCreateInvoice(t *testing.T) {
-	mockInvoiceRepo, _, service := setupInvoiceTest(t)
-	ctx := context.Background()
-
-	validRequest := &request_dto.CreateInvoiceRequest{
-		DueDate: time.Now().Add(24 * time.Hour),
-		Items: []request_dto.InvoiceItem{
-			{UnitPrice: 100.0, Quantity: 2},
-			{UnitPrice: 50.0, Quantity: 1},
+		{
+			name:       "no payment details and no default bank account",
+			customerID: 1,
+			request: &request_dto.CreateInvoiceRequest{
+				ClientID:          helper.ReturnPointer(uint(7)),
+				BusinessProfileID: helper.ReturnPointer(uint(4)),
+				DueDate:           time.Now().Add(24 * time.Hour),
+				BillingCurrency:   "USD",
+				Items:             []request_dto.InvoiceItem{{UnitPrice: money.MustParse("100.00", ""), Quantity: 1}},
+			},
+			mockSetup: func() {
+				issue.client.EXPECT().
+					GetByIDAndCustomerID(ctx, uint(7), uint(1)).
+					Return(&models.Client{ID: 7, CustomerID: 1, Name: "Acme"}, nil)
+				issue.businessProfile.EXPECT().
+					GetByIDAndCustomerID(ctx, uint(4), uint(1)).
+					Return(&models.BusinessProfile{ID: 4, CustomerID: 1, Name: "Numeris Studio"}, nil)
+				issue.bankAccount.EXPECT().
+					GetDefault(ctx, uint(1)).
+					Return(nil, fmt.Errorf("default bank account %w", exceptions.ErrNotFound))
+			},
+			wantErr: true,
+			errMsg:  "payment details are required when there is no default bank account",
 		},
-		Discount: 20.0,
-	}
-
-	tests := []struct {
-		name       string
-		customerID uint
-		request    *request_dto.CreateInvoiceRequest
-		mockSetup  func()
-		wantErr    bool
-		errMsg     string
-	}{
 		{
-			name:       "successful creation",
+			name:       "a bank account and payment details together",
 			customerID: 1,
-			request:    validRequest,
+			request: &request_dto.CreateInvoiceRequest{
+				ClientID:        helper.ReturnPointer(uint(7)),
+				BankAccountID:   helper.ReturnPointer(uint(5)),
+				PaymentInfo:     &request_dto.PaymentInfo{BankName: "Chase", AccountNumber: "987654321", AccountName: "Numeris Studio"},
+				DueDate:         time.Now().Add(24 * time.Hour),
+				BillingCurrency: "USD",
+				Items:           []request_dto.InvoiceItem{{UnitPrice: money.MustParse("100.00", ""), Quantity: 1}},
+			},
+			mockSetup: func() {},
+			wantErr:   true,
+			errMsg:    "give either a bank account or payment details, not both",
+		},
+		{
+			name:       "a client and inline details together",
+			customerID: 1,
+			request: &request_dto.CreateInvoiceRequest{
+				ClientID:        helper.ReturnPointer(uint(7)),
+				Client:          &request_dto.ClientDetails{Name: "Globex", Phone: "+14155550199", Email: "ap@globex.test"},
+				DueDate:         time.Now().Add(24 * time.Hour),
+				BillingCurrency: "USD",
+				Items:           []request_dto.InvoiceItem{{UnitPrice: money.MustParse("100.00", ""), Quantity: 1}},
+			},
+			mockSetup: func() {},
+			wantErr:   true,
+			errMsg:    "give either a client or the billed party's details, not both",
+		},
+		{
+			name:       "catalog lines take what they leave out from the catalog",
+			customerID: 1,
+			request:    catalogRequest,
 			mockSetup: func() {
+				issue.catalogItem.EXPECT().GetByIDAndCustomerID(ctx, uint(11), uint(1)).Return(designHour, nil).Times(2)
+				issue.client.EXPECT().
+					GetByIDAndCustomerID(ctx, uint(7), uint(1)).
+					Return(&models.Client{ID: 7, CustomerID: 1, Name: "Acme"}, nil)
+				issuedWithDefaults()
 				mockInvoiceRepo.EXPECT().
 					CreateInvoiceWithItems(ctx, gomock.Any()).
 					DoAndReturn(func(_ context.Context, invoice *models.Invoice) (*models.Invoice, error) {
-						assert.Equal(t, float64(250), invoice.Subtotal)       // (100*2 + 50*1)
-						assert.Equal(t, float64(230), invoice.TotalAmountDue) // 250 - 20 (discount)
-						assert.NotEmpty(t, invoice.InvoiceNumber)
+						first, second := invoice.Items[0], invoice.Items[1]
+						assert.Equal(t, uint(11), *first.CatalogItemID)
+						assert.Equal(t, "Design hour", first.Description)
+						assert.Equal(t, "hour", first.Unit)
+						assert.Equal(t, money.New(12000, "USD"), first.UnitPrice)
+						assert.Equal(t, money.New(1800, "USD"), first.TaxTotal) // 7.5% of 240
+						// what the line gives itself wins over the catalog
+						assert.Equal(t, "Rush design hour", second.Description)
+						assert.Equal(t, money.New(15000, "USD"), second.UnitPrice)
+						// the request is left as it was sent
+						assert.Empty(t, catalogRequest.Items[0].Description)
 						return invoice, nil
 					})
 			},
 			wantErr: false,
 		},
+		{
+			name:       "deleted or another customer's catalog item",
+			customerID: 1,
+			request: &request_dto.CreateInvoiceRequest{
+				ClientID:        helper.ReturnPointer(uint(7)),
+				DueDate:         time.Now().Add(24 * time.Hour),
+				BillingCurrency: "USD",
+				Items:           []request_dto.InvoiceItem{{CatalogItemID: helper.ReturnPointer(uint(12)), Quantity: 1}},
+			},
+			mockSetup: func() {
+				issue.catalogItem.EXPECT().
+					GetByIDAndCustomerID(ctx, uint(12), uint(1)).
+					Return(nil, fmt.Errorf("catalog item %w", exceptions.ErrNotFound))
+			},
+			wantErr: true,
+			errMsg:  "item 1: catalog item 12 does not exist or has been deleted",
+		},
+		{
+			name:       "catalog price in another currency",
+			customerID: 1,
+			request: &request_dto.CreateInvoiceRequest{
+				ClientID:        helper.ReturnPointer(uint(7)),
+				DueDate:         time.Now().Add(24 * time.Hour),
+				BillingCurrency: "EUR",
+				Items:           []request_dto.InvoiceItem{{CatalogItemID: helper.ReturnPointer(uint(11)), Quantity: 1}},
+			},
+			mockSetup: func() {
+				issue.catalogItem.EXPECT().GetByIDAndCustomerID(ctx, uint(11), uint(1)).Return(designHour, nil)
+			},
+			wantErr: true,
+			errMsg:  `item 1: catalog item "DSG-01" is priced in USD, give a unit price to bill it in EUR`,
+		},
+		{
+			name:       "deleted or another customer's client",
+			customerID: 1,
+			request: &request_dto.CreateInvoiceRequest{
+				ClientID:        helper.ReturnPointer(uint(8)),
+				DueDate:         time.Now().Add(24 * time.Hour),
+				BillingCurrency: "USD",
+				Items:           []request_dto.InvoiceItem{{UnitPrice: money.MustParse("100.00", ""), Quantity: 1}},
+			},
+			mockSetup: func() {
+				issue.client.EXPECT().
+					GetByIDAndCustomerID(ctx, uint(8), uint(1)).
+					Return(nil, fmt.Errorf("client %w", exceptions.ErrNotFound))
+			},
+			wantErr: true,
+			errMsg:  "client 8 does not exist or has been deleted",
+		},
+		{
+			name:       "no billed party",
+			customerID: 1,
+			request: &request_dto.CreateInvoiceRequest{
+				DueDate:         time.Now().Add(24 * time.Hour),
+				BillingCurrency: "USD",
+				Items:           []request_dto.InvoiceItem{{UnitPrice: money.MustParse("100.00", ""), Quantity: 1}},
+			},
+			mockSetup: func() {},
+			wantErr:   true,
+			errMsg:    "either a client or the billed party's details are required",
+		},
+		{
+			name:       "unsupported currency",
+			customerID: 1,
+			request: &request_dto.CreateInvoiceRequest{
+				DueDate:         time.Now().Add(24 * time.Hour),
+				BillingCurrency: "XYZ",
+			},
+			mockSetup: func() {},
+			wantErr:   true,
+			errMsg:    "unsupported currency",
+		},
+		{
+			name:       "unit price with more decimals than the currency has",
+			customerID: 1,
+			request: &request_dto.CreateInvoiceRequest{
+				ClientID:        helper.ReturnPointer(uint(7)),
+				DueDate:         time.Now().Add(24 * time.Hour),
+				BillingCurrency: "USD",
+				Items:           []request_dto.InvoiceItem{{UnitPrice: overPrecisePrice, Quantity: 1}},
+			},
+			mockSetup: func() {},
+			wantErr:   true,
+			errMsg:    "amount 10.005 has more decimal places than its currency allows, USD has 2",
+		},
+		{
+			name:       "discount larger than the invoice",
+			customerID: 1,
+			request: &request_dto.CreateInvoiceRequest{
+				DueDate:         time.Now().Add(24 * time.Hour),
+				BillingCurrency: "USD",
+				Items:           []request_dto.InvoiceItem{{UnitPrice: money.MustParse("100.00", ""), Quantity: 1}},
+				Discount:        &request_dto.Discount{Type: models.DiscountTypeFixed, Value: "150"},
+			},
+			mockSetup: func() {},
+			wantErr:   true,
+			errMsg:    "discount of 150.00 exceeds the 100.00 it applies to",
+		},
 		{
 			name:       "past due date",
 			customerID: 1,
@@ -169,38 +472,149 @@ func TestCreateInvoice(t *testing.T) {
 	}
 }
 
+func TestUpdateInvoice(t *testing.T) {
+	ctx := auth.WithPrincipal(context.Background(), &auth.Principal{CustomerID: 1, Role: models.UserRoleOwner})
+	draft := &models.Invoice{ID: 5, CustomerID: 1, InvoiceNumber: "INV-5", Status: models.InvoiceStatusDraft, Version: 3}
+	request := &request_dto.CreateInvoiceRequest{
+		ClientID:        helper.ReturnPointer(uint(7)),
+		BankAccountID:   helper.ReturnPointer(uint(3)),
+		DueDate:         time.Now().Add(48 * time.Hour),
+		BillingCurrency: "USD",
+		Items:           []request_dto.InvoiceItem{{UnitPrice: money.MustParse("80.00", ""), Quantity: 3}},
+		Notes:           "Corrected quantities",
+	}
+
+	t.Run("a draft is rebuilt from the request at the version read", func(t *testing.T) {
+		mockInvoiceRepo, _, issue, service := setupInvoiceTest(t)
+		issue.client.EXPECT().
+			GetByIDAndCustomerID(ctx, uint(7), uint(1)).
+			Return(&models.Client{ID: 7, CustomerID: 1, Name: "Acme"}, nil)
+		issue.businessProfile.EXPECT().
+			GetDefault(ctx, uint(1)).
+			Return(&models.BusinessProfile{ID: 4, CustomerID: 1, Name: "Numeris Studio"}, nil)
+		issue.bankAccount.EXPECT().
+			GetByIDAndCustomerID(ctx, uint(3), uint(1)).
+			Return(&models.BankAccount{ID: 3, CustomerID: 1, BankName: "First Bank", AccountNumber: "0123456789", AccountName: "Numeris Ltd"}, nil)
+		mockInvoiceRepo.EXPECT().
+			UpdateDraftInvoice(ctx, gomock.Any(), gomock.Any()).
+			DoAndReturn(func(_ context.Context, invoice *models.Invoice, auditTrails []models.AuditTrail) (*models.Invoice, error) {
+				assert.Equal(t, []models.AuditTrail{{EventType: models.EventTypeInvoiceUpdated, LogLevel: models.LogLevelInfo, Message: "Updated Invoice INV-5"}}, auditTrails)
+				assert.Equal(t, uint(5), invoice.ID)
+				assert.Equal(t, "INV-5", invoice.InvoiceNumber)
+				assert.Equal(t, uint(3), invoice.Version)
+				assert.Equal(t, money.New(24000, "USD"), invoice.TotalAmountDue)
+				assert.Equal(t, "Corrected quantities", invoice.Notes)
+				invoice.Version++
+				return invoice, nil
+			})
+
+		invoice, err := service.UpdateInvoice(ctx, draft, 3, request)
+
+		assert.NoError(t, err)
+		assert.Equal(t, uint(4), invoice.Version)
+	})
+
+	t.Run("an invoice that has been sent", func(t *testing.T) {
+		_, _, _, service := setupInvoiceTest(t)
+		sent := &models.Invoice{ID: 5, CustomerID: 1, InvoiceNumber: "INV-5", Status: models.InvoiceStatusSent, Version: 4}
+
+		invoice, err := service.UpdateInvoice(ctx, sent, 4, request)
+
+		assert.ErrorIs(t, err, exceptions.ErrLocked)
+		assert.EqualError(t, err, "invoice INV-5 is sent and can no longer be changed")
+		assert.Nil(t, invoice)
+	})
+
+	t.Run("a stale version", func(t *testing.T) {
+		_, _, _, service := setupInvoiceTest(t)
+
+		invoice, err := service.UpdateInvoice(ctx, draft, 2, request)
+
+		assert.ErrorIs(t, err, exceptions.ErrVersionMismatch)
+		assert.Nil(t, invoice)
+	})
+}
+
+func TestDeleteInvoice(t *testing.T) {
+	ctx := auth.WithPrincipal(context.Background(), &auth.Principal{CustomerID: 1, Role: models.UserRoleOwner})
+
+	t.Run("a draft at the version read is deleted", func(t *testing.T) {
+		mockInvoiceRepo, _, _, service := setupInvoiceTest(t)
+		draft := &models.Invoice{ID: 5, InvoiceNumber: "INV-5", Status: models.InvoiceStatusDraft, Version: 3}
+		mockInvoiceRepo.EXPECT().
+			DeleteDraftInvoice(ctx, draft, uint(3), []models.AuditTrail{{EventType: models.EventTypeInvoiceDeleted, LogLevel: models.LogLevelInfo, Message: "Deleted Invoice INV-5"}}).
+			Return(nil)
+
+		err := service.DeleteInvoice(ctx, draft, 3)
+
+		assert.NoError(t, err)
+	})
+
+	t.Run("a paid invoice is kept", func(t *testing.T) {
+		_, _, _, service := setupInvoiceTest(t)
+
+		err := service.DeleteInvoice(ctx, &models.Invoice{ID: 5, InvoiceNumber: "INV-5", Status: models.InvoiceStatusPaid, Version: 6}, 6)
+
+		assert.EqualError(t, err, "invoice INV-5 is paid and can no longer be changed")
+	})
+}
+
+func TestDuplicateInvoice(t *testing.T) {
+	ctx := auth.WithPrincipal(context.Background(), &auth.Principal{CustomerID: 1, Role: models.UserRoleOwner})
+
+	t.Run("the copy of a prior year's invoice is issued today on the same terms", func(t *testing.T) {
+		mockInvoiceRepo, _, _, service := setupInvoiceTest(t)
+		source := &models.Invoice{
+			ID:        5,
+			IssueDate: time.Date(2024, 11, 20, 0, 0, 0, 0, time.UTC),
+			DueDate:   time.Date(2024, 12, 20, 0, 0, 0, 0, time.UTC),
+		}
+		before := time.Now().UTC()
+		mockInvoiceRepo.EXPECT().
+			DuplicateInvoice(ctx, source, gomock.Any(), gomock.Any()).
+			DoAndReturn(func(_ context.Context, _ *models.Invoice, issueDate time.Time, dueDate time.Time) (*models.Invoice, error) {
+				assert.False(t, issueDate.Before(before))
+				assert.WithinDuration(t, time.Now().UTC(), issueDate, time.Second)
+				assert.Equal(t, issueDate.AddDate(0, 0, 30), dueDate)
+				return &models.Invoice{ID: 9, IssueDate: issueDate, DueDate: dueDate}, nil
+			})
+
+		duplicate, err := service.DuplicateInvoice(ctx, source)
+
+		assert.NoError(t, err)
+		assert.Equal(t, uint(9), duplicate.ID)
+	})
+}
+
 func TestGetCustomerInvoices(t *testing.T) {
-	mockInvoiceRepo, _, service := setupInvoiceTest(t)
-	ctx := context.Background()
+	mockInvoiceRepo, _, _, service := setupInvoiceTest(t)
+	ctx := auth.WithPrincipal(context.Background(), &auth.Principal{CustomerID: 1, Role: models.UserRoleOwner})
 
 	tests := []struct {
-		name       string
-		limit      int
-		page       int
-		customerID uint
-		mockSetup  func()
-		wantErr    bool
+		name      string
+		limit     int
+		page      int
+		mockSetup func()
+		wantErr   bool
 	}{
 		{
-			name:       "successful retrieval",
-			limit:      10,
-			page:       1,
-			customerID: 1,
+			name:  "successful retrieval",
+			limit: 10,
+			page:  1,
 			mockSetup: func() {
 				mockInvoiceRepo.EXPECT().
-					GetAllCustomerInvoices(ctx, uint(1), 10, 0).
+					GetAllCustomerInvoices(ctx, 10, 0).
 					Return([]models.Invoice{{ID: 1}, {ID: 2}}, nil)
 			},
 			wantErr: false,
 		},
 		{
-			name:       "repository error",
-			limit:      10,
-			page:       1,
-			customerID: 1,
+			name:  "repository error",
+			limit: 10,
+			page:  1,
 			mockSetup: func() {
 				mockInvoiceRepo.EXPECT().
-					GetAllCustomerInvoices(ctx, uint(1), 10, 0).
+					GetAllCustomerInvoices(ctx, 10, 0).
 					Return(nil, errors.New("database error"))
 			},
 			wantErr: true,
@@ -211,7 +625,7 @@ func TestGetCustomerInvoices(t *testing.T) {
 		t.Run(tt.name, func(t *testing.T) {
 			tt.mockSetup()
 
-			invoices, err := service.GetCustomerInvoices(ctx, tt.limit, tt.page, tt.customerID)
+			invoices, err := service.GetCustomerInvoices(ctx, tt.limit, tt.page)
 
 			if tt.wantErr {
 				assert.Error(t, err)
@@ -225,89 +639,136 @@ func TestGetCustomerInvoices(t *testing.T) {
 	}
 }
 
-func TestValidatePaymentAmount(t *testing.T) {
-	_, mockPaymentRepo, service := setupInvoiceTest(t)
-	ctx := context.Background()
-
+func TestSettlePayment(t *testing.T) {
 	tests := []struct {
 		name          string
-		amount        float64
+		amount        money.Money
 		invoice       *models.Invoice
 		isPartial     bool
-		totalPayments float64
-		mockSetup     func()
+		totalPayments money.Money
 		wantErr       bool
 		errMsg        string
 	}{
 		{
 			name:   "valid full payment",
-			amount: 100.0,
+			amount: money.New(10000, "USD"),
 			invoice: &models.Invoice{
 				ID:             1,
-				TotalAmountDue: 100.0,
+				TotalAmountDue: money.New(10000, "USD"),
+				Status:         models.InvoiceStatusSent,
 			},
-			isPartial: false,
-			mockSetup: func() {
-				mockPaymentRepo.EXPECT().
-					GetTotalInvoicePayments(ctx, uint(1)).
-					Return(0.0, nil)
+			isPartial:     false,
+			totalPayments: money.New(0, "USD"),
+			wantErr:       false,
+		},
+		{
+			name:   "credit notes lower the amount that settles the invoice",
+			amount: money.New(7500, "USD"),
+			invoice: &models.Invoice{
+				ID:             1,
+				TotalAmountDue: money.New(10000, "USD"),
+				CreditedTotal:  money.New(2500, "USD"),
+				Status:         models.InvoiceStatusSent,
 			},
-			wantErr: false,
+			isPartial:     false,
+			totalPayments: money.New(0, "USD"),
+			wantErr:       false,
 		},
 		{
-			name:   "valid partial payment",
-			amount: 50.0,
+			name:   "payment above what is left after credit notes",
+			amount: money.New(10000, "USD"),
 			invoice: &models.Invoice{
 				ID:             1,
-				TotalAmountDue: 100.0,
+				TotalAmountDue: money.New(10000, "USD"),
+				CreditedTotal:  money.New(2500, "USD"),
+				Status:         models.InvoiceStatusSent,
 			},
-			isPartial: true,
-			mockSetup: func() {
-				mockPaymentRepo.EXPECT().
-					GetTotalInvoicePayments(ctx, uint(1)).
-					Return(0.0, nil)
+			isPartial:     false,
+			totalPayments: money.New(0, "USD"),
+			wantErr:       true,
+			errMsg:        "payment amount exceeds invoice total amount",
+		},
+		{
+			name:   "valid partial payment",
+			amount: money.New(5000, "USD"),
+			invoice: &models.Invoice{
+				ID:             1,
+				TotalAmountDue: money.New(10000, "USD"),
+				Status:         models.InvoiceStatusSent,
 			},
-			wantErr: false,
+			isPartial:     true,
+			totalPayments: money.New(0, "USD"),
+			wantErr:       false,
 		},
 		{
 			name:   "payment exceeds total",
-			amount: 150.0,
+			amount: money.New(15000, "USD"),
 			invoice: &models.Invoice{
 				ID:             1,
-				TotalAmountDue: 100.0,
+				TotalAmountDue: money.New(10000, "USD"),
+				Status:         models.InvoiceStatusSent,
 			},
-			isPartial: false,
-			mockSetup: func() {
-				mockPaymentRepo.EXPECT().
-					GetTotalInvoicePayments(ctx, uint(1)).
-					Return(0.0, nil)
+			isPartial:     false,
+			totalPayments: money.New(0, "USD"),
+			wantErr:       true,
+			errMsg:        "payment amount exceeds invoice total amount",
+		},
+		{
+			// 0.1 + 0.2 is not 0.3 in floating point, but it is in minor units
+			name:   "partial payments that add up exactly",
+			amount: money.MustParse("0.2", "USD"),
+			invoice: &models.Invoice{
+				ID:             1,
+				TotalAmountDue: money.MustParse("0.3", "USD"),
+				Status:         models.InvoiceStatusPartiallyPaid,
 			},
-			wantErr: true,
-			errMsg:  "payment amount exceeds invoice total amount",
+			isPartial:     false,
+			totalPayments: money.MustParse("0.1", "USD"),
+			wantErr:       false,
+		},
+		{
+			name:   "payment in another currency",
+			amount: money.New(5000, "EUR"),
+			invoice: &models.Invoice{
+				ID:             1,
+				TotalAmountDue: money.New(10000, "USD"),
+				Status:         models.InvoiceStatusSent,
+			},
+			isPartial:     true,
+			totalPayments: money.Zero("USD"),
+			wantErr:       true,
+			errMsg:        "currency mismatch",
 		},
 		{
 			name:   "insufficient non-partial payment",
-			amount: 50.0,
+			amount: money.New(5000, "USD"),
 			invoice: &models.Invoice{
 				ID:             1,
-				TotalAmountDue: 100.0,
+				TotalAmountDue: money.New(10000, "USD"),
+				Status:         models.InvoiceStatusSent,
 			},
-			isPartial: false,
-			mockSetup: func() {
-				mockPaymentRepo.EXPECT().
-					GetTotalInvoicePayments(ctx, uint(1)).
-					Return(0.0, nil)
+			isPartial:     false,
+			totalPayments: money.New(0, "USD"),
+			wantErr:       true,
+			errMsg:        "payment amount is less than invoice total amount",
+		},
+		{
+			name:   "draft invoice cannot take payments",
+			amount: money.New(10000, "USD"),
+			invoice: &models.Invoice{
+				ID:             1,
+				TotalAmountDue: money.New(10000, "USD"),
+				Status:         models.InvoiceStatusDraft,
 			},
-			wantErr: true,
-			errMsg:  "payment amount is less than invoice total amount",
+			isPartial: false,
+			wantErr:   true,
+			errMsg:    `cannot move invoice from "draft" to "paid"`,
 		},
 	}
 
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
-			tt.mockSetup()
-
-			err := service.ValidatePaymentAmount(ctx, tt.amount, tt.invoice, tt.isPartial)
+			_, err := settlePayment(tt.invoice, tt.totalPayments, tt.amount, tt.isPartial)
 
 			if tt.wantErr {
 				assert.Error(t, err)
@@ -319,58 +780,344 @@ func TestValidatePaymentAmount(t *testing.T) {
 	}
 }
 
-func TestGetShareableLink(t *testing.T) {
-	mockInvoiceRepo, _, service := setupInvoiceTest(t)
-	ctx := context.Background()
+func TestConfirmPayment(t *testing.T) {
+	ctx := auth.WithPrincipal(context.Background(), &auth.Principal{CustomerID: 1, Role: models.UserRoleAccountant})
+	date := time.Date(2025, 3, 8, 0, 0, 0, 0, time.UTC)
+
+	t.Run("settles the locked invoice and audits both changes", func(t *testing.T) {
+		_, mockPaymentRepo, _, service := setupInvoiceTest(t)
+		mockPaymentRepo.EXPECT().
+			RecordPayment(ctx, gomock.Any(), gomock.Any()).
+			DoAndReturn(func(_ context.Context, payment *models.Payment, settle repositories_interfaces.SettlePayment) (*models.Invoice, error) {
+				assert.Equal(t, uint(1), payment.InvoiceID)
+				assert.Equal(t, date, payment.Date)
+				assert.Equal(t, models.PaymentMethodBankTransfer, payment.Method)
+
+				invoice := &models.Invoice{ID: 1, InvoiceNumber: "INV-001", TotalAmountDue: money.New(10000, "USD"), Status: models.InvoiceStatusPartiallyPaid}
+				auditTrails, err := settle(invoice, money.New(4000, "USD"))
+
+				assert.NoError(t, err)
+				assert.Equal(t, models.InvoiceStatusPaid, invoice.Status)
+				assert.True(t, invoice.IsFullyPaid)
+				assert.Len(t, auditTrails, 2)
+				assert.Equal(t, models.EventTypePaymentConfirmed, auditTrails[0].EventType)
+				assert.Equal(t, "Confirmed Payment of 60.00 by bank_transfer for Invoice INV-001", auditTrails[0].Message)
+				assert.Equal(t, "Invoice INV-001 moved from partially_paid to paid", auditTrails[1].Message)
+				return invoice, nil
+			})
+
+		invoice, err := service.ConfirmPayment(ctx, &models.Payment{
+			InvoiceID: 1,
+			Amount:    money.New(6000, "USD"),
+			Date:      date,
+			Method:    models.PaymentMethodBankTransfer,
+			Reference: "TRF-20250308-001",
+		})
+
+		assert.NoError(t, err)
+		assert.Equal(t, models.InvoiceStatusPaid, invoice.Status)
+	})
+
+	t.Run("an overpayment is kept as the client's credit", func(t *testing.T) {
+		_, mockPaymentRepo, _, service := setupInvoiceTest(t)
+		clientID := uint(9)
+		mockPaymentRepo.EXPECT().
+			RecordPayment(ctx, gomock.Any(), gomock.Any()).
+			DoAndReturn(func(_ context.Context, payment *models.Payment, settle repositories_interfaces.SettlePayment) (*models.Invoice, error) {
+				invoice := &models.Invoice{ID: 1, InvoiceNumber: "INV-001", TotalAmountDue: money.New(10000, "USD"), Status: models.InvoiceStatusPartiallyPaid}
+				invoice.InvoiceClient = models.InvoiceClient{ClientID: &clientID, Name: "Acme Ltd"}
+				auditTrails, err := settle(invoice, money.New(4000, "USD"))
+
+				assert.NoError(t, err)
+				assert.Equal(t, models.InvoiceStatusPaid, invoice.Status)
+				// the invoice takes what it still asked for, the rest is credited
+				assert.Equal(t, money.New(6000, "USD"), payment.Amount)
+				assert.Equal(t, money.New(2500, "USD"), *payment.Overpayment)
+				assert.Len(t, auditTrails, 3)
+				assert.Equal(t, "Confirmed Payment of 85.00 by other for Invoice INV-001", auditTrails[0].Message)
+				assert.Equal(t, models.EventTypeClientCredited, auditTrails[1].EventType)
+				assert.Equal(t, "Credited 25.00 overpaid on Invoice INV-001 to Client Acme Ltd", auditTrails[1].Message)
+				return invoice, nil
+			})
+
+		_, err := service.ConfirmPayment(ctx, &models.Payment{InvoiceID: 1, Amount: money.New(8500, "USD"), Date: date})
+
+		assert.NoError(t, err)
+	})
+
+	t.Run("a refused payment writes nothing", func(t *testing.T) {
+		_, mockPaymentRepo, _, service := setupInvoiceTest(t)
+		mockPaymentRepo.EXPECT().
+			RecordPayment(ctx, gomock.Any(), gomock.Any()).
+			DoAndReturn(func(_ context.Context, payment *models.Payment, settle repositories_interfaces.SettlePayment) (*models.Invoice, error) {
+				// payments confirmed without a method are recorded as other
+				assert.Equal(t, models.PaymentMethodOther, payment.Method)
+
+				invoice := &models.Invoice{ID: 1, TotalAmountDue: money.New(10000, "USD"), Status: models.InvoiceStatusPartiallyPaid}
+				_, err := settle(invoice, money.New(9000, "USD"))
+				assert.Equal(t, models.InvoiceStatusPartiallyPaid, invoice.Status)
+				return nil, err
+			})
+
+		invoice, err := service.ConfirmPayment(ctx, &models.Payment{InvoiceID: 1, Amount: money.New(2000, "USD"), Date: date, IsPartial: true})
+
+		assert.Nil(t, invoice)
+		assert.EqualError(t, err, "payment amount exceeds invoice total amount")
+	})
+
+	t.Run("viewers cannot confirm payments", func(t *testing.T) {
+		_, _, _, service := setupInvoiceTest(t)
+		viewer := auth.WithPrincipal(context.Background(), &auth.Principal{CustomerID: 1, Role: models.UserRoleViewer})
+
+		_, err := service.ConfirmPayment(viewer, &models.Payment{InvoiceID: 1, Amount: money.New(2000, "USD"), Date: date, IsPartial: true})
+
+		assert.ErrorIs(t, err, auth.ErrForbidden)
+	})
+}
 
-	originalURL := os.Getenv("FRONTEND_URL")
-	os.Setenv("FRONTEND_URL", "http://example.com")
-	defer os.Setenv("FRONTEND_URL", originalURL)
+// lockingPaymentRepository records payments the way the database does, with
+// every payment on the invoice waiting for the row lock held by the one before
+type lockingPaymentRepository struct {
+	repositories_interfaces.PaymentRepository
+	mu          sync.Mutex
+	invoice     models.Invoice
+	payments    []models.Payment
+	auditTrails []models.AuditTrail
+}
+
+func (l *lockingPaymentRepository) RecordPayment(_ context.Context, payment *models.Payment, settle repositories_interfaces.SettlePayment) (*models.Invoice, error) {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+
+	paid := money.Zero(l.invoice.BillingCurrency)
+	for _, existing := range l.payments {
+		paid, _ = paid.Add(existing.Amount)
+	}
+
+	invoice := l.invoice
+	auditTrails, err := settle(&invoice, paid)
+	if err != nil {
+		return nil, err
+	}
+
+	l.payments = append(l.payments, *payment)
+	l.auditTrails = append(l.auditTrails, auditTrails...)
+	invoice.Version++
+	l.invoice = invoice
+	return &invoice, nil
+}
+
+// TestConfirmPayment_ConcurrentPaymentsCannotOverpay checks the service
+// settles each payment against what was paid before it, given a repository
+// that serialises payments per invoice. That the real repository does so by
+// holding the invoice's row lock is checked by
+// TestPaymentRepository_RecordPaymentOrdering.
+func TestConfirmPayment_ConcurrentPaymentsCannotOverpay(t *testing.T) {
+	ctx := auth.WithPrincipal(context.Background(), &auth.Principal{CustomerID: 1, Role: models.UserRoleAccountant})
+	repo := &lockingPaymentRepository{invoice: models.Invoice{
+		ID:              1,
+		InvoiceNumber:   "INV-001",
+		BillingCurrency: "USD",
+		TotalAmountDue:  money.New(10000, "USD"),
+		CreditedTotal:   money.Zero("USD"),
+		Status:          models.InvoiceStatusSent,
+	}}
+	service := &invoiceService{paymentRepository: repo}
+
+	// twenty payments of 10.00 race for an invoice of 100.00
+	var wg sync.WaitGroup
+	errs := make(chan error, 20)
+	for range 20 {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			_, err := service.ConfirmPayment(ctx, &models.Payment{InvoiceID: 1, Amount: money.New(1000, "USD"), Date: time.Now(), IsPartial: true})
+			errs <- err
+		}()
+	}
+	wg.Wait()
+	close(errs)
+
+	refused := 0
+	for err := range errs {
+		if err != nil {
+			// every payment after the tenth finds the invoice already paid
+			var transitionErr *exceptions.InvalidStatusTransitionError
+			assert.ErrorAs(t, err, &transitionErr)
+			refused++
+		}
+	}
+
+	paid := money.Zero("USD")
+	for _, payment := range repo.payments {
+		paid, _ = paid.Add(payment.Amount)
+	}
+	assert.Equal(t, 10, refused)
+	assert.Len(t, repo.payments, 10)
+	assert.Equal(t, money.New(10000, "USD"), paid)
+	assert.Equal(t, models.InvoiceStatusPaid, repo.invoice.Status)
+	assert.True(t, repo.invoice.IsFullyPaid)
+	assert.Equal(t, uint(10), repo.invoice.Version)
+	// ten payments, the move to partially paid on the first and to paid on the last
+	assert.Len(t, repo.auditTrails, 12)
+}
+
+func TestChangeInvoiceStatus(t *testing.T) {
+	mockInvoiceRepo, _, _, service := setupInvoiceTest(t)
+	ctx := auth.WithPrincipal(context.Background(), &auth.Principal{CustomerID: 1, Role: models.UserRoleOwner})
 
 	tests := []struct {
 		name      string
 		invoice   *models.Invoice
+		status    models.InvoiceStatus
 		mockSetup func()
 		wantErr   bool
 		errMsg    string
 	}{
 		{
-			name:    "successful link generation",
-			invoice: &models.Invoice{ID: 1},
+			name:    "draft can be sent",
+			invoice: &models.Invoice{ID: 1, CustomerID: 2, InvoiceNumber: "INV-1", Status: models.InvoiceStatusDraft},
+			status:  models.InvoiceStatusSent,
 			mockSetup: func() {
 				mockInvoiceRepo.EXPECT().
-					UpdateShareableLink(ctx, uint(1), "http://example.com/invoice/1").
+					UpdateInvoiceStatus(ctx, uint(1), models.InvoiceStatusDraft, models.InvoiceStatusSent, []models.AuditTrail{{
+						EventType: models.EventTypeInvoiceStatusChanged,
+						LogLevel:  models.LogLevelInfo,
+						Message:   "Invoice INV-1 moved from draft to sent",
+					}}).
 					Return(nil)
 			},
 			wantErr: false,
 		},
 		{
-			name:    "update error",
-			invoice: &models.Invoice{ID: 1},
+			name:      "paid invoice cannot be voided",
+			invoice:   &models.Invoice{ID: 1, Status: models.InvoiceStatusPaid},
+			status:    models.InvoiceStatusVoid,
+			mockSetup: func() {},
+			wantErr:   true,
+			errMsg:    `cannot move invoice from "paid" to "void"`,
+		},
+		{
+			name:      "draft cannot be written off",
+			invoice:   &models.Invoice{ID: 1, Status: models.InvoiceStatusDraft},
+			status:    models.InvoiceStatusWrittenOff,
+			mockSetup: func() {},
+			wantErr:   true,
+			errMsg:    `cannot move invoice from "draft" to "written_off"`,
+		},
+		{
+			name:    "concurrent update",
+			invoice: &models.Invoice{ID: 1, Status: models.InvoiceStatusSent},
+			status:  models.InvoiceStatusCancelled,
 			mockSetup: func() {
 				mockInvoiceRepo.EXPECT().
-					UpdateShareableLink(ctx, uint(1), "http://example.com/invoice/1").
-					Return(errors.New("database error"))
+					UpdateInvoiceStatus(ctx, uint(1), models.InvoiceStatusSent, models.InvoiceStatusCancelled, gomock.Any()).
+					Return(errors.New("invoice status was changed by another request"))
 			},
 			wantErr: true,
-			errMsg:  "failed to update shareable link",
+			errMsg:  "failed to update invoice status",
 		},
 	}
 
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
+			original := tt.invoice.Status
 			tt.mockSetup()
 
-			link, err := service.GetShareableLink(ctx, tt.invoice)
+			err := service.ChangeInvoiceStatus(ctx, tt.invoice, tt.status)
 
 			if tt.wantErr {
 				assert.Error(t, err)
 				assert.Contains(t, err.Error(), tt.errMsg)
-				assert.Empty(t, link)
+				assert.Equal(t, original, tt.invoice.Status)
 			} else {
 				assert.NoError(t, err)
-				assert.Equal(t, "http://example.com/invoice/1", link)
+				assert.Equal(t, tt.status, tt.invoice.Status)
 			}
 		})
 	}
 }
+
+func TestMarkOverdueInvoices(t *testing.T) {
+	mockInvoiceRepo, _, _, service := setupInvoiceTest(t)
+	ctx := context.Background()
+	now := time.Date(2025, 3, 1, 9, 0, 0, 0, time.UTC)
+
+	t.Run("past due invoices are moved to overdue in their own customer's scope", func(t *testing.T) {
+		mockInvoiceRepo.EXPECT().
+			GetPastDueInvoices(ctx, now, pastDueInvoicesBatchSize).
+			Return([]models.Invoice{
+				{ID: 1, CustomerID: 10, InvoiceNumber: "INV-1", Status: models.InvoiceStatusSent},
+				{ID: 2, CustomerID: 20, InvoiceNumber: "INV-2", Status: models.InvoiceStatusPartiallyPaid},
+			}, nil)
+
+		for _, invoice := range []struct {
+			id         uint
+			customerID uint
+			from       models.InvoiceStatus
+			message    string
+		}{
+			{1, 10, models.InvoiceStatusSent, "Invoice INV-1 moved from sent to overdue"},
+			{2, 20, models.InvoiceStatusPartiallyPaid, "Invoice INV-2 moved from partially_paid to overdue"},
+		} {
+			mockInvoiceRepo.EXPECT().
+				UpdateInvoiceStatus(gomock.Any(), invoice.id, invoice.from, models.InvoiceStatusOverdue, []models.AuditTrail{{
+					EventType: models.EventTypeInvoiceStatusChanged,
+					LogLevel:  models.LogLevelInfo,
+					Message:   invoice.message,
+				}}).
+				DoAndReturn(func(ctx context.Context, _ uint, _, _ models.InvoiceStatus, _ []models.AuditTrail) error {
+					customerID, err := tenant.CustomerIDFromContext(ctx)
+					assert.NoError(t, err)
+					assert.Equal(t, invoice.customerID, customerID)
+					return nil
+				})
+		}
+
+		marked, err := service.MarkOverdueInvoices(ctx, now)
+
+		assert.NoError(t, err)
+		assert.Equal(t, 2, marked)
+	})
+
+	t.Run("an invoice changed in the meantime does not hold up the others", func(t *testing.T) {
+		mockInvoiceRepo.EXPECT().
+			GetPastDueInvoices(ctx, now, pastDueInvoicesBatchSize).
+			Return([]models.Invoice{
+				{ID: 1, CustomerID: 10, InvoiceNumber: "INV-1", Status: models.InvoiceStatusSent},
+				{ID: 2, CustomerID: 20, InvoiceNumber: "INV-2", Status: models.InvoiceStatusSent},
+			}, nil)
+		mockInvoiceRepo.EXPECT().
+			UpdateInvoiceStatus(gomock.Any(), uint(1), models.InvoiceStatusSent, models.InvoiceStatusOverdue, gomock.Any()).
+			Return(errors.New("invoice status was changed by another request"))
+		mockInvoiceRepo.EXPECT().
+			UpdateInvoiceStatus(gomock.Any(), uint(2), models.InvoiceStatusSent, models.InvoiceStatusOverdue, gomock.Any()).
+			Return(nil)
+
+		marked, err := service.MarkOverdueInvoices(ctx, now)
+
+		assert.Equal(t, 1, marked)
+		assert.ErrorContains(t, err, "invoice 1: failed to update invoice status")
+	})
+}
+
+func TestCanTransitionInvoice(t *testing.T) {
+	terminal := []models.InvoiceStatus{
+		models.InvoiceStatusPaid,
+		models.InvoiceStatusVoid,
+		models.InvoiceStatusCancelled,
+		models.InvoiceStatusWrittenOff,
+	}
+
+	for _, from := range terminal {
+		for to := range invoiceStatusTransitions {
+			assert.False(t, canTransitionInvoice(from, to), "%s should be terminal", from)
+		}
+	}
+
+	assert.True(t, canTransitionInvoice(models.InvoiceStatusDraft, models.InvoiceStatusSent))
+	assert.True(t, canTransitionInvoice(models.InvoiceStatusSent, models.InvoiceStatusPartiallyPaid))
+	assert.True(t, canTransitionInvoice(models.InvoiceStatusPartiallyPaid, models.InvoiceStatusPaid))
+	assert.False(t, canTransitionInvoice(models.InvoiceStatusDraft, models.InvoiceStatusPaid))
+	assert.False(t, canTransitionInvoice(models.InvoiceStatusPartiallyPaid, models.InvoiceStatusVoid))
+}
@@ -0,0 +1,55 @@
+package services
+
+import (
+	"github.com/Adebayobenjamin/numerisbook/pkg/common/exceptions"
+	"github.com/Adebayobenjamin/numerisbook/pkg/models"
+)
+
+// invoiceStatusTransitions lists, for every status, the statuses an invoice
+// may move to next. Statuses without an entry are terminal.
+var invoiceStatusTransitions = map[models.InvoiceStatus][]models.InvoiceStatus{
+	models.InvoiceStatusDraft: {
+		models.InvoiceStatusSent,
+		models.InvoiceStatusCancelled,
+	},
+	models.InvoiceStatusSent: {
+		models.InvoiceStatusPartiallyPaid,
+		models.InvoiceStatusPaid,
+		models.InvoiceStatusOverdue,
+		models.InvoiceStatusVoid,
+		models.InvoiceStatusCancelled,
+	},
+	models.InvoiceStatusPartiallyPaid: {
+		models.InvoiceStatusPaid,
+		models.InvoiceStatusOverdue,
+		models.InvoiceStatusWrittenOff,
+	},
+	models.InvoiceStatusOverdue: {
+		models.InvoiceStatusPartiallyPaid,
+		models.InvoiceStatusPaid,
+		models.InvoiceStatusVoid,
+		models.InvoiceStatusWrittenOff,
+	},
+}
+
+// canTransitionInvoice reports whether the lifecycle allows moving from one status to another
+func canTransitionInvoice(from, to models.InvoiceStatus) bool {
+	for _, next := range invoiceStatusTransitions[from] {
+		if next == to {
+			return true
+		}
+	}
+	return false
+}
+
+// validateInvoiceTransition returns a typed error when the transition is not allowed
+func validateInvoiceTransition(from, to models.InvoiceStatus) error {
+	if !canTransitionInvoice(from, to) {
+		return &exceptions.InvalidStatusTransitionError{
+			Entity: "invoice",
+			From:   string(from),
+			To:     string(to),
+		}
+	}
+	return nil
+}
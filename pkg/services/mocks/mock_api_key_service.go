@@ -0,0 +1,88 @@
+// Code generated by MockGen. DO NOT EDIT.
+// Source: pkg/services/interfaces/api_key_service.interface.go
+//
+// Generated by this command:
+//
+//	mockgen -source=pkg/services/interfaces/api_key_service.interface.go -destination=pkg/services/mocks/mock_api_key_service.go -package=services_mocks
+//
+
+// Package services_mocks is a generated GoMock package.
+package services_mocks
+
+import (
+	context "context"
+	reflect "reflect"
+
+	request_dto "github.com/Adebayobenjamin/numerisbook/pkg/dtos/request"
+	response_dto "github.com/Adebayobenjamin/numerisbook/pkg/dtos/response"
+	models "github.com/Adebayobenjamin/numerisbook/pkg/models"
+	gomock "go.uber.org/mock/gomock"
+)
+
+// MockAPIKeyService is a mock of APIKeyService interface.
+type MockAPIKeyService struct {
+	ctrl     *gomock.Controller
+	recorder *MockAPIKeyServiceMockRecorder
+	isgomock struct{}
+}
+
+// MockAPIKeyServiceMockRecorder is the mock recorder for MockAPIKeyService.
+type MockAPIKeyServiceMockRecorder struct {
+	mock *MockAPIKeyService
+}
+
+// NewMockAPIKeyService creates a new mock instance.
+func NewMockAPIKeyService(ctrl *gomock.Controller) *MockAPIKeyService {
+	mock := &MockAPIKeyService{ctrl: ctrl}
+	mock.recorder = &MockAPIKeyServiceMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockAPIKeyService) EXPECT() *MockAPIKeyServiceMockRecorder {
+	return m.recorder
+}
+
+// CreateKey mocks base method.
+func (m *MockAPIKeyService) CreateKey(ctx context.Context, customerID uint, request *request_dto.CreateAPIKeyRequest) (*response_dto.CreateAPIKeyResponse, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CreateKey", ctx, customerID, request)
+	ret0, _ := ret[0].(*response_dto.CreateAPIKeyResponse)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// CreateKey indicates an expected call of CreateKey.
+func (mr *MockAPIKeyServiceMockRecorder) CreateKey(ctx, customerID, request any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CreateKey", reflect.TypeOf((*MockAPIKeyService)(nil).CreateKey), ctx, customerID, request)
+}
+
+// GetCustomerKeys mocks base method.
+func (m *MockAPIKeyService) GetCustomerKeys(ctx context.Context, customerID uint) ([]models.APIKey, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetCustomerKeys", ctx, customerID)
+	ret0, _ := ret[0].([]models.APIKey)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetCustomerKeys indicates an expected call of GetCustomerKeys.
+func (mr *MockAPIKeyServiceMockRecorder) GetCustomerKeys(ctx, customerID any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetCustomerKeys", reflect.TypeOf((*MockAPIKeyService)(nil).GetCustomerKeys), ctx, customerID)
+}
+
+// RevokeKey mocks base method.
+func (m *MockAPIKeyService) RevokeKey(ctx context.Context, keyID, customerID uint) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "RevokeKey", ctx, keyID, customerID)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// RevokeKey indicates an expected call of RevokeKey.
+func (mr *MockAPIKeyServiceMockRecorder) RevokeKey(ctx, keyID, customerID any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "RevokeKey", reflect.TypeOf((*MockAPIKeyService)(nil).RevokeKey), ctx, keyID, customerID)
+}
@@ -55,6 +55,20 @@ func (mr *MockAuditServiceMockRecorder) CreateAuditTrail(ctx, eventType, logLeve
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CreateAuditTrail", reflect.TypeOf((*MockAuditService)(nil).CreateAuditTrail), ctx, eventType, logLevel, message, invoiceID, customerID)
 }
 
+// CreateQuoteAuditTrail mocks base method.
+func (m *MockAuditService) CreateQuoteAuditTrail(ctx context.Context, eventType models.EventType, logLevel models.LogLevel, message string, invoiceID, quoteID, customerID uint) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CreateQuoteAuditTrail", ctx, eventType, logLevel, message, invoiceID, quoteID, customerID)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// CreateQuoteAuditTrail indicates an expected call of CreateQuoteAuditTrail.
+func (mr *MockAuditServiceMockRecorder) CreateQuoteAuditTrail(ctx, eventType, logLevel, message, invoiceID, quoteID, customerID any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CreateQuoteAuditTrail", reflect.TypeOf((*MockAuditService)(nil).CreateQuoteAuditTrail), ctx, eventType, logLevel, message, invoiceID, quoteID, customerID)
+}
+
 // GetAuditTrailsByInvoiceID mocks base method.
 func (m *MockAuditService) GetAuditTrailsByInvoiceID(ctx context.Context, invoiceID, customerID uint, limit, page int) ([]models.AuditTrail, error) {
 	m.ctrl.T.Helper()
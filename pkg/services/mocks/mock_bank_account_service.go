@@ -0,0 +1,132 @@
+// Code generated by MockGen. DO NOT EDIT.
+// Source: pkg/services/interfaces/bank_account_service.interface.go
+//
+// Generated by this command:
+//
+//	mockgen -source=pkg/services/interfaces/bank_account_service.interface.go -destination=pkg/services/mocks/mock_bank_account_service.go -package=services_mocks
+//
+
+// Package services_mocks is a generated GoMock package.
+package services_mocks
+
+import (
+	context "context"
+	reflect "reflect"
+
+	request_dto "github.com/Adebayobenjamin/numerisbook/pkg/dtos/request"
+	models "github.com/Adebayobenjamin/numerisbook/pkg/models"
+	gomock "go.uber.org/mock/gomock"
+)
+
+// MockBankAccountService is a mock of BankAccountService interface.
+type MockBankAccountService struct {
+	ctrl     *gomock.Controller
+	recorder *MockBankAccountServiceMockRecorder
+	isgomock struct{}
+}
+
+// MockBankAccountServiceMockRecorder is the mock recorder for MockBankAccountService.
+type MockBankAccountServiceMockRecorder struct {
+	mock *MockBankAccountService
+}
+
+// NewMockBankAccountService creates a new mock instance.
+func NewMockBankAccountService(ctrl *gomock.Controller) *MockBankAccountService {
+	mock := &MockBankAccountService{ctrl: ctrl}
+	mock.recorder = &MockBankAccountServiceMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockBankAccountService) EXPECT() *MockBankAccountServiceMockRecorder {
+	return m.recorder
+}
+
+// CreateBankAccount mocks base method.
+func (m *MockBankAccountService) CreateBankAccount(ctx context.Context, customerID uint, request *request_dto.CreateBankAccountRequest) (*models.BankAccount, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CreateBankAccount", ctx, customerID, request)
+	ret0, _ := ret[0].(*models.BankAccount)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// CreateBankAccount indicates an expected call of CreateBankAccount.
+func (mr *MockBankAccountServiceMockRecorder) CreateBankAccount(ctx, customerID, request any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CreateBankAccount", reflect.TypeOf((*MockBankAccountService)(nil).CreateBankAccount), ctx, customerID, request)
+}
+
+// DeleteBankAccount mocks base method.
+func (m *MockBankAccountService) DeleteBankAccount(ctx context.Context, accountID, customerID uint) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "DeleteBankAccount", ctx, accountID, customerID)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// DeleteBankAccount indicates an expected call of DeleteBankAccount.
+func (mr *MockBankAccountServiceMockRecorder) DeleteBankAccount(ctx, accountID, customerID any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeleteBankAccount", reflect.TypeOf((*MockBankAccountService)(nil).DeleteBankAccount), ctx, accountID, customerID)
+}
+
+// GetBankAccountByIDAndCustomer mocks base method.
+func (m *MockBankAccountService) GetBankAccountByIDAndCustomer(ctx context.Context, accountID, customerID uint) (*models.BankAccount, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetBankAccountByIDAndCustomer", ctx, accountID, customerID)
+	ret0, _ := ret[0].(*models.BankAccount)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetBankAccountByIDAndCustomer indicates an expected call of GetBankAccountByIDAndCustomer.
+func (mr *MockBankAccountServiceMockRecorder) GetBankAccountByIDAndCustomer(ctx, accountID, customerID any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetBankAccountByIDAndCustomer", reflect.TypeOf((*MockBankAccountService)(nil).GetBankAccountByIDAndCustomer), ctx, accountID, customerID)
+}
+
+// GetBankAccounts mocks base method.
+func (m *MockBankAccountService) GetBankAccounts(ctx context.Context, customerID uint) ([]models.BankAccount, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetBankAccounts", ctx, customerID)
+	ret0, _ := ret[0].([]models.BankAccount)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetBankAccounts indicates an expected call of GetBankAccounts.
+func (mr *MockBankAccountServiceMockRecorder) GetBankAccounts(ctx, customerID any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetBankAccounts", reflect.TypeOf((*MockBankAccountService)(nil).GetBankAccounts), ctx, customerID)
+}
+
+// SetDefaultBankAccount mocks base method.
+func (m *MockBankAccountService) SetDefaultBankAccount(ctx context.Context, accountID, customerID uint) (*models.BankAccount, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "SetDefaultBankAccount", ctx, accountID, customerID)
+	ret0, _ := ret[0].(*models.BankAccount)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// SetDefaultBankAccount indicates an expected call of SetDefaultBankAccount.
+func (mr *MockBankAccountServiceMockRecorder) SetDefaultBankAccount(ctx, accountID, customerID any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SetDefaultBankAccount", reflect.TypeOf((*MockBankAccountService)(nil).SetDefaultBankAccount), ctx, accountID, customerID)
+}
+
+// UpdateBankAccount mocks base method.
+func (m *MockBankAccountService) UpdateBankAccount(ctx context.Context, accountID, customerID uint, request *request_dto.UpdateBankAccountRequest) (*models.BankAccount, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "UpdateBankAccount", ctx, accountID, customerID, request)
+	ret0, _ := ret[0].(*models.BankAccount)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// UpdateBankAccount indicates an expected call of UpdateBankAccount.
+func (mr *MockBankAccountServiceMockRecorder) UpdateBankAccount(ctx, accountID, customerID, request any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UpdateBankAccount", reflect.TypeOf((*MockBankAccountService)(nil).UpdateBankAccount), ctx, accountID, customerID, request)
+}
@@ -0,0 +1,132 @@
+// Code generated by MockGen. DO NOT EDIT.
+// Source: pkg/services/interfaces/business_profile_service.interface.go
+//
+// Generated by this command:
+//
+//	mockgen -source=pkg/services/interfaces/business_profile_service.interface.go -destination=pkg/services/mocks/mock_business_profile_service.go -package=services_mocks
+//
+
+// Package services_mocks is a generated GoMock package.
+package services_mocks
+
+import (
+	context "context"
+	reflect "reflect"
+
+	request_dto "github.com/Adebayobenjamin/numerisbook/pkg/dtos/request"
+	models "github.com/Adebayobenjamin/numerisbook/pkg/models"
+	gomock "go.uber.org/mock/gomock"
+)
+
+// MockBusinessProfileService is a mock of BusinessProfileService interface.
+type MockBusinessProfileService struct {
+	ctrl     *gomock.Controller
+	recorder *MockBusinessProfileServiceMockRecorder
+	isgomock struct{}
+}
+
+// MockBusinessProfileServiceMockRecorder is the mock recorder for MockBusinessProfileService.
+type MockBusinessProfileServiceMockRecorder struct {
+	mock *MockBusinessProfileService
+}
+
+// NewMockBusinessProfileService creates a new mock instance.
+func NewMockBusinessProfileService(ctrl *gomock.Controller) *MockBusinessProfileService {
+	mock := &MockBusinessProfileService{ctrl: ctrl}
+	mock.recorder = &MockBusinessProfileServiceMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockBusinessProfileService) EXPECT() *MockBusinessProfileServiceMockRecorder {
+	return m.recorder
+}
+
+// CreateBusinessProfile mocks base method.
+func (m *MockBusinessProfileService) CreateBusinessProfile(ctx context.Context, customerID uint, request *request_dto.CreateBusinessProfileRequest) (*models.BusinessProfile, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CreateBusinessProfile", ctx, customerID, request)
+	ret0, _ := ret[0].(*models.BusinessProfile)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// CreateBusinessProfile indicates an expected call of CreateBusinessProfile.
+func (mr *MockBusinessProfileServiceMockRecorder) CreateBusinessProfile(ctx, customerID, request any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CreateBusinessProfile", reflect.TypeOf((*MockBusinessProfileService)(nil).CreateBusinessProfile), ctx, customerID, request)
+}
+
+// DeleteBusinessProfile mocks base method.
+func (m *MockBusinessProfileService) DeleteBusinessProfile(ctx context.Context, profileID, customerID uint) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "DeleteBusinessProfile", ctx, profileID, customerID)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// DeleteBusinessProfile indicates an expected call of DeleteBusinessProfile.
+func (mr *MockBusinessProfileServiceMockRecorder) DeleteBusinessProfile(ctx, profileID, customerID any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeleteBusinessProfile", reflect.TypeOf((*MockBusinessProfileService)(nil).DeleteBusinessProfile), ctx, profileID, customerID)
+}
+
+// GetBusinessProfileByIDAndCustomer mocks base method.
+func (m *MockBusinessProfileService) GetBusinessProfileByIDAndCustomer(ctx context.Context, profileID, customerID uint) (*models.BusinessProfile, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetBusinessProfileByIDAndCustomer", ctx, profileID, customerID)
+	ret0, _ := ret[0].(*models.BusinessProfile)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetBusinessProfileByIDAndCustomer indicates an expected call of GetBusinessProfileByIDAndCustomer.
+func (mr *MockBusinessProfileServiceMockRecorder) GetBusinessProfileByIDAndCustomer(ctx, profileID, customerID any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetBusinessProfileByIDAndCustomer", reflect.TypeOf((*MockBusinessProfileService)(nil).GetBusinessProfileByIDAndCustomer), ctx, profileID, customerID)
+}
+
+// GetBusinessProfiles mocks base method.
+func (m *MockBusinessProfileService) GetBusinessProfiles(ctx context.Context, customerID uint) ([]models.BusinessProfile, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetBusinessProfiles", ctx, customerID)
+	ret0, _ := ret[0].([]models.BusinessProfile)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetBusinessProfiles indicates an expected call of GetBusinessProfiles.
+func (mr *MockBusinessProfileServiceMockRecorder) GetBusinessProfiles(ctx, customerID any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetBusinessProfiles", reflect.TypeOf((*MockBusinessProfileService)(nil).GetBusinessProfiles), ctx, customerID)
+}
+
+// SetDefaultBusinessProfile mocks base method.
+func (m *MockBusinessProfileService) SetDefaultBusinessProfile(ctx context.Context, profileID, customerID uint) (*models.BusinessProfile, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "SetDefaultBusinessProfile", ctx, profileID, customerID)
+	ret0, _ := ret[0].(*models.BusinessProfile)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// SetDefaultBusinessProfile indicates an expected call of SetDefaultBusinessProfile.
+func (mr *MockBusinessProfileServiceMockRecorder) SetDefaultBusinessProfile(ctx, profileID, customerID any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SetDefaultBusinessProfile", reflect.TypeOf((*MockBusinessProfileService)(nil).SetDefaultBusinessProfile), ctx, profileID, customerID)
+}
+
+// UpdateBusinessProfile mocks base method.
+func (m *MockBusinessProfileService) UpdateBusinessProfile(ctx context.Context, profileID, customerID uint, request *request_dto.UpdateBusinessProfileRequest) (*models.BusinessProfile, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "UpdateBusinessProfile", ctx, profileID, customerID, request)
+	ret0, _ := ret[0].(*models.BusinessProfile)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// UpdateBusinessProfile indicates an expected call of UpdateBusinessProfile.
+func (mr *MockBusinessProfileServiceMockRecorder) UpdateBusinessProfile(ctx, profileID, customerID, request any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UpdateBusinessProfile", reflect.TypeOf((*MockBusinessProfileService)(nil).UpdateBusinessProfile), ctx, profileID, customerID, request)
+}
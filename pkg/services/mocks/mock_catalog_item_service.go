@@ -0,0 +1,133 @@
+// Code generated by MockGen. DO NOT EDIT.
+// Source: pkg/services/interfaces/catalog_item_service.interface.go
+//
+// Generated by this command:
+//
+//	mockgen -source=pkg/services/interfaces/catalog_item_service.interface.go -destination=pkg/services/mocks/mock_catalog_item_service.go -package=services_mocks
+//
+
+// Package services_mocks is a generated GoMock package.
+package services_mocks
+
+import (
+	context "context"
+	reflect "reflect"
+
+	request_dto "github.com/Adebayobenjamin/numerisbook/pkg/dtos/request"
+	response_dto "github.com/Adebayobenjamin/numerisbook/pkg/dtos/response"
+	models "github.com/Adebayobenjamin/numerisbook/pkg/models"
+	gomock "go.uber.org/mock/gomock"
+)
+
+// MockCatalogItemService is a mock of CatalogItemService interface.
+type MockCatalogItemService struct {
+	ctrl     *gomock.Controller
+	recorder *MockCatalogItemServiceMockRecorder
+	isgomock struct{}
+}
+
+// MockCatalogItemServiceMockRecorder is the mock recorder for MockCatalogItemService.
+type MockCatalogItemServiceMockRecorder struct {
+	mock *MockCatalogItemService
+}
+
+// NewMockCatalogItemService creates a new mock instance.
+func NewMockCatalogItemService(ctrl *gomock.Controller) *MockCatalogItemService {
+	mock := &MockCatalogItemService{ctrl: ctrl}
+	mock.recorder = &MockCatalogItemServiceMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockCatalogItemService) EXPECT() *MockCatalogItemServiceMockRecorder {
+	return m.recorder
+}
+
+// CreateCatalogItem mocks base method.
+func (m *MockCatalogItemService) CreateCatalogItem(ctx context.Context, customerID uint, request *request_dto.CreateCatalogItemRequest) (*models.CatalogItem, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CreateCatalogItem", ctx, customerID, request)
+	ret0, _ := ret[0].(*models.CatalogItem)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// CreateCatalogItem indicates an expected call of CreateCatalogItem.
+func (mr *MockCatalogItemServiceMockRecorder) CreateCatalogItem(ctx, customerID, request any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CreateCatalogItem", reflect.TypeOf((*MockCatalogItemService)(nil).CreateCatalogItem), ctx, customerID, request)
+}
+
+// DeleteCatalogItem mocks base method.
+func (m *MockCatalogItemService) DeleteCatalogItem(ctx context.Context, itemID, customerID uint) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "DeleteCatalogItem", ctx, itemID, customerID)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// DeleteCatalogItem indicates an expected call of DeleteCatalogItem.
+func (mr *MockCatalogItemServiceMockRecorder) DeleteCatalogItem(ctx, itemID, customerID any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeleteCatalogItem", reflect.TypeOf((*MockCatalogItemService)(nil).DeleteCatalogItem), ctx, itemID, customerID)
+}
+
+// GetCatalogItemByIDAndCustomer mocks base method.
+func (m *MockCatalogItemService) GetCatalogItemByIDAndCustomer(ctx context.Context, itemID, customerID uint) (*models.CatalogItem, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetCatalogItemByIDAndCustomer", ctx, itemID, customerID)
+	ret0, _ := ret[0].(*models.CatalogItem)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetCatalogItemByIDAndCustomer indicates an expected call of GetCatalogItemByIDAndCustomer.
+func (mr *MockCatalogItemServiceMockRecorder) GetCatalogItemByIDAndCustomer(ctx, itemID, customerID any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetCatalogItemByIDAndCustomer", reflect.TypeOf((*MockCatalogItemService)(nil).GetCatalogItemByIDAndCustomer), ctx, itemID, customerID)
+}
+
+// GetCatalogItems mocks base method.
+func (m *MockCatalogItemService) GetCatalogItems(ctx context.Context, customerID uint, request *request_dto.GetAllCatalogItemsRequest) ([]models.CatalogItem, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetCatalogItems", ctx, customerID, request)
+	ret0, _ := ret[0].([]models.CatalogItem)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetCatalogItems indicates an expected call of GetCatalogItems.
+func (mr *MockCatalogItemServiceMockRecorder) GetCatalogItems(ctx, customerID, request any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetCatalogItems", reflect.TypeOf((*MockCatalogItemService)(nil).GetCatalogItems), ctx, customerID, request)
+}
+
+// GetCatalogRevenue mocks base method.
+func (m *MockCatalogItemService) GetCatalogRevenue(ctx context.Context, customerID uint, request *request_dto.GetCatalogRevenueRequest) ([]response_dto.CatalogItemRevenue, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetCatalogRevenue", ctx, customerID, request)
+	ret0, _ := ret[0].([]response_dto.CatalogItemRevenue)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetCatalogRevenue indicates an expected call of GetCatalogRevenue.
+func (mr *MockCatalogItemServiceMockRecorder) GetCatalogRevenue(ctx, customerID, request any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetCatalogRevenue", reflect.TypeOf((*MockCatalogItemService)(nil).GetCatalogRevenue), ctx, customerID, request)
+}
+
+// UpdateCatalogItem mocks base method.
+func (m *MockCatalogItemService) UpdateCatalogItem(ctx context.Context, itemID, customerID uint, request *request_dto.UpdateCatalogItemRequest) (*models.CatalogItem, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "UpdateCatalogItem", ctx, itemID, customerID, request)
+	ret0, _ := ret[0].(*models.CatalogItem)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// UpdateCatalogItem indicates an expected call of UpdateCatalogItem.
+func (mr *MockCatalogItemServiceMockRecorder) UpdateCatalogItem(ctx, itemID, customerID, request any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UpdateCatalogItem", reflect.TypeOf((*MockCatalogItemService)(nil).UpdateCatalogItem), ctx, itemID, customerID, request)
+}
@@ -0,0 +1,74 @@
+// Code generated by MockGen. DO NOT EDIT.
+// Source: pkg/services/interfaces/client_credit_service.interface.go
+//
+// Generated by this command:
+//
+//	mockgen -source=pkg/services/interfaces/client_credit_service.interface.go -destination=pkg/services/mocks/mock_client_credit_service.go -package=services_mocks
+//
+
+// Package services_mocks is a generated GoMock package.
+package services_mocks
+
+import (
+	context "context"
+	reflect "reflect"
+
+	request_dto "github.com/Adebayobenjamin/numerisbook/pkg/dtos/request"
+	response_dto "github.com/Adebayobenjamin/numerisbook/pkg/dtos/response"
+	models "github.com/Adebayobenjamin/numerisbook/pkg/models"
+	gomock "go.uber.org/mock/gomock"
+)
+
+// MockClientCreditService is a mock of ClientCreditService interface.
+type MockClientCreditService struct {
+	ctrl     *gomock.Controller
+	recorder *MockClientCreditServiceMockRecorder
+	isgomock struct{}
+}
+
+// MockClientCreditServiceMockRecorder is the mock recorder for MockClientCreditService.
+type MockClientCreditServiceMockRecorder struct {
+	mock *MockClientCreditService
+}
+
+// NewMockClientCreditService creates a new mock instance.
+func NewMockClientCreditService(ctrl *gomock.Controller) *MockClientCreditService {
+	mock := &MockClientCreditService{ctrl: ctrl}
+	mock.recorder = &MockClientCreditServiceMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockClientCreditService) EXPECT() *MockClientCreditServiceMockRecorder {
+	return m.recorder
+}
+
+// ApplyCredit mocks base method.
+func (m *MockClientCreditService) ApplyCredit(ctx context.Context, clientID, customerID uint, request *request_dto.ApplyClientCreditRequest) (*models.Payment, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ApplyCredit", ctx, clientID, customerID, request)
+	ret0, _ := ret[0].(*models.Payment)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ApplyCredit indicates an expected call of ApplyCredit.
+func (mr *MockClientCreditServiceMockRecorder) ApplyCredit(ctx, clientID, customerID, request any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ApplyCredit", reflect.TypeOf((*MockClientCreditService)(nil).ApplyCredit), ctx, clientID, customerID, request)
+}
+
+// GetClientCredits mocks base method.
+func (m *MockClientCreditService) GetClientCredits(ctx context.Context, clientID, customerID uint, request *request_dto.GetClientCreditsRequest) (*response_dto.GetClientCreditsResponse, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetClientCredits", ctx, clientID, customerID, request)
+	ret0, _ := ret[0].(*response_dto.GetClientCreditsResponse)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetClientCredits indicates an expected call of GetClientCredits.
+func (mr *MockClientCreditServiceMockRecorder) GetClientCredits(ctx, clientID, customerID, request any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetClientCredits", reflect.TypeOf((*MockClientCreditService)(nil).GetClientCredits), ctx, clientID, customerID, request)
+}
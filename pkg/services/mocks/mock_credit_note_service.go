@@ -0,0 +1,103 @@
+// Code generated by MockGen. DO NOT EDIT.
+// Source: pkg/services/interfaces/credit_note_service.interface.go
+//
+// Generated by this command:
+//
+//	mockgen -source=pkg/services/interfaces/credit_note_service.interface.go -destination=pkg/services/mocks/mock_credit_note_service.go -package=services_mocks
+//
+
+// Package services_mocks is a generated GoMock package.
+package services_mocks
+
+import (
+	context "context"
+	reflect "reflect"
+
+	request_dto "github.com/Adebayobenjamin/numerisbook/pkg/dtos/request"
+	models "github.com/Adebayobenjamin/numerisbook/pkg/models"
+	gomock "go.uber.org/mock/gomock"
+)
+
+// MockCreditNoteService is a mock of CreditNoteService interface.
+type MockCreditNoteService struct {
+	ctrl     *gomock.Controller
+	recorder *MockCreditNoteServiceMockRecorder
+	isgomock struct{}
+}
+
+// MockCreditNoteServiceMockRecorder is the mock recorder for MockCreditNoteService.
+type MockCreditNoteServiceMockRecorder struct {
+	mock *MockCreditNoteService
+}
+
+// NewMockCreditNoteService creates a new mock instance.
+func NewMockCreditNoteService(ctrl *gomock.Controller) *MockCreditNoteService {
+	mock := &MockCreditNoteService{ctrl: ctrl}
+	mock.recorder = &MockCreditNoteServiceMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockCreditNoteService) EXPECT() *MockCreditNoteServiceMockRecorder {
+	return m.recorder
+}
+
+// CreateCreditNote mocks base method.
+func (m *MockCreditNoteService) CreateCreditNote(ctx context.Context, invoice *models.Invoice, request *request_dto.CreateCreditNoteRequest) (*models.CreditNote, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CreateCreditNote", ctx, invoice, request)
+	ret0, _ := ret[0].(*models.CreditNote)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// CreateCreditNote indicates an expected call of CreateCreditNote.
+func (mr *MockCreditNoteServiceMockRecorder) CreateCreditNote(ctx, invoice, request any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CreateCreditNote", reflect.TypeOf((*MockCreditNoteService)(nil).CreateCreditNote), ctx, invoice, request)
+}
+
+// GetCreditNoteByIDAndCustomer mocks base method.
+func (m *MockCreditNoteService) GetCreditNoteByIDAndCustomer(ctx context.Context, creditNoteID, customerID uint) (*models.CreditNote, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetCreditNoteByIDAndCustomer", ctx, creditNoteID, customerID)
+	ret0, _ := ret[0].(*models.CreditNote)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetCreditNoteByIDAndCustomer indicates an expected call of GetCreditNoteByIDAndCustomer.
+func (mr *MockCreditNoteServiceMockRecorder) GetCreditNoteByIDAndCustomer(ctx, creditNoteID, customerID any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetCreditNoteByIDAndCustomer", reflect.TypeOf((*MockCreditNoteService)(nil).GetCreditNoteByIDAndCustomer), ctx, creditNoteID, customerID)
+}
+
+// GetCustomerCreditNotes mocks base method.
+func (m *MockCreditNoteService) GetCustomerCreditNotes(ctx context.Context, customerID uint, request *request_dto.GetAllRequest) ([]models.CreditNote, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetCustomerCreditNotes", ctx, customerID, request)
+	ret0, _ := ret[0].([]models.CreditNote)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetCustomerCreditNotes indicates an expected call of GetCustomerCreditNotes.
+func (mr *MockCreditNoteServiceMockRecorder) GetCustomerCreditNotes(ctx, customerID, request any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetCustomerCreditNotes", reflect.TypeOf((*MockCreditNoteService)(nil).GetCustomerCreditNotes), ctx, customerID, request)
+}
+
+// GetInvoiceCreditNotes mocks base method.
+func (m *MockCreditNoteService) GetInvoiceCreditNotes(ctx context.Context, invoiceID uint) ([]models.CreditNote, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetInvoiceCreditNotes", ctx, invoiceID)
+	ret0, _ := ret[0].([]models.CreditNote)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetInvoiceCreditNotes indicates an expected call of GetInvoiceCreditNotes.
+func (mr *MockCreditNoteServiceMockRecorder) GetInvoiceCreditNotes(ctx, invoiceID any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetInvoiceCreditNotes", reflect.TypeOf((*MockCreditNoteService)(nil).GetInvoiceCreditNotes), ctx, invoiceID)
+}
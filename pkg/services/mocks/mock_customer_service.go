@@ -13,6 +13,7 @@ import (
 	context "context"
 	reflect "reflect"
 
+	request_dto "github.com/Adebayobenjamin/numerisbook/pkg/dtos/request"
 	models "github.com/Adebayobenjamin/numerisbook/pkg/models"
 	gomock "go.uber.org/mock/gomock"
 )
@@ -55,3 +56,18 @@ func (mr *MockCustomerServiceMockRecorder) GetCustomerByID(ctx, customerID any)
 	mr.mock.ctrl.T.Helper()
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetCustomerByID", reflect.TypeOf((*MockCustomerService)(nil).GetCustomerByID), ctx, customerID)
 }
+
+// UpdateBranding mocks base method.
+func (m *MockCustomerService) UpdateBranding(ctx context.Context, customerID uint, request *request_dto.UpdateBrandingRequest) (*models.Customer, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "UpdateBranding", ctx, customerID, request)
+	ret0, _ := ret[0].(*models.Customer)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// UpdateBranding indicates an expected call of UpdateBranding.
+func (mr *MockCustomerServiceMockRecorder) UpdateBranding(ctx, customerID, request any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UpdateBranding", reflect.TypeOf((*MockCustomerService)(nil).UpdateBranding), ctx, customerID, request)
+}
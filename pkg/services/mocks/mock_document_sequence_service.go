@@ -0,0 +1,73 @@
+// Code generated by MockGen. DO NOT EDIT.
+// Source: pkg/services/interfaces/document_sequence_service.interface.go
+//
+// Generated by this command:
+//
+//	mockgen -source=pkg/services/interfaces/document_sequence_service.interface.go -destination=pkg/services/mocks/mock_document_sequence_service.go -package=services_mocks
+//
+
+// Package services_mocks is a generated GoMock package.
+package services_mocks
+
+import (
+	context "context"
+	reflect "reflect"
+
+	request_dto "github.com/Adebayobenjamin/numerisbook/pkg/dtos/request"
+	models "github.com/Adebayobenjamin/numerisbook/pkg/models"
+	gomock "go.uber.org/mock/gomock"
+)
+
+// MockDocumentSequenceService is a mock of DocumentSequenceService interface.
+type MockDocumentSequenceService struct {
+	ctrl     *gomock.Controller
+	recorder *MockDocumentSequenceServiceMockRecorder
+	isgomock struct{}
+}
+
+// MockDocumentSequenceServiceMockRecorder is the mock recorder for MockDocumentSequenceService.
+type MockDocumentSequenceServiceMockRecorder struct {
+	mock *MockDocumentSequenceService
+}
+
+// NewMockDocumentSequenceService creates a new mock instance.
+func NewMockDocumentSequenceService(ctrl *gomock.Controller) *MockDocumentSequenceService {
+	mock := &MockDocumentSequenceService{ctrl: ctrl}
+	mock.recorder = &MockDocumentSequenceServiceMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockDocumentSequenceService) EXPECT() *MockDocumentSequenceServiceMockRecorder {
+	return m.recorder
+}
+
+// GetSequence mocks base method.
+func (m *MockDocumentSequenceService) GetSequence(ctx context.Context, customerID uint, documentType models.DocumentType) (*models.DocumentSequence, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetSequence", ctx, customerID, documentType)
+	ret0, _ := ret[0].(*models.DocumentSequence)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetSequence indicates an expected call of GetSequence.
+func (mr *MockDocumentSequenceServiceMockRecorder) GetSequence(ctx, customerID, documentType any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetSequence", reflect.TypeOf((*MockDocumentSequenceService)(nil).GetSequence), ctx, customerID, documentType)
+}
+
+// UpdateSequence mocks base method.
+func (m *MockDocumentSequenceService) UpdateSequence(ctx context.Context, customerID uint, documentType models.DocumentType, request *request_dto.UpdateDocumentSequenceRequest) (*models.DocumentSequence, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "UpdateSequence", ctx, customerID, documentType, request)
+	ret0, _ := ret[0].(*models.DocumentSequence)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// UpdateSequence indicates an expected call of UpdateSequence.
+func (mr *MockDocumentSequenceServiceMockRecorder) UpdateSequence(ctx, customerID, documentType, request any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UpdateSequence", reflect.TypeOf((*MockDocumentSequenceService)(nil).UpdateSequence), ctx, customerID, documentType, request)
+}
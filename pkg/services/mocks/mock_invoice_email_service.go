@@ -0,0 +1,72 @@
+// Code generated by MockGen. DO NOT EDIT.
+// Source: pkg/services/interfaces/invoice_email_service.interface.go
+//
+// Generated by this command:
+//
+//	mockgen -source=pkg/services/interfaces/invoice_email_service.interface.go -destination=pkg/services/mocks/mock_invoice_email_service.go -package=services_mocks
+//
+
+// Package services_mocks is a generated GoMock package.
+package services_mocks
+
+import (
+	context "context"
+	reflect "reflect"
+	time "time"
+
+	money "github.com/Adebayobenjamin/numerisbook/pkg/common/money"
+	models "github.com/Adebayobenjamin/numerisbook/pkg/models"
+	gomock "go.uber.org/mock/gomock"
+)
+
+// MockInvoiceEmailService is a mock of InvoiceEmailService interface.
+type MockInvoiceEmailService struct {
+	ctrl     *gomock.Controller
+	recorder *MockInvoiceEmailServiceMockRecorder
+	isgomock struct{}
+}
+
+// MockInvoiceEmailServiceMockRecorder is the mock recorder for MockInvoiceEmailService.
+type MockInvoiceEmailServiceMockRecorder struct {
+	mock *MockInvoiceEmailService
+}
+
+// NewMockInvoiceEmailService creates a new mock instance.
+func NewMockInvoiceEmailService(ctrl *gomock.Controller) *MockInvoiceEmailService {
+	mock := &MockInvoiceEmailService{ctrl: ctrl}
+	mock.recorder = &MockInvoiceEmailServiceMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockInvoiceEmailService) EXPECT() *MockInvoiceEmailServiceMockRecorder {
+	return m.recorder
+}
+
+// SendInvoice mocks base method.
+func (m *MockInvoiceEmailService) SendInvoice(ctx context.Context, invoice *models.Invoice) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "SendInvoice", ctx, invoice)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// SendInvoice indicates an expected call of SendInvoice.
+func (mr *MockInvoiceEmailServiceMockRecorder) SendInvoice(ctx, invoice any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SendInvoice", reflect.TypeOf((*MockInvoiceEmailService)(nil).SendInvoice), ctx, invoice)
+}
+
+// SendPaymentReceipt mocks base method.
+func (m *MockInvoiceEmailService) SendPaymentReceipt(ctx context.Context, invoiceID uint, amount money.Money, paymentDate time.Time) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "SendPaymentReceipt", ctx, invoiceID, amount, paymentDate)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// SendPaymentReceipt indicates an expected call of SendPaymentReceipt.
+func (mr *MockInvoiceEmailServiceMockRecorder) SendPaymentReceipt(ctx, invoiceID, amount, paymentDate any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SendPaymentReceipt", reflect.TypeOf((*MockInvoiceEmailService)(nil).SendPaymentReceipt), ctx, invoiceID, amount, paymentDate)
+}
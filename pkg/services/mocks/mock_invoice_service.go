@@ -44,18 +44,33 @@ func (m *MockInvoiceService) EXPECT() *MockInvoiceServiceMockRecorder {
 	return m.recorder
 }
 
-// ConfirmPayment mocks base method.
-func (m *MockInvoiceService) ConfirmPayment(ctx context.Context, invoiceID uint, amount float64, date time.Time, isPartial bool) error {
+// ChangeInvoiceStatus mocks base method.
+func (m *MockInvoiceService) ChangeInvoiceStatus(ctx context.Context, invoice *models.Invoice, status models.InvoiceStatus) error {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "ConfirmPayment", ctx, invoiceID, amount, date, isPartial)
+	ret := m.ctrl.Call(m, "ChangeInvoiceStatus", ctx, invoice, status)
 	ret0, _ := ret[0].(error)
 	return ret0
 }
 
+// ChangeInvoiceStatus indicates an expected call of ChangeInvoiceStatus.
+func (mr *MockInvoiceServiceMockRecorder) ChangeInvoiceStatus(ctx, invoice, status any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ChangeInvoiceStatus", reflect.TypeOf((*MockInvoiceService)(nil).ChangeInvoiceStatus), ctx, invoice, status)
+}
+
+// ConfirmPayment mocks base method.
+func (m *MockInvoiceService) ConfirmPayment(ctx context.Context, payment *models.Payment) (*models.Invoice, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ConfirmPayment", ctx, payment)
+	ret0, _ := ret[0].(*models.Invoice)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
 // ConfirmPayment indicates an expected call of ConfirmPayment.
-func (mr *MockInvoiceServiceMockRecorder) ConfirmPayment(ctx, invoiceID, amount, date, isPartial any) *gomock.Call {
+func (mr *MockInvoiceServiceMockRecorder) ConfirmPayment(ctx, payment any) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ConfirmPayment", reflect.TypeOf((*MockInvoiceService)(nil).ConfirmPayment), ctx, invoiceID, amount, date, isPartial)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ConfirmPayment", reflect.TypeOf((*MockInvoiceService)(nil).ConfirmPayment), ctx, payment)
 }
 
 // CreateInvoice mocks base method.
@@ -73,6 +88,20 @@ func (mr *MockInvoiceServiceMockRecorder) CreateInvoice(ctx, customerID, request
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CreateInvoice", reflect.TypeOf((*MockInvoiceService)(nil).CreateInvoice), ctx, customerID, request)
 }
 
+// DeleteInvoice mocks base method.
+func (m *MockInvoiceService) DeleteInvoice(ctx context.Context, invoice *models.Invoice, version uint) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "DeleteInvoice", ctx, invoice, version)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// DeleteInvoice indicates an expected call of DeleteInvoice.
+func (mr *MockInvoiceServiceMockRecorder) DeleteInvoice(ctx, invoice, version any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeleteInvoice", reflect.TypeOf((*MockInvoiceService)(nil).DeleteInvoice), ctx, invoice, version)
+}
+
 // DuplicateInvoice mocks base method.
 func (m *MockInvoiceService) DuplicateInvoice(ctx context.Context, invoice *models.Invoice) (*models.Invoice, error) {
 	m.ctrl.T.Helper()
@@ -89,33 +118,33 @@ func (mr *MockInvoiceServiceMockRecorder) DuplicateInvoice(ctx, invoice any) *go
 }
 
 // GetCustomerInvoices mocks base method.
-func (m *MockInvoiceService) GetCustomerInvoices(ctx context.Context, limit, page int, customerID uint) ([]models.Invoice, error) {
+func (m *MockInvoiceService) GetCustomerInvoices(ctx context.Context, limit, page int) ([]models.Invoice, error) {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "GetCustomerInvoices", ctx, limit, page, customerID)
+	ret := m.ctrl.Call(m, "GetCustomerInvoices", ctx, limit, page)
 	ret0, _ := ret[0].([]models.Invoice)
 	ret1, _ := ret[1].(error)
 	return ret0, ret1
 }
 
 // GetCustomerInvoices indicates an expected call of GetCustomerInvoices.
-func (mr *MockInvoiceServiceMockRecorder) GetCustomerInvoices(ctx, limit, page, customerID any) *gomock.Call {
+func (mr *MockInvoiceServiceMockRecorder) GetCustomerInvoices(ctx, limit, page any) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetCustomerInvoices", reflect.TypeOf((*MockInvoiceService)(nil).GetCustomerInvoices), ctx, limit, page, customerID)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetCustomerInvoices", reflect.TypeOf((*MockInvoiceService)(nil).GetCustomerInvoices), ctx, limit, page)
 }
 
 // GetInvoiceByIDandCustomer mocks base method.
-func (m *MockInvoiceService) GetInvoiceByIDandCustomer(ctx context.Context, invoiceID, customerID uint) (*models.Invoice, error) {
+func (m *MockInvoiceService) GetInvoiceByIDandCustomer(ctx context.Context, invoiceID uint) (*models.Invoice, error) {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "GetInvoiceByIDandCustomer", ctx, invoiceID, customerID)
+	ret := m.ctrl.Call(m, "GetInvoiceByIDandCustomer", ctx, invoiceID)
 	ret0, _ := ret[0].(*models.Invoice)
 	ret1, _ := ret[1].(error)
 	return ret0, ret1
 }
 
 // GetInvoiceByIDandCustomer indicates an expected call of GetInvoiceByIDandCustomer.
-func (mr *MockInvoiceServiceMockRecorder) GetInvoiceByIDandCustomer(ctx, invoiceID, customerID any) *gomock.Call {
+func (mr *MockInvoiceServiceMockRecorder) GetInvoiceByIDandCustomer(ctx, invoiceID any) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetInvoiceByIDandCustomer", reflect.TypeOf((*MockInvoiceService)(nil).GetInvoiceByIDandCustomer), ctx, invoiceID, customerID)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetInvoiceByIDandCustomer", reflect.TypeOf((*MockInvoiceService)(nil).GetInvoiceByIDandCustomer), ctx, invoiceID)
 }
 
 // GetInvoiceDetails mocks base method.
@@ -134,59 +163,76 @@ func (mr *MockInvoiceServiceMockRecorder) GetInvoiceDetails(ctx, invoiceID any)
 }
 
 // GetInvoiceStatistics mocks base method.
-func (m *MockInvoiceService) GetInvoiceStatistics(ctx context.Context, customerID uint) (*response_dto.GetInvoiceStatisticsResponse, error) {
+func (m *MockInvoiceService) GetInvoiceStatistics(ctx context.Context) (*response_dto.GetInvoiceStatisticsResponse, error) {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "GetInvoiceStatistics", ctx, customerID)
+	ret := m.ctrl.Call(m, "GetInvoiceStatistics", ctx)
 	ret0, _ := ret[0].(*response_dto.GetInvoiceStatisticsResponse)
 	ret1, _ := ret[1].(error)
 	return ret0, ret1
 }
 
 // GetInvoiceStatistics indicates an expected call of GetInvoiceStatistics.
-func (mr *MockInvoiceServiceMockRecorder) GetInvoiceStatistics(ctx, customerID any) *gomock.Call {
+func (mr *MockInvoiceServiceMockRecorder) GetInvoiceStatistics(ctx any) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetInvoiceStatistics", reflect.TypeOf((*MockInvoiceService)(nil).GetInvoiceStatistics), ctx, customerID)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetInvoiceStatistics", reflect.TypeOf((*MockInvoiceService)(nil).GetInvoiceStatistics), ctx)
 }
 
-// GetShareableLink mocks base method.
-func (m *MockInvoiceService) GetShareableLink(ctx context.Context, invoice *models.Invoice) (string, error) {
+// MarkOverdueInvoices mocks base method.
+func (m *MockInvoiceService) MarkOverdueInvoices(ctx context.Context, now time.Time) (int, error) {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "GetShareableLink", ctx, invoice)
-	ret0, _ := ret[0].(string)
+	ret := m.ctrl.Call(m, "MarkOverdueInvoices", ctx, now)
+	ret0, _ := ret[0].(int)
 	ret1, _ := ret[1].(error)
 	return ret0, ret1
 }
 
-// GetShareableLink indicates an expected call of GetShareableLink.
-func (mr *MockInvoiceServiceMockRecorder) GetShareableLink(ctx, invoice any) *gomock.Call {
+// MarkOverdueInvoices indicates an expected call of MarkOverdueInvoices.
+func (mr *MockInvoiceServiceMockRecorder) MarkOverdueInvoices(ctx, now any) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetShareableLink", reflect.TypeOf((*MockInvoiceService)(nil).GetShareableLink), ctx, invoice)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "MarkOverdueInvoices", reflect.TypeOf((*MockInvoiceService)(nil).MarkOverdueInvoices), ctx, now)
 }
 
-// SetInvoiceStatusIfFullyPaid mocks base method.
-func (m *MockInvoiceService) SetInvoiceStatusIfFullyPaid(ctx context.Context, invoice *models.Invoice) error {
+// PreviewInvoice mocks base method.
+func (m *MockInvoiceService) PreviewInvoice(ctx context.Context, customerID uint, request *request_dto.CreateInvoiceRequest) (*models.Invoice, error) {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "SetInvoiceStatusIfFullyPaid", ctx, invoice)
-	ret0, _ := ret[0].(error)
-	return ret0
+	ret := m.ctrl.Call(m, "PreviewInvoice", ctx, customerID, request)
+	ret0, _ := ret[0].(*models.Invoice)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
 }
 
-// SetInvoiceStatusIfFullyPaid indicates an expected call of SetInvoiceStatusIfFullyPaid.
-func (mr *MockInvoiceServiceMockRecorder) SetInvoiceStatusIfFullyPaid(ctx, invoice any) *gomock.Call {
+// PreviewInvoice indicates an expected call of PreviewInvoice.
+func (mr *MockInvoiceServiceMockRecorder) PreviewInvoice(ctx, customerID, request any) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SetInvoiceStatusIfFullyPaid", reflect.TypeOf((*MockInvoiceService)(nil).SetInvoiceStatusIfFullyPaid), ctx, invoice)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "PreviewInvoice", reflect.TypeOf((*MockInvoiceService)(nil).PreviewInvoice), ctx, customerID, request)
 }
 
-// ValidatePaymentAmount mocks base method.
-func (m *MockInvoiceService) ValidatePaymentAmount(ctx context.Context, amount float64, invoice *models.Invoice, isPartial bool) error {
+// RenderInvoicePDF mocks base method.
+func (m *MockInvoiceService) RenderInvoicePDF(ctx context.Context, invoiceID uint) ([]byte, error) {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "ValidatePaymentAmount", ctx, amount, invoice, isPartial)
-	ret0, _ := ret[0].(error)
-	return ret0
+	ret := m.ctrl.Call(m, "RenderInvoicePDF", ctx, invoiceID)
+	ret0, _ := ret[0].([]byte)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// RenderInvoicePDF indicates an expected call of RenderInvoicePDF.
+func (mr *MockInvoiceServiceMockRecorder) RenderInvoicePDF(ctx, invoiceID any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "RenderInvoicePDF", reflect.TypeOf((*MockInvoiceService)(nil).RenderInvoicePDF), ctx, invoiceID)
+}
+
+// UpdateInvoice mocks base method.
+func (m *MockInvoiceService) UpdateInvoice(ctx context.Context, invoice *models.Invoice, version uint, request *request_dto.CreateInvoiceRequest) (*models.Invoice, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "UpdateInvoice", ctx, invoice, version, request)
+	ret0, _ := ret[0].(*models.Invoice)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
 }
 
-// ValidatePaymentAmount indicates an expected call of ValidatePaymentAmount.
-func (mr *MockInvoiceServiceMockRecorder) ValidatePaymentAmount(ctx, amount, invoice, isPartial any) *gomock.Call {
+// UpdateInvoice indicates an expected call of UpdateInvoice.
+func (mr *MockInvoiceServiceMockRecorder) UpdateInvoice(ctx, invoice, version, request any) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ValidatePaymentAmount", reflect.TypeOf((*MockInvoiceService)(nil).ValidatePaymentAmount), ctx, amount, invoice, isPartial)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UpdateInvoice", reflect.TypeOf((*MockInvoiceService)(nil).UpdateInvoice), ctx, invoice, version, request)
 }
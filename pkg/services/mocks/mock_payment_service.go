@@ -0,0 +1,103 @@
+// Code generated by MockGen. DO NOT EDIT.
+// Source: pkg/services/interfaces/payment_service.interface.go
+//
+// Generated by this command:
+//
+//	mockgen -source=pkg/services/interfaces/payment_service.interface.go -destination=pkg/services/mocks/mock_payment_service.go -package=services_mocks
+//
+
+// Package services_mocks is a generated GoMock package.
+package services_mocks
+
+import (
+	context "context"
+	reflect "reflect"
+
+	request_dto "github.com/Adebayobenjamin/numerisbook/pkg/dtos/request"
+	models "github.com/Adebayobenjamin/numerisbook/pkg/models"
+	gomock "go.uber.org/mock/gomock"
+)
+
+// MockPaymentService is a mock of PaymentService interface.
+type MockPaymentService struct {
+	ctrl     *gomock.Controller
+	recorder *MockPaymentServiceMockRecorder
+	isgomock struct{}
+}
+
+// MockPaymentServiceMockRecorder is the mock recorder for MockPaymentService.
+type MockPaymentServiceMockRecorder struct {
+	mock *MockPaymentService
+}
+
+// NewMockPaymentService creates a new mock instance.
+func NewMockPaymentService(ctrl *gomock.Controller) *MockPaymentService {
+	mock := &MockPaymentService{ctrl: ctrl}
+	mock.recorder = &MockPaymentServiceMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockPaymentService) EXPECT() *MockPaymentServiceMockRecorder {
+	return m.recorder
+}
+
+// GetCustomerPayments mocks base method.
+func (m *MockPaymentService) GetCustomerPayments(ctx context.Context, customerID uint, request *request_dto.GetAllPaymentsRequest) ([]models.Payment, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetCustomerPayments", ctx, customerID, request)
+	ret0, _ := ret[0].([]models.Payment)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetCustomerPayments indicates an expected call of GetCustomerPayments.
+func (mr *MockPaymentServiceMockRecorder) GetCustomerPayments(ctx, customerID, request any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetCustomerPayments", reflect.TypeOf((*MockPaymentService)(nil).GetCustomerPayments), ctx, customerID, request)
+}
+
+// GetPaymentByIDAndCustomer mocks base method.
+func (m *MockPaymentService) GetPaymentByIDAndCustomer(ctx context.Context, paymentID, customerID uint) (*models.Payment, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetPaymentByIDAndCustomer", ctx, paymentID, customerID)
+	ret0, _ := ret[0].(*models.Payment)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetPaymentByIDAndCustomer indicates an expected call of GetPaymentByIDAndCustomer.
+func (mr *MockPaymentServiceMockRecorder) GetPaymentByIDAndCustomer(ctx, paymentID, customerID any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetPaymentByIDAndCustomer", reflect.TypeOf((*MockPaymentService)(nil).GetPaymentByIDAndCustomer), ctx, paymentID, customerID)
+}
+
+// RefundPayment mocks base method.
+func (m *MockPaymentService) RefundPayment(ctx context.Context, paymentID uint, request *request_dto.RefundPaymentRequest) (*models.Payment, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "RefundPayment", ctx, paymentID, request)
+	ret0, _ := ret[0].(*models.Payment)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// RefundPayment indicates an expected call of RefundPayment.
+func (mr *MockPaymentServiceMockRecorder) RefundPayment(ctx, paymentID, request any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "RefundPayment", reflect.TypeOf((*MockPaymentService)(nil).RefundPayment), ctx, paymentID, request)
+}
+
+// ReversePayment mocks base method.
+func (m *MockPaymentService) ReversePayment(ctx context.Context, paymentID uint, request *request_dto.ReversePaymentRequest) (*models.Payment, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ReversePayment", ctx, paymentID, request)
+	ret0, _ := ret[0].(*models.Payment)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ReversePayment indicates an expected call of ReversePayment.
+func (mr *MockPaymentServiceMockRecorder) ReversePayment(ctx, paymentID, request any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ReversePayment", reflect.TypeOf((*MockPaymentService)(nil).ReversePayment), ctx, paymentID, request)
+}
@@ -0,0 +1,192 @@
+// Code generated by MockGen. DO NOT EDIT.
+// Source: pkg/services/interfaces/quote_service.interface.go
+//
+// Generated by this command:
+//
+//	mockgen -source=pkg/services/interfaces/quote_service.interface.go -destination=pkg/services/mocks/mock_quote_service.go -package=services_mocks
+//
+
+// Package services_mocks is a generated GoMock package.
+package services_mocks
+
+import (
+	context "context"
+	reflect "reflect"
+	time "time"
+
+	request_dto "github.com/Adebayobenjamin/numerisbook/pkg/dtos/request"
+	response_dto "github.com/Adebayobenjamin/numerisbook/pkg/dtos/response"
+	models "github.com/Adebayobenjamin/numerisbook/pkg/models"
+	gomock "go.uber.org/mock/gomock"
+)
+
+// MockQuoteService is a mock of QuoteService interface.
+type MockQuoteService struct {
+	ctrl     *gomock.Controller
+	recorder *MockQuoteServiceMockRecorder
+	isgomock struct{}
+}
+
+// MockQuoteServiceMockRecorder is the mock recorder for MockQuoteService.
+type MockQuoteServiceMockRecorder struct {
+	mock *MockQuoteService
+}
+
+// NewMockQuoteService creates a new mock instance.
+func NewMockQuoteService(ctrl *gomock.Controller) *MockQuoteService {
+	mock := &MockQuoteService{ctrl: ctrl}
+	mock.recorder = &MockQuoteServiceMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockQuoteService) EXPECT() *MockQuoteServiceMockRecorder {
+	return m.recorder
+}
+
+// AcceptQuote mocks base method.
+func (m *MockQuoteService) AcceptQuote(ctx context.Context, quote *models.Quote) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "AcceptQuote", ctx, quote)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// AcceptQuote indicates an expected call of AcceptQuote.
+func (mr *MockQuoteServiceMockRecorder) AcceptQuote(ctx, quote any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "AcceptQuote", reflect.TypeOf((*MockQuoteService)(nil).AcceptQuote), ctx, quote)
+}
+
+// ConvertQuote mocks base method.
+func (m *MockQuoteService) ConvertQuote(ctx context.Context, quote *models.Quote) (*models.Invoice, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ConvertQuote", ctx, quote)
+	ret0, _ := ret[0].(*models.Invoice)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ConvertQuote indicates an expected call of ConvertQuote.
+func (mr *MockQuoteServiceMockRecorder) ConvertQuote(ctx, quote any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ConvertQuote", reflect.TypeOf((*MockQuoteService)(nil).ConvertQuote), ctx, quote)
+}
+
+// CreateQuote mocks base method.
+func (m *MockQuoteService) CreateQuote(ctx context.Context, customerID uint, request *request_dto.CreateQuoteRequest) (*models.Quote, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CreateQuote", ctx, customerID, request)
+	ret0, _ := ret[0].(*models.Quote)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// CreateQuote indicates an expected call of CreateQuote.
+func (mr *MockQuoteServiceMockRecorder) CreateQuote(ctx, customerID, request any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CreateQuote", reflect.TypeOf((*MockQuoteService)(nil).CreateQuote), ctx, customerID, request)
+}
+
+// DeclineQuote mocks base method.
+func (m *MockQuoteService) DeclineQuote(ctx context.Context, quote *models.Quote, reason string) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "DeclineQuote", ctx, quote, reason)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// DeclineQuote indicates an expected call of DeclineQuote.
+func (mr *MockQuoteServiceMockRecorder) DeclineQuote(ctx, quote, reason any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeclineQuote", reflect.TypeOf((*MockQuoteService)(nil).DeclineQuote), ctx, quote, reason)
+}
+
+// ExpireQuotes mocks base method.
+func (m *MockQuoteService) ExpireQuotes(ctx context.Context, now time.Time) (int64, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ExpireQuotes", ctx, now)
+	ret0, _ := ret[0].(int64)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ExpireQuotes indicates an expected call of ExpireQuotes.
+func (mr *MockQuoteServiceMockRecorder) ExpireQuotes(ctx, now any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ExpireQuotes", reflect.TypeOf((*MockQuoteService)(nil).ExpireQuotes), ctx, now)
+}
+
+// GetCustomerQuotes mocks base method.
+func (m *MockQuoteService) GetCustomerQuotes(ctx context.Context, customerID uint, request *request_dto.GetAllRequest) ([]models.Quote, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetCustomerQuotes", ctx, customerID, request)
+	ret0, _ := ret[0].([]models.Quote)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetCustomerQuotes indicates an expected call of GetCustomerQuotes.
+func (mr *MockQuoteServiceMockRecorder) GetCustomerQuotes(ctx, customerID, request any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetCustomerQuotes", reflect.TypeOf((*MockQuoteService)(nil).GetCustomerQuotes), ctx, customerID, request)
+}
+
+// GetQuoteByIDAndCustomer mocks base method.
+func (m *MockQuoteService) GetQuoteByIDAndCustomer(ctx context.Context, quoteID, customerID uint) (*models.Quote, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetQuoteByIDAndCustomer", ctx, quoteID, customerID)
+	ret0, _ := ret[0].(*models.Quote)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetQuoteByIDAndCustomer indicates an expected call of GetQuoteByIDAndCustomer.
+func (mr *MockQuoteServiceMockRecorder) GetQuoteByIDAndCustomer(ctx, quoteID, customerID any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetQuoteByIDAndCustomer", reflect.TypeOf((*MockQuoteService)(nil).GetQuoteByIDAndCustomer), ctx, quoteID, customerID)
+}
+
+// GetSharedQuote mocks base method.
+func (m *MockQuoteService) GetSharedQuote(ctx context.Context, token string) (*response_dto.PublicQuoteResponse, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetSharedQuote", ctx, token)
+	ret0, _ := ret[0].(*response_dto.PublicQuoteResponse)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetSharedQuote indicates an expected call of GetSharedQuote.
+func (mr *MockQuoteServiceMockRecorder) GetSharedQuote(ctx, token any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetSharedQuote", reflect.TypeOf((*MockQuoteService)(nil).GetSharedQuote), ctx, token)
+}
+
+// RespondToSharedQuote mocks base method.
+func (m *MockQuoteService) RespondToSharedQuote(ctx context.Context, token string, accept bool, reason string) (*response_dto.PublicQuoteResponse, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "RespondToSharedQuote", ctx, token, accept, reason)
+	ret0, _ := ret[0].(*response_dto.PublicQuoteResponse)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// RespondToSharedQuote indicates an expected call of RespondToSharedQuote.
+func (mr *MockQuoteServiceMockRecorder) RespondToSharedQuote(ctx, token, accept, reason any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "RespondToSharedQuote", reflect.TypeOf((*MockQuoteService)(nil).RespondToSharedQuote), ctx, token, accept, reason)
+}
+
+// SendQuote mocks base method.
+func (m *MockQuoteService) SendQuote(ctx context.Context, quote *models.Quote) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "SendQuote", ctx, quote)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// SendQuote indicates an expected call of SendQuote.
+func (mr *MockQuoteServiceMockRecorder) SendQuote(ctx, quote any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SendQuote", reflect.TypeOf((*MockQuoteService)(nil).SendQuote), ctx, quote)
+}
@@ -0,0 +1,118 @@
+// Code generated by MockGen. DO NOT EDIT.
+// Source: pkg/services/interfaces/recurring_invoice_service.interface.go
+//
+// Generated by this command:
+//
+//	mockgen -source=pkg/services/interfaces/recurring_invoice_service.interface.go -destination=pkg/services/mocks/mock_recurring_invoice_service.go -package=services_mocks
+//
+
+// Package services_mocks is a generated GoMock package.
+package services_mocks
+
+import (
+	context "context"
+	reflect "reflect"
+	time "time"
+
+	request_dto "github.com/Adebayobenjamin/numerisbook/pkg/dtos/request"
+	models "github.com/Adebayobenjamin/numerisbook/pkg/models"
+	gomock "go.uber.org/mock/gomock"
+)
+
+// MockRecurringInvoiceService is a mock of RecurringInvoiceService interface.
+type MockRecurringInvoiceService struct {
+	ctrl     *gomock.Controller
+	recorder *MockRecurringInvoiceServiceMockRecorder
+	isgomock struct{}
+}
+
+// MockRecurringInvoiceServiceMockRecorder is the mock recorder for MockRecurringInvoiceService.
+type MockRecurringInvoiceServiceMockRecorder struct {
+	mock *MockRecurringInvoiceService
+}
+
+// NewMockRecurringInvoiceService creates a new mock instance.
+func NewMockRecurringInvoiceService(ctrl *gomock.Controller) *MockRecurringInvoiceService {
+	mock := &MockRecurringInvoiceService{ctrl: ctrl}
+	mock.recorder = &MockRecurringInvoiceServiceMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockRecurringInvoiceService) EXPECT() *MockRecurringInvoiceServiceMockRecorder {
+	return m.recorder
+}
+
+// ChangeProfileStatus mocks base method.
+func (m *MockRecurringInvoiceService) ChangeProfileStatus(ctx context.Context, profile *models.RecurringInvoiceProfile, status models.RecurringProfileStatus) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ChangeProfileStatus", ctx, profile, status)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// ChangeProfileStatus indicates an expected call of ChangeProfileStatus.
+func (mr *MockRecurringInvoiceServiceMockRecorder) ChangeProfileStatus(ctx, profile, status any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ChangeProfileStatus", reflect.TypeOf((*MockRecurringInvoiceService)(nil).ChangeProfileStatus), ctx, profile, status)
+}
+
+// CreateProfile mocks base method.
+func (m *MockRecurringInvoiceService) CreateProfile(ctx context.Context, customerID uint, request *request_dto.CreateRecurringInvoiceRequest) (*models.RecurringInvoiceProfile, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CreateProfile", ctx, customerID, request)
+	ret0, _ := ret[0].(*models.RecurringInvoiceProfile)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// CreateProfile indicates an expected call of CreateProfile.
+func (mr *MockRecurringInvoiceServiceMockRecorder) CreateProfile(ctx, customerID, request any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CreateProfile", reflect.TypeOf((*MockRecurringInvoiceService)(nil).CreateProfile), ctx, customerID, request)
+}
+
+// GenerateDueInvoices mocks base method.
+func (m *MockRecurringInvoiceService) GenerateDueInvoices(ctx context.Context, now time.Time) (int, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GenerateDueInvoices", ctx, now)
+	ret0, _ := ret[0].(int)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GenerateDueInvoices indicates an expected call of GenerateDueInvoices.
+func (mr *MockRecurringInvoiceServiceMockRecorder) GenerateDueInvoices(ctx, now any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GenerateDueInvoices", reflect.TypeOf((*MockRecurringInvoiceService)(nil).GenerateDueInvoices), ctx, now)
+}
+
+// GetCustomerProfiles mocks base method.
+func (m *MockRecurringInvoiceService) GetCustomerProfiles(ctx context.Context, limit, page int, customerID uint) ([]models.RecurringInvoiceProfile, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetCustomerProfiles", ctx, limit, page, customerID)
+	ret0, _ := ret[0].([]models.RecurringInvoiceProfile)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetCustomerProfiles indicates an expected call of GetCustomerProfiles.
+func (mr *MockRecurringInvoiceServiceMockRecorder) GetCustomerProfiles(ctx, limit, page, customerID any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetCustomerProfiles", reflect.TypeOf((*MockRecurringInvoiceService)(nil).GetCustomerProfiles), ctx, limit, page, customerID)
+}
+
+// GetProfileByIDAndCustomer mocks base method.
+func (m *MockRecurringInvoiceService) GetProfileByIDAndCustomer(ctx context.Context, profileID, customerID uint) (*models.RecurringInvoiceProfile, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetProfileByIDAndCustomer", ctx, profileID, customerID)
+	ret0, _ := ret[0].(*models.RecurringInvoiceProfile)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetProfileByIDAndCustomer indicates an expected call of GetProfileByIDAndCustomer.
+func (mr *MockRecurringInvoiceServiceMockRecorder) GetProfileByIDAndCustomer(ctx, profileID, customerID any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetProfileByIDAndCustomer", reflect.TypeOf((*MockRecurringInvoiceService)(nil).GetProfileByIDAndCustomer), ctx, profileID, customerID)
+}
@@ -12,6 +12,7 @@ package services_mocks
 import (
 	context "context"
 	reflect "reflect"
+	time "time"
 
 	models "github.com/Adebayobenjamin/numerisbook/pkg/models"
 	gomock "go.uber.org/mock/gomock"
@@ -41,6 +42,21 @@ func (m *MockRemiderService) EXPECT() *MockRemiderServiceMockRecorder {
 	return m.recorder
 }
 
+// DispatchDueReminders mocks base method.
+func (m *MockRemiderService) DispatchDueReminders(ctx context.Context, now time.Time) (int, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "DispatchDueReminders", ctx, now)
+	ret0, _ := ret[0].(int)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// DispatchDueReminders indicates an expected call of DispatchDueReminders.
+func (mr *MockRemiderServiceMockRecorder) DispatchDueReminders(ctx, now any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DispatchDueReminders", reflect.TypeOf((*MockRemiderService)(nil).DispatchDueReminders), ctx, now)
+}
+
 // SetInvoiceReminders mocks base method.
 func (m *MockRemiderService) SetInvoiceReminders(ctx context.Context, invoice *models.Invoice, customerID uint, shedules map[models.InvoiceReminderSchedule]bool) error {
 	m.ctrl.T.Helper()
@@ -0,0 +1,102 @@
+// Code generated by MockGen. DO NOT EDIT.
+// Source: pkg/services/interfaces/share_link_service.interface.go
+//
+// Generated by this command:
+//
+//	mockgen -source=pkg/services/interfaces/share_link_service.interface.go -destination=pkg/services/mocks/mock_share_link_service.go -package=services_mocks
+//
+
+// Package services_mocks is a generated GoMock package.
+package services_mocks
+
+import (
+	context "context"
+	reflect "reflect"
+
+	response_dto "github.com/Adebayobenjamin/numerisbook/pkg/dtos/response"
+	models "github.com/Adebayobenjamin/numerisbook/pkg/models"
+	gomock "go.uber.org/mock/gomock"
+)
+
+// MockShareLinkService is a mock of ShareLinkService interface.
+type MockShareLinkService struct {
+	ctrl     *gomock.Controller
+	recorder *MockShareLinkServiceMockRecorder
+	isgomock struct{}
+}
+
+// MockShareLinkServiceMockRecorder is the mock recorder for MockShareLinkService.
+type MockShareLinkServiceMockRecorder struct {
+	mock *MockShareLinkService
+}
+
+// NewMockShareLinkService creates a new mock instance.
+func NewMockShareLinkService(ctrl *gomock.Controller) *MockShareLinkService {
+	mock := &MockShareLinkService{ctrl: ctrl}
+	mock.recorder = &MockShareLinkServiceMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockShareLinkService) EXPECT() *MockShareLinkServiceMockRecorder {
+	return m.recorder
+}
+
+// GetShareLink mocks base method.
+func (m *MockShareLinkService) GetShareLink(ctx context.Context, invoice *models.Invoice) (*response_dto.ShareLinkResponse, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetShareLink", ctx, invoice)
+	ret0, _ := ret[0].(*response_dto.ShareLinkResponse)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetShareLink indicates an expected call of GetShareLink.
+func (mr *MockShareLinkServiceMockRecorder) GetShareLink(ctx, invoice any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetShareLink", reflect.TypeOf((*MockShareLinkService)(nil).GetShareLink), ctx, invoice)
+}
+
+// GetSharedInvoice mocks base method.
+func (m *MockShareLinkService) GetSharedInvoice(ctx context.Context, token string) (*response_dto.PublicInvoiceResponse, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetSharedInvoice", ctx, token)
+	ret0, _ := ret[0].(*response_dto.PublicInvoiceResponse)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetSharedInvoice indicates an expected call of GetSharedInvoice.
+func (mr *MockShareLinkServiceMockRecorder) GetSharedInvoice(ctx, token any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetSharedInvoice", reflect.TypeOf((*MockShareLinkService)(nil).GetSharedInvoice), ctx, token)
+}
+
+// RegenerateShareLink mocks base method.
+func (m *MockShareLinkService) RegenerateShareLink(ctx context.Context, invoice *models.Invoice) (*response_dto.ShareLinkResponse, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "RegenerateShareLink", ctx, invoice)
+	ret0, _ := ret[0].(*response_dto.ShareLinkResponse)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// RegenerateShareLink indicates an expected call of RegenerateShareLink.
+func (mr *MockShareLinkServiceMockRecorder) RegenerateShareLink(ctx, invoice any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "RegenerateShareLink", reflect.TypeOf((*MockShareLinkService)(nil).RegenerateShareLink), ctx, invoice)
+}
+
+// RevokeShareLinks mocks base method.
+func (m *MockShareLinkService) RevokeShareLinks(ctx context.Context, invoice *models.Invoice) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "RevokeShareLinks", ctx, invoice)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// RevokeShareLinks indicates an expected call of RevokeShareLinks.
+func (mr *MockShareLinkServiceMockRecorder) RevokeShareLinks(ctx, invoice any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "RevokeShareLinks", reflect.TypeOf((*MockShareLinkService)(nil).RevokeShareLinks), ctx, invoice)
+}
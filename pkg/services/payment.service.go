@@ -0,0 +1,239 @@
+package services
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/Adebayobenjamin/numerisbook/pkg/auth"
+	"github.com/Adebayobenjamin/numerisbook/pkg/common/money"
+	request_dto "github.com/Adebayobenjamin/numerisbook/pkg/dtos/request"
+	"github.com/Adebayobenjamin/numerisbook/pkg/helper"
+	"github.com/Adebayobenjamin/numerisbook/pkg/models"
+	repositories_interfaces "github.com/Adebayobenjamin/numerisbook/pkg/repositories/interfaces"
+	services_interfaces "github.com/Adebayobenjamin/numerisbook/pkg/services/interfaces"
+	"github.com/rs/zerolog"
+)
+
+type paymentService struct {
+	logger            *zerolog.Logger
+	paymentRepository repositories_interfaces.PaymentRepository
+}
+
+// GetPaymentByIDAndCustomer implements services_interfaces.PaymentService.
+func (p *paymentService) GetPaymentByIDAndCustomer(ctx context.Context, paymentID uint, customerID uint) (*models.Payment, error) {
+	return p.paymentRepository.GetByIDAndCustomerID(ctx, paymentID, customerID)
+}
+
+// GetCustomerPayments implements services_interfaces.PaymentService.
+func (p *paymentService) GetCustomerPayments(ctx context.Context, customerID uint, request *request_dto.GetAllPaymentsRequest) ([]models.Payment, error) {
+	offset := helper.GetOffset(request.Page, request.Limit)
+	return p.paymentRepository.GetAllCustomerPayments(ctx, customerID, request.Method, request.InvoiceID, request.Limit, offset)
+}
+
+// RefundPayment implements services_interfaces.PaymentService.
+func (p *paymentService) RefundPayment(ctx context.Context, paymentID uint, request *request_dto.RefundPaymentRequest) (*models.Payment, error) {
+	if err := auth.Authorize(ctx, auth.PermissionPaymentsConfirm); err != nil {
+		return nil, err
+	}
+
+	date := time.Now().UTC()
+	if request.Date != nil {
+		date = *request.Date
+	}
+
+	refund := &models.Payment{
+		Kind:              models.PaymentKindRefund,
+		OriginalPaymentID: &paymentID,
+		Date:              date,
+		Method:            request.Method,
+		Reference:         request.Reference,
+		Reason:            request.Reason,
+	}
+	if err := p.returnPayment(ctx, refund, request.Amount); err != nil {
+		return nil, err
+	}
+
+	return refund, nil
+}
+
+// ReversePayment implements services_interfaces.PaymentService.
+func (p *paymentService) ReversePayment(ctx context.Context, paymentID uint, request *request_dto.ReversePaymentRequest) (*models.Payment, error) {
+	if err := auth.Authorize(ctx, auth.PermissionPaymentsConfirm); err != nil {
+		return nil, err
+	}
+
+	reversal := &models.Payment{
+		Kind:              models.PaymentKindReversal,
+		OriginalPaymentID: &paymentID,
+		Date:              time.Now().UTC(),
+		Reason:            request.Reason,
+	}
+	if err := p.returnPayment(ctx, reversal, nil); err != nil {
+		return nil, err
+	}
+
+	return reversal, nil
+}
+
+// returnPayment records entry, a refund or reversal, taking back requested
+// from its payment or, when nil, everything the payment has left
+func (p *paymentService) returnPayment(ctx context.Context, entry *models.Payment, requested *money.Money) error {
+	now := time.Now().UTC()
+
+	// the payment is checked and the refund recorded with the invoice locked,
+	// so two refunds made at the same time cannot both take the same money back
+	_, err := p.paymentRepository.RecordReturn(ctx, entry, func(invoice *models.Invoice, paid money.Money, original *models.Payment, returned money.Money) ([]models.AuditTrail, error) {
+		amount, err := settleReturn(invoice, entry, original, returned, requested)
+		if err != nil {
+			return nil, err
+		}
+
+		entry.Amount = amount.Neg()
+		if entry.Method == "" {
+			entry.Method = original.Method
+		}
+
+		eventType := models.EventTypePaymentRefunded
+		action := "Refunded"
+		if entry.Kind == models.PaymentKindReversal {
+			eventType = models.EventTypePaymentReversed
+			action = "Reversed"
+		}
+
+		auditTrails := []models.AuditTrail{{
+			EventType: eventType,
+			LogLevel:  models.LogLevelInfo,
+			Message:   fmt.Sprintf("%s %s of Payment %d on Invoice %s: %s", action, amount, original.ID, invoice.InvoiceNumber, entry.Reason),
+		}}
+
+		// the repository undoes what the reversed payment did to the client's
+		// credit along with it
+		if entry.Kind == models.PaymentKindReversal {
+			switch {
+			case original.Kind == models.PaymentKindCredit:
+				auditTrails = append(auditTrails, models.AuditTrail{
+					EventType: models.EventTypeClientCredited,
+					LogLevel:  models.LogLevelInfo,
+					Message:   fmt.Sprintf("Returned %s applied to Invoice %s to Client %s's credit", amount, invoice.InvoiceNumber, invoice.InvoiceClient.Name),
+				})
+			case original.Overpayment != nil:
+				auditTrails = append(auditTrails, models.AuditTrail{
+					EventType: models.EventTypeClientCredited,
+					LogLevel:  models.LogLevelInfo,
+					Message:   fmt.Sprintf("Took back %s overpaid by Payment %d from Client %s's credit", original.Overpayment, original.ID, invoice.InvoiceClient.Name),
+				})
+			}
+		}
+
+		status, err := statusAfterReturn(invoice, paid, amount, now)
+		if err != nil {
+			return nil, err
+		}
+		if status != invoice.Status {
+			auditTrails = append(auditTrails, models.AuditTrail{
+				EventType: models.EventTypeInvoiceStatusChanged,
+				LogLevel:  models.LogLevelInfo,
+				Message:   fmt.Sprintf("Invoice %s moved from %s to %s", invoice.InvoiceNumber, invoice.Status, status),
+			})
+		}
+
+		invoice.Status = status
+		invoice.IsFullyPaid = status == models.InvoiceStatusPaid
+		return auditTrails, nil
+	})
+
+	return err
+}
+
+// settleReturn works out how much entry, a refund or reversal, takes back
+// from original, of which returned was already taken back. A nil requested
+// amount takes back everything left. Credit applied from a client's balance
+// was never paid in, so it can be reversed but not refunded.
+func settleReturn(invoice *models.Invoice, entry *models.Payment, original *models.Payment, returned money.Money, requested *money.Money) (money.Money, error) {
+	switch original.Kind {
+	case models.PaymentKindPayment:
+	case models.PaymentKindCredit:
+		if entry.Kind != models.PaymentKindReversal {
+			return money.Money{}, fmt.Errorf("credit applied from a client's balance can only be reversed")
+		}
+	default:
+		return money.Money{}, fmt.Errorf("a %s cannot be refunded or reversed", original.Kind)
+	}
+
+	left, err := original.Amount.Sub(returned)
+	if err != nil {
+		return money.Money{}, fmt.Errorf("payment currency does not match invoice: %w", err)
+	}
+	if !left.IsPositive() {
+		return money.Money{}, fmt.Errorf("payment %d was already refunded in full", original.ID)
+	}
+
+	if requested == nil {
+		return left, nil
+	}
+
+	// the request only carries a number, the currency is always the invoice's
+	amount, err := requested.WithCurrency(invoice.BillingCurrency)
+	if err != nil {
+		return money.Money{}, err
+	}
+	if !amount.IsPositive() {
+		return money.Money{}, fmt.Errorf("refund amount must be greater than zero")
+	}
+
+	comparison, err := amount.Cmp(left)
+	if err != nil {
+		return money.Money{}, fmt.Errorf("refund currency does not match invoice: %w", err)
+	}
+	if comparison > 0 {
+		return money.Money{}, fmt.Errorf("refund amount exceeds the %s left on payment %d", left, original.ID)
+	}
+
+	return amount, nil
+}
+
+// statusAfterReturn is the invoice's status once amount of what it was paid
+// is taken back. Only the statuses payments move an invoice into are worked
+// out again; a void or written off invoice stays as it is.
+func statusAfterReturn(invoice *models.Invoice, paid money.Money, amount money.Money, now time.Time) (models.InvoiceStatus, error) {
+	if invoice.Status != models.InvoiceStatusPaid && invoice.Status != models.InvoiceStatusPartiallyPaid {
+		return invoice.Status, nil
+	}
+
+	paidAfterReturn, err := paid.Sub(amount)
+	if err != nil {
+		return "", fmt.Errorf("refund currency does not match invoice: %w", err)
+	}
+
+	payable, err := invoice.AmountPayable()
+	if err != nil {
+		return "", fmt.Errorf("failed to work out the amount payable: %w", err)
+	}
+
+	comparison, err := paidAfterReturn.Cmp(payable)
+	if err != nil {
+		return "", fmt.Errorf("refund currency does not match invoice: %w", err)
+	}
+
+	switch {
+	case comparison >= 0:
+		return models.InvoiceStatusPaid, nil
+	case paidAfterReturn.IsPositive():
+		return models.InvoiceStatusPartiallyPaid, nil
+	case invoice.DueDate.Before(now):
+		return models.InvoiceStatusOverdue, nil
+	default:
+		return models.InvoiceStatusSent, nil
+	}
+}
+
+func NewPaymentService(
+	logger *zerolog.Logger,
+	paymentRepository repositories_interfaces.PaymentRepository,
+) services_interfaces.PaymentService {
+	return &paymentService{
+		logger:            logger,
+		paymentRepository: paymentRepository,
+	}
+}
@@ -0,0 +1,207 @@
+package services
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/Adebayobenjamin/numerisbook/pkg/auth"
+	"github.com/Adebayobenjamin/numerisbook/pkg/common/money"
+	request_dto "github.com/Adebayobenjamin/numerisbook/pkg/dtos/request"
+	"github.com/Adebayobenjamin/numerisbook/pkg/models"
+	repositories_interfaces "github.com/Adebayobenjamin/numerisbook/pkg/repositories/interfaces"
+	repository_mocks "github.com/Adebayobenjamin/numerisbook/pkg/repositories/mocks"
+	"github.com/rs/zerolog"
+	"github.com/stretchr/testify/assert"
+	"go.uber.org/mock/gomock"
+)
+
+func setupPaymentTest(t *testing.T) (*repository_mocks.MockPaymentRepository, *paymentService) {
+	ctrl := gomock.NewController(t)
+	logger := zerolog.Nop()
+	mockPaymentRepo := repository_mocks.NewMockPaymentRepository(ctrl)
+	service := NewPaymentService(&logger, mockPaymentRepo).(*paymentService)
+	return mockPaymentRepo, service
+}
+
+func TestRefundPayment(t *testing.T) {
+	ctx := auth.WithPrincipal(context.Background(), &auth.Principal{CustomerID: 1, Role: models.UserRoleAccountant})
+	original := &models.Payment{ID: 5, InvoiceID: 1, Kind: models.PaymentKindPayment, Amount: money.New(10000, "USD"), Method: models.PaymentMethodCard}
+	paidInvoice := func() *models.Invoice {
+		return &models.Invoice{ID: 1, InvoiceNumber: "INV-001", BillingCurrency: "USD", TotalAmountDue: money.New(10000, "USD"), Status: models.InvoiceStatusPaid, IsFullyPaid: true, DueDate: time.Now().Add(24 * time.Hour)}
+	}
+
+	t.Run("a partial refund leaves the invoice partially paid", func(t *testing.T) {
+		mockPaymentRepo, service := setupPaymentTest(t)
+		amount := money.New(2500, "")
+		mockPaymentRepo.EXPECT().
+			RecordReturn(ctx, gomock.Any(), gomock.Any()).
+			DoAndReturn(func(_ context.Context, refund *models.Payment, settle repositories_interfaces.SettleReturn) (*models.Invoice, error) {
+				assert.Equal(t, models.PaymentKindRefund, refund.Kind)
+				assert.Equal(t, uint(5), *refund.OriginalPaymentID)
+
+				invoice := paidInvoice()
+				auditTrails, err := settle(invoice, money.New(10000, "USD"), original, money.Zero("USD"))
+
+				assert.NoError(t, err)
+				assert.Equal(t, models.InvoiceStatusPartiallyPaid, invoice.Status)
+				assert.False(t, invoice.IsFullyPaid)
+				assert.Len(t, auditTrails, 2)
+				assert.Equal(t, models.EventTypePaymentRefunded, auditTrails[0].EventType)
+				assert.Equal(t, "Refunded 25.00 of Payment 5 on Invoice INV-001: Returned goods", auditTrails[0].Message)
+				assert.Equal(t, "Invoice INV-001 moved from paid to partially_paid", auditTrails[1].Message)
+				return invoice, nil
+			})
+
+		refund, err := service.RefundPayment(ctx, 5, &request_dto.RefundPaymentRequest{Amount: &amount, Reason: "Returned goods"})
+
+		assert.NoError(t, err)
+		assert.Equal(t, money.New(-2500, "USD"), refund.Amount)
+		// refunds go back the way the payment came in unless told otherwise
+		assert.Equal(t, models.PaymentMethodCard, refund.Method)
+	})
+
+	t.Run("a refund cannot take back more than is left on the payment", func(t *testing.T) {
+		mockPaymentRepo, service := setupPaymentTest(t)
+		amount := money.New(3000, "")
+		mockPaymentRepo.EXPECT().
+			RecordReturn(ctx, gomock.Any(), gomock.Any()).
+			DoAndReturn(func(_ context.Context, _ *models.Payment, settle repositories_interfaces.SettleReturn) (*models.Invoice, error) {
+				invoice := paidInvoice()
+				_, err := settle(invoice, money.New(2000, "USD"), original, money.New(8000, "USD"))
+				assert.Equal(t, models.InvoiceStatusPaid, invoice.Status)
+				return nil, err
+			})
+
+		refund, err := service.RefundPayment(ctx, 5, &request_dto.RefundPaymentRequest{Amount: &amount, Reason: "Returned goods"})
+
+		assert.Nil(t, refund)
+		assert.EqualError(t, err, "refund amount exceeds the 20.00 left on payment 5")
+	})
+
+	t.Run("refunds and reversals cannot themselves be refunded", func(t *testing.T) {
+		mockPaymentRepo, service := setupPaymentTest(t)
+		mockPaymentRepo.EXPECT().
+			RecordReturn(ctx, gomock.Any(), gomock.Any()).
+			DoAndReturn(func(_ context.Context, _ *models.Payment, settle repositories_interfaces.SettleReturn) (*models.Invoice, error) {
+				refund := &models.Payment{ID: 6, Kind: models.PaymentKindRefund, Amount: money.New(-2500, "USD")}
+				_, err := settle(paidInvoice(), money.New(7500, "USD"), refund, money.Zero("USD"))
+				return nil, err
+			})
+
+		_, err := service.RefundPayment(ctx, 6, &request_dto.RefundPaymentRequest{Reason: "Returned goods"})
+
+		assert.EqualError(t, err, "a refund cannot be refunded or reversed")
+	})
+
+	t.Run("applied credit cannot be refunded", func(t *testing.T) {
+		mockPaymentRepo, service := setupPaymentTest(t)
+		mockPaymentRepo.EXPECT().
+			RecordReturn(ctx, gomock.Any(), gomock.Any()).
+			DoAndReturn(func(_ context.Context, _ *models.Payment, settle repositories_interfaces.SettleReturn) (*models.Invoice, error) {
+				credit := &models.Payment{ID: 6, Kind: models.PaymentKindCredit, Amount: money.New(2500, "USD")}
+				_, err := settle(paidInvoice(), money.New(10000, "USD"), credit, money.Zero("USD"))
+				return nil, err
+			})
+
+		_, err := service.RefundPayment(ctx, 6, &request_dto.RefundPaymentRequest{Reason: "Returned goods"})
+
+		assert.EqualError(t, err, "credit applied from a client's balance can only be reversed")
+	})
+
+	t.Run("viewers cannot refund payments", func(t *testing.T) {
+		_, service := setupPaymentTest(t)
+		viewer := auth.WithPrincipal(context.Background(), &auth.Principal{CustomerID: 1, Role: models.UserRoleViewer})
+
+		_, err := service.RefundPayment(viewer, 5, &request_dto.RefundPaymentRequest{Reason: "Returned goods"})
+
+		assert.ErrorIs(t, err, auth.ErrForbidden)
+	})
+}
+
+func TestReversePayment(t *testing.T) {
+	ctx := auth.WithPrincipal(context.Background(), &auth.Principal{CustomerID: 1, Role: models.UserRoleAccountant})
+	original := &models.Payment{ID: 5, InvoiceID: 1, Kind: models.PaymentKindPayment, Amount: money.New(10000, "USD"), Method: models.PaymentMethodCheque}
+
+	t.Run("reversing the only payment reopens an overdue invoice", func(t *testing.T) {
+		mockPaymentRepo, service := setupPaymentTest(t)
+		mockPaymentRepo.EXPECT().
+			RecordReturn(ctx, gomock.Any(), gomock.Any()).
+			DoAndReturn(func(_ context.Context, reversal *models.Payment, settle repositories_interfaces.SettleReturn) (*models.Invoice, error) {
+				invoice := &models.Invoice{ID: 1, InvoiceNumber: "INV-001", BillingCurrency: "USD", TotalAmountDue: money.New(10000, "USD"), Status: models.InvoiceStatusPaid, IsFullyPaid: true, DueDate: time.Now().Add(-24 * time.Hour)}
+				// a quarter of the payment was already refunded, the rest is reversed
+				auditTrails, err := settle(invoice, money.New(7500, "USD"), original, money.New(2500, "USD"))
+
+				assert.NoError(t, err)
+				assert.Equal(t, models.InvoiceStatusOverdue, invoice.Status)
+				assert.Equal(t, models.EventTypePaymentReversed, auditTrails[0].EventType)
+				assert.Equal(t, "Reversed 75.00 of Payment 5 on Invoice INV-001: Cheque bounced", auditTrails[0].Message)
+				return invoice, nil
+			})
+
+		reversal, err := service.ReversePayment(ctx, 5, &request_dto.ReversePaymentRequest{Reason: "Cheque bounced"})
+
+		assert.NoError(t, err)
+		assert.Equal(t, models.PaymentKindReversal, reversal.Kind)
+		assert.Equal(t, money.New(-7500, "USD"), reversal.Amount)
+		assert.Equal(t, "Cheque bounced", reversal.Reason)
+	})
+
+	t.Run("reversing an overpaying payment takes back its credit", func(t *testing.T) {
+		mockPaymentRepo, service := setupPaymentTest(t)
+		mockPaymentRepo.EXPECT().
+			RecordReturn(ctx, gomock.Any(), gomock.Any()).
+			DoAndReturn(func(_ context.Context, _ *models.Payment, settle repositories_interfaces.SettleReturn) (*models.Invoice, error) {
+				invoice := &models.Invoice{ID: 1, InvoiceNumber: "INV-001", BillingCurrency: "USD", TotalAmountDue: money.New(10000, "USD"), Status: models.InvoiceStatusPaid, IsFullyPaid: true, DueDate: time.Now().Add(24 * time.Hour), InvoiceClient: models.InvoiceClient{Name: "Acme"}}
+				overpayment := money.New(2500, "USD")
+				overpaid := &models.Payment{ID: 5, InvoiceID: 1, Kind: models.PaymentKindPayment, Amount: money.New(10000, "USD"), Overpayment: &overpayment}
+				auditTrails, err := settle(invoice, money.New(10000, "USD"), overpaid, money.Zero("USD"))
+
+				assert.NoError(t, err)
+				assert.Len(t, auditTrails, 3)
+				assert.Equal(t, models.EventTypeClientCredited, auditTrails[1].EventType)
+				assert.Equal(t, "Took back 25.00 overpaid by Payment 5 from Client Acme's credit", auditTrails[1].Message)
+				return invoice, nil
+			})
+
+		_, err := service.ReversePayment(ctx, 5, &request_dto.ReversePaymentRequest{Reason: "Cheque bounced"})
+
+		assert.NoError(t, err)
+	})
+
+	t.Run("reversing applied credit returns it to the client", func(t *testing.T) {
+		mockPaymentRepo, service := setupPaymentTest(t)
+		mockPaymentRepo.EXPECT().
+			RecordReturn(ctx, gomock.Any(), gomock.Any()).
+			DoAndReturn(func(_ context.Context, _ *models.Payment, settle repositories_interfaces.SettleReturn) (*models.Invoice, error) {
+				invoice := &models.Invoice{ID: 1, InvoiceNumber: "INV-001", BillingCurrency: "USD", TotalAmountDue: money.New(10000, "USD"), Status: models.InvoiceStatusPaid, IsFullyPaid: true, DueDate: time.Now().Add(24 * time.Hour), InvoiceClient: models.InvoiceClient{Name: "Acme"}}
+				credit := &models.Payment{ID: 6, InvoiceID: 1, Kind: models.PaymentKindCredit, Amount: money.New(3000, "USD")}
+				auditTrails, err := settle(invoice, money.New(10000, "USD"), credit, money.Zero("USD"))
+
+				assert.NoError(t, err)
+				assert.Equal(t, models.InvoiceStatusPartiallyPaid, invoice.Status)
+				assert.Equal(t, "Returned 30.00 applied to Invoice INV-001 to Client Acme's credit", auditTrails[1].Message)
+				return invoice, nil
+			})
+
+		reversal, err := service.ReversePayment(ctx, 6, &request_dto.ReversePaymentRequest{Reason: "Applied to the wrong invoice"})
+
+		assert.NoError(t, err)
+		assert.Equal(t, money.New(-3000, "USD"), reversal.Amount)
+	})
+
+	t.Run("a fully refunded payment cannot be reversed", func(t *testing.T) {
+		mockPaymentRepo, service := setupPaymentTest(t)
+		mockPaymentRepo.EXPECT().
+			RecordReturn(ctx, gomock.Any(), gomock.Any()).
+			DoAndReturn(func(_ context.Context, _ *models.Payment, settle repositories_interfaces.SettleReturn) (*models.Invoice, error) {
+				invoice := &models.Invoice{ID: 1, BillingCurrency: "USD", TotalAmountDue: money.New(10000, "USD"), Status: models.InvoiceStatusSent}
+				_, err := settle(invoice, money.Zero("USD"), original, money.New(10000, "USD"))
+				return nil, err
+			})
+
+		_, err := service.ReversePayment(ctx, 5, &request_dto.ReversePaymentRequest{Reason: "Cheque bounced"})
+
+		assert.EqualError(t, err, "payment 5 was already refunded in full")
+	})
+}
@@ -0,0 +1,432 @@
+package services
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"os"
+	"strings"
+	"time"
+
+	"github.com/Adebayobenjamin/numerisbook/pkg/auth"
+	"github.com/Adebayobenjamin/numerisbook/pkg/common/exceptions"
+	"github.com/Adebayobenjamin/numerisbook/pkg/common/money"
+	request_dto "github.com/Adebayobenjamin/numerisbook/pkg/dtos/request"
+	response_dto "github.com/Adebayobenjamin/numerisbook/pkg/dtos/response"
+	"github.com/Adebayobenjamin/numerisbook/pkg/helper"
+	"github.com/Adebayobenjamin/numerisbook/pkg/models"
+	repositories_interfaces "github.com/Adebayobenjamin/numerisbook/pkg/repositories/interfaces"
+	services_interfaces "github.com/Adebayobenjamin/numerisbook/pkg/services/interfaces"
+	"github.com/Adebayobenjamin/numerisbook/pkg/tenant"
+	"github.com/rs/zerolog"
+)
+
+// quoteTransitions lists the statuses a quote may move to from each status.
+// Accepted, declined and expired quotes are final.
+var quoteTransitions = map[models.QuoteStatus][]models.QuoteStatus{
+	models.QuoteStatusDraft: {models.QuoteStatusSent},
+	models.QuoteStatusSent:  {models.QuoteStatusAccepted, models.QuoteStatusDeclined, models.QuoteStatusExpired},
+}
+
+type quoteService struct {
+	logger            *zerolog.Logger
+	quoteRepository   repositories_interfaces.QuoteRepository
+	invoiceRepository repositories_interfaces.InvoiceRepository
+	invoiceService    services_interfaces.InvoiceService
+	auditService      services_interfaces.AuditService
+	secret            []byte
+	baseURL           string
+}
+
+// CreateQuote implements services_interfaces.QuoteService.
+func (q *quoteService) CreateQuote(ctx context.Context, customerID uint, request *request_dto.CreateQuoteRequest) (*models.Quote, error) {
+	if err := auth.Authorize(ctx, auth.PermissionInvoicesWrite); err != nil {
+		return nil, err
+	}
+
+	// stored timestamps have second precision and the share token has to match them
+	expiryDate := request.ExpiryDate.UTC().Truncate(time.Second)
+	if !expiryDate.After(time.Now()) {
+		return nil, fmt.Errorf("expiry date must be in the future")
+	}
+	if expiryDate.Before(request.IssueDate) {
+		return nil, fmt.Errorf("expiry date cannot be before the issue date")
+	}
+
+	// the quote is priced exactly like the invoice it will become
+	priced, err := q.invoiceService.PreviewInvoice(ctx, customerID, request.InvoiceRequest())
+	if err != nil {
+		return nil, err
+	}
+
+	quote := &models.Quote{
+		CustomerID:      customerID,
+		Status:          models.QuoteStatusDraft,
+		IssueDate:       priced.IssueDate,
+		ExpiryDate:      expiryDate,
+		DueInDays:       request.DueInDays,
+		BillingCurrency: priced.BillingCurrency,
+		Subtotal:        priced.Subtotal,
+		Discount:        priced.Discount,
+		DiscountType:    priced.DiscountType,
+		DiscountRate:    priced.DiscountRate,
+		DiscountTotal:   priced.DiscountTotal,
+		TaxTotal:        priced.TaxTotal,
+		TotalAmount:     priced.TotalAmountDue,
+		Notes:           priced.Notes,
+		Items:           models.QuoteItems(priced.Items),
+		InvoiceIssuer:   priced.InvoiceIssuer,
+		InvoiceClient:   priced.InvoiceClient,
+	}
+	if priced.PaymentInfo != nil {
+		quote.PaymentInfo = models.QuotePaymentInfo(*priced.PaymentInfo)
+	}
+
+	return q.quoteRepository.Create(ctx, quote)
+}
+
+// GetQuoteByIDAndCustomer implements services_interfaces.QuoteService.
+func (q *quoteService) GetQuoteByIDAndCustomer(ctx context.Context, quoteID uint, customerID uint) (*models.Quote, error) {
+	quote, err := q.quoteRepository.GetByIDAndCustomerID(ctx, quoteID, customerID)
+	if err != nil {
+		return nil, err
+	}
+
+	q.assignShareURL(quote)
+	return quote, nil
+}
+
+// GetCustomerQuotes implements services_interfaces.QuoteService.
+func (q *quoteService) GetCustomerQuotes(ctx context.Context, customerID uint, request *request_dto.GetAllRequest) ([]models.Quote, error) {
+	offset := helper.GetOffset(request.Page, request.Limit)
+	return q.quoteRepository.GetAllCustomerQuotes(ctx, customerID, request.Limit, offset)
+}
+
+// SendQuote implements services_interfaces.QuoteService.
+func (q *quoteService) SendQuote(ctx context.Context, quote *models.Quote) error {
+	if err := auth.Authorize(ctx, auth.PermissionInvoicesWrite); err != nil {
+		return err
+	}
+	if len(q.secret) == 0 {
+		return fmt.Errorf("share links are not configured")
+	}
+
+	now := time.Now().UTC()
+	if !quote.ExpiryDate.After(now) {
+		return fmt.Errorf("quote %s expired on %s and cannot be sent", quote.QuoteNumber, quote.ExpiryDate.Format(time.DateOnly))
+	}
+
+	tokenID, err := newShareTokenID()
+	if err != nil {
+		return err
+	}
+
+	err = q.changeStatus(ctx, quote, models.QuoteStatusSent, func(updated *models.Quote) {
+		updated.ShareTokenID = &tokenID
+		updated.SentAt = &now
+	})
+	if err != nil {
+		return err
+	}
+
+	q.assignShareURL(quote)
+	return nil
+}
+
+// AcceptQuote implements services_interfaces.QuoteService.
+func (q *quoteService) AcceptQuote(ctx context.Context, quote *models.Quote) error {
+	if err := auth.Authorize(ctx, auth.PermissionInvoicesWrite); err != nil {
+		return err
+	}
+
+	return q.respond(ctx, quote, true, "")
+}
+
+// DeclineQuote implements services_interfaces.QuoteService.
+func (q *quoteService) DeclineQuote(ctx context.Context, quote *models.Quote, reason string) error {
+	if err := auth.Authorize(ctx, auth.PermissionInvoicesWrite); err != nil {
+		return err
+	}
+
+	return q.respond(ctx, quote, false, reason)
+}
+
+// ConvertQuote implements services_interfaces.QuoteService.
+func (q *quoteService) ConvertQuote(ctx context.Context, quote *models.Quote) (*models.Invoice, error) {
+	if err := auth.Authorize(ctx, auth.PermissionInvoicesWrite); err != nil {
+		return nil, err
+	}
+
+	if quote.Status != models.QuoteStatusAccepted {
+		return nil, fmt.Errorf("quote %s is %s and only accepted quotes can be converted", quote.QuoteNumber, quote.Status)
+	}
+	if quote.ConvertedAt != nil {
+		return nil, fmt.Errorf("quote %s has already been converted and %w", quote.QuoteNumber, exceptions.ErrLocked)
+	}
+
+	// the conversion is claimed before the invoice is created, so a quote
+	// converted twice at the same time still gives a single invoice
+	now := time.Now().UTC()
+	if err := q.quoteRepository.ClaimForConversion(ctx, quote.ID, quote.CustomerID, now); err != nil {
+		return nil, err
+	}
+
+	invoice, err := q.invoiceRepository.CreateInvoiceWithItems(ctx, quoteToInvoice(quote, now))
+	if err != nil {
+		cause := fmt.Errorf("failed to create invoice: %w", err)
+		if releaseErr := q.quoteRepository.ReleaseConversion(ctx, quote.ID, quote.CustomerID); releaseErr != nil {
+			return nil, fmt.Errorf("%w (and failed to release the quote: %v)", cause, releaseErr)
+		}
+		return nil, cause
+	}
+
+	// the invoice exists from here on, so later failures are only logged
+	if err = q.quoteRepository.SetInvoice(ctx, quote.ID, quote.CustomerID, invoice.ID); err != nil {
+		q.logger.Error().Err(err).Uint("quote_id", quote.ID).Uint("invoice_id", invoice.ID).Msg("failed to link quote to invoice")
+	}
+
+	err = q.auditService.CreateQuoteAuditTrail(
+		ctx,
+		models.EventTypeQuoteConverted,
+		models.LogLevelInfo,
+		fmt.Sprintf("Invoice %s created from quote %s", invoice.InvoiceNumber, quote.QuoteNumber),
+		invoice.ID,
+		quote.ID,
+		quote.CustomerID,
+	)
+	if err != nil {
+		q.logger.Error().Err(err).Uint("quote_id", quote.ID).Uint("invoice_id", invoice.ID).Msg("failed to audit quote conversion")
+	}
+
+	quote.ConvertedAt = &now
+	quote.InvoiceID = &invoice.ID
+	return invoice, nil
+}
+
+// GetSharedQuote implements services_interfaces.QuoteService.
+func (q *quoteService) GetSharedQuote(ctx context.Context, token string) (*response_dto.PublicQuoteResponse, error) {
+	quote, err := q.sharedQuote(ctx, token)
+	if err != nil {
+		return nil, err
+	}
+
+	return buildPublicQuote(quote, time.Now().UTC()), nil
+}
+
+// RespondToSharedQuote implements services_interfaces.QuoteService.
+func (q *quoteService) RespondToSharedQuote(ctx context.Context, token string, accept bool, reason string) (*response_dto.PublicQuoteResponse, error) {
+	quote, err := q.sharedQuote(ctx, token)
+	if err != nil {
+		return nil, err
+	}
+
+	// the token is the only credential, the quote decides whose records are changed
+	ctx = auth.WithSystemPrincipal(tenant.WithCustomerID(ctx, quote.CustomerID), quote.CustomerID)
+
+	if err := q.respond(ctx, quote, accept, reason); err != nil {
+		return nil, err
+	}
+
+	return buildPublicQuote(quote, time.Now().UTC()), nil
+}
+
+// ExpireQuotes implements services_interfaces.QuoteService.
+func (q *quoteService) ExpireQuotes(ctx context.Context, now time.Time) (int64, error) {
+	return q.quoteRepository.ExpireLapsed(ctx, now)
+}
+
+// respond records the client's answer to a sent quote. A quote left
+// unanswered past its expiry date is marked expired instead.
+func (q *quoteService) respond(ctx context.Context, quote *models.Quote, accept bool, reason string) error {
+	now := time.Now().UTC()
+
+	if quote.HasLapsed(now) {
+		// the worker may not have got to it yet; whoever marks it first wins
+		if err := q.changeStatus(ctx, quote, models.QuoteStatusExpired, nil); err != nil && !errors.Is(err, exceptions.ErrVersionMismatch) {
+			return err
+		}
+		return fmt.Errorf("quote %s expired on %s and %w", quote.QuoteNumber, quote.ExpiryDate.Format(time.DateOnly), exceptions.ErrLocked)
+	}
+
+	if accept {
+		return q.changeStatus(ctx, quote, models.QuoteStatusAccepted, func(updated *models.Quote) {
+			updated.RespondedAt = &now
+		})
+	}
+
+	return q.changeStatus(ctx, quote, models.QuoteStatusDeclined, func(updated *models.Quote) {
+		updated.RespondedAt = &now
+		if reason = strings.TrimSpace(reason); reason != "" {
+			updated.DeclineReason = &reason
+		}
+	})
+}
+
+// changeStatus moves the quote to status if the transition is allowed,
+// applying change to the copy that is stored
+func (q *quoteService) changeStatus(ctx context.Context, quote *models.Quote, status models.QuoteStatus, change func(updated *models.Quote)) error {
+	allowed := false
+	for _, next := range quoteTransitions[quote.Status] {
+		allowed = allowed || next == status
+	}
+	if !allowed {
+		return &exceptions.InvalidStatusTransitionError{
+			Entity: "quote",
+			From:   string(quote.Status),
+			To:     string(status),
+		}
+	}
+
+	updated := *quote
+	updated.Status = status
+	if change != nil {
+		change(&updated)
+	}
+
+	if err := q.quoteRepository.UpdateStatus(ctx, &updated, quote.Status); err != nil {
+		return err
+	}
+
+	*quote = updated
+	return nil
+}
+
+// sharedQuote finds the quote a share token was issued for
+func (q *quoteService) sharedQuote(ctx context.Context, token string) (*models.Quote, error) {
+	notFound := fmt.Errorf("shared quote %w", exceptions.ErrNotFound)
+
+	tokenID, expiresAt, err := parseShareToken(q.secret, token)
+	if err != nil {
+		return nil, notFound
+	}
+
+	quote, err := q.quoteRepository.GetByShareTokenID(ctx, tokenID)
+	if err != nil {
+		if errors.Is(err, exceptions.ErrNotFound) {
+			return nil, notFound
+		}
+		return nil, err
+	}
+	// the expiry is signed into the token, so it must also match the stored one
+	if !quote.ExpiryDate.Equal(expiresAt) {
+		return nil, notFound
+	}
+
+	return quote, nil
+}
+
+// assignShareURL fills in the link a sent quote can be answered at
+func (q *quoteService) assignShareURL(quote *models.Quote) {
+	if quote.ShareTokenID == nil || len(q.secret) == 0 {
+		return
+	}
+	quote.ShareURL = fmt.Sprintf("%s/quote/%s", q.baseURL, signShareToken(q.secret, *quote.ShareTokenID, quote.ExpiryDate))
+}
+
+// quoteToInvoice copies an accepted quote into a new draft invoice, issued on
+// the day of the conversion and due after the quote's payment terms
+func quoteToInvoice(quote *models.Quote, now time.Time) *models.Invoice {
+	invoice := &models.Invoice{
+		CustomerID:      quote.CustomerID,
+		IssueDate:       now,
+		DueDate:         now.AddDate(0, 0, quote.DueInDays),
+		TotalAmountDue:  quote.TotalAmount,
+		CreditedTotal:   money.Zero(quote.BillingCurrency),
+		Subtotal:        quote.Subtotal,
+		TaxTotal:        quote.TaxTotal,
+		BillingCurrency: quote.BillingCurrency,
+		Items:           make([]models.InvoiceItem, 0, len(quote.Items)),
+		Discount:        quote.Discount,
+		DiscountType:    quote.DiscountType,
+		DiscountRate:    quote.DiscountRate,
+		DiscountTotal:   quote.DiscountTotal,
+		Status:          models.InvoiceStatusDraft,
+		Notes:           quote.Notes,
+		InvoiceIssuer:   quote.InvoiceIssuer,
+		InvoiceClient:   quote.InvoiceClient,
+	}
+
+	for _, item := range quote.Items {
+		item.ID, item.InvoiceID = 0, 0
+		taxes := make([]models.InvoiceItemTax, len(item.Taxes))
+		for idx, tax := range item.Taxes {
+			tax.ID, tax.InvoiceItemID, tax.InvoiceID = 0, 0, 0
+			taxes[idx] = tax
+		}
+		item.Taxes = taxes
+		invoice.Items = append(invoice.Items, item)
+	}
+
+	paymentInfo := models.PaymentInfo(quote.PaymentInfo)
+	paymentInfo.ID, paymentInfo.InvoiceID = 0, 0
+	invoice.PaymentInfo = &paymentInfo
+
+	return invoice
+}
+
+// buildPublicQuote copies the parts of a quote that are safe to show to its recipient
+func buildPublicQuote(quote *models.Quote, now time.Time) *response_dto.PublicQuoteResponse {
+	view := &response_dto.PublicQuoteResponse{
+		QuoteNumber:     quote.QuoteNumber,
+		Status:          quote.Status,
+		IssueDate:       quote.IssueDate,
+		ExpiryDate:      quote.ExpiryDate,
+		BillingCurrency: quote.BillingCurrency,
+		From: response_dto.PublicInvoiceParty{
+			Name:    quote.InvoiceIssuer.Name,
+			Email:   quote.InvoiceIssuer.Email,
+			Phone:   quote.InvoiceIssuer.Phone,
+			Address: quote.InvoiceIssuer.Address,
+		},
+		BillTo: response_dto.PublicInvoiceParty{
+			Name:    quote.InvoiceClient.Name,
+			Email:   quote.InvoiceClient.Email,
+			Phone:   quote.InvoiceClient.Phone,
+			Address: quote.InvoiceClient.Address,
+		},
+		Items:         make([]response_dto.PublicInvoiceItem, 0, len(quote.Items)),
+		Subtotal:      quote.Subtotal,
+		DiscountTotal: quote.DiscountTotal,
+		TaxTotal:      quote.TaxTotal,
+		TotalAmount:   quote.TotalAmount,
+		Notes:         quote.Notes,
+		RespondedAt:   quote.RespondedAt,
+	}
+	// a quote the worker has not expired yet is shown as it will be
+	if quote.HasLapsed(now) {
+		view.Status = models.QuoteStatusExpired
+	}
+
+	for _, item := range quote.Items {
+		view.Items = append(view.Items, response_dto.PublicInvoiceItem{
+			Description:    item.Description,
+			Quantity:       item.Quantity,
+			UnitPrice:      item.UnitPrice,
+			DiscountAmount: item.DiscountAmount,
+			TaxTotal:       item.TaxTotal,
+			TotalPrice:     item.TotalPrice,
+		})
+	}
+
+	return view
+}
+
+// NewQuoteService signs quote links with SHARE_LINK_SECRET and points them at
+// FRONTEND_URL, like invoice share links. A link lasts until the quote expires.
+func NewQuoteService(
+	logger *zerolog.Logger,
+	quoteRepository repositories_interfaces.QuoteRepository,
+	invoiceRepository repositories_interfaces.InvoiceRepository,
+	invoiceService services_interfaces.InvoiceService,
+	auditService services_interfaces.AuditService,
+) services_interfaces.QuoteService {
+	return &quoteService{
+		logger:            logger,
+		quoteRepository:   quoteRepository,
+		invoiceRepository: invoiceRepository,
+		invoiceService:    invoiceService,
+		auditService:      auditService,
+		secret:            []byte(os.Getenv("SHARE_LINK_SECRET")),
+		baseURL:           strings.TrimRight(os.Getenv("FRONTEND_URL"), "/"),
+	}
+}
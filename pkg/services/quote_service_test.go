@@ -0,0 +1,276 @@
+package services
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/Adebayobenjamin/numerisbook/pkg/auth"
+	"github.com/Adebayobenjamin/numerisbook/pkg/common/exceptions"
+	"github.com/Adebayobenjamin/numerisbook/pkg/common/money"
+	request_dto "github.com/Adebayobenjamin/numerisbook/pkg/dtos/request"
+	"github.com/Adebayobenjamin/numerisbook/pkg/models"
+	repository_mocks "github.com/Adebayobenjamin/numerisbook/pkg/repositories/mocks"
+	services_mocks "github.com/Adebayobenjamin/numerisbook/pkg/services/mocks"
+	"github.com/rs/zerolog"
+	"github.com/stretchr/testify/assert"
+	"go.uber.org/mock/gomock"
+)
+
+type quoteTestMocks struct {
+	quoteRepository   *repository_mocks.MockQuoteRepository
+	invoiceRepository *repository_mocks.MockInvoiceRepository
+	invoiceService    *services_mocks.MockInvoiceService
+	auditService      *services_mocks.MockAuditService
+}
+
+func setupQuoteTest(t *testing.T) (*quoteTestMocks, *quoteService) {
+	ctrl := gomock.NewController(t)
+	mocks := &quoteTestMocks{
+		quoteRepository:   repository_mocks.NewMockQuoteRepository(ctrl),
+		invoiceRepository: repository_mocks.NewMockInvoiceRepository(ctrl),
+		invoiceService:    services_mocks.NewMockInvoiceService(ctrl),
+		auditService:      services_mocks.NewMockAuditService(ctrl),
+	}
+	logger := zerolog.New(nil)
+	service := NewQuoteService(&logger, mocks.quoteRepository, mocks.invoiceRepository, mocks.invoiceService, mocks.auditService).(*quoteService)
+	service.secret = []byte("test-secret")
+	service.baseURL = "https://app.numeris.test"
+	return mocks, service
+}
+
+// acceptedQuote is two design hours with 7.5% VAT, accepted and not yet converted
+func acceptedQuote() *models.Quote {
+	bankAccountID := uint(4)
+	return &models.Quote{
+		ID:              9,
+		CustomerID:      1,
+		QuoteNumber:     "QT-2025-00009",
+		Status:          models.QuoteStatusAccepted,
+		ExpiryDate:      time.Now().UTC().AddDate(0, 0, 10).Truncate(time.Second),
+		DueInDays:       14,
+		BillingCurrency: "USD",
+		Subtotal:        money.New(20000, "USD"),
+		TaxTotal:        money.New(1500, "USD"),
+		TotalAmount:     money.New(21500, "USD"),
+		Notes:           "Two design hours",
+		Items: models.QuoteItems{{
+			ID:         3,
+			Quantity:   2,
+			UnitPrice:  money.New(10000, "USD"),
+			NetAmount:  money.New(20000, "USD"),
+			TaxTotal:   money.New(1500, "USD"),
+			TotalPrice: money.New(21500, "USD"),
+			Taxes:      []models.InvoiceItemTax{{ID: 6, Name: "VAT", Rate: money.MustParseRate("7.5"), Amount: money.New(1500, "USD")}},
+		}},
+		PaymentInfo:   models.QuotePaymentInfo{BankAccountID: &bankAccountID, BankName: "Chase", AccountNumber: "987654321"},
+		InvoiceIssuer: models.InvoiceIssuer{Name: "Numeris Studio"},
+		InvoiceClient: models.InvoiceClient{Name: "Acme"},
+	}
+}
+
+func TestCreateQuote(t *testing.T) {
+	ctx := auth.WithPrincipal(context.Background(), &auth.Principal{CustomerID: 1, Role: models.UserRoleOwner})
+	clientID := uint(7)
+
+	t.Run("priced like the invoice it will become", func(t *testing.T) {
+		mocks, service := setupQuoteTest(t)
+		issueDate := time.Now().UTC().Truncate(24 * time.Hour)
+		expiryDate := issueDate.AddDate(0, 0, 30)
+		request := &request_dto.CreateQuoteRequest{
+			IssueDate:       issueDate,
+			ExpiryDate:      expiryDate,
+			DueInDays:       14,
+			BillingCurrency: "USD",
+			ClientID:        &clientID,
+			Items:           []request_dto.InvoiceItem{{Description: "Design hour", Quantity: 2}},
+		}
+		mocks.invoiceService.EXPECT().
+			PreviewInvoice(ctx, uint(1), gomock.Any()).
+			DoAndReturn(func(_ context.Context, _ uint, invoiceRequest *request_dto.CreateInvoiceRequest) (*models.Invoice, error) {
+				assert.Equal(t, &clientID, invoiceRequest.ClientID)
+				assert.Equal(t, expiryDate.AddDate(0, 0, 14), invoiceRequest.DueDate)
+				return &models.Invoice{
+					IssueDate:       issueDate,
+					BillingCurrency: "USD",
+					Subtotal:        money.New(20000, "USD"),
+					TaxTotal:        money.New(1500, "USD"),
+					TotalAmountDue:  money.New(21500, "USD"),
+					Items:           []models.InvoiceItem{{Description: "Design hour", Quantity: 2, TotalPrice: money.New(21500, "USD")}},
+					PaymentInfo:     &models.PaymentInfo{BankName: "Chase"},
+					InvoiceClient:   models.InvoiceClient{ClientID: &clientID, Name: "Acme"},
+				}, nil
+			})
+		mocks.quoteRepository.EXPECT().
+			Create(ctx, gomock.Any()).
+			DoAndReturn(func(_ context.Context, quote *models.Quote) (*models.Quote, error) {
+				assert.Equal(t, models.QuoteStatusDraft, quote.Status)
+				assert.Equal(t, expiryDate, quote.ExpiryDate)
+				assert.Equal(t, 14, quote.DueInDays)
+				assert.Equal(t, money.New(21500, "USD"), quote.TotalAmount)
+				assert.Len(t, quote.Items, 1)
+				assert.Equal(t, "Chase", quote.PaymentInfo.BankName)
+				assert.Equal(t, "Acme", quote.InvoiceClient.Name)
+				return quote, nil
+			})
+
+		quote, err := service.CreateQuote(ctx, 1, request)
+
+		assert.NoError(t, err)
+		assert.NotNil(t, quote)
+	})
+
+	t.Run("an expiry date in the past is refused", func(t *testing.T) {
+		_, service := setupQuoteTest(t)
+
+		quote, err := service.CreateQuote(ctx, 1, &request_dto.CreateQuoteRequest{
+			IssueDate:  time.Now().AddDate(0, 0, -10),
+			ExpiryDate: time.Now().AddDate(0, 0, -1),
+			DueInDays:  14,
+		})
+
+		assert.Nil(t, quote)
+		assert.EqualError(t, err, "expiry date must be in the future")
+	})
+}
+
+func TestConvertQuote(t *testing.T) {
+	ctx := auth.WithPrincipal(context.Background(), &auth.Principal{CustomerID: 1, Role: models.UserRoleOwner})
+
+	t.Run("copies the quote into a new invoice and links both in the audit trail", func(t *testing.T) {
+		mocks, service := setupQuoteTest(t)
+		quote := acceptedQuote()
+		mocks.quoteRepository.EXPECT().ClaimForConversion(ctx, uint(9), uint(1), gomock.Any()).Return(nil)
+		mocks.invoiceRepository.EXPECT().
+			CreateInvoiceWithItems(ctx, gomock.Any()).
+			DoAndReturn(func(_ context.Context, invoice *models.Invoice) (*models.Invoice, error) {
+				assert.Equal(t, models.InvoiceStatusDraft, invoice.Status)
+				assert.Equal(t, uint(1), invoice.CustomerID)
+				assert.Equal(t, invoice.IssueDate.AddDate(0, 0, 14), invoice.DueDate)
+				assert.Equal(t, money.New(21500, "USD"), invoice.TotalAmountDue)
+				assert.Equal(t, "Two design hours", invoice.Notes)
+				assert.Equal(t, "Numeris Studio", invoice.InvoiceIssuer.Name)
+				assert.Equal(t, "Acme", invoice.InvoiceClient.Name)
+				assert.Equal(t, "Chase", invoice.PaymentInfo.BankName)
+				// the lines are new rows on the invoice, not the quote's
+				assert.Len(t, invoice.Items, 1)
+				assert.Zero(t, invoice.Items[0].ID)
+				assert.Zero(t, invoice.Items[0].Taxes[0].ID)
+				assert.Equal(t, money.New(1500, "USD"), invoice.Items[0].Taxes[0].Amount)
+
+				invoice.ID = 42
+				invoice.InvoiceNumber = "INV-2025-00042"
+				return invoice, nil
+			})
+		mocks.quoteRepository.EXPECT().SetInvoice(ctx, uint(9), uint(1), uint(42)).Return(nil)
+		mocks.auditService.EXPECT().
+			CreateQuoteAuditTrail(ctx, models.EventTypeQuoteConverted, models.LogLevelInfo,
+				"Invoice INV-2025-00042 created from quote QT-2025-00009", uint(42), uint(9), uint(1)).
+			Return(nil)
+
+		invoice, err := service.ConvertQuote(ctx, quote)
+
+		assert.NoError(t, err)
+		assert.Equal(t, uint(42), invoice.ID)
+		assert.Equal(t, uint(42), *quote.InvoiceID)
+		assert.NotNil(t, quote.ConvertedAt)
+		// the quote keeps its own lines
+		assert.Equal(t, uint(3), quote.Items[0].ID)
+		assert.Equal(t, uint(6), quote.Items[0].Taxes[0].ID)
+	})
+
+	t.Run("only accepted quotes are converted", func(t *testing.T) {
+		_, service := setupQuoteTest(t)
+		quote := acceptedQuote()
+		quote.Status = models.QuoteStatusSent
+
+		invoice, err := service.ConvertQuote(ctx, quote)
+
+		assert.Nil(t, invoice)
+		assert.EqualError(t, err, "quote QT-2025-00009 is sent and only accepted quotes can be converted")
+	})
+
+	t.Run("the claim is released when the invoice cannot be created", func(t *testing.T) {
+		mocks, service := setupQuoteTest(t)
+		mocks.quoteRepository.EXPECT().ClaimForConversion(ctx, uint(9), uint(1), gomock.Any()).Return(nil)
+		mocks.invoiceRepository.EXPECT().CreateInvoiceWithItems(ctx, gomock.Any()).Return(nil, assert.AnError)
+		mocks.quoteRepository.EXPECT().ReleaseConversion(ctx, uint(9), uint(1)).Return(nil)
+
+		invoice, err := service.ConvertQuote(ctx, acceptedQuote())
+
+		assert.Nil(t, invoice)
+		assert.ErrorIs(t, err, assert.AnError)
+	})
+
+	t.Run("a quote converted by another request is refused", func(t *testing.T) {
+		mocks, service := setupQuoteTest(t)
+		mocks.quoteRepository.EXPECT().
+			ClaimForConversion(ctx, uint(9), uint(1), gomock.Any()).
+			Return(exceptions.ErrLocked)
+
+		invoice, err := service.ConvertQuote(ctx, acceptedQuote())
+
+		assert.Nil(t, invoice)
+		assert.ErrorIs(t, err, exceptions.ErrLocked)
+	})
+}
+
+func TestRespondToSharedQuote(t *testing.T) {
+	sentQuote := func(expiryDate time.Time) *models.Quote {
+		quote := acceptedQuote()
+		tokenID := "a1b2c3"
+		quote.Status = models.QuoteStatusSent
+		quote.ShareTokenID = &tokenID
+		quote.ExpiryDate = expiryDate
+		return quote
+	}
+
+	t.Run("the client accepts through the link", func(t *testing.T) {
+		mocks, service := setupQuoteTest(t)
+		quote := sentQuote(time.Now().UTC().AddDate(0, 0, 5).Truncate(time.Second))
+		token := signShareToken(service.secret, *quote.ShareTokenID, quote.ExpiryDate)
+		mocks.quoteRepository.EXPECT().GetByShareTokenID(gomock.Any(), "a1b2c3").Return(quote, nil)
+		mocks.quoteRepository.EXPECT().
+			UpdateStatus(gomock.Any(), gomock.Any(), models.QuoteStatusSent).
+			DoAndReturn(func(_ context.Context, updated *models.Quote, _ models.QuoteStatus) error {
+				assert.Equal(t, models.QuoteStatusAccepted, updated.Status)
+				assert.NotNil(t, updated.RespondedAt)
+				return nil
+			})
+
+		view, err := service.RespondToSharedQuote(context.Background(), token, true, "")
+
+		assert.NoError(t, err)
+		assert.Equal(t, models.QuoteStatusAccepted, view.Status)
+	})
+
+	t.Run("a lapsed quote is expired instead of accepted", func(t *testing.T) {
+		mocks, service := setupQuoteTest(t)
+		quote := sentQuote(time.Now().UTC().Add(-time.Hour).Truncate(time.Second))
+		token := signShareToken(service.secret, *quote.ShareTokenID, quote.ExpiryDate)
+		mocks.quoteRepository.EXPECT().GetByShareTokenID(gomock.Any(), "a1b2c3").Return(quote, nil)
+		mocks.quoteRepository.EXPECT().
+			UpdateStatus(gomock.Any(), gomock.Any(), models.QuoteStatusSent).
+			DoAndReturn(func(_ context.Context, updated *models.Quote, _ models.QuoteStatus) error {
+				assert.Equal(t, models.QuoteStatusExpired, updated.Status)
+				return nil
+			})
+
+		view, err := service.RespondToSharedQuote(context.Background(), token, true, "")
+
+		assert.Nil(t, view)
+		assert.ErrorIs(t, err, exceptions.ErrLocked)
+	})
+
+	t.Run("a token with a changed expiry is not found", func(t *testing.T) {
+		mocks, service := setupQuoteTest(t)
+		quote := sentQuote(time.Now().UTC().AddDate(0, 0, 5).Truncate(time.Second))
+		token := signShareToken(service.secret, *quote.ShareTokenID, quote.ExpiryDate.AddDate(0, 0, 30))
+		mocks.quoteRepository.EXPECT().GetByShareTokenID(gomock.Any(), "a1b2c3").Return(quote, nil)
+
+		view, err := service.RespondToSharedQuote(context.Background(), token, true, "")
+
+		assert.Nil(t, view)
+		assert.ErrorIs(t, err, exceptions.ErrNotFound)
+	})
+}
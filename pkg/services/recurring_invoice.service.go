@@ -0,0 +1,332 @@
+package services
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"time"
+
+	"github.com/Adebayobenjamin/numerisbook/pkg/auth"
+	"github.com/Adebayobenjamin/numerisbook/pkg/common/exceptions"
+	"github.com/Adebayobenjamin/numerisbook/pkg/common/rrule"
+	request_dto "github.com/Adebayobenjamin/numerisbook/pkg/dtos/request"
+	"github.com/Adebayobenjamin/numerisbook/pkg/helper"
+	"github.com/Adebayobenjamin/numerisbook/pkg/models"
+	repositories_interfaces "github.com/Adebayobenjamin/numerisbook/pkg/repositories/interfaces"
+	services_interfaces "github.com/Adebayobenjamin/numerisbook/pkg/services/interfaces"
+	"github.com/Adebayobenjamin/numerisbook/pkg/tenant"
+	"github.com/rs/zerolog"
+)
+
+// dueProfilesBatchSize is how many due profiles one run of the scheduler picks up
+const dueProfilesBatchSize = 50
+
+var recurringFrequencyRules = map[models.RecurringFrequency]string{
+	models.RecurringFrequencyWeekly:    "FREQ=WEEKLY",
+	models.RecurringFrequencyMonthly:   "FREQ=MONTHLY",
+	models.RecurringFrequencyQuarterly: "FREQ=MONTHLY;INTERVAL=3",
+	models.RecurringFrequencyYearly:    "FREQ=YEARLY",
+}
+
+var recurringProfileTransitions = map[models.RecurringProfileStatus][]models.RecurringProfileStatus{
+	models.RecurringProfileStatusActive: {models.RecurringProfileStatusPaused, models.RecurringProfileStatusCancelled},
+	models.RecurringProfileStatusPaused: {models.RecurringProfileStatusActive, models.RecurringProfileStatusCancelled},
+}
+
+type recurringInvoiceService struct {
+	logger                     *zerolog.Logger
+	recurringInvoiceRepository repositories_interfaces.RecurringInvoiceRepository
+	invoiceService             services_interfaces.InvoiceService
+	reminderService            services_interfaces.RemiderService
+	auditService               services_interfaces.AuditService
+	invoiceEmailService        services_interfaces.InvoiceEmailService
+}
+
+// CreateProfile implements services_interfaces.RecurringInvoiceService.
+func (r *recurringInvoiceService) CreateProfile(ctx context.Context, customerID uint, request *request_dto.CreateRecurringInvoiceRequest) (*models.RecurringInvoiceProfile, error) {
+	if err := auth.Authorize(ctx, auth.PermissionInvoicesWrite); err != nil {
+		return nil, err
+	}
+
+	ruleText := request.RRule
+	if request.Frequency != models.RecurringFrequencyCustom {
+		ruleText = recurringFrequencyRules[request.Frequency]
+	}
+
+	rule, err := rrule.Parse(ruleText)
+	if err != nil {
+		return nil, fmt.Errorf("invalid recurrence rule: %w", err)
+	}
+
+	startDate := request.StartDate.UTC()
+	if startDate.Before(time.Now().UTC().Truncate(24 * time.Hour)) {
+		return nil, fmt.Errorf("start date cannot be in the past")
+	}
+	if request.EndDate != nil && request.EndDate.Before(startDate) {
+		return nil, fmt.Errorf("end date cannot be before the start date")
+	}
+
+	// build the first invoice up front so a template that can never be
+	// invoiced is rejected now rather than on every run of the scheduler
+	firstRun := templateToInvoiceRequest(&request.Invoice, startDate, time.Now().AddDate(0, 0, request.DueInDays+1))
+	if _, err = buildInvoice(customerID, firstRun); err != nil {
+		return nil, fmt.Errorf("invalid invoice template: %w", err)
+	}
+
+	template, err := json.Marshal(request.Invoice)
+	if err != nil {
+		return nil, fmt.Errorf("failed to marshal invoice template: %w", err)
+	}
+
+	profile := &models.RecurringInvoiceProfile{
+		CustomerID:     customerID,
+		Name:           request.Name,
+		Frequency:      request.Frequency,
+		RRule:          ruleText,
+		StartDate:      startDate,
+		EndDate:        request.EndDate,
+		MaxOccurrences: request.MaxOccurrences,
+		DueInDays:      request.DueInDays,
+		AutoSend:       request.AutoSend,
+		Template:       template,
+		Status:         models.RecurringProfileStatusActive,
+	}
+
+	// a COUNT in the rule is just another occurrence limit
+	if rule.Count > 0 && (profile.MaxOccurrences == nil || rule.Count < *profile.MaxOccurrences) {
+		profile.MaxOccurrences = helper.ReturnPointer(rule.Count)
+	}
+
+	profile.NextRunAt = nextProfileRun(profile, rule, startDate.Add(-time.Nanosecond))
+	if profile.NextRunAt == nil {
+		return nil, fmt.Errorf("schedule has no occurrences between the start and end dates")
+	}
+
+	return r.recurringInvoiceRepository.CreateProfile(ctx, profile)
+}
+
+// GetProfileByIDAndCustomer implements services_interfaces.RecurringInvoiceService.
+func (r *recurringInvoiceService) GetProfileByIDAndCustomer(ctx context.Context, profileID uint, customerID uint) (*models.RecurringInvoiceProfile, error) {
+	return r.recurringInvoiceRepository.GetByIDAndCustomerID(ctx, profileID, customerID)
+}
+
+// GetCustomerProfiles implements services_interfaces.RecurringInvoiceService.
+func (r *recurringInvoiceService) GetCustomerProfiles(ctx context.Context, limit int, page int, customerID uint) ([]models.RecurringInvoiceProfile, error) {
+	offset := helper.GetOffset(page, limit)
+	return r.recurringInvoiceRepository.GetAllCustomerProfiles(ctx, customerID, limit, offset)
+}
+
+// ChangeProfileStatus implements services_interfaces.RecurringInvoiceService.
+func (r *recurringInvoiceService) ChangeProfileStatus(ctx context.Context, profile *models.RecurringInvoiceProfile, status models.RecurringProfileStatus) error {
+	if err := auth.Authorize(ctx, auth.PermissionInvoicesWrite); err != nil {
+		return err
+	}
+
+	allowed := false
+	for _, next := range recurringProfileTransitions[profile.Status] {
+		allowed = allowed || next == status
+	}
+	if !allowed {
+		return &exceptions.InvalidStatusTransitionError{
+			Entity: "recurring invoice profile",
+			From:   string(profile.Status),
+			To:     string(status),
+		}
+	}
+
+	expectedNextRunAt := profile.NextRunAt
+	updated := *profile
+	updated.Status = status
+
+	switch status {
+	case models.RecurringProfileStatusActive:
+		// runs that fell due while the profile was paused are skipped, not made up
+		rule, err := rrule.Parse(profile.RRule)
+		if err != nil {
+			return fmt.Errorf("invalid recurrence rule: %w", err)
+		}
+		after := time.Now().UTC()
+		if profile.LastRunAt != nil && profile.LastRunAt.After(after) {
+			after = *profile.LastRunAt
+		}
+		updated.NextRunAt = nextProfileRun(&updated, rule, after)
+		if updated.NextRunAt == nil {
+			updated.Status = models.RecurringProfileStatusCompleted
+		}
+	case models.RecurringProfileStatusCancelled:
+		updated.NextRunAt = nil
+	}
+
+	claimed, err := r.recurringInvoiceRepository.UpdateSchedule(ctx, &updated, expectedNextRunAt)
+	if err != nil {
+		return err
+	}
+	if !claimed {
+		return fmt.Errorf("recurring invoice profile was changed by another request")
+	}
+
+	*profile = updated
+	return nil
+}
+
+// GenerateDueInvoices implements services_interfaces.RecurringInvoiceService.
+func (r *recurringInvoiceService) GenerateDueInvoices(ctx context.Context, now time.Time) (int, error) {
+	profiles, err := r.recurringInvoiceRepository.GetDueProfiles(ctx, now, dueProfilesBatchSize)
+	if err != nil {
+		return 0, err
+	}
+
+	generated := 0
+	for idx := range profiles {
+		profile := &profiles[idx]
+
+		// due profiles span customers, each run is scoped to its profile's
+		// customer and done by the system on their behalf
+		profileCtx := auth.WithSystemPrincipal(tenant.WithCustomerID(ctx, profile.CustomerID), profile.CustomerID)
+		ok, err := r.runProfile(profileCtx, profile, now)
+		if err != nil {
+			// one broken profile must not hold up the others
+			r.logger.Error().Err(err).Uint("profile_id", profile.ID).Msg("failed to generate recurring invoice")
+			continue
+		}
+		if ok {
+			generated++
+		}
+	}
+
+	return generated, nil
+}
+
+// runProfile generates the invoice for the profile's current run. The run is
+// claimed by moving the schedule on before the invoice is created, so when
+// several workers see the same due profile only one of them generates it. It
+// reports false when another worker got there first.
+func (r *recurringInvoiceService) runProfile(ctx context.Context, profile *models.RecurringInvoiceProfile, now time.Time) (bool, error) {
+	rule, err := rrule.Parse(profile.RRule)
+	if err != nil {
+		return false, fmt.Errorf("invalid recurrence rule: %w", err)
+	}
+
+	original := *profile
+	runAt := *profile.NextRunAt
+
+	profile.OccurrencesGenerated++
+	profile.LastRunAt = &runAt
+	profile.NextRunAt = nextProfileRun(profile, rule, runAt)
+	if profile.NextRunAt == nil {
+		profile.Status = models.RecurringProfileStatusCompleted
+	}
+
+	claimed, err := r.recurringInvoiceRepository.UpdateSchedule(ctx, profile, original.NextRunAt)
+	if err != nil || !claimed {
+		return false, err
+	}
+
+	var template request_dto.RecurringInvoiceTemplate
+	if err = json.Unmarshal(profile.Template, &template); err != nil {
+		return false, r.releaseRun(ctx, profile, &original, fmt.Errorf("failed to read invoice template: %w", err))
+	}
+
+	// a run generated late, e.g. after the worker was down, still gives the
+	// client the full payment terms
+	dueDate := runAt.AddDate(0, 0, profile.DueInDays)
+	if dueDate.Before(now) {
+		dueDate = now.AddDate(0, 0, profile.DueInDays)
+	}
+
+	invoice, err := r.invoiceService.CreateInvoice(ctx, profile.CustomerID, templateToInvoiceRequest(&template, runAt, dueDate))
+	if err != nil {
+		return false, r.releaseRun(ctx, profile, &original, err)
+	}
+
+	// the invoice exists from here on, so later failures are only logged;
+	// releasing the run now would generate the invoice a second time
+	if len(template.ReminderSchedules) > 0 {
+		if err = r.reminderService.SetInvoiceReminders(ctx, invoice, profile.CustomerID, template.ReminderSchedules); err != nil {
+			r.logger.Error().Err(err).Uint("invoice_id", invoice.ID).Msg("failed to set reminders on recurring invoice")
+		}
+	}
+
+	if profile.AutoSend {
+		if err = r.invoiceEmailService.SendInvoice(ctx, invoice); err != nil {
+			r.logger.Error().Err(err).Uint("invoice_id", invoice.ID).Msg("failed to send recurring invoice")
+		}
+	}
+
+	err = r.auditService.CreateAuditTrail(
+		ctx,
+		models.EventTypeRecurringInvoiceGenerated,
+		models.LogLevelInfo,
+		fmt.Sprintf("Generated Invoice %s from recurring profile %s (run %d)", invoice.InvoiceNumber, profile.Name, profile.OccurrencesGenerated),
+		invoice.ID,
+		profile.CustomerID,
+	)
+	if err != nil {
+		r.logger.Error().Err(err).Uint("invoice_id", invoice.ID).Msg("failed to audit recurring invoice")
+	}
+
+	return true, nil
+}
+
+// releaseRun puts the schedule back after a run that could not generate its
+// invoice, so the next run of the scheduler tries again
+func (r *recurringInvoiceService) releaseRun(ctx context.Context, claimed *models.RecurringInvoiceProfile, original *models.RecurringInvoiceProfile, cause error) error {
+	if _, err := r.recurringInvoiceRepository.UpdateSchedule(ctx, original, claimed.NextRunAt); err != nil {
+		return fmt.Errorf("%w (and failed to release the run: %v)", cause, err)
+	}
+	return cause
+}
+
+// nextProfileRun returns the first run of the profile after the given time,
+// or nil once the rule, the end date or the occurrence limit has run out
+func nextProfileRun(profile *models.RecurringInvoiceProfile, rule *rrule.Rule, after time.Time) *time.Time {
+	if profile.MaxOccurrences != nil && profile.OccurrencesGenerated >= *profile.MaxOccurrences {
+		return nil
+	}
+
+	next, ok := rule.Next(profile.StartDate, after)
+	if !ok {
+		return nil
+	}
+	if profile.EndDate != nil && next.After(*profile.EndDate) {
+		return nil
+	}
+
+	return &next
+}
+
+func templateToInvoiceRequest(template *request_dto.RecurringInvoiceTemplate, issueDate time.Time, dueDate time.Time) *request_dto.CreateInvoiceRequest {
+	return &request_dto.CreateInvoiceRequest{
+		ClientID:          template.ClientID,
+		Client:            template.Client,
+		Sender:            template.Sender,
+		IssueDate:         issueDate,
+		DueDate:           dueDate,
+		BillingCurrency:   template.BillingCurrency,
+		Items:             template.Items,
+		Discount:          template.Discount,
+		Notes:             template.Notes,
+		ReminderSchedules: template.ReminderSchedules,
+		PaymentInfo:       template.PaymentInfo,
+		BusinessProfileID: template.BusinessProfileID,
+		BankAccountID:     template.BankAccountID,
+	}
+}
+
+func NewRecurringInvoiceService(
+	logger *zerolog.Logger,
+	recurringInvoiceRepository repositories_interfaces.RecurringInvoiceRepository,
+	invoiceService services_interfaces.InvoiceService,
+	reminderService services_interfaces.RemiderService,
+	auditService services_interfaces.AuditService,
+	invoiceEmailService services_interfaces.InvoiceEmailService,
+) services_interfaces.RecurringInvoiceService {
+	return &recurringInvoiceService{
+		logger:                     logger,
+		recurringInvoiceRepository: recurringInvoiceRepository,
+		invoiceService:             invoiceService,
+		reminderService:            reminderService,
+		auditService:               auditService,
+		invoiceEmailService:        invoiceEmailService,
+	}
+}
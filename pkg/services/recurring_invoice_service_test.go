@@ -0,0 +1,319 @@
+package services
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/Adebayobenjamin/numerisbook/pkg/auth"
+	"github.com/Adebayobenjamin/numerisbook/pkg/common/exceptions"
+	"github.com/Adebayobenjamin/numerisbook/pkg/common/money"
+	request_dto "github.com/Adebayobenjamin/numerisbook/pkg/dtos/request"
+	"github.com/Adebayobenjamin/numerisbook/pkg/helper"
+	"github.com/Adebayobenjamin/numerisbook/pkg/models"
+	repository_mocks "github.com/Adebayobenjamin/numerisbook/pkg/repositories/mocks"
+	services_mocks "github.com/Adebayobenjamin/numerisbook/pkg/services/mocks"
+	"github.com/Adebayobenjamin/numerisbook/pkg/tenant"
+	"github.com/rs/zerolog"
+	"github.com/stretchr/testify/assert"
+	"go.uber.org/mock/gomock"
+)
+
+func setupRecurringInvoiceTest(t *testing.T) (
+	*repository_mocks.MockRecurringInvoiceRepository,
+	*services_mocks.MockInvoiceService,
+	*services_mocks.MockRemiderService,
+	*services_mocks.MockAuditService,
+	*services_mocks.MockInvoiceEmailService,
+	*recurringInvoiceService,
+) {
+	ctrl := gomock.NewController(t)
+	mockRepo := repository_mocks.NewMockRecurringInvoiceRepository(ctrl)
+	mockInvoiceService := services_mocks.NewMockInvoiceService(ctrl)
+	mockReminderService := services_mocks.NewMockRemiderService(ctrl)
+	mockAuditService := services_mocks.NewMockAuditService(ctrl)
+	mockEmailService := services_mocks.NewMockInvoiceEmailService(ctrl)
+	logger := zerolog.New(nil)
+	service := NewRecurringInvoiceService(&logger, mockRepo, mockInvoiceService, mockReminderService, mockAuditService, mockEmailService).(*recurringInvoiceService)
+	return mockRepo, mockInvoiceService, mockReminderService, mockAuditService, mockEmailService, service
+}
+
+func recurringTemplate() request_dto.RecurringInvoiceTemplate {
+	return request_dto.RecurringInvoiceTemplate{
+		Client:          &request_dto.ClientDetails{Name: "Acme", Phone: "+14155550123", Address: "1 Main St", Email: "billing@acme.test"},
+		BillingCurrency: "USD",
+		Items:           []request_dto.InvoiceItem{{Description: "Retainer", UnitPrice: money.MustParse("500.00", ""), Quantity: 1}},
+	}
+}
+
+func TestCreateRecurringProfile(t *testing.T) {
+	mockRepo, _, _, _, _, service := setupRecurringInvoiceTest(t)
+	ctx := auth.WithPrincipal(context.Background(), &auth.Principal{CustomerID: 1, Role: models.UserRoleOwner})
+	start := time.Now().UTC().AddDate(0, 0, 1).Truncate(time.Second)
+
+	tests := []struct {
+		name      string
+		request   *request_dto.CreateRecurringInvoiceRequest
+		mockSetup func()
+		wantErr   bool
+		errMsg    string
+	}{
+		{
+			name: "quarterly preset starts on the start date",
+			request: &request_dto.CreateRecurringInvoiceRequest{
+				Name:      "Retainer",
+				Frequency: models.RecurringFrequencyQuarterly,
+				StartDate: start,
+				DueInDays: 14,
+				Invoice:   recurringTemplate(),
+			},
+			mockSetup: func() {
+				mockRepo.EXPECT().
+					CreateProfile(ctx, gomock.Any()).
+					DoAndReturn(func(_ context.Context, profile *models.RecurringInvoiceProfile) (*models.RecurringInvoiceProfile, error) {
+						assert.Equal(t, "FREQ=MONTHLY;INTERVAL=3", profile.RRule)
+						assert.Equal(t, start, *profile.NextRunAt)
+						assert.Equal(t, models.RecurringProfileStatusActive, profile.Status)
+						assert.Nil(t, profile.MaxOccurrences)
+						return profile, nil
+					})
+			},
+		},
+		{
+			name: "a COUNT in a custom rule limits the occurrences",
+			request: &request_dto.CreateRecurringInvoiceRequest{
+				Name:           "Instalments",
+				Frequency:      models.RecurringFrequencyCustom,
+				RRule:          "FREQ=MONTHLY;BYMONTHDAY=-1;COUNT=6",
+				StartDate:      start,
+				MaxOccurrences: helper.ReturnPointer(12),
+				DueInDays:      7,
+				Invoice:        recurringTemplate(),
+			},
+			mockSetup: func() {
+				mockRepo.EXPECT().
+					CreateProfile(ctx, gomock.Any()).
+					DoAndReturn(func(_ context.Context, profile *models.RecurringInvoiceProfile) (*models.RecurringInvoiceProfile, error) {
+						assert.Equal(t, 6, *profile.MaxOccurrences)
+						return profile, nil
+					})
+			},
+		},
+		{
+			name: "invalid custom rule",
+			request: &request_dto.CreateRecurringInvoiceRequest{
+				Frequency: models.RecurringFrequencyCustom,
+				RRule:     "FREQ=HOURLY",
+				StartDate: start,
+				Invoice:   recurringTemplate(),
+			},
+			mockSetup: func() {},
+			wantErr:   true,
+			errMsg:    "invalid recurrence rule",
+		},
+		{
+			name: "start date in the past",
+			request: &request_dto.CreateRecurringInvoiceRequest{
+				Frequency: models.RecurringFrequencyMonthly,
+				StartDate: start.AddDate(0, -1, 0),
+				Invoice:   recurringTemplate(),
+			},
+			mockSetup: func() {},
+			wantErr:   true,
+			errMsg:    "start date cannot be in the past",
+		},
+		{
+			name: "template that cannot be invoiced",
+			request: &request_dto.CreateRecurringInvoiceRequest{
+				Frequency: models.RecurringFrequencyMonthly,
+				StartDate: start,
+				DueInDays: 7,
+				Invoice: request_dto.RecurringInvoiceTemplate{
+					BillingCurrency: "USD",
+					Items:           []request_dto.InvoiceItem{{UnitPrice: money.MustParse("10.00", ""), Quantity: 1}},
+					Discount:        &request_dto.Discount{Type: models.DiscountTypeFixed, Value: "50"},
+				},
+			},
+			mockSetup: func() {},
+			wantErr:   true,
+			errMsg:    "invalid invoice template",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			tt.mockSetup()
+
+			profile, err := service.CreateProfile(ctx, 1, tt.request)
+
+			if tt.wantErr {
+				assert.Error(t, err)
+				assert.Contains(t, err.Error(), tt.errMsg)
+				assert.Nil(t, profile)
+			} else {
+				assert.NoError(t, err)
+				assert.NotNil(t, profile)
+			}
+		})
+	}
+}
+
+func TestGenerateDueInvoices(t *testing.T) {
+	ctx := context.Background()
+	scoped := auth.WithSystemPrincipal(tenant.WithCustomerID(ctx, 1), 1)
+	now := time.Date(2025, time.April, 1, 10, 0, 0, 0, time.UTC)
+	runAt := time.Date(2025, time.April, 1, 9, 0, 0, 0, time.UTC)
+	template, _ := json.Marshal(recurringTemplate())
+
+	dueProfile := func() models.RecurringInvoiceProfile {
+		return models.RecurringInvoiceProfile{
+			ID:         3,
+			CustomerID: 1,
+			Name:       "Retainer",
+			RRule:      "FREQ=MONTHLY",
+			StartDate:  time.Date(2025, time.January, 1, 9, 0, 0, 0, time.UTC),
+			DueInDays:  14,
+			AutoSend:   true,
+			Template:   template,
+			Status:     models.RecurringProfileStatusActive,
+			NextRunAt:  &runAt,
+		}
+	}
+
+	t.Run("claims the run, creates the invoice and sends it", func(t *testing.T) {
+		mockRepo, mockInvoiceService, _, mockAuditService, mockEmailService, service := setupRecurringInvoiceTest(t)
+		invoice := &models.Invoice{ID: 10, InvoiceNumber: "INV-2025-00010"}
+
+		mockRepo.EXPECT().GetDueProfiles(ctx, now, dueProfilesBatchSize).Return([]models.RecurringInvoiceProfile{dueProfile()}, nil)
+		mockRepo.EXPECT().
+			UpdateSchedule(scoped, gomock.Any(), &runAt).
+			DoAndReturn(func(_ context.Context, profile *models.RecurringInvoiceProfile, _ *time.Time) (bool, error) {
+				assert.Equal(t, time.Date(2025, time.May, 1, 9, 0, 0, 0, time.UTC), *profile.NextRunAt)
+				assert.Equal(t, runAt, *profile.LastRunAt)
+				assert.Equal(t, 1, profile.OccurrencesGenerated)
+				return true, nil
+			})
+		mockInvoiceService.EXPECT().
+			CreateInvoice(scoped, uint(1), gomock.Any()).
+			DoAndReturn(func(_ context.Context, _ uint, request *request_dto.CreateInvoiceRequest) (*models.Invoice, error) {
+				assert.Equal(t, runAt, request.IssueDate)
+				assert.Equal(t, runAt.AddDate(0, 0, 14), request.DueDate)
+				assert.Equal(t, "Retainer", request.Items[0].Description)
+				return invoice, nil
+			})
+		mockEmailService.EXPECT().SendInvoice(scoped, invoice).Return(nil)
+		mockAuditService.EXPECT().
+			CreateAuditTrail(scoped, models.EventTypeRecurringInvoiceGenerated, models.LogLevelInfo, gomock.Any(), invoice.ID, uint(1)).
+			Return(nil)
+
+		generated, err := service.GenerateDueInvoices(ctx, now)
+
+		assert.NoError(t, err)
+		assert.Equal(t, 1, generated)
+	})
+
+	t.Run("skips a run another worker has claimed", func(t *testing.T) {
+		mockRepo, _, _, _, _, service := setupRecurringInvoiceTest(t)
+
+		mockRepo.EXPECT().GetDueProfiles(ctx, now, dueProfilesBatchSize).Return([]models.RecurringInvoiceProfile{dueProfile()}, nil)
+		mockRepo.EXPECT().UpdateSchedule(scoped, gomock.Any(), &runAt).Return(false, nil)
+
+		generated, err := service.GenerateDueInvoices(ctx, now)
+
+		assert.NoError(t, err)
+		assert.Equal(t, 0, generated)
+	})
+
+	t.Run("gives the run back when the invoice cannot be created", func(t *testing.T) {
+		mockRepo, mockInvoiceService, _, _, _, service := setupRecurringInvoiceTest(t)
+		nextRun := time.Date(2025, time.May, 1, 9, 0, 0, 0, time.UTC)
+
+		mockRepo.EXPECT().GetDueProfiles(ctx, now, dueProfilesBatchSize).Return([]models.RecurringInvoiceProfile{dueProfile()}, nil)
+		mockRepo.EXPECT().UpdateSchedule(scoped, gomock.Any(), &runAt).Return(true, nil)
+		mockInvoiceService.EXPECT().CreateInvoice(scoped, uint(1), gomock.Any()).Return(nil, errors.New("database error"))
+		mockRepo.EXPECT().
+			UpdateSchedule(scoped, gomock.Any(), &nextRun).
+			DoAndReturn(func(_ context.Context, profile *models.RecurringInvoiceProfile, _ *time.Time) (bool, error) {
+				assert.Equal(t, runAt, *profile.NextRunAt)
+				assert.Equal(t, 0, profile.OccurrencesGenerated)
+				return true, nil
+			})
+
+		generated, err := service.GenerateDueInvoices(ctx, now)
+
+		assert.NoError(t, err)
+		assert.Equal(t, 0, generated)
+	})
+
+	t.Run("completes the profile on its last occurrence and shifts a late due date", func(t *testing.T) {
+		mockRepo, mockInvoiceService, _, mockAuditService, _, service := setupRecurringInvoiceTest(t)
+		profile := dueProfile()
+		profile.AutoSend = false
+		profile.MaxOccurrences = helper.ReturnPointer(4)
+		profile.OccurrencesGenerated = 3
+		lateNow := runAt.AddDate(0, 0, 20)
+
+		mockRepo.EXPECT().GetDueProfiles(ctx, lateNow, dueProfilesBatchSize).Return([]models.RecurringInvoiceProfile{profile}, nil)
+		mockRepo.EXPECT().
+			UpdateSchedule(scoped, gomock.Any(), &runAt).
+			DoAndReturn(func(_ context.Context, profile *models.RecurringInvoiceProfile, _ *time.Time) (bool, error) {
+				assert.Nil(t, profile.NextRunAt)
+				assert.Equal(t, models.RecurringProfileStatusCompleted, profile.Status)
+				return true, nil
+			})
+		mockInvoiceService.EXPECT().
+			CreateInvoice(scoped, uint(1), gomock.Any()).
+			DoAndReturn(func(_ context.Context, _ uint, request *request_dto.CreateInvoiceRequest) (*models.Invoice, error) {
+				assert.Equal(t, lateNow.AddDate(0, 0, 14), request.DueDate)
+				return &models.Invoice{ID: 11}, nil
+			})
+		mockAuditService.EXPECT().CreateAuditTrail(scoped, models.EventTypeRecurringInvoiceGenerated, models.LogLevelInfo, gomock.Any(), uint(11), uint(1)).Return(nil)
+
+		generated, err := service.GenerateDueInvoices(ctx, lateNow)
+
+		assert.NoError(t, err)
+		assert.Equal(t, 1, generated)
+	})
+}
+
+func TestChangeProfileStatus(t *testing.T) {
+	mockRepo, _, _, _, _, service := setupRecurringInvoiceTest(t)
+	ctx := auth.WithPrincipal(context.Background(), &auth.Principal{CustomerID: 1, Role: models.UserRoleOwner})
+	lastRun := time.Now().UTC().AddDate(0, -3, 0).Truncate(time.Second)
+	missedRun := lastRun.AddDate(0, 0, 7)
+
+	t.Run("resuming skips the runs missed while paused", func(t *testing.T) {
+		profile := &models.RecurringInvoiceProfile{
+			ID:        1,
+			RRule:     "FREQ=WEEKLY",
+			StartDate: lastRun,
+			Status:    models.RecurringProfileStatusPaused,
+			LastRunAt: &lastRun,
+			NextRunAt: &missedRun,
+		}
+
+		mockRepo.EXPECT().
+			UpdateSchedule(ctx, gomock.Any(), &missedRun).
+			DoAndReturn(func(_ context.Context, profile *models.RecurringInvoiceProfile, _ *time.Time) (bool, error) {
+				assert.True(t, profile.NextRunAt.After(time.Now()))
+				assert.True(t, profile.NextRunAt.Before(time.Now().AddDate(0, 0, 8)))
+				return true, nil
+			})
+
+		err := service.ChangeProfileStatus(ctx, profile, models.RecurringProfileStatusActive)
+
+		assert.NoError(t, err)
+		assert.Equal(t, models.RecurringProfileStatusActive, profile.Status)
+	})
+
+	t.Run("completed profiles cannot be resumed", func(t *testing.T) {
+		profile := &models.RecurringInvoiceProfile{Status: models.RecurringProfileStatusCompleted}
+
+		err := service.ChangeProfileStatus(ctx, profile, models.RecurringProfileStatusActive)
+
+		var transitionErr *exceptions.InvalidStatusTransitionError
+		assert.ErrorAs(t, err, &transitionErr)
+	})
+}
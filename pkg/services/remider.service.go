@@ -4,14 +4,159 @@ import (
 	"context"
 	"time"
 
+	"github.com/Adebayobenjamin/numerisbook/pkg/auth"
+	response_dto "github.com/Adebayobenjamin/numerisbook/pkg/dtos/response"
+	"github.com/Adebayobenjamin/numerisbook/pkg/email"
 	"github.com/Adebayobenjamin/numerisbook/pkg/helper"
 	"github.com/Adebayobenjamin/numerisbook/pkg/models"
+	"github.com/Adebayobenjamin/numerisbook/pkg/notifiers"
 	repositories_interfaces "github.com/Adebayobenjamin/numerisbook/pkg/repositories/interfaces"
 	services_interfaces "github.com/Adebayobenjamin/numerisbook/pkg/services/interfaces"
+	"github.com/Adebayobenjamin/numerisbook/pkg/tenant"
+	"github.com/rs/zerolog"
 )
 
+const (
+	// dueRemindersBatchSize caps how many reminders one dispatch run claims
+	dueRemindersBatchSize = 50
+	// reminderClaimLease is how long a claimed reminder is held before another
+	// worker may pick it up again, should the one sending it die
+	reminderClaimLease = 5 * time.Minute
+	// maxReminderAttempts is how many times delivery is tried before giving up
+	maxReminderAttempts = 5
+	// reminderRetryBaseDelay is the wait after the first failure; it doubles
+	// with every further attempt up to reminderRetryMaxDelay
+	reminderRetryBaseDelay = 5 * time.Minute
+	reminderRetryMaxDelay  = 6 * time.Hour
+)
+
+// remindableInvoiceStatuses are the statuses of invoices still waiting on payment
+var remindableInvoiceStatuses = map[models.InvoiceStatus]bool{
+	models.InvoiceStatusSent:          true,
+	models.InvoiceStatusPartiallyPaid: true,
+	models.InvoiceStatusOverdue:       true,
+}
+
 type reminderService struct {
+	logger             *zerolog.Logger
 	reminderRepository repositories_interfaces.ReminderRepository
+	invoiceRepository  repositories_interfaces.InvoiceRepository
+	notifier           notifiers.Notifier
+}
+
+// DispatchDueReminders implements services_interfaces.RemiderService.
+func (r *reminderService) DispatchDueReminders(ctx context.Context, now time.Time) (int, error) {
+	reminders, err := r.reminderRepository.ClaimDueReminders(ctx, now, reminderClaimLease, maxReminderAttempts, dueRemindersBatchSize)
+	if err != nil {
+		return 0, err
+	}
+
+	sent := 0
+	for _, reminder := range reminders {
+		if ctx.Err() != nil {
+			break
+		}
+
+		// reminders are claimed across customers, each one is handled in its
+		// own customer's scope and sent by the system on their behalf
+		reminderCtx := auth.WithSystemPrincipal(tenant.WithCustomerID(ctx, reminder.CustomerID), reminder.CustomerID)
+
+		delivered, err := r.dispatchReminder(reminderCtx, reminder, now)
+		if err != nil {
+			r.logger.Error().Err(err).Uint("reminder_id", reminder.ID).Uint("invoice_id", reminder.InvoiceID).Msg("failed to send invoice reminder")
+			nextAttemptAt := nextReminderAttempt(reminder.Attempts, now)
+			if err := r.reminderRepository.MarkReminderFailed(reminderCtx, reminder.ID, err.Error(), nextAttemptAt); err != nil {
+				r.logger.Error().Err(err).Uint("reminder_id", reminder.ID).Msg("failed to record reminder failure")
+			}
+			continue
+		}
+		if delivered {
+			sent++
+		}
+	}
+
+	return sent, nil
+}
+
+// dispatchReminder sends a single claimed reminder. It reports false without
+// an error when the invoice no longer needs reminding.
+func (r *reminderService) dispatchReminder(ctx context.Context, reminder models.InvoiceReminder, now time.Time) (bool, error) {
+	invoice, err := r.invoiceRepository.GetDetails(ctx, reminder.InvoiceID)
+	if err != nil {
+		return false, err
+	}
+
+	// the invoice may have been paid or voided since the reminder was claimed;
+	// it is left unsent and will not be claimed again
+	if !remindableInvoiceStatuses[invoice.Status] {
+		return false, nil
+	}
+
+	notification, err := buildReminderNotification(invoice, now)
+	if err != nil {
+		return false, err
+	}
+
+	if err := r.notifier.Notify(ctx, notification); err != nil {
+		return false, err
+	}
+
+	if err := r.reminderRepository.MarkReminderSent(ctx, reminder.ID, now); err != nil {
+		// the reminder went out, so it is not retried; at worst it is sent
+		// again once the claim lease runs out
+		r.logger.Error().Err(err).Uint("reminder_id", reminder.ID).Msg("failed to record sent reminder")
+	}
+
+	return true, nil
+}
+
+// nextReminderAttempt returns when a reminder that has failed attempts times
+// should be retried, or nil once it has run out of attempts
+func nextReminderAttempt(attempts int, now time.Time) *time.Time {
+	if attempts >= maxReminderAttempts {
+		return nil
+	}
+
+	delay := reminderRetryBaseDelay
+	for i := 1; i < attempts && delay < reminderRetryMaxDelay; i++ {
+		delay *= 2
+	}
+	if delay > reminderRetryMaxDelay {
+		delay = reminderRetryMaxDelay
+	}
+
+	return helper.ReturnPointer(now.Add(delay))
+}
+
+// buildReminderNotification renders the reminder for an invoice, switching
+// to the overdue email once its due date has passed
+func buildReminderNotification(invoice *response_dto.GetInvoiceDetailsResponse, now time.Time) (notifiers.Notification, error) {
+	template := email.TemplatePaymentReminder
+	if invoice.DueDate.Before(now) {
+		template = email.TemplateInvoiceOverdue
+	}
+
+	data, err := invoiceTemplateData(invoice)
+	if err != nil {
+		return notifiers.Notification{}, err
+	}
+
+	message, err := invoiceEmailMessage(template, invoice, data)
+	if err != nil {
+		return notifiers.Notification{}, err
+	}
+
+	return notifiers.Notification{
+		To:         message.To,
+		Name:       message.ToName,
+		FromName:   message.FromName,
+		ReplyTo:    message.ReplyTo,
+		Subject:    message.Subject,
+		Body:       message.Text,
+		HTML:       message.HTML,
+		InvoiceID:  invoice.ID,
+		CustomerID: invoice.CustomerID,
+	}, nil
 }
 
 // SetInvoiceReminders implements services_interfaces.RemiderService.
@@ -54,9 +199,15 @@ func getReminderDateFromSchedule(schedule models.InvoiceReminderSchedule, invoic
 }
 
 func NewReminderService(
+	logger *zerolog.Logger,
 	reminderRepository repositories_interfaces.ReminderRepository,
+	invoiceRepository repositories_interfaces.InvoiceRepository,
+	notifier notifiers.Notifier,
 ) services_interfaces.RemiderService {
 	return &reminderService{
+		logger:             logger,
 		reminderRepository: reminderRepository,
+		invoiceRepository:  invoiceRepository,
+		notifier:           notifier,
 	}
 }
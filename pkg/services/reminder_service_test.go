@@ -6,18 +6,33 @@ import (
 	"testing"
 	"time"
 
+	"github.com/Adebayobenjamin/numerisbook/pkg/auth"
+	"github.com/Adebayobenjamin/numerisbook/pkg/common/money"
+	response_dto "github.com/Adebayobenjamin/numerisbook/pkg/dtos/response"
 	"github.com/Adebayobenjamin/numerisbook/pkg/helper"
 	"github.com/Adebayobenjamin/numerisbook/pkg/models"
+	"github.com/Adebayobenjamin/numerisbook/pkg/notifiers"
+	notifiers_mocks "github.com/Adebayobenjamin/numerisbook/pkg/notifiers/mocks"
 	repository_mocks "github.com/Adebayobenjamin/numerisbook/pkg/repositories/mocks"
+	"github.com/Adebayobenjamin/numerisbook/pkg/tenant"
+	"github.com/rs/zerolog"
 	"github.com/stretchr/testify/assert"
 	"go.uber.org/mock/gomock"
 )
 
-func setupReminderTest(t *testing.T) (*repository_mocks.MockReminderRepository, *reminderService) {
+func setupReminderTest(t *testing.T) (
+	*repository_mocks.MockReminderRepository,
+	*repository_mocks.MockInvoiceRepository,
+	*notifiers_mocks.MockNotifier,
+	*reminderService,
+) {
 	ctrl := gomock.NewController(t)
 	mockRepo := repository_mocks.NewMockReminderRepository(ctrl)
-	service := NewReminderService(mockRepo).(*reminderService)
-	return mockRepo, service
+	mockInvoiceRepo := repository_mocks.NewMockInvoiceRepository(ctrl)
+	mockNotifier := notifiers_mocks.NewMockNotifier(ctrl)
+	logger := zerolog.New(nil)
+	service := NewReminderService(&logger, mockRepo, mockInvoiceRepo, mockNotifier).(*reminderService)
+	return mockRepo, mockInvoiceRepo, mockNotifier, service
 }
 
 func TestGetReminderDateFromSchedule(t *testing.T) {
@@ -64,7 +79,7 @@ func TestGetReminderDateFromSchedule(t *testing.T) {
 }
 
 func TestSetInvoiceReminders(t *testing.T) {
-	mockRepo, service := setupReminderTest(t)
+	mockRepo, _, _, service := setupReminderTest(t)
 	ctx := context.Background()
 	dueDate := time.Date(2024, 3, 15, 0, 0, 0, 0, time.UTC)
 
@@ -181,11 +196,127 @@ func TestSetInvoiceReminders(t *testing.T) {
 	}
 }
 
+func TestDispatchDueReminders(t *testing.T) {
+	ctx := context.Background()
+	now := time.Date(2024, 3, 8, 9, 0, 0, 0, time.UTC)
+	lease := now.Add(reminderClaimLease)
+	// every claimed reminder is handled in its own customer's scope
+	scoped := auth.WithSystemPrincipal(tenant.WithCustomerID(ctx, 2), 2)
+
+	claimed := func(attempts int) []models.InvoiceReminder {
+		return []models.InvoiceReminder{{
+			ID:            7,
+			InvoiceID:     1,
+			CustomerID:    2,
+			Schedule:      models.InvoiceReminderSchedule7DaysBeforeDue,
+			ReminderDate:  now.Add(-time.Hour),
+			Attempts:      attempts,
+			NextAttemptAt: &lease,
+		}}
+	}
+	details := func(status models.InvoiceStatus) *response_dto.GetInvoiceDetailsResponse {
+		return &response_dto.GetInvoiceDetailsResponse{
+			ID:              1,
+			InvoiceNumber:   "INV-2024-00001",
+			CustomerID:      2,
+			Issuer:          &models.Customer{Name: "Numeris Ltd"},
+			InvoiceClient:   models.InvoiceClient{Name: "Ada", Email: "ada@example.com"},
+			DueDate:         time.Date(2024, 3, 15, 0, 0, 0, 0, time.UTC),
+			BillingCurrency: "USD",
+			TotalAmountDue:  money.New(100000, "USD"),
+			Payments:        []models.Payment{{Amount: money.New(25000, "USD")}},
+			Status:          status,
+		}
+	}
+
+	t.Run("sends the reminder and records it", func(t *testing.T) {
+		mockRepo, mockInvoiceRepo, mockNotifier, service := setupReminderTest(t)
+
+		mockRepo.EXPECT().ClaimDueReminders(ctx, now, reminderClaimLease, maxReminderAttempts, dueRemindersBatchSize).Return(claimed(1), nil)
+		mockInvoiceRepo.EXPECT().GetDetails(scoped, uint(1)).Return(details(models.InvoiceStatusSent), nil)
+		mockNotifier.EXPECT().
+			Notify(scoped, gomock.Any()).
+			DoAndReturn(func(_ context.Context, notification notifiers.Notification) error {
+				assert.Equal(t, "ada@example.com", notification.To)
+				assert.Equal(t, "Reminder: invoice INV-2024-00001 is due on March 15, 2024", notification.Subject)
+				assert.Contains(t, notification.Body, "USD 750.00 is due on March 15, 2024")
+				assert.Contains(t, notification.HTML, "Numeris Ltd")
+				return nil
+			})
+		mockRepo.EXPECT().MarkReminderSent(scoped, uint(7), now).Return(nil)
+
+		sent, err := service.DispatchDueReminders(ctx, now)
+
+		assert.NoError(t, err)
+		assert.Equal(t, 1, sent)
+	})
+
+	t.Run("skips invoices that have been paid since they were claimed", func(t *testing.T) {
+		mockRepo, mockInvoiceRepo, _, service := setupReminderTest(t)
+
+		mockRepo.EXPECT().ClaimDueReminders(ctx, now, reminderClaimLease, maxReminderAttempts, dueRemindersBatchSize).Return(claimed(1), nil)
+		mockInvoiceRepo.EXPECT().GetDetails(scoped, uint(1)).Return(details(models.InvoiceStatusPaid), nil)
+
+		sent, err := service.DispatchDueReminders(ctx, now)
+
+		assert.NoError(t, err)
+		assert.Equal(t, 0, sent)
+	})
+
+	t.Run("records the error and backs off when delivery fails", func(t *testing.T) {
+		mockRepo, mockInvoiceRepo, mockNotifier, service := setupReminderTest(t)
+		retryAt := now.Add(2 * reminderRetryBaseDelay)
+
+		mockRepo.EXPECT().ClaimDueReminders(ctx, now, reminderClaimLease, maxReminderAttempts, dueRemindersBatchSize).Return(claimed(2), nil)
+		mockInvoiceRepo.EXPECT().GetDetails(scoped, uint(1)).Return(details(models.InvoiceStatusOverdue), nil)
+		mockNotifier.EXPECT().Notify(scoped, gomock.Any()).Return(errors.New("smtp unavailable"))
+		mockRepo.EXPECT().MarkReminderFailed(scoped, uint(7), "smtp unavailable", &retryAt).Return(nil)
+
+		sent, err := service.DispatchDueReminders(ctx, now)
+
+		assert.NoError(t, err)
+		assert.Equal(t, 0, sent)
+	})
+
+	t.Run("gives up after the last attempt", func(t *testing.T) {
+		mockRepo, mockInvoiceRepo, mockNotifier, service := setupReminderTest(t)
+
+		mockRepo.EXPECT().ClaimDueReminders(ctx, now, reminderClaimLease, maxReminderAttempts, dueRemindersBatchSize).Return(claimed(maxReminderAttempts), nil)
+		mockInvoiceRepo.EXPECT().GetDetails(scoped, uint(1)).Return(details(models.InvoiceStatusSent), nil)
+		mockNotifier.EXPECT().Notify(scoped, gomock.Any()).Return(errors.New("smtp unavailable"))
+		mockRepo.EXPECT().MarkReminderFailed(scoped, uint(7), "smtp unavailable", nil).Return(nil)
+
+		_, err := service.DispatchDueReminders(ctx, now)
+
+		assert.NoError(t, err)
+	})
+
+	t.Run("claim error", func(t *testing.T) {
+		mockRepo, _, _, service := setupReminderTest(t)
+
+		mockRepo.EXPECT().ClaimDueReminders(ctx, now, reminderClaimLease, maxReminderAttempts, dueRemindersBatchSize).Return(nil, errors.New("database error"))
+
+		_, err := service.DispatchDueReminders(ctx, now)
+
+		assert.Error(t, err)
+	})
+}
+
+func TestNextReminderAttempt(t *testing.T) {
+	now := time.Date(2024, 3, 8, 9, 0, 0, 0, time.UTC)
+
+	assert.Equal(t, now.Add(5*time.Minute), *nextReminderAttempt(1, now))
+	assert.Equal(t, now.Add(10*time.Minute), *nextReminderAttempt(2, now))
+	assert.Equal(t, now.Add(40*time.Minute), *nextReminderAttempt(4, now))
+	assert.Nil(t, nextReminderAttempt(maxReminderAttempts, now))
+}
+
 func TestNewReminderService(t *testing.T) {
 	ctrl := gomock.NewController(t)
 	mockRepo := repository_mocks.NewMockReminderRepository(ctrl)
+	logger := zerolog.New(nil)
 
-	service := NewReminderService(mockRepo)
+	service := NewReminderService(&logger, mockRepo, repository_mocks.NewMockInvoiceRepository(ctrl), notifiers_mocks.NewMockNotifier(ctrl))
 
 	assert.NotNil(t, service)
 
@@ -0,0 +1,356 @@
+package services
+
+import (
+	"context"
+	"crypto/hmac"
+	"crypto/rand"
+	"crypto/sha256"
+	"encoding/base64"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"os"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/Adebayobenjamin/numerisbook/pkg/auth"
+	"github.com/Adebayobenjamin/numerisbook/pkg/common/exceptions"
+	response_dto "github.com/Adebayobenjamin/numerisbook/pkg/dtos/response"
+	"github.com/Adebayobenjamin/numerisbook/pkg/models"
+	repositories_interfaces "github.com/Adebayobenjamin/numerisbook/pkg/repositories/interfaces"
+	services_interfaces "github.com/Adebayobenjamin/numerisbook/pkg/services/interfaces"
+	"github.com/Adebayobenjamin/numerisbook/pkg/tenant"
+	"github.com/rs/zerolog"
+)
+
+// defaultShareLinkTTL is how long a share link stays valid when SHARE_LINK_TTL is not set
+const defaultShareLinkTTL = 30 * 24 * time.Hour
+
+var errInvalidShareToken = errors.New("invalid share token")
+
+type shareLinkService struct {
+	logger              *zerolog.Logger
+	shareLinkRepository repositories_interfaces.ShareLinkRepository
+	invoiceRepository   repositories_interfaces.InvoiceRepository
+	invoiceService      services_interfaces.InvoiceService
+	auditService        services_interfaces.AuditService
+	secret              []byte
+	baseURL             string
+	ttl                 time.Duration
+}
+
+// GetShareLink implements services_interfaces.ShareLinkService.
+func (s *shareLinkService) GetShareLink(ctx context.Context, invoice *models.Invoice) (*response_dto.ShareLinkResponse, error) {
+	link, err := s.shareLinkRepository.GetActiveByInvoiceID(ctx, invoice.ID, time.Now().UTC())
+	if err == nil {
+		return s.buildShareLinkResponse(link)
+	}
+	if !errors.Is(err, exceptions.ErrNotFound) {
+		return nil, err
+	}
+
+	// readers may look at an existing link but not mint a new one
+	if err := auth.Authorize(ctx, auth.PermissionInvoicesWrite); err != nil {
+		return nil, err
+	}
+
+	return s.createShareLink(ctx, invoice)
+}
+
+// RegenerateShareLink implements services_interfaces.ShareLinkService.
+func (s *shareLinkService) RegenerateShareLink(ctx context.Context, invoice *models.Invoice) (*response_dto.ShareLinkResponse, error) {
+	if err := auth.Authorize(ctx, auth.PermissionInvoicesWrite); err != nil {
+		return nil, err
+	}
+
+	revoked, err := s.shareLinkRepository.RevokeByInvoiceID(ctx, invoice.ID, time.Now().UTC())
+	if err != nil {
+		return nil, err
+	}
+	if revoked > 0 {
+		s.auditService.CreateAuditTrail(ctx, models.EventTypeShareLinkRevoked, models.LogLevelInfo,
+			fmt.Sprintf("shareable link for invoice %s revoked by regeneration", invoice.InvoiceNumber), invoice.ID, invoice.CustomerID)
+	}
+
+	return s.createShareLink(ctx, invoice)
+}
+
+// RevokeShareLinks implements services_interfaces.ShareLinkService.
+func (s *shareLinkService) RevokeShareLinks(ctx context.Context, invoice *models.Invoice) error {
+	if err := auth.Authorize(ctx, auth.PermissionInvoicesWrite); err != nil {
+		return err
+	}
+
+	revoked, err := s.shareLinkRepository.RevokeByInvoiceID(ctx, invoice.ID, time.Now().UTC())
+	if err != nil {
+		return err
+	}
+
+	if invoice.ShareableLink != nil {
+		if err := s.invoiceRepository.UpdateShareableLink(ctx, invoice.ID, nil); err != nil {
+			return err
+		}
+	}
+
+	if revoked > 0 {
+		s.auditService.CreateAuditTrail(ctx, models.EventTypeShareLinkRevoked, models.LogLevelInfo,
+			fmt.Sprintf("shareable link for invoice %s revoked", invoice.InvoiceNumber), invoice.ID, invoice.CustomerID)
+	}
+
+	return nil
+}
+
+// GetSharedInvoice implements services_interfaces.ShareLinkService.
+func (s *shareLinkService) GetSharedInvoice(ctx context.Context, token string) (*response_dto.PublicInvoiceResponse, error) {
+	notFound := fmt.Errorf("shared invoice %w", exceptions.ErrNotFound)
+
+	tokenID, expiresAt, err := s.parseToken(token)
+	if err != nil {
+		return nil, notFound
+	}
+
+	now := time.Now().UTC()
+	link, err := s.shareLinkRepository.GetByTokenID(ctx, tokenID)
+	if err != nil {
+		if errors.Is(err, exceptions.ErrNotFound) {
+			return nil, notFound
+		}
+		return nil, err
+	}
+	// the expiry is signed into the token, so it must also match the stored one
+	if !link.IsActive(now) || !link.ExpiresAt.Equal(expiresAt) {
+		return nil, notFound
+	}
+
+	// the token is the only credential, the link decides whose invoice is read
+	ctx = auth.WithSystemPrincipal(tenant.WithCustomerID(ctx, link.CustomerID), link.CustomerID)
+
+	details, err := s.invoiceService.GetInvoiceDetails(ctx, link.InvoiceID)
+	if err != nil {
+		return nil, err
+	}
+
+	view, err := buildPublicInvoice(details)
+	if err != nil {
+		return nil, err
+	}
+
+	if err := s.shareLinkRepository.RecordView(ctx, link.ID, now); err != nil {
+		return nil, err
+	}
+
+	message := fmt.Sprintf("invoice %s viewed via shareable link (%d views)", details.InvoiceNumber, link.ViewCount+1)
+	if link.FirstViewedAt == nil {
+		message = fmt.Sprintf("invoice %s first viewed via shareable link", details.InvoiceNumber)
+	}
+	s.auditService.CreateAuditTrail(ctx, models.EventTypeInvoiceViewed, models.LogLevelInfo, message, link.InvoiceID, link.CustomerID)
+
+	return view, nil
+}
+
+func (s *shareLinkService) createShareLink(ctx context.Context, invoice *models.Invoice) (*response_dto.ShareLinkResponse, error) {
+	if invoice.Status == models.InvoiceStatusDraft {
+		return nil, fmt.Errorf("draft invoices cannot be shared")
+	}
+	if len(s.secret) == 0 {
+		return nil, fmt.Errorf("share links are not configured")
+	}
+
+	tokenID, err := newShareTokenID()
+	if err != nil {
+		return nil, err
+	}
+
+	link, err := s.shareLinkRepository.Create(ctx, &models.InvoiceShareLink{
+		InvoiceID:  invoice.ID,
+		CustomerID: invoice.CustomerID,
+		TokenID:    tokenID,
+		// stored timestamps have second precision and the token has to match them
+		ExpiresAt: time.Now().UTC().Add(s.ttl).Truncate(time.Second),
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	response, err := s.buildShareLinkResponse(link)
+	if err != nil {
+		return nil, err
+	}
+
+	if err := s.invoiceRepository.UpdateShareableLink(ctx, invoice.ID, &response.URL); err != nil {
+		return nil, err
+	}
+
+	s.auditService.CreateAuditTrail(ctx, models.EventTypeShareLinkCreated, models.LogLevelInfo,
+		fmt.Sprintf("shareable link for invoice %s created, expires %s", invoice.InvoiceNumber, link.ExpiresAt.Format(time.RFC3339)), invoice.ID, invoice.CustomerID)
+
+	return response, nil
+}
+
+func (s *shareLinkService) buildShareLinkResponse(link *models.InvoiceShareLink) (*response_dto.ShareLinkResponse, error) {
+	if len(s.secret) == 0 {
+		return nil, fmt.Errorf("share links are not configured")
+	}
+
+	return &response_dto.ShareLinkResponse{
+		URL:           fmt.Sprintf("%s/invoice/%s", s.baseURL, s.signToken(link.TokenID, link.ExpiresAt)),
+		ExpiresAt:     link.ExpiresAt,
+		FirstViewedAt: link.FirstViewedAt,
+		LastViewedAt:  link.LastViewedAt,
+		ViewCount:     link.ViewCount,
+		CreatedAt:     link.CreatedAt,
+	}, nil
+}
+
+// signToken builds a "<token id>.<expiry>.<signature>" token, signed with
+// HMAC-SHA256 so that tokens cannot be forged or have their expiry extended
+func (s *shareLinkService) signToken(tokenID string, expiresAt time.Time) string {
+	return signShareToken(s.secret, tokenID, expiresAt)
+}
+
+// parseToken checks the signature of a token and returns the token ID and expiry it carries
+func (s *shareLinkService) parseToken(token string) (string, time.Time, error) {
+	return parseShareToken(s.secret, token)
+}
+
+// signShareToken signs a token for anything shared by link, invoices and quotes alike
+func signShareToken(secret []byte, tokenID string, expiresAt time.Time) string {
+	payload := tokenID + "." + strconv.FormatInt(expiresAt.Unix(), 10)
+	return payload + "." + shareTokenSignature(secret, payload)
+}
+
+// parseShareToken is the counterpart of signShareToken
+func parseShareToken(secret []byte, token string) (string, time.Time, error) {
+	if len(secret) == 0 {
+		return "", time.Time{}, errInvalidShareToken
+	}
+
+	parts := strings.Split(token, ".")
+	if len(parts) != 3 {
+		return "", time.Time{}, errInvalidShareToken
+	}
+
+	payload := parts[0] + "." + parts[1]
+	if !hmac.Equal([]byte(parts[2]), []byte(shareTokenSignature(secret, payload))) {
+		return "", time.Time{}, errInvalidShareToken
+	}
+
+	expiry, err := strconv.ParseInt(parts[1], 10, 64)
+	if err != nil {
+		return "", time.Time{}, errInvalidShareToken
+	}
+
+	return parts[0], time.Unix(expiry, 0).UTC(), nil
+}
+
+// newShareTokenID returns the random part of a share token
+func newShareTokenID() (string, error) {
+	tokenID := make([]byte, 16)
+	if _, err := rand.Read(tokenID); err != nil {
+		return "", fmt.Errorf("failed to generate share token: %w", err)
+	}
+	return hex.EncodeToString(tokenID), nil
+}
+
+func shareTokenSignature(secret []byte, payload string) string {
+	mac := hmac.New(sha256.New, secret)
+	mac.Write([]byte(payload))
+	return base64.RawURLEncoding.EncodeToString(mac.Sum(nil))
+}
+
+// buildPublicInvoice copies the parts of an invoice that are safe to show to its recipient
+func buildPublicInvoice(details *response_dto.GetInvoiceDetailsResponse) (*response_dto.PublicInvoiceResponse, error) {
+	paid, err := invoiceAmountPaid(details)
+	if err != nil {
+		return nil, err
+	}
+
+	balance, err := details.TotalAmountDue.Sub(paid)
+	if err != nil {
+		return nil, fmt.Errorf("failed to calculate outstanding balance: %w", err)
+	}
+
+	view := &response_dto.PublicInvoiceResponse{
+		InvoiceNumber:   details.InvoiceNumber,
+		Status:          details.Status,
+		IssueDate:       details.IssueDate,
+		DueDate:         details.DueDate,
+		BillingCurrency: details.BillingCurrency,
+		Items:           make([]response_dto.PublicInvoiceItem, 0, len(details.Items)),
+		Subtotal:        details.Subtotal,
+		DiscountTotal:   details.DiscountTotal,
+		TaxTotal:        details.TaxTotal,
+		TaxSummary:      details.TaxSummary,
+		TotalAmountDue:  details.TotalAmountDue,
+		AmountPaid:      paid,
+		BalanceDue:      balance,
+		Notes:           details.Notes,
+	}
+
+	if customer := details.Issuer; customer != nil {
+		view.From = response_dto.PublicInvoiceParty{
+			Name:       customer.Name,
+			Email:      customer.Email,
+			Phone:      customer.Phone,
+			Address:    customer.Address,
+			LogoURL:    customer.LogoURL,
+			BrandColor: customer.BrandColor,
+		}
+	}
+	view.BillTo = response_dto.PublicInvoiceParty{
+		Name:    details.InvoiceClient.Name,
+		Email:   details.InvoiceClient.Email,
+		Phone:   details.InvoiceClient.Phone,
+		Address: details.InvoiceClient.Address,
+	}
+
+	for _, item := range details.Items {
+		view.Items = append(view.Items, response_dto.PublicInvoiceItem{
+			Description:    item.Description,
+			Quantity:       item.Quantity,
+			UnitPrice:      item.UnitPrice,
+			DiscountAmount: item.DiscountAmount,
+			TaxTotal:       item.TaxTotal,
+			TotalPrice:     item.TotalPrice,
+		})
+	}
+
+	if info := details.PaymentInformation; info != nil {
+		view.PaymentInformation = &response_dto.PublicPaymentInformation{
+			BankName:      info.BankName,
+			AccountName:   info.AccountName,
+			AccountNumber: info.AccountNumber,
+			AchRoutingNo:  info.AchRoutingNo,
+			BankAddress:   info.BankAddress,
+		}
+	}
+
+	return view, nil
+}
+
+// NewShareLinkService signs share links with SHARE_LINK_SECRET and points them
+// at FRONTEND_URL. Links are valid for SHARE_LINK_TTL, 30 days by default.
+func NewShareLinkService(
+	logger *zerolog.Logger,
+	shareLinkRepository repositories_interfaces.ShareLinkRepository,
+	invoiceRepository repositories_interfaces.InvoiceRepository,
+	invoiceService services_interfaces.InvoiceService,
+	auditService services_interfaces.AuditService,
+) services_interfaces.ShareLinkService {
+	ttl, err := time.ParseDuration(os.Getenv("SHARE_LINK_TTL"))
+	if err != nil || ttl <= 0 {
+		ttl = defaultShareLinkTTL
+	}
+
+	return &shareLinkService{
+		logger:              logger,
+		shareLinkRepository: shareLinkRepository,
+		invoiceRepository:   invoiceRepository,
+		invoiceService:      invoiceService,
+		auditService:        auditService,
+		secret:              []byte(os.Getenv("SHARE_LINK_SECRET")),
+		baseURL:             strings.TrimRight(os.Getenv("FRONTEND_URL"), "/"),
+		ttl:                 ttl,
+	}
+}
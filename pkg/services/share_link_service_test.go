@@ -0,0 +1,241 @@
+package services
+
+import (
+	"context"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/Adebayobenjamin/numerisbook/pkg/auth"
+	"github.com/Adebayobenjamin/numerisbook/pkg/common/exceptions"
+	"github.com/Adebayobenjamin/numerisbook/pkg/common/money"
+	"github.com/Adebayobenjamin/numerisbook/pkg/models"
+	repository_mocks "github.com/Adebayobenjamin/numerisbook/pkg/repositories/mocks"
+	services_mocks "github.com/Adebayobenjamin/numerisbook/pkg/services/mocks"
+	"github.com/Adebayobenjamin/numerisbook/pkg/tenant"
+	"github.com/rs/zerolog"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/mock/gomock"
+)
+
+func setupShareLinkTest(t *testing.T) (
+	*repository_mocks.MockShareLinkRepository,
+	*repository_mocks.MockInvoiceRepository,
+	*services_mocks.MockInvoiceService,
+	*services_mocks.MockAuditService,
+	*shareLinkService,
+) {
+	ctrl := gomock.NewController(t)
+	mockShareLinkRepo := repository_mocks.NewMockShareLinkRepository(ctrl)
+	mockInvoiceRepo := repository_mocks.NewMockInvoiceRepository(ctrl)
+	mockInvoiceService := services_mocks.NewMockInvoiceService(ctrl)
+	mockAuditService := services_mocks.NewMockAuditService(ctrl)
+	logger := zerolog.New(nil)
+	service := &shareLinkService{
+		logger:              &logger,
+		shareLinkRepository: mockShareLinkRepo,
+		invoiceRepository:   mockInvoiceRepo,
+		invoiceService:      mockInvoiceService,
+		auditService:        mockAuditService,
+		secret:              []byte("test-secret"),
+		baseURL:             "http://example.com",
+		ttl:                 defaultShareLinkTTL,
+	}
+	return mockShareLinkRepo, mockInvoiceRepo, mockInvoiceService, mockAuditService, service
+}
+
+func shareLinkToken(t *testing.T, url string) string {
+	t.Helper()
+	token, found := strings.CutPrefix(url, "http://example.com/invoice/")
+	require.True(t, found, url)
+	return token
+}
+
+func TestGetShareLink(t *testing.T) {
+	ctx := auth.WithPrincipal(context.Background(), &auth.Principal{CustomerID: 1, Role: models.UserRoleOwner})
+	invoice := &models.Invoice{ID: 1, CustomerID: 2, InvoiceNumber: "INV-001", Status: models.InvoiceStatusSent}
+	expiresAt := time.Now().UTC().Add(time.Hour).Truncate(time.Second)
+
+	t.Run("returns the active link", func(t *testing.T) {
+		mockShareLinkRepo, _, _, _, service := setupShareLinkTest(t)
+		link := &models.InvoiceShareLink{ID: 7, InvoiceID: 1, TokenID: "abc", ExpiresAt: expiresAt, ViewCount: 4}
+		mockShareLinkRepo.EXPECT().GetActiveByInvoiceID(ctx, uint(1), gomock.Any()).Return(link, nil)
+
+		response, err := service.GetShareLink(ctx, invoice)
+
+		require.NoError(t, err)
+		assert.Equal(t, service.signToken("abc", expiresAt), shareLinkToken(t, response.URL))
+		assert.Equal(t, 4, response.ViewCount)
+	})
+
+	t.Run("creates a link when there is none", func(t *testing.T) {
+		mockShareLinkRepo, mockInvoiceRepo, _, mockAuditService, service := setupShareLinkTest(t)
+		mockShareLinkRepo.EXPECT().GetActiveByInvoiceID(ctx, uint(1), gomock.Any()).Return(nil, exceptions.ErrNotFound)
+		mockShareLinkRepo.EXPECT().Create(ctx, gomock.Any()).DoAndReturn(
+			func(ctx context.Context, link *models.InvoiceShareLink) (*models.InvoiceShareLink, error) {
+				assert.Len(t, link.TokenID, 32)
+				assert.Equal(t, uint(2), link.CustomerID)
+				assert.WithinDuration(t, time.Now().Add(defaultShareLinkTTL), link.ExpiresAt, time.Minute)
+				return link, nil
+			})
+		mockInvoiceRepo.EXPECT().UpdateShareableLink(ctx, uint(1), gomock.Any()).Return(nil)
+		mockAuditService.EXPECT().CreateAuditTrail(ctx, models.EventTypeShareLinkCreated, models.LogLevelInfo, gomock.Any(), uint(1), uint(2))
+
+		response, err := service.GetShareLink(ctx, invoice)
+
+		require.NoError(t, err)
+		assert.True(t, strings.HasPrefix(response.URL, "http://example.com/invoice/"))
+	})
+
+	t.Run("drafts cannot be shared", func(t *testing.T) {
+		mockShareLinkRepo, _, _, _, service := setupShareLinkTest(t)
+		mockShareLinkRepo.EXPECT().GetActiveByInvoiceID(ctx, uint(1), gomock.Any()).Return(nil, exceptions.ErrNotFound)
+
+		_, err := service.GetShareLink(ctx, &models.Invoice{ID: 1, Status: models.InvoiceStatusDraft})
+
+		assert.EqualError(t, err, "draft invoices cannot be shared")
+	})
+
+	t.Run("unconfigured secret", func(t *testing.T) {
+		mockShareLinkRepo, _, _, _, service := setupShareLinkTest(t)
+		service.secret = nil
+		mockShareLinkRepo.EXPECT().GetActiveByInvoiceID(ctx, uint(1), gomock.Any()).Return(nil, exceptions.ErrNotFound)
+
+		_, err := service.GetShareLink(ctx, invoice)
+
+		assert.EqualError(t, err, "share links are not configured")
+	})
+}
+
+func TestRegenerateShareLink(t *testing.T) {
+	mockShareLinkRepo, mockInvoiceRepo, _, mockAuditService, service := setupShareLinkTest(t)
+	ctx := auth.WithPrincipal(context.Background(), &auth.Principal{CustomerID: 1, Role: models.UserRoleOwner})
+	invoice := &models.Invoice{ID: 1, CustomerID: 2, Status: models.InvoiceStatusSent}
+
+	gomock.InOrder(
+		mockShareLinkRepo.EXPECT().RevokeByInvoiceID(ctx, uint(1), gomock.Any()).Return(int64(1), nil),
+		mockAuditService.EXPECT().CreateAuditTrail(ctx, models.EventTypeShareLinkRevoked, models.LogLevelInfo, gomock.Any(), uint(1), uint(2)),
+		mockShareLinkRepo.EXPECT().Create(ctx, gomock.Any()).DoAndReturn(
+			func(ctx context.Context, link *models.InvoiceShareLink) (*models.InvoiceShareLink, error) {
+				return link, nil
+			}),
+		mockInvoiceRepo.EXPECT().UpdateShareableLink(ctx, uint(1), gomock.Any()).Return(nil),
+		mockAuditService.EXPECT().CreateAuditTrail(ctx, models.EventTypeShareLinkCreated, models.LogLevelInfo, gomock.Any(), uint(1), uint(2)),
+	)
+
+	response, err := service.RegenerateShareLink(ctx, invoice)
+
+	require.NoError(t, err)
+	assert.NotEmpty(t, response.URL)
+}
+
+func TestRevokeShareLinks(t *testing.T) {
+	mockShareLinkRepo, mockInvoiceRepo, _, mockAuditService, service := setupShareLinkTest(t)
+	ctx := auth.WithPrincipal(context.Background(), &auth.Principal{CustomerID: 1, Role: models.UserRoleOwner})
+	link := "http://example.com/invoice/token"
+	invoice := &models.Invoice{ID: 1, CustomerID: 2, ShareableLink: &link}
+
+	mockShareLinkRepo.EXPECT().RevokeByInvoiceID(ctx, uint(1), gomock.Any()).Return(int64(1), nil)
+	mockInvoiceRepo.EXPECT().UpdateShareableLink(ctx, uint(1), nil).Return(nil)
+	mockAuditService.EXPECT().CreateAuditTrail(ctx, models.EventTypeShareLinkRevoked, models.LogLevelInfo, gomock.Any(), uint(1), uint(2))
+
+	assert.NoError(t, service.RevokeShareLinks(ctx, invoice))
+}
+
+func TestGetSharedInvoice(t *testing.T) {
+	ctx := context.Background()
+	expiresAt := time.Now().UTC().Add(time.Hour).Truncate(time.Second)
+	scoped := auth.WithSystemPrincipal(tenant.WithCustomerID(ctx, 2), 2)
+
+	t.Run("returns the sanitized invoice and records the first view", func(t *testing.T) {
+		mockShareLinkRepo, _, mockInvoiceService, mockAuditService, service := setupShareLinkTest(t)
+		link := &models.InvoiceShareLink{ID: 7, InvoiceID: 1, CustomerID: 2, TokenID: "abc", ExpiresAt: expiresAt}
+		details := invoiceEmailDetails()
+		details.Payments = []models.Payment{{Amount: money.New(25000, "USD")}}
+		details.Items = []models.InvoiceItem{{ID: 9, Description: "Design", Quantity: 1, UnitPrice: money.New(100000, "USD")}}
+
+		mockShareLinkRepo.EXPECT().GetByTokenID(ctx, "abc").Return(link, nil)
+		mockInvoiceService.EXPECT().GetInvoiceDetails(scoped, uint(1)).Return(details, nil)
+		mockShareLinkRepo.EXPECT().RecordView(scoped, uint(7), gomock.Any()).Return(nil)
+		mockAuditService.EXPECT().CreateAuditTrail(scoped, models.EventTypeInvoiceViewed, models.LogLevelInfo,
+			"invoice INV-2024-00001 first viewed via shareable link", uint(1), uint(2))
+
+		view, err := service.GetSharedInvoice(ctx, service.signToken("abc", expiresAt))
+
+		require.NoError(t, err)
+		assert.Equal(t, "INV-2024-00001", view.InvoiceNumber)
+		assert.Equal(t, "Acme Ltd", view.From.Name)
+		assert.Equal(t, "Ada", view.BillTo.Name)
+		assert.Equal(t, money.New(75000, "USD"), view.BalanceDue)
+		assert.Equal(t, "Design", view.Items[0].Description)
+	})
+
+	t.Run("counts later views", func(t *testing.T) {
+		mockShareLinkRepo, _, mockInvoiceService, mockAuditService, service := setupShareLinkTest(t)
+		viewedAt := time.Now().Add(-time.Hour)
+		link := &models.InvoiceShareLink{ID: 7, InvoiceID: 1, CustomerID: 2, TokenID: "abc", ExpiresAt: expiresAt, FirstViewedAt: &viewedAt, ViewCount: 2}
+
+		mockShareLinkRepo.EXPECT().GetByTokenID(ctx, "abc").Return(link, nil)
+		mockInvoiceService.EXPECT().GetInvoiceDetails(scoped, uint(1)).Return(invoiceEmailDetails(), nil)
+		mockShareLinkRepo.EXPECT().RecordView(scoped, uint(7), gomock.Any()).Return(nil)
+		mockAuditService.EXPECT().CreateAuditTrail(scoped, models.EventTypeInvoiceViewed, models.LogLevelInfo,
+			"invoice INV-2024-00001 viewed via shareable link (3 views)", uint(1), uint(2))
+
+		_, err := service.GetSharedInvoice(ctx, service.signToken("abc", expiresAt))
+
+		assert.NoError(t, err)
+	})
+
+	revokedAt := time.Now().Add(-time.Minute)
+	tests := []struct {
+		name  string
+		token func(service *shareLinkService) string
+		link  *models.InvoiceShareLink
+	}{
+		{
+			name:  "malformed token",
+			token: func(service *shareLinkService) string { return "not-a-token" },
+		},
+		{
+			name: "forged signature",
+			token: func(service *shareLinkService) string {
+				return "abc." + service.signToken("abc", expiresAt)[4:14] + ".forged"
+			},
+		},
+		{
+			name: "extended expiry",
+			token: func(service *shareLinkService) string {
+				signed := service.signToken("abc", expiresAt)
+				parts := strings.Split(signed, ".")
+				return parts[0] + ".9999999999." + parts[2]
+			},
+		},
+		{
+			name:  "revoked link",
+			token: func(service *shareLinkService) string { return service.signToken("abc", expiresAt) },
+			link:  &models.InvoiceShareLink{ID: 7, TokenID: "abc", ExpiresAt: expiresAt, RevokedAt: &revokedAt},
+		},
+		{
+			name: "expired link",
+			token: func(service *shareLinkService) string {
+				return service.signToken("abc", time.Now().UTC().Add(-time.Hour).Truncate(time.Second))
+			},
+			link: &models.InvoiceShareLink{ID: 7, TokenID: "abc", ExpiresAt: time.Now().UTC().Add(-time.Hour).Truncate(time.Second)},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			mockShareLinkRepo, _, _, _, service := setupShareLinkTest(t)
+			if tt.link != nil {
+				mockShareLinkRepo.EXPECT().GetByTokenID(ctx, "abc").Return(tt.link, nil)
+			}
+
+			view, err := service.GetSharedInvoice(ctx, tt.token(service))
+
+			assert.Nil(t, view)
+			assert.ErrorIs(t, err, exceptions.ErrNotFound)
+		})
+	}
+}
@@ -0,0 +1,121 @@
+package services
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/Adebayobenjamin/numerisbook/pkg/auth"
+	request_dto "github.com/Adebayobenjamin/numerisbook/pkg/dtos/request"
+	"github.com/Adebayobenjamin/numerisbook/pkg/models"
+	repositories_interfaces "github.com/Adebayobenjamin/numerisbook/pkg/repositories/interfaces"
+	services_interfaces "github.com/Adebayobenjamin/numerisbook/pkg/services/interfaces"
+	"github.com/rs/zerolog"
+)
+
+type userService struct {
+	logger         *zerolog.Logger
+	userRepository repositories_interfaces.UserRepository
+}
+
+// GetUsers implements services_interfaces.UserService.
+func (u *userService) GetUsers(ctx context.Context, customerID uint) ([]models.User, error) {
+	if err := auth.Authorize(ctx, auth.PermissionMembersManage); err != nil {
+		return nil, err
+	}
+
+	return u.userRepository.GetAllCustomerUsers(ctx, customerID)
+}
+
+// AddUser implements services_interfaces.UserService.
+func (u *userService) AddUser(ctx context.Context, customerID uint, request *request_dto.AddUserRequest) (*models.User, error) {
+	if err := auth.Authorize(ctx, auth.PermissionMembersManage); err != nil {
+		return nil, err
+	}
+
+	if !auth.IsValidRole(request.Role) {
+		return nil, fmt.Errorf("unknown role %q", request.Role)
+	}
+
+	return u.userRepository.Create(ctx, &models.User{
+		CustomerID: customerID,
+		Subject:    request.Subject,
+		Email:      request.Email,
+		Name:       request.Name,
+		Role:       request.Role,
+	})
+}
+
+// ChangeRole implements services_interfaces.UserService.
+func (u *userService) ChangeRole(ctx context.Context, userID uint, customerID uint, role models.UserRole) (*models.User, error) {
+	if err := auth.Authorize(ctx, auth.PermissionMembersManage); err != nil {
+		return nil, err
+	}
+
+	if !auth.IsValidRole(role) {
+		return nil, fmt.Errorf("unknown role %q", role)
+	}
+
+	user, err := u.userRepository.GetByIDAndCustomerID(ctx, userID, customerID)
+	if err != nil {
+		return nil, err
+	}
+	if user.Role == role {
+		return user, nil
+	}
+
+	if user.Role == models.UserRoleOwner {
+		if err := u.ensureAnotherOwner(ctx, customerID); err != nil {
+			return nil, err
+		}
+	}
+
+	if err := u.userRepository.UpdateRole(ctx, userID, customerID, role); err != nil {
+		return nil, err
+	}
+	user.Role = role
+
+	return user, nil
+}
+
+// RemoveUser implements services_interfaces.UserService.
+func (u *userService) RemoveUser(ctx context.Context, userID uint, customerID uint) error {
+	if err := auth.Authorize(ctx, auth.PermissionMembersManage); err != nil {
+		return err
+	}
+
+	user, err := u.userRepository.GetByIDAndCustomerID(ctx, userID, customerID)
+	if err != nil {
+		return err
+	}
+
+	if user.Role == models.UserRoleOwner {
+		if err := u.ensureAnotherOwner(ctx, customerID); err != nil {
+			return err
+		}
+	}
+
+	return u.userRepository.Delete(ctx, userID, customerID)
+}
+
+// ensureAnotherOwner stops the last owner from being demoted or removed,
+// which would leave nobody able to manage the organisation
+func (u *userService) ensureAnotherOwner(ctx context.Context, customerID uint) error {
+	owners, err := u.userRepository.CountOwners(ctx, customerID)
+	if err != nil {
+		return err
+	}
+	if owners <= 1 {
+		return fmt.Errorf("an organisation must keep at least one owner")
+	}
+	return nil
+}
+
+func NewUserService(
+	logger *zerolog.Logger,
+	userRepository repositories_interfaces.UserRepository,
+) services_interfaces.UserService {
+	return &userService{
+		logger:         logger,
+		userRepository: userRepository,
+	}
+}
@@ -0,0 +1,103 @@
+package services
+
+import (
+	"context"
+	"testing"
+
+	"github.com/Adebayobenjamin/numerisbook/pkg/auth"
+	"github.com/Adebayobenjamin/numerisbook/pkg/models"
+	repository_mocks "github.com/Adebayobenjamin/numerisbook/pkg/repositories/mocks"
+	"github.com/rs/zerolog"
+	"github.com/stretchr/testify/assert"
+	"go.uber.org/mock/gomock"
+)
+
+func setupUserTest(t *testing.T) (*repository_mocks.MockUserRepository, *userService) {
+	ctrl := gomock.NewController(t)
+	mockRepo := repository_mocks.NewMockUserRepository(ctrl)
+	logger := zerolog.New(nil)
+	service := NewUserService(&logger, mockRepo).(*userService)
+	return mockRepo, service
+}
+
+func TestChangeRole(t *testing.T) {
+	ctx := auth.WithPrincipal(context.Background(), &auth.Principal{CustomerID: 1, Role: models.UserRoleOwner})
+
+	tests := []struct {
+		name      string
+		user      *models.User
+		role      models.UserRole
+		owners    int
+		wantRole  models.UserRole
+		wantErr   string
+		wantWrite bool
+	}{
+		{
+			name:      "promote a viewer",
+			user:      &models.User{ID: 2, CustomerID: 1, Role: models.UserRoleViewer},
+			role:      models.UserRoleAccountant,
+			wantRole:  models.UserRoleAccountant,
+			wantWrite: true,
+		},
+		{
+			name:      "demote one of two owners",
+			user:      &models.User{ID: 2, CustomerID: 1, Role: models.UserRoleOwner},
+			role:      models.UserRoleViewer,
+			owners:    2,
+			wantRole:  models.UserRoleViewer,
+			wantWrite: true,
+		},
+		{
+			name:    "demote the last owner",
+			user:    &models.User{ID: 2, CustomerID: 1, Role: models.UserRoleOwner},
+			role:    models.UserRoleAccountant,
+			owners:  1,
+			wantErr: "an organisation must keep at least one owner",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			mockRepo, service := setupUserTest(t)
+			mockRepo.EXPECT().GetByIDAndCustomerID(ctx, tt.user.ID, uint(1)).Return(tt.user, nil)
+			if tt.user.Role == models.UserRoleOwner {
+				mockRepo.EXPECT().CountOwners(ctx, uint(1)).Return(tt.owners, nil)
+			}
+			if tt.wantWrite {
+				mockRepo.EXPECT().UpdateRole(ctx, tt.user.ID, uint(1), tt.role).Return(nil)
+			}
+
+			user, err := service.ChangeRole(ctx, tt.user.ID, 1, tt.role)
+
+			if tt.wantErr != "" {
+				assert.EqualError(t, err, tt.wantErr)
+				return
+			}
+			assert.NoError(t, err)
+			assert.Equal(t, tt.wantRole, user.Role)
+		})
+	}
+}
+
+func TestRemoveUser(t *testing.T) {
+	owner := auth.WithPrincipal(context.Background(), &auth.Principal{CustomerID: 1, Role: models.UserRoleOwner})
+
+	t.Run("the last owner cannot be removed", func(t *testing.T) {
+		mockRepo, service := setupUserTest(t)
+		mockRepo.EXPECT().GetByIDAndCustomerID(owner, uint(1), uint(1)).Return(&models.User{ID: 1, CustomerID: 1, Role: models.UserRoleOwner}, nil)
+		mockRepo.EXPECT().CountOwners(owner, uint(1)).Return(1, nil)
+
+		err := service.RemoveUser(owner, 1, 1)
+
+		assert.EqualError(t, err, "an organisation must keep at least one owner")
+	})
+
+	t.Run("accountants cannot manage members", func(t *testing.T) {
+		_, service := setupUserTest(t)
+		accountant := auth.WithPrincipal(context.Background(), &auth.Principal{CustomerID: 1, Role: models.UserRoleAccountant})
+
+		err := service.RemoveUser(accountant, 2, 1)
+
+		assert.ErrorIs(t, err, auth.ErrForbidden)
+	})
+}
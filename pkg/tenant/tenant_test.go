@@ -0,0 +1,23 @@
+package tenant
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestCustomerIDFromContext(t *testing.T) {
+	t.Run("scoped context", func(t *testing.T) {
+		customerID, err := CustomerIDFromContext(WithCustomerID(context.Background(), 7))
+
+		assert.NoError(t, err)
+		assert.Equal(t, uint(7), customerID)
+	})
+
+	t.Run("unscoped context fails closed", func(t *testing.T) {
+		_, err := CustomerIDFromContext(context.Background())
+
+		assert.ErrorIs(t, err, ErrMissingTenant)
+	})
+}
@@ -0,0 +1,59 @@
+package workers
+
+import (
+	"context"
+	"time"
+
+	services_interfaces "github.com/Adebayobenjamin/numerisbook/pkg/services/interfaces"
+	"github.com/rs/zerolog"
+)
+
+// idempotencyKeyPurgeInterval is how often expired idempotency keys are deleted
+const idempotencyKeyPurgeInterval = time.Hour
+
+// IdempotencyKeyWorker periodically deletes idempotency keys whose responses
+// are no longer kept for replay
+type IdempotencyKeyWorker struct {
+	logger             *zerolog.Logger
+	idempotencyService services_interfaces.IdempotencyService
+	interval           time.Duration
+}
+
+// Start runs the worker until ctx is cancelled
+func (w *IdempotencyKeyWorker) Start(ctx context.Context) {
+	ticker := time.NewTicker(w.interval)
+	defer ticker.Stop()
+
+	for {
+		w.run(ctx)
+
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+		}
+	}
+}
+
+func (w *IdempotencyKeyWorker) run(ctx context.Context) {
+	purged, err := w.idempotencyService.PurgeExpired(ctx, time.Now().UTC())
+	if err != nil {
+		w.logger.Error().Err(err).Msg("failed to purge expired idempotency keys")
+		return
+	}
+
+	if purged > 0 {
+		w.logger.Info().Int64("purged", purged).Msg("purged expired idempotency keys")
+	}
+}
+
+func NewIdempotencyKeyWorker(
+	logger *zerolog.Logger,
+	idempotencyService services_interfaces.IdempotencyService,
+) *IdempotencyKeyWorker {
+	return &IdempotencyKeyWorker{
+		logger:             logger,
+		idempotencyService: idempotencyService,
+		interval:           idempotencyKeyPurgeInterval,
+	}
+}
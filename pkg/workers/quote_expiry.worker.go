@@ -0,0 +1,66 @@
+package workers
+
+import (
+	"context"
+	"os"
+	"time"
+
+	services_interfaces "github.com/Adebayobenjamin/numerisbook/pkg/services/interfaces"
+	"github.com/rs/zerolog"
+)
+
+// defaultQuoteExpiryInterval is how often lapsed quotes are expired when
+// QUOTE_EXPIRY_INTERVAL is not set
+const defaultQuoteExpiryInterval = time.Hour
+
+// QuoteExpiryWorker periodically marks sent quotes that were not answered
+// before their expiry date as expired
+type QuoteExpiryWorker struct {
+	logger       *zerolog.Logger
+	quoteService services_interfaces.QuoteService
+	interval     time.Duration
+}
+
+// Start runs the worker until ctx is cancelled
+func (w *QuoteExpiryWorker) Start(ctx context.Context) {
+	ticker := time.NewTicker(w.interval)
+	defer ticker.Stop()
+
+	for {
+		w.run(ctx)
+
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+		}
+	}
+}
+
+func (w *QuoteExpiryWorker) run(ctx context.Context) {
+	expired, err := w.quoteService.ExpireQuotes(ctx, time.Now().UTC())
+	if err != nil {
+		w.logger.Error().Err(err).Msg("failed to expire quotes")
+		return
+	}
+
+	if expired > 0 {
+		w.logger.Info().Int64("expired", expired).Msg("expired lapsed quotes")
+	}
+}
+
+func NewQuoteExpiryWorker(
+	logger *zerolog.Logger,
+	quoteService services_interfaces.QuoteService,
+) *QuoteExpiryWorker {
+	interval, err := time.ParseDuration(os.Getenv("QUOTE_EXPIRY_INTERVAL"))
+	if err != nil || interval <= 0 {
+		interval = defaultQuoteExpiryInterval
+	}
+
+	return &QuoteExpiryWorker{
+		logger:       logger,
+		quoteService: quoteService,
+		interval:     interval,
+	}
+}
@@ -0,0 +1,66 @@
+package workers
+
+import (
+	"context"
+	"os"
+	"time"
+
+	services_interfaces "github.com/Adebayobenjamin/numerisbook/pkg/services/interfaces"
+	"github.com/rs/zerolog"
+)
+
+// defaultRecurringInvoiceInterval is how often due profiles are checked when
+// RECURRING_INVOICE_INTERVAL is not set
+const defaultRecurringInvoiceInterval = time.Minute
+
+// RecurringInvoiceWorker periodically generates the invoices of recurring
+// profiles that have fallen due
+type RecurringInvoiceWorker struct {
+	logger                  *zerolog.Logger
+	recurringInvoiceService services_interfaces.RecurringInvoiceService
+	interval                time.Duration
+}
+
+// Start runs the worker until ctx is cancelled
+func (w *RecurringInvoiceWorker) Start(ctx context.Context) {
+	ticker := time.NewTicker(w.interval)
+	defer ticker.Stop()
+
+	for {
+		w.run(ctx)
+
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+		}
+	}
+}
+
+func (w *RecurringInvoiceWorker) run(ctx context.Context) {
+	generated, err := w.recurringInvoiceService.GenerateDueInvoices(ctx, time.Now().UTC())
+	if err != nil {
+		w.logger.Error().Err(err).Msg("failed to generate recurring invoices")
+		return
+	}
+
+	if generated > 0 {
+		w.logger.Info().Int("generated", generated).Msg("generated recurring invoices")
+	}
+}
+
+func NewRecurringInvoiceWorker(
+	logger *zerolog.Logger,
+	recurringInvoiceService services_interfaces.RecurringInvoiceService,
+) *RecurringInvoiceWorker {
+	interval, err := time.ParseDuration(os.Getenv("RECURRING_INVOICE_INTERVAL"))
+	if err != nil || interval <= 0 {
+		interval = defaultRecurringInvoiceInterval
+	}
+
+	return &RecurringInvoiceWorker{
+		logger:                  logger,
+		recurringInvoiceService: recurringInvoiceService,
+		interval:                interval,
+	}
+}
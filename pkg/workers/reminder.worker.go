@@ -0,0 +1,81 @@
+package workers
+
+import (
+	"context"
+	"os"
+	"time"
+
+	services_interfaces "github.com/Adebayobenjamin/numerisbook/pkg/services/interfaces"
+	"github.com/rs/zerolog"
+)
+
+// defaultReminderDispatchInterval is how often due reminders are checked when
+// REMINDER_DISPATCH_INTERVAL is not set
+const defaultReminderDispatchInterval = time.Minute
+
+// ReminderWorker periodically moves invoices past their due date to overdue
+// and sends the invoice reminders that have fallen due
+type ReminderWorker struct {
+	logger          *zerolog.Logger
+	invoiceService  services_interfaces.InvoiceService
+	reminderService services_interfaces.RemiderService
+	interval        time.Duration
+}
+
+// Start runs the worker until ctx is cancelled
+func (w *ReminderWorker) Start(ctx context.Context) {
+	ticker := time.NewTicker(w.interval)
+	defer ticker.Stop()
+
+	for {
+		w.run(ctx)
+
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+		}
+	}
+}
+
+func (w *ReminderWorker) run(ctx context.Context) {
+	now := time.Now().UTC()
+
+	// invoices are moved to overdue first so reminders going out in the same
+	// run already see the status they are reminding about
+	overdue, err := w.invoiceService.MarkOverdueInvoices(ctx, now)
+	if err != nil {
+		w.logger.Error().Err(err).Msg("failed to mark overdue invoices")
+	}
+	if overdue > 0 {
+		w.logger.Info().Int("overdue", overdue).Msg("marked invoices overdue")
+	}
+
+	sent, err := w.reminderService.DispatchDueReminders(ctx, now)
+	if err != nil {
+		w.logger.Error().Err(err).Msg("failed to dispatch invoice reminders")
+		return
+	}
+
+	if sent > 0 {
+		w.logger.Info().Int("sent", sent).Msg("sent invoice reminders")
+	}
+}
+
+func NewReminderWorker(
+	logger *zerolog.Logger,
+	invoiceService services_interfaces.InvoiceService,
+	reminderService services_interfaces.RemiderService,
+) *ReminderWorker {
+	interval, err := time.ParseDuration(os.Getenv("REMINDER_DISPATCH_INTERVAL"))
+	if err != nil || interval <= 0 {
+		interval = defaultReminderDispatchInterval
+	}
+
+	return &ReminderWorker{
+		logger:          logger,
+		invoiceService:  invoiceService,
+		reminderService: reminderService,
+		interval:        interval,
+	}
+}